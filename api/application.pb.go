@@ -44,7 +44,7 @@ func (x IntegrationKind) String() string {
 	return proto.EnumName(IntegrationKind_name, int32(x))
 }
 func (IntegrationKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{0}
+	return fileDescriptor_application_80dec113db365134, []int{0}
 }
 
 type InfluxDBPrecision int32
@@ -79,7 +79,7 @@ func (x InfluxDBPrecision) String() string {
 	return proto.EnumName(InfluxDBPrecision_name, int32(x))
 }
 func (InfluxDBPrecision) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{1}
+	return fileDescriptor_application_80dec113db365134, []int{1}
 }
 
 type CreateApplicationRequest struct {
@@ -96,17 +96,24 @@ type CreateApplicationRequest struct {
 	// Payload encoder script.
 	PayloadEncoderScript string `protobuf:"bytes,17,opt,name=payloadEncoderScript" json:"payloadEncoderScript,omitempty"`
 	// Payload decoder script.
-	PayloadDecoderScript string   `protobuf:"bytes,18,opt,name=payloadDecoderScript" json:"payloadDecoderScript,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PayloadDecoderScript string `protobuf:"bytes,18,opt,name=payloadDecoderScript" json:"payloadDecoderScript,omitempty"`
+	// ID of the organization codec (0 = use the payload codec configured
+	// above). The codec of the device-profile takes precedence.
+	CodecID int64 `protobuf:"varint,19,opt,name=codecID" json:"codecID,omitempty"`
+	// Version of the codec to use (0 = always use the latest version).
+	CodecVersion int64 `protobuf:"varint,20,opt,name=codecVersion" json:"codecVersion,omitempty"`
+	// Metadata of the fields of the object decoded by the payload codec.
+	PayloadFieldsMetadata []*CodecFieldMetadata `protobuf:"bytes,21,rep,name=payloadFieldsMetadata" json:"payloadFieldsMetadata,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}              `json:"-"`
+	XXX_unrecognized      []byte                `json:"-"`
+	XXX_sizecache         int32                 `json:"-"`
 }
 
 func (m *CreateApplicationRequest) Reset()         { *m = CreateApplicationRequest{} }
 func (m *CreateApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateApplicationRequest) ProtoMessage()    {}
 func (*CreateApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{0}
+	return fileDescriptor_application_80dec113db365134, []int{0}
 }
 func (m *CreateApplicationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateApplicationRequest.Unmarshal(m, b)
@@ -175,6 +182,27 @@ func (m *CreateApplicationRequest) GetPayloadDecoderScript() string {
 	return ""
 }
 
+func (m *CreateApplicationRequest) GetCodecID() int64 {
+	if m != nil {
+		return m.CodecID
+	}
+	return 0
+}
+
+func (m *CreateApplicationRequest) GetCodecVersion() int64 {
+	if m != nil {
+		return m.CodecVersion
+	}
+	return 0
+}
+
+func (m *CreateApplicationRequest) GetPayloadFieldsMetadata() []*CodecFieldMetadata {
+	if m != nil {
+		return m.PayloadFieldsMetadata
+	}
+	return nil
+}
+
 type CreateApplicationResponse struct {
 	// ID of the application that was created.
 	Id                   int64    `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
@@ -187,7 +215,7 @@ func (m *CreateApplicationResponse) Reset()         { *m = CreateApplicationResp
 func (m *CreateApplicationResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateApplicationResponse) ProtoMessage()    {}
 func (*CreateApplicationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{1}
+	return fileDescriptor_application_80dec113db365134, []int{1}
 }
 func (m *CreateApplicationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateApplicationResponse.Unmarshal(m, b)
@@ -226,7 +254,7 @@ func (m *GetApplicationRequest) Reset()         { *m = GetApplicationRequest{} }
 func (m *GetApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetApplicationRequest) ProtoMessage()    {}
 func (*GetApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{2}
+	return fileDescriptor_application_80dec113db365134, []int{2}
 }
 func (m *GetApplicationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetApplicationRequest.Unmarshal(m, b)
@@ -269,17 +297,27 @@ type GetApplicationResponse struct {
 	// Payload encoder script.
 	PayloadEncoderScript string `protobuf:"bytes,17,opt,name=payloadEncoderScript" json:"payloadEncoderScript,omitempty"`
 	// Payload decoder script.
-	PayloadDecoderScript string   `protobuf:"bytes,18,opt,name=payloadDecoderScript" json:"payloadDecoderScript,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PayloadDecoderScript string `protobuf:"bytes,18,opt,name=payloadDecoderScript" json:"payloadDecoderScript,omitempty"`
+	// ID of the organization codec (0 = use the payload codec configured
+	// above). The codec of the device-profile takes precedence.
+	CodecID int64 `protobuf:"varint,19,opt,name=codecID" json:"codecID,omitempty"`
+	// Version of the codec to use (0 = always use the latest version).
+	CodecVersion int64 `protobuf:"varint,20,opt,name=codecVersion" json:"codecVersion,omitempty"`
+	// Latest version of the codec. When this is greater than codecVersion,
+	// the application is pinned to an older version of the codec.
+	CodecLatestVersion int64 `protobuf:"varint,21,opt,name=codecLatestVersion" json:"codecLatestVersion,omitempty"`
+	// Metadata of the fields of the object decoded by the payload codec.
+	PayloadFieldsMetadata []*CodecFieldMetadata `protobuf:"bytes,22,rep,name=payloadFieldsMetadata" json:"payloadFieldsMetadata,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}              `json:"-"`
+	XXX_unrecognized      []byte                `json:"-"`
+	XXX_sizecache         int32                 `json:"-"`
 }
 
 func (m *GetApplicationResponse) Reset()         { *m = GetApplicationResponse{} }
 func (m *GetApplicationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetApplicationResponse) ProtoMessage()    {}
 func (*GetApplicationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{3}
+	return fileDescriptor_application_80dec113db365134, []int{3}
 }
 func (m *GetApplicationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetApplicationResponse.Unmarshal(m, b)
@@ -355,6 +393,34 @@ func (m *GetApplicationResponse) GetPayloadDecoderScript() string {
 	return ""
 }
 
+func (m *GetApplicationResponse) GetCodecID() int64 {
+	if m != nil {
+		return m.CodecID
+	}
+	return 0
+}
+
+func (m *GetApplicationResponse) GetCodecVersion() int64 {
+	if m != nil {
+		return m.CodecVersion
+	}
+	return 0
+}
+
+func (m *GetApplicationResponse) GetCodecLatestVersion() int64 {
+	if m != nil {
+		return m.CodecLatestVersion
+	}
+	return 0
+}
+
+func (m *GetApplicationResponse) GetPayloadFieldsMetadata() []*CodecFieldMetadata {
+	if m != nil {
+		return m.PayloadFieldsMetadata
+	}
+	return nil
+}
+
 type UpdateApplicationRequest struct {
 	// ID of the application to update.
 	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
@@ -369,17 +435,24 @@ type UpdateApplicationRequest struct {
 	// Payload encoder script.
 	PayloadEncoderScript string `protobuf:"bytes,17,opt,name=payloadEncoderScript" json:"payloadEncoderScript,omitempty"`
 	// Payload decoder script.
-	PayloadDecoderScript string   `protobuf:"bytes,18,opt,name=payloadDecoderScript" json:"payloadDecoderScript,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PayloadDecoderScript string `protobuf:"bytes,18,opt,name=payloadDecoderScript" json:"payloadDecoderScript,omitempty"`
+	// ID of the organization codec (0 = use the payload codec configured
+	// above). The codec of the device-profile takes precedence.
+	CodecID int64 `protobuf:"varint,19,opt,name=codecID" json:"codecID,omitempty"`
+	// Version of the codec to use (0 = always use the latest version).
+	CodecVersion int64 `protobuf:"varint,20,opt,name=codecVersion" json:"codecVersion,omitempty"`
+	// Metadata of the fields of the object decoded by the payload codec.
+	PayloadFieldsMetadata []*CodecFieldMetadata `protobuf:"bytes,21,rep,name=payloadFieldsMetadata" json:"payloadFieldsMetadata,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}              `json:"-"`
+	XXX_unrecognized      []byte                `json:"-"`
+	XXX_sizecache         int32                 `json:"-"`
 }
 
 func (m *UpdateApplicationRequest) Reset()         { *m = UpdateApplicationRequest{} }
 func (m *UpdateApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateApplicationRequest) ProtoMessage()    {}
 func (*UpdateApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{4}
+	return fileDescriptor_application_80dec113db365134, []int{4}
 }
 func (m *UpdateApplicationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateApplicationRequest.Unmarshal(m, b)
@@ -448,6 +521,27 @@ func (m *UpdateApplicationRequest) GetPayloadDecoderScript() string {
 	return ""
 }
 
+func (m *UpdateApplicationRequest) GetCodecID() int64 {
+	if m != nil {
+		return m.CodecID
+	}
+	return 0
+}
+
+func (m *UpdateApplicationRequest) GetCodecVersion() int64 {
+	if m != nil {
+		return m.CodecVersion
+	}
+	return 0
+}
+
+func (m *UpdateApplicationRequest) GetPayloadFieldsMetadata() []*CodecFieldMetadata {
+	if m != nil {
+		return m.PayloadFieldsMetadata
+	}
+	return nil
+}
+
 type UpdateApplicationResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -458,7 +552,7 @@ func (m *UpdateApplicationResponse) Reset()         { *m = UpdateApplicationResp
 func (m *UpdateApplicationResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateApplicationResponse) ProtoMessage()    {}
 func (*UpdateApplicationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{5}
+	return fileDescriptor_application_80dec113db365134, []int{5}
 }
 func (m *UpdateApplicationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateApplicationResponse.Unmarshal(m, b)
@@ -490,7 +584,7 @@ func (m *DeleteApplicationRequest) Reset()         { *m = DeleteApplicationReque
 func (m *DeleteApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteApplicationRequest) ProtoMessage()    {}
 func (*DeleteApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{6}
+	return fileDescriptor_application_80dec113db365134, []int{6}
 }
 func (m *DeleteApplicationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteApplicationRequest.Unmarshal(m, b)
@@ -527,7 +621,7 @@ func (m *DeleteApplicationResponse) Reset()         { *m = DeleteApplicationResp
 func (m *DeleteApplicationResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteApplicationResponse) ProtoMessage()    {}
 func (*DeleteApplicationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{7}
+	return fileDescriptor_application_80dec113db365134, []int{7}
 }
 func (m *DeleteApplicationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteApplicationResponse.Unmarshal(m, b)
@@ -565,7 +659,7 @@ func (m *ListApplicationRequest) Reset()         { *m = ListApplicationRequest{}
 func (m *ListApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*ListApplicationRequest) ProtoMessage()    {}
 func (*ListApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{8}
+	return fileDescriptor_application_80dec113db365134, []int{8}
 }
 func (m *ListApplicationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListApplicationRequest.Unmarshal(m, b)
@@ -635,7 +729,7 @@ func (m *ApplicationListItem) Reset()         { *m = ApplicationListItem{} }
 func (m *ApplicationListItem) String() string { return proto.CompactTextString(m) }
 func (*ApplicationListItem) ProtoMessage()    {}
 func (*ApplicationListItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{9}
+	return fileDescriptor_application_80dec113db365134, []int{9}
 }
 func (m *ApplicationListItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApplicationListItem.Unmarshal(m, b)
@@ -711,7 +805,7 @@ func (m *ListApplicationResponse) Reset()         { *m = ListApplicationResponse
 func (m *ListApplicationResponse) String() string { return proto.CompactTextString(m) }
 func (*ListApplicationResponse) ProtoMessage()    {}
 func (*ListApplicationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{10}
+	return fileDescriptor_application_80dec113db365134, []int{10}
 }
 func (m *ListApplicationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListApplicationResponse.Unmarshal(m, b)
@@ -755,7 +849,7 @@ func (m *EmptyResponse) Reset()         { *m = EmptyResponse{} }
 func (m *EmptyResponse) String() string { return proto.CompactTextString(m) }
 func (*EmptyResponse) ProtoMessage()    {}
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{11}
+	return fileDescriptor_application_80dec113db365134, []int{11}
 }
 func (m *EmptyResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EmptyResponse.Unmarshal(m, b)
@@ -789,7 +883,7 @@ func (m *HTTPIntegrationHeader) Reset()         { *m = HTTPIntegrationHeader{} }
 func (m *HTTPIntegrationHeader) String() string { return proto.CompactTextString(m) }
 func (*HTTPIntegrationHeader) ProtoMessage()    {}
 func (*HTTPIntegrationHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{12}
+	return fileDescriptor_application_80dec113db365134, []int{12}
 }
 func (m *HTTPIntegrationHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HTTPIntegrationHeader.Unmarshal(m, b)
@@ -845,7 +939,7 @@ func (m *HTTPIntegration) Reset()         { *m = HTTPIntegration{} }
 func (m *HTTPIntegration) String() string { return proto.CompactTextString(m) }
 func (*HTTPIntegration) ProtoMessage()    {}
 func (*HTTPIntegration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{13}
+	return fileDescriptor_application_80dec113db365134, []int{13}
 }
 func (m *HTTPIntegration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HTTPIntegration.Unmarshal(m, b)
@@ -919,7 +1013,7 @@ func (m *GetHTTPIntegrationRequest) Reset()         { *m = GetHTTPIntegrationReq
 func (m *GetHTTPIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHTTPIntegrationRequest) ProtoMessage()    {}
 func (*GetHTTPIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{14}
+	return fileDescriptor_application_80dec113db365134, []int{14}
 }
 func (m *GetHTTPIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHTTPIntegrationRequest.Unmarshal(m, b)
@@ -958,7 +1052,7 @@ func (m *DeleteHTTPIntegrationRequest) Reset()         { *m = DeleteHTTPIntegrat
 func (m *DeleteHTTPIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteHTTPIntegrationRequest) ProtoMessage()    {}
 func (*DeleteHTTPIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{15}
+	return fileDescriptor_application_80dec113db365134, []int{15}
 }
 func (m *DeleteHTTPIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteHTTPIntegrationRequest.Unmarshal(m, b)
@@ -997,7 +1091,7 @@ func (m *ListIntegrationRequest) Reset()         { *m = ListIntegrationRequest{}
 func (m *ListIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*ListIntegrationRequest) ProtoMessage()    {}
 func (*ListIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{16}
+	return fileDescriptor_application_80dec113db365134, []int{16}
 }
 func (m *ListIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListIntegrationRequest.Unmarshal(m, b)
@@ -1036,7 +1130,7 @@ func (m *ListIntegrationResponse) Reset()         { *m = ListIntegrationResponse
 func (m *ListIntegrationResponse) String() string { return proto.CompactTextString(m) }
 func (*ListIntegrationResponse) ProtoMessage()    {}
 func (*ListIntegrationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{17}
+	return fileDescriptor_application_80dec113db365134, []int{17}
 }
 func (m *ListIntegrationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListIntegrationResponse.Unmarshal(m, b)
@@ -1085,7 +1179,7 @@ func (m *InfluxDBIntegrationConfiguration) Reset()         { *m = InfluxDBIntegr
 func (m *InfluxDBIntegrationConfiguration) String() string { return proto.CompactTextString(m) }
 func (*InfluxDBIntegrationConfiguration) ProtoMessage()    {}
 func (*InfluxDBIntegrationConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{18}
+	return fileDescriptor_application_80dec113db365134, []int{18}
 }
 func (m *InfluxDBIntegrationConfiguration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InfluxDBIntegrationConfiguration.Unmarshal(m, b)
@@ -1161,7 +1255,7 @@ func (m *CreateInfluxDBIntegrationRequest) Reset()         { *m = CreateInfluxDB
 func (m *CreateInfluxDBIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateInfluxDBIntegrationRequest) ProtoMessage()    {}
 func (*CreateInfluxDBIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{19}
+	return fileDescriptor_application_80dec113db365134, []int{19}
 }
 func (m *CreateInfluxDBIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateInfluxDBIntegrationRequest.Unmarshal(m, b)
@@ -1207,7 +1301,7 @@ func (m *GetInfluxDBIntegrationRequest) Reset()         { *m = GetInfluxDBIntegr
 func (m *GetInfluxDBIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetInfluxDBIntegrationRequest) ProtoMessage()    {}
 func (*GetInfluxDBIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{20}
+	return fileDescriptor_application_80dec113db365134, []int{20}
 }
 func (m *GetInfluxDBIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetInfluxDBIntegrationRequest.Unmarshal(m, b)
@@ -1246,7 +1340,7 @@ func (m *GetInfluxDBIntegrationResponse) Reset()         { *m = GetInfluxDBInteg
 func (m *GetInfluxDBIntegrationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetInfluxDBIntegrationResponse) ProtoMessage()    {}
 func (*GetInfluxDBIntegrationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{21}
+	return fileDescriptor_application_80dec113db365134, []int{21}
 }
 func (m *GetInfluxDBIntegrationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetInfluxDBIntegrationResponse.Unmarshal(m, b)
@@ -1287,7 +1381,7 @@ func (m *UpdateInfluxDBIntegrationRequest) Reset()         { *m = UpdateInfluxDB
 func (m *UpdateInfluxDBIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateInfluxDBIntegrationRequest) ProtoMessage()    {}
 func (*UpdateInfluxDBIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{22}
+	return fileDescriptor_application_80dec113db365134, []int{22}
 }
 func (m *UpdateInfluxDBIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateInfluxDBIntegrationRequest.Unmarshal(m, b)
@@ -1333,7 +1427,7 @@ func (m *DeleteInfluxDBIntegrationRequest) Reset()         { *m = DeleteInfluxDB
 func (m *DeleteInfluxDBIntegrationRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteInfluxDBIntegrationRequest) ProtoMessage()    {}
 func (*DeleteInfluxDBIntegrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_application_20e211c2d4112780, []int{23}
+	return fileDescriptor_application_80dec113db365134, []int{23}
 }
 func (m *DeleteInfluxDBIntegrationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteInfluxDBIntegrationRequest.Unmarshal(m, b)
@@ -1919,87 +2013,92 @@ var _Application_serviceDesc = grpc.ServiceDesc{
 	Metadata: "application.proto",
 }
 
-func init() { proto.RegisterFile("application.proto", fileDescriptor_application_20e211c2d4112780) }
-
-var fileDescriptor_application_20e211c2d4112780 = []byte{
-	// 1261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xef, 0x9d, 0x13, 0xd3, 0x4c, 0x1a, 0xe7, 0xb2, 0x49, 0xcc, 0xf5, 0xea, 0x46, 0xee, 0x55,
-	0x01, 0xe3, 0x8a, 0x24, 0x72, 0xfb, 0x80, 0x2a, 0xa1, 0x42, 0xe3, 0x34, 0xb1, 0x9a, 0x46, 0xd1,
-	0xa5, 0x91, 0x78, 0x40, 0x44, 0x17, 0xdf, 0xda, 0xd9, 0xe6, 0x72, 0x7b, 0xdc, 0x9d, 0x03, 0x29,
-	0x20, 0x21, 0x1e, 0x78, 0xe1, 0x11, 0x09, 0x55, 0xe2, 0x91, 0x2f, 0xc0, 0x27, 0xe0, 0x4b, 0xf0,
-	0x01, 0x78, 0xe1, 0x03, 0xc0, 0x37, 0x40, 0xfb, 0xc7, 0xce, 0xf9, 0xbc, 0x97, 0xba, 0x0d, 0x0f,
-	0x45, 0x3c, 0xc5, 0xbb, 0x33, 0x3b, 0xfb, 0x9b, 0xdf, 0xcc, 0xec, 0xcc, 0x05, 0xe6, 0xdc, 0x30,
-	0xf4, 0x49, 0xdb, 0x4d, 0x08, 0x0d, 0x56, 0xc2, 0x88, 0x26, 0x14, 0x15, 0xdc, 0x90, 0x58, 0x95,
-	0x2e, 0xa5, 0x5d, 0x1f, 0xaf, 0xba, 0x21, 0x59, 0x75, 0x83, 0x80, 0x26, 0x5c, 0x23, 0x16, 0x2a,
-	0xf6, 0xaf, 0x3a, 0x98, 0xeb, 0x11, 0x76, 0x13, 0xfc, 0xf1, 0xf9, 0x71, 0x07, 0x7f, 0xde, 0xc3,
-	0x71, 0x82, 0x10, 0x4c, 0x04, 0xee, 0x09, 0x36, 0xb5, 0xaa, 0x56, 0x9b, 0x72, 0xf8, 0x6f, 0x54,
-	0x85, 0x69, 0x0f, 0xc7, 0xed, 0x88, 0x84, 0x4c, 0xd3, 0xd4, 0xb9, 0x28, 0xbd, 0x85, 0xde, 0x81,
-	0x12, 0x8d, 0xba, 0x6e, 0x40, 0x9e, 0x73, 0x63, 0xad, 0xa6, 0x59, 0xaa, 0x6a, 0xb5, 0x82, 0x93,
-	0xd9, 0x45, 0x75, 0x30, 0x62, 0x1c, 0x9d, 0x92, 0x36, 0xde, 0x8d, 0x68, 0x87, 0xf8, 0xb8, 0xd5,
-	0x34, 0x67, 0xb9, 0xb9, 0x91, 0x7d, 0x64, 0xc3, 0xb5, 0xd0, 0x3d, 0xf3, 0xa9, 0xeb, 0xad, 0x53,
-	0x0f, 0xb7, 0x4d, 0x83, 0xeb, 0x0d, 0xed, 0xa1, 0x06, 0x2c, 0xc8, 0xf5, 0x46, 0xd0, 0xa6, 0x1e,
-	0x8e, 0xf6, 0x38, 0x24, 0x73, 0x8e, 0xeb, 0x2a, 0x65, 0xa9, 0x33, 0x4d, 0x9c, 0x3e, 0x83, 0x86,
-	0xce, 0x0c, 0xc9, 0xec, 0x3b, 0x70, 0x5d, 0xc1, 0x58, 0x1c, 0xd2, 0x20, 0xc6, 0xa8, 0x04, 0x3a,
-	0xf1, 0x38, 0x61, 0x05, 0x47, 0x27, 0x9e, 0xfd, 0x2e, 0x2c, 0x6e, 0xe2, 0x44, 0xc1, 0x6d, 0x56,
-	0xf1, 0x37, 0x1d, 0xca, 0x59, 0x4d, 0xb5, 0xcd, 0x41, 0x58, 0xf4, 0xfc, 0xb0, 0x14, 0xfe, 0x7f,
-	0x61, 0x79, 0xa1, 0x83, 0xb9, 0x1f, 0x7a, 0xea, 0x4c, 0xfe, 0x77, 0x28, 0xfc, 0xaf, 0x52, 0x73,
-	0x03, 0xae, 0x2b, 0x98, 0x11, 0xd9, 0x65, 0xd7, 0xc1, 0x6c, 0x62, 0x1f, 0x8f, 0x43, 0x1b, 0x33,
-	0xa4, 0xd0, 0x95, 0x86, 0xbe, 0xd7, 0xa0, 0xbc, 0x4d, 0x62, 0x55, 0xb2, 0x2f, 0xc0, 0xa4, 0x4f,
-	0x4e, 0x48, 0x22, 0x4d, 0x89, 0x05, 0x2a, 0x43, 0x91, 0x76, 0x3a, 0x31, 0x4e, 0x78, 0x18, 0x0a,
-	0x8e, 0x5c, 0x29, 0x32, 0xb5, 0xa0, 0xcc, 0xd4, 0x32, 0x14, 0x63, 0xec, 0x46, 0xed, 0x23, 0x73,
-	0x82, 0x3b, 0x2f, 0x57, 0xf6, 0x1f, 0x1a, 0xcc, 0xa7, 0x40, 0x30, 0x4c, 0xad, 0x04, 0x9f, 0xbc,
-	0xc1, 0x75, 0xb4, 0x02, 0x68, 0x78, 0x6f, 0x87, 0xe1, 0x12, 0x29, 0xa3, 0x90, 0xd8, 0xc7, 0xf0,
-	0xf6, 0x08, 0xd3, 0xf2, 0xb1, 0x58, 0x02, 0x48, 0x68, 0xe2, 0xfa, 0xeb, 0xb4, 0x17, 0xf4, 0xf9,
-	0x4e, 0xed, 0xa0, 0x35, 0x28, 0x46, 0x38, 0xee, 0xf9, 0x8c, 0xf4, 0x42, 0x6d, 0xba, 0x61, 0xae,
-	0xb8, 0x21, 0x59, 0x51, 0xd0, 0xe5, 0x48, 0x3d, 0x7b, 0x16, 0x66, 0x36, 0x4e, 0xc2, 0xe4, 0x6c,
-	0x10, 0xe8, 0x07, 0xb0, 0xb8, 0xf5, 0xf4, 0xe9, 0x6e, 0x2b, 0x48, 0x70, 0x37, 0xe2, 0x67, 0xb6,
-	0xb0, 0xeb, 0xe1, 0x08, 0x19, 0x50, 0x38, 0xc6, 0x67, 0xb2, 0x5d, 0xb0, 0x9f, 0x2c, 0xf0, 0xa7,
-	0xae, 0xdf, 0xeb, 0x73, 0x2c, 0x16, 0xf6, 0x0f, 0x3a, 0xcc, 0x66, 0x2c, 0x8c, 0x04, 0xe7, 0x1e,
-	0xbc, 0x75, 0xc4, 0xad, 0xc6, 0x12, 0xa8, 0xc5, 0x81, 0x2a, 0x2f, 0x76, 0xfa, 0xaa, 0xa8, 0x02,
-	0x53, 0x9e, 0x9b, 0xb8, 0xfb, 0xe1, 0xbe, 0xb3, 0x2d, 0x83, 0x77, 0xbe, 0x81, 0xd6, 0x60, 0xfe,
-	0x19, 0x25, 0xc1, 0x0e, 0x4d, 0x48, 0x47, 0x7a, 0xcb, 0xf4, 0x44, 0xf6, 0xa8, 0x44, 0x2c, 0x30,
-	0x6e, 0xfb, 0x38, 0x7b, 0x60, 0x52, 0x04, 0x66, 0x54, 0xc2, 0xaa, 0x13, 0x47, 0x11, 0x8d, 0xb2,
-	0x27, 0x8a, 0xa2, 0x3a, 0x55, 0x32, 0xd6, 0x4f, 0x36, 0x71, 0x92, 0x71, 0x2c, 0xaf, 0x02, 0x57,
-	0xa0, 0x22, 0x2a, 0x70, 0x4c, 0xfd, 0x9a, 0xa8, 0xc9, 0x31, 0x34, 0x37, 0x44, 0x4e, 0x0d, 0x69,
-	0xca, 0x9c, 0xaa, 0xc3, 0xe4, 0x31, 0x09, 0xbc, 0xd8, 0xd4, 0xaa, 0x85, 0x5a, 0xa9, 0xb1, 0xc0,
-	0x23, 0x91, 0x52, 0x7c, 0x4c, 0x02, 0xcf, 0x11, 0x2a, 0xf6, 0x5f, 0x1a, 0x54, 0x5b, 0x41, 0xc7,
-	0xef, 0x7d, 0xd9, 0x7c, 0x98, 0x52, 0x59, 0xa7, 0x41, 0x87, 0x74, 0x7b, 0x32, 0xd8, 0x16, 0x5c,
-	0xc5, 0x81, 0x17, 0x52, 0x22, 0x53, 0x74, 0xca, 0x19, 0xac, 0x19, 0x2e, 0xef, 0x50, 0xe6, 0x8b,
-	0xee, 0x1d, 0x32, 0xdd, 0x5e, 0x8c, 0x23, 0x5e, 0xa9, 0x22, 0xa2, 0x83, 0x35, 0x93, 0x85, 0x6e,
-	0x1c, 0x7f, 0x41, 0x23, 0x4f, 0x46, 0x71, 0xb0, 0x46, 0x0d, 0x58, 0x8c, 0x70, 0x82, 0x03, 0x76,
-	0xe1, 0x41, 0x48, 0x7d, 0xd2, 0x3e, 0x3b, 0xe0, 0x46, 0x44, 0xf4, 0xe6, 0x07, 0xc2, 0x5d, 0x2e,
-	0x63, 0x75, 0x85, 0xee, 0xc1, 0x54, 0x18, 0xe1, 0x36, 0x89, 0x59, 0xed, 0xb3, 0x98, 0x95, 0x1a,
-	0x65, 0xe9, 0xac, 0xf0, 0x68, 0xb7, 0x2f, 0x75, 0xce, 0x15, 0xed, 0x9f, 0x34, 0xa8, 0x8a, 0x89,
-	0x40, 0xe1, 0x78, 0x9f, 0xee, 0x65, 0x28, 0xa5, 0x06, 0xb4, 0x83, 0x01, 0xf5, 0x33, 0xa9, 0xdd,
-	0x96, 0x87, 0x1e, 0xc3, 0x4c, 0x3b, 0x4d, 0x15, 0x27, 0x62, 0xba, 0xb1, 0x3c, 0x84, 0x22, 0x8f,
-	0x57, 0x67, 0xf8, 0xac, 0xfd, 0x08, 0x6e, 0x6e, 0xe2, 0xe4, 0x95, 0x40, 0xe9, 0x0a, 0x50, 0xf6,
-	0x09, 0x2c, 0xe5, 0xd9, 0x91, 0x19, 0x32, 0x02, 0x5b, 0xbb, 0x04, 0x6c, 0xc6, 0xa7, 0xe8, 0x57,
-	0x6f, 0x18, 0x9f, 0x2d, 0xa8, 0x8a, 0xe2, 0xbb, 0x34, 0xae, 0xfa, 0x7b, 0x30, 0x9b, 0x29, 0x20,
-	0x74, 0x15, 0x26, 0x58, 0x51, 0x1b, 0x57, 0xd0, 0x35, 0xb8, 0xda, 0xda, 0x79, 0xb4, 0xbd, 0xff,
-	0x49, 0xf3, 0xa1, 0xa1, 0xd5, 0x1f, 0xc0, 0xdc, 0x48, 0xfa, 0xa1, 0x22, 0xe8, 0x3b, 0x7b, 0xc6,
-	0x15, 0x34, 0x09, 0xda, 0xbe, 0xa1, 0xb1, 0xe5, 0x93, 0x3d, 0x43, 0x67, 0xcb, 0x3d, 0xa3, 0xc0,
-	0xfe, 0x3c, 0x31, 0x26, 0xd8, 0x9f, 0x2d, 0x63, 0xb2, 0xf1, 0xf7, 0x0c, 0x4c, 0xa7, 0x1e, 0x78,
-	0x84, 0xa1, 0x28, 0xd2, 0x15, 0xdd, 0xe4, 0x34, 0xe4, 0xcd, 0xff, 0xd6, 0x52, 0x9e, 0x58, 0x36,
-	0x82, 0xca, 0x77, 0xbf, 0xff, 0xf9, 0xa3, 0x5e, 0xb6, 0xe7, 0xc4, 0xc7, 0xc5, 0xb9, 0x46, 0x7c,
-	0x5f, 0xab, 0xa3, 0xcf, 0xa0, 0xb0, 0x89, 0x13, 0x24, 0xde, 0x6d, 0xe5, 0x10, 0x6c, 0xdd, 0x50,
-	0xca, 0xa4, 0xf5, 0x25, 0x6e, 0xdd, 0x44, 0xe5, 0x11, 0xeb, 0xab, 0x5f, 0x11, 0xef, 0x1b, 0xf4,
-	0x0c, 0x8a, 0x22, 0x4b, 0xa4, 0x1b, 0x79, 0xc3, 0x9f, 0x74, 0x23, 0x7f, 0x02, 0xba, 0xc5, 0x2f,
-	0xba, 0x61, 0xe5, 0x5c, 0xc4, 0x7c, 0xe9, 0x42, 0x51, 0x44, 0x5e, 0xde, 0x95, 0x37, 0x31, 0xc9,
-	0xbb, 0xf2, 0x87, 0x24, 0xe9, 0x54, 0x3d, 0xcf, 0xa9, 0x4f, 0x61, 0x82, 0xbd, 0xc2, 0x48, 0x30,
-	0xa3, 0x1e, 0xa7, 0xac, 0x8a, 0x5a, 0x28, 0xaf, 0xb8, 0xce, 0xaf, 0x98, 0x47, 0xa3, 0x51, 0x41,
-	0xa7, 0xb0, 0x28, 0xa2, 0x99, 0xed, 0xbe, 0x0b, 0xaa, 0xe6, 0x6a, 0x21, 0xbe, 0x3b, 0xdc, 0xfc,
-	0xef, 0x72, 0xeb, 0xef, 0xdb, 0x35, 0xb5, 0x03, 0xab, 0xe4, 0xfc, 0x7c, 0xbc, 0x7a, 0x94, 0x24,
-	0x21, 0xa3, 0xef, 0x6b, 0x40, 0xa3, 0x2d, 0x0e, 0x2d, 0xf5, 0xa3, 0xaf, 0xee, 0x65, 0x96, 0x12,
-	0x94, 0xbd, 0xc6, 0x01, 0xd4, 0xd1, 0xd8, 0x00, 0x98, 0xd7, 0x22, 0xf8, 0x97, 0xf6, 0xda, 0x7a,
-	0x25, 0xaf, 0xbf, 0xd5, 0x60, 0x51, 0xd9, 0xac, 0xd1, 0xad, 0x54, 0x96, 0xe4, 0x38, 0xaf, 0x42,
-	0x21, 0x5d, 0xaf, 0x8f, 0xef, 0xfa, 0xcf, 0x5a, 0xff, 0x63, 0x55, 0xf1, 0x64, 0xa1, 0xe5, 0x54,
-	0x7d, 0xe7, 0x3f, 0x69, 0x4a, 0x28, 0xeb, 0x1c, 0xca, 0x87, 0xf6, 0x07, 0x0a, 0x28, 0xc3, 0xef,
-	0x5f, 0x06, 0x16, 0xe1, 0xf7, 0x78, 0x87, 0x8c, 0xa0, 0x5f, 0x34, 0xfe, 0xcd, 0xab, 0x82, 0x66,
-	0xf7, 0x73, 0xe3, 0x02, 0x5c, 0xb7, 0x2f, 0xd4, 0x91, 0x40, 0x3f, 0xe2, 0x40, 0xef, 0xa3, 0xd7,
-	0x06, 0xca, 0x39, 0xcc, 0x6d, 0x47, 0x92, 0xc3, 0x97, 0xb5, 0xab, 0x8b, 0x38, 0xb4, 0x2e, 0xc5,
-	0xe1, 0x0b, 0xad, 0xff, 0x4d, 0x96, 0x8f, 0xee, 0x65, 0x4d, 0x4b, 0x89, 0x4e, 0x12, 0x57, 0x7f,
-	0x7d, 0xe2, 0x9e, 0x83, 0x91, 0x99, 0x28, 0xe3, 0xd4, 0xbb, 0xa6, 0x80, 0x51, 0x51, 0x0b, 0x25,
-	0xa0, 0x3b, 0x1c, 0xd0, 0x32, 0xba, 0x3d, 0x46, 0xf6, 0x1f, 0x16, 0xf9, 0xbf, 0xb7, 0xee, 0xfe,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0x39, 0x82, 0xdd, 0xee, 0x16, 0x13, 0x00, 0x00,
+func init() { proto.RegisterFile("application.proto", fileDescriptor_application_80dec113db365134) }
+
+var fileDescriptor_application_80dec113db365134 = []byte{
+	// 1344 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xef, 0x7a, 0x13, 0xb7, 0x79, 0x69, 0x92, 0xcd, 0x24, 0x76, 0xb7, 0x5b, 0xd7, 0x72, 0xb7,
+	0x0a, 0x18, 0x57, 0x38, 0x95, 0xdb, 0x03, 0xaa, 0x84, 0x0a, 0x8d, 0xdb, 0xd4, 0x6a, 0x12, 0x45,
+	0x9b, 0x06, 0x71, 0x40, 0x54, 0x1b, 0xef, 0xd8, 0x9d, 0x66, 0xb3, 0xbb, 0xec, 0x8e, 0x0b, 0x29,
+	0x20, 0x21, 0x0e, 0x5c, 0x38, 0x22, 0x21, 0x24, 0x8e, 0x88, 0x8f, 0xc1, 0xa7, 0xe0, 0x03, 0x70,
+	0xe1, 0x03, 0xc0, 0x99, 0x0b, 0x9a, 0x3f, 0xeb, 0xac, 0xed, 0xd9, 0xd6, 0x6d, 0x7a, 0xe8, 0xa1,
+	0x27, 0xef, 0x9b, 0xf7, 0xe6, 0xcd, 0xef, 0xfd, 0x9f, 0x31, 0x2c, 0xbb, 0x51, 0xe4, 0x93, 0xae,
+	0x4b, 0x49, 0x18, 0x34, 0xa3, 0x38, 0xa4, 0x21, 0xd2, 0xdd, 0x88, 0x58, 0x95, 0x7e, 0x18, 0xf6,
+	0x7d, 0xbc, 0xee, 0x46, 0x64, 0xdd, 0x0d, 0x82, 0x90, 0x72, 0x89, 0x44, 0x88, 0x58, 0xf3, 0xdd,
+	0xd0, 0xc3, 0x5d, 0x41, 0xd8, 0x7f, 0xe8, 0x60, 0x6e, 0xc4, 0xd8, 0xa5, 0xf8, 0xe3, 0x13, 0x5d,
+	0x0e, 0xfe, 0x62, 0x80, 0x13, 0x8a, 0x10, 0xcc, 0x04, 0xee, 0x11, 0x36, 0xb5, 0x9a, 0x56, 0x9f,
+	0x73, 0xf8, 0x37, 0xaa, 0xc1, 0xbc, 0x87, 0x93, 0x6e, 0x4c, 0x22, 0x26, 0x69, 0x16, 0x38, 0x2b,
+	0xbb, 0x84, 0xde, 0x81, 0xc5, 0x30, 0xee, 0xbb, 0x01, 0x79, 0xc6, 0x95, 0x75, 0xda, 0xe6, 0x62,
+	0x4d, 0xab, 0xeb, 0xce, 0xd8, 0x2a, 0x6a, 0x80, 0x91, 0xe0, 0xf8, 0x29, 0xe9, 0xe2, 0xdd, 0x38,
+	0xec, 0x11, 0x1f, 0x77, 0xda, 0xe6, 0x12, 0x57, 0x37, 0xb1, 0x8e, 0x6c, 0x38, 0x1f, 0xb9, 0xc7,
+	0x7e, 0xe8, 0x7a, 0x1b, 0x0c, 0xbc, 0x69, 0x70, 0xb9, 0x91, 0x35, 0xd4, 0x82, 0x55, 0x49, 0xdf,
+	0x0d, 0x98, 0x89, 0xf1, 0x1e, 0x87, 0x64, 0x2e, 0x73, 0x59, 0x25, 0x2f, 0xb3, 0xa7, 0x8d, 0xb3,
+	0x7b, 0xd0, 0xc8, 0x9e, 0x11, 0x1e, 0x32, 0xe1, 0x2c, 0x23, 0xbb, 0x9d, 0xb6, 0xb9, 0xc2, 0x0d,
+	0x4b, 0x49, 0x86, 0x92, 0x7f, 0x7e, 0x82, 0xe3, 0x84, 0x39, 0x67, 0x95, 0xb3, 0x47, 0xd6, 0xd0,
+	0x36, 0x94, 0xa4, 0xd6, 0x7b, 0x04, 0xfb, 0x5e, 0xb2, 0x8d, 0xa9, 0xeb, 0xb9, 0xd4, 0x35, 0x4b,
+	0x35, 0xbd, 0x3e, 0xdf, 0xba, 0xd0, 0x74, 0x23, 0xd2, 0xe4, 0x06, 0x71, 0x7e, 0xca, 0x76, 0xd4,
+	0xbb, 0xec, 0x6b, 0x70, 0x51, 0x11, 0xbe, 0x24, 0x0a, 0x83, 0x04, 0xa3, 0x45, 0x28, 0x10, 0x8f,
+	0x47, 0x4f, 0x77, 0x0a, 0xc4, 0xb3, 0xdf, 0x85, 0xd2, 0x26, 0xa6, 0x8a, 0x40, 0x8f, 0x0b, 0xfe,
+	0xa7, 0x43, 0x79, 0x5c, 0x52, 0xad, 0x73, 0x98, 0x23, 0x85, 0xfc, 0x1c, 0xd1, 0xdf, 0xe6, 0xc8,
+	0xcb, 0xe5, 0x48, 0x13, 0x10, 0xa7, 0xb7, 0x5c, 0x8a, 0x13, 0x9a, 0x4a, 0x96, 0xb8, 0xa4, 0x82,
+	0x93, 0x9f, 0x53, 0xe5, 0x57, 0xca, 0xa9, 0xdf, 0x75, 0x30, 0xf7, 0x23, 0x4f, 0xdd, 0x13, 0x5e,
+	0x4f, 0xfc, 0xdf, 0xd6, 0xfe, 0x6b, 0xa8, 0xfd, 0x4b, 0x70, 0x51, 0x11, 0x26, 0x51, 0xa7, 0x76,
+	0x03, 0xcc, 0x36, 0xf6, 0xf1, 0x34, 0x31, 0x64, 0x8a, 0x14, 0xb2, 0x52, 0xd1, 0x0f, 0x1a, 0x94,
+	0xb7, 0x48, 0xa2, 0x6a, 0x1b, 0xab, 0x30, 0xeb, 0x93, 0x23, 0x42, 0xa5, 0x2a, 0x41, 0xa0, 0x32,
+	0x14, 0xc3, 0x5e, 0x2f, 0xc1, 0x94, 0xe7, 0x84, 0xee, 0x48, 0x4a, 0x51, 0xf3, 0xba, 0xb2, 0xe6,
+	0xcb, 0x50, 0x4c, 0xb0, 0x1b, 0x77, 0x1f, 0x9b, 0x33, 0x3c, 0x12, 0x92, 0xb2, 0xff, 0xd2, 0x60,
+	0x25, 0x03, 0x82, 0x61, 0xea, 0x50, 0x7c, 0xf4, 0x06, 0x77, 0xa4, 0x26, 0xa0, 0xd1, 0xb5, 0x1d,
+	0x86, 0x4b, 0xe4, 0xaf, 0x82, 0x63, 0x1f, 0xc2, 0x85, 0x09, 0x4f, 0xcb, 0xb6, 0x5b, 0x05, 0xa0,
+	0x21, 0x75, 0xfd, 0x8d, 0x70, 0x10, 0xa4, 0xfe, 0xce, 0xac, 0xa0, 0xeb, 0x50, 0x8c, 0x71, 0x32,
+	0xf0, 0x99, 0xd3, 0x59, 0x2e, 0x99, 0x3c, 0x97, 0x14, 0xee, 0x72, 0xa4, 0x9c, 0xbd, 0x04, 0x0b,
+	0x77, 0x8f, 0x22, 0x7a, 0x3c, 0x0c, 0xf4, 0x6d, 0x28, 0xdd, 0x7f, 0xf8, 0x70, 0xb7, 0x13, 0x50,
+	0xdc, 0x8f, 0xf9, 0x9e, 0xfb, 0xd8, 0xf5, 0x70, 0x8c, 0x0c, 0xd0, 0x0f, 0xf1, 0xb1, 0xbc, 0x05,
+	0xb0, 0x4f, 0x16, 0xf8, 0xa7, 0xae, 0x3f, 0x48, 0x7d, 0x2c, 0x08, 0xfb, 0xc7, 0x02, 0x2c, 0x8d,
+	0x69, 0x98, 0x08, 0xce, 0x4d, 0x38, 0xfb, 0x98, 0x6b, 0x4d, 0x24, 0x50, 0x8b, 0x03, 0x55, 0x1e,
+	0xec, 0xa4, 0xa2, 0xa8, 0x02, 0x73, 0x2c, 0xe3, 0xf7, 0xa3, 0x7d, 0x67, 0x4b, 0x06, 0xef, 0x64,
+	0x01, 0x5d, 0x87, 0x95, 0x27, 0x21, 0x09, 0x76, 0x42, 0x4a, 0x7a, 0xd2, 0x5a, 0x26, 0x27, 0xb2,
+	0x47, 0xc5, 0x62, 0x81, 0x71, 0xbb, 0x87, 0xe3, 0x1b, 0x66, 0x45, 0x60, 0x26, 0x39, 0xac, 0x55,
+	0xe0, 0x38, 0x0e, 0xe3, 0xf1, 0x1d, 0x45, 0xd1, 0x2a, 0x54, 0x3c, 0x36, 0x99, 0x37, 0x31, 0x1d,
+	0x33, 0x2c, 0xaf, 0x02, 0x9b, 0x50, 0x11, 0x15, 0x38, 0xa5, 0x7c, 0x5d, 0xd4, 0xe4, 0x14, 0x92,
+	0x77, 0xe1, 0xc2, 0x84, 0xa4, 0xcc, 0xa9, 0x06, 0xcc, 0x1e, 0x92, 0xc0, 0x4b, 0x4c, 0xad, 0xa6,
+	0xd7, 0x17, 0x5b, 0xab, 0x3c, 0x12, 0x19, 0xc1, 0x07, 0x24, 0xf0, 0x1c, 0x21, 0x62, 0xff, 0xa3,
+	0x41, 0xad, 0x13, 0xf4, 0xfc, 0xc1, 0x57, 0xed, 0x3b, 0x19, 0x91, 0x8d, 0x30, 0xe8, 0x91, 0xfe,
+	0x40, 0x10, 0xc8, 0x82, 0x73, 0x38, 0xf0, 0xa2, 0x90, 0xc8, 0x14, 0x9d, 0x73, 0x86, 0x34, 0xc3,
+	0xe5, 0x1d, 0xc8, 0x7c, 0x29, 0x78, 0x07, 0x4c, 0x76, 0x90, 0xe0, 0x98, 0x57, 0xaa, 0x88, 0xe8,
+	0x90, 0x66, 0xbc, 0xc8, 0x4d, 0x92, 0x2f, 0xc3, 0xd8, 0x93, 0x51, 0x1c, 0xd2, 0xa8, 0x05, 0xa5,
+	0x18, 0x53, 0x1c, 0xb0, 0x03, 0x1f, 0x45, 0xa1, 0x4f, 0xba, 0xc7, 0x8f, 0xb8, 0x12, 0x11, 0xbd,
+	0x95, 0x21, 0x73, 0x97, 0xf3, 0x58, 0x5d, 0xa1, 0x9b, 0x30, 0x17, 0xc5, 0xb8, 0x4b, 0x78, 0x63,
+	0x66, 0x31, 0x5b, 0x6c, 0x95, 0xa5, 0xb1, 0xc2, 0xa2, 0xdd, 0x94, 0xeb, 0x9c, 0x08, 0xda, 0x3f,
+	0x6b, 0x50, 0x13, 0x77, 0x2b, 0x85, 0xe1, 0xa9, 0xbb, 0xd7, 0x60, 0x31, 0x73, 0x09, 0x7f, 0x34,
+	0x74, 0xfd, 0x42, 0x66, 0xb5, 0xe3, 0xa1, 0x07, 0xb0, 0xd0, 0xcd, 0xba, 0x8a, 0x3b, 0x62, 0xbe,
+	0xb5, 0x36, 0x82, 0x22, 0xcf, 0xaf, 0xce, 0xe8, 0x5e, 0xfb, 0x1e, 0x5c, 0xde, 0xc4, 0xf4, 0xa5,
+	0x40, 0x15, 0x14, 0xa0, 0xec, 0x23, 0xa8, 0xe6, 0xe9, 0x91, 0x19, 0x32, 0x01, 0x5b, 0x3b, 0x05,
+	0x6c, 0xe6, 0x4f, 0x31, 0xaf, 0xde, 0x30, 0x7f, 0x76, 0xa0, 0x26, 0x8a, 0xef, 0xd4, 0xb8, 0x1a,
+	0xef, 0xc1, 0xd2, 0x58, 0x01, 0xa1, 0x73, 0x30, 0xc3, 0x8a, 0xda, 0x38, 0x83, 0xce, 0xc3, 0xb9,
+	0xce, 0xce, 0xbd, 0xad, 0xfd, 0x4f, 0xdb, 0x77, 0x0c, 0xad, 0x71, 0x1b, 0x96, 0x27, 0xd2, 0x0f,
+	0x15, 0xa1, 0xb0, 0xb3, 0x67, 0x9c, 0x41, 0xb3, 0xa0, 0xed, 0x1b, 0x1a, 0x23, 0xb7, 0xf7, 0x8c,
+	0x02, 0x23, 0xf7, 0x0c, 0x9d, 0xfd, 0x6c, 0x1b, 0x33, 0xec, 0xe7, 0xbe, 0x31, 0xdb, 0xfa, 0x77,
+	0x01, 0xe6, 0x33, 0x0d, 0x1e, 0x61, 0x28, 0x8a, 0x74, 0x45, 0x97, 0xc5, 0x45, 0x22, 0xe7, 0x59,
+	0x67, 0x55, 0xf3, 0xd8, 0x72, 0x10, 0x54, 0xbe, 0xff, 0xf3, 0xef, 0x9f, 0x0a, 0x65, 0x7b, 0x59,
+	0x3c, 0x20, 0x4f, 0x24, 0x92, 0x5b, 0x5a, 0x03, 0x7d, 0x0e, 0xfa, 0x26, 0xa6, 0x48, 0xf4, 0x6d,
+	0xe5, 0x73, 0xc2, 0xba, 0xa4, 0xe4, 0x49, 0xed, 0x55, 0xae, 0xdd, 0x44, 0xe5, 0x09, 0xed, 0xeb,
+	0x5f, 0x13, 0xef, 0x5b, 0xf4, 0x04, 0x8a, 0x22, 0x4b, 0xa4, 0x19, 0x79, 0x37, 0x51, 0xab, 0x9a,
+	0xc7, 0x96, 0x07, 0x5d, 0xe1, 0x07, 0x5d, 0xb2, 0x72, 0x0e, 0x62, 0xb6, 0xf4, 0xa1, 0x28, 0x22,
+	0x2f, 0xcf, 0xca, 0xbb, 0x31, 0x59, 0xd5, 0x3c, 0xf6, 0xa8, 0x51, 0x8d, 0x3c, 0xa3, 0x3e, 0x83,
+	0x19, 0xd6, 0x85, 0x91, 0xf0, 0x8c, 0xfa, 0x3a, 0x65, 0x55, 0xd4, 0x4c, 0x79, 0xc4, 0x45, 0x7e,
+	0xc4, 0x0a, 0x9a, 0x8c, 0x0a, 0x7a, 0x0a, 0x25, 0x11, 0xcd, 0xf1, 0xe9, 0xbb, 0xaa, 0x1a, 0xae,
+	0x16, 0xe2, 0xab, 0xa3, 0xc3, 0xff, 0x06, 0xd7, 0xfe, 0xbe, 0x5d, 0x57, 0x1b, 0xb0, 0x4e, 0x4e,
+	0xf6, 0x27, 0xeb, 0x8f, 0x29, 0x8d, 0x98, 0xfb, 0xbe, 0x01, 0x34, 0x39, 0xe2, 0x50, 0x35, 0x8d,
+	0xbe, 0x7a, 0x96, 0x59, 0x4a, 0x50, 0xf6, 0x75, 0x0e, 0xa0, 0x81, 0xa6, 0x06, 0xc0, 0xac, 0x16,
+	0xc1, 0x3f, 0xb5, 0xd5, 0xd6, 0x4b, 0x59, 0xfd, 0x9d, 0x06, 0x25, 0xe5, 0xb0, 0x46, 0x57, 0x32,
+	0x59, 0x92, 0x63, 0xbc, 0x0a, 0x85, 0x34, 0xbd, 0x31, 0xbd, 0xe9, 0xbf, 0x6a, 0xe9, 0xb3, 0x5f,
+	0xd1, 0xb2, 0xd0, 0x5a, 0xa6, 0xbe, 0xf3, 0x5b, 0x9a, 0x12, 0xca, 0x06, 0x87, 0xf2, 0xa1, 0xfd,
+	0x81, 0x02, 0xca, 0x68, 0xff, 0x1b, 0x83, 0x45, 0xf8, 0x39, 0xde, 0x01, 0x73, 0xd0, 0x6f, 0x1a,
+	0xff, 0xf7, 0x40, 0x05, 0xcd, 0x4e, 0x73, 0xe3, 0x39, 0xb8, 0xae, 0x3e, 0x57, 0x46, 0x02, 0xfd,
+	0x88, 0x03, 0xbd, 0x85, 0x5e, 0x19, 0x28, 0xf7, 0x61, 0xee, 0x38, 0x92, 0x3e, 0x7c, 0xd1, 0xb8,
+	0x7a, 0x9e, 0x0f, 0xad, 0x53, 0xf9, 0xf0, 0x17, 0x2d, 0x7d, 0x93, 0xe5, 0xa3, 0x7b, 0xd1, 0xd0,
+	0x52, 0xa2, 0x93, 0x8e, 0x6b, 0xbc, 0xba, 0xe3, 0x9e, 0x81, 0x31, 0x76, 0xa3, 0x4c, 0x32, 0x7d,
+	0x4d, 0x01, 0xa3, 0xa2, 0x66, 0x4a, 0x40, 0xd7, 0x38, 0xa0, 0x35, 0x74, 0x75, 0x8a, 0xec, 0x3f,
+	0x28, 0xf2, 0x7f, 0x2d, 0x6f, 0xfc, 0x3f, 0x00, 0x03, 0x9a, 0x89, 0xaf, 0xfa, 0x14, 0x00, 0x00,
 }
@@ -24,9 +24,41 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type JoinThrottleReason int32
+
+const (
+	// The join-request rate limit of the device was exceeded.
+	JoinThrottleReason_RATE_LIMIT JoinThrottleReason = 0
+	// The device was blocked because of repeated join-requests with an
+	// invalid MIC.
+	JoinThrottleReason_MIC_FAILURES JoinThrottleReason = 1
+)
+
+var JoinThrottleReason_name = map[int32]string{
+	0: "RATE_LIMIT",
+	1: "MIC_FAILURES",
+}
+var JoinThrottleReason_value = map[string]int32{
+	"RATE_LIMIT":   0,
+	"MIC_FAILURES": 1,
+}
+
+func (x JoinThrottleReason) String() string {
+	return proto.EnumName(JoinThrottleReason_name, int32(x))
+}
+func (JoinThrottleReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{0}
+}
+
 type DeviceKeys struct {
 	// HEX encoded application key.
-	AppKey               string   `protobuf:"bytes,1,opt,name=appKey" json:"appKey,omitempty"`
+	AppKey string `protobuf:"bytes,1,opt,name=appKey" json:"appKey,omitempty"`
+	// HEX encoded network key (LoRaWAN 1.1 devices only).
+	NwkKey string `protobuf:"bytes,2,opt,name=nwkKey" json:"nwkKey,omitempty"`
+	// HEX encoded gen application key (LoRaWAN 1.0.x devices only).
+	// This key is used to derive the McRootKey of the Remote Multicast
+	// Setup application-layer package.
+	GenAppKey            string   `protobuf:"bytes,3,opt,name=genAppKey" json:"genAppKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -36,7 +68,7 @@ func (m *DeviceKeys) Reset()         { *m = DeviceKeys{} }
 func (m *DeviceKeys) String() string { return proto.CompactTextString(m) }
 func (*DeviceKeys) ProtoMessage()    {}
 func (*DeviceKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{0}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{0}
 }
 func (m *DeviceKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceKeys.Unmarshal(m, b)
@@ -63,6 +95,20 @@ func (m *DeviceKeys) GetAppKey() string {
 	return ""
 }
 
+func (m *DeviceKeys) GetNwkKey() string {
+	if m != nil {
+		return m.NwkKey
+	}
+	return ""
+}
+
+func (m *DeviceKeys) GetGenAppKey() string {
+	if m != nil {
+		return m.GenAppKey
+	}
+	return ""
+}
+
 type CreateDeviceRequest struct {
 	// Hex encoded DevEUI.
 	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -75,17 +121,20 @@ type CreateDeviceRequest struct {
 	// DeviceProfileID attached to the device.
 	DeviceProfileID string `protobuf:"bytes,18,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 	// Skip frame-counter checks (this is insecure, but could be helpful for debugging).
-	SkipFCntCheck        bool     `protobuf:"varint,19,opt,name=skipFCntCheck" json:"skipFCntCheck,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SkipFCntCheck bool `protobuf:"varint,19,opt,name=skipFCntCheck" json:"skipFCntCheck,omitempty"`
+	// Tags of the device (optional). The tags can be used to select the
+	// devices of a downlink batch.
+	Tags                 map[string]string `protobuf:"bytes,20,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CreateDeviceRequest) Reset()         { *m = CreateDeviceRequest{} }
 func (m *CreateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceRequest) ProtoMessage()    {}
 func (*CreateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{1}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{1}
 }
 func (m *CreateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceRequest.Unmarshal(m, b)
@@ -147,6 +196,13 @@ func (m *CreateDeviceRequest) GetSkipFCntCheck() bool {
 	return false
 }
 
+func (m *CreateDeviceRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type CreateDeviceResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -157,7 +213,7 @@ func (m *CreateDeviceResponse) Reset()         { *m = CreateDeviceResponse{} }
 func (m *CreateDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceResponse) ProtoMessage()    {}
 func (*CreateDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{2}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{2}
 }
 func (m *CreateDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceResponse.Unmarshal(m, b)
@@ -189,7 +245,7 @@ func (m *GetDeviceRequest) Reset()         { *m = GetDeviceRequest{} }
 func (m *GetDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceRequest) ProtoMessage()    {}
 func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{3}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{3}
 }
 func (m *GetDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceRequest.Unmarshal(m, b)
@@ -241,17 +297,20 @@ type GetDeviceResponse struct {
 	// or an empty string when the device never sent any data.
 	LastSeenAt string `protobuf:"bytes,21,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
 	// Skip frame-counter checks (this is insecure, but could be helpful for debugging).
-	SkipFCntCheck        bool     `protobuf:"varint,22,opt,name=skipFCntCheck" json:"skipFCntCheck,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SkipFCntCheck bool `protobuf:"varint,22,opt,name=skipFCntCheck" json:"skipFCntCheck,omitempty"`
+	// Tags of the device (optional). The tags can be used to select the
+	// devices of a downlink batch.
+	Tags                 map[string]string `protobuf:"bytes,23,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *GetDeviceResponse) Reset()         { *m = GetDeviceResponse{} }
 func (m *GetDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceResponse) ProtoMessage()    {}
 func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{4}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{4}
 }
 func (m *GetDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceResponse.Unmarshal(m, b)
@@ -334,6 +393,13 @@ func (m *GetDeviceResponse) GetSkipFCntCheck() bool {
 	return false
 }
 
+func (m *GetDeviceResponse) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type DeleteDeviceRequest struct {
 	// Hex encoded DevEUI of the device.
 	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -346,7 +412,7 @@ func (m *DeleteDeviceRequest) Reset()         { *m = DeleteDeviceRequest{} }
 func (m *DeleteDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceRequest) ProtoMessage()    {}
 func (*DeleteDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{5}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{5}
 }
 func (m *DeleteDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceRequest.Unmarshal(m, b)
@@ -383,7 +449,7 @@ func (m *DeleteDeviceResponse) Reset()         { *m = DeleteDeviceResponse{} }
 func (m *DeleteDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceResponse) ProtoMessage()    {}
 func (*DeleteDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{6}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{6}
 }
 func (m *DeleteDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceResponse.Unmarshal(m, b)
@@ -421,7 +487,7 @@ func (m *ListDeviceByApplicationIDRequest) Reset()         { *m = ListDeviceByAp
 func (m *ListDeviceByApplicationIDRequest) String() string { return proto.CompactTextString(m) }
 func (*ListDeviceByApplicationIDRequest) ProtoMessage()    {}
 func (*ListDeviceByApplicationIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{7}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{7}
 }
 func (m *ListDeviceByApplicationIDRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListDeviceByApplicationIDRequest.Unmarshal(m, b)
@@ -494,17 +560,20 @@ type DeviceListItem struct {
 	DeviceStatusMargin int32 `protobuf:"varint,21,opt,name=deviceStatusMargin" json:"deviceStatusMargin,omitempty"`
 	// The last time the application-server received any data from the device,
 	// or an empty string when the device never sent any data.
-	LastSeenAt           string   `protobuf:"bytes,22,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LastSeenAt string `protobuf:"bytes,22,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+	// Tags of the device (optional). The tags can be used to select the
+	// devices of a downlink batch.
+	Tags                 map[string]string `protobuf:"bytes,23,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *DeviceListItem) Reset()         { *m = DeviceListItem{} }
 func (m *DeviceListItem) String() string { return proto.CompactTextString(m) }
 func (*DeviceListItem) ProtoMessage()    {}
 func (*DeviceListItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{8}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{8}
 }
 func (m *DeviceListItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceListItem.Unmarshal(m, b)
@@ -587,6 +656,13 @@ func (m *DeviceListItem) GetLastSeenAt() string {
 	return ""
 }
 
+func (m *DeviceListItem) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type ListDeviceResponse struct {
 	// Total number of devices available within the result-set.
 	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
@@ -601,7 +677,7 @@ func (m *ListDeviceResponse) Reset()         { *m = ListDeviceResponse{} }
 func (m *ListDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*ListDeviceResponse) ProtoMessage()    {}
 func (*ListDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{9}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{9}
 }
 func (m *ListDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListDeviceResponse.Unmarshal(m, b)
@@ -647,17 +723,20 @@ type UpdateDeviceRequest struct {
 	// DeviceProfileID attached to the device.
 	DeviceProfileID string `protobuf:"bytes,18,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 	// Skip frame-counter checks (this is insecure, but could be helpful for debugging).
-	SkipFCntCheck        bool     `protobuf:"varint,19,opt,name=skipFCntCheck" json:"skipFCntCheck,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SkipFCntCheck bool `protobuf:"varint,19,opt,name=skipFCntCheck" json:"skipFCntCheck,omitempty"`
+	// Tags of the device (optional). The tags can be used to select the
+	// devices of a downlink batch.
+	Tags                 map[string]string `protobuf:"bytes,20,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *UpdateDeviceRequest) Reset()         { *m = UpdateDeviceRequest{} }
 func (m *UpdateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceRequest) ProtoMessage()    {}
 func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{10}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{10}
 }
 func (m *UpdateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceRequest.Unmarshal(m, b)
@@ -719,6 +798,13 @@ func (m *UpdateDeviceRequest) GetSkipFCntCheck() bool {
 	return false
 }
 
+func (m *UpdateDeviceRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type UpdateDeviceResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -729,7 +815,7 @@ func (m *UpdateDeviceResponse) Reset()         { *m = UpdateDeviceResponse{} }
 func (m *UpdateDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceResponse) ProtoMessage()    {}
 func (*UpdateDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{11}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{11}
 }
 func (m *UpdateDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceResponse.Unmarshal(m, b)
@@ -762,7 +848,7 @@ func (m *CreateDeviceKeysRequest) Reset()         { *m = CreateDeviceKeysRequest
 func (m *CreateDeviceKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceKeysRequest) ProtoMessage()    {}
 func (*CreateDeviceKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{12}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{12}
 }
 func (m *CreateDeviceKeysRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceKeysRequest.Unmarshal(m, b)
@@ -806,7 +892,7 @@ func (m *CreateDeviceKeysResponse) Reset()         { *m = CreateDeviceKeysRespon
 func (m *CreateDeviceKeysResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceKeysResponse) ProtoMessage()    {}
 func (*CreateDeviceKeysResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{13}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{13}
 }
 func (m *CreateDeviceKeysResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceKeysResponse.Unmarshal(m, b)
@@ -838,7 +924,7 @@ func (m *GetDeviceKeysRequest) Reset()         { *m = GetDeviceKeysRequest{} }
 func (m *GetDeviceKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceKeysRequest) ProtoMessage()    {}
 func (*GetDeviceKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{14}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{14}
 }
 func (m *GetDeviceKeysRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceKeysRequest.Unmarshal(m, b)
@@ -876,7 +962,7 @@ func (m *GetDeviceKeysResponse) Reset()         { *m = GetDeviceKeysResponse{} }
 func (m *GetDeviceKeysResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceKeysResponse) ProtoMessage()    {}
 func (*GetDeviceKeysResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{15}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{15}
 }
 func (m *GetDeviceKeysResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceKeysResponse.Unmarshal(m, b)
@@ -916,7 +1002,7 @@ func (m *UpdateDeviceKeysRequest) Reset()         { *m = UpdateDeviceKeysRequest
 func (m *UpdateDeviceKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceKeysRequest) ProtoMessage()    {}
 func (*UpdateDeviceKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{16}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{16}
 }
 func (m *UpdateDeviceKeysRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceKeysRequest.Unmarshal(m, b)
@@ -960,7 +1046,7 @@ func (m *UpdateDeviceKeysResponse) Reset()         { *m = UpdateDeviceKeysRespon
 func (m *UpdateDeviceKeysResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceKeysResponse) ProtoMessage()    {}
 func (*UpdateDeviceKeysResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{17}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{17}
 }
 func (m *UpdateDeviceKeysResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceKeysResponse.Unmarshal(m, b)
@@ -992,7 +1078,7 @@ func (m *DeleteDeviceKeysRequest) Reset()         { *m = DeleteDeviceKeysRequest
 func (m *DeleteDeviceKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceKeysRequest) ProtoMessage()    {}
 func (*DeleteDeviceKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{18}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{18}
 }
 func (m *DeleteDeviceKeysRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceKeysRequest.Unmarshal(m, b)
@@ -1029,7 +1115,7 @@ func (m *DeleteDeviceKeysResponse) Reset()         { *m = DeleteDeviceKeysRespon
 func (m *DeleteDeviceKeysResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceKeysResponse) ProtoMessage()    {}
 func (*DeleteDeviceKeysResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{19}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{19}
 }
 func (m *DeleteDeviceKeysResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceKeysResponse.Unmarshal(m, b)
@@ -1074,7 +1160,7 @@ func (m *ActivateDeviceRequest) Reset()         { *m = ActivateDeviceRequest{} }
 func (m *ActivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*ActivateDeviceRequest) ProtoMessage()    {}
 func (*ActivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{20}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{20}
 }
 func (m *ActivateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActivateDeviceRequest.Unmarshal(m, b)
@@ -1153,7 +1239,7 @@ func (m *ActivateDeviceResponse) Reset()         { *m = ActivateDeviceResponse{}
 func (m *ActivateDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*ActivateDeviceResponse) ProtoMessage()    {}
 func (*ActivateDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{21}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{21}
 }
 func (m *ActivateDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActivateDeviceResponse.Unmarshal(m, b)
@@ -1185,7 +1271,7 @@ func (m *GetDeviceActivationRequest) Reset()         { *m = GetDeviceActivationR
 func (m *GetDeviceActivationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationRequest) ProtoMessage()    {}
 func (*GetDeviceActivationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{22}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{22}
 }
 func (m *GetDeviceActivationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceActivationRequest.Unmarshal(m, b)
@@ -1234,7 +1320,7 @@ func (m *GetDeviceActivationResponse) Reset()         { *m = GetDeviceActivation
 func (m *GetDeviceActivationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationResponse) ProtoMessage()    {}
 func (*GetDeviceActivationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{23}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{23}
 }
 func (m *GetDeviceActivationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceActivationResponse.Unmarshal(m, b)
@@ -1296,6 +1382,213 @@ func (m *GetDeviceActivationResponse) GetSkipFCntCheck() bool {
 	return false
 }
 
+type ListDeviceActivationsRequest struct {
+	// Hex encoded DevEUI of the device.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Max number of items to return in the result-set.
+	Limit int64 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset of the result-set (for pagination).
+	Offset               int64    `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeviceActivationsRequest) Reset()         { *m = ListDeviceActivationsRequest{} }
+func (m *ListDeviceActivationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeviceActivationsRequest) ProtoMessage()    {}
+func (*ListDeviceActivationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{24}
+}
+func (m *ListDeviceActivationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeviceActivationsRequest.Unmarshal(m, b)
+}
+func (m *ListDeviceActivationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeviceActivationsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListDeviceActivationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeviceActivationsRequest.Merge(dst, src)
+}
+func (m *ListDeviceActivationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeviceActivationsRequest.Size(m)
+}
+func (m *ListDeviceActivationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeviceActivationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeviceActivationsRequest proto.InternalMessageInfo
+
+func (m *ListDeviceActivationsRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *ListDeviceActivationsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListDeviceActivationsRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type DeviceActivationListItem struct {
+	// Timestamp when the (re)join-request was handled.
+	CreatedAt string `protobuf:"bytes,1,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Message type (JoinReq or RejoinReq).
+	MessageType string `protobuf:"bytes,2,opt,name=messageType" json:"messageType,omitempty"`
+	// DevNonce (or RJcount) of the (re)join-request.
+	DevNonce uint32 `protobuf:"varint,3,opt,name=devNonce" json:"devNonce,omitempty"`
+	// JoinNonce of the join-accept (0 when the request was rejected).
+	JoinNonce uint32 `protobuf:"varint,4,opt,name=joinNonce" json:"joinNonce,omitempty"`
+	// Hex encoded DevAddr.
+	DevAddr string `protobuf:"bytes,5,opt,name=devAddr" json:"devAddr,omitempty"`
+	// SenderID (NetID) of the network-server.
+	SenderID string `protobuf:"bytes,6,opt,name=senderID" json:"senderID,omitempty"`
+	// Result code (e.g. Success or MICFailed).
+	ResultCode string `protobuf:"bytes,7,opt,name=resultCode" json:"resultCode,omitempty"`
+	// Description of the result (in case of an error).
+	Description          string   `protobuf:"bytes,8,opt,name=description" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceActivationListItem) Reset()         { *m = DeviceActivationListItem{} }
+func (m *DeviceActivationListItem) String() string { return proto.CompactTextString(m) }
+func (*DeviceActivationListItem) ProtoMessage()    {}
+func (*DeviceActivationListItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{25}
+}
+func (m *DeviceActivationListItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceActivationListItem.Unmarshal(m, b)
+}
+func (m *DeviceActivationListItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceActivationListItem.Marshal(b, m, deterministic)
+}
+func (dst *DeviceActivationListItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceActivationListItem.Merge(dst, src)
+}
+func (m *DeviceActivationListItem) XXX_Size() int {
+	return xxx_messageInfo_DeviceActivationListItem.Size(m)
+}
+func (m *DeviceActivationListItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceActivationListItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceActivationListItem proto.InternalMessageInfo
+
+func (m *DeviceActivationListItem) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *DeviceActivationListItem) GetMessageType() string {
+	if m != nil {
+		return m.MessageType
+	}
+	return ""
+}
+
+func (m *DeviceActivationListItem) GetDevNonce() uint32 {
+	if m != nil {
+		return m.DevNonce
+	}
+	return 0
+}
+
+func (m *DeviceActivationListItem) GetJoinNonce() uint32 {
+	if m != nil {
+		return m.JoinNonce
+	}
+	return 0
+}
+
+func (m *DeviceActivationListItem) GetDevAddr() string {
+	if m != nil {
+		return m.DevAddr
+	}
+	return ""
+}
+
+func (m *DeviceActivationListItem) GetSenderID() string {
+	if m != nil {
+		return m.SenderID
+	}
+	return ""
+}
+
+func (m *DeviceActivationListItem) GetResultCode() string {
+	if m != nil {
+		return m.ResultCode
+	}
+	return ""
+}
+
+func (m *DeviceActivationListItem) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type ListDeviceActivationsResponse struct {
+	// Total number of items in the join history.
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Items within this result-set.
+	Result               []*DeviceActivationListItem `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ListDeviceActivationsResponse) Reset()         { *m = ListDeviceActivationsResponse{} }
+func (m *ListDeviceActivationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeviceActivationsResponse) ProtoMessage()    {}
+func (*ListDeviceActivationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{26}
+}
+func (m *ListDeviceActivationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeviceActivationsResponse.Unmarshal(m, b)
+}
+func (m *ListDeviceActivationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeviceActivationsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListDeviceActivationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeviceActivationsResponse.Merge(dst, src)
+}
+func (m *ListDeviceActivationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeviceActivationsResponse.Size(m)
+}
+func (m *ListDeviceActivationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeviceActivationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeviceActivationsResponse proto.InternalMessageInfo
+
+func (m *ListDeviceActivationsResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListDeviceActivationsResponse) GetResult() []*DeviceActivationListItem {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
 type GetRandomDevAddrRequest struct {
 	// Hex encoded DevEUI of the device to activate.
 	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -1308,7 +1601,7 @@ func (m *GetRandomDevAddrRequest) Reset()         { *m = GetRandomDevAddrRequest
 func (m *GetRandomDevAddrRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRandomDevAddrRequest) ProtoMessage()    {}
 func (*GetRandomDevAddrRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{24}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{27}
 }
 func (m *GetRandomDevAddrRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRandomDevAddrRequest.Unmarshal(m, b)
@@ -1347,7 +1640,7 @@ func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrRespon
 func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRandomDevAddrResponse) ProtoMessage()    {}
 func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{25}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{28}
 }
 func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRandomDevAddrResponse.Unmarshal(m, b)
@@ -1386,7 +1679,7 @@ func (m *StreamDeviceFrameLogsRequest) Reset()         { *m = StreamDeviceFrameL
 func (m *StreamDeviceFrameLogsRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamDeviceFrameLogsRequest) ProtoMessage()    {}
 func (*StreamDeviceFrameLogsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{26}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{29}
 }
 func (m *StreamDeviceFrameLogsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamDeviceFrameLogsRequest.Unmarshal(m, b)
@@ -1427,7 +1720,7 @@ func (m *StreamDeviceFrameLogsResponse) Reset()         { *m = StreamDeviceFrame
 func (m *StreamDeviceFrameLogsResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamDeviceFrameLogsResponse) ProtoMessage()    {}
 func (*StreamDeviceFrameLogsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{27}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{30}
 }
 func (m *StreamDeviceFrameLogsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamDeviceFrameLogsResponse.Unmarshal(m, b)
@@ -1473,7 +1766,7 @@ func (m *StreamDeviceEventLogsRequest) Reset()         { *m = StreamDeviceEventL
 func (m *StreamDeviceEventLogsRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamDeviceEventLogsRequest) ProtoMessage()    {}
 func (*StreamDeviceEventLogsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{28}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{31}
 }
 func (m *StreamDeviceEventLogsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamDeviceEventLogsRequest.Unmarshal(m, b)
@@ -1514,7 +1807,7 @@ func (m *StreamDeviceEventLogsResponse) Reset()         { *m = StreamDeviceEvent
 func (m *StreamDeviceEventLogsResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamDeviceEventLogsResponse) ProtoMessage()    {}
 func (*StreamDeviceEventLogsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_e3b1e4891c217207, []int{29}
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{32}
 }
 func (m *StreamDeviceEventLogsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamDeviceEventLogsResponse.Unmarshal(m, b)
@@ -1548,80 +1841,1085 @@ func (m *StreamDeviceEventLogsResponse) GetPayloadJSON() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*DeviceKeys)(nil), "api.DeviceKeys")
-	proto.RegisterType((*CreateDeviceRequest)(nil), "api.CreateDeviceRequest")
-	proto.RegisterType((*CreateDeviceResponse)(nil), "api.CreateDeviceResponse")
-	proto.RegisterType((*GetDeviceRequest)(nil), "api.GetDeviceRequest")
-	proto.RegisterType((*GetDeviceResponse)(nil), "api.GetDeviceResponse")
-	proto.RegisterType((*DeleteDeviceRequest)(nil), "api.DeleteDeviceRequest")
-	proto.RegisterType((*DeleteDeviceResponse)(nil), "api.DeleteDeviceResponse")
-	proto.RegisterType((*ListDeviceByApplicationIDRequest)(nil), "api.ListDeviceByApplicationIDRequest")
-	proto.RegisterType((*DeviceListItem)(nil), "api.DeviceListItem")
-	proto.RegisterType((*ListDeviceResponse)(nil), "api.ListDeviceResponse")
-	proto.RegisterType((*UpdateDeviceRequest)(nil), "api.UpdateDeviceRequest")
-	proto.RegisterType((*UpdateDeviceResponse)(nil), "api.UpdateDeviceResponse")
-	proto.RegisterType((*CreateDeviceKeysRequest)(nil), "api.CreateDeviceKeysRequest")
-	proto.RegisterType((*CreateDeviceKeysResponse)(nil), "api.CreateDeviceKeysResponse")
-	proto.RegisterType((*GetDeviceKeysRequest)(nil), "api.GetDeviceKeysRequest")
-	proto.RegisterType((*GetDeviceKeysResponse)(nil), "api.GetDeviceKeysResponse")
-	proto.RegisterType((*UpdateDeviceKeysRequest)(nil), "api.UpdateDeviceKeysRequest")
-	proto.RegisterType((*UpdateDeviceKeysResponse)(nil), "api.UpdateDeviceKeysResponse")
-	proto.RegisterType((*DeleteDeviceKeysRequest)(nil), "api.DeleteDeviceKeysRequest")
-	proto.RegisterType((*DeleteDeviceKeysResponse)(nil), "api.DeleteDeviceKeysResponse")
-	proto.RegisterType((*ActivateDeviceRequest)(nil), "api.ActivateDeviceRequest")
-	proto.RegisterType((*ActivateDeviceResponse)(nil), "api.ActivateDeviceResponse")
-	proto.RegisterType((*GetDeviceActivationRequest)(nil), "api.GetDeviceActivationRequest")
-	proto.RegisterType((*GetDeviceActivationResponse)(nil), "api.GetDeviceActivationResponse")
-	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "api.GetRandomDevAddrRequest")
-	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "api.GetRandomDevAddrResponse")
-	proto.RegisterType((*StreamDeviceFrameLogsRequest)(nil), "api.StreamDeviceFrameLogsRequest")
-	proto.RegisterType((*StreamDeviceFrameLogsResponse)(nil), "api.StreamDeviceFrameLogsResponse")
-	proto.RegisterType((*StreamDeviceEventLogsRequest)(nil), "api.StreamDeviceEventLogsRequest")
-	proto.RegisterType((*StreamDeviceEventLogsResponse)(nil), "api.StreamDeviceEventLogsResponse")
+type GetDeviceShadowRequest struct {
+	// Hex encoded DevEUI.
+	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *GetDeviceShadowRequest) Reset()         { *m = GetDeviceShadowRequest{} }
+func (m *GetDeviceShadowRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceShadowRequest) ProtoMessage()    {}
+func (*GetDeviceShadowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{33}
+}
+func (m *GetDeviceShadowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceShadowRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceShadowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceShadowRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetDeviceShadowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceShadowRequest.Merge(dst, src)
+}
+func (m *GetDeviceShadowRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceShadowRequest.Size(m)
+}
+func (m *GetDeviceShadowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceShadowRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_GetDeviceShadowRequest proto.InternalMessageInfo
 
-// DeviceClient is the client API for Device service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DeviceClient interface {
-	// Create creates the given device.
-	Create(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error)
-	// Get returns the device matching the given DevEUI.
-	Get(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
-	// Delete deletes the device matching the given DevEUI.
-	Delete(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
-	// ListByApplicationID lists the devices by the given application ID, sorted by the name of the device.
-	ListByApplicationID(ctx context.Context, in *ListDeviceByApplicationIDRequest, opts ...grpc.CallOption) (*ListDeviceResponse, error)
-	// Update updates the device matching the given DevEUI.
-	Update(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
-	// CreateKeys creates the given device-keys.
-	CreateKeys(ctx context.Context, in *CreateDeviceKeysRequest, opts ...grpc.CallOption) (*CreateDeviceKeysResponse, error)
-	// GetKeys returns the device-keys for the given DevEUI.
-	GetKeys(ctx context.Context, in *GetDeviceKeysRequest, opts ...grpc.CallOption) (*GetDeviceKeysResponse, error)
-	// UpdateKeys updates the device-keys.
-	UpdateKeys(ctx context.Context, in *UpdateDeviceKeysRequest, opts ...grpc.CallOption) (*UpdateDeviceKeysResponse, error)
-	// DeleteKeys deletes the device-keys for the given DevEUI.
-	DeleteKeys(ctx context.Context, in *DeleteDeviceKeysRequest, opts ...grpc.CallOption) (*DeleteDeviceKeysResponse, error)
-	// Activate (re)activates the device (only when ABP is set to true).
-	Activate(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*ActivateDeviceResponse, error)
-	// GetActivation returns the current activation details of the device (OTAA and ABP).
-	GetActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
-	// StreamFrameLogs streams the uplink and downlink frame-logs for the given DevEUI.
-	// Note: these are the raw LoRaWAN frames and this endpoint is intended for debugging.
-	StreamFrameLogs(ctx context.Context, in *StreamDeviceFrameLogsRequest, opts ...grpc.CallOption) (Device_StreamFrameLogsClient, error)
-	// StreamEventLogs stream the device events (uplink payloads, ACKs, joins, errors).
-	// Note: this endpoint is intended for debugging and should not be used for building
+func (m *GetDeviceShadowRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type GetDeviceShadowResponse struct {
+	// The desired state in JSON encoding.
+	DesiredJSON string `protobuf:"bytes,1,opt,name=desiredJSON" json:"desiredJSON,omitempty"`
+	// Version of the desired state, incremented on each update.
+	DesiredVersion int64 `protobuf:"varint,2,opt,name=desiredVersion" json:"desiredVersion,omitempty"`
+	// The reported state (merged objects of the decoded uplinks) in JSON encoding.
+	ReportedJSON string `protobuf:"bytes,3,opt,name=reportedJSON" json:"reportedJSON,omitempty"`
+	// Last time the reported state was updated.
+	ReportedAt string `protobuf:"bytes,4,opt,name=reportedAt" json:"reportedAt,omitempty"`
+	// The difference between the desired and reported state in JSON encoding
+	// (empty when the device is in sync).
+	DeltaJSON string `protobuf:"bytes,5,opt,name=deltaJSON" json:"deltaJSON,omitempty"`
+	// FPort used for the delta downlinks.
+	FPort uint32 `protobuf:"varint,6,opt,name=fPort" json:"fPort,omitempty"`
+	// The delta downlinks are sent as confirmed downlinks.
+	Confirmed bool `protobuf:"varint,7,opt,name=confirmed" json:"confirmed,omitempty"`
+	// Last time the delta was enqueued.
+	DeltaSentAt          string   `protobuf:"bytes,8,opt,name=deltaSentAt" json:"deltaSentAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceShadowResponse) Reset()         { *m = GetDeviceShadowResponse{} }
+func (m *GetDeviceShadowResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceShadowResponse) ProtoMessage()    {}
+func (*GetDeviceShadowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{34}
+}
+func (m *GetDeviceShadowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceShadowResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceShadowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceShadowResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetDeviceShadowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceShadowResponse.Merge(dst, src)
+}
+func (m *GetDeviceShadowResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceShadowResponse.Size(m)
+}
+func (m *GetDeviceShadowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceShadowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceShadowResponse proto.InternalMessageInfo
+
+func (m *GetDeviceShadowResponse) GetDesiredJSON() string {
+	if m != nil {
+		return m.DesiredJSON
+	}
+	return ""
+}
+
+func (m *GetDeviceShadowResponse) GetDesiredVersion() int64 {
+	if m != nil {
+		return m.DesiredVersion
+	}
+	return 0
+}
+
+func (m *GetDeviceShadowResponse) GetReportedJSON() string {
+	if m != nil {
+		return m.ReportedJSON
+	}
+	return ""
+}
+
+func (m *GetDeviceShadowResponse) GetReportedAt() string {
+	if m != nil {
+		return m.ReportedAt
+	}
+	return ""
+}
+
+func (m *GetDeviceShadowResponse) GetDeltaJSON() string {
+	if m != nil {
+		return m.DeltaJSON
+	}
+	return ""
+}
+
+func (m *GetDeviceShadowResponse) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *GetDeviceShadowResponse) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *GetDeviceShadowResponse) GetDeltaSentAt() string {
+	if m != nil {
+		return m.DeltaSentAt
+	}
+	return ""
+}
+
+type UpdateDeviceShadowRequest struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// The desired state in JSON encoding (must be a JSON object). It will be
+	// encoded to bytes using the application codec.
+	DesiredJSON string `protobuf:"bytes,2,opt,name=desiredJSON" json:"desiredJSON,omitempty"`
+	// FPort used for the delta downlinks (must be > 0).
+	FPort uint32 `protobuf:"varint,3,opt,name=fPort" json:"fPort,omitempty"`
+	// Send the delta downlinks as confirmed downlinks.
+	Confirmed            bool     `protobuf:"varint,4,opt,name=confirmed" json:"confirmed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateDeviceShadowRequest) Reset()         { *m = UpdateDeviceShadowRequest{} }
+func (m *UpdateDeviceShadowRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDeviceShadowRequest) ProtoMessage()    {}
+func (*UpdateDeviceShadowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{35}
+}
+func (m *UpdateDeviceShadowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDeviceShadowRequest.Unmarshal(m, b)
+}
+func (m *UpdateDeviceShadowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDeviceShadowRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateDeviceShadowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDeviceShadowRequest.Merge(dst, src)
+}
+func (m *UpdateDeviceShadowRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateDeviceShadowRequest.Size(m)
+}
+func (m *UpdateDeviceShadowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDeviceShadowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDeviceShadowRequest proto.InternalMessageInfo
+
+func (m *UpdateDeviceShadowRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *UpdateDeviceShadowRequest) GetDesiredJSON() string {
+	if m != nil {
+		return m.DesiredJSON
+	}
+	return ""
+}
+
+func (m *UpdateDeviceShadowRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *UpdateDeviceShadowRequest) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+type UpdateDeviceShadowResponse struct {
+	// Version of the desired state.
+	DesiredVersion       int64    `protobuf:"varint,1,opt,name=desiredVersion" json:"desiredVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateDeviceShadowResponse) Reset()         { *m = UpdateDeviceShadowResponse{} }
+func (m *UpdateDeviceShadowResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateDeviceShadowResponse) ProtoMessage()    {}
+func (*UpdateDeviceShadowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{36}
+}
+func (m *UpdateDeviceShadowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDeviceShadowResponse.Unmarshal(m, b)
+}
+func (m *UpdateDeviceShadowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDeviceShadowResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateDeviceShadowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDeviceShadowResponse.Merge(dst, src)
+}
+func (m *UpdateDeviceShadowResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateDeviceShadowResponse.Size(m)
+}
+func (m *UpdateDeviceShadowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDeviceShadowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDeviceShadowResponse proto.InternalMessageInfo
+
+func (m *UpdateDeviceShadowResponse) GetDesiredVersion() int64 {
+	if m != nil {
+		return m.DesiredVersion
+	}
+	return 0
+}
+
+type DeleteDeviceShadowRequest struct {
+	// Hex encoded DevEUI.
+	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDeviceShadowRequest) Reset()         { *m = DeleteDeviceShadowRequest{} }
+func (m *DeleteDeviceShadowRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDeviceShadowRequest) ProtoMessage()    {}
+func (*DeleteDeviceShadowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{37}
+}
+func (m *DeleteDeviceShadowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDeviceShadowRequest.Unmarshal(m, b)
+}
+func (m *DeleteDeviceShadowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDeviceShadowRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteDeviceShadowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDeviceShadowRequest.Merge(dst, src)
+}
+func (m *DeleteDeviceShadowRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDeviceShadowRequest.Size(m)
+}
+func (m *DeleteDeviceShadowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDeviceShadowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDeviceShadowRequest proto.InternalMessageInfo
+
+func (m *DeleteDeviceShadowRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type DeleteDeviceShadowResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDeviceShadowResponse) Reset()         { *m = DeleteDeviceShadowResponse{} }
+func (m *DeleteDeviceShadowResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteDeviceShadowResponse) ProtoMessage()    {}
+func (*DeleteDeviceShadowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{38}
+}
+func (m *DeleteDeviceShadowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDeviceShadowResponse.Unmarshal(m, b)
+}
+func (m *DeleteDeviceShadowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDeviceShadowResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteDeviceShadowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDeviceShadowResponse.Merge(dst, src)
+}
+func (m *DeleteDeviceShadowResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteDeviceShadowResponse.Size(m)
+}
+func (m *DeleteDeviceShadowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDeviceShadowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDeviceShadowResponse proto.InternalMessageInfo
+
+type ListThrottledDevicesRequest struct {
+	// ID of the application.
+	ApplicationID        int64    `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListThrottledDevicesRequest) Reset()         { *m = ListThrottledDevicesRequest{} }
+func (m *ListThrottledDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListThrottledDevicesRequest) ProtoMessage()    {}
+func (*ListThrottledDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{39}
+}
+func (m *ListThrottledDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListThrottledDevicesRequest.Unmarshal(m, b)
+}
+func (m *ListThrottledDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListThrottledDevicesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListThrottledDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListThrottledDevicesRequest.Merge(dst, src)
+}
+func (m *ListThrottledDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListThrottledDevicesRequest.Size(m)
+}
+func (m *ListThrottledDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListThrottledDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListThrottledDevicesRequest proto.InternalMessageInfo
+
+func (m *ListThrottledDevicesRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+type ThrottledDeviceListItem struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Reason why the join-requests were throttled.
+	Reason JoinThrottleReason `protobuf:"varint,2,opt,name=reason,enum=api.JoinThrottleReason" json:"reason,omitempty"`
+	// Timestamp when the join-requests were throttled.
+	ThrottledAt          string   `protobuf:"bytes,3,opt,name=throttledAt" json:"throttledAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThrottledDeviceListItem) Reset()         { *m = ThrottledDeviceListItem{} }
+func (m *ThrottledDeviceListItem) String() string { return proto.CompactTextString(m) }
+func (*ThrottledDeviceListItem) ProtoMessage()    {}
+func (*ThrottledDeviceListItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{40}
+}
+func (m *ThrottledDeviceListItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThrottledDeviceListItem.Unmarshal(m, b)
+}
+func (m *ThrottledDeviceListItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThrottledDeviceListItem.Marshal(b, m, deterministic)
+}
+func (dst *ThrottledDeviceListItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThrottledDeviceListItem.Merge(dst, src)
+}
+func (m *ThrottledDeviceListItem) XXX_Size() int {
+	return xxx_messageInfo_ThrottledDeviceListItem.Size(m)
+}
+func (m *ThrottledDeviceListItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThrottledDeviceListItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThrottledDeviceListItem proto.InternalMessageInfo
+
+func (m *ThrottledDeviceListItem) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *ThrottledDeviceListItem) GetReason() JoinThrottleReason {
+	if m != nil {
+		return m.Reason
+	}
+	return JoinThrottleReason_RATE_LIMIT
+}
+
+func (m *ThrottledDeviceListItem) GetThrottledAt() string {
+	if m != nil {
+		return m.ThrottledAt
+	}
+	return ""
+}
+
+type ListThrottledDevicesResponse struct {
+	// Throttled devices, most recent first.
+	Result               []*ThrottledDeviceListItem `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ListThrottledDevicesResponse) Reset()         { *m = ListThrottledDevicesResponse{} }
+func (m *ListThrottledDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListThrottledDevicesResponse) ProtoMessage()    {}
+func (*ListThrottledDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{41}
+}
+func (m *ListThrottledDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListThrottledDevicesResponse.Unmarshal(m, b)
+}
+func (m *ListThrottledDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListThrottledDevicesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListThrottledDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListThrottledDevicesResponse.Merge(dst, src)
+}
+func (m *ListThrottledDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListThrottledDevicesResponse.Size(m)
+}
+func (m *ListThrottledDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListThrottledDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListThrottledDevicesResponse proto.InternalMessageInfo
+
+func (m *ListThrottledDevicesResponse) GetResult() []*ThrottledDeviceListItem {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type UnclaimedDevice struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// ID of the device-profile.
+	DeviceProfileID string `protobuf:"bytes,2,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
+	// Root-keys of the device.
+	DeviceKeys *DeviceKeys `protobuf:"bytes,3,opt,name=deviceKeys" json:"deviceKeys,omitempty"`
+	// One-time claim-code of the device. When left blank, a random
+	// claim-code will be generated.
+	ClaimCode            string   `protobuf:"bytes,4,opt,name=claimCode" json:"claimCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnclaimedDevice) Reset()         { *m = UnclaimedDevice{} }
+func (m *UnclaimedDevice) String() string { return proto.CompactTextString(m) }
+func (*UnclaimedDevice) ProtoMessage()    {}
+func (*UnclaimedDevice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{42}
+}
+func (m *UnclaimedDevice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnclaimedDevice.Unmarshal(m, b)
+}
+func (m *UnclaimedDevice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnclaimedDevice.Marshal(b, m, deterministic)
+}
+func (dst *UnclaimedDevice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnclaimedDevice.Merge(dst, src)
+}
+func (m *UnclaimedDevice) XXX_Size() int {
+	return xxx_messageInfo_UnclaimedDevice.Size(m)
+}
+func (m *UnclaimedDevice) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnclaimedDevice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnclaimedDevice proto.InternalMessageInfo
+
+func (m *UnclaimedDevice) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *UnclaimedDevice) GetDeviceProfileID() string {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return ""
+}
+
+func (m *UnclaimedDevice) GetDeviceKeys() *DeviceKeys {
+	if m != nil {
+		return m.DeviceKeys
+	}
+	return nil
+}
+
+func (m *UnclaimedDevice) GetClaimCode() string {
+	if m != nil {
+		return m.ClaimCode
+	}
+	return ""
+}
+
+type CreateUnclaimedDevicesRequest struct {
+	// Devices to add to the unclaimed devices.
+	Devices              []*UnclaimedDevice `protobuf:"bytes,1,rep,name=devices" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CreateUnclaimedDevicesRequest) Reset()         { *m = CreateUnclaimedDevicesRequest{} }
+func (m *CreateUnclaimedDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateUnclaimedDevicesRequest) ProtoMessage()    {}
+func (*CreateUnclaimedDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{43}
+}
+func (m *CreateUnclaimedDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUnclaimedDevicesRequest.Unmarshal(m, b)
+}
+func (m *CreateUnclaimedDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUnclaimedDevicesRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateUnclaimedDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUnclaimedDevicesRequest.Merge(dst, src)
+}
+func (m *CreateUnclaimedDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateUnclaimedDevicesRequest.Size(m)
+}
+func (m *CreateUnclaimedDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUnclaimedDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUnclaimedDevicesRequest proto.InternalMessageInfo
+
+func (m *CreateUnclaimedDevicesRequest) GetDevices() []*UnclaimedDevice {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type UnclaimedDeviceClaimCode struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// One-time claim-code of the device.
+	ClaimCode            string   `protobuf:"bytes,2,opt,name=claimCode" json:"claimCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnclaimedDeviceClaimCode) Reset()         { *m = UnclaimedDeviceClaimCode{} }
+func (m *UnclaimedDeviceClaimCode) String() string { return proto.CompactTextString(m) }
+func (*UnclaimedDeviceClaimCode) ProtoMessage()    {}
+func (*UnclaimedDeviceClaimCode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{44}
+}
+func (m *UnclaimedDeviceClaimCode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnclaimedDeviceClaimCode.Unmarshal(m, b)
+}
+func (m *UnclaimedDeviceClaimCode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnclaimedDeviceClaimCode.Marshal(b, m, deterministic)
+}
+func (dst *UnclaimedDeviceClaimCode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnclaimedDeviceClaimCode.Merge(dst, src)
+}
+func (m *UnclaimedDeviceClaimCode) XXX_Size() int {
+	return xxx_messageInfo_UnclaimedDeviceClaimCode.Size(m)
+}
+func (m *UnclaimedDeviceClaimCode) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnclaimedDeviceClaimCode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnclaimedDeviceClaimCode proto.InternalMessageInfo
+
+func (m *UnclaimedDeviceClaimCode) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *UnclaimedDeviceClaimCode) GetClaimCode() string {
+	if m != nil {
+		return m.ClaimCode
+	}
+	return ""
+}
+
+type CreateUnclaimedDevicesResponse struct {
+	// Claim-codes of the created devices.
+	Result               []*UnclaimedDeviceClaimCode `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *CreateUnclaimedDevicesResponse) Reset()         { *m = CreateUnclaimedDevicesResponse{} }
+func (m *CreateUnclaimedDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateUnclaimedDevicesResponse) ProtoMessage()    {}
+func (*CreateUnclaimedDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{45}
+}
+func (m *CreateUnclaimedDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUnclaimedDevicesResponse.Unmarshal(m, b)
+}
+func (m *CreateUnclaimedDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUnclaimedDevicesResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateUnclaimedDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUnclaimedDevicesResponse.Merge(dst, src)
+}
+func (m *CreateUnclaimedDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateUnclaimedDevicesResponse.Size(m)
+}
+func (m *CreateUnclaimedDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUnclaimedDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUnclaimedDevicesResponse proto.InternalMessageInfo
+
+func (m *CreateUnclaimedDevicesResponse) GetResult() []*UnclaimedDeviceClaimCode {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ListUnclaimedDevicesRequest struct {
+	// Max number of items to return.
+	Limit int64 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset               int64    `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUnclaimedDevicesRequest) Reset()         { *m = ListUnclaimedDevicesRequest{} }
+func (m *ListUnclaimedDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUnclaimedDevicesRequest) ProtoMessage()    {}
+func (*ListUnclaimedDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{46}
+}
+func (m *ListUnclaimedDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnclaimedDevicesRequest.Unmarshal(m, b)
+}
+func (m *ListUnclaimedDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnclaimedDevicesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListUnclaimedDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnclaimedDevicesRequest.Merge(dst, src)
+}
+func (m *ListUnclaimedDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUnclaimedDevicesRequest.Size(m)
+}
+func (m *ListUnclaimedDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnclaimedDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnclaimedDevicesRequest proto.InternalMessageInfo
+
+func (m *ListUnclaimedDevicesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListUnclaimedDevicesRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type UnclaimedDeviceListItem struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// ID of the device-profile.
+	DeviceProfileID string `protobuf:"bytes,2,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
+	// Timestamp when the device was added.
+	CreatedAt            string   `protobuf:"bytes,3,opt,name=createdAt" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnclaimedDeviceListItem) Reset()         { *m = UnclaimedDeviceListItem{} }
+func (m *UnclaimedDeviceListItem) String() string { return proto.CompactTextString(m) }
+func (*UnclaimedDeviceListItem) ProtoMessage()    {}
+func (*UnclaimedDeviceListItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{47}
+}
+func (m *UnclaimedDeviceListItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnclaimedDeviceListItem.Unmarshal(m, b)
+}
+func (m *UnclaimedDeviceListItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnclaimedDeviceListItem.Marshal(b, m, deterministic)
+}
+func (dst *UnclaimedDeviceListItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnclaimedDeviceListItem.Merge(dst, src)
+}
+func (m *UnclaimedDeviceListItem) XXX_Size() int {
+	return xxx_messageInfo_UnclaimedDeviceListItem.Size(m)
+}
+func (m *UnclaimedDeviceListItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnclaimedDeviceListItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnclaimedDeviceListItem proto.InternalMessageInfo
+
+func (m *UnclaimedDeviceListItem) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *UnclaimedDeviceListItem) GetDeviceProfileID() string {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return ""
+}
+
+func (m *UnclaimedDeviceListItem) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type ListUnclaimedDevicesResponse struct {
+	// Total number of unclaimed devices.
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Unclaimed devices within the result-set.
+	Result               []*UnclaimedDeviceListItem `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ListUnclaimedDevicesResponse) Reset()         { *m = ListUnclaimedDevicesResponse{} }
+func (m *ListUnclaimedDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUnclaimedDevicesResponse) ProtoMessage()    {}
+func (*ListUnclaimedDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{48}
+}
+func (m *ListUnclaimedDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnclaimedDevicesResponse.Unmarshal(m, b)
+}
+func (m *ListUnclaimedDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnclaimedDevicesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListUnclaimedDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnclaimedDevicesResponse.Merge(dst, src)
+}
+func (m *ListUnclaimedDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUnclaimedDevicesResponse.Size(m)
+}
+func (m *ListUnclaimedDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnclaimedDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnclaimedDevicesResponse proto.InternalMessageInfo
+
+func (m *ListUnclaimedDevicesResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListUnclaimedDevicesResponse) GetResult() []*UnclaimedDeviceListItem {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DeleteUnclaimedDeviceRequest struct {
+	// Hex encoded DevEUI.
+	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteUnclaimedDeviceRequest) Reset()         { *m = DeleteUnclaimedDeviceRequest{} }
+func (m *DeleteUnclaimedDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteUnclaimedDeviceRequest) ProtoMessage()    {}
+func (*DeleteUnclaimedDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{49}
+}
+func (m *DeleteUnclaimedDeviceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteUnclaimedDeviceRequest.Unmarshal(m, b)
+}
+func (m *DeleteUnclaimedDeviceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteUnclaimedDeviceRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteUnclaimedDeviceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteUnclaimedDeviceRequest.Merge(dst, src)
+}
+func (m *DeleteUnclaimedDeviceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteUnclaimedDeviceRequest.Size(m)
+}
+func (m *DeleteUnclaimedDeviceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteUnclaimedDeviceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteUnclaimedDeviceRequest proto.InternalMessageInfo
+
+func (m *DeleteUnclaimedDeviceRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type DeleteUnclaimedDeviceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteUnclaimedDeviceResponse) Reset()         { *m = DeleteUnclaimedDeviceResponse{} }
+func (m *DeleteUnclaimedDeviceResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteUnclaimedDeviceResponse) ProtoMessage()    {}
+func (*DeleteUnclaimedDeviceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{50}
+}
+func (m *DeleteUnclaimedDeviceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteUnclaimedDeviceResponse.Unmarshal(m, b)
+}
+func (m *DeleteUnclaimedDeviceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteUnclaimedDeviceResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteUnclaimedDeviceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteUnclaimedDeviceResponse.Merge(dst, src)
+}
+func (m *DeleteUnclaimedDeviceResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteUnclaimedDeviceResponse.Size(m)
+}
+func (m *DeleteUnclaimedDeviceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteUnclaimedDeviceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteUnclaimedDeviceResponse proto.InternalMessageInfo
+
+type ClaimDeviceRequest struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// One-time claim-code of the device.
+	ClaimCode string `protobuf:"bytes,2,opt,name=claimCode" json:"claimCode,omitempty"`
+	// ID of the application to which the device must be added.
+	ApplicationID        int64    `protobuf:"varint,3,opt,name=applicationID" json:"applicationID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClaimDeviceRequest) Reset()         { *m = ClaimDeviceRequest{} }
+func (m *ClaimDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*ClaimDeviceRequest) ProtoMessage()    {}
+func (*ClaimDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{51}
+}
+func (m *ClaimDeviceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClaimDeviceRequest.Unmarshal(m, b)
+}
+func (m *ClaimDeviceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClaimDeviceRequest.Marshal(b, m, deterministic)
+}
+func (dst *ClaimDeviceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClaimDeviceRequest.Merge(dst, src)
+}
+func (m *ClaimDeviceRequest) XXX_Size() int {
+	return xxx_messageInfo_ClaimDeviceRequest.Size(m)
+}
+func (m *ClaimDeviceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClaimDeviceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClaimDeviceRequest proto.InternalMessageInfo
+
+func (m *ClaimDeviceRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *ClaimDeviceRequest) GetClaimCode() string {
+	if m != nil {
+		return m.ClaimCode
+	}
+	return ""
+}
+
+func (m *ClaimDeviceRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+type ClaimDeviceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClaimDeviceResponse) Reset()         { *m = ClaimDeviceResponse{} }
+func (m *ClaimDeviceResponse) String() string { return proto.CompactTextString(m) }
+func (*ClaimDeviceResponse) ProtoMessage()    {}
+func (*ClaimDeviceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_device_3e317a9d2c4ed9fe, []int{52}
+}
+func (m *ClaimDeviceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClaimDeviceResponse.Unmarshal(m, b)
+}
+func (m *ClaimDeviceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClaimDeviceResponse.Marshal(b, m, deterministic)
+}
+func (dst *ClaimDeviceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClaimDeviceResponse.Merge(dst, src)
+}
+func (m *ClaimDeviceResponse) XXX_Size() int {
+	return xxx_messageInfo_ClaimDeviceResponse.Size(m)
+}
+func (m *ClaimDeviceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClaimDeviceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClaimDeviceResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*DeviceKeys)(nil), "api.DeviceKeys")
+	proto.RegisterType((*CreateDeviceRequest)(nil), "api.CreateDeviceRequest")
+	proto.RegisterMapType((map[string]string)(nil), "api.CreateDeviceRequest.TagsEntry")
+	proto.RegisterType((*CreateDeviceResponse)(nil), "api.CreateDeviceResponse")
+	proto.RegisterType((*GetDeviceRequest)(nil), "api.GetDeviceRequest")
+	proto.RegisterType((*GetDeviceResponse)(nil), "api.GetDeviceResponse")
+	proto.RegisterMapType((map[string]string)(nil), "api.GetDeviceResponse.TagsEntry")
+	proto.RegisterType((*DeleteDeviceRequest)(nil), "api.DeleteDeviceRequest")
+	proto.RegisterType((*DeleteDeviceResponse)(nil), "api.DeleteDeviceResponse")
+	proto.RegisterType((*ListDeviceByApplicationIDRequest)(nil), "api.ListDeviceByApplicationIDRequest")
+	proto.RegisterType((*DeviceListItem)(nil), "api.DeviceListItem")
+	proto.RegisterMapType((map[string]string)(nil), "api.DeviceListItem.TagsEntry")
+	proto.RegisterType((*ListDeviceResponse)(nil), "api.ListDeviceResponse")
+	proto.RegisterType((*UpdateDeviceRequest)(nil), "api.UpdateDeviceRequest")
+	proto.RegisterMapType((map[string]string)(nil), "api.UpdateDeviceRequest.TagsEntry")
+	proto.RegisterType((*UpdateDeviceResponse)(nil), "api.UpdateDeviceResponse")
+	proto.RegisterType((*CreateDeviceKeysRequest)(nil), "api.CreateDeviceKeysRequest")
+	proto.RegisterType((*CreateDeviceKeysResponse)(nil), "api.CreateDeviceKeysResponse")
+	proto.RegisterType((*GetDeviceKeysRequest)(nil), "api.GetDeviceKeysRequest")
+	proto.RegisterType((*GetDeviceKeysResponse)(nil), "api.GetDeviceKeysResponse")
+	proto.RegisterType((*UpdateDeviceKeysRequest)(nil), "api.UpdateDeviceKeysRequest")
+	proto.RegisterType((*UpdateDeviceKeysResponse)(nil), "api.UpdateDeviceKeysResponse")
+	proto.RegisterType((*DeleteDeviceKeysRequest)(nil), "api.DeleteDeviceKeysRequest")
+	proto.RegisterType((*DeleteDeviceKeysResponse)(nil), "api.DeleteDeviceKeysResponse")
+	proto.RegisterType((*ActivateDeviceRequest)(nil), "api.ActivateDeviceRequest")
+	proto.RegisterType((*ActivateDeviceResponse)(nil), "api.ActivateDeviceResponse")
+	proto.RegisterType((*GetDeviceActivationRequest)(nil), "api.GetDeviceActivationRequest")
+	proto.RegisterType((*GetDeviceActivationResponse)(nil), "api.GetDeviceActivationResponse")
+	proto.RegisterType((*ListDeviceActivationsRequest)(nil), "api.ListDeviceActivationsRequest")
+	proto.RegisterType((*DeviceActivationListItem)(nil), "api.DeviceActivationListItem")
+	proto.RegisterType((*ListDeviceActivationsResponse)(nil), "api.ListDeviceActivationsResponse")
+	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "api.GetRandomDevAddrRequest")
+	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "api.GetRandomDevAddrResponse")
+	proto.RegisterType((*StreamDeviceFrameLogsRequest)(nil), "api.StreamDeviceFrameLogsRequest")
+	proto.RegisterType((*StreamDeviceFrameLogsResponse)(nil), "api.StreamDeviceFrameLogsResponse")
+	proto.RegisterType((*StreamDeviceEventLogsRequest)(nil), "api.StreamDeviceEventLogsRequest")
+	proto.RegisterType((*StreamDeviceEventLogsResponse)(nil), "api.StreamDeviceEventLogsResponse")
+	proto.RegisterType((*GetDeviceShadowRequest)(nil), "api.GetDeviceShadowRequest")
+	proto.RegisterType((*GetDeviceShadowResponse)(nil), "api.GetDeviceShadowResponse")
+	proto.RegisterType((*UpdateDeviceShadowRequest)(nil), "api.UpdateDeviceShadowRequest")
+	proto.RegisterType((*UpdateDeviceShadowResponse)(nil), "api.UpdateDeviceShadowResponse")
+	proto.RegisterType((*DeleteDeviceShadowRequest)(nil), "api.DeleteDeviceShadowRequest")
+	proto.RegisterType((*DeleteDeviceShadowResponse)(nil), "api.DeleteDeviceShadowResponse")
+	proto.RegisterType((*ListThrottledDevicesRequest)(nil), "api.ListThrottledDevicesRequest")
+	proto.RegisterType((*ThrottledDeviceListItem)(nil), "api.ThrottledDeviceListItem")
+	proto.RegisterType((*ListThrottledDevicesResponse)(nil), "api.ListThrottledDevicesResponse")
+	proto.RegisterType((*UnclaimedDevice)(nil), "api.UnclaimedDevice")
+	proto.RegisterType((*CreateUnclaimedDevicesRequest)(nil), "api.CreateUnclaimedDevicesRequest")
+	proto.RegisterType((*UnclaimedDeviceClaimCode)(nil), "api.UnclaimedDeviceClaimCode")
+	proto.RegisterType((*CreateUnclaimedDevicesResponse)(nil), "api.CreateUnclaimedDevicesResponse")
+	proto.RegisterType((*ListUnclaimedDevicesRequest)(nil), "api.ListUnclaimedDevicesRequest")
+	proto.RegisterType((*UnclaimedDeviceListItem)(nil), "api.UnclaimedDeviceListItem")
+	proto.RegisterType((*ListUnclaimedDevicesResponse)(nil), "api.ListUnclaimedDevicesResponse")
+	proto.RegisterType((*DeleteUnclaimedDeviceRequest)(nil), "api.DeleteUnclaimedDeviceRequest")
+	proto.RegisterType((*DeleteUnclaimedDeviceResponse)(nil), "api.DeleteUnclaimedDeviceResponse")
+	proto.RegisterType((*ClaimDeviceRequest)(nil), "api.ClaimDeviceRequest")
+	proto.RegisterType((*ClaimDeviceResponse)(nil), "api.ClaimDeviceResponse")
+	proto.RegisterEnum("api.JoinThrottleReason", JoinThrottleReason_name, JoinThrottleReason_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DeviceClient is the client API for Device service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DeviceClient interface {
+	// Create creates the given device.
+	Create(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error)
+	// Get returns the device matching the given DevEUI.
+	Get(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
+	// Delete deletes the device matching the given DevEUI.
+	Delete(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
+	// ListByApplicationID lists the devices by the given application ID, sorted by the name of the device.
+	ListByApplicationID(ctx context.Context, in *ListDeviceByApplicationIDRequest, opts ...grpc.CallOption) (*ListDeviceResponse, error)
+	// Update updates the device matching the given DevEUI.
+	Update(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
+	// CreateKeys creates the given device-keys.
+	CreateKeys(ctx context.Context, in *CreateDeviceKeysRequest, opts ...grpc.CallOption) (*CreateDeviceKeysResponse, error)
+	// GetKeys returns the device-keys for the given DevEUI.
+	GetKeys(ctx context.Context, in *GetDeviceKeysRequest, opts ...grpc.CallOption) (*GetDeviceKeysResponse, error)
+	// UpdateKeys updates the device-keys.
+	UpdateKeys(ctx context.Context, in *UpdateDeviceKeysRequest, opts ...grpc.CallOption) (*UpdateDeviceKeysResponse, error)
+	// DeleteKeys deletes the device-keys for the given DevEUI.
+	DeleteKeys(ctx context.Context, in *DeleteDeviceKeysRequest, opts ...grpc.CallOption) (*DeleteDeviceKeysResponse, error)
+	// Activate (re)activates the device (only when ABP is set to true).
+	Activate(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*ActivateDeviceResponse, error)
+	// GetActivation returns the current activation details of the device (OTAA and ABP).
+	GetActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// ListActivations returns the join history of the device, containing the
+	// accepted and rejected (re)join-requests, most recent first.
+	ListActivations(ctx context.Context, in *ListDeviceActivationsRequest, opts ...grpc.CallOption) (*ListDeviceActivationsResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
+	// GetShadow returns the device shadow (desired and reported state) for the given DevEUI.
+	GetShadow(ctx context.Context, in *GetDeviceShadowRequest, opts ...grpc.CallOption) (*GetDeviceShadowResponse, error)
+	// UpdateShadow sets the desired state of the device. The difference with
+	// the reported state is enqueued when the device is next seen.
+	UpdateShadow(ctx context.Context, in *UpdateDeviceShadowRequest, opts ...grpc.CallOption) (*UpdateDeviceShadowResponse, error)
+	// DeleteShadow deletes the device shadow for the given DevEUI.
+	DeleteShadow(ctx context.Context, in *DeleteDeviceShadowRequest, opts ...grpc.CallOption) (*DeleteDeviceShadowResponse, error)
+	// ListThrottledByApplicationID lists the devices of the given application
+	// for which join-requests were throttled within the last 24 hours.
+	ListThrottledByApplicationID(ctx context.Context, in *ListThrottledDevicesRequest, opts ...grpc.CallOption) (*ListThrottledDevicesResponse, error)
+	// CreateUnclaimed adds the given devices to the pool of unclaimed devices.
+	// These devices can be claimed into an application using their claim-code.
+	CreateUnclaimed(ctx context.Context, in *CreateUnclaimedDevicesRequest, opts ...grpc.CallOption) (*CreateUnclaimedDevicesResponse, error)
+	// ListUnclaimed lists the unclaimed devices.
+	ListUnclaimed(ctx context.Context, in *ListUnclaimedDevicesRequest, opts ...grpc.CallOption) (*ListUnclaimedDevicesResponse, error)
+	// DeleteUnclaimed removes the given device from the unclaimed devices.
+	DeleteUnclaimed(ctx context.Context, in *DeleteUnclaimedDeviceRequest, opts ...grpc.CallOption) (*DeleteUnclaimedDeviceResponse, error)
+	// Claim claims the unclaimed device matching the given DevEUI and
+	// claim-code into the given application.
+	Claim(ctx context.Context, in *ClaimDeviceRequest, opts ...grpc.CallOption) (*ClaimDeviceResponse, error)
+	// StreamFrameLogs streams the uplink and downlink frame-logs for the given DevEUI.
+	// Note: these are the raw LoRaWAN frames and this endpoint is intended for debugging.
+	StreamFrameLogs(ctx context.Context, in *StreamDeviceFrameLogsRequest, opts ...grpc.CallOption) (Device_StreamFrameLogsClient, error)
+	// StreamEventLogs stream the device events (uplink payloads, ACKs, joins, errors).
+	// Note: this endpoint is intended for debugging and should not be used for building
 	// integrations.
 	StreamEventLogs(ctx context.Context, in *StreamDeviceEventLogsRequest, opts ...grpc.CallOption) (Device_StreamEventLogsClient, error)
 }
@@ -1733,6 +3031,15 @@ func (c *deviceClient) GetActivation(ctx context.Context, in *GetDeviceActivatio
 	return out, nil
 }
 
+func (c *deviceClient) ListActivations(ctx context.Context, in *ListDeviceActivationsRequest, opts ...grpc.CallOption) (*ListDeviceActivationsResponse, error) {
+	out := new(ListDeviceActivationsResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/ListActivations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deviceClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
 	out := new(GetRandomDevAddrResponse)
 	err := c.cc.Invoke(ctx, "/api.Device/GetRandomDevAddr", in, out, opts...)
@@ -1742,6 +3049,78 @@ func (c *deviceClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAdd
 	return out, nil
 }
 
+func (c *deviceClient) GetShadow(ctx context.Context, in *GetDeviceShadowRequest, opts ...grpc.CallOption) (*GetDeviceShadowResponse, error) {
+	out := new(GetDeviceShadowResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/GetShadow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) UpdateShadow(ctx context.Context, in *UpdateDeviceShadowRequest, opts ...grpc.CallOption) (*UpdateDeviceShadowResponse, error) {
+	out := new(UpdateDeviceShadowResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/UpdateShadow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) DeleteShadow(ctx context.Context, in *DeleteDeviceShadowRequest, opts ...grpc.CallOption) (*DeleteDeviceShadowResponse, error) {
+	out := new(DeleteDeviceShadowResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/DeleteShadow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) ListThrottledByApplicationID(ctx context.Context, in *ListThrottledDevicesRequest, opts ...grpc.CallOption) (*ListThrottledDevicesResponse, error) {
+	out := new(ListThrottledDevicesResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/ListThrottledByApplicationID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) CreateUnclaimed(ctx context.Context, in *CreateUnclaimedDevicesRequest, opts ...grpc.CallOption) (*CreateUnclaimedDevicesResponse, error) {
+	out := new(CreateUnclaimedDevicesResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/CreateUnclaimed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) ListUnclaimed(ctx context.Context, in *ListUnclaimedDevicesRequest, opts ...grpc.CallOption) (*ListUnclaimedDevicesResponse, error) {
+	out := new(ListUnclaimedDevicesResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/ListUnclaimed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) DeleteUnclaimed(ctx context.Context, in *DeleteUnclaimedDeviceRequest, opts ...grpc.CallOption) (*DeleteUnclaimedDeviceResponse, error) {
+	out := new(DeleteUnclaimedDeviceResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/DeleteUnclaimed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceClient) Claim(ctx context.Context, in *ClaimDeviceRequest, opts ...grpc.CallOption) (*ClaimDeviceResponse, error) {
+	out := new(ClaimDeviceResponse)
+	err := c.cc.Invoke(ctx, "/api.Device/Claim", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deviceClient) StreamFrameLogs(ctx context.Context, in *StreamDeviceFrameLogsRequest, opts ...grpc.CallOption) (Device_StreamFrameLogsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Device_serviceDesc.Streams[0], "/api.Device/StreamFrameLogs", opts...)
 	if err != nil {
@@ -1831,8 +3210,31 @@ type DeviceServer interface {
 	Activate(context.Context, *ActivateDeviceRequest) (*ActivateDeviceResponse, error)
 	// GetActivation returns the current activation details of the device (OTAA and ABP).
 	GetActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// ListActivations returns the join history of the device, containing the
+	// accepted and rejected (re)join-requests, most recent first.
+	ListActivations(context.Context, *ListDeviceActivationsRequest) (*ListDeviceActivationsResponse, error)
 	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
 	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
+	// GetShadow returns the device shadow (desired and reported state) for the given DevEUI.
+	GetShadow(context.Context, *GetDeviceShadowRequest) (*GetDeviceShadowResponse, error)
+	// UpdateShadow sets the desired state of the device. The difference with
+	// the reported state is enqueued when the device is next seen.
+	UpdateShadow(context.Context, *UpdateDeviceShadowRequest) (*UpdateDeviceShadowResponse, error)
+	// DeleteShadow deletes the device shadow for the given DevEUI.
+	DeleteShadow(context.Context, *DeleteDeviceShadowRequest) (*DeleteDeviceShadowResponse, error)
+	// ListThrottledByApplicationID lists the devices of the given application
+	// for which join-requests were throttled within the last 24 hours.
+	ListThrottledByApplicationID(context.Context, *ListThrottledDevicesRequest) (*ListThrottledDevicesResponse, error)
+	// CreateUnclaimed adds the given devices to the pool of unclaimed devices.
+	// These devices can be claimed into an application using their claim-code.
+	CreateUnclaimed(context.Context, *CreateUnclaimedDevicesRequest) (*CreateUnclaimedDevicesResponse, error)
+	// ListUnclaimed lists the unclaimed devices.
+	ListUnclaimed(context.Context, *ListUnclaimedDevicesRequest) (*ListUnclaimedDevicesResponse, error)
+	// DeleteUnclaimed removes the given device from the unclaimed devices.
+	DeleteUnclaimed(context.Context, *DeleteUnclaimedDeviceRequest) (*DeleteUnclaimedDeviceResponse, error)
+	// Claim claims the unclaimed device matching the given DevEUI and
+	// claim-code into the given application.
+	Claim(context.Context, *ClaimDeviceRequest) (*ClaimDeviceResponse, error)
 	// StreamFrameLogs streams the uplink and downlink frame-logs for the given DevEUI.
 	// Note: these are the raw LoRaWAN frames and this endpoint is intended for debugging.
 	StreamFrameLogs(*StreamDeviceFrameLogsRequest, Device_StreamFrameLogsServer) error
@@ -2044,6 +3446,24 @@ func _Device_GetActivation_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Device_ListActivations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceActivationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).ListActivations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/ListActivations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).ListActivations(ctx, req.(*ListDeviceActivationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Device_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRandomDevAddrRequest)
 	if err := dec(in); err != nil {
@@ -2062,6 +3482,150 @@ func _Device_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Device_GetShadow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceShadowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).GetShadow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/GetShadow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).GetShadow(ctx, req.(*GetDeviceShadowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_UpdateShadow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceShadowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).UpdateShadow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/UpdateShadow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).UpdateShadow(ctx, req.(*UpdateDeviceShadowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_DeleteShadow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceShadowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).DeleteShadow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/DeleteShadow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).DeleteShadow(ctx, req.(*DeleteDeviceShadowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_ListThrottledByApplicationID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThrottledDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).ListThrottledByApplicationID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/ListThrottledByApplicationID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).ListThrottledByApplicationID(ctx, req.(*ListThrottledDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_CreateUnclaimed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUnclaimedDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).CreateUnclaimed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/CreateUnclaimed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).CreateUnclaimed(ctx, req.(*CreateUnclaimedDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_ListUnclaimed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnclaimedDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).ListUnclaimed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/ListUnclaimed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).ListUnclaimed(ctx, req.(*ListUnclaimedDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_DeleteUnclaimed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUnclaimedDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).DeleteUnclaimed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/DeleteUnclaimed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).DeleteUnclaimed(ctx, req.(*DeleteUnclaimedDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Device_Claim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).Claim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Device/Claim",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).Claim(ctx, req.(*ClaimDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Device_StreamFrameLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamDeviceFrameLogsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2152,10 +3716,46 @@ var _Device_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetActivation",
 			Handler:    _Device_GetActivation_Handler,
 		},
+		{
+			MethodName: "ListActivations",
+			Handler:    _Device_ListActivations_Handler,
+		},
 		{
 			MethodName: "GetRandomDevAddr",
 			Handler:    _Device_GetRandomDevAddr_Handler,
 		},
+		{
+			MethodName: "GetShadow",
+			Handler:    _Device_GetShadow_Handler,
+		},
+		{
+			MethodName: "UpdateShadow",
+			Handler:    _Device_UpdateShadow_Handler,
+		},
+		{
+			MethodName: "DeleteShadow",
+			Handler:    _Device_DeleteShadow_Handler,
+		},
+		{
+			MethodName: "ListThrottledByApplicationID",
+			Handler:    _Device_ListThrottledByApplicationID_Handler,
+		},
+		{
+			MethodName: "CreateUnclaimed",
+			Handler:    _Device_CreateUnclaimed_Handler,
+		},
+		{
+			MethodName: "ListUnclaimed",
+			Handler:    _Device_ListUnclaimed_Handler,
+		},
+		{
+			MethodName: "DeleteUnclaimed",
+			Handler:    _Device_DeleteUnclaimed_Handler,
+		},
+		{
+			MethodName: "Claim",
+			Handler:    _Device_Claim_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2172,86 +3772,145 @@ var _Device_serviceDesc = grpc.ServiceDesc{
 	Metadata: "device.proto",
 }
 
-func init() { proto.RegisterFile("device.proto", fileDescriptor_device_e3b1e4891c217207) }
-
-var fileDescriptor_device_e3b1e4891c217207 = []byte{
-	// 1245 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xd7, 0xc6, 0x89, 0x9b, 0xbc, 0xfc, 0x23, 0xe3, 0xd8, 0xde, 0x6e, 0x93, 0xd4, 0x2c, 0x29,
-	0x72, 0x53, 0xb0, 0xd3, 0x50, 0x81, 0x84, 0xc4, 0x21, 0x8d, 0xdb, 0x10, 0x1a, 0x0a, 0x5a, 0x2b,
-	0x9c, 0x90, 0xd0, 0xc4, 0x3b, 0x71, 0x57, 0xb1, 0x77, 0x97, 0xdd, 0x49, 0x22, 0xab, 0x54, 0x20,
-	0x4e, 0x9c, 0xb8, 0x70, 0xe0, 0x53, 0xf0, 0x31, 0xf8, 0x04, 0x1c, 0x50, 0x2f, 0x9c, 0xf8, 0x20,
-	0x68, 0xfe, 0xd8, 0x9e, 0xdd, 0x9d, 0xb5, 0xcd, 0x01, 0xa9, 0xe2, 0xe6, 0x79, 0xef, 0xcd, 0xfc,
-	0xde, 0xef, 0xbd, 0x37, 0xef, 0xcd, 0x1a, 0x56, 0x5c, 0x72, 0xed, 0x75, 0x48, 0x23, 0x8c, 0x02,
-	0x1a, 0xa0, 0x02, 0x0e, 0x3d, 0x6b, 0xab, 0x1b, 0x04, 0xdd, 0x1e, 0x69, 0xe2, 0xd0, 0x6b, 0x62,
-	0xdf, 0x0f, 0x28, 0xa6, 0x5e, 0xe0, 0xc7, 0xc2, 0xc4, 0x5a, 0xe9, 0x04, 0xfd, 0x7e, 0xe0, 0x8b,
-	0x95, 0xbd, 0x0b, 0xd0, 0xe2, 0x07, 0x3c, 0x23, 0x83, 0x18, 0x55, 0xa0, 0x88, 0xc3, 0xf0, 0x19,
-	0x19, 0x98, 0x46, 0xcd, 0xa8, 0x2f, 0x39, 0x72, 0x65, 0xbf, 0x36, 0xa0, 0x74, 0x14, 0x11, 0x4c,
-	0x89, 0x30, 0x76, 0xc8, 0xb7, 0x57, 0x24, 0xa6, 0xcc, 0xde, 0x25, 0xd7, 0x4f, 0xce, 0x4e, 0x86,
-	0xf6, 0x62, 0x85, 0x10, 0xcc, 0xfb, 0xb8, 0x4f, 0xcc, 0x25, 0x2e, 0xe5, 0xbf, 0xd1, 0x2e, 0xac,
-	0xe2, 0x30, 0xec, 0x79, 0x1d, 0xee, 0xcd, 0x49, 0xcb, 0x5c, 0xad, 0x19, 0xf5, 0x82, 0x93, 0x14,
-	0xa2, 0x1a, 0x2c, 0xbb, 0x24, 0xee, 0x44, 0x5e, 0xc8, 0x04, 0xe6, 0x1a, 0x3f, 0x40, 0x15, 0xa1,
-	0x3a, 0xac, 0x0b, 0xca, 0x5f, 0x46, 0xc1, 0x85, 0xd7, 0x23, 0x27, 0x2d, 0x13, 0x71, 0xab, 0xb4,
-	0x98, 0x21, 0xc6, 0x97, 0x5e, 0xf8, 0xf4, 0xc8, 0xa7, 0x47, 0x2f, 0x48, 0xe7, 0xd2, 0x2c, 0xd5,
-	0x8c, 0xfa, 0xa2, 0x93, 0x14, 0xda, 0x15, 0xd8, 0x4c, 0x52, 0x8b, 0xc3, 0xc0, 0x8f, 0x89, 0xbd,
-	0x07, 0x6f, 0x1d, 0x13, 0x3a, 0x13, 0x5f, 0xfb, 0xf5, 0x1c, 0x6c, 0x28, 0xc6, 0xe2, 0x84, 0x37,
-	0x3c, 0x3a, 0xfb, 0x50, 0x12, 0xa2, 0x36, 0xc5, 0xf4, 0x2a, 0x7e, 0x8c, 0x29, 0x25, 0xd1, 0x80,
-	0xc7, 0x68, 0xd5, 0xd1, 0xa9, 0x50, 0x03, 0x90, 0x2a, 0xfe, 0x1c, 0x47, 0x5d, 0xcf, 0x37, 0x37,
-	0x6b, 0x46, 0x7d, 0xc1, 0xd1, 0x68, 0xd0, 0x0e, 0x40, 0x0f, 0xc7, 0xb4, 0x4d, 0x88, 0x7f, 0x48,
-	0xcd, 0x32, 0x77, 0x43, 0x91, 0x64, 0xf3, 0x53, 0xd1, 0xe5, 0xe7, 0x7d, 0x28, 0xb5, 0x48, 0x8f,
-	0xcc, 0x58, 0x7a, 0x2c, 0x9d, 0x49, 0x73, 0x99, 0xce, 0x9f, 0x0d, 0xa8, 0x9d, 0x7a, 0xb1, 0xcc,
-	0xd1, 0xe3, 0xc1, 0xa1, 0x1a, 0xd8, 0xe1, 0xa1, 0x99, 0x2c, 0x14, 0x74, 0x59, 0xd8, 0x84, 0x85,
-	0x9e, 0xd7, 0xf7, 0x28, 0x47, 0x2e, 0x38, 0x62, 0xc1, 0x1c, 0x0a, 0x2e, 0x2e, 0x62, 0x42, 0xcd,
-	0x39, 0x2e, 0x96, 0x2b, 0x26, 0x8f, 0x09, 0x8e, 0x3a, 0x2f, 0xcc, 0x79, 0xe1, 0xa8, 0x58, 0xd9,
-	0x7f, 0xcd, 0xc1, 0x9a, 0x70, 0x86, 0xb9, 0x75, 0x42, 0x49, 0xff, 0x0d, 0x2f, 0x98, 0xf7, 0x60,
-	0x23, 0x21, 0x7a, 0xce, 0x5c, 0x2a, 0x71, 0xdb, 0xac, 0x22, 0xaf, 0xbc, 0x36, 0xff, 0x6d, 0x79,
-	0x95, 0x67, 0x2c, 0xaf, 0x4a, 0xba, 0xbc, 0x6c, 0x0c, 0x68, 0x9c, 0xf0, 0xd1, 0xa5, 0xdc, 0x01,
-	0xa0, 0x01, 0xc5, 0xbd, 0xa3, 0xe0, 0xca, 0x1f, 0x66, 0x50, 0x91, 0xa0, 0x07, 0x50, 0x8c, 0x48,
-	0x7c, 0xd5, 0x63, 0x69, 0x2c, 0xd4, 0x97, 0x0f, 0x4a, 0x0d, 0x1c, 0x7a, 0x8d, 0x64, 0xa2, 0x1c,
-	0x69, 0xc2, 0xfb, 0xe2, 0x59, 0xe8, 0xfe, 0x5f, 0xfb, 0x62, 0x92, 0x9a, 0xbc, 0x48, 0xe7, 0x50,
-	0x55, 0xfb, 0x25, 0x9b, 0x1b, 0xd3, 0x68, 0x37, 0x01, 0xdc, 0x91, 0x31, 0xbf, 0x1e, 0xcb, 0x07,
-	0xeb, 0x4a, 0x5c, 0xf9, 0x19, 0x8a, 0x89, 0x6d, 0x81, 0x99, 0xc5, 0x90, 0xf8, 0x0d, 0xd8, 0x1c,
-	0xb5, 0xda, 0x19, 0xc0, 0xed, 0x4f, 0xa1, 0x9c, 0xb2, 0x97, 0x95, 0x90, 0xf4, 0xca, 0x98, 0xee,
-	0xd5, 0x39, 0x54, 0xd5, 0x88, 0xfc, 0x57, 0xcc, 0xb3, 0x18, 0x92, 0xf9, 0x43, 0xa8, 0xaa, 0xad,
-	0x6d, 0x16, 0xf2, 0x16, 0x98, 0xd9, 0x2d, 0xf2, 0xb8, 0x3f, 0x0d, 0x28, 0x1f, 0x76, 0xa8, 0x77,
-	0x3d, 0x73, 0xf9, 0x9a, 0x70, 0xcb, 0x25, 0xd7, 0x87, 0xae, 0x1b, 0x71, 0x2a, 0x4b, 0xce, 0x70,
-	0xc9, 0x34, 0x38, 0x0c, 0xdb, 0xec, 0xe5, 0x50, 0x10, 0x1a, 0xb9, 0x64, 0x1a, 0xff, 0xe6, 0x92,
-	0x6b, 0x44, 0xff, 0x1b, 0x2e, 0x19, 0xca, 0xc5, 0x91, 0x4f, 0xcf, 0x42, 0x73, 0x81, 0x37, 0x05,
-	0xb9, 0x42, 0x16, 0x2c, 0xb2, 0x5f, 0xad, 0xe0, 0xc6, 0x37, 0x8b, 0x5c, 0x33, 0x5a, 0x67, 0x4b,
-	0xf7, 0x96, 0xae, 0x74, 0x4d, 0xa8, 0xa4, 0x89, 0x49, 0xce, 0x8f, 0xc0, 0x1a, 0x15, 0x83, 0x34,
-	0xf1, 0x02, 0x7f, 0x5a, 0x14, 0x7f, 0x37, 0xe0, 0x8e, 0x76, 0x9b, 0xac, 0x24, 0x25, 0x2e, 0x46,
-	0x6e, 0x5c, 0xe6, 0x72, 0xe3, 0x52, 0xc8, 0x8b, 0xcb, 0x7c, 0x6e, 0x5c, 0x16, 0xa6, 0xc5, 0xa5,
-	0xa8, 0x8b, 0xcb, 0x43, 0xa8, 0x1e, 0x13, 0xea, 0x60, 0xdf, 0x0d, 0xfa, 0x2d, 0xe1, 0xe1, 0x34,
-	0xea, 0x8f, 0xc0, 0xcc, 0x6e, 0x99, 0x46, 0xdb, 0xfe, 0x10, 0xb6, 0xda, 0x34, 0x22, 0xb8, 0x2f,
-	0x42, 0xf6, 0x34, 0xc2, 0x7d, 0x72, 0x1a, 0x74, 0xa7, 0x96, 0xeb, 0xaf, 0x06, 0x6c, 0xe7, 0x6c,
-	0x94, 0x98, 0x1f, 0xc1, 0xca, 0x55, 0xd8, 0xf3, 0xfc, 0x4b, 0xae, 0x62, 0xd7, 0x76, 0xdc, 0xa4,
-	0xcf, 0xc6, 0x8a, 0xd3, 0xa0, 0xeb, 0x24, 0x0c, 0xd1, 0x27, 0xb0, 0xe6, 0x06, 0x37, 0xbe, 0xb2,
-	0x55, 0xf4, 0xf7, 0xb2, 0xb8, 0x8d, 0xaa, 0x8a, 0x6d, 0x4e, 0x19, 0xa7, 0x19, 0x3d, 0xb9, 0x26,
-	0x3e, 0x9d, 0x85, 0xd1, 0x59, 0x92, 0x90, 0xb2, 0x4f, 0x12, 0x42, 0x30, 0x4f, 0x07, 0x21, 0x91,
-	0xdb, 0xf8, 0x6f, 0xd6, 0xec, 0x43, 0x3c, 0xe8, 0x05, 0xd8, 0xfd, 0xac, 0xfd, 0xc5, 0x73, 0x59,
-	0x39, 0xaa, 0xe8, 0xe0, 0xb7, 0x15, 0x28, 0x8a, 0x13, 0xd1, 0x57, 0x50, 0x14, 0xbd, 0x12, 0x99,
-	0x9c, 0x8a, 0xe6, 0x9d, 0x6e, 0xdd, 0xd6, 0x68, 0xe4, 0x8d, 0xa8, 0xfe, 0xf8, 0xc7, 0xdf, 0xbf,
-	0xcc, 0x6d, 0xd8, 0x2b, 0xfc, 0x73, 0x41, 0x74, 0xa2, 0xf8, 0x63, 0x63, 0x0f, 0xb5, 0xa1, 0x70,
-	0x4c, 0x28, 0x12, 0xf1, 0x49, 0xbf, 0x84, 0xad, 0x4a, 0x5a, 0x2c, 0x8f, 0xdb, 0xe6, 0xc7, 0x55,
-	0x51, 0x59, 0x3d, 0xae, 0xf9, 0x52, 0x44, 0xe3, 0x15, 0xfa, 0x9a, 0xb9, 0xcd, 0xfa, 0x91, 0x74,
-	0x56, 0xf3, 0xb2, 0x93, 0xce, 0x6a, 0x1f, 0x71, 0xf2, 0xf4, 0xbd, 0x9c, 0xd3, 0x7f, 0x32, 0xa0,
-	0xc4, 0x66, 0x74, 0xea, 0x75, 0x87, 0xee, 0xf1, 0x13, 0xa7, 0xbd, 0xfe, 0xac, 0x6a, 0xca, 0x6c,
-	0xdc, 0x78, 0x39, 0xec, 0x03, 0x74, 0x5f, 0x7c, 0x52, 0x8d, 0xf7, 0xc6, 0xcd, 0x97, 0x89, 0x31,
-	0xfd, 0x6a, 0xe8, 0x13, 0xfa, 0x06, 0x8a, 0xa2, 0x8f, 0x4b, 0xa2, 0x9a, 0x57, 0x82, 0x24, 0xaa,
-	0x1d, 0xb2, 0x35, 0x8e, 0x68, 0x59, 0x7a, 0xa2, 0x2c, 0x3d, 0x21, 0x80, 0xc8, 0x27, 0xff, 0x70,
-	0xdb, 0xca, 0x24, 0x58, 0x99, 0x0e, 0xd6, 0x76, 0x8e, 0x56, 0x82, 0xdd, 0xe3, 0x60, 0x77, 0x6d,
-	0x4b, 0x0b, 0xd6, 0xbc, 0x24, 0x03, 0x5e, 0x10, 0x2e, 0xdc, 0x3a, 0x26, 0x94, 0xc3, 0xdd, 0x4e,
-	0x66, 0x5f, 0xc5, 0xb2, 0x74, 0x2a, 0x09, 0x64, 0x73, 0xa0, 0x2d, 0x34, 0x01, 0x88, 0xf1, 0x12,
-	0x11, 0x51, 0x78, 0xe5, 0x4c, 0x5d, 0xc9, 0x2b, 0x77, 0x5e, 0x4a, 0x5e, 0xd6, 0x14, 0x5e, 0x7d,
-	0xf6, 0x09, 0xcc, 0x8a, 0x4d, 0x41, 0xcc, 0x99, 0xb3, 0x12, 0x31, 0x77, 0xa4, 0x4a, 0x82, 0x7b,
-	0x93, 0x08, 0xfa, 0xb0, 0x38, 0x1c, 0x4e, 0x48, 0x04, 0x4b, 0x3b, 0x84, 0xad, 0x3b, 0x5a, 0x9d,
-	0x04, 0xba, 0xcf, 0x81, 0xde, 0xb1, 0x77, 0xf4, 0x40, 0x58, 0xee, 0x62, 0xf4, 0xbe, 0x83, 0xd5,
-	0x63, 0x42, 0xc7, 0x53, 0x0b, 0xdd, 0x4d, 0x66, 0x28, 0x33, 0x06, 0xad, 0x5a, 0xbe, 0x81, 0x84,
-	0xaf, 0x73, 0x78, 0x1b, 0xd5, 0x26, 0xc2, 0x33, 0xb0, 0xef, 0xf9, 0x57, 0x74, 0x62, 0x7e, 0xc8,
-	0x10, 0xe7, 0x4c, 0x22, 0x19, 0xe2, 0xbc, 0xa1, 0x63, 0x37, 0x38, 0x74, 0xdd, 0x7e, 0x57, 0x0f,
-	0xdd, 0x4d, 0x83, 0xfd, 0x60, 0xc0, 0xba, 0xe8, 0xc0, 0xa3, 0x61, 0x82, 0xde, 0xe6, 0x10, 0x93,
-	0x26, 0x94, 0x65, 0x4f, 0x32, 0x91, 0xae, 0xec, 0x72, 0x57, 0x76, 0xd0, 0x96, 0xde, 0x95, 0x0b,
-	0x3e, 0x39, 0xf6, 0x0d, 0xc5, 0x85, 0x51, 0xfb, 0xd7, 0xb8, 0x90, 0x1e, 0x29, 0x1a, 0x17, 0x32,
-	0xd3, 0x63, 0x9a, 0x0b, 0x84, 0x6d, 0x88, 0xf7, 0x8d, 0xf3, 0x22, 0xff, 0xb7, 0xe7, 0x83, 0x7f,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xa0, 0x47, 0xbd, 0x38, 0x2e, 0x12, 0x00, 0x00,
+func init() { proto.RegisterFile("device.proto", fileDescriptor_device_3e317a9d2c4ed9fe) }
+
+var fileDescriptor_device_3e317a9d2c4ed9fe = []byte{
+	// 2180 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x5b, 0x6f, 0xdb, 0xc8,
+	0x15, 0x2e, 0x25, 0xdb, 0xb1, 0x4f, 0x7c, 0xcb, 0xf8, 0x22, 0x9a, 0x96, 0x6c, 0x79, 0x76, 0x37,
+	0xf5, 0x7a, 0x1b, 0x3b, 0x17, 0x27, 0x5b, 0x6c, 0xd1, 0x07, 0xad, 0xed, 0xb8, 0xde, 0x38, 0xd9,
+	0x80, 0xb2, 0xb7, 0x40, 0x51, 0x20, 0x60, 0xc4, 0xb1, 0xc2, 0x5a, 0x22, 0x59, 0x72, 0x6c, 0x57,
+	0xd8, 0x06, 0xbd, 0x3e, 0xb4, 0x40, 0xd1, 0x87, 0xf6, 0xa1, 0x0f, 0x7d, 0x29, 0xfa, 0x5f, 0x0a,
+	0x14, 0xfb, 0xda, 0x87, 0xfe, 0x81, 0xfe, 0x90, 0x62, 0x2e, 0xa4, 0x78, 0x19, 0x8a, 0x5a, 0x20,
+	0x05, 0x16, 0xe8, 0x9b, 0xe6, 0x9c, 0x33, 0xf3, 0x9d, 0xcb, 0x37, 0x73, 0x86, 0x63, 0xc3, 0xac,
+	0x4d, 0xae, 0x9d, 0x0e, 0xd9, 0xf5, 0x03, 0x8f, 0x7a, 0xa8, 0x6a, 0xf9, 0x8e, 0x51, 0xef, 0x7a,
+	0x5e, 0xb7, 0x47, 0xf6, 0x2c, 0xdf, 0xd9, 0xb3, 0x5c, 0xd7, 0xa3, 0x16, 0x75, 0x3c, 0x37, 0x14,
+	0x26, 0xc6, 0x6c, 0xc7, 0xeb, 0xf7, 0x3d, 0x57, 0x8c, 0xf0, 0x8f, 0x00, 0x0e, 0xf9, 0x02, 0xcf,
+	0xc8, 0x20, 0x44, 0xab, 0x30, 0x65, 0xf9, 0xfe, 0x33, 0x32, 0xd0, 0xb5, 0xa6, 0xb6, 0x3d, 0x63,
+	0xca, 0x11, 0x93, 0xbb, 0x37, 0x97, 0x4c, 0x5e, 0x11, 0x72, 0x31, 0x42, 0x75, 0x98, 0xe9, 0x12,
+	0xb7, 0x25, 0xa6, 0x54, 0xb9, 0x6a, 0x28, 0xc0, 0x5f, 0x55, 0x60, 0xe9, 0x20, 0x20, 0x16, 0x25,
+	0x02, 0xc2, 0x24, 0x3f, 0xbd, 0x22, 0x21, 0x65, 0xab, 0xd9, 0xe4, 0xfa, 0xe8, 0xfc, 0x24, 0x42,
+	0x11, 0x23, 0x84, 0x60, 0xc2, 0xb5, 0xfa, 0x44, 0x9f, 0xe1, 0x52, 0xfe, 0x1b, 0xbd, 0x0f, 0x73,
+	0x96, 0xef, 0xf7, 0x9c, 0x0e, 0x8f, 0xe1, 0xe4, 0x50, 0x9f, 0x6b, 0x6a, 0xdb, 0x55, 0x33, 0x2d,
+	0x44, 0x4d, 0xb8, 0x6d, 0x93, 0xb0, 0x13, 0x38, 0x3e, 0x13, 0xe8, 0xf3, 0x7c, 0x81, 0xa4, 0x08,
+	0x6d, 0xc3, 0x82, 0x48, 0xd4, 0xcb, 0xc0, 0xbb, 0x70, 0x7a, 0xe4, 0xe4, 0x50, 0x47, 0xdc, 0x2a,
+	0x2b, 0x66, 0x88, 0xe1, 0xa5, 0xe3, 0x3f, 0x3d, 0x70, 0xe9, 0xc1, 0x1b, 0xd2, 0xb9, 0xd4, 0x97,
+	0x9a, 0xda, 0xf6, 0xb4, 0x99, 0x16, 0xa2, 0x27, 0x30, 0x41, 0xad, 0x6e, 0xa8, 0x2f, 0x37, 0xab,
+	0xdb, 0xb7, 0x1f, 0xe2, 0x5d, 0xcb, 0x77, 0x76, 0x15, 0xb1, 0xee, 0x9e, 0x59, 0xdd, 0xf0, 0xc8,
+	0xa5, 0xc1, 0xc0, 0xe4, 0xf6, 0xc6, 0xc7, 0x30, 0x13, 0x8b, 0xd0, 0x22, 0x54, 0x2f, 0xe3, 0x5c,
+	0xb3, 0x9f, 0x68, 0x19, 0x26, 0xaf, 0xad, 0xde, 0x15, 0x91, 0x79, 0x16, 0x83, 0x4f, 0x2a, 0xdf,
+	0xd5, 0xf0, 0x2a, 0x2c, 0xa7, 0xd7, 0x0f, 0x7d, 0xcf, 0x0d, 0x09, 0xde, 0x81, 0xc5, 0x63, 0x42,
+	0xc7, 0x4a, 0x30, 0xfe, 0x67, 0x15, 0xee, 0x24, 0x8c, 0xc5, 0x0a, 0xdf, 0xf0, 0x72, 0xdc, 0x87,
+	0x25, 0x21, 0x6a, 0x53, 0x8b, 0x5e, 0x85, 0x9f, 0x5a, 0x94, 0x92, 0x60, 0xc0, 0x8b, 0x32, 0x67,
+	0xaa, 0x54, 0x68, 0x17, 0x50, 0x52, 0xfc, 0xdc, 0x0a, 0xba, 0x8e, 0xab, 0x2f, 0x37, 0xb5, 0xed,
+	0x49, 0x53, 0xa1, 0x41, 0x1b, 0x00, 0x3d, 0x2b, 0xa4, 0x6d, 0x42, 0xdc, 0x16, 0xd5, 0x57, 0xb8,
+	0x1b, 0x09, 0x49, 0x9e, 0x10, 0xab, 0x2a, 0x42, 0xec, 0x4b, 0x42, 0xd4, 0x38, 0x21, 0x9a, 0x9c,
+	0x10, 0xb9, 0x5c, 0xbf, 0x3b, 0x3a, 0xdc, 0x83, 0xa5, 0x43, 0xd2, 0x23, 0x63, 0x6e, 0x2d, 0xc6,
+	0x9e, 0xb4, 0xb9, 0x64, 0xcf, 0x1f, 0x35, 0x68, 0x9e, 0x3a, 0xa1, 0x74, 0xf3, 0xd3, 0x41, 0x2b,
+	0x59, 0xc7, 0x68, 0xd1, 0x5c, 0xd1, 0xab, 0xaa, 0xa2, 0x2f, 0xc3, 0x64, 0xcf, 0xe9, 0x3b, 0x94,
+	0x23, 0x57, 0x4d, 0x31, 0x60, 0x0e, 0x79, 0x17, 0x17, 0x21, 0xa1, 0x3c, 0x84, 0xaa, 0x29, 0x47,
+	0x4c, 0x1e, 0x12, 0x2b, 0xe8, 0xbc, 0xd1, 0x27, 0x84, 0xa3, 0x62, 0x84, 0xbf, 0xaa, 0xc2, 0xbc,
+	0x70, 0x86, 0xb9, 0x75, 0x42, 0x49, 0xff, 0x1b, 0xce, 0xcf, 0xef, 0xc0, 0x9d, 0x94, 0xe8, 0x05,
+	0x73, 0x69, 0x89, 0xdb, 0xe6, 0x15, 0x45, 0x6c, 0x5e, 0xfe, 0xba, 0x6c, 0x5e, 0x19, 0x93, 0xcd,
+	0xab, 0x39, 0x36, 0x3f, 0x48, 0xf1, 0xb4, 0xc1, 0x79, 0x9a, 0x4e, 0xf8, 0xbb, 0x23, 0xa9, 0x05,
+	0x68, 0x48, 0xae, 0xf8, 0xbc, 0xd9, 0x00, 0xa0, 0x1e, 0xb5, 0x7a, 0x07, 0xde, 0x95, 0x1b, 0xb1,
+	0x25, 0x21, 0x41, 0x1f, 0xc1, 0x54, 0x40, 0xc2, 0xab, 0x1e, 0xa3, 0x0c, 0xf3, 0x71, 0x49, 0xe1,
+	0xa3, 0x29, 0x4d, 0x78, 0x8f, 0x39, 0xf7, 0xed, 0xff, 0x9b, 0x1e, 0xa3, 0x88, 0xf5, 0x9d, 0xf6,
+	0x98, 0xf4, 0xfa, 0xf2, 0x94, 0x78, 0x0d, 0xb5, 0x64, 0xef, 0x61, 0x57, 0x85, 0xb2, 0x3c, 0xef,
+	0x01, 0xd8, 0xb1, 0x31, 0x47, 0xba, 0xfd, 0x70, 0x21, 0x51, 0x48, 0xbe, 0x46, 0xc2, 0x04, 0x1b,
+	0xa0, 0xe7, 0x31, 0x24, 0xfe, 0x2e, 0x2c, 0xc7, 0x47, 0xe9, 0x18, 0xe0, 0xf8, 0x07, 0xb0, 0x92,
+	0xb1, 0x97, 0xd4, 0x4b, 0x7b, 0xa5, 0x95, 0x7b, 0xf5, 0x1a, 0x6a, 0xc9, 0x8c, 0xfc, 0xaf, 0x22,
+	0xcf, 0x63, 0xc8, 0xc8, 0x1f, 0x40, 0x2d, 0x79, 0x6e, 0x8f, 0x13, 0xbc, 0x01, 0x7a, 0x7e, 0x8a,
+	0x5c, 0xee, 0xdf, 0x1a, 0xac, 0xb4, 0x3a, 0xd4, 0xb9, 0x1e, 0x7b, 0xbf, 0xe8, 0x70, 0xcb, 0x26,
+	0xd7, 0x2d, 0xdb, 0x0e, 0x24, 0x5d, 0xa2, 0x21, 0xd3, 0x58, 0xbe, 0xdf, 0x1e, 0xde, 0xfc, 0xa2,
+	0x21, 0xd3, 0xb8, 0x37, 0x97, 0x5c, 0x23, 0x0e, 0xf7, 0x68, 0xc8, 0x50, 0x2e, 0x0e, 0x5c, 0x7a,
+	0xee, 0xeb, 0x93, 0xfc, 0xc4, 0x93, 0x23, 0x64, 0xc0, 0x34, 0xfb, 0x75, 0xe8, 0xdd, 0xb8, 0xfa,
+	0x14, 0xd7, 0xc4, 0xe3, 0xfc, 0x5e, 0xb9, 0xa5, 0xd8, 0x2b, 0x58, 0x87, 0xd5, 0x6c, 0x60, 0x32,
+	0xe6, 0x7d, 0x30, 0x62, 0x32, 0x48, 0x13, 0xc7, 0x73, 0xcb, 0xb2, 0xf8, 0x0f, 0x0d, 0xd6, 0x95,
+	0xd3, 0x24, 0x93, 0x12, 0x79, 0xd1, 0x0a, 0xf3, 0x52, 0x29, 0xcc, 0x4b, 0xb5, 0x28, 0x2f, 0x13,
+	0x85, 0x79, 0x99, 0x2c, 0xcb, 0xcb, 0x94, 0x2a, 0x2f, 0x36, 0xd4, 0x87, 0x47, 0xf0, 0x30, 0x8e,
+	0x52, 0x16, 0xc7, 0xdd, 0xbc, 0xa2, 0xee, 0xe6, 0xd5, 0x64, 0x37, 0xc7, 0x7f, 0xa8, 0x80, 0x9e,
+	0x85, 0x88, 0xfb, 0x77, 0x1d, 0x66, 0x3a, 0x7c, 0x67, 0xdb, 0x2d, 0x2a, 0x51, 0x86, 0x02, 0x76,
+	0xac, 0xf6, 0x49, 0x18, 0x5a, 0x5d, 0x72, 0x36, 0xf0, 0xa3, 0x33, 0x29, 0x29, 0x62, 0x49, 0xb0,
+	0xc9, 0xf5, 0x0b, 0xcf, 0xed, 0x10, 0x0e, 0x3b, 0x67, 0xc6, 0x63, 0xb6, 0xf6, 0x4f, 0x3c, 0xc7,
+	0x15, 0x4a, 0x91, 0xbb, 0xa1, 0x20, 0x59, 0xa4, 0xc9, 0x74, 0x91, 0x0c, 0x98, 0x0e, 0x89, 0x6b,
+	0x93, 0xe0, 0xe4, 0x90, 0xe7, 0x6d, 0xc6, 0x8c, 0xc7, 0xac, 0x3f, 0x89, 0xe6, 0x72, 0xe0, 0xd9,
+	0x84, 0xb3, 0x6d, 0xc6, 0x4c, 0x48, 0xb2, 0x8d, 0x60, 0x3a, 0xd7, 0x08, 0xf0, 0x35, 0x34, 0x0a,
+	0x92, 0x3e, 0x66, 0x0b, 0x7c, 0x9c, 0x69, 0x81, 0xc9, 0x36, 0x9d, 0xcf, 0x70, 0xdc, 0x0c, 0x1f,
+	0x40, 0xed, 0x98, 0x50, 0xd3, 0x72, 0x6d, 0xaf, 0x7f, 0x28, 0x22, 0x2d, 0xe3, 0xf9, 0x3e, 0xe8,
+	0xf9, 0x29, 0x65, 0x1c, 0xc7, 0x4f, 0xa0, 0xde, 0xa6, 0x01, 0xb1, 0xfa, 0xc2, 0xa5, 0xa7, 0x81,
+	0xd5, 0x27, 0xa7, 0x5e, 0xb7, 0xf4, 0x6c, 0xfa, 0x8b, 0x06, 0x8d, 0x82, 0x89, 0x12, 0xf3, 0x63,
+	0x98, 0xbd, 0xf2, 0x7b, 0x8e, 0x7b, 0xc9, 0x55, 0xec, 0x8c, 0x1e, 0x5e, 0x01, 0xce, 0x87, 0x8a,
+	0x53, 0xaf, 0x6b, 0xa6, 0x0c, 0xd1, 0xf7, 0x61, 0xde, 0xf6, 0x6e, 0xdc, 0xc4, 0x54, 0x91, 0xba,
+	0x15, 0x91, 0xba, 0xa4, 0x8a, 0x4d, 0xce, 0x18, 0x67, 0x23, 0x3a, 0xba, 0x26, 0x2e, 0x1d, 0x27,
+	0xa2, 0x73, 0x68, 0x14, 0xcc, 0x93, 0x01, 0x21, 0x98, 0xa0, 0x8c, 0xd8, 0x62, 0x1a, 0xff, 0xcd,
+	0x18, 0xe4, 0x5b, 0x83, 0x9e, 0x67, 0xd9, 0x9f, 0xb5, 0x3f, 0x7f, 0x11, 0x71, 0x3e, 0x21, 0xc2,
+	0xf7, 0x61, 0x35, 0x3e, 0x7d, 0xda, 0x6f, 0x2c, 0xdb, 0xbb, 0x29, 0x73, 0xe4, 0xaf, 0x15, 0xa8,
+	0xe5, 0xa6, 0x48, 0x1f, 0x04, 0x63, 0x9d, 0x80, 0x08, 0x3c, 0x2d, 0x66, 0x6c, 0x24, 0x42, 0x77,
+	0x61, 0x5e, 0x0e, 0xbf, 0x20, 0x41, 0xc8, 0x68, 0x2d, 0xf6, 0x7d, 0x46, 0x8a, 0x30, 0xcc, 0x06,
+	0xc4, 0xf7, 0x02, 0x2a, 0x97, 0x12, 0xe7, 0x58, 0x4a, 0x26, 0xf6, 0x8f, 0x18, 0xb7, 0xa8, 0xec,
+	0x00, 0x09, 0x09, 0xdb, 0xb3, 0x36, 0xe9, 0x51, 0x8b, 0x2f, 0x20, 0xf6, 0xe5, 0x50, 0xc0, 0x0e,
+	0x9e, 0x8b, 0x97, 0x5e, 0x40, 0x65, 0x1f, 0x10, 0x03, 0x36, 0xa7, 0xe3, 0xb9, 0x17, 0x4e, 0xd0,
+	0x27, 0xb6, 0x6c, 0x00, 0x43, 0x81, 0x88, 0xaf, 0x47, 0xad, 0x36, 0x71, 0x69, 0x8b, 0x0e, 0x77,
+	0x64, 0x2c, 0xc2, 0xbf, 0xd7, 0x60, 0x2d, 0xd9, 0x64, 0xc7, 0xca, 0x69, 0x36, 0x6f, 0x95, 0x7c,
+	0xde, 0x62, 0x6f, 0xab, 0x85, 0xde, 0x4e, 0x64, 0xbc, 0xc5, 0x87, 0x60, 0xa8, 0x5c, 0x91, 0xb5,
+	0xca, 0x57, 0x42, 0x53, 0x55, 0x02, 0x3f, 0x82, 0xb5, 0x64, 0x9b, 0x1f, 0x8f, 0x24, 0x75, 0x30,
+	0x54, 0x93, 0x64, 0xa7, 0x3c, 0x80, 0x75, 0x76, 0xa4, 0x9c, 0xbd, 0x09, 0x3c, 0x4a, 0x7b, 0xc4,
+	0x16, 0x46, 0x61, 0xe1, 0x67, 0xa0, 0xa6, 0xb8, 0x26, 0xe3, 0xdf, 0x6a, 0x50, 0xcb, 0xac, 0x50,
+	0xfa, 0x25, 0xb7, 0xc7, 0x8e, 0x3b, 0x2b, 0x94, 0xac, 0x9b, 0x7f, 0x58, 0xe3, 0x7b, 0xf6, 0x33,
+	0xcf, 0x71, 0xa3, 0x95, 0x4c, 0xae, 0x36, 0xa5, 0x19, 0x2b, 0x0c, 0x8d, 0x30, 0x5a, 0x54, 0xb2,
+	0x30, 0x29, 0xc2, 0x67, 0xa2, 0xef, 0xe5, 0x63, 0x91, 0x69, 0xde, 0x8f, 0x4f, 0x58, 0x71, 0xc2,
+	0xd4, 0x39, 0x64, 0x81, 0xe3, 0xf1, 0x01, 0xfb, 0x77, 0x0d, 0x16, 0xce, 0xdd, 0x4e, 0xcf, 0x72,
+	0xfa, 0x91, 0x4d, 0x61, 0x50, 0x8a, 0xaf, 0x81, 0x8a, 0xfa, 0x6b, 0x20, 0x7d, 0x63, 0xac, 0x96,
+	0xde, 0x18, 0x39, 0xbf, 0x98, 0x0f, 0xbc, 0x41, 0x4d, 0xc8, 0x8e, 0x1a, 0x09, 0xf0, 0xe7, 0xd0,
+	0x10, 0x37, 0xe9, 0x8c, 0xa7, 0x71, 0x21, 0x77, 0xf9, 0xb9, 0xce, 0x24, 0x32, 0xf8, 0x65, 0x71,
+	0xbc, 0xa6, 0xcd, 0xcd, 0xc8, 0x08, 0xbf, 0x04, 0x3d, 0xa3, 0x3b, 0x88, 0xc0, 0x0a, 0xa3, 0x4f,
+	0xb9, 0x58, 0xc9, 0xba, 0xf8, 0x43, 0xd8, 0x28, 0x72, 0x51, 0xd6, 0xe7, 0x71, 0xa6, 0x3e, 0x0d,
+	0x95, 0x8b, 0xb1, 0x1b, 0x71, 0x81, 0x9e, 0x09, 0x0a, 0x17, 0x45, 0xfe, 0xb5, 0xde, 0x28, 0xf0,
+	0x00, 0x6a, 0x99, 0x85, 0x4a, 0x99, 0x3c, 0x7e, 0xd1, 0x53, 0xb7, 0xa2, 0x6a, 0xe6, 0x56, 0x84,
+	0xa9, 0xa0, 0x6f, 0x61, 0x7a, 0xca, 0x2e, 0x10, 0xfb, 0x99, 0x0b, 0x44, 0x5d, 0x95, 0xbe, 0x1c,
+	0xbd, 0x9f, 0x40, 0x5d, 0x1c, 0x0f, 0x59, 0x2a, 0x94, 0x1c, 0x2b, 0x9b, 0xd0, 0x28, 0x98, 0x27,
+	0x4f, 0x16, 0x1f, 0x10, 0xaf, 0xd5, 0x58, 0xcb, 0x8d, 0xe6, 0xce, 0x78, 0xaf, 0x51, 0x78, 0x05,
+	0x96, 0x52, 0x88, 0xc2, 0x91, 0x9d, 0x27, 0x80, 0xf2, 0xc7, 0x0a, 0x9a, 0x07, 0x30, 0x5b, 0x67,
+	0x47, 0xaf, 0x4e, 0x4f, 0x9e, 0x9f, 0x9c, 0x2d, 0x7e, 0x0b, 0x2d, 0xc2, 0xec, 0xf3, 0x93, 0x83,
+	0x57, 0x4f, 0x5b, 0x27, 0xa7, 0xe7, 0xe6, 0x51, 0x7b, 0x51, 0x7b, 0xf8, 0xa7, 0x15, 0x98, 0x92,
+	0xfb, 0xfd, 0x0b, 0x98, 0x12, 0xdc, 0x45, 0x7a, 0xd1, 0xab, 0xaf, 0xb1, 0xa6, 0xd0, 0xc8, 0x54,
+	0xd4, 0x7e, 0xfd, 0xaf, 0xff, 0xfc, 0xb9, 0x72, 0x07, 0xcf, 0xf2, 0xe7, 0x79, 0xb9, 0xc5, 0x3e,
+	0xd1, 0x76, 0x50, 0x1b, 0xaa, 0xc7, 0x84, 0xa2, 0x95, 0xec, 0xcb, 0xa1, 0x58, 0x71, 0x55, 0xfd,
+	0xa0, 0x88, 0x1b, 0x7c, 0xb9, 0x1a, 0x5a, 0x49, 0x2e, 0xb7, 0xf7, 0xa5, 0xc8, 0xe4, 0x5b, 0xf4,
+	0x63, 0x98, 0x12, 0x95, 0x91, 0xce, 0x2a, 0xde, 0x0c, 0x8d, 0x35, 0x85, 0x26, 0xbd, 0xfa, 0x4e,
+	0xc1, 0xea, 0xbf, 0xd3, 0x60, 0x89, 0x91, 0x28, 0xf3, 0x6e, 0x88, 0x3e, 0xe0, 0x2b, 0x96, 0xbd,
+	0x2b, 0x1a, 0xb5, 0x8c, 0xd9, 0xf0, 0xab, 0x97, 0xc3, 0x7e, 0x84, 0x3e, 0xe4, 0xb0, 0x89, 0xc2,
+	0x86, 0x7b, 0x5f, 0xa6, 0xca, 0xfc, 0x36, 0xf2, 0x09, 0xbd, 0x82, 0x29, 0xd1, 0x54, 0x65, 0xa0,
+	0x8a, 0x77, 0x12, 0x63, 0x4d, 0xa1, 0x91, 0x88, 0x4d, 0x8e, 0x68, 0x18, 0xea, 0x40, 0x59, 0x79,
+	0x7c, 0x00, 0x51, 0x4f, 0x71, 0x02, 0xe7, 0x0a, 0x9c, 0xf8, 0x34, 0x37, 0x1a, 0x05, 0x5a, 0x09,
+	0xf6, 0x01, 0x07, 0xdb, 0xc4, 0x86, 0x12, 0x6c, 0xef, 0x92, 0x0c, 0x38, 0x21, 0x6c, 0xb8, 0x75,
+	0x4c, 0x28, 0x87, 0x5b, 0x4b, 0x57, 0x3f, 0x89, 0x65, 0xa8, 0x54, 0x12, 0x08, 0x73, 0xa0, 0x3a,
+	0x1a, 0x01, 0xc4, 0xe2, 0x12, 0x19, 0x49, 0xc4, 0x55, 0xf0, 0xe4, 0x61, 0x34, 0x0a, 0xb4, 0xe9,
+	0xb8, 0x8c, 0x92, 0xb8, 0xfa, 0x00, 0x82, 0x6c, 0x09, 0xc4, 0x82, 0x47, 0x0e, 0xa3, 0x51, 0xa0,
+	0x4d, 0x07, 0xb8, 0x33, 0x2a, 0x40, 0x17, 0xa6, 0xa3, 0x97, 0x01, 0x24, 0x92, 0xa5, 0x7c, 0x01,
+	0x31, 0xd6, 0x95, 0x3a, 0x09, 0xf4, 0x21, 0x07, 0x7a, 0x0f, 0x6f, 0xa8, 0x81, 0x2c, 0x39, 0x8b,
+	0x85, 0xf7, 0x73, 0x98, 0x3b, 0x26, 0x74, 0xf8, 0x95, 0x86, 0x36, 0xd3, 0x15, 0xca, 0xbd, 0x41,
+	0x18, 0xcd, 0x62, 0x03, 0x09, 0xbf, 0xcd, 0xe1, 0x31, 0x6a, 0x8e, 0x84, 0x67, 0x60, 0xbf, 0xd1,
+	0x60, 0x81, 0xed, 0xa8, 0xe1, 0x22, 0x21, 0xda, 0xca, 0xec, 0xb3, 0xfc, 0x33, 0x80, 0x81, 0x47,
+	0x99, 0xa4, 0x73, 0x80, 0xb6, 0xca, 0x9c, 0x08, 0xd1, 0x2f, 0x60, 0x31, 0xfb, 0x55, 0x29, 0x0b,
+	0x5d, 0xf0, 0x7d, 0x6a, 0x34, 0x0a, 0xb4, 0x12, 0x7b, 0x97, 0x63, 0x6f, 0xe3, 0xbb, 0x6a, 0xec,
+	0x6e, 0x16, 0xac, 0x07, 0x33, 0xc7, 0x84, 0x8a, 0xfb, 0x2d, 0x5a, 0x4f, 0xe7, 0x37, 0x75, 0x55,
+	0x36, 0xea, 0x6a, 0xa5, 0xc4, 0x7d, 0x9f, 0xe3, 0x6e, 0xa0, 0xba, 0x1a, 0x37, 0x14, 0x00, 0x3f,
+	0x83, 0x59, 0xb1, 0x29, 0x24, 0xe0, 0x46, 0x6e, 0x9f, 0xa4, 0x31, 0x37, 0x0b, 0xf5, 0x12, 0xf6,
+	0xdb, 0x1c, 0x76, 0xcb, 0x18, 0x09, 0xcb, 0xc8, 0x76, 0x05, 0xb3, 0x62, 0x73, 0xa4, 0x90, 0x0b,
+	0x3f, 0x0c, 0x8c, 0xcd, 0x42, 0x7d, 0x3a, 0xe0, 0x9d, 0xd1, 0x01, 0xff, 0x4d, 0xcb, 0x5c, 0xaf,
+	0xb3, 0x1d, 0xa0, 0x19, 0xf3, 0xa9, 0xe0, 0x6b, 0xc2, 0xd8, 0x1a, 0x61, 0x21, 0x7d, 0xf9, 0x1e,
+	0xf7, 0xe5, 0x31, 0x7a, 0x54, 0xde, 0x06, 0xe2, 0xab, 0xff, 0xbd, 0xa8, 0x21, 0xbc, 0x85, 0x85,
+	0xcc, 0x15, 0x13, 0x25, 0xff, 0x4a, 0x5b, 0x70, 0x43, 0x34, 0xde, 0x1b, 0x69, 0x23, 0x1d, 0xdb,
+	0xe2, 0x8e, 0xad, 0xe3, 0x55, 0xee, 0xd8, 0x55, 0x64, 0x76, 0x2f, 0xd1, 0xcd, 0x29, 0xcc, 0xa5,
+	0x2e, 0x70, 0x89, 0x8c, 0x14, 0x41, 0x6f, 0x8d, 0xb0, 0x90, 0xc0, 0x1b, 0x1c, 0x58, 0x47, 0x05,
+	0xc0, 0xe8, 0x57, 0x1a, 0x2c, 0x64, 0x6e, 0x62, 0x72, 0xf7, 0x8f, 0xba, 0xd7, 0x19, 0x78, 0x94,
+	0x49, 0x9a, 0x92, 0x3b, 0x9b, 0x6a, 0xe8, 0xe1, 0xa5, 0xe0, 0x35, 0x4c, 0xf2, 0x9b, 0x17, 0x12,
+	0xed, 0x3d, 0x7f, 0xef, 0x33, 0xf4, 0xbc, 0x42, 0x82, 0xdc, 0xe5, 0x20, 0x4d, 0xbc, 0xae, 0x66,
+	0x1f, 0xc7, 0x64, 0xd9, 0xfd, 0xa5, 0x06, 0x0b, 0xe2, 0xd9, 0x25, 0x7e, 0x41, 0x92, 0x71, 0x8e,
+	0x7a, 0x96, 0x32, 0xf0, 0x28, 0x93, 0xf1, 0x76, 0xfc, 0x05, 0x9b, 0x10, 0xde, 0xd7, 0x12, 0x2e,
+	0xc4, 0x6f, 0x3e, 0x0a, 0x17, 0xb2, 0xef, 0x48, 0x06, 0x1e, 0x65, 0x32, 0x9e, 0x0b, 0x84, 0x4d,
+	0x08, 0xef, 0x6b, 0xaf, 0xa7, 0xf8, 0xbf, 0x70, 0x3c, 0xfa, 0xef, 0x00, 0x5e, 0xf6, 0x63, 0xac,
+	0x03, 0x22, 0x00, 0x00,
 }
@@ -31,17 +31,36 @@ type CreateDeviceProfileRequest struct {
 	// Organization id of the device-profile.
 	OrganizationID int64 `protobuf:"varint,3,opt,name=organizationID" json:"organizationID,omitempty"`
 	// Network-server id of the device-profile.
-	NetworkServerID      int64    `protobuf:"varint,4,opt,name=networkServerID" json:"networkServerID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	NetworkServerID int64 `protobuf:"varint,4,opt,name=networkServerID" json:"networkServerID,omitempty"`
+	// ID of the organization codec used by the devices using this
+	// device-profile (0 = use the codec of the application).
+	CodecID int64 `protobuf:"varint,7,opt,name=codecID" json:"codecID,omitempty"`
+	// Version of the codec to use (0 = always use the latest version).
+	CodecVersion int64 `protobuf:"varint,8,opt,name=codecVersion" json:"codecVersion,omitempty"`
+	// Answer the Clock Synchronization requests (fPort 202) of the devices.
+	ClockSyncEnabled bool `protobuf:"varint,10,opt,name=clockSyncEnabled" json:"clockSyncEnabled,omitempty"`
+	// Request the devices to synchronize their clock periodically.
+	ClockSyncRequestPeriodicity bool `protobuf:"varint,11,opt,name=clockSyncRequestPeriodicity" json:"clockSyncRequestPeriodicity,omitempty"`
+	// Clock synchronization periodicity, 128 * 2^periodicity seconds (0 - 15).
+	ClockSyncPeriodicity uint32 `protobuf:"varint,12,opt,name=clockSyncPeriodicity" json:"clockSyncPeriodicity,omitempty"`
+	// Max number of attempts (including the first one) of confirmed downlinks
+	// which are not acknowledged (0 or 1 disables the retries, max 10).
+	DownlinkRetryMaxAttempts uint32 `protobuf:"varint,13,opt,name=downlinkRetryMaxAttempts" json:"downlinkRetryMaxAttempts,omitempty"`
+	// Backoff (in seconds) before retrying a confirmed downlink, doubled
+	// after each retry.
+	DownlinkRetryBackoff uint32 `protobuf:"varint,14,opt,name=downlinkRetryBackoff" json:"downlinkRetryBackoff,omitempty"`
+	// Encode the codec object again on each retry.
+	DownlinkRetryReencode bool     `protobuf:"varint,15,opt,name=downlinkRetryReencode" json:"downlinkRetryReencode,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *CreateDeviceProfileRequest) Reset()         { *m = CreateDeviceProfileRequest{} }
 func (m *CreateDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceProfileRequest) ProtoMessage()    {}
 func (*CreateDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{0}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{0}
 }
 func (m *CreateDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceProfileRequest.Unmarshal(m, b)
@@ -89,6 +108,62 @@ func (m *CreateDeviceProfileRequest) GetNetworkServerID() int64 {
 	return 0
 }
 
+func (m *CreateDeviceProfileRequest) GetCodecID() int64 {
+	if m != nil {
+		return m.CodecID
+	}
+	return 0
+}
+
+func (m *CreateDeviceProfileRequest) GetCodecVersion() int64 {
+	if m != nil {
+		return m.CodecVersion
+	}
+	return 0
+}
+
+func (m *CreateDeviceProfileRequest) GetClockSyncEnabled() bool {
+	if m != nil {
+		return m.ClockSyncEnabled
+	}
+	return false
+}
+
+func (m *CreateDeviceProfileRequest) GetClockSyncRequestPeriodicity() bool {
+	if m != nil {
+		return m.ClockSyncRequestPeriodicity
+	}
+	return false
+}
+
+func (m *CreateDeviceProfileRequest) GetClockSyncPeriodicity() uint32 {
+	if m != nil {
+		return m.ClockSyncPeriodicity
+	}
+	return 0
+}
+
+func (m *CreateDeviceProfileRequest) GetDownlinkRetryMaxAttempts() uint32 {
+	if m != nil {
+		return m.DownlinkRetryMaxAttempts
+	}
+	return 0
+}
+
+func (m *CreateDeviceProfileRequest) GetDownlinkRetryBackoff() uint32 {
+	if m != nil {
+		return m.DownlinkRetryBackoff
+	}
+	return 0
+}
+
+func (m *CreateDeviceProfileRequest) GetDownlinkRetryReencode() bool {
+	if m != nil {
+		return m.DownlinkRetryReencode
+	}
+	return false
+}
+
 type CreateDeviceProfileResponse struct {
 	// ID of the device-profile.
 	DeviceProfileID      string   `protobuf:"bytes,1,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
@@ -101,7 +176,7 @@ func (m *CreateDeviceProfileResponse) Reset()         { *m = CreateDeviceProfile
 func (m *CreateDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceProfileResponse) ProtoMessage()    {}
 func (*CreateDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{1}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{1}
 }
 func (m *CreateDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceProfileResponse.Unmarshal(m, b)
@@ -140,7 +215,7 @@ func (m *GetDeviceProfileRequest) Reset()         { *m = GetDeviceProfileRequest
 func (m *GetDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceProfileRequest) ProtoMessage()    {}
 func (*GetDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{2}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{2}
 }
 func (m *GetDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceProfileRequest.Unmarshal(m, b)
@@ -178,17 +253,39 @@ type GetDeviceProfileResponse struct {
 	// Timestamp when the record was created.
 	CreatedAt string `protobuf:"bytes,5,opt,name=createdAt" json:"createdAt,omitempty"`
 	// Timestamp when the record was last updated.
-	UpdatedAt            string   `protobuf:"bytes,6,opt,name=updatedAt" json:"updatedAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UpdatedAt string `protobuf:"bytes,6,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// ID of the organization codec used by the devices using this
+	// device-profile (0 = use the codec of the application).
+	CodecID int64 `protobuf:"varint,7,opt,name=codecID" json:"codecID,omitempty"`
+	// Version of the codec to use (0 = always use the latest version).
+	CodecVersion int64 `protobuf:"varint,8,opt,name=codecVersion" json:"codecVersion,omitempty"`
+	// Latest version of the codec. When this is greater than codecVersion,
+	// the device-profile is pinned to an older version of the codec.
+	CodecLatestVersion int64 `protobuf:"varint,9,opt,name=codecLatestVersion" json:"codecLatestVersion,omitempty"`
+	// Answer the Clock Synchronization requests (fPort 202) of the devices.
+	ClockSyncEnabled bool `protobuf:"varint,10,opt,name=clockSyncEnabled" json:"clockSyncEnabled,omitempty"`
+	// Request the devices to synchronize their clock periodically.
+	ClockSyncRequestPeriodicity bool `protobuf:"varint,11,opt,name=clockSyncRequestPeriodicity" json:"clockSyncRequestPeriodicity,omitempty"`
+	// Clock synchronization periodicity, 128 * 2^periodicity seconds (0 - 15).
+	ClockSyncPeriodicity uint32 `protobuf:"varint,12,opt,name=clockSyncPeriodicity" json:"clockSyncPeriodicity,omitempty"`
+	// Max number of attempts (including the first one) of confirmed downlinks
+	// which are not acknowledged (0 or 1 disables the retries, max 10).
+	DownlinkRetryMaxAttempts uint32 `protobuf:"varint,13,opt,name=downlinkRetryMaxAttempts" json:"downlinkRetryMaxAttempts,omitempty"`
+	// Backoff (in seconds) before retrying a confirmed downlink, doubled
+	// after each retry.
+	DownlinkRetryBackoff uint32 `protobuf:"varint,14,opt,name=downlinkRetryBackoff" json:"downlinkRetryBackoff,omitempty"`
+	// Encode the codec object again on each retry.
+	DownlinkRetryReencode bool     `protobuf:"varint,15,opt,name=downlinkRetryReencode" json:"downlinkRetryReencode,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *GetDeviceProfileResponse) Reset()         { *m = GetDeviceProfileResponse{} }
 func (m *GetDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceProfileResponse) ProtoMessage()    {}
 func (*GetDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{3}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{3}
 }
 func (m *GetDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceProfileResponse.Unmarshal(m, b)
@@ -250,20 +347,102 @@ func (m *GetDeviceProfileResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (m *GetDeviceProfileResponse) GetCodecID() int64 {
+	if m != nil {
+		return m.CodecID
+	}
+	return 0
+}
+
+func (m *GetDeviceProfileResponse) GetCodecVersion() int64 {
+	if m != nil {
+		return m.CodecVersion
+	}
+	return 0
+}
+
+func (m *GetDeviceProfileResponse) GetCodecLatestVersion() int64 {
+	if m != nil {
+		return m.CodecLatestVersion
+	}
+	return 0
+}
+
+func (m *GetDeviceProfileResponse) GetClockSyncEnabled() bool {
+	if m != nil {
+		return m.ClockSyncEnabled
+	}
+	return false
+}
+
+func (m *GetDeviceProfileResponse) GetClockSyncRequestPeriodicity() bool {
+	if m != nil {
+		return m.ClockSyncRequestPeriodicity
+	}
+	return false
+}
+
+func (m *GetDeviceProfileResponse) GetClockSyncPeriodicity() uint32 {
+	if m != nil {
+		return m.ClockSyncPeriodicity
+	}
+	return 0
+}
+
+func (m *GetDeviceProfileResponse) GetDownlinkRetryMaxAttempts() uint32 {
+	if m != nil {
+		return m.DownlinkRetryMaxAttempts
+	}
+	return 0
+}
+
+func (m *GetDeviceProfileResponse) GetDownlinkRetryBackoff() uint32 {
+	if m != nil {
+		return m.DownlinkRetryBackoff
+	}
+	return 0
+}
+
+func (m *GetDeviceProfileResponse) GetDownlinkRetryReencode() bool {
+	if m != nil {
+		return m.DownlinkRetryReencode
+	}
+	return false
+}
+
 type UpdateDeviceProfileRequest struct {
 	DeviceProfile *DeviceProfile `protobuf:"bytes,1,opt,name=deviceProfile" json:"deviceProfile,omitempty"`
 	// Name of the device-profile.
-	Name                 string   `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// ID of the organization codec used by the devices using this
+	// device-profile (0 = use the codec of the application).
+	CodecID int64 `protobuf:"varint,7,opt,name=codecID" json:"codecID,omitempty"`
+	// Version of the codec to use (0 = always use the latest version).
+	CodecVersion int64 `protobuf:"varint,8,opt,name=codecVersion" json:"codecVersion,omitempty"`
+	// Answer the Clock Synchronization requests (fPort 202) of the devices.
+	ClockSyncEnabled bool `protobuf:"varint,10,opt,name=clockSyncEnabled" json:"clockSyncEnabled,omitempty"`
+	// Request the devices to synchronize their clock periodically.
+	ClockSyncRequestPeriodicity bool `protobuf:"varint,11,opt,name=clockSyncRequestPeriodicity" json:"clockSyncRequestPeriodicity,omitempty"`
+	// Clock synchronization periodicity, 128 * 2^periodicity seconds (0 - 15).
+	ClockSyncPeriodicity uint32 `protobuf:"varint,12,opt,name=clockSyncPeriodicity" json:"clockSyncPeriodicity,omitempty"`
+	// Max number of attempts (including the first one) of confirmed downlinks
+	// which are not acknowledged (0 or 1 disables the retries, max 10).
+	DownlinkRetryMaxAttempts uint32 `protobuf:"varint,13,opt,name=downlinkRetryMaxAttempts" json:"downlinkRetryMaxAttempts,omitempty"`
+	// Backoff (in seconds) before retrying a confirmed downlink, doubled
+	// after each retry.
+	DownlinkRetryBackoff uint32 `protobuf:"varint,14,opt,name=downlinkRetryBackoff" json:"downlinkRetryBackoff,omitempty"`
+	// Encode the codec object again on each retry.
+	DownlinkRetryReencode bool     `protobuf:"varint,15,opt,name=downlinkRetryReencode" json:"downlinkRetryReencode,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *UpdateDeviceProfileRequest) Reset()         { *m = UpdateDeviceProfileRequest{} }
 func (m *UpdateDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceProfileRequest) ProtoMessage()    {}
 func (*UpdateDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{4}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{4}
 }
 func (m *UpdateDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceProfileRequest.Unmarshal(m, b)
@@ -297,6 +476,62 @@ func (m *UpdateDeviceProfileRequest) GetName() string {
 	return ""
 }
 
+func (m *UpdateDeviceProfileRequest) GetCodecID() int64 {
+	if m != nil {
+		return m.CodecID
+	}
+	return 0
+}
+
+func (m *UpdateDeviceProfileRequest) GetCodecVersion() int64 {
+	if m != nil {
+		return m.CodecVersion
+	}
+	return 0
+}
+
+func (m *UpdateDeviceProfileRequest) GetClockSyncEnabled() bool {
+	if m != nil {
+		return m.ClockSyncEnabled
+	}
+	return false
+}
+
+func (m *UpdateDeviceProfileRequest) GetClockSyncRequestPeriodicity() bool {
+	if m != nil {
+		return m.ClockSyncRequestPeriodicity
+	}
+	return false
+}
+
+func (m *UpdateDeviceProfileRequest) GetClockSyncPeriodicity() uint32 {
+	if m != nil {
+		return m.ClockSyncPeriodicity
+	}
+	return 0
+}
+
+func (m *UpdateDeviceProfileRequest) GetDownlinkRetryMaxAttempts() uint32 {
+	if m != nil {
+		return m.DownlinkRetryMaxAttempts
+	}
+	return 0
+}
+
+func (m *UpdateDeviceProfileRequest) GetDownlinkRetryBackoff() uint32 {
+	if m != nil {
+		return m.DownlinkRetryBackoff
+	}
+	return 0
+}
+
+func (m *UpdateDeviceProfileRequest) GetDownlinkRetryReencode() bool {
+	if m != nil {
+		return m.DownlinkRetryReencode
+	}
+	return false
+}
+
 type UpdateDeviceProfileResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -307,7 +542,7 @@ func (m *UpdateDeviceProfileResponse) Reset()         { *m = UpdateDeviceProfile
 func (m *UpdateDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceProfileResponse) ProtoMessage()    {}
 func (*UpdateDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{5}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{5}
 }
 func (m *UpdateDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceProfileResponse.Unmarshal(m, b)
@@ -339,7 +574,7 @@ func (m *DeleteDeviceProfileRequest) Reset()         { *m = DeleteDeviceProfileR
 func (m *DeleteDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceProfileRequest) ProtoMessage()    {}
 func (*DeleteDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{6}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{6}
 }
 func (m *DeleteDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceProfileRequest.Unmarshal(m, b)
@@ -376,7 +611,7 @@ func (m *DeleteDeviceProfileResponse) Reset()         { *m = DeleteDeviceProfile
 func (m *DeleteDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceProfileResponse) ProtoMessage()    {}
 func (*DeleteDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{7}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{7}
 }
 func (m *DeleteDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceProfileResponse.Unmarshal(m, b)
@@ -414,7 +649,7 @@ func (m *ListDeviceProfileRequest) Reset()         { *m = ListDeviceProfileReque
 func (m *ListDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*ListDeviceProfileRequest) ProtoMessage()    {}
 func (*ListDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{8}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{8}
 }
 func (m *ListDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListDeviceProfileRequest.Unmarshal(m, b)
@@ -484,7 +719,7 @@ func (m *DeviceProfileMeta) Reset()         { *m = DeviceProfileMeta{} }
 func (m *DeviceProfileMeta) String() string { return proto.CompactTextString(m) }
 func (*DeviceProfileMeta) ProtoMessage()    {}
 func (*DeviceProfileMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{9}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{9}
 }
 func (m *DeviceProfileMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceProfileMeta.Unmarshal(m, b)
@@ -559,7 +794,7 @@ func (m *ListDeviceProfileResponse) Reset()         { *m = ListDeviceProfileResp
 func (m *ListDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*ListDeviceProfileResponse) ProtoMessage()    {}
 func (*ListDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceProfile_6e56822e3b74ff39, []int{10}
+	return fileDescriptor_deviceProfile_c4eeb55f433e62b0, []int{10}
 }
 func (m *ListDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListDeviceProfileResponse.Unmarshal(m, b)
@@ -822,45 +1057,56 @@ var _DeviceProfileService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "deviceProfile.proto",
 }
 
-func init() { proto.RegisterFile("deviceProfile.proto", fileDescriptor_deviceProfile_6e56822e3b74ff39) }
-
-var fileDescriptor_deviceProfile_6e56822e3b74ff39 = []byte{
-	// 577 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0x4f, 0x8b, 0xd3, 0x40,
-	0x14, 0x67, 0x36, 0xdd, 0x40, 0xdf, 0xb2, 0x2b, 0x8e, 0xa5, 0x66, 0xd3, 0x76, 0xb7, 0x04, 0x59,
-	0xc2, 0x82, 0x29, 0x54, 0x0f, 0xea, 0x45, 0xa4, 0xc5, 0x52, 0x50, 0x90, 0x88, 0x1f, 0x60, 0x6c,
-	0x5f, 0xcb, 0xb8, 0xd9, 0x4c, 0x4c, 0xa6, 0x2b, 0xb8, 0x78, 0xf1, 0xec, 0x4d, 0xf0, 0xd3, 0xf8,
-	0x2d, 0xfc, 0x00, 0x5e, 0xbc, 0xf8, 0x05, 0x3c, 0x4b, 0x66, 0xa6, 0xba, 0xe9, 0x26, 0x4b, 0x2e,
-	0x82, 0x7b, 0xeb, 0xbc, 0xf7, 0xf2, 0x7e, 0xbf, 0xf7, 0x7b, 0x7f, 0x0a, 0xb7, 0xe6, 0x78, 0xc6,
-	0x67, 0xf8, 0x22, 0x15, 0x0b, 0x1e, 0x61, 0x90, 0xa4, 0x42, 0x0a, 0x6a, 0xb1, 0x84, 0xbb, 0xdd,
-	0xa5, 0x10, 0xcb, 0x08, 0x07, 0x2c, 0xe1, 0x03, 0x16, 0xc7, 0x42, 0x32, 0xc9, 0x45, 0x9c, 0xe9,
-	0x10, 0x77, 0x2f, 0xd1, 0x5f, 0x98, 0xb7, 0xf7, 0x95, 0x80, 0x3b, 0x4a, 0x91, 0x49, 0x1c, 0x5f,
-	0x4c, 0x18, 0xe2, 0xdb, 0x15, 0x66, 0x92, 0x3e, 0x80, 0xdd, 0x02, 0x90, 0x43, 0xfa, 0xc4, 0xdf,
-	0x19, 0xd2, 0x80, 0x25, 0x3c, 0x28, 0x7e, 0x51, 0x0c, 0xa4, 0x14, 0x1a, 0x31, 0x3b, 0x45, 0x67,
-	0xab, 0x4f, 0xfc, 0x66, 0xa8, 0x7e, 0xd3, 0x23, 0xd8, 0x13, 0xe9, 0x92, 0xc5, 0xfc, 0xbd, 0xe2,
-	0x34, 0x1d, 0x3b, 0x56, 0x9f, 0xf8, 0x56, 0xb8, 0x61, 0xa5, 0x3e, 0xdc, 0x88, 0x51, 0xbe, 0x13,
-	0xe9, 0xc9, 0x4b, 0x4c, 0xcf, 0x30, 0x9d, 0x8e, 0x9d, 0x86, 0x0a, 0xdc, 0x34, 0x7b, 0x13, 0xe8,
-	0x94, 0xb2, 0xcf, 0x12, 0x11, 0x67, 0x98, 0x27, 0x2a, 0xb0, 0x9a, 0x8e, 0x55, 0x01, 0xcd, 0x70,
-	0xd3, 0xec, 0x8d, 0xe0, 0xf6, 0x04, 0x65, 0xa9, 0x06, 0xf5, 0x93, 0xfc, 0x22, 0xe0, 0x5c, 0xce,
-	0x62, 0xb8, 0xfc, 0xe7, 0x52, 0xd2, 0x2e, 0x34, 0x67, 0x4a, 0xca, 0xf9, 0x13, 0xe9, 0x6c, 0x2b,
-	0xa8, 0xbf, 0x86, 0xdc, 0xbb, 0x4a, 0xe6, 0xc6, 0x6b, 0x6b, 0xef, 0x1f, 0x83, 0xf7, 0x06, 0xdc,
-	0x57, 0xea, 0xf1, 0xef, 0x87, 0xc8, 0xeb, 0x41, 0xa7, 0x14, 0x4b, 0xcb, 0xec, 0x3d, 0x05, 0x77,
-	0x8c, 0x11, 0x56, 0x50, 0xa9, 0xdf, 0xcb, 0x1e, 0x74, 0x4a, 0xf3, 0x18, 0x98, 0x2f, 0x04, 0x9c,
-	0x67, 0x3c, 0x2b, 0x9f, 0x98, 0x16, 0x6c, 0x47, 0xfc, 0x94, 0x4b, 0x95, 0xdb, 0x0a, 0xf5, 0x83,
-	0xb6, 0xc1, 0x16, 0x8b, 0x45, 0x86, 0x52, 0x95, 0x63, 0x85, 0xe6, 0x55, 0xbb, 0x95, 0x77, 0x60,
-	0x97, 0x25, 0x49, 0xc4, 0x67, 0xeb, 0x30, 0xdd, 0xc8, 0xa2, 0xd1, 0xfb, 0x4e, 0xe0, 0x66, 0x81,
-	0xd4, 0x73, 0x94, 0xac, 0x7e, 0xdd, 0xd7, 0x60, 0xd8, 0x4e, 0x60, 0xbf, 0x44, 0x79, 0xb3, 0x65,
-	0x07, 0x00, 0x52, 0x48, 0x16, 0x8d, 0xc4, 0x2a, 0x5e, 0xeb, 0x7f, 0xc1, 0x42, 0x03, 0xb0, 0x53,
-	0xcc, 0x56, 0x51, 0xde, 0x04, 0xcb, 0xdf, 0x19, 0xb6, 0x2f, 0x0f, 0x61, 0x2e, 0x58, 0x68, 0xa2,
-	0x86, 0x3f, 0x1b, 0xd0, 0x2a, 0x78, 0xf3, 0x12, 0xf8, 0x0c, 0x69, 0x04, 0xb6, 0xbe, 0x3c, 0xf4,
-	0x50, 0xa5, 0xa8, 0x3e, 0xa2, 0x6e, 0xbf, 0x3a, 0xc0, 0x4c, 0xd3, 0xe1, 0xc7, 0x6f, 0x3f, 0x3e,
-	0x6f, 0xed, 0x7b, 0x2d, 0x75, 0xb5, 0x75, 0x4b, 0xee, 0xae, 0x2f, 0xf5, 0x23, 0x72, 0x4c, 0x53,
-	0xb0, 0x26, 0x28, 0x69, 0x57, 0x65, 0xaa, 0x38, 0x54, 0x6e, 0xaf, 0xc2, 0x6b, 0x40, 0x02, 0x05,
-	0xe2, 0xd3, 0xa3, 0x32, 0x90, 0xc1, 0xf9, 0xc6, 0x20, 0x7c, 0xa0, 0x9f, 0x08, 0xd8, 0x7a, 0xd3,
-	0x4c, 0x89, 0xd5, 0x2b, 0x6e, 0x4a, 0xbc, 0x6a, 0x2f, 0x1f, 0x2b, 0xf4, 0x87, 0xee, 0xfd, 0x1a,
-	0xe8, 0xc1, 0x26, 0x97, 0x5c, 0x82, 0x73, 0xb0, 0xf5, 0x42, 0x1a, 0x36, 0xd5, 0x5b, 0x6e, 0xd8,
-	0x5c, 0xb5, 0xbe, 0x46, 0x8b, 0xe3, 0xba, 0x5a, 0xcc, 0xa0, 0x91, 0xcf, 0x1c, 0xd5, 0x12, 0x57,
-	0x2d, 0xbe, 0x7b, 0x50, 0xe5, 0x36, 0xb0, 0x5d, 0x05, 0xdb, 0xa6, 0xa5, 0x7d, 0x7e, 0x6d, 0xab,
-	0xbf, 0xe4, 0x7b, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0xe1, 0x63, 0xb6, 0x83, 0xdc, 0x07, 0x00,
-	0x00,
+func init() { proto.RegisterFile("deviceProfile.proto", fileDescriptor_deviceProfile_c4eeb55f433e62b0) }
+
+var fileDescriptor_deviceProfile_c4eeb55f433e62b0 = []byte{
+	// 757 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0xcd, 0x4e, 0xdb, 0x4c,
+	0x14, 0x95, 0x71, 0x08, 0xe4, 0x42, 0xe0, 0xfb, 0xa6, 0x29, 0x1d, 0x9c, 0x00, 0x96, 0x55, 0x21,
+	0x0b, 0xa9, 0x41, 0x4a, 0x59, 0xb4, 0x6c, 0x5a, 0x4a, 0x5a, 0x14, 0x09, 0x24, 0x64, 0xd4, 0xee,
+	0x07, 0xe7, 0x06, 0x8d, 0x62, 0x3c, 0xae, 0x3d, 0x81, 0x52, 0xd4, 0x4d, 0xd7, 0xdd, 0x55, 0xea,
+	0x53, 0xf4, 0x29, 0xfa, 0x0a, 0x7d, 0x80, 0x6e, 0xba, 0xe9, 0xae, 0x8f, 0x50, 0x79, 0xec, 0x50,
+	0x1c, 0xec, 0x2a, 0x12, 0x55, 0xc5, 0x82, 0x5d, 0xe6, 0x9e, 0x33, 0xf7, 0xcc, 0xdc, 0x3f, 0x4f,
+	0xe0, 0x4e, 0x17, 0x4f, 0xb8, 0x8b, 0xfb, 0xa1, 0xe8, 0x71, 0x0f, 0x9b, 0x41, 0x28, 0xa4, 0x20,
+	0x3a, 0x0b, 0xb8, 0xd1, 0x38, 0x12, 0xe2, 0xc8, 0xc3, 0x75, 0x16, 0xf0, 0x75, 0xe6, 0xfb, 0x42,
+	0x32, 0xc9, 0x85, 0x1f, 0x25, 0x14, 0x63, 0x2e, 0x48, 0x76, 0xa4, 0x6b, 0xeb, 0x4b, 0x09, 0x8c,
+	0xed, 0x10, 0x99, 0xc4, 0xf6, 0x65, 0x87, 0x0e, 0xbe, 0x1e, 0x60, 0x24, 0xc9, 0x23, 0xa8, 0x66,
+	0x84, 0xa8, 0x66, 0x6a, 0xf6, 0x4c, 0x8b, 0x34, 0x59, 0xc0, 0x9b, 0xd9, 0x1d, 0x59, 0x22, 0x21,
+	0x50, 0xf2, 0xd9, 0x31, 0xd2, 0x09, 0x53, 0xb3, 0x2b, 0x8e, 0xfa, 0x4d, 0x56, 0x61, 0x4e, 0x84,
+	0x47, 0xcc, 0xe7, 0x6f, 0xd5, 0x99, 0x3a, 0x6d, 0xaa, 0x9b, 0x9a, 0xad, 0x3b, 0x23, 0x56, 0x62,
+	0xc3, 0xbc, 0x8f, 0xf2, 0x54, 0x84, 0xfd, 0x03, 0x0c, 0x4f, 0x30, 0xec, 0xb4, 0x69, 0x49, 0x11,
+	0x47, 0xcd, 0x84, 0xc2, 0x94, 0x2b, 0xba, 0xe8, 0x76, 0xda, 0x74, 0x4a, 0x31, 0x86, 0x4b, 0x62,
+	0xc1, 0xac, 0xfa, 0xf9, 0x0a, 0xc3, 0x88, 0x0b, 0x9f, 0x4e, 0x2b, 0x38, 0x63, 0x23, 0x6b, 0xf0,
+	0x9f, 0xeb, 0x09, 0xb7, 0x7f, 0x70, 0xe6, 0xbb, 0xcf, 0x7d, 0x76, 0xe8, 0x61, 0x97, 0x82, 0xa9,
+	0xd9, 0xd3, 0xce, 0x15, 0x3b, 0x79, 0x0a, 0xf5, 0x0b, 0x5b, 0x1a, 0x9d, 0x7d, 0x0c, 0xb9, 0xe8,
+	0x72, 0x97, 0xcb, 0x33, 0x3a, 0xa3, 0xb6, 0xfd, 0x89, 0x42, 0x5a, 0x50, 0xbb, 0x80, 0x2f, 0x6f,
+	0x9d, 0x35, 0x35, 0xbb, 0xea, 0xe4, 0x62, 0x64, 0x13, 0x68, 0x57, 0x9c, 0xfa, 0x1e, 0xf7, 0xfb,
+	0x0e, 0xca, 0xf0, 0x6c, 0x8f, 0xbd, 0xd9, 0x92, 0x12, 0x8f, 0x03, 0x19, 0xd1, 0xaa, 0xda, 0x57,
+	0x88, 0xc7, 0x7a, 0x19, 0xec, 0x19, 0x73, 0xfb, 0xa2, 0xd7, 0xa3, 0x73, 0x89, 0x5e, 0x1e, 0x46,
+	0x36, 0xe0, 0x6e, 0xc6, 0xee, 0x20, 0xfa, 0x71, 0xc8, 0xe8, 0xbc, 0xba, 0x5f, 0x3e, 0x68, 0xed,
+	0x40, 0x3d, 0xb7, 0x86, 0xa2, 0x40, 0xf8, 0x11, 0xc6, 0xe9, 0xcc, 0xd4, 0x46, 0xa7, 0xad, 0xca,
+	0xa8, 0xe2, 0x8c, 0x9a, 0xad, 0x6d, 0xb8, 0xb7, 0x83, 0x32, 0xb7, 0x12, 0xc7, 0x77, 0xf2, 0x79,
+	0x12, 0xe8, 0x55, 0x2f, 0xe9, 0x59, 0x6e, 0x7a, 0x41, 0x37, 0xa0, 0xe2, 0xaa, 0x50, 0x76, 0xb7,
+	0x24, 0x9d, 0x54, 0x52, 0xbf, 0x0d, 0x31, 0x3a, 0x08, 0xba, 0x29, 0x5a, 0x4e, 0xd0, 0x0b, 0xc3,
+	0x35, 0x9b, 0xa1, 0x09, 0x44, 0xad, 0x77, 0x99, 0xc4, 0x48, 0x0e, 0x99, 0x15, 0xc5, 0xcc, 0x41,
+	0x6e, 0x9b, 0xe7, 0x2f, 0x35, 0xcf, 0x4f, 0x1d, 0x8c, 0x97, 0x2a, 0x87, 0xff, 0x60, 0x02, 0xdf,
+	0xce, 0xcb, 0x9b, 0x90, 0xf2, 0x25, 0xa8, 0xe7, 0x66, 0x3c, 0x99, 0x51, 0xd6, 0x0b, 0x30, 0xda,
+	0xe8, 0xa1, 0xc4, 0x6b, 0x0e, 0xc2, 0x25, 0xa8, 0xe7, 0xfa, 0x49, 0x65, 0x3e, 0x69, 0x40, 0x77,
+	0x79, 0x94, 0x3f, 0x6e, 0x6b, 0x30, 0xe9, 0xf1, 0x63, 0x2e, 0x95, 0x6f, 0xdd, 0x49, 0x16, 0x64,
+	0x01, 0xca, 0xa2, 0xd7, 0x8b, 0x50, 0xaa, 0xa2, 0xd2, 0x9d, 0x74, 0x35, 0xf6, 0x1c, 0xbc, 0x0f,
+	0x55, 0x16, 0x04, 0x1e, 0x77, 0x87, 0xb4, 0x64, 0x0a, 0x66, 0x8d, 0xd6, 0x37, 0x0d, 0xfe, 0xcf,
+	0x1c, 0x6a, 0x0f, 0x25, 0x1b, 0xff, 0xde, 0x37, 0x7f, 0x52, 0x5b, 0x7d, 0x58, 0xcc, 0x89, 0x7c,
+	0xfa, 0x89, 0x5a, 0x06, 0x90, 0x42, 0x32, 0x6f, 0x5b, 0x0c, 0xfc, 0x61, 0xfc, 0x2f, 0x59, 0x48,
+	0x13, 0xca, 0x21, 0x46, 0x03, 0x2f, 0x4e, 0x82, 0x6e, 0xcf, 0xb4, 0x16, 0xae, 0x8e, 0x82, 0x38,
+	0x60, 0x4e, 0xca, 0x6a, 0xfd, 0x28, 0x41, 0x2d, 0x83, 0xc6, 0x57, 0xe0, 0x2e, 0x12, 0x0f, 0xca,
+	0xc9, 0x67, 0x9b, 0xac, 0x28, 0x17, 0xc5, 0xef, 0x40, 0xc3, 0x2c, 0x26, 0xa4, 0xd5, 0xb4, 0xf2,
+	0xfe, 0xeb, 0xf7, 0x8f, 0x13, 0x8b, 0x56, 0x4d, 0x3d, 0x3c, 0x93, 0x94, 0x3c, 0x18, 0x3e, 0x36,
+	0x37, 0xb5, 0x35, 0x12, 0x82, 0xbe, 0x83, 0x92, 0x34, 0x94, 0xa7, 0x82, 0xaf, 0xbc, 0xb1, 0x54,
+	0x80, 0xa6, 0x22, 0x4d, 0x25, 0x62, 0x93, 0xd5, 0x3c, 0x91, 0xf5, 0xf3, 0x91, 0x42, 0x78, 0x47,
+	0x3e, 0x68, 0x50, 0x4e, 0x3a, 0x2d, 0xbd, 0x62, 0xf1, 0xa0, 0x35, 0xcc, 0x62, 0x42, 0xaa, 0xfe,
+	0x44, 0xa9, 0x3f, 0x36, 0x36, 0xc6, 0x50, 0x6f, 0x8e, 0x9e, 0x25, 0x0e, 0xc1, 0x39, 0x94, 0x93,
+	0x86, 0x4c, 0x4f, 0x53, 0xdc, 0xe5, 0x86, 0x59, 0x4c, 0xc8, 0xc6, 0x62, 0x6d, 0xdc, 0x58, 0xb8,
+	0x50, 0x8a, 0x6b, 0x8e, 0x24, 0x21, 0x2e, 0x6a, 0x7c, 0x63, 0xb9, 0x08, 0x4e, 0x65, 0x1b, 0x4a,
+	0x76, 0x81, 0xe4, 0xe6, 0xf9, 0xb0, 0xac, 0xfe, 0x55, 0x3c, 0xfc, 0x35, 0x00, 0x5b, 0x65, 0xbe,
+	0x1a, 0x9f, 0x0c, 0x00, 0x00,
 }
@@ -36,7 +36,20 @@ type EnqueueDeviceQueueItemRequest struct {
 	// Base64 encoded data (or use the jsonObject when an application codec has been configured).
 	Data []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
 	// String containing a JSON object (to be enqueued by the application codec).
-	JsonObject           string   `protobuf:"bytes,6,opt,name=jsonObject" json:"jsonObject,omitempty"`
+	JsonObject string `protobuf:"bytes,6,opt,name=jsonObject" json:"jsonObject,omitempty"`
+	// Timestamp (RFC3339) at which the item must be added to the
+	// device-queue. When empty or in the past, the item is enqueued directly.
+	ScheduledAt string `protobuf:"bytes,7,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+	// Timestamp (RFC3339) after which a scheduled item is dropped when it
+	// could not be enqueued (optional). It is ignored when the item is
+	// enqueued directly.
+	ExpiresAt string `protobuf:"bytes,8,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Retry policy of the confirmed item (optional, the retry policy of the
+	// device-profile is used when not set).
+	RetryPolicy *DownlinkRetryPolicy `protobuf:"bytes,9,opt,name=retryPolicy" json:"retryPolicy,omitempty"`
+	// Idempotency key (optional). An item enqueued with a key which was
+	// already used for the device (within the configured window) is rejected.
+	IdempotencyKey       string   `protobuf:"bytes,10,opt,name=idempotencyKey" json:"idempotencyKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -46,7 +59,7 @@ func (m *EnqueueDeviceQueueItemRequest) Reset()         { *m = EnqueueDeviceQueu
 func (m *EnqueueDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*EnqueueDeviceQueueItemRequest) ProtoMessage()    {}
 func (*EnqueueDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{0}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{0}
 }
 func (m *EnqueueDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EnqueueDeviceQueueItemRequest.Unmarshal(m, b)
@@ -108,7 +121,95 @@ func (m *EnqueueDeviceQueueItemRequest) GetJsonObject() string {
 	return ""
 }
 
+func (m *EnqueueDeviceQueueItemRequest) GetScheduledAt() string {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueItemRequest) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueItemRequest) GetRetryPolicy() *DownlinkRetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *EnqueueDeviceQueueItemRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type DownlinkRetryPolicy struct {
+	// Max number of attempts, including the first one (0 or 1 disables the
+	// retries, max 10).
+	MaxAttempts uint32 `protobuf:"varint,1,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	// Backoff (in seconds) before the first retry, doubled after each retry.
+	Backoff uint32 `protobuf:"varint,2,opt,name=backoff" json:"backoff,omitempty"`
+	// Encode the jsonObject again on each retry.
+	Reencode             bool     `protobuf:"varint,3,opt,name=reencode" json:"reencode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownlinkRetryPolicy) Reset()         { *m = DownlinkRetryPolicy{} }
+func (m *DownlinkRetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*DownlinkRetryPolicy) ProtoMessage()    {}
+func (*DownlinkRetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{1}
+}
+func (m *DownlinkRetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownlinkRetryPolicy.Unmarshal(m, b)
+}
+func (m *DownlinkRetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownlinkRetryPolicy.Marshal(b, m, deterministic)
+}
+func (dst *DownlinkRetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownlinkRetryPolicy.Merge(dst, src)
+}
+func (m *DownlinkRetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_DownlinkRetryPolicy.Size(m)
+}
+func (m *DownlinkRetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownlinkRetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownlinkRetryPolicy proto.InternalMessageInfo
+
+func (m *DownlinkRetryPolicy) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *DownlinkRetryPolicy) GetBackoff() uint32 {
+	if m != nil {
+		return m.Backoff
+	}
+	return 0
+}
+
+func (m *DownlinkRetryPolicy) GetReencode() bool {
+	if m != nil {
+		return m.Reencode
+	}
+	return false
+}
+
 type EnqueueDeviceQueueItemResponse struct {
+	// ID of the scheduled item (only set when the item was scheduled).
+	ScheduledItemID      int64    `protobuf:"varint,1,opt,name=scheduledItemID" json:"scheduledItemID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -118,7 +219,7 @@ func (m *EnqueueDeviceQueueItemResponse) Reset()         { *m = EnqueueDeviceQue
 func (m *EnqueueDeviceQueueItemResponse) String() string { return proto.CompactTextString(m) }
 func (*EnqueueDeviceQueueItemResponse) ProtoMessage()    {}
 func (*EnqueueDeviceQueueItemResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{1}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{2}
 }
 func (m *EnqueueDeviceQueueItemResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EnqueueDeviceQueueItemResponse.Unmarshal(m, b)
@@ -138,6 +239,13 @@ func (m *EnqueueDeviceQueueItemResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_EnqueueDeviceQueueItemResponse proto.InternalMessageInfo
 
+func (m *EnqueueDeviceQueueItemResponse) GetScheduledItemID() int64 {
+	if m != nil {
+		return m.ScheduledItemID
+	}
+	return 0
+}
+
 type FlushDeviceQueueRequest struct {
 	// Hex encoded DevEUI of the node.
 	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -150,7 +258,7 @@ func (m *FlushDeviceQueueRequest) Reset()         { *m = FlushDeviceQueueRequest
 func (m *FlushDeviceQueueRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushDeviceQueueRequest) ProtoMessage()    {}
 func (*FlushDeviceQueueRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{2}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{3}
 }
 func (m *FlushDeviceQueueRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FlushDeviceQueueRequest.Unmarshal(m, b)
@@ -187,7 +295,7 @@ func (m *FlushDeviceQueueResponse) Reset()         { *m = FlushDeviceQueueRespon
 func (m *FlushDeviceQueueResponse) String() string { return proto.CompactTextString(m) }
 func (*FlushDeviceQueueResponse) ProtoMessage()    {}
 func (*FlushDeviceQueueResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{3}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{4}
 }
 func (m *FlushDeviceQueueResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FlushDeviceQueueResponse.Unmarshal(m, b)
@@ -229,7 +337,7 @@ func (m *DeviceQueueItem) Reset()         { *m = DeviceQueueItem{} }
 func (m *DeviceQueueItem) String() string { return proto.CompactTextString(m) }
 func (*DeviceQueueItem) ProtoMessage()    {}
 func (*DeviceQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{4}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{5}
 }
 func (m *DeviceQueueItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceQueueItem.Unmarshal(m, b)
@@ -303,7 +411,7 @@ func (m *ListDeviceQueueItemsRequest) Reset()         { *m = ListDeviceQueueItem
 func (m *ListDeviceQueueItemsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListDeviceQueueItemsRequest) ProtoMessage()    {}
 func (*ListDeviceQueueItemsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{5}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{6}
 }
 func (m *ListDeviceQueueItemsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListDeviceQueueItemsRequest.Unmarshal(m, b)
@@ -341,7 +449,7 @@ func (m *ListDeviceQueueItemsResponse) Reset()         { *m = ListDeviceQueueIte
 func (m *ListDeviceQueueItemsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListDeviceQueueItemsResponse) ProtoMessage()    {}
 func (*ListDeviceQueueItemsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_deviceQueue_7a2ba650b6273f85, []int{6}
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{7}
 }
 func (m *ListDeviceQueueItemsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListDeviceQueueItemsResponse.Unmarshal(m, b)
@@ -368,143 +476,987 @@ func (m *ListDeviceQueueItemsResponse) GetItems() []*DeviceQueueItem {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*EnqueueDeviceQueueItemRequest)(nil), "api.EnqueueDeviceQueueItemRequest")
-	proto.RegisterType((*EnqueueDeviceQueueItemResponse)(nil), "api.EnqueueDeviceQueueItemResponse")
-	proto.RegisterType((*FlushDeviceQueueRequest)(nil), "api.FlushDeviceQueueRequest")
-	proto.RegisterType((*FlushDeviceQueueResponse)(nil), "api.FlushDeviceQueueResponse")
-	proto.RegisterType((*DeviceQueueItem)(nil), "api.DeviceQueueItem")
-	proto.RegisterType((*ListDeviceQueueItemsRequest)(nil), "api.ListDeviceQueueItemsRequest")
-	proto.RegisterType((*ListDeviceQueueItemsResponse)(nil), "api.ListDeviceQueueItemsResponse")
+type ScheduledDeviceQueueItem struct {
+	// ID of the scheduled item.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Hex encoded DevEUI of the device.
+	DevEUI string `protobuf:"bytes,2,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Random reference (used on ack notification).
+	Reference string `protobuf:"bytes,3,opt,name=reference" json:"reference,omitempty"`
+	// Is an ACK required from the device.
+	Confirmed bool `protobuf:"varint,4,opt,name=confirmed" json:"confirmed,omitempty"`
+	// FPort used (must be >0).
+	FPort uint32 `protobuf:"varint,5,opt,name=fPort" json:"fPort,omitempty"`
+	// Base64 encoded data.
+	Data []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	// Timestamp at which the item will be added to the device-queue.
+	ScheduledAt string `protobuf:"bytes,7,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+	// Timestamp after which the item is dropped (optional).
+	ExpiresAt string `protobuf:"bytes,8,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Timestamp when the item was created.
+	CreatedAt            string   `protobuf:"bytes,9,opt,name=createdAt" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ScheduledDeviceQueueItem) Reset()         { *m = ScheduledDeviceQueueItem{} }
+func (m *ScheduledDeviceQueueItem) String() string { return proto.CompactTextString(m) }
+func (*ScheduledDeviceQueueItem) ProtoMessage()    {}
+func (*ScheduledDeviceQueueItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{8}
+}
+func (m *ScheduledDeviceQueueItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduledDeviceQueueItem.Unmarshal(m, b)
+}
+func (m *ScheduledDeviceQueueItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduledDeviceQueueItem.Marshal(b, m, deterministic)
+}
+func (dst *ScheduledDeviceQueueItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduledDeviceQueueItem.Merge(dst, src)
+}
+func (m *ScheduledDeviceQueueItem) XXX_Size() int {
+	return xxx_messageInfo_ScheduledDeviceQueueItem.Size(m)
+}
+func (m *ScheduledDeviceQueueItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduledDeviceQueueItem.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_ScheduledDeviceQueueItem proto.InternalMessageInfo
 
-// DeviceQueueClient is the client API for DeviceQueue service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DeviceQueueClient interface {
-	// Enqueue adds the given item to the device-queue.
-	Enqueue(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error)
-	// Flush flushes the downlink device-queue.
-	Flush(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error)
-	// List lists the items in the device-queue.
-	List(ctx context.Context, in *ListDeviceQueueItemsRequest, opts ...grpc.CallOption) (*ListDeviceQueueItemsResponse, error)
+func (m *ScheduledDeviceQueueItem) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
 }
 
-type deviceQueueClient struct {
-	cc *grpc.ClientConn
+func (m *ScheduledDeviceQueueItem) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
 }
 
-func NewDeviceQueueClient(cc *grpc.ClientConn) DeviceQueueClient {
-	return &deviceQueueClient{cc}
+func (m *ScheduledDeviceQueueItem) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
 }
 
-func (c *deviceQueueClient) Enqueue(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error) {
-	out := new(EnqueueDeviceQueueItemResponse)
-	err := c.cc.Invoke(ctx, "/api.DeviceQueue/Enqueue", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScheduledDeviceQueueItem) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
 	}
-	return out, nil
+	return false
 }
 
-func (c *deviceQueueClient) Flush(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error) {
-	out := new(FlushDeviceQueueResponse)
-	err := c.cc.Invoke(ctx, "/api.DeviceQueue/Flush", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScheduledDeviceQueueItem) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
 	}
-	return out, nil
+	return 0
 }
 
-func (c *deviceQueueClient) List(ctx context.Context, in *ListDeviceQueueItemsRequest, opts ...grpc.CallOption) (*ListDeviceQueueItemsResponse, error) {
-	out := new(ListDeviceQueueItemsResponse)
-	err := c.cc.Invoke(ctx, "/api.DeviceQueue/List", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScheduledDeviceQueueItem) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return nil
 }
 
-// Server API for DeviceQueue service
-
-type DeviceQueueServer interface {
-	// Enqueue adds the given item to the device-queue.
-	Enqueue(context.Context, *EnqueueDeviceQueueItemRequest) (*EnqueueDeviceQueueItemResponse, error)
-	// Flush flushes the downlink device-queue.
-	Flush(context.Context, *FlushDeviceQueueRequest) (*FlushDeviceQueueResponse, error)
-	// List lists the items in the device-queue.
-	List(context.Context, *ListDeviceQueueItemsRequest) (*ListDeviceQueueItemsResponse, error)
+func (m *ScheduledDeviceQueueItem) GetScheduledAt() string {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return ""
 }
 
-func RegisterDeviceQueueServer(s *grpc.Server, srv DeviceQueueServer) {
-	s.RegisterService(&_DeviceQueue_serviceDesc, srv)
+func (m *ScheduledDeviceQueueItem) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
 }
 
-func _DeviceQueue_Enqueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnqueueDeviceQueueItemRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DeviceQueueServer).Enqueue(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.DeviceQueue/Enqueue",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceQueueServer).Enqueue(ctx, req.(*EnqueueDeviceQueueItemRequest))
+func (m *ScheduledDeviceQueueItem) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _DeviceQueue_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FlushDeviceQueueRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DeviceQueueServer).Flush(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.DeviceQueue/Flush",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceQueueServer).Flush(ctx, req.(*FlushDeviceQueueRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type ListScheduledDeviceQueueItemsRequest struct {
+	// Hex encoded DevEUI of the device.
+	DevEUI               string   `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _DeviceQueue_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDeviceQueueItemsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ListScheduledDeviceQueueItemsRequest) Reset()         { *m = ListScheduledDeviceQueueItemsRequest{} }
+func (m *ListScheduledDeviceQueueItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListScheduledDeviceQueueItemsRequest) ProtoMessage()    {}
+func (*ListScheduledDeviceQueueItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{9}
+}
+func (m *ListScheduledDeviceQueueItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListScheduledDeviceQueueItemsRequest.Unmarshal(m, b)
+}
+func (m *ListScheduledDeviceQueueItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListScheduledDeviceQueueItemsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListScheduledDeviceQueueItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListScheduledDeviceQueueItemsRequest.Merge(dst, src)
+}
+func (m *ListScheduledDeviceQueueItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListScheduledDeviceQueueItemsRequest.Size(m)
+}
+func (m *ListScheduledDeviceQueueItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListScheduledDeviceQueueItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListScheduledDeviceQueueItemsRequest proto.InternalMessageInfo
+
+func (m *ListScheduledDeviceQueueItemsRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
 	}
-	if interceptor == nil {
-		return srv.(DeviceQueueServer).List(ctx, in)
+	return ""
+}
+
+type ListScheduledDeviceQueueItemsResponse struct {
+	Items                []*ScheduledDeviceQueueItem `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ListScheduledDeviceQueueItemsResponse) Reset()         { *m = ListScheduledDeviceQueueItemsResponse{} }
+func (m *ListScheduledDeviceQueueItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListScheduledDeviceQueueItemsResponse) ProtoMessage()    {}
+func (*ListScheduledDeviceQueueItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{10}
+}
+func (m *ListScheduledDeviceQueueItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListScheduledDeviceQueueItemsResponse.Unmarshal(m, b)
+}
+func (m *ListScheduledDeviceQueueItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListScheduledDeviceQueueItemsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListScheduledDeviceQueueItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListScheduledDeviceQueueItemsResponse.Merge(dst, src)
+}
+func (m *ListScheduledDeviceQueueItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListScheduledDeviceQueueItemsResponse.Size(m)
+}
+func (m *ListScheduledDeviceQueueItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListScheduledDeviceQueueItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListScheduledDeviceQueueItemsResponse proto.InternalMessageInfo
+
+func (m *ListScheduledDeviceQueueItemsResponse) GetItems() []*ScheduledDeviceQueueItem {
+	if m != nil {
+		return m.Items
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.DeviceQueue/List",
+	return nil
+}
+
+type CancelScheduledDeviceQueueItemRequest struct {
+	// Hex encoded DevEUI of the device.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// ID of the scheduled item.
+	Id                   int64    `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelScheduledDeviceQueueItemRequest) Reset()         { *m = CancelScheduledDeviceQueueItemRequest{} }
+func (m *CancelScheduledDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelScheduledDeviceQueueItemRequest) ProtoMessage()    {}
+func (*CancelScheduledDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{11}
+}
+func (m *CancelScheduledDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelScheduledDeviceQueueItemRequest.Unmarshal(m, b)
+}
+func (m *CancelScheduledDeviceQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelScheduledDeviceQueueItemRequest.Marshal(b, m, deterministic)
+}
+func (dst *CancelScheduledDeviceQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelScheduledDeviceQueueItemRequest.Merge(dst, src)
+}
+func (m *CancelScheduledDeviceQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelScheduledDeviceQueueItemRequest.Size(m)
+}
+func (m *CancelScheduledDeviceQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelScheduledDeviceQueueItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelScheduledDeviceQueueItemRequest proto.InternalMessageInfo
+
+func (m *CancelScheduledDeviceQueueItemRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceQueueServer).List(ctx, req.(*ListDeviceQueueItemsRequest))
+	return ""
+}
+
+func (m *CancelScheduledDeviceQueueItemRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _DeviceQueue_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "api.DeviceQueue",
-	HandlerType: (*DeviceQueueServer)(nil),
+type CancelScheduledDeviceQueueItemResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelScheduledDeviceQueueItemResponse) Reset() {
+	*m = CancelScheduledDeviceQueueItemResponse{}
+}
+func (m *CancelScheduledDeviceQueueItemResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelScheduledDeviceQueueItemResponse) ProtoMessage()    {}
+func (*CancelScheduledDeviceQueueItemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{12}
+}
+func (m *CancelScheduledDeviceQueueItemResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelScheduledDeviceQueueItemResponse.Unmarshal(m, b)
+}
+func (m *CancelScheduledDeviceQueueItemResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelScheduledDeviceQueueItemResponse.Marshal(b, m, deterministic)
+}
+func (dst *CancelScheduledDeviceQueueItemResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelScheduledDeviceQueueItemResponse.Merge(dst, src)
+}
+func (m *CancelScheduledDeviceQueueItemResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelScheduledDeviceQueueItemResponse.Size(m)
+}
+func (m *CancelScheduledDeviceQueueItemResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelScheduledDeviceQueueItemResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelScheduledDeviceQueueItemResponse proto.InternalMessageInfo
+
+type GetDeviceQueueItemStatusRequest struct {
+	// Hex encoded DevEUI of the device.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Reference given when enqueueing the item.
+	Reference            string   `protobuf:"bytes,2,opt,name=reference" json:"reference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceQueueItemStatusRequest) Reset()         { *m = GetDeviceQueueItemStatusRequest{} }
+func (m *GetDeviceQueueItemStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemStatusRequest) ProtoMessage()    {}
+func (*GetDeviceQueueItemStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{13}
+}
+func (m *GetDeviceQueueItemStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceQueueItemStatusRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceQueueItemStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceQueueItemStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetDeviceQueueItemStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceQueueItemStatusRequest.Merge(dst, src)
+}
+func (m *GetDeviceQueueItemStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceQueueItemStatusRequest.Size(m)
+}
+func (m *GetDeviceQueueItemStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceQueueItemStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceQueueItemStatusRequest proto.InternalMessageInfo
+
+func (m *GetDeviceQueueItemStatusRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+type GetDeviceQueueItemStatusResponse struct {
+	// Hex encoded DevEUI of the device.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Reference given when enqueueing the item.
+	Reference string `protobuf:"bytes,2,opt,name=reference" json:"reference,omitempty"`
+	// Is an ACK required from the device.
+	Confirmed bool `protobuf:"varint,3,opt,name=confirmed" json:"confirmed,omitempty"`
+	// FCnt of the queue item (not set while the item is scheduled).
+	FCnt uint32 `protobuf:"varint,4,opt,name=fCnt" json:"fCnt,omitempty"`
+	// ID of the scheduled item (only set when the item was scheduled).
+	ScheduledItemID int64 `protobuf:"varint,5,opt,name=scheduledItemID" json:"scheduledItemID,omitempty"`
+	// Delivery status: QUEUED, SENT, UNKNOWN, ACKNOWLEDGED, NACKED,
+	// EXPIRED, FLUSHED or FAILED.
+	Status string `protobuf:"bytes,6,opt,name=status" json:"status,omitempty"`
+	// Timestamp when the item was enqueued or scheduled.
+	CreatedAt string `protobuf:"bytes,7,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Timestamp when the status was last updated.
+	UpdatedAt string `protobuf:"bytes,8,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// Timestamp when the item was transmitted (empty when not yet known).
+	SentAt string `protobuf:"bytes,9,opt,name=sentAt" json:"sentAt,omitempty"`
+	// Timestamp when the item was acknowledged, nacked, expired, flushed or
+	// failed (empty when still pending).
+	CompletedAt string `protobuf:"bytes,10,opt,name=completedAt" json:"completedAt,omitempty"`
+	// Current attempt (greater than 1 when the item has been retried).
+	Attempt uint32 `protobuf:"varint,11,opt,name=attempt" json:"attempt,omitempty"`
+	// Max number of attempts of the retry policy.
+	MaxAttempts          uint32   `protobuf:"varint,12,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceQueueItemStatusResponse) Reset()         { *m = GetDeviceQueueItemStatusResponse{} }
+func (m *GetDeviceQueueItemStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemStatusResponse) ProtoMessage()    {}
+func (*GetDeviceQueueItemStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{14}
+}
+func (m *GetDeviceQueueItemStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceQueueItemStatusResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceQueueItemStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceQueueItemStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetDeviceQueueItemStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceQueueItemStatusResponse.Merge(dst, src)
+}
+func (m *GetDeviceQueueItemStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceQueueItemStatusResponse.Size(m)
+}
+func (m *GetDeviceQueueItemStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceQueueItemStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceQueueItemStatusResponse proto.InternalMessageInfo
+
+func (m *GetDeviceQueueItemStatusResponse) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetScheduledItemID() int64 {
+	if m != nil {
+		return m.ScheduledItemID
+	}
+	return 0
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetSentAt() string {
+	if m != nil {
+		return m.SentAt
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetCompletedAt() string {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return ""
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *GetDeviceQueueItemStatusResponse) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+type EnqueueDeviceQueueBatchRequest struct {
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Hex encoded DevEUIs of the devices (optional).
+	DevEUIs []string `protobuf:"bytes,2,rep,name=devEUIs" json:"devEUIs,omitempty"`
+	// ID of the device-profile of the devices (optional, only used when no
+	// devEUIs are given). When no devEUIs, no device-profile ID and no tags
+	// are given, the item is enqueued for all the devices of the application.
+	DeviceProfileID string `protobuf:"bytes,3,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
+	// Random reference (used on ack notification).
+	Reference string `protobuf:"bytes,4,opt,name=reference" json:"reference,omitempty"`
+	// Is an ACK required from the devices.
+	Confirmed bool `protobuf:"varint,5,opt,name=confirmed" json:"confirmed,omitempty"`
+	// FPort used (must be >0)
+	FPort uint32 `protobuf:"varint,6,opt,name=fPort" json:"fPort,omitempty"`
+	// Base64 encoded data (or use the jsonObject when an application codec has been configured).
+	Data []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	// String containing a JSON object (to be encoded by the codec of each device).
+	JsonObject string `protobuf:"bytes,8,opt,name=jsonObject" json:"jsonObject,omitempty"`
+	// Retry policy of the confirmed items (optional, the retry policy of the
+	// device-profile is used when not set).
+	RetryPolicy *DownlinkRetryPolicy `protobuf:"bytes,9,opt,name=retryPolicy" json:"retryPolicy,omitempty"`
+	// Idempotency key (optional). Devices for which the key was already used
+	// (within the configured window) are reported with an error.
+	IdempotencyKey string `protobuf:"bytes,10,opt,name=idempotencyKey" json:"idempotencyKey,omitempty"`
+	// Tags of the devices (optional, only used when no devEUIs are given).
+	// The item is enqueued for the devices having all the given tags.
+	Tags                 map[string]string `protobuf:"bytes,11,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) Reset()         { *m = EnqueueDeviceQueueBatchRequest{} }
+func (m *EnqueueDeviceQueueBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueBatchRequest) ProtoMessage()    {}
+func (*EnqueueDeviceQueueBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{15}
+}
+func (m *EnqueueDeviceQueueBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchRequest.Unmarshal(m, b)
+}
+func (m *EnqueueDeviceQueueBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchRequest.Marshal(b, m, deterministic)
+}
+func (dst *EnqueueDeviceQueueBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueDeviceQueueBatchRequest.Merge(dst, src)
+}
+func (m *EnqueueDeviceQueueBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchRequest.Size(m)
+}
+func (m *EnqueueDeviceQueueBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueDeviceQueueBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueDeviceQueueBatchRequest proto.InternalMessageInfo
+
+func (m *EnqueueDeviceQueueBatchRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetDevEUIs() []string {
+	if m != nil {
+		return m.DevEUIs
+	}
+	return nil
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetDeviceProfileID() string {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetJsonObject() string {
+	if m != nil {
+		return m.JsonObject
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetRetryPolicy() *DownlinkRetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueBatchRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type EnqueueDeviceQueueBatchResult struct {
+	// Hex encoded DevEUI of the device.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Downlink frame-counter of the enqueued item.
+	FCnt uint32 `protobuf:"varint,2,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Error (empty when the item was enqueued).
+	Error                string   `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnqueueDeviceQueueBatchResult) Reset()         { *m = EnqueueDeviceQueueBatchResult{} }
+func (m *EnqueueDeviceQueueBatchResult) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueBatchResult) ProtoMessage()    {}
+func (*EnqueueDeviceQueueBatchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{16}
+}
+func (m *EnqueueDeviceQueueBatchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchResult.Unmarshal(m, b)
+}
+func (m *EnqueueDeviceQueueBatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchResult.Marshal(b, m, deterministic)
+}
+func (dst *EnqueueDeviceQueueBatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueDeviceQueueBatchResult.Merge(dst, src)
+}
+func (m *EnqueueDeviceQueueBatchResult) XXX_Size() int {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchResult.Size(m)
+}
+func (m *EnqueueDeviceQueueBatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueDeviceQueueBatchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueDeviceQueueBatchResult proto.InternalMessageInfo
+
+func (m *EnqueueDeviceQueueBatchResult) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *EnqueueDeviceQueueBatchResult) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *EnqueueDeviceQueueBatchResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type EnqueueDeviceQueueBatchResponse struct {
+	// Number of devices for which the item was enqueued.
+	EnqueueCount uint32 `protobuf:"varint,1,opt,name=enqueueCount" json:"enqueueCount,omitempty"`
+	// Number of devices for which enqueueing the item failed.
+	ErrorCount uint32 `protobuf:"varint,2,opt,name=errorCount" json:"errorCount,omitempty"`
+	// Result per device.
+	Result               []*EnqueueDeviceQueueBatchResult `protobuf:"bytes,3,rep,name=result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *EnqueueDeviceQueueBatchResponse) Reset()         { *m = EnqueueDeviceQueueBatchResponse{} }
+func (m *EnqueueDeviceQueueBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueBatchResponse) ProtoMessage()    {}
+func (*EnqueueDeviceQueueBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_deviceQueue_c12affdde13dc2cb, []int{17}
+}
+func (m *EnqueueDeviceQueueBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchResponse.Unmarshal(m, b)
+}
+func (m *EnqueueDeviceQueueBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchResponse.Marshal(b, m, deterministic)
+}
+func (dst *EnqueueDeviceQueueBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueDeviceQueueBatchResponse.Merge(dst, src)
+}
+func (m *EnqueueDeviceQueueBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_EnqueueDeviceQueueBatchResponse.Size(m)
+}
+func (m *EnqueueDeviceQueueBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueDeviceQueueBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueDeviceQueueBatchResponse proto.InternalMessageInfo
+
+func (m *EnqueueDeviceQueueBatchResponse) GetEnqueueCount() uint32 {
+	if m != nil {
+		return m.EnqueueCount
+	}
+	return 0
+}
+
+func (m *EnqueueDeviceQueueBatchResponse) GetErrorCount() uint32 {
+	if m != nil {
+		return m.ErrorCount
+	}
+	return 0
+}
+
+func (m *EnqueueDeviceQueueBatchResponse) GetResult() []*EnqueueDeviceQueueBatchResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EnqueueDeviceQueueItemRequest)(nil), "api.EnqueueDeviceQueueItemRequest")
+	proto.RegisterType((*DownlinkRetryPolicy)(nil), "api.DownlinkRetryPolicy")
+	proto.RegisterType((*EnqueueDeviceQueueItemResponse)(nil), "api.EnqueueDeviceQueueItemResponse")
+	proto.RegisterType((*FlushDeviceQueueRequest)(nil), "api.FlushDeviceQueueRequest")
+	proto.RegisterType((*FlushDeviceQueueResponse)(nil), "api.FlushDeviceQueueResponse")
+	proto.RegisterType((*DeviceQueueItem)(nil), "api.DeviceQueueItem")
+	proto.RegisterType((*ListDeviceQueueItemsRequest)(nil), "api.ListDeviceQueueItemsRequest")
+	proto.RegisterType((*ListDeviceQueueItemsResponse)(nil), "api.ListDeviceQueueItemsResponse")
+	proto.RegisterType((*ScheduledDeviceQueueItem)(nil), "api.ScheduledDeviceQueueItem")
+	proto.RegisterType((*ListScheduledDeviceQueueItemsRequest)(nil), "api.ListScheduledDeviceQueueItemsRequest")
+	proto.RegisterType((*ListScheduledDeviceQueueItemsResponse)(nil), "api.ListScheduledDeviceQueueItemsResponse")
+	proto.RegisterType((*CancelScheduledDeviceQueueItemRequest)(nil), "api.CancelScheduledDeviceQueueItemRequest")
+	proto.RegisterType((*CancelScheduledDeviceQueueItemResponse)(nil), "api.CancelScheduledDeviceQueueItemResponse")
+	proto.RegisterType((*GetDeviceQueueItemStatusRequest)(nil), "api.GetDeviceQueueItemStatusRequest")
+	proto.RegisterType((*GetDeviceQueueItemStatusResponse)(nil), "api.GetDeviceQueueItemStatusResponse")
+	proto.RegisterType((*EnqueueDeviceQueueBatchRequest)(nil), "api.EnqueueDeviceQueueBatchRequest")
+	proto.RegisterMapType((map[string]string)(nil), "api.EnqueueDeviceQueueBatchRequest.TagsEntry")
+	proto.RegisterType((*EnqueueDeviceQueueBatchResult)(nil), "api.EnqueueDeviceQueueBatchResult")
+	proto.RegisterType((*EnqueueDeviceQueueBatchResponse)(nil), "api.EnqueueDeviceQueueBatchResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DeviceQueueClient is the client API for DeviceQueue service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DeviceQueueClient interface {
+	// Enqueue adds the given item to the device-queue.
+	Enqueue(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error)
+	// Flush flushes the downlink device-queue.
+	Flush(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error)
+	// List lists the items in the device-queue.
+	List(ctx context.Context, in *ListDeviceQueueItemsRequest, opts ...grpc.CallOption) (*ListDeviceQueueItemsResponse, error)
+	// ListScheduled lists the pending scheduled items of the device.
+	ListScheduled(ctx context.Context, in *ListScheduledDeviceQueueItemsRequest, opts ...grpc.CallOption) (*ListScheduledDeviceQueueItemsResponse, error)
+	// CancelScheduled cancels the given pending scheduled item.
+	CancelScheduled(ctx context.Context, in *CancelScheduledDeviceQueueItemRequest, opts ...grpc.CallOption) (*CancelScheduledDeviceQueueItemResponse, error)
+	// GetStatus returns the delivery status of the most recent downlink
+	// enqueued with the given reference.
+	GetStatus(ctx context.Context, in *GetDeviceQueueItemStatusRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemStatusResponse, error)
+	// EnqueueBatch adds the given item to the device-queue of multiple
+	// devices of an application.
+	EnqueueBatch(ctx context.Context, in *EnqueueDeviceQueueBatchRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueBatchResponse, error)
+}
+
+type deviceQueueClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDeviceQueueClient(cc *grpc.ClientConn) DeviceQueueClient {
+	return &deviceQueueClient{cc}
+}
+
+func (c *deviceQueueClient) Enqueue(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error) {
+	out := new(EnqueueDeviceQueueItemResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/Enqueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceQueueClient) Flush(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error) {
+	out := new(FlushDeviceQueueResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/Flush", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceQueueClient) List(ctx context.Context, in *ListDeviceQueueItemsRequest, opts ...grpc.CallOption) (*ListDeviceQueueItemsResponse, error) {
+	out := new(ListDeviceQueueItemsResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceQueueClient) ListScheduled(ctx context.Context, in *ListScheduledDeviceQueueItemsRequest, opts ...grpc.CallOption) (*ListScheduledDeviceQueueItemsResponse, error) {
+	out := new(ListScheduledDeviceQueueItemsResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/ListScheduled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceQueueClient) CancelScheduled(ctx context.Context, in *CancelScheduledDeviceQueueItemRequest, opts ...grpc.CallOption) (*CancelScheduledDeviceQueueItemResponse, error) {
+	out := new(CancelScheduledDeviceQueueItemResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/CancelScheduled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceQueueClient) GetStatus(ctx context.Context, in *GetDeviceQueueItemStatusRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemStatusResponse, error) {
+	out := new(GetDeviceQueueItemStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceQueueClient) EnqueueBatch(ctx context.Context, in *EnqueueDeviceQueueBatchRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueBatchResponse, error) {
+	out := new(EnqueueDeviceQueueBatchResponse)
+	err := c.cc.Invoke(ctx, "/api.DeviceQueue/EnqueueBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for DeviceQueue service
+
+type DeviceQueueServer interface {
+	// Enqueue adds the given item to the device-queue.
+	Enqueue(context.Context, *EnqueueDeviceQueueItemRequest) (*EnqueueDeviceQueueItemResponse, error)
+	// Flush flushes the downlink device-queue.
+	Flush(context.Context, *FlushDeviceQueueRequest) (*FlushDeviceQueueResponse, error)
+	// List lists the items in the device-queue.
+	List(context.Context, *ListDeviceQueueItemsRequest) (*ListDeviceQueueItemsResponse, error)
+	// ListScheduled lists the pending scheduled items of the device.
+	ListScheduled(context.Context, *ListScheduledDeviceQueueItemsRequest) (*ListScheduledDeviceQueueItemsResponse, error)
+	// CancelScheduled cancels the given pending scheduled item.
+	CancelScheduled(context.Context, *CancelScheduledDeviceQueueItemRequest) (*CancelScheduledDeviceQueueItemResponse, error)
+	// GetStatus returns the delivery status of the most recent downlink
+	// enqueued with the given reference.
+	GetStatus(context.Context, *GetDeviceQueueItemStatusRequest) (*GetDeviceQueueItemStatusResponse, error)
+	// EnqueueBatch adds the given item to the device-queue of multiple
+	// devices of an application.
+	EnqueueBatch(context.Context, *EnqueueDeviceQueueBatchRequest) (*EnqueueDeviceQueueBatchResponse, error)
+}
+
+func RegisterDeviceQueueServer(s *grpc.Server, srv DeviceQueueServer) {
+	s.RegisterService(&_DeviceQueue_serviceDesc, srv)
+}
+
+func _DeviceQueue_Enqueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).Enqueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/Enqueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).Enqueue(ctx, req.(*EnqueueDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceQueue_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushDeviceQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/Flush",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).Flush(ctx, req.(*FlushDeviceQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceQueue_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceQueueItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).List(ctx, req.(*ListDeviceQueueItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceQueue_ListScheduled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledDeviceQueueItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).ListScheduled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/ListScheduled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).ListScheduled(ctx, req.(*ListScheduledDeviceQueueItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceQueue_CancelScheduled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).CancelScheduled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/CancelScheduled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).CancelScheduled(ctx, req.(*CancelScheduledDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceQueue_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceQueueItemStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).GetStatus(ctx, req.(*GetDeviceQueueItemStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceQueue_EnqueueBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDeviceQueueBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceQueueServer).EnqueueBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DeviceQueue/EnqueueBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceQueueServer).EnqueueBatch(ctx, req.(*EnqueueDeviceQueueBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DeviceQueue_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.DeviceQueue",
+	HandlerType: (*DeviceQueueServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "Enqueue",
@@ -518,40 +1470,99 @@ var _DeviceQueue_serviceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _DeviceQueue_List_Handler,
 		},
+		{
+			MethodName: "ListScheduled",
+			Handler:    _DeviceQueue_ListScheduled_Handler,
+		},
+		{
+			MethodName: "CancelScheduled",
+			Handler:    _DeviceQueue_CancelScheduled_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _DeviceQueue_GetStatus_Handler,
+		},
+		{
+			MethodName: "EnqueueBatch",
+			Handler:    _DeviceQueue_EnqueueBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "deviceQueue.proto",
 }
 
-func init() { proto.RegisterFile("deviceQueue.proto", fileDescriptor_deviceQueue_7a2ba650b6273f85) }
-
-var fileDescriptor_deviceQueue_7a2ba650b6273f85 = []byte{
-	// 425 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x51, 0x6e, 0xd4, 0x30,
-	0x10, 0x95, 0x77, 0xb3, 0xdb, 0x76, 0x0a, 0x42, 0x8c, 0x2a, 0xb0, 0xd2, 0x6c, 0x65, 0x52, 0x09,
-	0x45, 0xfb, 0xb1, 0x11, 0x45, 0xfc, 0xf0, 0x0b, 0x45, 0x2a, 0x42, 0x02, 0x22, 0x71, 0x80, 0x34,
-	0x99, 0x14, 0x57, 0xad, 0x9d, 0xc6, 0x4e, 0x3f, 0x40, 0xfc, 0x70, 0x05, 0x8e, 0xc0, 0x39, 0x38,
-	0x05, 0x57, 0x40, 0x9c, 0x03, 0xc5, 0x89, 0xb4, 0x61, 0xdb, 0xa4, 0x7f, 0xf6, 0xcc, 0x7b, 0xf3,
-	0x3c, 0x6f, 0xc6, 0xf0, 0x30, 0xa7, 0x6b, 0x99, 0xd1, 0xc7, 0x9a, 0x6a, 0x5a, 0x95, 0x95, 0xb6,
-	0x1a, 0xa7, 0x69, 0x29, 0xfd, 0xe0, 0x4c, 0xeb, 0xb3, 0x0b, 0x8a, 0xd3, 0x52, 0xc6, 0xa9, 0x52,
-	0xda, 0xa6, 0x56, 0x6a, 0x65, 0x5a, 0x48, 0xf8, 0x8b, 0xc1, 0xe2, 0x58, 0x5d, 0x35, 0xa4, 0xd7,
-	0x6b, 0xfe, 0x89, 0xa5, 0xcb, 0x84, 0xae, 0x6a, 0x32, 0x16, 0x1f, 0xc1, 0x3c, 0xa7, 0xeb, 0xe3,
-	0x4f, 0x27, 0x9c, 0x09, 0x16, 0xed, 0x24, 0xdd, 0x0d, 0x03, 0xd8, 0xa9, 0xa8, 0xa0, 0x8a, 0x54,
-	0x46, 0x7c, 0xe2, 0x52, 0xeb, 0x40, 0x93, 0xcd, 0xb4, 0x2a, 0x64, 0x75, 0x49, 0x39, 0x9f, 0x0a,
-	0x16, 0x6d, 0x27, 0xeb, 0x00, 0xee, 0xc1, 0xac, 0xf8, 0xa0, 0x2b, 0xcb, 0x3d, 0xc1, 0xa2, 0xfb,
-	0x49, 0x7b, 0x41, 0x04, 0x2f, 0x4f, 0x6d, 0xca, 0x67, 0x82, 0x45, 0xf7, 0x12, 0x77, 0xc6, 0x03,
-	0x80, 0x73, 0xa3, 0xd5, 0xfb, 0xd3, 0x73, 0xca, 0x2c, 0x9f, 0x3b, 0x99, 0x5e, 0x24, 0x14, 0x70,
-	0x30, 0xf4, 0x7c, 0x53, 0x6a, 0x65, 0x28, 0x7c, 0x06, 0x8f, 0xdf, 0x5c, 0xd4, 0xe6, 0x73, 0x2f,
-	0x7f, 0x47, 0x6b, 0xa1, 0x0f, 0xfc, 0x26, 0xa5, 0x2b, 0xf7, 0x93, 0xc1, 0x83, 0x0d, 0xa9, 0x5e,
-	0x9d, 0xc9, 0xb0, 0x45, 0xd3, 0x51, 0x8b, 0xbc, 0x41, 0x8b, 0xe6, 0xb7, 0x59, 0xb4, 0xd5, 0xb3,
-	0x08, 0xc1, 0x2b, 0x5e, 0x29, 0xcb, 0xb7, 0x1d, 0xd0, 0x9d, 0xc3, 0x17, 0xb0, 0xff, 0x4e, 0x1a,
-	0xbb, 0xf1, 0x50, 0x73, 0x57, 0xe3, 0x6f, 0x21, 0xb8, 0x9d, 0xd6, 0x36, 0x8f, 0x4b, 0x98, 0xc9,
-	0x26, 0xc0, 0x99, 0x98, 0x46, 0xbb, 0x47, 0x7b, 0xab, 0xb4, 0x94, 0xab, 0x4d, 0xe3, 0x5b, 0xc8,
-	0xd1, 0xdf, 0x09, 0xec, 0xf6, 0x52, 0xf8, 0x05, 0xb6, 0xba, 0x49, 0x61, 0xe8, 0x78, 0xa3, 0x6b,
-	0xe7, 0x1f, 0x8e, 0x62, 0xba, 0x61, 0x3c, 0xfd, 0xfe, 0xfb, 0xcf, 0x8f, 0x89, 0x08, 0xf7, 0xdd,
-	0x76, 0xb7, 0x1f, 0xc0, 0xc4, 0x5f, 0xdb, 0x6e, 0xbe, 0xc5, 0x8e, 0xfb, 0x92, 0x2d, 0x51, 0xc2,
-	0xcc, 0x0d, 0x14, 0x03, 0x57, 0x75, 0x60, 0x1f, 0xfc, 0xc5, 0x40, 0xb6, 0x53, 0x3b, 0x74, 0x6a,
-	0x8b, 0xe5, 0x98, 0x1a, 0x96, 0xe0, 0x35, 0x16, 0xa2, 0x70, 0xb5, 0x46, 0x86, 0xe0, 0x3f, 0x19,
-	0x41, 0xfc, 0xaf, 0x88, 0x63, 0x8a, 0xa7, 0x73, 0xf7, 0x93, 0x9f, 0xff, 0x0b, 0x00, 0x00, 0xff,
-	0xff, 0xbf, 0xca, 0x4f, 0x78, 0x01, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("deviceQueue.proto", fileDescriptor_deviceQueue_c12affdde13dc2cb) }
+
+var fileDescriptor_deviceQueue_c12affdde13dc2cb = []byte{
+	// 1107 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcd, 0x6e, 0xe3, 0x54,
+	0x14, 0x96, 0x9d, 0xbf, 0xe6, 0xa4, 0x99, 0xc2, 0xa5, 0x02, 0xcb, 0xd3, 0xcc, 0x18, 0x37, 0xed,
+	0x84, 0xc0, 0x34, 0xa2, 0x23, 0x04, 0xea, 0x02, 0xa9, 0xb4, 0x65, 0xd4, 0x01, 0x69, 0x8a, 0x07,
+	0xc4, 0x86, 0x8d, 0x6b, 0xdf, 0xb4, 0x9e, 0x3a, 0xb6, 0xc7, 0xbe, 0x2e, 0x13, 0xaa, 0xd9, 0x20,
+	0xc4, 0x8e, 0x15, 0x62, 0x8b, 0x84, 0x78, 0x07, 0x5e, 0x84, 0x27, 0x40, 0xe2, 0x01, 0x78, 0x02,
+	0x84, 0xee, 0x4f, 0x1c, 0xdb, 0x8d, 0xed, 0x88, 0x51, 0x67, 0xe7, 0x7b, 0xee, 0xf9, 0xf9, 0x72,
+	0x7e, 0xbe, 0x73, 0x03, 0xaf, 0xdb, 0xf8, 0xd2, 0xb1, 0xf0, 0x17, 0x31, 0x8e, 0xf1, 0x4e, 0x10,
+	0xfa, 0xc4, 0x47, 0x35, 0x33, 0x70, 0xd4, 0x8d, 0x33, 0xdf, 0x3f, 0x73, 0xf1, 0xc8, 0x0c, 0x9c,
+	0x91, 0xe9, 0x79, 0x3e, 0x31, 0x89, 0xe3, 0x7b, 0x11, 0x57, 0xd1, 0xff, 0x92, 0xa1, 0x77, 0xe4,
+	0x3d, 0xa3, 0x46, 0x87, 0x73, 0xfb, 0x63, 0x82, 0x27, 0x06, 0x7e, 0x16, 0xe3, 0x88, 0xa0, 0x37,
+	0xa1, 0x69, 0xe3, 0xcb, 0xa3, 0xaf, 0x8e, 0x15, 0x49, 0x93, 0x06, 0x6d, 0x43, 0x9c, 0xd0, 0x06,
+	0xb4, 0x43, 0x3c, 0xc6, 0x21, 0xf6, 0x2c, 0xac, 0xc8, 0xec, 0x6a, 0x2e, 0xa0, 0xb7, 0x96, 0xef,
+	0x8d, 0x9d, 0x70, 0x82, 0x6d, 0xa5, 0xa6, 0x49, 0x83, 0x15, 0x63, 0x2e, 0x40, 0xeb, 0xd0, 0x18,
+	0x9f, 0xf8, 0x21, 0x51, 0xea, 0x9a, 0x34, 0xe8, 0x1a, 0xfc, 0x80, 0x10, 0xd4, 0x6d, 0x93, 0x98,
+	0x4a, 0x43, 0x93, 0x06, 0xab, 0x06, 0xfb, 0x46, 0x77, 0x00, 0x9e, 0x46, 0xbe, 0xf7, 0xf8, 0xf4,
+	0x29, 0xb6, 0x88, 0xd2, 0x64, 0x61, 0x52, 0x12, 0xa4, 0x41, 0x27, 0xb2, 0xce, 0xb1, 0x1d, 0xbb,
+	0xd8, 0xde, 0x27, 0x4a, 0x8b, 0x29, 0xa4, 0x45, 0x14, 0x09, 0x7e, 0x1e, 0x38, 0x21, 0x8e, 0xf6,
+	0x89, 0xb2, 0xc2, 0x71, 0x26, 0x02, 0xb4, 0x07, 0x9d, 0x10, 0x93, 0x70, 0x7a, 0xe2, 0xbb, 0x8e,
+	0x35, 0x55, 0xda, 0x9a, 0x34, 0xe8, 0xec, 0x2a, 0x3b, 0x66, 0xe0, 0xec, 0x1c, 0xfa, 0xdf, 0x7a,
+	0xae, 0xe3, 0x5d, 0x18, 0xf3, 0x7b, 0x23, 0xad, 0x8c, 0xb6, 0xe1, 0x96, 0x63, 0xe3, 0x49, 0xe0,
+	0x13, 0xec, 0x59, 0xd3, 0xcf, 0xf0, 0x54, 0x01, 0xe6, 0x3e, 0x27, 0xd5, 0x27, 0xf0, 0xc6, 0x02,
+	0x5f, 0x14, 0xfa, 0xc4, 0x7c, 0xbe, 0x4f, 0x08, 0x9e, 0x04, 0x24, 0x62, 0xd9, 0xed, 0x1a, 0x69,
+	0x11, 0x52, 0xa0, 0x75, 0x6a, 0x5a, 0x17, 0xfe, 0x78, 0xcc, 0x12, 0xdc, 0x35, 0x66, 0x47, 0xa4,
+	0xc2, 0x4a, 0x88, 0xb1, 0x67, 0xf9, 0x36, 0x16, 0xd9, 0x4d, 0xce, 0xfa, 0x23, 0xb8, 0x53, 0x54,
+	0xd1, 0x28, 0xf0, 0xbd, 0x08, 0xa3, 0x01, 0xac, 0x25, 0x19, 0xa2, 0x17, 0xc7, 0x87, 0x2c, 0x7a,
+	0xcd, 0xc8, 0x8b, 0xf5, 0xf7, 0xe1, 0xad, 0x4f, 0xdd, 0x38, 0x3a, 0x4f, 0x79, 0xaa, 0xe8, 0x0b,
+	0x5d, 0x05, 0xe5, 0xba, 0x09, 0x0f, 0xac, 0xff, 0x2e, 0xc1, 0x5a, 0x0e, 0x54, 0xca, 0x8f, 0x5c,
+	0xdc, 0x5f, 0xb5, 0xd2, 0xfe, 0xaa, 0x17, 0xf6, 0x57, 0x73, 0x51, 0x7f, 0xb5, 0x52, 0xfd, 0x85,
+	0xa0, 0x3e, 0x3e, 0xf0, 0x78, 0x63, 0x74, 0x0d, 0xf6, 0xad, 0x7f, 0x00, 0xb7, 0x3f, 0x77, 0x22,
+	0x92, 0x03, 0x1a, 0x55, 0xfd, 0xf0, 0x47, 0xb0, 0xb1, 0xd8, 0x4c, 0x64, 0x7d, 0x08, 0x0d, 0x87,
+	0x0a, 0x14, 0x49, 0xab, 0x0d, 0x3a, 0xbb, 0xeb, 0xbc, 0xc9, 0x72, 0x25, 0xe2, 0x2a, 0xfa, 0x8f,
+	0x32, 0x28, 0x4f, 0x66, 0xb5, 0xc8, 0x67, 0xec, 0x16, 0xc8, 0x8e, 0x2d, 0x2a, 0x26, 0x3b, 0xf6,
+	0xcd, 0x66, 0xb0, 0xb1, 0x28, 0x83, 0xcd, 0x54, 0x06, 0x5f, 0x76, 0x02, 0x29, 0x8e, 0x10, 0x9b,
+	0x84, 0x59, 0xb7, 0xf9, 0x6d, 0x22, 0xd0, 0x3f, 0x86, 0x3e, 0x4d, 0x6a, 0x51, 0x2e, 0x2a, 0x8b,
+	0xf2, 0x0d, 0x6c, 0x55, 0xd8, 0x8b, 0xea, 0x3c, 0xc8, 0x56, 0xa7, 0xc7, 0xaa, 0x53, 0x64, 0x36,
+	0x2b, 0xd3, 0x63, 0xd8, 0x3a, 0x30, 0x3d, 0x0b, 0xbb, 0x85, 0x8a, 0x15, 0x24, 0xca, 0x4b, 0x29,
+	0xcf, 0x4a, 0xa9, 0x0f, 0x60, 0xbb, 0xca, 0xa1, 0x18, 0xa5, 0xaf, 0xe1, 0xee, 0x43, 0x9c, 0x6f,
+	0xb6, 0x27, 0xc4, 0x24, 0x71, 0xf4, 0x52, 0xcc, 0xad, 0xff, 0x2b, 0x83, 0x56, 0xec, 0x59, 0x64,
+	0xeb, 0x26, 0x96, 0xc2, 0x6c, 0x14, 0xeb, 0xf3, 0x51, 0x5c, 0xc4, 0x54, 0x8d, 0x85, 0x4c, 0x45,
+	0x11, 0x45, 0x0c, 0xa3, 0x58, 0x12, 0xe2, 0x94, 0x6d, 0xaf, 0x56, 0xae, 0xbd, 0xe8, 0x6d, 0x1c,
+	0xd8, 0xe2, 0x56, 0xb4, 0x66, 0x22, 0x60, 0x3e, 0xb1, 0x47, 0x92, 0xbe, 0x14, 0x27, 0xda, 0xf2,
+	0x96, 0x3f, 0x09, 0x5c, 0xcc, 0xed, 0x38, 0xeb, 0xa7, 0x45, 0x94, 0xb9, 0x4d, 0xce, 0xe2, 0x4a,
+	0x87, 0x33, 0xb7, 0x38, 0xe6, 0x59, 0x7f, 0xf5, 0x1a, 0xeb, 0xeb, 0xff, 0xd4, 0x16, 0x11, 0xf8,
+	0x27, 0x26, 0xb1, 0xce, 0x67, 0x95, 0xed, 0x43, 0xd7, 0x0c, 0x02, 0xd7, 0xb1, 0xd8, 0x2e, 0x4f,
+	0xe8, 0x3b, 0x2b, 0xa4, 0x20, 0x78, 0x59, 0x22, 0x45, 0xd6, 0x6a, 0x83, 0xb6, 0x31, 0x3b, 0xd2,
+	0xb4, 0xf2, 0xd7, 0xc2, 0x49, 0xe8, 0x8f, 0x1d, 0x17, 0x1f, 0x1f, 0x0a, 0x7e, 0xc8, 0x8b, 0xb3,
+	0x05, 0xad, 0x97, 0x16, 0xb4, 0xf1, 0xff, 0x59, 0x38, 0xbb, 0xe5, 0x57, 0xae, 0x6d, 0xf9, 0x57,
+	0xb0, 0xa5, 0xd1, 0x3e, 0xd4, 0x89, 0x79, 0x16, 0x29, 0x1d, 0x36, 0xff, 0xf7, 0x99, 0xf3, 0xf2,
+	0x32, 0xec, 0x7c, 0x69, 0x9e, 0x45, 0x47, 0x1e, 0x09, 0xa7, 0x06, 0x33, 0x55, 0x3f, 0x84, 0x76,
+	0x22, 0x42, 0xaf, 0x41, 0xed, 0x02, 0x4f, 0xc5, 0x7c, 0xd0, 0x4f, 0x9a, 0x8f, 0x4b, 0xd3, 0x8d,
+	0x67, 0x83, 0xc1, 0x0f, 0x7b, 0xf2, 0x47, 0x92, 0x6e, 0x42, 0xaf, 0x30, 0x54, 0x14, 0xbb, 0xc5,
+	0xa3, 0x3c, 0x9b, 0x19, 0x39, 0x35, 0x33, 0xeb, 0xd0, 0xc0, 0x61, 0xe8, 0x87, 0xa2, 0xa4, 0xfc,
+	0xa0, 0xff, 0x26, 0xc1, 0xdd, 0xe2, 0x18, 0x7c, 0xaa, 0x75, 0x58, 0xc5, 0x5c, 0xe5, 0xc0, 0x8f,
+	0x3d, 0x22, 0x9e, 0x24, 0x19, 0x19, 0x2d, 0x15, 0x73, 0xc8, 0x35, 0x78, 0xdc, 0x94, 0x04, 0xed,
+	0x41, 0x33, 0x64, 0x98, 0x95, 0x1a, 0x4b, 0xa4, 0x5e, 0x9e, 0x48, 0xaa, 0x69, 0x08, 0x8b, 0xdd,
+	0x3f, 0x5a, 0xd0, 0x49, 0xa9, 0xa0, 0xef, 0xa0, 0x25, 0x0c, 0x51, 0x91, 0x9b, 0x14, 0xc9, 0xaa,
+	0x9b, 0xa5, 0x3a, 0x82, 0x37, 0xb7, 0xbf, 0xff, 0xf3, 0xef, 0x9f, 0x65, 0x4d, 0xbf, 0xcd, 0x1e,
+	0xc4, 0xbc, 0xdd, 0xa3, 0xd1, 0x15, 0xcf, 0xe7, 0x8b, 0x11, 0xb3, 0xdd, 0x93, 0x86, 0xc8, 0x81,
+	0x06, 0x7b, 0xc6, 0xa0, 0x0d, 0xe6, 0xb5, 0xe0, 0x15, 0xa4, 0xf6, 0x0a, 0x6e, 0x45, 0xb4, 0x4d,
+	0x16, 0xad, 0x37, 0x2c, 0x8b, 0x86, 0x02, 0xa8, 0xd3, 0x1d, 0x85, 0x34, 0xe6, 0xab, 0xe4, 0xe9,
+	0xa1, 0xbe, 0x5d, 0xa2, 0x91, 0x8d, 0x88, 0x4a, 0x23, 0xfe, 0x22, 0x41, 0x37, 0xb3, 0x16, 0xd1,
+	0x3b, 0x89, 0xe7, 0xaa, 0x55, 0xab, 0x0e, 0x97, 0x51, 0x15, 0x68, 0xee, 0x33, 0x34, 0xf7, 0xd0,
+	0x56, 0x09, 0x9a, 0x51, 0x42, 0xe5, 0xe8, 0x57, 0x09, 0xd6, 0x72, 0xfb, 0x0f, 0xf1, 0x70, 0x4b,
+	0xad, 0x59, 0xf5, 0xdd, 0xa5, 0x74, 0x05, 0xb6, 0x5d, 0x86, 0xed, 0xbd, 0xe1, 0x70, 0x29, 0x6c,
+	0xa3, 0x2b, 0xc7, 0x7e, 0x81, 0x7e, 0x90, 0xa0, 0xfd, 0x10, 0x13, 0xbe, 0x0d, 0x51, 0x9f, 0x85,
+	0xab, 0x58, 0xc3, 0xea, 0x56, 0x85, 0x96, 0x80, 0x33, 0x64, 0x70, 0xfa, 0x48, 0x2f, 0x85, 0xc3,
+	0x03, 0xff, 0x24, 0xc1, 0xaa, 0xe8, 0x73, 0x36, 0x47, 0x68, 0x73, 0x09, 0xba, 0x52, 0xfb, 0x15,
+	0xa3, 0x98, 0x49, 0x8b, 0x7e, 0x8f, 0xff, 0x63, 0x9c, 0x6f, 0x94, 0x68, 0x74, 0x95, 0xd9, 0x2f,
+	0xf3, 0x61, 0x39, 0x6d, 0xb2, 0x3f, 0x93, 0x0f, 0xfe, 0x1b, 0x00, 0x7b, 0xed, 0xf9, 0x8f, 0x84,
+	0x0e, 0x00, 0x00,
 }
@@ -28,7 +28,11 @@ protoc  -I/usr/local/include -I. ${GOPATHLIST} --go_out=plugins=grpc:. \
     networkServer.proto \
     serviceProfile.proto \
     deviceProfile.proto \
-    gatewayProfile.proto
+    gatewayProfile.proto \
+    codec.proto \
+    downlinkSchedule.proto \
+    multicastGroup.proto \
+    fuota.proto
 
 # generate the JSON interface code
 protoc -I/usr/local/include -I. ${GOPATHLIST} --grpc-gateway_out=logtostderr=true:. \
@@ -43,7 +47,11 @@ protoc -I/usr/local/include -I. ${GOPATHLIST} --grpc-gateway_out=logtostderr=tru
     networkServer.proto \
     serviceProfile.proto \
     deviceProfile.proto \
-    gatewayProfile.proto
+    gatewayProfile.proto \
+    codec.proto \
+    downlinkSchedule.proto \
+    multicastGroup.proto \
+    fuota.proto
 
 # generate the swagger definitions
 protoc -I/usr/local/include -I. ${GOPATHLIST} --swagger_out=logtostderr=true:./swagger \
@@ -58,7 +66,11 @@ protoc -I/usr/local/include -I. ${GOPATHLIST} --swagger_out=logtostderr=true:./s
     networkServer.proto \
     serviceProfile.proto \
     deviceProfile.proto \
-    gatewayProfile.proto
+    gatewayProfile.proto \
+    codec.proto \
+    downlinkSchedule.proto \
+    multicastGroup.proto \
+    fuota.proto
 
 # merge the swagger code into one file
 go run swagger/main.go swagger > ../static/swagger/api.swagger.json
@@ -53,7 +53,15 @@ type CreateNetworkServerRequest struct {
 	// The frequency (Hz) of the gateway discovery 'ping'.
 	GatewayDiscoveryTXFrequency uint32 `protobuf:"varint,11,opt,name=gatewayDiscoveryTXFrequency" json:"gatewayDiscoveryTXFrequency,omitempty"`
 	// The data-rate of the gateway discovery 'ping'.
-	GatewayDiscoveryDR   uint32   `protobuf:"varint,12,opt,name=gatewayDiscoveryDR" json:"gatewayDiscoveryDR,omitempty"`
+	GatewayDiscoveryDR uint32 `protobuf:"varint,12,opt,name=gatewayDiscoveryDR" json:"gatewayDiscoveryDR,omitempty"`
+	// NetID of the network-server (HEX encoded). When set, the join-server
+	// only accepts join-requests for devices of this network-server when
+	// the SenderID matches this NetID.
+	NetID string `protobuf:"bytes,13,opt,name=netID" json:"netID,omitempty"`
+	// Common name of the TLS client certificate used by the network-server
+	// to connect to the join-server. When set, the join-server only accepts
+	// join-requests for devices of this network-server using this certificate.
+	JoinServerClientCN   string   `protobuf:"bytes,14,opt,name=joinServerClientCN" json:"joinServerClientCN,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -63,7 +71,7 @@ func (m *CreateNetworkServerRequest) Reset()         { *m = CreateNetworkServerR
 func (m *CreateNetworkServerRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateNetworkServerRequest) ProtoMessage()    {}
 func (*CreateNetworkServerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{0}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{0}
 }
 func (m *CreateNetworkServerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNetworkServerRequest.Unmarshal(m, b)
@@ -167,6 +175,20 @@ func (m *CreateNetworkServerRequest) GetGatewayDiscoveryDR() uint32 {
 	return 0
 }
 
+func (m *CreateNetworkServerRequest) GetNetID() string {
+	if m != nil {
+		return m.NetID
+	}
+	return ""
+}
+
+func (m *CreateNetworkServerRequest) GetJoinServerClientCN() string {
+	if m != nil {
+		return m.JoinServerClientCN
+	}
+	return ""
+}
+
 type CreateNetworkServerResponse struct {
 	// ID of the network-server.
 	Id                   int64    `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
@@ -179,7 +201,7 @@ func (m *CreateNetworkServerResponse) Reset()         { *m = CreateNetworkServer
 func (m *CreateNetworkServerResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateNetworkServerResponse) ProtoMessage()    {}
 func (*CreateNetworkServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{1}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{1}
 }
 func (m *CreateNetworkServerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNetworkServerResponse.Unmarshal(m, b)
@@ -218,7 +240,7 @@ func (m *GetNetworkServerRequest) Reset()         { *m = GetNetworkServerRequest
 func (m *GetNetworkServerRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNetworkServerRequest) ProtoMessage()    {}
 func (*GetNetworkServerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{2}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{2}
 }
 func (m *GetNetworkServerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNetworkServerRequest.Unmarshal(m, b)
@@ -279,7 +301,15 @@ type GetNetworkServerResponse struct {
 	// The LoRa Server version.
 	Version string `protobuf:"bytes,14,opt,name=version" json:"version,omitempty"`
 	// The LoRa Server region configured.
-	Region               string   `protobuf:"bytes,15,opt,name=region" json:"region,omitempty"`
+	Region string `protobuf:"bytes,15,opt,name=region" json:"region,omitempty"`
+	// NetID of the network-server (HEX encoded). When set, the join-server
+	// only accepts join-requests for devices of this network-server when
+	// the SenderID matches this NetID.
+	NetID string `protobuf:"bytes,16,opt,name=netID" json:"netID,omitempty"`
+	// Common name of the TLS client certificate used by the network-server
+	// to connect to the join-server. When set, the join-server only accepts
+	// join-requests for devices of this network-server using this certificate.
+	JoinServerClientCN   string   `protobuf:"bytes,17,opt,name=joinServerClientCN" json:"joinServerClientCN,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -289,7 +319,7 @@ func (m *GetNetworkServerResponse) Reset()         { *m = GetNetworkServerRespon
 func (m *GetNetworkServerResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNetworkServerResponse) ProtoMessage()    {}
 func (*GetNetworkServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{3}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{3}
 }
 func (m *GetNetworkServerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNetworkServerResponse.Unmarshal(m, b)
@@ -414,6 +444,20 @@ func (m *GetNetworkServerResponse) GetRegion() string {
 	return ""
 }
 
+func (m *GetNetworkServerResponse) GetNetID() string {
+	if m != nil {
+		return m.NetID
+	}
+	return ""
+}
+
+func (m *GetNetworkServerResponse) GetJoinServerClientCN() string {
+	if m != nil {
+		return m.JoinServerClientCN
+	}
+	return ""
+}
+
 type UpdateNetworkServerRequest struct {
 	// ID of the network-server.
 	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
@@ -445,7 +489,15 @@ type UpdateNetworkServerRequest struct {
 	// The frequency (Hz) of the gateway discovery 'ping'.
 	GatewayDiscoveryTXFrequency uint32 `protobuf:"varint,12,opt,name=gatewayDiscoveryTXFrequency" json:"gatewayDiscoveryTXFrequency,omitempty"`
 	// The data-rate of the gateway discovery 'ping'.
-	GatewayDiscoveryDR   uint32   `protobuf:"varint,13,opt,name=gatewayDiscoveryDR" json:"gatewayDiscoveryDR,omitempty"`
+	GatewayDiscoveryDR uint32 `protobuf:"varint,13,opt,name=gatewayDiscoveryDR" json:"gatewayDiscoveryDR,omitempty"`
+	// NetID of the network-server (HEX encoded). When set, the join-server
+	// only accepts join-requests for devices of this network-server when
+	// the SenderID matches this NetID.
+	NetID string `protobuf:"bytes,14,opt,name=netID" json:"netID,omitempty"`
+	// Common name of the TLS client certificate used by the network-server
+	// to connect to the join-server. When set, the join-server only accepts
+	// join-requests for devices of this network-server using this certificate.
+	JoinServerClientCN   string   `protobuf:"bytes,15,opt,name=joinServerClientCN" json:"joinServerClientCN,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -455,7 +507,7 @@ func (m *UpdateNetworkServerRequest) Reset()         { *m = UpdateNetworkServerR
 func (m *UpdateNetworkServerRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateNetworkServerRequest) ProtoMessage()    {}
 func (*UpdateNetworkServerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{4}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{4}
 }
 func (m *UpdateNetworkServerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateNetworkServerRequest.Unmarshal(m, b)
@@ -566,6 +618,20 @@ func (m *UpdateNetworkServerRequest) GetGatewayDiscoveryDR() uint32 {
 	return 0
 }
 
+func (m *UpdateNetworkServerRequest) GetNetID() string {
+	if m != nil {
+		return m.NetID
+	}
+	return ""
+}
+
+func (m *UpdateNetworkServerRequest) GetJoinServerClientCN() string {
+	if m != nil {
+		return m.JoinServerClientCN
+	}
+	return ""
+}
+
 type UpdateNetworkServerResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -576,7 +642,7 @@ func (m *UpdateNetworkServerResponse) Reset()         { *m = UpdateNetworkServer
 func (m *UpdateNetworkServerResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateNetworkServerResponse) ProtoMessage()    {}
 func (*UpdateNetworkServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{5}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{5}
 }
 func (m *UpdateNetworkServerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateNetworkServerResponse.Unmarshal(m, b)
@@ -608,7 +674,7 @@ func (m *DeleteNetworkServerRequest) Reset()         { *m = DeleteNetworkServerR
 func (m *DeleteNetworkServerRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteNetworkServerRequest) ProtoMessage()    {}
 func (*DeleteNetworkServerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{6}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{6}
 }
 func (m *DeleteNetworkServerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteNetworkServerRequest.Unmarshal(m, b)
@@ -645,7 +711,7 @@ func (m *DeleteNetworkServerResponse) Reset()         { *m = DeleteNetworkServer
 func (m *DeleteNetworkServerResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteNetworkServerResponse) ProtoMessage()    {}
 func (*DeleteNetworkServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{7}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{7}
 }
 func (m *DeleteNetworkServerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteNetworkServerResponse.Unmarshal(m, b)
@@ -681,7 +747,7 @@ func (m *ListNetworkServerRequest) Reset()         { *m = ListNetworkServerReque
 func (m *ListNetworkServerRequest) String() string { return proto.CompactTextString(m) }
 func (*ListNetworkServerRequest) ProtoMessage()    {}
 func (*ListNetworkServerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{8}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{8}
 }
 func (m *ListNetworkServerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListNetworkServerRequest.Unmarshal(m, b)
@@ -736,7 +802,7 @@ func (m *ListNetworkServerResponse) Reset()         { *m = ListNetworkServerResp
 func (m *ListNetworkServerResponse) String() string { return proto.CompactTextString(m) }
 func (*ListNetworkServerResponse) ProtoMessage()    {}
 func (*ListNetworkServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_networkServer_4c10e9cf61681e4d, []int{9}
+	return fileDescriptor_networkServer_a0e6abc190ad558f, []int{9}
 }
 func (m *ListNetworkServerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListNetworkServerResponse.Unmarshal(m, b)
@@ -998,55 +1064,58 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 	Metadata: "networkServer.proto",
 }
 
-func init() { proto.RegisterFile("networkServer.proto", fileDescriptor_networkServer_4c10e9cf61681e4d) }
-
-var fileDescriptor_networkServer_4c10e9cf61681e4d = []byte{
-	// 746 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x96, 0xcd, 0x6e, 0x13, 0x3b,
-	0x14, 0xc7, 0x95, 0xcc, 0x64, 0x92, 0x9c, 0x7e, 0x5c, 0xc9, 0xb7, 0xb7, 0x9d, 0x26, 0x69, 0x9b,
-	0x3b, 0xba, 0xba, 0x0a, 0x15, 0x4d, 0xa5, 0x02, 0x12, 0xea, 0x8a, 0x2a, 0x81, 0xaa, 0xa2, 0x42,
-	0x68, 0x5a, 0x24, 0xb6, 0xd3, 0xc4, 0x8d, 0x2c, 0xa6, 0x76, 0xea, 0x71, 0x52, 0x05, 0xc4, 0x86,
-	0x57, 0x60, 0xc3, 0x23, 0xb0, 0xe0, 0x41, 0xd8, 0xf3, 0x0a, 0x3c, 0x08, 0xf2, 0x47, 0xbf, 0x52,
-	0x7b, 0x54, 0x85, 0x1d, 0xbb, 0x9c, 0xf3, 0x3f, 0xc7, 0xff, 0xf1, 0xf8, 0x97, 0x33, 0x86, 0xbf,
-	0x29, 0x16, 0x17, 0x8c, 0xbf, 0x3b, 0xc2, 0x7c, 0x8c, 0x79, 0x7b, 0xc8, 0x99, 0x60, 0xc8, 0x4b,
-	0x86, 0xa4, 0xd6, 0x18, 0x30, 0x36, 0x48, 0xf1, 0x76, 0x32, 0x24, 0xdb, 0x09, 0xa5, 0x4c, 0x24,
-	0x82, 0x30, 0x9a, 0xe9, 0x92, 0xe8, 0x8b, 0x0f, 0xb5, 0x0e, 0xc7, 0x89, 0xc0, 0xaf, 0x6e, 0x2e,
-	0x10, 0xe3, 0xf3, 0x11, 0xce, 0x04, 0x42, 0xe0, 0xd3, 0xe4, 0x0c, 0x87, 0x85, 0x66, 0xa1, 0x55,
-	0x8d, 0xd5, 0x6f, 0xb4, 0x0c, 0x41, 0xa6, 0x8a, 0xc2, 0xa2, 0xca, 0x9a, 0x48, 0xe6, 0x7b, 0x49,
-	0x07, 0x73, 0x11, 0x7a, 0x3a, 0xaf, 0x23, 0x14, 0x42, 0x59, 0xa4, 0x99, 0x12, 0x7c, 0x25, 0x5c,
-	0x86, 0xb2, 0x43, 0xa4, 0xd9, 0x4b, 0x3c, 0x09, 0x4b, 0xba, 0x43, 0x47, 0x68, 0x07, 0x96, 0x38,
-	0x1b, 0x09, 0x42, 0x07, 0xaf, 0x39, 0x3b, 0x25, 0x29, 0xee, 0xec, 0xa9, 0xf6, 0x40, 0x55, 0x59,
-	0x35, 0xf4, 0x18, 0xfe, 0xb9, 0x9d, 0x3f, 0x3e, 0x3c, 0x52, 0x4d, 0x65, 0xd5, 0x64, 0x17, 0xef,
-	0x3a, 0x1d, 0x1f, 0x1e, 0xc9, 0xe7, 0xa9, 0xd8, 0x9c, 0xb4, 0x86, 0x9e, 0xc2, 0xca, 0x20, 0x11,
-	0xf8, 0x22, 0x99, 0x74, 0x49, 0xd6, 0x63, 0x63, 0xcc, 0x27, 0xcf, 0x69, 0x72, 0x92, 0xe2, 0x7e,
-	0x58, 0x6d, 0x16, 0x5a, 0x95, 0xd8, 0x25, 0xa3, 0x5d, 0x08, 0xa7, 0xa5, 0x03, 0x2a, 0x30, 0x1f,
-	0x27, 0x69, 0x08, 0xcd, 0x42, 0x6b, 0x21, 0x76, 0xea, 0xe8, 0x19, 0xd4, 0xa7, 0xb5, 0xe3, 0xb7,
-	0x2f, 0xb8, 0x3c, 0x27, 0xda, 0x9b, 0x84, 0x73, 0xaa, 0x3d, 0xaf, 0x04, 0xb5, 0x01, 0x4d, 0xcb,
-	0xdd, 0x38, 0x9c, 0x57, 0x8d, 0x16, 0x25, 0xda, 0x82, 0xba, 0x95, 0x8c, 0x6c, 0xc8, 0x68, 0x86,
-	0xd1, 0x22, 0x14, 0x49, 0x5f, 0x81, 0xe1, 0xc5, 0x45, 0xd2, 0x8f, 0x1e, 0xc0, 0xca, 0x3e, 0x16,
-	0x56, 0x8a, 0xa6, 0x4b, 0xbf, 0xfb, 0x10, 0xde, 0xad, 0xb5, 0xaf, 0x8b, 0x1a, 0x50, 0xed, 0xa9,
-	0xc7, 0xe8, 0xef, 0x09, 0x43, 0xdc, 0x75, 0x42, 0xaa, 0xa3, 0x61, 0xdf, 0xa8, 0x9a, 0xbb, 0xeb,
-	0xc4, 0x15, 0xbe, 0xbe, 0x15, 0xdf, 0x92, 0x03, 0xdf, 0xc0, 0x85, 0x6f, 0xf9, 0x36, 0xbe, 0x2e,
-	0x4c, 0x2b, 0xb3, 0x60, 0x5a, 0xcd, 0xc3, 0x34, 0x07, 0x39, 0x98, 0x1d, 0xb9, 0xb9, 0xdf, 0x43,
-	0x6e, 0x7e, 0x56, 0xe4, 0x16, 0x5c, 0xc8, 0xc9, 0x77, 0x3d, 0xc6, 0x3c, 0x23, 0x8c, 0x86, 0x8b,
-	0xfa, 0x5d, 0x9b, 0x50, 0x9e, 0x0e, 0xc7, 0x03, 0x29, 0xfc, 0xa5, 0x4f, 0x47, 0x47, 0xd1, 0x57,
-	0x1f, 0x6a, 0x6f, 0xd4, 0x79, 0xdf, 0x87, 0xbc, 0x2b, 0x20, 0x8a, 0x56, 0x20, 0x3c, 0x07, 0x10,
-	0xbe, 0x0b, 0x88, 0x92, 0x6b, 0x9e, 0x05, 0xf7, 0x9a, 0x67, 0xe5, 0x59, 0x40, 0xa9, 0xcc, 0x32,
-	0xcf, 0xaa, 0xb3, 0xcd, 0xb3, 0x3f, 0x09, 0xae, 0x68, 0x0d, 0xea, 0x56, 0x52, 0xf4, 0xdc, 0x89,
-	0x1e, 0x42, 0xad, 0x8b, 0x53, 0x7c, 0x3f, 0x90, 0xe4, 0x62, 0xd6, 0x6a, 0xb3, 0xd8, 0x10, 0xc2,
-	0x43, 0x92, 0xd9, 0xa7, 0xe1, 0x12, 0x94, 0x52, 0x72, 0x46, 0x84, 0x59, 0x4d, 0x07, 0x92, 0x1d,
-	0x76, 0x7a, 0x9a, 0x61, 0x3d, 0xe3, 0xbc, 0xd8, 0x44, 0xe8, 0x7f, 0x58, 0x64, 0x7c, 0x90, 0x50,
-	0xf2, 0x5e, 0x7d, 0xb7, 0x0f, 0xba, 0x8a, 0x52, 0x2f, 0x9e, 0xca, 0x46, 0x1c, 0x56, 0x2d, 0x8e,
-	0x66, 0xa6, 0xae, 0x03, 0x08, 0x26, 0x92, 0xb4, 0xc3, 0x46, 0xf4, 0xd2, 0xf7, 0x46, 0x06, 0x3d,
-	0x91, 0xff, 0xae, 0x6c, 0x94, 0x4a, 0x73, 0xaf, 0x35, 0xb7, 0xb3, 0xd6, 0x4e, 0x86, 0xa4, 0xed,
-	0x1a, 0xd1, 0xb1, 0x29, 0xde, 0xf9, 0xe6, 0xc3, 0xc2, 0xad, 0x0a, 0x94, 0x42, 0xa0, 0xbf, 0x19,
-	0x68, 0x43, 0x2d, 0xe1, 0xbe, 0x5a, 0xd4, 0x9a, 0xee, 0x02, 0xf3, 0x12, 0x37, 0x3e, 0xfd, 0xf8,
-	0xf9, 0xb9, 0xb8, 0x1a, 0x2d, 0xa9, 0xbb, 0x8b, 0xb9, 0xe0, 0x6c, 0xe9, 0x7f, 0x67, 0xb6, 0x5b,
-	0xd8, 0x44, 0x18, 0xbc, 0x7d, 0x2c, 0x50, 0xc3, 0xf1, 0xb4, 0xda, 0x27, 0x7f, 0x2f, 0xd1, 0xbf,
-	0xca, 0xa4, 0x8e, 0x56, 0x6d, 0x26, 0xdb, 0x1f, 0x48, 0xff, 0x23, 0x3a, 0x87, 0x40, 0x83, 0x63,
-	0x36, 0xe5, 0x9e, 0x37, 0x66, 0x53, 0x79, 0x98, 0xfd, 0xa7, 0xfc, 0xd6, 0x6b, 0x6e, 0x3f, 0xb9,
-	0x33, 0x0a, 0x81, 0xc6, 0xcb, 0x58, 0xba, 0xc9, 0x34, 0x96, 0x79, 0x30, 0x9a, 0x2d, 0x6e, 0xe6,
-	0x6c, 0xb1, 0x07, 0xbe, 0xa4, 0x07, 0xe9, 0x97, 0xe5, 0x42, 0xb7, 0xb6, 0xee, 0x92, 0x8d, 0x53,
-	0x43, 0x39, 0x2d, 0x23, 0xeb, 0x89, 0x9d, 0x04, 0xea, 0xca, 0xf9, 0xe8, 0x57, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xbc, 0xab, 0x70, 0x95, 0xac, 0x0a, 0x00, 0x00,
+func init() { proto.RegisterFile("networkServer.proto", fileDescriptor_networkServer_a0e6abc190ad558f) }
+
+var fileDescriptor_networkServer_a0e6abc190ad558f = []byte{
+	// 792 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x96, 0xdf, 0x4e, 0x1b, 0x39,
+	0x14, 0xc6, 0x95, 0xcc, 0x64, 0x92, 0x1c, 0x48, 0xd8, 0xf5, 0x66, 0x61, 0x98, 0x04, 0xc8, 0x46,
+	0xab, 0x55, 0x16, 0x2d, 0x41, 0x62, 0x77, 0xa5, 0x8a, 0xab, 0xa2, 0xa4, 0x45, 0xa8, 0x08, 0x55,
+	0x03, 0x95, 0x7a, 0x3b, 0x24, 0x26, 0x72, 0x3b, 0xd8, 0xc1, 0xe3, 0x04, 0xa5, 0x55, 0x6f, 0xfa,
+	0x00, 0xbd, 0xe9, 0x6b, 0xf4, 0x6d, 0x7a, 0xdf, 0xab, 0x5e, 0xf7, 0x19, 0xaa, 0xb1, 0xcd, 0x9f,
+	0x04, 0x3b, 0xa0, 0xf4, 0xae, 0x77, 0x39, 0xe7, 0x3b, 0xf6, 0x37, 0x9e, 0xf9, 0x9d, 0x13, 0xc3,
+	0x6f, 0x14, 0x8b, 0x4b, 0xc6, 0x5f, 0x1f, 0x63, 0x3e, 0xc2, 0xbc, 0x35, 0xe0, 0x4c, 0x30, 0xe4,
+	0x44, 0x03, 0x12, 0xd4, 0xfa, 0x8c, 0xf5, 0x63, 0xbc, 0x1d, 0x0d, 0xc8, 0x76, 0x44, 0x29, 0x13,
+	0x91, 0x20, 0x8c, 0x26, 0xaa, 0xa4, 0xf1, 0xc5, 0x85, 0xa0, 0xcd, 0x71, 0x24, 0xf0, 0xd1, 0xed,
+	0x0d, 0x42, 0x7c, 0x31, 0xc4, 0x89, 0x40, 0x08, 0x5c, 0x1a, 0x9d, 0x63, 0x3f, 0x53, 0xcf, 0x34,
+	0x8b, 0xa1, 0xfc, 0x8d, 0x96, 0xc1, 0x4b, 0x64, 0x91, 0x9f, 0x95, 0x59, 0x1d, 0xa5, 0xf9, 0x6e,
+	0xd4, 0xc6, 0x5c, 0xf8, 0x8e, 0xca, 0xab, 0x08, 0xf9, 0x90, 0x17, 0x71, 0x22, 0x05, 0x57, 0x0a,
+	0x57, 0x61, 0xba, 0x42, 0xc4, 0xc9, 0x33, 0x3c, 0xf6, 0x73, 0x6a, 0x85, 0x8a, 0xd0, 0x0e, 0x54,
+	0x38, 0x1b, 0x0a, 0x42, 0xfb, 0xcf, 0x39, 0x3b, 0x23, 0x31, 0x6e, 0xef, 0xc9, 0xe5, 0x9e, 0xac,
+	0x32, 0x6a, 0xe8, 0x3f, 0xf8, 0x7d, 0x32, 0x7f, 0x72, 0x78, 0x2c, 0x17, 0xe5, 0xe5, 0x22, 0xb3,
+	0x78, 0xd7, 0xe9, 0xe4, 0xf0, 0x38, 0x7d, 0x9e, 0x82, 0xc9, 0x49, 0x69, 0xe8, 0x11, 0xac, 0xf4,
+	0x23, 0x81, 0x2f, 0xa3, 0x71, 0x87, 0x24, 0x5d, 0x36, 0xc2, 0x7c, 0xfc, 0x84, 0x46, 0xa7, 0x31,
+	0xee, 0xf9, 0xc5, 0x7a, 0xa6, 0x59, 0x08, 0x6d, 0x32, 0xda, 0x05, 0x7f, 0x5a, 0x3a, 0xa0, 0x02,
+	0xf3, 0x51, 0x14, 0xfb, 0x50, 0xcf, 0x34, 0x4b, 0xa1, 0x55, 0x47, 0x8f, 0xa1, 0x3a, 0xad, 0x9d,
+	0xbc, 0x7c, 0xca, 0xd3, 0xef, 0x44, 0xbb, 0x63, 0x7f, 0x41, 0x2e, 0x9f, 0x55, 0x82, 0x5a, 0x80,
+	0xa6, 0xe5, 0x4e, 0xe8, 0x2f, 0xca, 0x85, 0x06, 0x05, 0x55, 0x20, 0x47, 0xb1, 0x38, 0xe8, 0xf8,
+	0x25, 0xf9, 0x32, 0x54, 0x90, 0xee, 0xf2, 0x8a, 0x11, 0xaa, 0x30, 0x69, 0xc7, 0x04, 0x53, 0xd1,
+	0x3e, 0xf2, 0xcb, 0xb2, 0xc4, 0xa0, 0x34, 0xb6, 0xa0, 0x6a, 0xe4, 0x2b, 0x19, 0x30, 0x9a, 0x60,
+	0x54, 0x86, 0x2c, 0xe9, 0x49, 0xbc, 0x9c, 0x30, 0x4b, 0x7a, 0x8d, 0xbf, 0x61, 0x65, 0x1f, 0x0b,
+	0x23, 0x8b, 0xd3, 0xa5, 0x1f, 0x72, 0xe0, 0xdf, 0xad, 0x35, 0xef, 0x8b, 0x6a, 0x50, 0xec, 0xca,
+	0xc7, 0xe8, 0xed, 0x09, 0xcd, 0xed, 0x4d, 0x22, 0x55, 0x87, 0x83, 0x9e, 0x56, 0x15, 0xbd, 0x37,
+	0x89, 0xeb, 0x26, 0x70, 0x8d, 0x4d, 0x90, 0xb3, 0x34, 0x81, 0x67, 0x6b, 0x82, 0xfc, 0x64, 0x13,
+	0xd8, 0x60, 0x2f, 0xcc, 0x03, 0x7b, 0x71, 0x16, 0xec, 0x33, 0xc0, 0x85, 0xf9, 0xc1, 0x5d, 0xf8,
+	0x31, 0x70, 0x17, 0xe7, 0x05, 0xb7, 0x64, 0x05, 0xd7, 0x87, 0xfc, 0x08, 0xf3, 0x84, 0x30, 0xaa,
+	0xb9, 0xbc, 0x0a, 0xd3, 0xaf, 0xc3, 0x71, 0x3f, 0x15, 0x96, 0xd4, 0xd7, 0x51, 0xd1, 0x0d, 0xea,
+	0xbf, 0xdc, 0x8f, 0xfa, 0xaf, 0x56, 0xd4, 0xbf, 0xb9, 0x10, 0xbc, 0x90, 0xd4, 0x3c, 0x84, 0xdf,
+	0x6b, 0xac, 0xb2, 0x46, 0xac, 0x1c, 0x0b, 0x56, 0xae, 0x0d, 0xab, 0x9c, 0x6d, 0xb6, 0x7a, 0x0f,
+	0x9a, 0xad, 0xf9, 0x79, 0x70, 0x2b, 0xcc, 0x33, 0x5b, 0x8b, 0xf3, 0xcd, 0xd6, 0x9f, 0x0a, 0xd1,
+	0x6b, 0xe0, 0xca, 0xf7, 0x03, 0xb7, 0x64, 0x05, 0x6e, 0x0d, 0xaa, 0x46, 0xde, 0xd4, 0x0c, 0x6c,
+	0xfc, 0x03, 0x41, 0x07, 0xc7, 0xf8, 0x61, 0x38, 0xa6, 0x9b, 0x19, 0xab, 0xf5, 0x66, 0x03, 0xf0,
+	0x0f, 0x49, 0x62, 0x9e, 0xcc, 0x15, 0xc8, 0xc5, 0xe4, 0x9c, 0x08, 0xbd, 0x9b, 0x0a, 0x52, 0x02,
+	0xd9, 0xd9, 0x59, 0x82, 0xd5, 0xbc, 0x75, 0x42, 0x1d, 0xa1, 0xbf, 0xa0, 0xcc, 0x78, 0x3f, 0xa2,
+	0xe4, 0x8d, 0xbc, 0x89, 0x1c, 0x74, 0x24, 0xeb, 0x4e, 0x38, 0x95, 0x6d, 0x70, 0x58, 0x35, 0x38,
+	0xea, 0xf9, 0xbe, 0x0e, 0x20, 0x98, 0x88, 0xe2, 0x36, 0x1b, 0xd2, 0x2b, 0xdf, 0x5b, 0x19, 0xf4,
+	0x7f, 0xda, 0xe9, 0xc9, 0x30, 0x4e, 0xcd, 0x9d, 0xe6, 0xc2, 0xce, 0x5a, 0x2b, 0x1a, 0x90, 0x96,
+	0xed, 0xef, 0x22, 0xd4, 0xc5, 0x3b, 0x9f, 0x5c, 0x28, 0x4d, 0x54, 0xa0, 0x18, 0x3c, 0xf5, 0xff,
+	0x85, 0x36, 0xe4, 0x16, 0xf6, 0xcb, 0x52, 0x50, 0xb7, 0x17, 0xe8, 0x97, 0xb8, 0xf1, 0xfe, 0xf3,
+	0xd7, 0x8f, 0xd9, 0xd5, 0x46, 0x45, 0xde, 0xc6, 0xf4, 0x95, 0x6d, 0x4b, 0xf5, 0x78, 0xb2, 0x9b,
+	0xd9, 0x44, 0x18, 0x9c, 0x7d, 0x2c, 0x50, 0xcd, 0xf2, 0xb4, 0xca, 0x67, 0xf6, 0x59, 0x1a, 0x7f,
+	0x48, 0x93, 0x2a, 0x5a, 0x35, 0x99, 0x6c, 0xbf, 0x25, 0xbd, 0x77, 0xe8, 0x02, 0x3c, 0x05, 0x8e,
+	0x3e, 0x94, 0x7d, 0x6a, 0x05, 0x75, 0x7b, 0x81, 0xf6, 0xfb, 0x53, 0xfa, 0xad, 0x07, 0x76, 0xbf,
+	0xf4, 0x64, 0x14, 0x3c, 0x85, 0x97, 0xb6, 0xb4, 0x93, 0x19, 0xd4, 0xed, 0x05, 0x93, 0x47, 0xdc,
+	0x9c, 0x71, 0xc4, 0x2e, 0xb8, 0x29, 0x3d, 0x48, 0xbd, 0x2c, 0x1b, 0xba, 0xc1, 0xba, 0x4d, 0xd6,
+	0x4e, 0x35, 0xe9, 0xb4, 0x8c, 0x8c, 0x5f, 0xec, 0xd4, 0x93, 0x97, 0xe8, 0x7f, 0xbf, 0x0f, 0x00,
+	0xaa, 0xf8, 0xc0, 0x42, 0x7e, 0x0b, 0x00, 0x00,
 }
@@ -63,6 +63,42 @@ dsn="{{ .PostgreSQL.DSN }}"
 automigrate={{ .PostgreSQL.Automigrate }}
 
 
+  # Encryption of the device keys at rest.
+  #
+  # When a master-key is configured, the root-keys (AppKey and NwkKey) and
+  # session-keys of the devices are encrypted before they are stored in the
+  # database. Each row is encrypted using its own data encryption key, which
+  # is wrapped using the master-key (AES key wrap, RFC 3394). The ID of the
+  # master-key is stored next to the encrypted keys.
+  #
+  # To rotate the master-key, add a new master-key, set the master_key_id to
+  # the ID of the new master-key and execute:
+  #   lora-app-server rotate-master-key
+  # The keys are re-encrypted in batches, each committed separately. When
+  # interrupted, the command can be executed again to resume the rotation.
+  # After this, the previous master-key can be removed.
+  [postgresql.encryption]
+  # ID of the master-key used to encrypt the device keys.
+  #
+  # When empty, the device keys are stored unencrypted.
+  master_key_id="{{ .PostgreSQL.Encryption.MasterKeyID }}"
+
+  # Master-keys.
+  #
+  # Example (the key must be a HEX encoded 128, 192 or 256 bit key, either
+  # set directly or through a file containing the key):
+  # [[postgresql.encryption.master_keys]]
+  # id="key-1"
+  # key="000102030405060708090a0b0c0d0e0f"
+  # key_file=""
+{{ range $index, $element := .PostgreSQL.Encryption.MasterKeys }}
+  [[postgresql.encryption.master_keys]]
+  id="{{ $element.ID }}"
+  key="{{ $element.Key }}"
+  key_file="{{ $element.KeyFile }}"
+{{ end }}
+
+
 # Redis settings
 #
 # Please note that Redis 2.6.0+ is required.
@@ -99,9 +135,12 @@ id="{{ .ApplicationServer.ID }}"
   # * "{{ "{{ .DevEUI }}" }}" for the DevEUI of the device.
   #
   # Note: the downlink_topic_template must contain both the application id and
-  # DevEUI substitution!
+  # DevEUI substitution! The batch_downlink_topic_template is used for sending
+  # the same downlink to multiple devices of an application and must contain
+  # the application id substitution.
   uplink_topic_template="{{ .ApplicationServer.Integration.MQTT.UplinkTopicTemplate }}"
   downlink_topic_template="{{ .ApplicationServer.Integration.MQTT.DownlinkTopicTemplate }}"
+  batch_downlink_topic_template="{{ .ApplicationServer.Integration.MQTT.BatchDownlinkTopicTemplate }}"
   join_topic_template="{{ .ApplicationServer.Integration.MQTT.JoinTopicTemplate }}"
   ack_topic_template="{{ .ApplicationServer.Integration.MQTT.AckTopicTemplate }}"
   error_topic_template="{{ .ApplicationServer.Integration.MQTT.ErrorTopicTemplate }}"
@@ -200,6 +239,42 @@ id="{{ .ApplicationServer.ID }}"
   # when set, existing users can't be re-assigned (to avoid exposure of all users to an organization admin)"
   disable_assign_existing_users={{ .ApplicationServer.ExternalAPI.DisableAssignExistingUsers }}
 
+
+  # Downlink settings.
+  [application_server.downlink]
+  # Window during which an idempotency key is remembered per device.
+  #
+  # A downlink enqueued with an idempotency key which was already used for
+  # the same device within this window is rejected (API) or ignored (MQTT).
+  # Set this to 0 to disable the deduplication.
+  idempotency_window="{{ .ApplicationServer.Downlink.IdempotencyWindow }}"
+
+  # Retention of the downlink delivery status.
+  #
+  # The delivery status of a downlink is removed once it has not been updated
+  # for this duration. Set this to 0 to keep the delivery status forever.
+  status_retention="{{ .ApplicationServer.Downlink.StatusRetention }}"
+
+
+  # Settings for enqueueing a downlink to multiple devices at once
+  # (the EnqueueBatch API method and the batch MQTT topic).
+  [application_server.downlink_batch]
+  # Max number of devices for which the downlink is enqueued concurrently.
+  concurrency={{ .ApplicationServer.DownlinkBatch.Concurrency }}
+
+  # Device claiming.
+  #
+  # The number of failed claims (invalid claim-code or unknown DevEUI) is
+  # limited per DevEUI within the given window. When reached, claims of the
+  # DevEUI are rejected until the window expires, so that a claim-code can't
+  # be brute-forced. Set the max. to 0 to disable the limit.
+  [application_server.device_claim]
+  # Max. number of failed claims per DevEUI within the window.
+  failure_max={{ .ApplicationServer.DeviceClaim.FailureMax }}
+
+  # Failed claims window.
+  failure_window="{{ .ApplicationServer.DeviceClaim.FailureWindow }}"
+
 {{ if ne .ApplicationServer.Branding.Header  "" }}
   # Branding configuration.
   [application_server.branding]
@@ -230,6 +305,127 @@ tls_cert="{{ .JoinServer.TLSCert }}"
 
 # tls key used by the join-server api server (optional)
 tls_key="{{ .JoinServer.TLSKey }}"
+
+# number of used DevNonce values to remember per device
+#
+# Join-requests re-using one of these DevNonce values are rejected.
+# For LoRaWAN 1.0.3+ devices, the DevNonce is a counter and must be
+# greater than the last used DevNonce.
+dev_nonce_history_size={{ .JoinServer.DevNonceHistorySize }}
+
+# session-key lifetime
+#
+# The lifetime of the session-keys, returned to the network-server in the
+# join-answer. Set this to 0s to not return a lifetime (the session-keys
+# don't expire).
+session_key_lifetime="{{ .JoinServer.SessionKeyLifetime }}"
+
+# number of handled join-requests to keep as join history per device
+#
+# The join history contains both the accepted and rejected join-requests.
+join_history_size={{ .JoinServer.JoinHistorySize }}
+
+# allow join-requests from unauthenticated senders
+#
+# By default, join-requests are rejected for devices of which the
+# network-server has neither a NetID nor a join-server client certificate CN
+# configured. When set, join-requests for these devices are accepted from
+# any sender. Rejoin-requests are always rejected from unauthenticated
+# senders.
+allow_unauthenticated_senders={{ .JoinServer.AllowUnauthenticatedSenders }}
+
+
+  # Key Encryption Key (KEK) configuration.
+  #
+  # The KEK mechanism is used to encrypt the session-keys sent from the
+  # join-server to the network-server and application-server, using the
+  # AES key wrap algorithm (RFC 3394). The KEK is selected using the label,
+  # which must be equal to the NetID (SenderID) of the network-server or to
+  # the as_kek_label. Session-keys are sent unwrapped when no KEK is
+  # configured for the label, unless require is set.
+  [join_server.kek]
+  # Application-server KEK label.
+  #
+  # This defines the KEK label used to encrypt the AppSKey when delivered
+  # to an application-server (AppSKeyReq).
+  as_kek_label="{{ .JoinServer.KEK.ASKEKLabel }}"
+
+  # Require a KEK.
+  #
+  # When set, join-requests from a network-server without configured KEK
+  # are rejected, instead of sending the session-keys unwrapped.
+  require={{ .JoinServer.KEK.Require }}
+
+  # KEK set.
+  #
+  # Example (the KEK must be a HEX encoded 128, 192 or 256 bit key):
+  # [[join_server.kek.set]]
+  # label="000000"
+  # kek="01020304050607080102030405060708"
+{{ range $index, $element := .JoinServer.KEK.Set }}
+  [[join_server.kek.set]]
+  label="{{ $element.Label }}"
+  kek="{{ $element.KEK }}"
+{{ end }}
+
+  # Join-request rate limiting.
+  #
+  # The number of join-requests (and rejoin-requests) is limited per DevEUI
+  # and per SenderID (the NetID of the network-server) within the given
+  # window. Join-requests exceeding the limit are rejected before any
+  # database read. Set the max. to 0 to disable the limit.
+  [join_server.rate_limit]
+  # Max. number of join-requests per DevEUI within the window.
+  dev_eui_max={{ .JoinServer.RateLimit.DevEUIMax }}
+
+  # DevEUI rate limit window.
+  dev_eui_window="{{ .JoinServer.RateLimit.DevEUIWindow }}"
+
+  # Max. number of join-requests per SenderID within the window.
+  sender_id_max={{ .JoinServer.RateLimit.SenderIDMax }}
+
+  # SenderID rate limit window.
+  sender_id_window="{{ .JoinServer.RateLimit.SenderIDWindow }}"
+
+  # Max. number of join-requests with an invalid MIC per DevEUI within the
+  # window.
+  #
+  # When reached, an error notification is sent and the device is blocked
+  # for the given block duration. Set this to 0 to disable.
+  mic_failure_max={{ .JoinServer.RateLimit.MICFailureMax }}
+
+  # MIC failure window.
+  mic_failure_window="{{ .JoinServer.RateLimit.MICFailureWindow }}"
+
+  # Duration for which a device is blocked.
+  block_duration="{{ .JoinServer.RateLimit.BlockDuration }}"
+
+  # PKCS#11 HSM configuration.
+  #
+  # When enabled, the device root-keys (AppKey and NwkKey) are stored in a
+  # HSM, accessed through its PKCS#11 module, instead of in the database.
+  # The keys must be stored as AES secret-key objects, using the
+  # <DevEUI>:app_key, <DevEUI>:nwk_key and <DevEUI>:gen_app_key labels
+  # (e.g. 0102030405060708:app_key).
+  [join_server.pkcs11]
+  # Use the HSM for the device root-keys.
+  enabled={{ .JoinServer.PKCS11.Enabled }}
+
+  # Path to the PKCS#11 module (shared library).
+  module="{{ .JoinServer.PKCS11.Module }}"
+
+  # Label of the token holding the keys.
+  token_label="{{ .JoinServer.PKCS11.TokenLabel }}"
+
+  # User PIN of the token.
+  pin="{{ .JoinServer.PKCS11.PIN }}"
+
+  # Number of sessions opened on the token.
+  #
+  # The HSM operations are executed concurrently using a pool of sessions.
+  # Sessions which have become invalid (e.g. after a restart of the HSM)
+  # are re-opened.
+  session_pool_size={{ .JoinServer.PKCS11.SessionPoolSize }}
 `
 
 var configCmd = &cobra.Command{
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"io/ioutil"
+	"time"
 
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/spf13/viper"
@@ -46,15 +47,31 @@ func init() {
 	viper.SetDefault("application_server.api.bind", "0.0.0.0:8001")
 	viper.SetDefault("application_server.external_api.bind", "0.0.0.0:8080")
 	viper.SetDefault("join_server.bind", "0.0.0.0:8003")
+	viper.SetDefault("join_server.dev_nonce_history_size", 100)
+	viper.SetDefault("join_server.join_history_size", 100)
+	viper.SetDefault("join_server.rate_limit.dev_eui_max", 10)
+	viper.SetDefault("join_server.rate_limit.dev_eui_window", time.Minute)
+	viper.SetDefault("join_server.rate_limit.sender_id_window", time.Minute)
+	viper.SetDefault("join_server.rate_limit.mic_failure_max", 10)
+	viper.SetDefault("join_server.rate_limit.mic_failure_window", time.Hour)
+	viper.SetDefault("join_server.rate_limit.block_duration", time.Hour)
+	viper.SetDefault("join_server.pkcs11.session_pool_size", 10)
 	viper.SetDefault("application_server.integration.mqtt.uplink_topic_template", "application/{{ .ApplicationID }}/device/{{ .DevEUI }}/rx")
 	viper.SetDefault("application_server.integration.mqtt.downlink_topic_template", "application/{{ .ApplicationID }}/device/{{ .DevEUI }}/tx")
+	viper.SetDefault("application_server.integration.mqtt.batch_downlink_topic_template", "application/{{ .ApplicationID }}/tx")
 	viper.SetDefault("application_server.integration.mqtt.join_topic_template", "application/{{ .ApplicationID }}/device/{{ .DevEUI }}/join")
 	viper.SetDefault("application_server.integration.mqtt.ack_topic_template", "application/{{ .ApplicationID }}/device/{{ .DevEUI }}/ack")
 	viper.SetDefault("application_server.integration.mqtt.error_topic_template", "application/{{ .ApplicationID }}/device/{{ .DevEUI }}/error")
 	viper.SetDefault("application_server.integration.mqtt.clean_session", true)
+	viper.SetDefault("application_server.downlink.idempotency_window", 24*time.Hour)
+	viper.SetDefault("application_server.downlink.status_retention", 30*24*time.Hour)
+	viper.SetDefault("application_server.downlink_batch.concurrency", 10)
+	viper.SetDefault("application_server.device_claim.failure_max", 5)
+	viper.SetDefault("application_server.device_claim.failure_window", time.Hour)
 
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(rotateMasterKeyCmd)
 }
 
 // Execute executes the root command.
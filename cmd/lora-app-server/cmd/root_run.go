@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -33,9 +34,12 @@ import (
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/downlink"
+	"github.com/brocaar/lora-app-server/internal/fuota"
 	"github.com/brocaar/lora-app-server/internal/gwping"
 	"github.com/brocaar/lora-app-server/internal/handler/mqtthandler"
 	"github.com/brocaar/lora-app-server/internal/handler/multihandler"
+	"github.com/brocaar/lora-app-server/internal/hsm"
+	"github.com/brocaar/lora-app-server/internal/join"
 	"github.com/brocaar/lora-app-server/internal/migrations"
 	"github.com/brocaar/lora-app-server/internal/nsclient"
 	"github.com/brocaar/lora-app-server/internal/static"
@@ -52,6 +56,7 @@ func run(cmd *cobra.Command, args []string) error {
 		setLogLevel,
 		printStartMessage,
 		setPostgreSQLConnection,
+		setMasterKeys,
 		setRedisPool,
 		setHandler,
 		setNetworkServerClient,
@@ -59,7 +64,12 @@ func run(cmd *cobra.Command, args []string) error {
 		setJWTSecret,
 		setHashIterations,
 		setDisableAssignExistingUsers,
+		setJoinServerHSM,
 		handleDataDownPayloads,
+		startScheduledDownlinks,
+		startDownlinkSchedules,
+		startDeviceQueueItemStatusCleanup,
+		startFUOTACampaigns,
 		startApplicationServerAPI,
 		startGatewayPing,
 		startJoinServerAPI,
@@ -113,6 +123,33 @@ func setPostgreSQLConnection() error {
 	return nil
 }
 
+func setMasterKeys() error {
+	keys := make(map[string][]byte)
+
+	for _, mk := range config.C.PostgreSQL.Encryption.MasterKeys {
+		keyHEX := mk.Key
+		if mk.KeyFile != "" {
+			b, err := ioutil.ReadFile(mk.KeyFile)
+			if err != nil {
+				return errors.Wrapf(err, "read master-key %s file error", mk.ID)
+			}
+			keyHEX = strings.TrimSpace(string(b))
+		}
+
+		key, err := hex.DecodeString(keyHEX)
+		if err != nil {
+			return errors.Wrapf(err, "decode master-key %s error", mk.ID)
+		}
+		keys[mk.ID] = key
+	}
+
+	if err := storage.SetMasterKeys(config.C.PostgreSQL.Encryption.MasterKeyID, keys); err != nil {
+		return errors.Wrap(err, "set master-keys error")
+	}
+
+	return nil
+}
+
 func setRedisPool() error {
 	// setup redis pool
 	log.Info("setup redis connection pool")
@@ -170,11 +207,50 @@ func setDisableAssignExistingUsers() error {
 	return nil
 }
 
+func setJoinServerHSM() error {
+	if !config.C.JoinServer.PKCS11.Enabled {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"module":      config.C.JoinServer.PKCS11.Module,
+		"token_label": config.C.JoinServer.PKCS11.TokenLabel,
+	}).Info("connecting to pkcs#11 hsm")
+
+	b, err := hsm.NewPKCS11(config.C.JoinServer.PKCS11.Module, config.C.JoinServer.PKCS11.TokenLabel, config.C.JoinServer.PKCS11.PIN, config.C.JoinServer.PKCS11.SessionPoolSize)
+	if err != nil {
+		return errors.Wrap(err, "new pkcs#11 hsm error")
+	}
+	join.SetHSM(b)
+
+	return nil
+}
+
 func handleDataDownPayloads() error {
 	go downlink.HandleDataDownPayloads()
 	return nil
 }
 
+func startScheduledDownlinks() error {
+	go downlink.ScheduledDownlinksLoop()
+	return nil
+}
+
+func startDownlinkSchedules() error {
+	go downlink.DownlinkSchedulesLoop()
+	return nil
+}
+
+func startDeviceQueueItemStatusCleanup() error {
+	go downlink.DeviceQueueItemStatusCleanupLoop()
+	return nil
+}
+
+func startFUOTACampaigns() error {
+	go fuota.CampaignsLoop()
+	return nil
+}
+
 func startApplicationServerAPI() error {
 	log.WithFields(log.Fields{
 		"bind":     config.C.ApplicationServer.API.Bind,
@@ -263,6 +339,10 @@ func startClientAPI(ctx context.Context) func() error {
 		pb.RegisterNetworkServerServer(clientAPIHandler, api.NewNetworkServerAPI(validator))
 		pb.RegisterServiceProfileServiceServer(clientAPIHandler, api.NewServiceProfileServiceAPI(validator))
 		pb.RegisterDeviceProfileServiceServer(clientAPIHandler, api.NewDeviceProfileServiceAPI(validator))
+		pb.RegisterCodecServiceServer(clientAPIHandler, api.NewCodecAPI(validator))
+		pb.RegisterDownlinkScheduleServiceServer(clientAPIHandler, api.NewDownlinkScheduleAPI(validator))
+		pb.RegisterMulticastGroupServiceServer(clientAPIHandler, api.NewMulticastGroupAPI(validator))
+		pb.RegisterFUOTAServiceServer(clientAPIHandler, api.NewFUOTAAPI(validator))
 
 		// setup the client http interface variable
 		// we need to start the gRPC service first, as it is used by the
@@ -335,7 +415,7 @@ func mustGetAPIServer() *grpc.Server {
 	}
 	gs := grpc.NewServer(opts...)
 	asAPI := api.NewApplicationServerAPI()
-	as.RegisterApplicationServerServer(gs, asAPI)
+	as.RegisterApplicationServerServiceServer(gs, asAPI)
 	return gs
 }
 
@@ -435,6 +515,18 @@ func getJSONGateway(ctx context.Context) (http.Handler, error) {
 	if err := pb.RegisterDeviceProfileServiceHandlerFromEndpoint(ctx, mux, apiEndpoint, grpcDialOpts); err != nil {
 		return nil, errors.Wrap(err, "register device-profile handler error")
 	}
+	if err := pb.RegisterCodecServiceHandlerFromEndpoint(ctx, mux, apiEndpoint, grpcDialOpts); err != nil {
+		return nil, errors.Wrap(err, "register codec handler error")
+	}
+	if err := pb.RegisterDownlinkScheduleServiceHandlerFromEndpoint(ctx, mux, apiEndpoint, grpcDialOpts); err != nil {
+		return nil, errors.Wrap(err, "register downlink schedule handler error")
+	}
+	if err := pb.RegisterMulticastGroupServiceHandlerFromEndpoint(ctx, mux, apiEndpoint, grpcDialOpts); err != nil {
+		return nil, errors.Wrap(err, "register multicast-group handler error")
+	}
+	if err := pb.RegisterFUOTAServiceHandlerFromEndpoint(ctx, mux, apiEndpoint, grpcDialOpts); err != nil {
+		return nil, errors.Wrap(err, "register fuota handler error")
+	}
 
 	return mux, nil
 }
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+var rotateMasterKeyCmd = &cobra.Command{
+	Use:   "rotate-master-key",
+	Short: "Re-encrypt the device keys using the active master-key",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tasks := []func() error{
+			setLogLevel,
+			setPostgreSQLConnection,
+			setMasterKeys,
+			runDatabaseMigrations,
+		}
+
+		for _, t := range tasks {
+			if err := t(); err != nil {
+				return err
+			}
+		}
+
+		count, err := storage.RotateMasterKey(config.C.PostgreSQL.DB)
+		if err != nil {
+			return errors.Wrap(err, "rotate master-key error")
+		}
+
+		log.WithFields(log.Fields{
+			"master_key_id": config.C.PostgreSQL.Encryption.MasterKeyID,
+			"count":         count,
+		}).Info("master-key rotated")
+
+		return nil
+	},
+}
@@ -48,6 +48,17 @@ func (a *ApplicationAPI) Create(ctx context.Context, req *pb.CreateApplicationRe
 		PayloadEncoderScript: req.PayloadEncoderScript,
 		PayloadDecoderScript: req.PayloadDecoderScript,
 	}
+	app.CodecID, app.CodecVersion = codecReferenceFromPB(req.CodecID, req.CodecVersion)
+
+	var err error
+	app.PayloadFieldsMetadata, err = codecFieldsFromPB(req.PayloadFieldsMetadata)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.ValidateCodecReference(config.C.PostgreSQL.DB, app.OrganizationID, app.CodecID, app.CodecVersion); err != nil {
+		return nil, errToRPCError(err)
+	}
 
 	if err := storage.CreateApplication(config.C.PostgreSQL.DB, &app); err != nil {
 		return nil, errToRPCError(err)
@@ -71,14 +82,20 @@ func (a *ApplicationAPI) Get(ctx context.Context, req *pb.GetApplicationRequest)
 		return nil, errToRPCError(err)
 	}
 	resp := pb.GetApplicationResponse{
-		Id:                   app.ID,
-		Name:                 app.Name,
-		Description:          app.Description,
-		OrganizationID:       app.OrganizationID,
-		ServiceProfileID:     app.ServiceProfileID,
-		PayloadCodec:         string(app.PayloadCodec),
-		PayloadEncoderScript: app.PayloadEncoderScript,
-		PayloadDecoderScript: app.PayloadDecoderScript,
+		Id:                    app.ID,
+		Name:                  app.Name,
+		Description:           app.Description,
+		OrganizationID:        app.OrganizationID,
+		ServiceProfileID:      app.ServiceProfileID,
+		PayloadCodec:          string(app.PayloadCodec),
+		PayloadEncoderScript:  app.PayloadEncoderScript,
+		PayloadDecoderScript:  app.PayloadDecoderScript,
+		PayloadFieldsMetadata: codecFieldsToPB(app.PayloadFieldsMetadata),
+	}
+
+	resp.CodecID, resp.CodecVersion, resp.CodecLatestVersion, err = codecReferenceToPB(config.C.PostgreSQL.DB, app.CodecID, app.CodecVersion)
+	if err != nil {
+		return nil, errToRPCError(err)
 	}
 
 	return &resp, nil
@@ -104,6 +121,16 @@ func (a *ApplicationAPI) Update(ctx context.Context, req *pb.UpdateApplicationRe
 	app.PayloadCodec = codec.Type(req.PayloadCodec)
 	app.PayloadEncoderScript = req.PayloadEncoderScript
 	app.PayloadDecoderScript = req.PayloadDecoderScript
+	app.CodecID, app.CodecVersion = codecReferenceFromPB(req.CodecID, req.CodecVersion)
+
+	app.PayloadFieldsMetadata, err = codecFieldsFromPB(req.PayloadFieldsMetadata)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.ValidateCodecReference(config.C.PostgreSQL.DB, app.OrganizationID, app.CodecID, app.CodecVersion); err != nil {
+		return nil, errToRPCError(err)
+	}
 
 	err = storage.UpdateApplication(config.C.PostgreSQL.DB, app)
 	if err != nil {
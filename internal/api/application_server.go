@@ -6,18 +6,33 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/brocaar/lorawan/backend"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/lora-app-server/internal/applayer/clocksync"
+	"github.com/brocaar/lora-app-server/internal/applayer/fragmentation"
+	"github.com/brocaar/lora-app-server/internal/applayer/multicastsetup"
 	"github.com/brocaar/lora-app-server/internal/codec"
 	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/downlink"
 	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/fuota"
 	"github.com/brocaar/lora-app-server/internal/gwping"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/join"
+	"github.com/brocaar/lora-app-server/internal/multicast"
+	"github.com/brocaar/lora-app-server/internal/shadow"
 	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/timesync"
 	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/lorawan"
 )
 
@@ -31,10 +46,9 @@ func NewApplicationServerAPI() *ApplicationServerAPI {
 }
 
 // HandleUplinkData handles incoming (uplink) data.
-func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.HandleUplinkDataRequest) (*as.HandleUplinkDataResponse, error) {
-	var appEUI, devEUI lorawan.EUI64
-	copy(appEUI[:], req.AppEUI)
-	copy(devEUI[:], req.DevEUI)
+func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.HandleUplinkDataRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
 
 	d, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI)
 	if err != nil {
@@ -50,6 +64,17 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 		return nil, grpc.Errorf(codes.Internal, errStr)
 	}
 
+	// the device-activation context is set on the first uplink after the
+	// security context has changed, e.g. when the device was activated
+	// through an other join-server
+	if req.DeviceActivationContext != nil {
+		if err := handleDeviceActivationContext(d.DevEUI, req.DeviceActivationContext); err != nil {
+			errStr := fmt.Sprintf("handle device-activation context error: %s", err)
+			log.WithField("dev_eui", d.DevEUI).Error(errStr)
+			return nil, grpc.Errorf(codes.Internal, errStr)
+		}
+	}
+
 	da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
 	if err != nil {
 		errStr := fmt.Sprintf("get device-activation error: %s", err)
@@ -59,16 +84,6 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 
 	now := time.Now()
 	d.LastSeenAt = &now
-	d.DeviceStatusBattery = nil
-	if req.DeviceStatusBattery != 256 {
-		batt := int(req.DeviceStatusBattery)
-		d.DeviceStatusBattery = &batt
-	}
-	d.DeviceStatusMargin = nil
-	if req.DeviceStatusMargin != 256 {
-		marg := int(req.DeviceStatusMargin)
-		d.DeviceStatusMargin = &marg
-	}
 	err = storage.UpdateDevice(config.C.PostgreSQL.DB, &d)
 	if err != nil {
 		errStr := fmt.Sprintf("update device error: %s", err)
@@ -85,12 +100,100 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 		return nil, grpc.Errorf(codes.Internal, "decrypt payload error: %s", err)
 	}
 
+	// Remote Multicast Setup answers of multicast-group members are handled
+	// by the application-server and are not forwarded to the integrations
+	if req.FPort == uint32(multicastsetup.DefaultFPort) {
+		count, err := storage.GetMulticastGroupCountForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+		if err != nil {
+			errStr := fmt.Sprintf("get multicast-group count error: %s", err)
+			log.WithField("dev_eui", d.DevEUI).Error(errStr)
+			return nil, grpc.Errorf(codes.Internal, errStr)
+		}
+
+		if count > 0 {
+			if err := multicast.HandleRemoteMulticastSetupCommand(config.C.PostgreSQL.DB, d.DevEUI, b); err != nil {
+				log.WithFields(log.Fields{
+					"dev_eui": d.DevEUI,
+					"f_cnt":   req.FCnt,
+				}).WithError(err).Error("handle remote multicast setup command error")
+			}
+			return &empty.Empty{}, nil
+		}
+	}
+
+	// Fragmented Data Block Transport answers of devices which are part of
+	// an active FUOTA campaign are handled by the campaign and are not
+	// forwarded to the integrations
+	if req.FPort == uint32(fragmentation.DefaultFPort) {
+		count, err := storage.GetActiveFUOTACampaignCountForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+		if err != nil {
+			errStr := fmt.Sprintf("get active fuota campaign count error: %s", err)
+			log.WithField("dev_eui", d.DevEUI).Error(errStr)
+			return nil, grpc.Errorf(codes.Internal, errStr)
+		}
+
+		if count > 0 {
+			if err := fuota.HandleFragmentationCommand(config.C.PostgreSQL.DB, d.DevEUI, b); err != nil {
+				log.WithFields(log.Fields{
+					"dev_eui": d.DevEUI,
+					"f_cnt":   req.FCnt,
+				}).WithError(err).Error("handle fragmentation command error")
+			}
+			return &empty.Empty{}, nil
+		}
+	}
+
+	// Clock Synchronization requests are answered by the application-server
+	// when enabled by the device-profile and are then not forwarded to the
+	// integrations
+	if req.FPort == uint32(clocksync.DefaultFPort) {
+		dp, err := storage.GetDeviceProfileMeta(config.C.PostgreSQL.DB, d.DeviceProfileID)
+		if err != nil {
+			errStr := fmt.Sprintf("get device-profile error: %s", err)
+			log.WithField("dev_eui", d.DevEUI).Error(errStr)
+			return nil, grpc.Errorf(codes.Internal, errStr)
+		}
+
+		if dp.ClockSyncEnabled {
+			// gateways without GPS time report a zero time, in which case
+			// the time of the application-server is used
+			rxTime := now
+			for _, rxInfo := range req.RxInfo {
+				if ts := getRXTime(rxInfo); ts != nil {
+					rxTime = *ts
+					break
+				}
+			}
+
+			if err := timesync.HandleClockSyncCommand(config.C.PostgreSQL.DB, d.DevEUI, dp.ClockSyncPeriodicity, rxTime, b); err != nil {
+				log.WithFields(log.Fields{
+					"dev_eui": d.DevEUI,
+					"f_cnt":   req.FCnt,
+				}).WithError(err).Error("handle clock sync command error")
+			}
+			return &empty.Empty{}, nil
+		}
+	}
+
+	cc, err := storage.GetCodecConfigForDevice(config.C.PostgreSQL.DB, app, d.DeviceProfileID)
+	if err != nil {
+		errStr := fmt.Sprintf("get codec config error: %s", err)
+		log.WithField("dev_eui", d.DevEUI).Error(errStr)
+		return nil, grpc.Errorf(codes.Internal, errStr)
+	}
+
 	var object interface{}
-	codecPL := codec.NewPayload(app.PayloadCodec, uint8(req.FPort), app.PayloadEncoderScript, app.PayloadDecoderScript)
+	codecPL := codec.NewPayload(cc.Type, uint8(req.FPort), cc.EncoderScript, cc.DecoderScript)
 	if codecPL != nil {
-		if err := codecPL.DecodeBytes(b); err != nil {
+		// on a normalization error, only the invalid fields are removed
+		// from the object
+		err := codecPL.DecodeBytes(b)
+		if err == nil {
+			object, err = codec.NormalizeObject(codecPL.Object(), cc.FieldsMetadata)
+		}
+		if err != nil {
 			log.WithFields(log.Fields{
-				"codec":          app.PayloadCodec,
+				"codec":          cc.Type,
 				"application_id": app.ID,
 				"f_port":         req.FPort,
 				"f_cnt":          req.FCnt,
@@ -117,8 +220,20 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 			if err := config.C.ApplicationServer.Integration.Handler.SendErrorNotification(errNotification); err != nil {
 				log.WithError(err).Error("send error notification to handler error")
 			}
-		} else {
-			object = codecPL.Object()
+		}
+	}
+
+	// update the reported state of the device shadow and enqueue the
+	// delta with the desired state (if any)
+	if object != nil {
+		err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+			return shadow.HandleUplink(tx, app, d, object)
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": d.DevEUI,
+				"f_cnt":   req.FCnt,
+			}).WithError(err).Error("handle device shadow error")
 		}
 	}
 
@@ -131,15 +246,11 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 		DeviceStatusMargin:  d.DeviceStatusMargin,
 		RXInfo:              []handler.RXInfo{},
 		TXInfo: handler.TXInfo{
-			Frequency: int(req.TxInfo.Frequency),
+			Frequency: int(req.GetTxInfo().GetFrequency()),
 			DataRate: handler.DataRate{
-				Modulation:   req.TxInfo.DataRate.Modulation,
-				Bandwidth:    int(req.TxInfo.DataRate.BandWidth),
-				SpreadFactor: int(req.TxInfo.DataRate.SpreadFactor),
-				Bitrate:      int(req.TxInfo.DataRate.Bitrate),
+				Modulation: req.GetTxInfo().GetModulation().String(),
 			},
-			ADR:      req.TxInfo.Adr,
-			CodeRate: req.TxInfo.CodeRate,
+			ADR: req.Adr,
 		},
 		FCnt:   req.FCnt,
 		FPort:  uint8(req.FPort),
@@ -147,32 +258,44 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 		Object: object,
 	}
 
+	if modInfo := req.GetTxInfo().GetLoraModulationInfo(); modInfo != nil {
+		pl.TXInfo.DataRate.Bandwidth = int(modInfo.Bandwidth)
+		pl.TXInfo.DataRate.SpreadFactor = int(modInfo.SpreadingFactor)
+		pl.TXInfo.CodeRate = modInfo.CodeRate
+	}
+	if modInfo := req.GetTxInfo().GetFskModulationInfo(); modInfo != nil {
+		pl.TXInfo.DataRate.Bandwidth = int(modInfo.Bandwidth)
+		pl.TXInfo.DataRate.Bitrate = int(modInfo.Bitrate)
+	}
+
+	if object != nil {
+		pl.ObjectMetadata = cc.FieldsMetadata
+	}
+
 	for _, rxInfo := range req.RxInfo {
-		var timestamp *time.Time
 		var mac lorawan.EUI64
-		copy(mac[:], rxInfo.Mac)
+		copy(mac[:], rxInfo.GatewayId)
 
-		if len(rxInfo.Time) > 0 {
-			ts, err := time.Parse(time.RFC3339Nano, rxInfo.Time)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"dev_eui":  devEUI,
-					"time_str": rxInfo.Time,
-				}).Errorf("unmarshal time error: %s", err)
-			} else if !ts.Equal(time.Time{}) {
-				timestamp = &ts
-			}
+		rx := handler.RXInfo{
+			MAC:     mac,
+			Time:    getRXTime(rxInfo),
+			RSSI:    int(rxInfo.Rssi),
+			LoRaSNR: rxInfo.LoraSnr,
 		}
-		pl.RXInfo = append(pl.RXInfo, handler.RXInfo{
-			MAC:       mac,
-			Time:      timestamp,
-			RSSI:      int(rxInfo.Rssi),
-			LoRaSNR:   rxInfo.LoRaSNR,
-			Name:      rxInfo.Name,
-			Latitude:  rxInfo.Latitude,
-			Longitude: rxInfo.Longitude,
-			Altitude:  rxInfo.Altitude,
-		})
+
+		// the gateway name is not part of the rx-info, gateways which are
+		// unknown to the application-server are reported without name
+		if g, err := storage.GetGateway(config.C.PostgreSQL.DB, mac, false); err == nil {
+			rx.Name = g.Name
+		}
+
+		if rxInfo.Location != nil {
+			rx.Latitude = rxInfo.Location.Latitude
+			rx.Longitude = rxInfo.Location.Longitude
+			rx.Altitude = rxInfo.Location.Altitude
+		}
+
+		pl.RXInfo = append(pl.RXInfo, rx)
 	}
 
 	err = eventlog.LogEventForDevice(devEUI, eventlog.EventLog{
@@ -189,13 +312,69 @@ func (a *ApplicationServerAPI) HandleUplinkData(ctx context.Context, req *as.Han
 		return nil, grpc.Errorf(codes.Internal, err.Error())
 	}
 
-	return &as.HandleUplinkDataResponse{}, nil
+	return &empty.Empty{}, nil
+}
+
+// getRXTime returns the time at which the frame was received by the gateway.
+// It returns nil when the gateway did not report a (valid) time, e.g. a
+// gateway without GPS time.
+func getRXTime(rxInfo *gw.UplinkRXInfo) *time.Time {
+	if rxInfo.Time == nil {
+		return nil
+	}
+
+	ts, err := ptypes.Timestamp(rxInfo.Time)
+	if err != nil || ts.Equal(time.Time{}) {
+		return nil
+	}
+
+	return &ts
+}
+
+// handleDeviceActivationContext stores the device-activation of the given
+// context, when its AppSKey differs from the AppSKey of the last
+// device-activation.
+func handleDeviceActivationContext(devEUI lorawan.EUI64, dac *as.DeviceActivationContext) error {
+	var ke *backend.KeyEnvelope
+	if dac.AppSKey != nil {
+		ke = &backend.KeyEnvelope{
+			KEKLabel: dac.AppSKey.KekLabel,
+			AESKey:   backend.HEXBytes(dac.AppSKey.AesKey),
+		}
+	}
+
+	appSKey, err := join.UnwrapKeyEnvelope(ke)
+	if err != nil {
+		return errors.Wrap(err, "unwrap AppSKey error")
+	}
+
+	var devAddr lorawan.DevAddr
+	copy(devAddr[:], dac.DevAddr)
+
+	da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, devEUI)
+	if err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get last device-activation error")
+	}
+	if err == nil && da.DevAddr == devAddr && da.AppSKey == appSKey {
+		return nil
+	}
+
+	err = storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &storage.DeviceActivation{
+		DevEUI:  devEUI,
+		DevAddr: devAddr,
+		AppSKey: appSKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "create device-activation error")
+	}
+
+	return nil
 }
 
 // HandleDownlinkACK handles an ack on a downlink transmission.
-func (a *ApplicationServerAPI) HandleDownlinkACK(ctx context.Context, req *as.HandleDownlinkACKRequest) (*as.HandleDownlinkACKResponse, error) {
+func (a *ApplicationServerAPI) HandleDownlinkACK(ctx context.Context, req *as.HandleDownlinkACKRequest) (*empty.Empty, error) {
 	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.DevEUI)
+	copy(devEUI[:], req.DevEui)
 
 	d, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI)
 	if err != nil {
@@ -219,6 +398,27 @@ func (a *ApplicationServerAPI) HandleDownlinkACK(ctx context.Context, req *as.Ha
 		return nil, errToRPCError(err)
 	}
 
+	status, err := setDeviceQueueItemAckStatus(devEUI, req.FCnt, req.Acknowledged)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var retryErr error
+	if !req.Acknowledged && status.Confirmed && status.DownlinkRetryPolicy.Enabled() {
+		retried, err := retryDeviceQueueItem(status)
+		if err != nil {
+			log.WithError(err).WithField("dev_eui", devEUI).Error("retry confirmed downlink error")
+		}
+		if retried {
+			return &empty.Empty{}, nil
+		}
+
+		retryErr = fmt.Errorf("confirmed downlink not acknowledged after %d attempt(s)", status.Attempt)
+		if err != nil {
+			retryErr = fmt.Errorf("confirmed downlink not acknowledged after %d attempt(s), retry error: %s", status.Attempt, err)
+		}
+	}
+
 	log.WithFields(log.Fields{
 		"dev_eui": devEUI,
 	}).Info("downlink device-queue item acknowledged")
@@ -231,6 +431,7 @@ func (a *ApplicationServerAPI) HandleDownlinkACK(ctx context.Context, req *as.Ha
 		Reference:       dqm.Reference,
 		Acknowledged:    req.Acknowledged,
 		FCnt:            req.FCnt,
+		Status:          string(status.State),
 	}
 
 	err = eventlog.LogEventForDevice(devEUI, eventlog.EventLog{
@@ -246,13 +447,93 @@ func (a *ApplicationServerAPI) HandleDownlinkACK(ctx context.Context, req *as.Ha
 		log.Errorf("send ack notification to handler error: %s", err)
 	}
 
-	return &as.HandleDownlinkACKResponse{}, nil
+	if retryErr != nil {
+		errPl := handler.ErrorNotification{
+			ApplicationID:   app.ID,
+			ApplicationName: app.Name,
+			DeviceName:      d.Name,
+			DevEUI:          devEUI,
+			Type:            "DOWNLINK_RETRY",
+			Error:           retryErr.Error(),
+			FCnt:            req.FCnt,
+		}
+
+		err = eventlog.LogEventForDevice(devEUI, eventlog.EventLog{
+			Type:    eventlog.Error,
+			Payload: errPl,
+		})
+		if err != nil {
+			log.WithError(err).Error("log event for device error")
+		}
+
+		err = config.C.ApplicationServer.Integration.Handler.SendErrorNotification(errPl)
+		if err != nil {
+			log.Errorf("send error notification to handler error: %s", err)
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// setDeviceQueueItemAckStatus sets the state of the acknowledged (or not
+// acknowledged) device-queue item and returns its status. As the device-queue
+// items are transmitted in order, the items queued before are set to sent.
+// An empty status is returned when the item has no status.
+func setDeviceQueueItemAckStatus(devEUI lorawan.EUI64, fCnt uint32, acknowledged bool) (storage.DeviceQueueItemStatus, error) {
+	var status storage.DeviceQueueItemStatus
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		if err := storage.SetDeviceQueueItemStatusSentBeforeFCnt(tx, devEUI, fCnt); err != nil {
+			return err
+		}
+
+		s, err := storage.GetDeviceQueueItemStatusForFCnt(tx, devEUI, fCnt)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return nil
+			}
+			return err
+		}
+
+		if acknowledged {
+			s.SetState(storage.DeviceQueueItemAcknowledged, time.Now())
+		} else {
+			s.SetState(storage.DeviceQueueItemNacked, time.Now())
+		}
+
+		if err := storage.UpdateDeviceQueueItemStatus(tx, &s); err != nil {
+			return err
+		}
+		status = s
+
+		return nil
+	})
+
+	return status, err
+}
+
+// retryDeviceQueueItem enqueues the not acknowledged device-queue item again
+// when its retry policy allows this. It returns false when the max number of
+// attempts has been reached.
+func retryDeviceQueueItem(s storage.DeviceQueueItemStatus) (bool, error) {
+	var retried bool
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		retried, err = downlink.RetryDownlinkPayload(tx, &s)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return retried, nil
 }
 
 // HandleError handles an incoming error.
-func (a *ApplicationServerAPI) HandleError(ctx context.Context, req *as.HandleErrorRequest) (*as.HandleErrorResponse, error) {
+func (a *ApplicationServerAPI) HandleError(ctx context.Context, req *as.HandleErrorRequest) (*empty.Empty, error) {
 	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.DevEUI)
+	copy(devEUI[:], req.DevEui)
 
 	d, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI)
 	if err != nil {
@@ -297,11 +578,11 @@ func (a *ApplicationServerAPI) HandleError(ctx context.Context, req *as.HandleEr
 		return nil, grpc.Errorf(codes.Internal, errStr)
 	}
 
-	return &as.HandleErrorResponse{}, nil
+	return &empty.Empty{}, nil
 }
 
 // HandleProprietaryUplink handles proprietary uplink payloads.
-func (a *ApplicationServerAPI) HandleProprietaryUplink(ctx context.Context, req *as.HandleProprietaryUplinkRequest) (*as.HandleProprietaryUplinkResponse, error) {
+func (a *ApplicationServerAPI) HandleProprietaryUplink(ctx context.Context, req *as.HandleProprietaryUplinkRequest) (*empty.Empty, error) {
 	err := gwping.HandleReceivedPing(req)
 	if err != nil {
 		errStr := fmt.Sprintf("handle received ping error: %s", err)
@@ -309,7 +590,60 @@ func (a *ApplicationServerAPI) HandleProprietaryUplink(ctx context.Context, req
 		return nil, grpc.Errorf(codes.Internal, errStr)
 	}
 
-	return &as.HandleProprietaryUplinkResponse{}, nil
+	return &empty.Empty{}, nil
+}
+
+// SetDeviceStatus updates the device-status of the device.
+func (a *ApplicationServerAPI) SetDeviceStatus(ctx context.Context, req *as.SetDeviceStatusRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		d, err := storage.GetDevice(tx, devEUI)
+		if err != nil {
+			return errors.Wrap(err, "get device error")
+		}
+
+		d.DeviceStatusBattery = nil
+		if req.Battery != 256 {
+			batt := int(req.Battery)
+			d.DeviceStatusBattery = &batt
+		}
+
+		d.DeviceStatusMargin = nil
+		if req.Margin != 256 {
+			marg := int(req.Margin)
+			d.DeviceStatusMargin = &marg
+		}
+
+		return storage.UpdateDevice(tx, &d)
+	})
+	if err != nil {
+		errStr := fmt.Sprintf("update device-status error: %s", err)
+		log.WithField("dev_eui", devEUI).Error(errStr)
+		return nil, grpc.Errorf(codes.Internal, errStr)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// SetDeviceLocation handles the location of the device, as resolved by the
+// network-server. The device location is not (yet) stored by the
+// application-server.
+func (a *ApplicationServerAPI) SetDeviceLocation(ctx context.Context, req *as.SetDeviceLocationRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	if req.Location != nil {
+		log.WithFields(log.Fields{
+			"dev_eui":   devEUI,
+			"latitude":  req.Location.Latitude,
+			"longitude": req.Location.Longitude,
+			"altitude":  req.Location.Altitude,
+		}).Info("device location received")
+	}
+
+	return &empty.Empty{}, nil
 }
 
 // getAppNonce returns a random application nonce (used for OTAA).
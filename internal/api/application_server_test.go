@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/brocaar/lora-app-server/internal/codec"
@@ -15,6 +16,8 @@ import (
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/lora-app-server/internal/test/testhandler"
 	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
@@ -90,14 +93,14 @@ func TestApplicationServerAPI(t *testing.T) {
 		}
 		So(storage.CreateDeviceKeys(config.C.PostgreSQL.DB, &dc), ShouldBeNil)
 
-		gw := storage.Gateway{
+		gateway := storage.Gateway{
 			MAC:             lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
 			Name:            "test-gw",
 			Description:     "test gateway",
 			OrganizationID:  org.ID,
 			NetworkServerID: n.ID,
 		}
-		So(storage.CreateGateway(config.C.PostgreSQL.DB, &gw), ShouldBeNil)
+		So(storage.CreateGateway(config.C.PostgreSQL.DB, &gateway), ShouldBeNil)
 
 		h := testhandler.NewTestHandler()
 		config.C.ApplicationServer.Integration.Handler = h
@@ -107,7 +110,7 @@ func TestApplicationServerAPI(t *testing.T) {
 
 		Convey("When calling HandleError", func() {
 			_, err := api.HandleError(ctx, &as.HandleErrorRequest{
-				DevEUI: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				DevEui: []byte{1, 2, 3, 4, 5, 6, 7, 8},
 				Type:   as.ErrorType_DATA_UP_FCNT,
 				Error:  "BOOM!",
 				FCnt:   123,
@@ -138,49 +141,61 @@ func TestApplicationServerAPI(t *testing.T) {
 			So(storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &da), ShouldBeNil)
 
 			now := time.Now().UTC()
+			nowPB, err := ptypes.TimestampProto(now)
+			So(err, ShouldBeNil)
 			mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
 
 			req := as.HandleUplinkDataRequest{
-				DevEUI: d.DevEUI[:],
+				DevEui: d.DevEUI[:],
 				FCnt:   10,
 				FPort:  3,
+				Adr:    true,
 				Data:   []byte{1, 2, 3, 4},
-				RxInfo: []*as.RXInfo{
+				RxInfo: []*gw.UplinkRXInfo{
 					{
-						Mac:       []byte{1, 2, 3, 4, 5, 6, 7, 8},
-						Name:      "test-gateway",
-						Latitude:  52.3740364,
-						Longitude: 4.9144401,
-						Altitude:  10,
-						Time:      now.Format(time.RFC3339Nano),
+						GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Time:      nowPB,
 						Rssi:      -60,
-						LoRaSNR:   5,
+						LoraSnr:   5,
+						Location: &common.Location{
+							Latitude:  52.3740364,
+							Longitude: 4.9144401,
+							Altitude:  10,
+						},
 					},
 				},
-				TxInfo: &as.TXInfo{
-					Frequency: 868100000,
-					DataRate: &as.DataRate{
-						Modulation:   "LORA",
-						BandWidth:    250,
-						SpreadFactor: 5,
-						Bitrate:      50000,
+				TxInfo: &gw.UplinkTXInfo{
+					Frequency:  868100000,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       250,
+							SpreadingFactor: 5,
+							CodeRate:        "4/6",
+						},
 					},
-					Adr:      true,
-					CodeRate: "4/6",
 				},
-				DeviceStatusBattery: 256,
-				DeviceStatusMargin:  256,
 			}
 
-			reqWithDeviceStatus := req
-			reqWithDeviceStatus.DeviceStatusBattery = 10
-			reqWithDeviceStatus.DeviceStatusMargin = 11
-
-			Convey("When calling HandleUplinkData with device-status data", func() {
-				_, err := api.HandleUplinkData(ctx, &reqWithDeviceStatus)
+			Convey("When calling SetDeviceStatus", func() {
+				_, err := api.SetDeviceStatus(ctx, &as.SetDeviceStatusRequest{
+					DevEui:  d.DevEUI[:],
+					Battery: 10,
+					Margin:  11,
+				})
 				So(err, ShouldBeNil)
 
-				Convey("Then a payload was sent to the handler", func() {
+				Convey("Then the device-status was updated", func() {
+					d, err := storage.GetDevice(config.C.PostgreSQL.DB, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(*d.DeviceStatusBattery, ShouldEqual, 10)
+					So(*d.DeviceStatusMargin, ShouldEqual, 11)
+				})
+
+				Convey("Then calling HandleUplinkData sends the device-status to the handler", func() {
+					_, err := api.HandleUplinkData(ctx, &req)
+					So(err, ShouldBeNil)
+
 					ten := 10
 					eleven := 11
 
@@ -195,7 +210,7 @@ func TestApplicationServerAPI(t *testing.T) {
 						RXInfo: []handler.RXInfo{
 							{
 								MAC:       mac,
-								Name:      "test-gateway",
+								Name:      "test-gw",
 								Latitude:  52.3740364,
 								Longitude: 4.9144401,
 								Altitude:  10,
@@ -210,7 +225,6 @@ func TestApplicationServerAPI(t *testing.T) {
 								Modulation:   "LORA",
 								Bandwidth:    250,
 								SpreadFactor: 5,
-								Bitrate:      50000,
 							},
 							ADR:      true,
 							CodeRate: "4/6",
@@ -220,14 +234,6 @@ func TestApplicationServerAPI(t *testing.T) {
 						Data:  []byte{67, 216, 236, 205},
 					})
 				})
-
-				Convey("Then the device was updated", func() {
-					d, err := storage.GetDevice(config.C.PostgreSQL.DB, d.DevEUI)
-					So(err, ShouldBeNil)
-					So(*d.DeviceStatusBattery, ShouldEqual, 10)
-					So(*d.DeviceStatusMargin, ShouldEqual, 11)
-					So(time.Now().Sub(*d.LastSeenAt), ShouldBeLessThan, time.Second)
-				})
 			})
 
 			Convey("When calling HandleUplinkData without device-status data", func() {
@@ -247,6 +253,26 @@ func TestApplicationServerAPI(t *testing.T) {
 				})
 			})
 
+			Convey("When calling HandleUplinkData with a device-activation context", func() {
+				reqWithContext := req
+				reqWithContext.DeviceActivationContext = &as.DeviceActivationContext{
+					DevAddr: []byte{1, 2, 3, 4},
+					AppSKey: &common.KeyEnvelope{
+						AesKey: []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+					},
+				}
+
+				_, err := api.HandleUplinkData(ctx, &reqWithContext)
+				So(err, ShouldBeNil)
+
+				Convey("Then a new device-activation was created", func() {
+					da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(da.DevAddr, ShouldEqual, lorawan.DevAddr{1, 2, 3, 4})
+					So(da.AppSKey, ShouldEqual, lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8})
+				})
+			})
+
 			Convey("When calling HandleUplinkData (Custom JS codec configured)", func() {
 				app.PayloadCodec = codec.CustomJSType
 				app.PayloadDecoderScript = `
@@ -286,7 +312,7 @@ func TestApplicationServerAPI(t *testing.T) {
 						RXInfo: []handler.RXInfo{
 							{
 								MAC:       mac,
-								Name:      "test-gateway",
+								Name:      "test-gw",
 								Latitude:  52.3740364,
 								Longitude: 4.9144401,
 								Altitude:  10,
@@ -301,7 +327,6 @@ func TestApplicationServerAPI(t *testing.T) {
 								Modulation:   "LORA",
 								Bandwidth:    250,
 								SpreadFactor: 5,
-								Bitrate:      50000,
 							},
 							ADR:      true,
 							CodeRate: "4/6",
@@ -313,6 +338,100 @@ func TestApplicationServerAPI(t *testing.T) {
 				})
 			})
 
+			Convey("When calling HandleUplinkData on the Remote Multicast Setup fPort (no multicast-group member)", func() {
+				reqMulticastSetup := req
+				reqMulticastSetup.FPort = 200
+				_, err := api.HandleUplinkData(ctx, &reqMulticastSetup)
+				So(err, ShouldBeNil)
+
+				Convey("Then the payload was sent to the handler", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When calling HandleUplinkData on the Remote Multicast Setup fPort (multicast-group member)", func() {
+				mg := storage.MulticastGroup{
+					ApplicationID: app.ID,
+					Name:          "test-group",
+					GroupType:     storage.MulticastGroupClassC,
+					Frequency:     869525000,
+				}
+				So(storage.CreateMulticastGroup(config.C.PostgreSQL.DB, &mg), ShouldBeNil)
+				So(storage.AddDeviceToMulticastGroup(config.C.PostgreSQL.DB, mg.ID, d.DevEUI), ShouldBeNil)
+
+				reqMulticastSetup := req
+				reqMulticastSetup.FPort = 200
+				_, err := api.HandleUplinkData(ctx, &reqMulticastSetup)
+				So(err, ShouldBeNil)
+
+				Convey("Then the payload was not sent to the handler", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When calling HandleUplinkData on the Fragmented Data Block Transport fPort (no active FUOTA campaign)", func() {
+				reqFragmentation := req
+				reqFragmentation.FPort = 201
+				_, err := api.HandleUplinkData(ctx, &reqFragmentation)
+				So(err, ShouldBeNil)
+
+				Convey("Then the payload was sent to the handler", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When calling HandleUplinkData on the Fragmented Data Block Transport fPort (active FUOTA campaign)", func() {
+				fw := storage.Firmware{
+					OrganizationID: org.ID,
+					Name:           "test-firmware",
+					Data:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				}
+				So(storage.CreateFirmware(config.C.PostgreSQL.DB, &fw), ShouldBeNil)
+				So(storage.CreateFUOTACampaign(config.C.PostgreSQL.DB, &storage.FUOTACampaign{
+					ApplicationID: app.ID,
+					FirmwareID:    fw.ID,
+					Name:          "test-campaign",
+					FragSize:      4,
+					Descriptor:    []byte{1, 2, 3, 4},
+					StepTimeout:   60,
+					DevEUIs:       []lorawan.EUI64{d.DevEUI},
+				}), ShouldBeNil)
+
+				reqFragmentation := req
+				reqFragmentation.FPort = 201
+				_, err := api.HandleUplinkData(ctx, &reqFragmentation)
+				So(err, ShouldBeNil)
+
+				Convey("Then the payload was not sent to the handler", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When calling HandleUplinkData on the Clock Synchronization fPort (clock sync disabled)", func() {
+				reqClockSync := req
+				reqClockSync.FPort = 202
+				_, err := api.HandleUplinkData(ctx, &reqClockSync)
+				So(err, ShouldBeNil)
+
+				Convey("Then the payload was sent to the handler", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When calling HandleUplinkData on the Clock Synchronization fPort (clock sync enabled)", func() {
+				dp.ClockSyncEnabled = true
+				So(storage.UpdateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+				reqClockSync := req
+				reqClockSync.FPort = 202
+				_, err := api.HandleUplinkData(ctx, &reqClockSync)
+				So(err, ShouldBeNil)
+
+				Convey("Then the payload was not sent to the handler", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 0)
+				})
+			})
+
 			Convey("Given a device-queue mapping", func() {
 				dqm := storage.DeviceQueueMapping{
 					Reference: "test-1234",
@@ -323,7 +442,7 @@ func TestApplicationServerAPI(t *testing.T) {
 
 				Convey("On HandleDownlinkACK (ack: true)", func() {
 					_, err := api.HandleDownlinkACK(ctx, &as.HandleDownlinkACKRequest{
-						DevEUI:       d.DevEUI[:],
+						DevEui:       d.DevEUI[:],
 						FCnt:         10,
 						Acknowledged: true,
 					})
@@ -350,7 +469,7 @@ func TestApplicationServerAPI(t *testing.T) {
 
 				Convey("On HandleDownlinkACK (ack: false)", func() {
 					_, err := api.HandleDownlinkACK(ctx, &as.HandleDownlinkACKRequest{
-						DevEUI:       d.DevEUI[:],
+						DevEui:       d.DevEUI[:],
 						FCnt:         10,
 						Acknowledged: false,
 					})
@@ -374,6 +493,132 @@ func TestApplicationServerAPI(t *testing.T) {
 						})
 					})
 				})
+
+				Convey("Given device-queue item statuses", func() {
+					fCnts := []uint32{9, 10}
+					statuses := make([]storage.DeviceQueueItemStatus, len(fCnts))
+					for i := range fCnts {
+						statuses[i] = storage.DeviceQueueItemStatus{
+							DevEUI:    d.DevEUI,
+							Reference: "test-1234",
+							Confirmed: true,
+							FCnt:      &fCnts[i],
+							State:     storage.DeviceQueueItemQueued,
+						}
+						So(storage.CreateDeviceQueueItemStatus(config.C.PostgreSQL.DB, &statuses[i]), ShouldBeNil)
+					}
+
+					Convey("On HandleDownlinkACK (ack: false)", func() {
+						_, err := api.HandleDownlinkACK(ctx, &as.HandleDownlinkACKRequest{
+							DevEui:       d.DevEUI[:],
+							FCnt:         10,
+							Acknowledged: false,
+						})
+						So(err, ShouldBeNil)
+
+						Convey("Then the item has been set to nacked", func() {
+							s, err := storage.GetDeviceQueueItemStatusForFCnt(config.C.PostgreSQL.DB, d.DevEUI, 10)
+							So(err, ShouldBeNil)
+							So(s.State, ShouldEqual, storage.DeviceQueueItemNacked)
+							So(s.SentAt, ShouldNotBeNil)
+							So(s.CompletedAt, ShouldNotBeNil)
+						})
+
+						Convey("Then the item queued before has been set to sent", func() {
+							s, err := storage.GetDeviceQueueItemStatusForFCnt(config.C.PostgreSQL.DB, d.DevEUI, 9)
+							So(err, ShouldBeNil)
+							So(s.State, ShouldEqual, storage.DeviceQueueItemSent)
+							So(s.SentAt, ShouldNotBeNil)
+							So(s.CompletedAt, ShouldBeNil)
+						})
+
+						Convey("Then the status is included in the ack notification", func() {
+							So(h.SendACKNotificationChan, ShouldHaveLength, 1)
+							pl := <-h.SendACKNotificationChan
+							So(pl.Status, ShouldEqual, "NACKED")
+						})
+					})
+				})
+
+				Convey("Given a device-queue item status with a retry policy", func() {
+					nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+						FCnt: 11,
+					}
+
+					fCnt := uint32(10)
+					st := storage.DeviceQueueItemStatus{
+						DevEUI:    d.DevEUI,
+						Reference: "test-1234",
+						Confirmed: true,
+						FCnt:      &fCnt,
+						State:     storage.DeviceQueueItemQueued,
+						Attempt:   1,
+						FPort:     10,
+						Data:      []byte{1, 2, 3, 4},
+						DownlinkRetryPolicy: storage.DownlinkRetryPolicy{
+							MaxAttempts: 2,
+						},
+					}
+					So(storage.CreateDeviceQueueItemStatus(config.C.PostgreSQL.DB, &st), ShouldBeNil)
+
+					Convey("On HandleDownlinkACK (ack: false)", func() {
+						_, err := api.HandleDownlinkACK(ctx, &as.HandleDownlinkACKRequest{
+							DevEui:       d.DevEUI[:],
+							FCnt:         10,
+							Acknowledged: false,
+						})
+						So(err, ShouldBeNil)
+
+						Convey("Then the item has been enqueued again", func() {
+							s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, d.DevEUI, "test-1234")
+							So(err, ShouldBeNil)
+							So(s.ID, ShouldEqual, st.ID)
+							So(s.State, ShouldEqual, storage.DeviceQueueItemQueued)
+							So(s.Attempt, ShouldEqual, 2)
+							So(*s.FCnt, ShouldEqual, 11)
+
+							_, err = storage.GetDeviceQueueMappingForDevEUIAndFCnt(config.C.PostgreSQL.DB, d.DevEUI, 11)
+							So(err, ShouldBeNil)
+						})
+
+						Convey("Then no notification was sent to the handler", func() {
+							So(h.SendACKNotificationChan, ShouldHaveLength, 0)
+							So(h.SendErrorNotificationChan, ShouldHaveLength, 0)
+						})
+
+						Convey("On HandleDownlinkACK of the last attempt (ack: false)", func() {
+							_, err := api.HandleDownlinkACK(ctx, &as.HandleDownlinkACKRequest{
+								DevEui:       d.DevEUI[:],
+								FCnt:         11,
+								Acknowledged: false,
+							})
+							So(err, ShouldBeNil)
+
+							Convey("Then the item has been set to nacked", func() {
+								s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, d.DevEUI, "test-1234")
+								So(err, ShouldBeNil)
+								So(s.State, ShouldEqual, storage.DeviceQueueItemNacked)
+								So(s.Attempt, ShouldEqual, 2)
+							})
+
+							Convey("Then an ack and error notification were sent to the handler", func() {
+								So(h.SendACKNotificationChan, ShouldHaveLength, 1)
+								So((<-h.SendACKNotificationChan).Status, ShouldEqual, "NACKED")
+
+								So(h.SendErrorNotificationChan, ShouldHaveLength, 1)
+								So(<-h.SendErrorNotificationChan, ShouldResemble, handler.ErrorNotification{
+									ApplicationID:   app.ID,
+									ApplicationName: app.Name,
+									DeviceName:      d.Name,
+									DevEUI:          d.DevEUI,
+									Type:            "DOWNLINK_RETRY",
+									Error:           "confirmed downlink not acknowledged after 2 attempt(s)",
+									FCnt:            11,
+								})
+							})
+						})
+					})
+				})
 			})
 		})
 	})
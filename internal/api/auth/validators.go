@@ -47,6 +47,17 @@ const userQuery = `
 	left join device d
 		on a.id = d.application_id`
 
+// The following conditions validate that the resource with the given ID
+// belongs to the organization. They are used instead of additional joins
+// on userQuery, as each join multiplies the number of rows to count.
+const (
+	codecCondition            = "exists (select 1 from codec c where c.id = $2 and c.organization_id = o.id)"
+	downlinkScheduleCondition = "exists (select 1 from downlink_schedule dls where dls.id = $2 and dls.organization_id = o.id)"
+	multicastGroupCondition   = "exists (select 1 from multicast_group mg inner join application mga on mga.id = mg.application_id where mg.id = $2 and mga.organization_id = o.id)"
+	firmwareCondition         = "exists (select 1 from firmware fw where fw.id = $2 and fw.organization_id = o.id)"
+	fuotaCampaignCondition    = "exists (select 1 from fuota_campaign fc inner join application fca on fca.id = fc.application_id where fc.id = $2 and fca.organization_id = o.id)"
+)
+
 // ValidateActiveUser validates if the user in the JWT claim is active.
 func ValidateActiveUser() ValidatorFunc {
 	where := [][]string{
@@ -370,6 +381,48 @@ func ValidateDeviceQueueAccess(devEUI lorawan.EUI64, flag Flag) ValidatorFunc {
 	}
 }
 
+// ValidateDeviceQueueBatchAccess validates if the client has access to the
+// queue of the devices of the given application.
+func ValidateDeviceQueueBatchAccess(applicationID int64, flag Flag) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create:
+		// global admin
+		// organization user
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "a.id = $2"},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, applicationID)
+	}
+}
+
+// ValidateUnclaimedDevicesAccess validates if the client has access to the
+// unclaimed devices.
+func ValidateUnclaimedDevicesAccess(flag Flag) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create, List, Delete:
+		// global admin
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username)
+	}
+}
+
 // ValidateGatewaysAccess validates if the client has access to the gateways.
 func ValidateGatewaysAccess(flag Flag, organizationID int64) ValidatorFunc {
 	var where = [][]string{}
@@ -775,6 +828,308 @@ func ValidateDeviceProfileAccess(flag Flag, id string) ValidatorFunc {
 	}
 }
 
+// ValidateCodecsAccess validates if the client has access to the codecs.
+func ValidateCodecsAccess(flag Flag, organizationID int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create:
+		// global admin
+		// organization admin
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "o.id = $2", "ou.is_admin = true"},
+		}
+	case List:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "o.id = $2"},
+		}
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, organizationID)
+	}
+}
+
+// ValidateCodecAccess validates if the client has access to the given codec.
+func ValidateCodecAccess(flag Flag, id int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Read:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", codecCondition},
+		}
+	case Update, Delete:
+		// global admin
+		// organization admin users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", codecCondition},
+		}
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, id)
+	}
+}
+
+// ValidateDownlinkSchedulesAccess validates if the client has access to the
+// downlink schedules of the given organization.
+func ValidateDownlinkSchedulesAccess(flag Flag, organizationID int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create:
+		// global admin
+		// organization admin
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "o.id = $2", "ou.is_admin = true"},
+		}
+	case List:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "o.id = $2"},
+		}
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, organizationID)
+	}
+}
+
+// ValidateDownlinkScheduleAccess validates if the client has access to the
+// given downlink schedule.
+func ValidateDownlinkScheduleAccess(flag Flag, id int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Read:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", downlinkScheduleCondition},
+		}
+	case Update, Delete:
+		// global admin
+		// organization admin users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", downlinkScheduleCondition},
+		}
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, id)
+	}
+}
+
+// ValidateMulticastGroupsAccess validates if the client has access to the
+// multicast-groups of the given application.
+func ValidateMulticastGroupsAccess(flag Flag, applicationID int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create:
+		// global admin
+		// organization admin
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", "a.id = $2"},
+		}
+	case List:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "a.id = $2"},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, applicationID)
+	}
+}
+
+// ValidateMulticastGroupAccess validates if the client has access to the
+// given multicast-group.
+func ValidateMulticastGroupAccess(flag Flag, id int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Read:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", multicastGroupCondition},
+		}
+	case Update, Delete:
+		// global admin
+		// organization admin users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", multicastGroupCondition},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, id)
+	}
+}
+
+// ValidateMulticastGroupQueueAccess validates if the client has access to
+// the queue of the given multicast-group.
+func ValidateMulticastGroupQueueAccess(flag Flag, id int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create, List, Delete:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", multicastGroupCondition},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, id)
+	}
+}
+
+// ValidateFirmwaresAccess validates if the client has access to the
+// firmwares of the given organization.
+func ValidateFirmwaresAccess(flag Flag, organizationID int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create:
+		// global admin
+		// organization admin
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "o.id = $2", "ou.is_admin = true"},
+		}
+	case List:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "o.id = $2"},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, organizationID)
+	}
+}
+
+// ValidateFirmwareAccess validates if the client has access to the given
+// firmware.
+func ValidateFirmwareAccess(flag Flag, id int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Read:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", firmwareCondition},
+		}
+	case Delete:
+		// global admin
+		// organization admin users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", firmwareCondition},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, id)
+	}
+}
+
+// ValidateFUOTACampaignsAccess validates if the client has access to the
+// FUOTA campaigns of the given application.
+func ValidateFUOTACampaignsAccess(flag Flag, applicationID int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Create:
+		// global admin
+		// organization admin
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", "a.id = $2"},
+		}
+	case List:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "a.id = $2"},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, applicationID)
+	}
+}
+
+// ValidateFUOTACampaignAccess validates if the client has access to the
+// given FUOTA campaign.
+func ValidateFUOTACampaignAccess(flag Flag, id int64) ValidatorFunc {
+	var where = [][]string{}
+
+	switch flag {
+	case Read:
+		// global admin
+		// organization users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", fuotaCampaignCondition},
+		}
+	case Delete:
+		// global admin
+		// organization admin users
+		where = [][]string{
+			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+			{"u.username = $1", "u.is_active = true", "ou.is_admin = true", fuotaCampaignCondition},
+		}
+	default:
+		panic("unsupported flag")
+	}
+
+	return func(db sqlx.Queryer, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username, id)
+	}
+}
+
 func executeQuery(db sqlx.Queryer, query string, where [][]string, args ...interface{}) (bool, error) {
 	var ors []string
 	for _, ands := range where {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/brocaar/lora-app-server/internal/codec"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
@@ -106,6 +107,16 @@ func TestValidators(t *testing.T) {
 		}
 	}
 
+	codecs := []storage.Codec{
+		{OrganizationID: organizations[0].ID, Name: "test-codec-1", Type: codec.CayenneLPPType},
+		{OrganizationID: organizations[1].ID, Name: "test-codec-2", Type: codec.CayenneLPPType},
+	}
+	for i := range codecs {
+		if err := storage.CreateCodec(db, &codecs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	devices := []storage.Device{
 		{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Name: "test-1", ApplicationID: applications[0].ID, DeviceProfileID: deviceProfiles[0].DeviceProfile.DeviceProfileID},
 		{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Name: "test-2", ApplicationID: applications[1].ID, DeviceProfileID: deviceProfiles[1].DeviceProfile.DeviceProfileID},
@@ -116,6 +127,46 @@ func TestValidators(t *testing.T) {
 		}
 	}
 
+	downlinkSchedules := []storage.DownlinkSchedule{
+		{OrganizationID: organizations[0].ID, Name: "test-schedule-1", CronExpression: "@hourly", ApplicationID: &applications[0].ID, FPort: 1, Data: []byte{1}},
+		{OrganizationID: organizations[1].ID, Name: "test-schedule-2", CronExpression: "@hourly", ApplicationID: &applications[1].ID, FPort: 1, Data: []byte{1}},
+	}
+	for i := range downlinkSchedules {
+		if err := storage.CreateDownlinkSchedule(db, &downlinkSchedules[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	multicastGroups := []storage.MulticastGroup{
+		{ApplicationID: applications[0].ID, Name: "test-mg-1", GroupType: storage.MulticastGroupClassC, Frequency: 869525000},
+		{ApplicationID: applications[1].ID, Name: "test-mg-2", GroupType: storage.MulticastGroupClassC, Frequency: 869525000},
+	}
+	for i := range multicastGroups {
+		if err := storage.CreateMulticastGroup(db, &multicastGroups[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	firmwares := []storage.Firmware{
+		{OrganizationID: organizations[0].ID, Name: "test-fw-1", Data: []byte{1, 2, 3, 4}},
+		{OrganizationID: organizations[1].ID, Name: "test-fw-2", Data: []byte{1, 2, 3, 4}},
+	}
+	for i := range firmwares {
+		if err := storage.CreateFirmware(db, &firmwares[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fuotaCampaigns := []storage.FUOTACampaign{
+		{ApplicationID: applications[0].ID, FirmwareID: firmwares[0].ID, Name: "test-fc-1", FragSize: 2, Descriptor: []byte{1, 2, 3, 4}, StepTimeout: 60, DevEUIs: []lorawan.EUI64{devices[0].DevEUI}},
+		{ApplicationID: applications[1].ID, FirmwareID: firmwares[1].ID, Name: "test-fc-2", FragSize: 2, Descriptor: []byte{1, 2, 3, 4}, StepTimeout: 60, DevEUIs: []lorawan.EUI64{devices[1].DevEUI}},
+	}
+	for i := range fuotaCampaigns {
+		if err := storage.CreateFUOTACampaign(db, &fuotaCampaigns[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	// cleanup once structs are in place
 	users := []struct {
 		ID       int64
@@ -394,6 +445,31 @@ func TestValidators(t *testing.T) {
 			runTests(tests, db)
 		})
 
+		Convey("When testing ValidateUnclaimedDevicesAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create, list and delete",
+					Validators: []ValidatorFunc{ValidateUnclaimedDevicesAccess(Create), ValidateUnclaimedDevicesAccess(List), ValidateUnclaimedDevicesAccess(Delete)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can not create, list or delete",
+					Validators: []ValidatorFunc{ValidateUnclaimedDevicesAccess(Create), ValidateUnclaimedDevicesAccess(List), ValidateUnclaimedDevicesAccess(Delete)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "normal users can not create, list or delete",
+					Validators: []ValidatorFunc{ValidateUnclaimedDevicesAccess(Create), ValidateUnclaimedDevicesAccess(List), ValidateUnclaimedDevicesAccess(Delete)},
+					Claims:     Claims{Username: "user4"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
 		Convey("When testing ValidateNodeAccess", func() {
 			tests := []validatorTest{
 				{
@@ -450,6 +526,31 @@ func TestValidators(t *testing.T) {
 			runTests(tests, db)
 		})
 
+		Convey("When testing ValidateDeviceQueueBatchAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create",
+					Validators: []ValidatorFunc{ValidateDeviceQueueBatchAccess(applications[0].ID, Create)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can create",
+					Validators: []ValidatorFunc{ValidateDeviceQueueBatchAccess(applications[0].ID, Create)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "other users can not create",
+					Validators: []ValidatorFunc{ValidateDeviceQueueBatchAccess(applications[0].ID, Create)},
+					Claims:     Claims{Username: "user4"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
 		Convey("When testing ValidateGatewaysAccess", func() {
 			tests := []validatorTest{
 				{
@@ -1045,6 +1146,401 @@ func TestValidators(t *testing.T) {
 
 			runTests(tests, db)
 		})
+
+		Convey("When testing ValidateCodecsAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create and list",
+					Validators: []ValidatorFunc{ValidateCodecsAccess(Create, organizations[0].ID), ValidateCodecsAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can create and list",
+					Validators: []ValidatorFunc{ValidateCodecsAccess(Create, organizations[0].ID), ValidateCodecsAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can list",
+					Validators: []ValidatorFunc{ValidateCodecsAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not create",
+					Validators: []ValidatorFunc{ValidateCodecsAccess(Create, organizations[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not create or list",
+					Validators: []ValidatorFunc{ValidateCodecsAccess(Create, organizations[0].ID), ValidateCodecsAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateCodecAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can read, update and delete",
+					Validators: []ValidatorFunc{ValidateCodecAccess(Read, codecs[0].ID), ValidateCodecAccess(Update, codecs[0].ID), ValidateCodecAccess(Delete, codecs[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can read, update and delete",
+					Validators: []ValidatorFunc{ValidateCodecAccess(Read, codecs[0].ID), ValidateCodecAccess(Update, codecs[0].ID), ValidateCodecAccess(Delete, codecs[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can read",
+					Validators: []ValidatorFunc{ValidateCodecAccess(Read, codecs[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not update and delete",
+					Validators: []ValidatorFunc{ValidateCodecAccess(Update, codecs[0].ID), ValidateCodecAccess(Delete, codecs[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not read, update and delete",
+					Validators: []ValidatorFunc{ValidateCodecAccess(Read, codecs[0].ID), ValidateCodecAccess(Update, codecs[0].ID), ValidateCodecAccess(Delete, codecs[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateDownlinkSchedulesAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create and list",
+					Validators: []ValidatorFunc{ValidateDownlinkSchedulesAccess(Create, organizations[0].ID), ValidateDownlinkSchedulesAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can create and list",
+					Validators: []ValidatorFunc{ValidateDownlinkSchedulesAccess(Create, organizations[0].ID), ValidateDownlinkSchedulesAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can list",
+					Validators: []ValidatorFunc{ValidateDownlinkSchedulesAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not create",
+					Validators: []ValidatorFunc{ValidateDownlinkSchedulesAccess(Create, organizations[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not create or list",
+					Validators: []ValidatorFunc{ValidateDownlinkSchedulesAccess(Create, organizations[0].ID), ValidateDownlinkSchedulesAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateDownlinkScheduleAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can read, update and delete",
+					Validators: []ValidatorFunc{ValidateDownlinkScheduleAccess(Read, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Update, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Delete, downlinkSchedules[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can read, update and delete",
+					Validators: []ValidatorFunc{ValidateDownlinkScheduleAccess(Read, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Update, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Delete, downlinkSchedules[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can read",
+					Validators: []ValidatorFunc{ValidateDownlinkScheduleAccess(Read, downlinkSchedules[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not update and delete",
+					Validators: []ValidatorFunc{ValidateDownlinkScheduleAccess(Update, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Delete, downlinkSchedules[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not read, update and delete",
+					Validators: []ValidatorFunc{ValidateDownlinkScheduleAccess(Read, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Update, downlinkSchedules[0].ID), ValidateDownlinkScheduleAccess(Delete, downlinkSchedules[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateMulticastGroupsAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create and list",
+					Validators: []ValidatorFunc{ValidateMulticastGroupsAccess(Create, applications[0].ID), ValidateMulticastGroupsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can create and list",
+					Validators: []ValidatorFunc{ValidateMulticastGroupsAccess(Create, applications[0].ID), ValidateMulticastGroupsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can list",
+					Validators: []ValidatorFunc{ValidateMulticastGroupsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not create",
+					Validators: []ValidatorFunc{ValidateMulticastGroupsAccess(Create, applications[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not create or list",
+					Validators: []ValidatorFunc{ValidateMulticastGroupsAccess(Create, applications[0].ID), ValidateMulticastGroupsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateMulticastGroupAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can read, update and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupAccess(Read, multicastGroups[0].ID), ValidateMulticastGroupAccess(Update, multicastGroups[0].ID), ValidateMulticastGroupAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can read, update and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupAccess(Read, multicastGroups[0].ID), ValidateMulticastGroupAccess(Update, multicastGroups[0].ID), ValidateMulticastGroupAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can read",
+					Validators: []ValidatorFunc{ValidateMulticastGroupAccess(Read, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not update and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupAccess(Update, multicastGroups[0].ID), ValidateMulticastGroupAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not read, update and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupAccess(Read, multicastGroups[0].ID), ValidateMulticastGroupAccess(Update, multicastGroups[0].ID), ValidateMulticastGroupAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateMulticastGroupQueueAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create, list and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupQueueAccess(Create, multicastGroups[0].ID), ValidateMulticastGroupQueueAccess(List, multicastGroups[0].ID), ValidateMulticastGroupQueueAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can create, list and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupQueueAccess(Create, multicastGroups[0].ID), ValidateMulticastGroupQueueAccess(List, multicastGroups[0].ID), ValidateMulticastGroupQueueAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "non-organization users can not create, list and delete",
+					Validators: []ValidatorFunc{ValidateMulticastGroupQueueAccess(Create, multicastGroups[0].ID), ValidateMulticastGroupQueueAccess(List, multicastGroups[0].ID), ValidateMulticastGroupQueueAccess(Delete, multicastGroups[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateFirmwaresAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create and list",
+					Validators: []ValidatorFunc{ValidateFirmwaresAccess(Create, organizations[0].ID), ValidateFirmwaresAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can create and list",
+					Validators: []ValidatorFunc{ValidateFirmwaresAccess(Create, organizations[0].ID), ValidateFirmwaresAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can list",
+					Validators: []ValidatorFunc{ValidateFirmwaresAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not create",
+					Validators: []ValidatorFunc{ValidateFirmwaresAccess(Create, organizations[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not create or list",
+					Validators: []ValidatorFunc{ValidateFirmwaresAccess(Create, organizations[0].ID), ValidateFirmwaresAccess(List, organizations[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateFirmwareAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can read and delete",
+					Validators: []ValidatorFunc{ValidateFirmwareAccess(Read, firmwares[0].ID), ValidateFirmwareAccess(Delete, firmwares[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can read and delete",
+					Validators: []ValidatorFunc{ValidateFirmwareAccess(Read, firmwares[0].ID), ValidateFirmwareAccess(Delete, firmwares[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can read",
+					Validators: []ValidatorFunc{ValidateFirmwareAccess(Read, firmwares[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not delete",
+					Validators: []ValidatorFunc{ValidateFirmwareAccess(Delete, firmwares[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not read and delete",
+					Validators: []ValidatorFunc{ValidateFirmwareAccess(Read, firmwares[0].ID), ValidateFirmwareAccess(Delete, firmwares[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateFUOTACampaignsAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can create and list",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignsAccess(Create, applications[0].ID), ValidateFUOTACampaignsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can create and list",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignsAccess(Create, applications[0].ID), ValidateFUOTACampaignsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can list",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not create",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignsAccess(Create, applications[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not create or list",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignsAccess(Create, applications[0].ID), ValidateFUOTACampaignsAccess(List, applications[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
+		Convey("When testing ValidateFUOTACampaignAccess", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin users can read and delete",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignAccess(Read, fuotaCampaigns[0].ID), ValidateFUOTACampaignAccess(Delete, fuotaCampaigns[0].ID)},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization admin users can read and delete",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignAccess(Read, fuotaCampaigns[0].ID), ValidateFUOTACampaignAccess(Delete, fuotaCampaigns[0].ID)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can read",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignAccess(Read, fuotaCampaigns[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "organization users can not delete",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignAccess(Delete, fuotaCampaigns[0].ID)},
+					Claims:     Claims{Username: "user9"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "non-organization users can not read and delete",
+					Validators: []ValidatorFunc{ValidateFUOTACampaignAccess(Read, fuotaCampaigns[0].ID), ValidateFUOTACampaignAccess(Delete, fuotaCampaigns[0].ID)},
+					Claims:     Claims{Username: "user12"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
 	})
 }
 
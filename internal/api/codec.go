@@ -0,0 +1,380 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/codec"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// CodecAPI exports the Codec related functions.
+type CodecAPI struct {
+	validator auth.Validator
+}
+
+// NewCodecAPI creates a new CodecAPI.
+func NewCodecAPI(validator auth.Validator) *CodecAPI {
+	return &CodecAPI{
+		validator: validator,
+	}
+}
+
+// Create creates the given codec.
+func (a *CodecAPI) Create(ctx context.Context, req *pb.CreateCodecRequest) (*pb.CreateCodecResponse, error) {
+	if req.Codec == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "codec expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecsAccess(auth.Create, req.Codec.OrganizationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	fields, err := codecFieldsFromPB(req.Codec.FieldsMetadata)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	c := storage.Codec{
+		OrganizationID: req.Codec.OrganizationID,
+		Name:           req.Codec.Name,
+		Type:           codec.Type(req.Codec.PayloadCodec),
+		EncoderScript:  req.Codec.PayloadEncoderScript,
+		DecoderScript:  req.Codec.PayloadDecoderScript,
+		FieldsMetadata: fields,
+	}
+
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.CreateCodec(tx, &c)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateCodecResponse{
+		Id: c.ID,
+	}, nil
+}
+
+// Get returns the codec matching the given id.
+func (a *CodecAPI) Get(ctx context.Context, req *pb.GetCodecRequest) (*pb.GetCodecResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	c, err := storage.GetCodec(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.GetCodecResponse{
+		Codec: &pb.Codec{
+			Id:                   c.ID,
+			Name:                 c.Name,
+			OrganizationID:       c.OrganizationID,
+			PayloadCodec:         string(c.Type),
+			PayloadEncoderScript: c.EncoderScript,
+			PayloadDecoderScript: c.DecoderScript,
+			FieldsMetadata:       codecFieldsToPB(c.FieldsMetadata),
+		},
+		Version:   int64(c.Version),
+		CreatedAt: c.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: c.UpdatedAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// Update updates the given codec.
+func (a *CodecAPI) Update(ctx context.Context, req *pb.UpdateCodecRequest) (*pb.UpdateCodecResponse, error) {
+	if req.Codec == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "codec expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecAccess(auth.Update, req.Codec.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	fields, err := codecFieldsFromPB(req.Codec.FieldsMetadata)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var c storage.Codec
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		c, err = storage.GetCodec(tx, req.Codec.Id)
+		if err != nil {
+			return err
+		}
+
+		c.Name = req.Codec.Name
+		c.Type = codec.Type(req.Codec.PayloadCodec)
+		c.EncoderScript = req.Codec.PayloadEncoderScript
+		c.DecoderScript = req.Codec.PayloadDecoderScript
+		c.FieldsMetadata = fields
+
+		return storage.UpdateCodec(tx, &c)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.UpdateCodecResponse{
+		Version: int64(c.Version),
+	}, nil
+}
+
+// Delete deletes the codec matching the given id.
+func (a *CodecAPI) Delete(ctx context.Context, req *pb.DeleteCodecRequest) (*pb.DeleteCodecResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecAccess(auth.Delete, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteCodec(config.C.PostgreSQL.DB, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteCodecResponse{}, nil
+}
+
+// List returns the codecs of the given organization.
+func (a *CodecAPI) List(ctx context.Context, req *pb.ListCodecRequest) (*pb.ListCodecResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecsAccess(auth.List, req.OrganizationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetCodecCountForOrganizationID(config.C.PostgreSQL.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	codecs, err := storage.GetCodecsForOrganizationID(config.C.PostgreSQL.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := pb.ListCodecResponse{
+		TotalCount: int64(count),
+	}
+
+	for _, c := range codecs {
+		out.Result = append(out.Result, &pb.CodecListItem{
+			Id:             c.ID,
+			Name:           c.Name,
+			OrganizationID: c.OrganizationID,
+			PayloadCodec:   string(c.Type),
+			Version:        int64(c.Version),
+			CreatedAt:      c.CreatedAt.Format(time.RFC3339Nano),
+			UpdatedAt:      c.UpdatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &out, nil
+}
+
+// ListVersions returns the version history of the given codec.
+func (a *CodecAPI) ListVersions(ctx context.Context, req *pb.ListCodecVersionsRequest) (*pb.ListCodecVersionsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	versions, err := storage.GetCodecVersions(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListCodecVersionsResponse
+	for _, cv := range versions {
+		out.Result = append(out.Result, codecVersionToPB(cv))
+	}
+
+	return &out, nil
+}
+
+// GetVersion returns the given version of the codec.
+func (a *CodecAPI) GetVersion(ctx context.Context, req *pb.GetCodecVersionRequest) (*pb.GetCodecVersionResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	cv, err := storage.GetCodecVersion(config.C.PostgreSQL.DB, req.Id, int(req.Version))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.GetCodecVersionResponse{
+		CodecVersion: codecVersionToPB(cv),
+	}, nil
+}
+
+// ListReferences returns the applications and device-profiles using the
+// given codec.
+func (a *CodecAPI) ListReferences(ctx context.Context, req *pb.ListCodecReferencesRequest) (*pb.ListCodecReferencesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateCodecAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	c, err := storage.GetCodec(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	refs, err := storage.GetCodecReferences(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListCodecReferencesResponse
+	for _, ref := range refs {
+		r := pb.CodecReference{
+			Name: ref.Name,
+		}
+		if ref.ApplicationID != nil {
+			r.ApplicationID = *ref.ApplicationID
+		}
+		if ref.DeviceProfileID != nil {
+			r.DeviceProfileID = *ref.DeviceProfileID
+		}
+		if ref.Version != nil {
+			r.Version = int64(*ref.Version)
+			r.Outdated = *ref.Version < c.Version
+		}
+
+		out.Result = append(out.Result, &r)
+	}
+
+	return &out, nil
+}
+
+func codecVersionToPB(cv storage.CodecVersion) *pb.CodecVersion {
+	return &pb.CodecVersion{
+		Version:              int64(cv.Version),
+		PayloadCodec:         string(cv.Type),
+		PayloadEncoderScript: cv.EncoderScript,
+		PayloadDecoderScript: cv.DecoderScript,
+		CreatedAt:            cv.CreatedAt.Format(time.RFC3339Nano),
+		FieldsMetadata:       codecFieldsToPB(cv.FieldsMetadata),
+	}
+}
+
+// codecFieldsFromPB returns the codec fields metadata for the given API
+// values. Defining the same field more than once is not allowed.
+func codecFieldsFromPB(fields []*pb.CodecFieldMetadata) (codec.Fields, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	out := make(codec.Fields)
+	for _, f := range fields {
+		if _, ok := out[f.Field]; ok {
+			return nil, storage.ErrInvalidFieldsMetadata
+		}
+
+		m := codec.FieldMetadata{
+			Unit:         f.Unit,
+			DataType:     codec.DataType(f.DataType),
+			SemanticType: f.SemanticType,
+		}
+		if f.Min != nil {
+			m.Min = &f.Min.Value
+		}
+		if f.Max != nil {
+			m.Max = &f.Max.Value
+		}
+
+		out[f.Field] = m
+	}
+
+	return out, nil
+}
+
+// codecFieldsToPB returns the API values for the given codec fields
+// metadata, sorted by field path.
+func codecFieldsToPB(fields codec.Fields) []*pb.CodecFieldMetadata {
+	var paths []string
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var out []*pb.CodecFieldMetadata
+	for _, path := range paths {
+		m := fields[path]
+		f := pb.CodecFieldMetadata{
+			Field:        path,
+			Unit:         m.Unit,
+			DataType:     string(m.DataType),
+			SemanticType: m.SemanticType,
+		}
+		if m.Min != nil {
+			f.Min = &pb.CodecFieldBound{Value: *m.Min}
+		}
+		if m.Max != nil {
+			f.Max = &pb.CodecFieldBound{Value: *m.Max}
+		}
+
+		out = append(out, &f)
+	}
+
+	return out
+}
+
+// codecReferenceFromPB returns the codec id and version pointers for the
+// given API values, where 0 means not set.
+func codecReferenceFromPB(codecID, version int64) (*int64, *int) {
+	var id *int64
+	var v *int
+
+	if codecID != 0 {
+		id = &codecID
+	}
+	if version != 0 {
+		i := int(version)
+		v = &i
+	}
+
+	return id, v
+}
+
+// codecReferenceToPB returns the API values for the given codec reference,
+// including the latest version of the referenced codec.
+func codecReferenceToPB(db sqlx.Queryer, codecID *int64, version *int) (int64, int64, int64, error) {
+	if codecID == nil {
+		return 0, 0, 0, nil
+	}
+
+	c, err := storage.GetCodec(db, *codecID)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var v int64
+	if version != nil {
+		v = int64(*version)
+	}
+
+	return c.ID, v, int64(c.Version), nil
+}
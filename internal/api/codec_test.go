@@ -0,0 +1,236 @@
+package api
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestCodecAPI(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nsClient := test.NewNetworkServerClient()
+
+	config.C.PostgreSQL.DB = db
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization and an api instance", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		ctx := context.Background()
+		validator := &TestValidator{}
+		api := NewCodecAPI(validator)
+		appAPI := NewApplicationAPI(validator)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		Convey("When creating a codec", func() {
+			createResp, err := api.Create(ctx, &pb.CreateCodecRequest{
+				Codec: &pb.Codec{
+					OrganizationID:       org.ID,
+					Name:                 "test-codec",
+					PayloadCodec:         "CUSTOM_JS",
+					PayloadEncoderScript: "Encode() {}",
+					PayloadDecoderScript: "Decode() {}",
+					FieldsMetadata: []*pb.CodecFieldMetadata{
+						{
+							Field:        "temperature",
+							Unit:         "Cel",
+							DataType:     "NUMBER",
+							Min:          &pb.CodecFieldBound{Value: -40},
+							SemanticType: "temperature",
+						},
+					},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(validator.validatorFuncs, ShouldHaveLength, 1)
+			So(createResp.Id, ShouldBeGreaterThan, 0)
+
+			Convey("Then the codec has been created", func() {
+				resp, err := api.Get(ctx, &pb.GetCodecRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(resp.Codec, ShouldResemble, &pb.Codec{
+					Id:                   createResp.Id,
+					OrganizationID:       org.ID,
+					Name:                 "test-codec",
+					PayloadCodec:         "CUSTOM_JS",
+					PayloadEncoderScript: "Encode() {}",
+					PayloadDecoderScript: "Decode() {}",
+					FieldsMetadata: []*pb.CodecFieldMetadata{
+						{
+							Field:        "temperature",
+							Unit:         "Cel",
+							DataType:     "NUMBER",
+							Min:          &pb.CodecFieldBound{Value: -40},
+							SemanticType: "temperature",
+						},
+					},
+				})
+				So(resp.Version, ShouldEqual, 1)
+			})
+
+			Convey("Then invalid fields metadata is rejected", func() {
+				_, err := api.Create(ctx, &pb.CreateCodecRequest{
+					Codec: &pb.Codec{
+						OrganizationID: org.ID,
+						Name:           "test-codec-2",
+						PayloadCodec:   "CBOR",
+						FieldsMetadata: []*pb.CodecFieldMetadata{
+							{Field: "temperature", DataType: "FLOAT"},
+						},
+					},
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then List returns the codec", func() {
+				resp, err := api.List(ctx, &pb.ListCodecRequest{
+					OrganizationID: org.ID,
+					Limit:          10,
+				})
+				So(err, ShouldBeNil)
+				So(resp.TotalCount, ShouldEqual, 1)
+				So(resp.Result, ShouldHaveLength, 1)
+				So(resp.Result[0].Name, ShouldEqual, "test-codec")
+				So(resp.Result[0].Version, ShouldEqual, 1)
+			})
+
+			Convey("When creating an application pinned to version 1", func() {
+				appResp, err := appAPI.Create(ctx, &pb.CreateApplicationRequest{
+					OrganizationID:   org.ID,
+					Name:             "test-app",
+					Description:      "A test application",
+					ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+					CodecID:          createResp.Id,
+					CodecVersion:     1,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("When updating the codec decoder script", func() {
+					updateResp, err := api.Update(ctx, &pb.UpdateCodecRequest{
+						Codec: &pb.Codec{
+							Id:                   createResp.Id,
+							OrganizationID:       org.ID,
+							Name:                 "test-codec",
+							PayloadCodec:         "CUSTOM_JS",
+							PayloadEncoderScript: "Encode() {}",
+							PayloadDecoderScript: "Decode() { return {}; }",
+						},
+					})
+					So(err, ShouldBeNil)
+					So(updateResp.Version, ShouldEqual, 2)
+
+					Convey("Then ListVersions returns both versions", func() {
+						resp, err := api.ListVersions(ctx, &pb.ListCodecVersionsRequest{
+							Id: createResp.Id,
+						})
+						So(err, ShouldBeNil)
+						So(resp.Result, ShouldHaveLength, 2)
+						So(resp.Result[0].Version, ShouldEqual, 2)
+						So(resp.Result[1].Version, ShouldEqual, 1)
+					})
+
+					Convey("Then GetVersion returns the previous version", func() {
+						resp, err := api.GetVersion(ctx, &pb.GetCodecVersionRequest{
+							Id:      createResp.Id,
+							Version: 1,
+						})
+						So(err, ShouldBeNil)
+						So(resp.CodecVersion.PayloadDecoderScript, ShouldEqual, "Decode() {}")
+					})
+
+					Convey("Then ListReferences marks the application as outdated", func() {
+						resp, err := api.ListReferences(ctx, &pb.ListCodecReferencesRequest{
+							Id: createResp.Id,
+						})
+						So(err, ShouldBeNil)
+						So(resp.Result, ShouldResemble, []*pb.CodecReference{
+							{
+								ApplicationID: appResp.Id,
+								Name:          "test-app",
+								Version:       1,
+								Outdated:      true,
+							},
+						})
+					})
+
+					Convey("Then the application returns the latest codec version", func() {
+						resp, err := appAPI.Get(ctx, &pb.GetApplicationRequest{
+							Id: appResp.Id,
+						})
+						So(err, ShouldBeNil)
+						So(resp.CodecID, ShouldEqual, createResp.Id)
+						So(resp.CodecVersion, ShouldEqual, 1)
+						So(resp.CodecLatestVersion, ShouldEqual, 2)
+					})
+				})
+
+				Convey("Then the codec can not be deleted", func() {
+					_, err := api.Delete(ctx, &pb.DeleteCodecRequest{
+						Id: createResp.Id,
+					})
+					So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+				})
+			})
+
+			Convey("Then an application can not reference a non-existing version", func() {
+				_, err := appAPI.Create(ctx, &pb.CreateApplicationRequest{
+					OrganizationID:   org.ID,
+					Name:             "test-app",
+					Description:      "A test application",
+					ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+					CodecID:          createResp.Id,
+					CodecVersion:     2,
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("When deleting the codec", func() {
+				_, err := api.Delete(ctx, &pb.DeleteCodecRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the codec has been deleted", func() {
+					_, err := api.Get(ctx, &pb.GetCodecRequest{
+						Id: createResp.Id,
+					})
+					So(grpc.Code(err), ShouldEqual, codes.NotFound)
+				})
+			})
+		})
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -16,7 +18,10 @@ import (
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/join"
+	"github.com/brocaar/lora-app-server/internal/shadow"
 	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan"
 )
@@ -57,6 +62,7 @@ func (a *DeviceAPI) Create(ctx context.Context, req *pb.CreateDeviceRequest) (*p
 		Name:            req.Name,
 		Description:     req.Description,
 		SkipFCntCheck:   req.SkipFCntCheck,
+		Tags:            req.Tags,
 	}
 
 	// as this also performs a remote call to create the node on the
@@ -97,6 +103,7 @@ func (a *DeviceAPI) Get(ctx context.Context, req *pb.GetDeviceRequest) (*pb.GetD
 		DeviceStatusBattery: 256,
 		DeviceStatusMargin:  256,
 		SkipFCntCheck:       d.SkipFCntCheck,
+		Tags:                d.Tags,
 	}
 
 	if d.DeviceStatusBattery != nil {
@@ -151,6 +158,7 @@ func (a *DeviceAPI) Update(ctx context.Context, req *pb.UpdateDeviceRequest) (*p
 	d.Name = req.Name
 	d.Description = req.Description
 	d.SkipFCntCheck = req.SkipFCntCheck
+	d.Tags = req.Tags
 
 	// as this also performs a remote call to update the node on the
 	// network-server, wrap it in a transaction
@@ -199,9 +207,9 @@ func (a *DeviceAPI) CreateKeys(ctx context.Context, req *pb.CreateDeviceKeysRequ
 		return nil, grpc.Errorf(codes.InvalidArgument, "devicesKeys expected")
 	}
 
-	var key lorawan.AES128Key
-	if err := key.UnmarshalText([]byte(req.DeviceKeys.AppKey)); err != nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	keys, err := getRootKeys(req.DeviceKeys)
+	if err != nil {
+		return nil, err
 	}
 
 	var eui lorawan.EUI64
@@ -215,10 +223,8 @@ func (a *DeviceAPI) CreateKeys(ctx context.Context, req *pb.CreateDeviceKeysRequ
 		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
 	}
 
-	err := storage.CreateDeviceKeys(config.C.PostgreSQL.DB, &storage.DeviceKeys{
-		DevEUI: eui,
-		AppKey: key,
-	})
+	keys.DevEUI = eui
+	err = storage.CreateDeviceKeys(config.C.PostgreSQL.DB, &keys)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -244,11 +250,24 @@ func (a *DeviceAPI) GetKeys(ctx context.Context, req *pb.GetDeviceKeysRequest) (
 		return nil, errToRPCError(err)
 	}
 
-	return &pb.GetDeviceKeysResponse{
-		DeviceKeys: &pb.DeviceKeys{
-			AppKey: dk.AppKey.String(),
-		},
-	}, nil
+	resp := pb.GetDeviceKeysResponse{
+		DeviceKeys: &pb.DeviceKeys{},
+	}
+
+	// the root-keys stored in the HSM can't be retrieved
+	if join.RootKeysInHSM() {
+		return &resp, nil
+	}
+
+	resp.DeviceKeys.AppKey = dk.AppKey.String()
+	if dk.NwkKey != (lorawan.AES128Key{}) {
+		resp.DeviceKeys.NwkKey = dk.NwkKey.String()
+	}
+	if dk.GenAppKey != (lorawan.AES128Key{}) {
+		resp.DeviceKeys.GenAppKey = dk.GenAppKey.String()
+	}
+
+	return &resp, nil
 }
 
 // UpdateKeys updates the device-keys.
@@ -257,9 +276,9 @@ func (a *DeviceAPI) UpdateKeys(ctx context.Context, req *pb.UpdateDeviceKeysRequ
 		return nil, grpc.Errorf(codes.InvalidArgument, "devicesKeys expected")
 	}
 
-	var key lorawan.AES128Key
-	if err := key.UnmarshalText([]byte(req.DeviceKeys.AppKey)); err != nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	keys, err := getRootKeys(req.DeviceKeys)
+	if err != nil {
+		return nil, err
 	}
 
 	var eui lorawan.EUI64
@@ -277,9 +296,19 @@ func (a *DeviceAPI) UpdateKeys(ctx context.Context, req *pb.UpdateDeviceKeysRequ
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
-	dk.AppKey = key
+	dk.AppKey = keys.AppKey
+	dk.NwkKey = keys.NwkKey
+	dk.GenAppKey = keys.GenAppKey
+	dk.RJCount1 = 0
 
-	err = storage.UpdateDeviceKeys(config.C.PostgreSQL.DB, &dk)
+	// the used DevNonces are reset, as the device might have been
+	// re-provisioned with the new keys
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		if err := storage.UpdateDeviceKeys(tx, &dk); err != nil {
+			return err
+		}
+		return storage.DeleteDevNonces(tx, eui)
+	})
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -360,16 +389,22 @@ func (a *DeviceAPI) Activate(ctx context.Context, req *pb.ActivateDeviceRequest)
 	}
 
 	_, _ = nsClient.DeactivateDevice(context.Background(), &ns.DeactivateDeviceRequest{
-		DevEUI: d.DevEUI[:],
+		DevEui: d.DevEUI[:],
 	})
 
+	// for LoRaWAN 1.0 devices, the NwkSKey is used for all network
+	// session keys
 	actReq := ns.ActivateDeviceRequest{
-		DevEUI:        d.DevEUI[:],
-		DevAddr:       devAddr[:],
-		NwkSKey:       nwkSKey[:],
-		FCntUp:        req.FCntUp,
-		FCntDown:      req.FCntDown,
-		SkipFCntCheck: req.SkipFCntCheck,
+		DeviceActivation: &ns.DeviceActivation{
+			DevEui:        d.DevEUI[:],
+			DevAddr:       devAddr[:],
+			SNwkSIntKey:   nwkSKey[:],
+			FNwkSIntKey:   nwkSKey[:],
+			NwkSEncKey:    nwkSKey[:],
+			FCntUp:        req.FCntUp,
+			NFCntDown:     req.FCntDown,
+			SkipFCntCheck: req.SkipFCntCheck,
+		},
 	}
 
 	_, err = nsClient.ActivateDevice(context.Background(), &actReq)
@@ -391,6 +426,10 @@ func (a *DeviceAPI) Activate(ctx context.Context, req *pb.ActivateDeviceRequest)
 		return nil, errToRPCError(err)
 	}
 
+	if err = storage.FlushDeviceQueueItemStatusForDevEUI(config.C.PostgreSQL.DB, d.DevEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	log.WithFields(log.Fields{
 		"dev_addr": devAddr,
 		"dev_eui":  d.DevEUI,
@@ -435,25 +474,330 @@ func (a *DeviceAPI) GetActivation(ctx context.Context, req *pb.GetDeviceActivati
 	}
 
 	devAct, err := nsClient.GetDeviceActivation(context.Background(), &ns.GetDeviceActivationRequest{
-		DevEUI: d.DevEUI[:],
+		DevEui: d.DevEUI[:],
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	copy(devAddr[:], devAct.DevAddr)
-	copy(nwkSKey[:], devAct.NwkSKey)
+	copy(devAddr[:], devAct.GetDeviceActivation().GetDevAddr())
+	copy(nwkSKey[:], devAct.GetDeviceActivation().GetNwkSEncKey())
 
 	return &pb.GetDeviceActivationResponse{
 		DevAddr:       devAddr.String(),
 		AppSKey:       da.AppSKey.String(),
 		NwkSKey:       nwkSKey.String(),
-		FCntUp:        devAct.FCntUp,
-		FCntDown:      devAct.FCntDown,
-		SkipFCntCheck: devAct.SkipFCntCheck,
+		FCntUp:        devAct.GetDeviceActivation().GetFCntUp(),
+		FCntDown:      devAct.GetDeviceActivation().GetNFCntDown(),
+		SkipFCntCheck: devAct.GetDeviceActivation().GetSkipFCntCheck(),
+	}, nil
+}
+
+// ListActivations lists the join history (join and rejoin-requests handled
+// by the join-server) for the given DevEUI, most recent first.
+func (a *DeviceAPI) ListActivations(ctx context.Context, req *pb.ListDeviceActivationsRequest) (*pb.ListDeviceActivationsResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetDeviceJoinCount(config.C.PostgreSQL.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	joins, err := storage.GetDeviceJoins(config.C.PostgreSQL.DB, devEUI, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.ListDeviceActivationsResponse{
+		TotalCount: int64(count),
+	}
+	for _, dj := range joins {
+		resp.Result = append(resp.Result, &pb.DeviceActivationListItem{
+			CreatedAt:   dj.CreatedAt.Format(time.RFC3339Nano),
+			MessageType: dj.MessageType,
+			DevNonce:    uint32(dj.DevNonce),
+			JoinNonce:   uint32(dj.JoinNonce),
+			DevAddr:     dj.DevAddr.String(),
+			SenderID:    dj.SenderID,
+			ResultCode:  dj.ResultCode,
+			Description: dj.Description,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetShadow returns the device shadow for the given DevEUI.
+func (a *DeviceAPI) GetShadow(ctx context.Context, req *pb.GetDeviceShadowRequest) (*pb.GetDeviceShadowResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	s, err := storage.GetDeviceShadow(config.C.PostgreSQL.DB, devEUI, false)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	delta, err := shadow.GetDelta(s)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetDeviceShadowResponse{
+		DesiredVersion: s.DesiredVersion,
+		DeltaJSON:      string(delta),
+		FPort:          uint32(s.FPort),
+		Confirmed:      s.Confirmed,
+	}
+	if s.Desired != nil {
+		resp.DesiredJSON = *s.Desired
+	}
+	if s.Reported != nil {
+		resp.ReportedJSON = *s.Reported
+	}
+	if s.ReportedAt != nil {
+		resp.ReportedAt = s.ReportedAt.Format(time.RFC3339Nano)
+	}
+	if s.DeltaSentAt != nil {
+		resp.DeltaSentAt = s.DeltaSentAt.Format(time.RFC3339Nano)
+	}
+
+	return &resp, nil
+}
+
+// UpdateShadow sets the desired state of the device shadow. The delta with
+// the reported state is enqueued on the next uplink of the device.
+func (a *DeviceAPI) UpdateShadow(ctx context.Context, req *pb.UpdateDeviceShadowRequest) (*pb.UpdateDeviceShadowResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDeviceQueueAccess(devEUI, auth.Create)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if req.FPort == 0 || req.FPort > 223 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fPort must be between 1 and 223")
+	}
+
+	var s storage.DeviceShadow
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		s, err = shadow.UpdateDesired(tx, devEUI, req.DesiredJSON, uint8(req.FPort), req.Confirmed)
+		return err
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.UpdateDeviceShadowResponse{
+		DesiredVersion: s.DesiredVersion,
 	}, nil
 }
 
+// DeleteShadow deletes the device shadow for the given DevEUI.
+func (a *DeviceAPI) DeleteShadow(ctx context.Context, req *pb.DeleteDeviceShadowRequest) (*pb.DeleteDeviceShadowResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDeviceQueueAccess(devEUI, auth.Delete)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteDeviceShadow(config.C.PostgreSQL.DB, devEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteDeviceShadowResponse{}, nil
+}
+
+// ListThrottledByApplicationID lists the devices of the given application
+// for which join-requests were recently throttled.
+func (a *DeviceAPI) ListThrottledByApplicationID(ctx context.Context, req *pb.ListThrottledDevicesRequest) (*pb.ListThrottledDevicesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	devices, err := join.ListThrottledDevices(req.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp pb.ListThrottledDevicesResponse
+	for _, d := range devices {
+		item := pb.ThrottledDeviceListItem{
+			DevEUI:      d.DevEUI.String(),
+			ThrottledAt: d.ThrottledAt.Format(time.RFC3339Nano),
+		}
+		if d.Reason == join.ThrottleMICFailures {
+			item.Reason = pb.JoinThrottleReason_MIC_FAILURES
+		}
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// CreateUnclaimed adds the given devices to the unclaimed devices. For
+// devices without claim-code, a random claim-code is generated. The
+// claim-codes are returned, as only their hash is stored.
+func (a *DeviceAPI) CreateUnclaimed(ctx context.Context, req *pb.CreateUnclaimedDevicesRequest) (*pb.CreateUnclaimedDevicesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateUnclaimedDevicesAccess(auth.Create)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	devices := make([]storage.UnclaimedDevice, len(req.Devices))
+	claimCodes := make([]string, len(req.Devices))
+
+	for i, ud := range req.Devices {
+		if err := devices[i].DevEUI.UnmarshalText([]byte(ud.DevEUI)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+		}
+		devices[i].DeviceProfileID = ud.DeviceProfileID
+
+		dk := ud.DeviceKeys
+		if dk == nil {
+			dk = &pb.DeviceKeys{}
+		}
+
+		keys, err := getRootKeys(dk)
+		if err != nil {
+			return nil, err
+		}
+		devices[i].AppKey = keys.AppKey
+		devices[i].NwkKey = keys.NwkKey
+		devices[i].GenAppKey = keys.GenAppKey
+
+		claimCodes[i] = ud.ClaimCode
+		if claimCodes[i] == "" {
+			claimCodes[i], err = storage.GenerateClaimCode()
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
+		}
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		for i := range devices {
+			if err := storage.CreateUnclaimedDevice(tx, &devices[i], claimCodes[i]); err != nil {
+				return errors.Wrapf(err, "create unclaimed device %s error", devices[i].DevEUI)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp pb.CreateUnclaimedDevicesResponse
+	for i := range devices {
+		resp.Result = append(resp.Result, &pb.UnclaimedDeviceClaimCode{
+			DevEUI:    devices[i].DevEUI.String(),
+			ClaimCode: claimCodes[i],
+		})
+	}
+
+	return &resp, nil
+}
+
+// ListUnclaimed lists the unclaimed devices.
+func (a *DeviceAPI) ListUnclaimed(ctx context.Context, req *pb.ListUnclaimedDevicesRequest) (*pb.ListUnclaimedDevicesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateUnclaimedDevicesAccess(auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetUnclaimedDeviceCount(config.C.PostgreSQL.DB)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	devices, err := storage.GetUnclaimedDevices(config.C.PostgreSQL.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.ListUnclaimedDevicesResponse{
+		TotalCount: int64(count),
+	}
+	for _, d := range devices {
+		resp.Result = append(resp.Result, &pb.UnclaimedDeviceListItem{
+			DevEUI:          d.DevEUI.String(),
+			DeviceProfileID: d.DeviceProfileID,
+			CreatedAt:       d.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &resp, nil
+}
+
+// DeleteUnclaimed removes the given device from the unclaimed devices.
+func (a *DeviceAPI) DeleteUnclaimed(ctx context.Context, req *pb.DeleteUnclaimedDeviceRequest) (*pb.DeleteUnclaimedDeviceResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateUnclaimedDevicesAccess(auth.Delete)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteUnclaimedDevice(config.C.PostgreSQL.DB, devEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteUnclaimedDeviceResponse{}, nil
+}
+
+// Claim claims the unclaimed device matching the given DevEUI and claim-code
+// into the given application. This requires the same permissions as
+// creating a device within the application.
+func (a *DeviceAPI) Claim(ctx context.Context, req *pb.ClaimDeviceRequest) (*pb.ClaimDeviceResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.Create)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	// as this also performs a remote call to create the device on the
+	// network-server, wrap it in a transaction
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		_, err := storage.ClaimDevice(tx, devEUI, req.ClaimCode, req.ApplicationID)
+		return err
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.ClaimDeviceResponse{}, nil
+}
+
 // StreamFrameLogs streams the uplink and downlink frame-logs for the given DevEUI.
 // Note: these are the raw LoRaWAN frames and this endpoint is intended for debugging.
 func (a *DeviceAPI) StreamFrameLogs(req *pb.StreamDeviceFrameLogsRequest, srv pb.Device_StreamFrameLogsServer) error {
@@ -479,7 +823,7 @@ func (a *DeviceAPI) StreamFrameLogs(req *pb.StreamDeviceFrameLogsRequest, srv pb
 	}
 
 	streamClient, err := nsClient.StreamFrameLogsForDevice(srv.Context(), &ns.StreamFrameLogsForDeviceRequest{
-		DevEUI: devEUI[:],
+		DevEui: devEUI[:],
 	})
 	if err != nil {
 		return err
@@ -491,7 +835,7 @@ func (a *DeviceAPI) StreamFrameLogs(req *pb.StreamDeviceFrameLogsRequest, srv pb
 			return err
 		}
 
-		up, down, err := convertUplinkAndDownlinkFrames(resp.UplinkFrames, resp.DownlinkFrames)
+		up, down, err := convertUplinkAndDownlinkFrames(resp.GetUplinkFrameSet(), resp.GetDownlinkFrame())
 		if err != nil {
 			return errToRPCError(err)
 		}
@@ -568,7 +912,7 @@ func (a *DeviceAPI) GetRandomDevAddr(ctx context.Context, req *pb.GetRandomDevAd
 		return nil, errToRPCError(err)
 	}
 
-	resp, err := nsClient.GetRandomDevAddr(context.Background(), &ns.GetRandomDevAddrRequest{})
+	resp, err := nsClient.GetRandomDevAddr(context.Background(), &empty.Empty{})
 	if err != nil {
 		return nil, err
 	}
@@ -595,6 +939,7 @@ func (a *DeviceAPI) returnList(count int, devices []storage.DeviceListItem) (*pb
 			DeviceProfileName:   device.DeviceProfileName,
 			DeviceStatusBattery: 256,
 			DeviceStatusMargin:  256,
+			Tags:                device.Tags,
 		}
 
 		if device.DeviceStatusBattery != nil {
@@ -612,15 +957,15 @@ func (a *DeviceAPI) returnList(count int, devices []storage.DeviceListItem) (*pb
 	return &resp, nil
 }
 
-func convertUplinkAndDownlinkFrames(up []*ns.UplinkFrameLog, down []*ns.DownlinkFrameLog) ([]*pb.UplinkFrameLog, []*pb.DownlinkFrameLog, error) {
+func convertUplinkAndDownlinkFrames(up *gw.UplinkFrameSet, down *gw.DownlinkFrame) ([]*pb.UplinkFrameLog, []*pb.DownlinkFrameLog, error) {
 	var outUp []*pb.UplinkFrameLog
 	var outDown []*pb.DownlinkFrameLog
 
-	for _, upFL := range up {
+	if up != nil {
 		var rxInfo []*pb.UplinkRXInfo
 		var phy lorawan.PHYPayload
 
-		if err := phy.UnmarshalBinary(upFL.PhyPayload); err != nil {
+		if err := phy.UnmarshalBinary(up.PhyPayload); err != nil {
 			return nil, nil, errors.Wrap(err, "unmarshal phypayload error")
 		}
 
@@ -629,38 +974,59 @@ func convertUplinkAndDownlinkFrames(up []*ns.UplinkFrameLog, down []*ns.Downlink
 			return nil, nil, errors.Wrap(err, "marshal phypayload error")
 		}
 
-		for _, upRXInfo := range upFL.RxInfo {
-			rxInfo = append(rxInfo, &pb.UplinkRXInfo{
-				Mac:               hex.EncodeToString(upRXInfo.Mac),
-				Time:              upRXInfo.Time,
-				TimeSinceGPSEpoch: upRXInfo.TimeSinceGPSEpoch,
-				Timestamp:         upRXInfo.Timestamp,
-				Rssi:              upRXInfo.Rssi,
-				LoRaSNR:           upRXInfo.LoRaSNR,
-				Board:             upRXInfo.Board,
-				Antenna:           upRXInfo.Antenna,
-			})
+		for _, upRXInfo := range up.RxInfo {
+			rx := pb.UplinkRXInfo{
+				Mac:       hex.EncodeToString(upRXInfo.GatewayId),
+				Timestamp: upRXInfo.Timestamp,
+				Rssi:      upRXInfo.Rssi,
+				LoRaSNR:   float32(upRXInfo.LoraSnr),
+				Board:     upRXInfo.Board,
+				Antenna:   upRXInfo.Antenna,
+			}
+
+			if upRXInfo.Time != nil {
+				if ts, err := ptypes.Timestamp(upRXInfo.Time); err == nil {
+					rx.Time = ts.Format(time.RFC3339Nano)
+				}
+			}
+
+			if upRXInfo.TimeSinceGpsEpoch != nil {
+				if d, err := ptypes.Duration(upRXInfo.TimeSinceGpsEpoch); err == nil {
+					rx.TimeSinceGPSEpoch = d.String()
+				}
+			}
+
+			rxInfo = append(rxInfo, &rx)
 		}
 
-		outUp = append(outUp, &pb.UplinkFrameLog{
-			TxInfo: &pb.UplinkTXInfo{
-				Frequency: upFL.TxInfo.Frequency,
-				DataRate: &pb.DataRate{
-					Modulation:   upFL.TxInfo.DataRate.Modulation,
-					Bandwidth:    upFL.TxInfo.DataRate.Bandwidth,
-					SpreadFactor: upFL.TxInfo.DataRate.SpreadFactor,
-					Bitrate:      upFL.TxInfo.DataRate.Bitrate,
-				},
-				CodeRate: upFL.TxInfo.CodeRate,
+		txInfo := pb.UplinkTXInfo{
+			Frequency: up.GetTxInfo().GetFrequency(),
+			DataRate: &pb.DataRate{
+				Modulation: up.GetTxInfo().GetModulation().String(),
 			},
+		}
+
+		if modInfo := up.GetTxInfo().GetLoraModulationInfo(); modInfo != nil {
+			txInfo.DataRate.Bandwidth = modInfo.Bandwidth
+			txInfo.DataRate.SpreadFactor = modInfo.SpreadingFactor
+			txInfo.CodeRate = modInfo.CodeRate
+		}
+
+		if modInfo := up.GetTxInfo().GetFskModulationInfo(); modInfo != nil {
+			txInfo.DataRate.Bandwidth = modInfo.Bandwidth
+			txInfo.DataRate.Bitrate = modInfo.Bitrate
+		}
+
+		outUp = append(outUp, &pb.UplinkFrameLog{
+			TxInfo:         &txInfo,
 			RxInfo:         rxInfo,
 			PhyPayloadJSON: string(phyB),
 		})
 	}
 
-	for _, downFL := range down {
+	if down != nil {
 		var phy lorawan.PHYPayload
-		if err := phy.UnmarshalBinary(downFL.PhyPayload); err != nil {
+		if err := phy.UnmarshalBinary(down.PhyPayload); err != nil {
 			return nil, nil, errors.Wrap(err, "unmarshal phypayload error")
 		}
 
@@ -669,28 +1035,76 @@ func convertUplinkAndDownlinkFrames(up []*ns.UplinkFrameLog, down []*ns.Downlink
 			return nil, nil, errors.Wrap(err, "marshal phypayload error")
 		}
 
-		outDown = append(outDown, &pb.DownlinkFrameLog{
-			TxInfo: &pb.DownlinkTXInfo{
-				Mac:               hex.EncodeToString(downFL.TxInfo.Mac),
-				Immediately:       downFL.TxInfo.Immediately,
-				TimeSinceGPSEpoch: downFL.TxInfo.TimeSinceGPSEpoch,
-				Timestamp:         downFL.TxInfo.Timestamp,
-				Frequency:         downFL.TxInfo.Frequency,
-				Power:             downFL.TxInfo.Power,
-				DataRate: &pb.DataRate{
-					Modulation:   downFL.TxInfo.DataRate.Modulation,
-					Bandwidth:    downFL.TxInfo.DataRate.Bandwidth,
-					SpreadFactor: downFL.TxInfo.DataRate.SpreadFactor,
-					Bitrate:      downFL.TxInfo.DataRate.Bitrate,
-				},
-				CodeRate: downFL.TxInfo.CodeRate,
-				IPol:     downFL.TxInfo.IPol,
-				Board:    downFL.TxInfo.Board,
-				Antenna:  downFL.TxInfo.Antenna,
+		txInfo := pb.DownlinkTXInfo{
+			Mac:         hex.EncodeToString(down.GetTxInfo().GetGatewayId()),
+			Immediately: down.GetTxInfo().GetImmediately(),
+			Timestamp:   down.GetTxInfo().GetTimestamp(),
+			Frequency:   down.GetTxInfo().GetFrequency(),
+			Power:       down.GetTxInfo().GetPower(),
+			DataRate: &pb.DataRate{
+				Modulation: down.GetTxInfo().GetModulation().String(),
 			},
+			Board:   down.GetTxInfo().GetBoard(),
+			Antenna: down.GetTxInfo().GetAntenna(),
+		}
+
+		if gpsEpoch := down.GetTxInfo().GetTimeSinceGpsEpoch(); gpsEpoch != nil {
+			if d, err := ptypes.Duration(gpsEpoch); err == nil {
+				txInfo.TimeSinceGPSEpoch = d.String()
+			}
+		}
+
+		if modInfo := down.GetTxInfo().GetLoraModulationInfo(); modInfo != nil {
+			txInfo.DataRate.Bandwidth = modInfo.Bandwidth
+			txInfo.DataRate.SpreadFactor = modInfo.SpreadingFactor
+			txInfo.CodeRate = modInfo.CodeRate
+			txInfo.IPol = modInfo.PolarizationInversion
+		}
+
+		if modInfo := down.GetTxInfo().GetFskModulationInfo(); modInfo != nil {
+			txInfo.DataRate.Bandwidth = modInfo.Bandwidth
+			txInfo.DataRate.Bitrate = modInfo.Bitrate
+		}
+
+		outDown = append(outDown, &pb.DownlinkFrameLog{
+			TxInfo:         &txInfo,
 			PhyPayloadJSON: string(phyB),
 		})
 	}
 
 	return outUp, outDown, nil
 }
+
+// getRootKeys returns the AppKey, NwkKey and GenAppKey from the given
+// device-keys. When the root-keys are stored in the HSM, no keys can be set
+// and empty keys are returned.
+func getRootKeys(dk *pb.DeviceKeys) (storage.DeviceKeys, error) {
+	var out storage.DeviceKeys
+
+	if join.RootKeysInHSM() {
+		if dk.AppKey != "" || dk.NwkKey != "" || dk.GenAppKey != "" {
+			return out, grpc.Errorf(codes.InvalidArgument, "the device root-keys are stored in the hsm and can't be set")
+		}
+		return out, nil
+	}
+
+	if err := out.AppKey.UnmarshalText([]byte(dk.AppKey)); err != nil {
+		return out, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	// the NwkKey is only used by LoRaWAN 1.1 devices
+	if dk.NwkKey != "" {
+		if err := out.NwkKey.UnmarshalText([]byte(dk.NwkKey)); err != nil {
+			return out, grpc.Errorf(codes.InvalidArgument, "nwkKey: %s", err)
+		}
+	}
+
+	// the GenAppKey is only used by LoRaWAN 1.0.x devices
+	if dk.GenAppKey != "" {
+		if err := out.GenAppKey.UnmarshalText([]byte(dk.GenAppKey)); err != nil {
+			return out, grpc.Errorf(codes.InvalidArgument, "genAppKey: %s", err)
+		}
+	}
+
+	return out, nil
+}
@@ -69,9 +69,27 @@ func (a *DeviceProfileServiceAPI) Create(ctx context.Context, req *pb.CreateDevi
 		dp.DeviceProfile.FactoryPresetFreqs = append(dp.DeviceProfile.FactoryPresetFreqs, backend.Frequency(freq))
 	}
 
+	dp.CodecID, dp.CodecVersion = codecReferenceFromPB(req.CodecID, req.CodecVersion)
+	if err := storage.ValidateCodecReference(config.C.PostgreSQL.DB, dp.OrganizationID, dp.CodecID, dp.CodecVersion); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var err error
+	dp.ClockSyncEnabled = req.ClockSyncEnabled
+	dp.ClockSyncPeriodicity, err = clockSyncPeriodicityFromPB(req.ClockSyncRequestPeriodicity, req.ClockSyncPeriodicity)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp.DownlinkRetryPolicy = storage.DownlinkRetryPolicy{
+		MaxAttempts: int(req.DownlinkRetryMaxAttempts),
+		Backoff:     int(req.DownlinkRetryBackoff),
+		Reencode:    req.DownlinkRetryReencode,
+	}
+
 	// as this also performs a remote call to create the device-profile
 	// on the network-server, wrap it in a transaction
-	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
 		return storage.CreateDeviceProfile(tx, &dp)
 	})
 	if err != nil {
@@ -129,6 +147,21 @@ func (a *DeviceProfileServiceAPI) Get(ctx context.Context, req *pb.GetDeviceProf
 		resp.DeviceProfile.FactoryPresetFreqs = append(resp.DeviceProfile.FactoryPresetFreqs, uint32(freq))
 	}
 
+	resp.CodecID, resp.CodecVersion, resp.CodecLatestVersion, err = codecReferenceToPB(config.C.PostgreSQL.DB, dp.CodecID, dp.CodecVersion)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp.ClockSyncEnabled = dp.ClockSyncEnabled
+	if dp.ClockSyncPeriodicity != nil {
+		resp.ClockSyncRequestPeriodicity = true
+		resp.ClockSyncPeriodicity = uint32(*dp.ClockSyncPeriodicity)
+	}
+
+	resp.DownlinkRetryMaxAttempts = uint32(dp.DownlinkRetryPolicy.MaxAttempts)
+	resp.DownlinkRetryBackoff = uint32(dp.DownlinkRetryPolicy.Backoff)
+	resp.DownlinkRetryReencode = dp.DownlinkRetryPolicy.Reencode
+
 	return &resp, nil
 }
 
@@ -176,6 +209,23 @@ func (a *DeviceProfileServiceAPI) Update(ctx context.Context, req *pb.UpdateDevi
 		dp.DeviceProfile.FactoryPresetFreqs = append(dp.DeviceProfile.FactoryPresetFreqs, backend.Frequency(freq))
 	}
 
+	dp.CodecID, dp.CodecVersion = codecReferenceFromPB(req.CodecID, req.CodecVersion)
+	if err := storage.ValidateCodecReference(config.C.PostgreSQL.DB, dp.OrganizationID, dp.CodecID, dp.CodecVersion); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp.ClockSyncEnabled = req.ClockSyncEnabled
+	dp.ClockSyncPeriodicity, err = clockSyncPeriodicityFromPB(req.ClockSyncRequestPeriodicity, req.ClockSyncPeriodicity)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp.DownlinkRetryPolicy = storage.DownlinkRetryPolicy{
+		MaxAttempts: int(req.DownlinkRetryMaxAttempts),
+		Backoff:     int(req.DownlinkRetryBackoff),
+		Reencode:    req.DownlinkRetryReencode,
+	}
+
 	// as this also performs a remote call to update the device-profile
 	// on the network-server, wrap it in a transaction
 	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
@@ -297,3 +347,18 @@ func (a *DeviceProfileServiceAPI) List(ctx context.Context, req *pb.ListDevicePr
 
 	return &resp, nil
 }
+
+// clockSyncPeriodicityFromPB returns the clock sync periodicity to store
+// for the given API values.
+func clockSyncPeriodicityFromPB(request bool, periodicity uint32) (*uint8, error) {
+	if !request {
+		return nil, nil
+	}
+
+	if periodicity > 15 {
+		return nil, storage.ErrDeviceProfileInvalidClockSync
+	}
+
+	p := uint8(periodicity)
+	return &p, nil
+}
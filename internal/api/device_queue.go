@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -43,8 +44,30 @@ func (d *DeviceQueueAPI) Enqueue(ctx context.Context, req *pb.EnqueueDeviceQueue
 		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
 	}
 
+	scheduledAt, err := parseOptionalTimestamp(req.ScheduledAt)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "scheduledAt: %s", err)
+	}
+	expiresAt, err := parseOptionalTimestamp(req.ExpiresAt)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "expiresAt: %s", err)
+	}
+
+	var object *string
+	var retry *storage.DownlinkRetryPolicy
+
+	if req.RetryPolicy != nil {
+		retry = &storage.DownlinkRetryPolicy{
+			MaxAttempts: int(req.RetryPolicy.MaxAttempts),
+			Backoff:     int(req.RetryPolicy.Backoff),
+			Reencode:    req.RetryPolicy.Reencode,
+		}
+	}
+
 	// if JSON object is set, try to encode it to bytes
 	if req.JsonObject != "" {
+		object = &req.JsonObject
+
 		dev, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI)
 		if err != nil {
 			return nil, errToRPCError(err)
@@ -55,8 +78,13 @@ func (d *DeviceQueueAPI) Enqueue(ctx context.Context, req *pb.EnqueueDeviceQueue
 			return nil, errToRPCError(err)
 		}
 
-		// get codec payload configured for the application
-		codecPL := codec.NewPayload(app.PayloadCodec, uint8(req.FPort), app.PayloadEncoderScript, app.PayloadDecoderScript)
+		cc, err := storage.GetCodecConfigForDevice(config.C.PostgreSQL.DB, app, dev.DeviceProfileID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		// get codec payload configured for the application / device-profile
+		codecPL := codec.NewPayload(cc.Type, uint8(req.FPort), cc.EncoderScript, cc.DecoderScript)
 		if codecPL == nil {
 			return nil, grpc.Errorf(codes.FailedPrecondition, "no or invalid codec configured for application")
 		}
@@ -72,17 +100,24 @@ func (d *DeviceQueueAPI) Enqueue(ctx context.Context, req *pb.EnqueueDeviceQueue
 		}
 	}
 
-	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
-		if err := downlink.EnqueueDownlinkPayload(tx, devEUI, req.Reference, req.Confirmed, uint8(req.FPort), req.Data); err != nil {
-			return errors.Wrap(err, "enqueue downlink payload error")
-		}
-		return nil
+	var scheduledItemID int64
+	err = downlink.WithIdempotencyKey(devEUI, req.IdempotencyKey, func() error {
+		return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+			var err error
+			scheduledItemID, err = downlink.ScheduleDownlinkPayload(tx, devEUI, req.Reference, req.Confirmed, uint8(req.FPort), req.Data, object, retry, scheduledAt, expiresAt)
+			if err != nil {
+				return errors.Wrap(err, "enqueue downlink payload error")
+			}
+			return nil
+		})
 	})
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	return &pb.EnqueueDeviceQueueItemResponse{}, nil
+	return &pb.EnqueueDeviceQueueItemResponse{
+		ScheduledItemID: scheduledItemID,
+	}, nil
 }
 
 // Flush flushes the downlink device-queue.
@@ -112,8 +147,20 @@ func (d *DeviceQueueAPI) Flush(ctx context.Context, req *pb.FlushDeviceQueueRequ
 			return errToRPCError(err)
 		}
 
+		if err := storage.FlushDeviceQueueScheduledItemsForDevEUI(tx, devEUI); err != nil {
+			return errToRPCError(err)
+		}
+
+		if err := storage.FlushDeviceQueueItemStatusForDevEUI(tx, devEUI); err != nil {
+			return errToRPCError(err)
+		}
+
+		if err := storage.FlushScheduledDeviceQueueItemStatusForDevEUI(tx, devEUI); err != nil {
+			return errToRPCError(err)
+		}
+
 		_, err := nsClient.FlushDeviceQueueForDevEUI(ctx, &ns.FlushDeviceQueueForDevEUIRequest{
-			DevEUI: devEUI[:],
+			DevEui: devEUI[:],
 		})
 		return err
 	})
@@ -152,7 +199,7 @@ func (d *DeviceQueueAPI) List(ctx context.Context, req *pb.ListDeviceQueueItemsR
 	}
 
 	queueItemsResp, err := nsClient.GetDeviceQueueItemsForDevEUI(ctx, &ns.GetDeviceQueueItemsForDevEUIRequest{
-		DevEUI: devEUI[:],
+		DevEui: devEUI[:],
 	})
 
 	var resp pb.ListDeviceQueueItemsResponse
@@ -173,3 +220,211 @@ func (d *DeviceQueueAPI) List(ctx context.Context, req *pb.ListDeviceQueueItemsR
 
 	return &resp, nil
 }
+
+// ListScheduled lists the pending scheduled items of the device.
+func (d *DeviceQueueAPI) ListScheduled(ctx context.Context, req *pb.ListScheduledDeviceQueueItemsRequest) (*pb.ListScheduledDeviceQueueItemsResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := d.validator.Validate(ctx,
+		auth.ValidateDeviceQueueAccess(devEUI, auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	items, err := storage.GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp pb.ListScheduledDeviceQueueItemsResponse
+	for _, item := range items {
+		qi := pb.ScheduledDeviceQueueItem{
+			Id:          item.ID,
+			DevEUI:      item.DevEUI.String(),
+			Reference:   item.Reference,
+			Confirmed:   item.Confirmed,
+			FPort:       uint32(item.FPort),
+			Data:        item.Data,
+			ScheduledAt: item.ScheduledAt.Format(time.RFC3339Nano),
+			CreatedAt:   item.CreatedAt.Format(time.RFC3339Nano),
+		}
+		if item.ExpiresAt != nil {
+			qi.ExpiresAt = item.ExpiresAt.Format(time.RFC3339Nano)
+		}
+
+		resp.Items = append(resp.Items, &qi)
+	}
+
+	return &resp, nil
+}
+
+// CancelScheduled cancels the given pending scheduled item.
+func (d *DeviceQueueAPI) CancelScheduled(ctx context.Context, req *pb.CancelScheduledDeviceQueueItemRequest) (*pb.CancelScheduledDeviceQueueItemResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := d.validator.Validate(ctx,
+		auth.ValidateDeviceQueueAccess(devEUI, auth.Delete)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		item, err := storage.GetDeviceQueueScheduledItem(tx, req.Id)
+		if err != nil {
+			return err
+		}
+
+		// the item must belong to the device for which access was validated
+		if item.DevEUI != devEUI {
+			return storage.ErrDoesNotExist
+		}
+
+		if err := storage.DeleteDeviceQueueScheduledItem(tx, item.ID); err != nil {
+			return err
+		}
+
+		s, err := storage.GetDeviceQueueItemStatusForScheduledItem(tx, item.ID)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return nil
+			}
+			return err
+		}
+		s.SetState(storage.DeviceQueueItemFlushed, time.Now())
+		return storage.UpdateDeviceQueueItemStatus(tx, &s)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CancelScheduledDeviceQueueItemResponse{}, nil
+}
+
+// GetStatus returns the delivery status of the most recent downlink
+// enqueued with the given reference.
+func (d *DeviceQueueAPI) GetStatus(ctx context.Context, req *pb.GetDeviceQueueItemStatusRequest) (*pb.GetDeviceQueueItemStatusResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := d.validator.Validate(ctx,
+		auth.ValidateDeviceQueueAccess(devEUI, auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	s, err := downlink.GetDeviceQueueItemStatus(config.C.PostgreSQL.DB, devEUI, req.Reference)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetDeviceQueueItemStatusResponse{
+		DevEUI:      s.DevEUI.String(),
+		Reference:   s.Reference,
+		Confirmed:   s.Confirmed,
+		Status:      string(s.State),
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   s.UpdatedAt.Format(time.RFC3339Nano),
+		SentAt:      formatTimePtr(s.SentAt),
+		CompletedAt: formatTimePtr(s.CompletedAt),
+		Attempt:     uint32(s.Attempt),
+		MaxAttempts: uint32(s.DownlinkRetryPolicy.MaxAttempts),
+	}
+	if s.FCnt != nil {
+		resp.FCnt = *s.FCnt
+	}
+	if s.DeviceQueueScheduledItemID != nil {
+		resp.ScheduledItemID = *s.DeviceQueueScheduledItemID
+	}
+
+	return &resp, nil
+}
+
+// parseOptionalTimestamp parses the given RFC3339 timestamp. It returns nil
+// when the given string is empty.
+func parseOptionalTimestamp(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// EnqueueBatch adds the given item to the device-queue of multiple devices
+// of an application.
+func (d *DeviceQueueAPI) EnqueueBatch(ctx context.Context, req *pb.EnqueueDeviceQueueBatchRequest) (*pb.EnqueueDeviceQueueBatchResponse, error) {
+	if err := d.validator.Validate(ctx,
+		auth.ValidateDeviceQueueBatchAccess(req.ApplicationID, auth.Create)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if req.FPort == 0 || req.FPort > 224 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fPort must be between 1 - 224")
+	}
+
+	b := downlink.DownlinkBatch{
+		ApplicationID:   req.ApplicationID,
+		DeviceProfileID: req.DeviceProfileID,
+		Tags:            req.Tags,
+		Reference:       req.Reference,
+		Confirmed:       req.Confirmed,
+		FPort:           uint8(req.FPort),
+		Data:            req.Data,
+		IdempotencyKey:  req.IdempotencyKey,
+	}
+
+	for _, s := range req.DevEUIs {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(s)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "devEUIs: %s", err)
+		}
+		b.DevEUIs = append(b.DevEUIs, devEUI)
+	}
+
+	if req.JsonObject != "" {
+		if !json.Valid([]byte(req.JsonObject)) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "jsonObject: invalid json")
+		}
+		b.Object = &req.JsonObject
+	}
+
+	if req.RetryPolicy != nil {
+		b.RetryPolicy = &storage.DownlinkRetryPolicy{
+			MaxAttempts: int(req.RetryPolicy.MaxAttempts),
+			Backoff:     int(req.RetryPolicy.Backoff),
+			Reencode:    req.RetryPolicy.Reencode,
+		}
+	}
+
+	results, err := downlink.EnqueueDownlinkBatch(b)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp pb.EnqueueDeviceQueueBatchResponse
+	for _, res := range results {
+		r := pb.EnqueueDeviceQueueBatchResult{
+			DevEUI: res.DevEUI.String(),
+			FCnt:   res.FCnt,
+		}
+
+		if res.Error != nil {
+			r.Error = res.Error.Error()
+			resp.ErrorCount++
+		} else {
+			resp.EnqueueCount++
+		}
+
+		resp.Result = append(resp.Result, &r)
+	}
+
+	return &resp, nil
+}
@@ -3,6 +3,10 @@ package api
 import (
 	"context"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/codec"
@@ -23,9 +27,12 @@ func TestDownlinkQueueAPI(t *testing.T) {
 	}
 
 	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = storage.NewRedisPool(conf.RedisURL)
+	config.C.ApplicationServer.Downlink.IdempotencyWindow = time.Minute
 
 	Convey("Given a clean database, an organization, application + node and api instance", t, func() {
 		test.MustResetDB(config.C.PostgreSQL.DB)
+		test.MustFlushRedis(config.C.Redis.Pool)
 
 		nsClient := test.NewNetworkServerClient()
 		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
@@ -76,6 +83,7 @@ func TestDownlinkQueueAPI(t *testing.T) {
 			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
 			Name:            "test-node",
 			DevEUI:          [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			Tags:            storage.DeviceTags{"building": "a"},
 		}
 		So(storage.CreateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
 
@@ -118,7 +126,7 @@ func TestDownlinkQueueAPI(t *testing.T) {
 					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
 					So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
 						Item: &ns.DeviceQueueItem{
-							DevEUI:     d.DevEUI[:],
+							DevEui:     d.DevEUI[:],
 							FrmPayload: b,
 							FCnt:       12,
 							FPort:      10,
@@ -142,7 +150,7 @@ func TestDownlinkQueueAPI(t *testing.T) {
 				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
 				So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
 					Item: &ns.DeviceQueueItem{
-						DevEUI:     d.DevEUI[:],
+						DevEui:     d.DevEUI[:],
 						FrmPayload: b,
 						FCnt:       12,
 						FPort:      10,
@@ -151,11 +159,271 @@ func TestDownlinkQueueAPI(t *testing.T) {
 			})
 		})
 
+		Convey("When enqueueing a downlink queue item scheduled in the future", func() {
+			scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+			resp, err := api.Enqueue(ctx, &pb.EnqueueDeviceQueueItemRequest{
+				DevEUI:      d.DevEUI.String(),
+				FPort:       10,
+				Data:        []byte{1, 2, 3, 4},
+				ScheduledAt: scheduledAt.Format(time.RFC3339),
+			})
+			So(err, ShouldBeNil)
+			So(resp.ScheduledItemID, ShouldBeGreaterThan, 0)
+
+			Convey("Then no request has been made to the network-server", func() {
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+			})
+
+			Convey("Then ListScheduled returns the item", func() {
+				listResp, err := api.ListScheduled(ctx, &pb.ListScheduledDeviceQueueItemsRequest{
+					DevEUI: d.DevEUI.String(),
+				})
+				So(err, ShouldBeNil)
+				So(listResp.Items, ShouldHaveLength, 1)
+				So(listResp.Items[0].Id, ShouldEqual, resp.ScheduledItemID)
+				So(listResp.Items[0].FPort, ShouldEqual, 10)
+				So(listResp.Items[0].Data, ShouldResemble, []byte{1, 2, 3, 4})
+				So(listResp.Items[0].ExpiresAt, ShouldEqual, "")
+			})
+
+			Convey("When cancelling the item", func() {
+				_, err := api.CancelScheduled(ctx, &pb.CancelScheduledDeviceQueueItemRequest{
+					DevEUI: d.DevEUI.String(),
+					Id:     resp.ScheduledItemID,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the item has been removed", func() {
+					listResp, err := api.ListScheduled(ctx, &pb.ListScheduledDeviceQueueItemsRequest{
+						DevEUI: d.DevEUI.String(),
+					})
+					So(err, ShouldBeNil)
+					So(listResp.Items, ShouldHaveLength, 0)
+				})
+
+				Convey("Then GetStatus returns the item as flushed", func() {
+					statusResp, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+						DevEUI: d.DevEUI.String(),
+					})
+					So(err, ShouldBeNil)
+					So(statusResp.Status, ShouldEqual, "FLUSHED")
+					So(statusResp.ScheduledItemID, ShouldEqual, resp.ScheduledItemID)
+					So(statusResp.CompletedAt, ShouldNotEqual, "")
+				})
+			})
+		})
+
+		Convey("When enqueueing a downlink queue item with a reference", func() {
+			_, err := api.Enqueue(ctx, &pb.EnqueueDeviceQueueItemRequest{
+				DevEUI:    d.DevEUI.String(),
+				Reference: "test-status",
+				Confirmed: true,
+				FPort:     10,
+				Data:      []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then GetStatus returns the item as queued while it is in the device-queue", func() {
+				nsClient.GetDeviceQueueItemsForDevEUIResponse = ns.GetDeviceQueueItemsForDevEUIResponse{
+					Items: []*ns.DeviceQueueItem{
+						{DevEui: d.DevEUI[:], FCnt: 12, FPort: 10, Confirmed: true},
+					},
+				}
+
+				resp, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+					DevEUI:    d.DevEUI.String(),
+					Reference: "test-status",
+				})
+				So(err, ShouldBeNil)
+				So(resp.Status, ShouldEqual, "QUEUED")
+				So(resp.Reference, ShouldEqual, "test-status")
+				So(resp.Confirmed, ShouldBeTrue)
+				So(resp.FCnt, ShouldEqual, 12)
+				So(resp.SentAt, ShouldEqual, "")
+			})
+
+			Convey("Then GetStatus returns the item as unknown once it left the device-queue without FCnt progression", func() {
+				resp, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+					DevEUI:    d.DevEUI.String(),
+					Reference: "test-status",
+				})
+				So(err, ShouldBeNil)
+				So(resp.Status, ShouldEqual, "UNKNOWN")
+				So(resp.SentAt, ShouldEqual, "")
+				So(resp.CompletedAt, ShouldEqual, "")
+
+				Convey("Then GetStatus returns the item as sent once the FCnt progressed", func() {
+					nsClient.GetNextDownlinkFCntForDevEUIResponse.FCnt = 13
+
+					resp, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+						DevEUI:    d.DevEUI.String(),
+						Reference: "test-status",
+					})
+					So(err, ShouldBeNil)
+					So(resp.Status, ShouldEqual, "SENT")
+					So(resp.SentAt, ShouldNotEqual, "")
+				})
+			})
+
+			Convey("Then GetStatus returns the item as sent once it left the device-queue and the FCnt progressed", func() {
+				nsClient.GetNextDownlinkFCntForDevEUIResponse.FCnt = 13
+
+				resp, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+					DevEUI:    d.DevEUI.String(),
+					Reference: "test-status",
+				})
+				So(err, ShouldBeNil)
+				So(resp.Status, ShouldEqual, "SENT")
+				So(resp.SentAt, ShouldNotEqual, "")
+				So(resp.CompletedAt, ShouldEqual, "")
+			})
+
+			Convey("When calling Flush", func() {
+				_, err := api.Flush(ctx, &pb.FlushDeviceQueueRequest{
+					DevEUI: d.DevEUI.String(),
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then GetStatus returns the item as flushed", func() {
+					resp, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+						DevEUI:    d.DevEUI.String(),
+						Reference: "test-status",
+					})
+					So(err, ShouldBeNil)
+					So(resp.Status, ShouldEqual, "FLUSHED")
+				})
+			})
+		})
+
+		Convey("When calling GetStatus for an unknown reference", func() {
+			_, err := api.GetStatus(ctx, &pb.GetDeviceQueueItemStatusRequest{
+				DevEUI:    d.DevEUI.String(),
+				Reference: "unknown",
+			})
+
+			Convey("Then a NotFound error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.NotFound)
+			})
+		})
+
+		Convey("When enqueueing a downlink queue item with an idempotency key", func() {
+			req := pb.EnqueueDeviceQueueItemRequest{
+				DevEUI:         d.DevEUI.String(),
+				FPort:          10,
+				Data:           []byte{1, 2, 3, 4},
+				IdempotencyKey: "test-key",
+			}
+			_, err := api.Enqueue(ctx, &req)
+			So(err, ShouldBeNil)
+			So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+
+			Convey("Then enqueueing it again returns an AlreadyExists error", func() {
+				_, err := api.Enqueue(ctx, &req)
+				So(grpc.Code(err), ShouldEqual, codes.AlreadyExists)
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When calling EnqueueBatch for the devices of the application", func() {
+			resp, err := api.EnqueueBatch(ctx, &pb.EnqueueDeviceQueueBatchRequest{
+				ApplicationID: app.ID,
+				DevEUIs:       []string{d.DevEUI.String(), "0807060504030201"},
+				Reference:     "test-batch",
+				FPort:         10,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+			So(validator.ctx, ShouldResemble, ctx)
+			So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+			Convey("Then the result is returned per device", func() {
+				So(resp, ShouldResemble, &pb.EnqueueDeviceQueueBatchResponse{
+					EnqueueCount: 1,
+					ErrorCount:   1,
+					Result: []*pb.EnqueueDeviceQueueBatchResult{
+						{DevEUI: d.DevEUI.String(), FCnt: 12},
+						{DevEUI: "0807060504030201", Error: storage.ErrDoesNotExist.Error()},
+					},
+				})
+			})
+
+			Convey("Then the item was added to the device-queue", func() {
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+				So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     d.DevEUI[:],
+						FrmPayload: b,
+						FCnt:       12,
+						FPort:      10,
+					},
+				})
+			})
+		})
+
+		Convey("When calling EnqueueBatch for the devices having the given tags", func() {
+			resp, err := api.EnqueueBatch(ctx, &pb.EnqueueDeviceQueueBatchRequest{
+				ApplicationID: app.ID,
+				Tags:          map[string]string{"building": "a"},
+				Reference:     "test-batch",
+				FPort:         10,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the item was enqueued for the device having these tags", func() {
+				So(resp.EnqueueCount, ShouldEqual, 1)
+				So(resp.Result, ShouldResemble, []*pb.EnqueueDeviceQueueBatchResult{
+					{DevEUI: d.DevEUI.String(), FCnt: 12},
+				})
+			})
+		})
+
+		Convey("When calling EnqueueBatch for tags no device has", func() {
+			resp, err := api.EnqueueBatch(ctx, &pb.EnqueueDeviceQueueBatchRequest{
+				ApplicationID: app.ID,
+				Tags:          map[string]string{"building": "b"},
+				Reference:     "test-batch",
+				FPort:         10,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the item was not enqueued", func() {
+				So(resp.EnqueueCount, ShouldEqual, 0)
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When calling EnqueueBatch with an invalid fPort", func() {
+			_, err := api.EnqueueBatch(ctx, &pb.EnqueueDeviceQueueBatchRequest{
+				ApplicationID: app.ID,
+				Data:          []byte{1, 2, 3, 4},
+			})
+
+			Convey("Then an InvalidArgument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When enqueueing a downlink queue item which has already expired", func() {
+			_, err := api.Enqueue(ctx, &pb.EnqueueDeviceQueueItemRequest{
+				DevEUI:    d.DevEUI.String(),
+				FPort:     10,
+				Data:      []byte{1, 2, 3, 4},
+				ExpiresAt: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			})
+
+			Convey("Then an InvalidArgument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+			})
+		})
+
 		Convey("Given a mocked device-queue item", func() {
 			nsClient.GetDeviceQueueItemsForDevEUIResponse = ns.GetDeviceQueueItemsForDevEUIResponse{
 				Items: []*ns.DeviceQueueItem{
 					{
-						DevEUI:     d.DevEUI[:],
+						DevEui:     d.DevEUI[:],
 						FrmPayload: b,
 						FCnt:       12,
 						FPort:      10,
@@ -197,7 +465,7 @@ func TestDownlinkQueueAPI(t *testing.T) {
 				Convey("Then the expected request has been made to the network-server", func() {
 					So(nsClient.FlushDeviceQueueForDevEUIChan, ShouldHaveLength, 1)
 					So(<-nsClient.FlushDeviceQueueForDevEUIChan, ShouldResemble, ns.FlushDeviceQueueForDevEUIRequest{
-						DevEUI: d.DevEUI[:],
+						DevEui: d.DevEUI[:],
 					})
 				})
 
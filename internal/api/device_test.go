@@ -13,6 +13,8 @@ import (
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/hsm"
+	"github.com/brocaar/lora-app-server/internal/join"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/loraserver/api/ns"
@@ -97,6 +99,97 @@ func TestNodeAPI(t *testing.T) {
 		}
 		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
 
+		Convey("When creating unclaimed devices", func() {
+			resp, err := api.CreateUnclaimed(ctx, &pb.CreateUnclaimedDevicesRequest{
+				Devices: []*pb.UnclaimedDevice{
+					{
+						DevEUI:          "0102030405060708",
+						DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+						DeviceKeys: &pb.DeviceKeys{
+							AppKey: "01020304050607080102030405060708",
+						},
+						ClaimCode: "CLAIM-CODE-1234",
+					},
+					{
+						DevEUI:          "0807060504030201",
+						DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+						DeviceKeys: &pb.DeviceKeys{
+							AppKey:    "08070605040302010807060504030201",
+							GenAppKey: "02020202020202020202020202020202",
+						},
+					},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(validator.validatorFuncs, ShouldHaveLength, 1)
+			So(resp.Result, ShouldHaveLength, 2)
+			So(resp.Result[0], ShouldResemble, &pb.UnclaimedDeviceClaimCode{
+				DevEUI:    "0102030405060708",
+				ClaimCode: "CLAIM-CODE-1234",
+			})
+			So(resp.Result[1].DevEUI, ShouldEqual, "0807060504030201")
+			So(resp.Result[1].ClaimCode, ShouldNotEqual, "")
+
+			Convey("Then ListUnclaimed returns the unclaimed devices", func() {
+				list, err := api.ListUnclaimed(ctx, &pb.ListUnclaimedDevicesRequest{
+					Limit: 10,
+				})
+				So(err, ShouldBeNil)
+				So(list.TotalCount, ShouldEqual, 2)
+				So(list.Result, ShouldHaveLength, 2)
+			})
+
+			Convey("Then Claim with an invalid claim-code returns an error", func() {
+				_, err := api.Claim(ctx, &pb.ClaimDeviceRequest{
+					DevEUI:        "0807060504030201",
+					ClaimCode:     "INVALID",
+					ApplicationID: app.ID,
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("When claiming a device using the generated claim-code", func() {
+				_, err := api.Claim(ctx, &pb.ClaimDeviceRequest{
+					DevEUI:        "0807060504030201",
+					ClaimCode:     resp.Result[1].ClaimCode,
+					ApplicationID: app.ID,
+				})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				Convey("Then the device has been added to the application", func() {
+					d, err := storage.GetDevice(config.C.PostgreSQL.DB, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+					So(err, ShouldBeNil)
+					So(d.ApplicationID, ShouldEqual, app.ID)
+
+					dk, err := storage.GetDeviceKeys(config.C.PostgreSQL.DB, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(dk.AppKey, ShouldEqual, lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1})
+					So(dk.GenAppKey, ShouldEqual, lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2})
+				})
+
+				Convey("Then the device is no longer unclaimed", func() {
+					list, err := api.ListUnclaimed(ctx, &pb.ListUnclaimedDevicesRequest{
+						Limit: 10,
+					})
+					So(err, ShouldBeNil)
+					So(list.TotalCount, ShouldEqual, 1)
+				})
+			})
+
+			Convey("Then DeleteUnclaimed deletes the unclaimed device", func() {
+				_, err := api.DeleteUnclaimed(ctx, &pb.DeleteUnclaimedDeviceRequest{
+					DevEUI: "0102030405060708",
+				})
+				So(err, ShouldBeNil)
+
+				_, err = api.DeleteUnclaimed(ctx, &pb.DeleteUnclaimedDeviceRequest{
+					DevEUI: "0102030405060708",
+				})
+				So(grpc.Code(err), ShouldEqual, codes.NotFound)
+			})
+		})
+
 		Convey("When creating a device without a name set", func() {
 			_, err := api.Create(ctx, &pb.CreateDeviceRequest{
 				ApplicationID:   app.ID,
@@ -124,6 +217,7 @@ func TestNodeAPI(t *testing.T) {
 				DevEUI:          "0807060504030201",
 				DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
 				SkipFCntCheck:   true,
+				Tags:            map[string]string{"building": "a"},
 			})
 			So(err, ShouldBeNil)
 			So(validator.validatorFuncs, ShouldHaveLength, 1)
@@ -148,6 +242,7 @@ func TestNodeAPI(t *testing.T) {
 					DeviceStatusMargin:  256,
 					DeviceStatusBattery: 256,
 					SkipFCntCheck:       true,
+					Tags:                map[string]string{"building": "a"},
 				})
 
 				Convey("When setting the device-status battery and margin", func() {
@@ -207,6 +302,7 @@ func TestNodeAPI(t *testing.T) {
 					DeviceProfileName:   dp.Name,
 					DeviceStatusBattery: 256,
 					DeviceStatusMargin:  256,
+					Tags:                map[string]string{"building": "a"},
 				})
 			})
 
@@ -218,6 +314,7 @@ func TestNodeAPI(t *testing.T) {
 					Description:     "test device description updated",
 					DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
 					SkipFCntCheck:   true,
+					Tags:            map[string]string{"building": "b", "floor": "2"},
 				})
 				So(err, ShouldBeNil)
 				So(validator.validatorFuncs, ShouldHaveLength, 1)
@@ -236,6 +333,7 @@ func TestNodeAPI(t *testing.T) {
 						DeviceStatusBattery: 256,
 						DeviceStatusMargin:  256,
 						SkipFCntCheck:       true,
+						Tags:                map[string]string{"building": "b", "floor": "2"},
 					})
 				})
 			})
@@ -258,6 +356,47 @@ func TestNodeAPI(t *testing.T) {
 				})
 			})
 
+			Convey("Then ListThrottledByApplicationID returns the throttled devices", func() {
+				resp, err := api.ListThrottledByApplicationID(ctx, &pb.ListThrottledDevicesRequest{
+					ApplicationID: app.ID,
+				})
+				So(err, ShouldBeNil)
+				So(resp.Result, ShouldHaveLength, 0)
+			})
+
+			Convey("Given a device-join record", func() {
+				dj := storage.DeviceJoin{
+					DevEUI:      lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					MessageType: "JoinReq",
+					DevNonce:    258,
+					JoinNonce:   1,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+					SenderID:    "010203",
+					ResultCode:  "Success",
+				}
+				So(storage.CreateDeviceJoin(config.C.PostgreSQL.DB, &dj, 10), ShouldBeNil)
+
+				Convey("Then ListActivations returns the join history", func() {
+					resp, err := api.ListActivations(ctx, &pb.ListDeviceActivationsRequest{
+						DevEUI: "0807060504030201",
+						Limit:  10,
+					})
+					So(err, ShouldBeNil)
+					So(resp.TotalCount, ShouldEqual, 1)
+					So(resp.Result, ShouldHaveLength, 1)
+					So(resp.Result[0].CreatedAt, ShouldNotEqual, "")
+					resp.Result[0].CreatedAt = ""
+					So(resp.Result[0], ShouldResemble, &pb.DeviceActivationListItem{
+						MessageType: "JoinReq",
+						DevNonce:    258,
+						JoinNonce:   1,
+						DevAddr:     "01020304",
+						SenderID:    "010203",
+						ResultCode:  "Success",
+					})
+				})
+			})
+
 			Convey("Then CreateKeys creates device-keys", func() {
 				_, err := api.CreateKeys(ctx, &pb.CreateDeviceKeysRequest{
 					DevEUI: "0807060504030201",
@@ -280,6 +419,9 @@ func TestNodeAPI(t *testing.T) {
 				})
 
 				Convey("Then UpdateKeys updates the device-keys", func() {
+					devEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+					So(storage.ValidateAndStoreDevNonce(config.C.PostgreSQL.DB, devEUI, 10, false, 10), ShouldBeNil)
+
 					_, err := api.UpdateKeys(ctx, &pb.UpdateDeviceKeysRequest{
 						DevEUI: "0807060504030201",
 						DeviceKeys: &pb.DeviceKeys{
@@ -297,6 +439,80 @@ func TestNodeAPI(t *testing.T) {
 							AppKey: "08070605040302010102030405060708",
 						},
 					})
+
+					nonces, err := storage.GetDevNonces(config.C.PostgreSQL.DB, devEUI)
+					So(err, ShouldBeNil)
+					So(nonces, ShouldHaveLength, 0)
+				})
+
+				Convey("Then UpdateKeys sets the NwkKey", func() {
+					_, err := api.UpdateKeys(ctx, &pb.UpdateDeviceKeysRequest{
+						DevEUI: "0807060504030201",
+						DeviceKeys: &pb.DeviceKeys{
+							AppKey: "01020304050607080807060504030201",
+							NwkKey: "08070605040302010102030405060708",
+						},
+					})
+					So(err, ShouldBeNil)
+
+					dk, err := api.GetKeys(ctx, &pb.GetDeviceKeysRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(dk, ShouldResemble, &pb.GetDeviceKeysResponse{
+						DeviceKeys: &pb.DeviceKeys{
+							AppKey: "01020304050607080807060504030201",
+							NwkKey: "08070605040302010102030405060708",
+						},
+					})
+				})
+
+				Convey("Then UpdateKeys with an invalid NwkKey returns an error", func() {
+					_, err := api.UpdateKeys(ctx, &pb.UpdateDeviceKeysRequest{
+						DevEUI: "0807060504030201",
+						DeviceKeys: &pb.DeviceKeys{
+							AppKey: "01020304050607080807060504030201",
+							NwkKey: "0807",
+						},
+					})
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+
+				Convey("Given the root-keys are stored in the HSM", func() {
+					join.SetHSM(testHSM{})
+					defer join.SetHSM(nil)
+
+					Convey("Then GetKeys does not return the root-keys", func() {
+						dk, err := api.GetKeys(ctx, &pb.GetDeviceKeysRequest{
+							DevEUI: "0807060504030201",
+						})
+						So(err, ShouldBeNil)
+						So(dk, ShouldResemble, &pb.GetDeviceKeysResponse{
+							DeviceKeys: &pb.DeviceKeys{},
+						})
+					})
+
+					Convey("Then UpdateKeys with an AppKey returns an error", func() {
+						_, err := api.UpdateKeys(ctx, &pb.UpdateDeviceKeysRequest{
+							DevEUI: "0807060504030201",
+							DeviceKeys: &pb.DeviceKeys{
+								AppKey: "08070605040302010102030405060708",
+							},
+						})
+						So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+					})
+
+					Convey("Then UpdateKeys without root-keys resets the device-keys", func() {
+						_, err := api.UpdateKeys(ctx, &pb.UpdateDeviceKeysRequest{
+							DevEUI:     "0807060504030201",
+							DeviceKeys: &pb.DeviceKeys{},
+						})
+						So(err, ShouldBeNil)
+
+						dk, err := storage.GetDeviceKeys(config.C.PostgreSQL.DB, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+						So(err, ShouldBeNil)
+						So(dk.AppKey, ShouldEqual, lorawan.AES128Key{})
+					})
 				})
 
 				Convey("Then DeleteKeys deletes the device-keys", func() {
@@ -313,6 +529,54 @@ func TestNodeAPI(t *testing.T) {
 				})
 			})
 
+			Convey("Then UpdateShadow sets the desired state", func() {
+				_, err := api.UpdateShadow(ctx, &pb.UpdateDeviceShadowRequest{
+					DevEUI:      "0807060504030201",
+					DesiredJSON: `[1, 2, 3]`,
+					FPort:       10,
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+
+				_, err = api.UpdateShadow(ctx, &pb.UpdateDeviceShadowRequest{
+					DevEUI:      "0807060504030201",
+					DesiredJSON: `{"interval": 60}`,
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+
+				resp, err := api.UpdateShadow(ctx, &pb.UpdateDeviceShadowRequest{
+					DevEUI:      "0807060504030201",
+					DesiredJSON: `{"interval": 60}`,
+					FPort:       10,
+				})
+				So(err, ShouldBeNil)
+				So(resp.DesiredVersion, ShouldEqual, 1)
+
+				Convey("Then GetShadow returns the shadow", func() {
+					s, err := api.GetShadow(ctx, &pb.GetDeviceShadowRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(s, ShouldResemble, &pb.GetDeviceShadowResponse{
+						DesiredJSON:    `{"interval": 60}`,
+						DesiredVersion: 1,
+						DeltaJSON:      `{"interval":60}`,
+						FPort:          10,
+					})
+				})
+
+				Convey("Then DeleteShadow deletes the shadow", func() {
+					_, err := api.DeleteShadow(ctx, &pb.DeleteDeviceShadowRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+
+					_, err = api.GetShadow(ctx, &pb.GetDeviceShadowRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(grpc.Code(err), ShouldEqual, codes.NotFound)
+				})
+			})
+
 			Convey("When activating the device (ABP)", func() {
 				_, err := api.Activate(ctx, &pb.ActivateDeviceRequest{
 					DevEUI:   "0807060504030201",
@@ -328,18 +592,22 @@ func TestNodeAPI(t *testing.T) {
 				Convey("Then an attempt was made to deactivate the device-session", func() {
 					So(nsClient.DeactivateDeviceChan, ShouldHaveLength, 1)
 					So(<-nsClient.DeactivateDeviceChan, ShouldResemble, ns.DeactivateDeviceRequest{
-						DevEUI: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+						DevEui: []byte{8, 7, 6, 5, 4, 3, 2, 1},
 					})
 				})
 
 				Convey("Then a device-session was created", func() {
 					So(nsClient.ActivateDeviceChan, ShouldHaveLength, 1)
 					So(<-nsClient.ActivateDeviceChan, ShouldResemble, ns.ActivateDeviceRequest{
-						DevAddr:  []uint8{1, 2, 3, 4},
-						DevEUI:   []uint8{8, 7, 6, 5, 4, 3, 2, 1},
-						NwkSKey:  []uint8{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
-						FCntUp:   10,
-						FCntDown: 11,
+						DeviceActivation: &ns.DeviceActivation{
+							DevAddr:     []uint8{1, 2, 3, 4},
+							DevEui:      []uint8{8, 7, 6, 5, 4, 3, 2, 1},
+							SNwkSIntKey: []uint8{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+							FNwkSIntKey: []uint8{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+							NwkSEncKey:  []uint8{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+							FCntUp:      10,
+							NFCntDown:   11,
+						},
 					})
 				})
 
@@ -387,3 +655,18 @@ func TestNodeAPI(t *testing.T) {
 		})
 	})
 }
+
+// testHSM implements the hsm.Backend interface without any stored keys.
+type testHSM struct{}
+
+func (testHSM) Encrypt(label string, data []byte) ([]byte, error) {
+	return nil, hsm.ErrKeyNotFound
+}
+
+func (testHSM) Decrypt(label string, data []byte) ([]byte, error) {
+	return nil, hsm.ErrKeyNotFound
+}
+
+func (testHSM) CMAC(label string, data []byte) ([]byte, error) {
+	return nil, hsm.ErrKeyNotFound
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// DownlinkScheduleAPI exports the DownlinkSchedule related functions.
+type DownlinkScheduleAPI struct {
+	validator auth.Validator
+}
+
+// NewDownlinkScheduleAPI creates a new DownlinkScheduleAPI.
+func NewDownlinkScheduleAPI(validator auth.Validator) *DownlinkScheduleAPI {
+	return &DownlinkScheduleAPI{
+		validator: validator,
+	}
+}
+
+// Create creates the given downlink schedule.
+func (a *DownlinkScheduleAPI) Create(ctx context.Context, req *pb.CreateDownlinkScheduleRequest) (*pb.CreateDownlinkScheduleResponse, error) {
+	if req.DownlinkSchedule == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "downlinkSchedule expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDownlinkSchedulesAccess(auth.Create, req.DownlinkSchedule.OrganizationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	s := storage.DownlinkSchedule{
+		OrganizationID: req.DownlinkSchedule.OrganizationID,
+	}
+	if err := downlinkScheduleFromPB(req.DownlinkSchedule, &s); err != nil {
+		return nil, err
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.CreateDownlinkSchedule(tx, &s)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateDownlinkScheduleResponse{
+		Id: s.ID,
+	}, nil
+}
+
+// Get returns the downlink schedule matching the given id.
+func (a *DownlinkScheduleAPI) Get(ctx context.Context, req *pb.GetDownlinkScheduleRequest) (*pb.GetDownlinkScheduleResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDownlinkScheduleAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	s, err := storage.GetDownlinkSchedule(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetDownlinkScheduleResponse{
+		DownlinkSchedule: &pb.DownlinkSchedule{
+			Id:             s.ID,
+			Name:           s.Name,
+			OrganizationID: s.OrganizationID,
+			CronExpression: s.CronExpression,
+			Confirmed:      s.Confirmed,
+			FPort:          uint32(s.FPort),
+			Data:           s.Data,
+		},
+		NextRunAt: s.NextRunAt.Format(time.RFC3339Nano),
+		CreatedAt: s.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: s.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	for _, devEUI := range s.DevEUIs {
+		resp.DownlinkSchedule.DevEUIs = append(resp.DownlinkSchedule.DevEUIs, devEUI.String())
+	}
+	if s.ApplicationID != nil {
+		resp.DownlinkSchedule.ApplicationID = *s.ApplicationID
+	}
+	if s.DeviceProfileID != nil {
+		resp.DownlinkSchedule.DeviceProfileID = *s.DeviceProfileID
+	}
+	if s.Object != nil {
+		resp.DownlinkSchedule.JsonObject = *s.Object
+	}
+	if s.LastRunAt != nil {
+		resp.LastRunAt = s.LastRunAt.Format(time.RFC3339Nano)
+	}
+
+	return &resp, nil
+}
+
+// Update updates the given downlink schedule.
+func (a *DownlinkScheduleAPI) Update(ctx context.Context, req *pb.UpdateDownlinkScheduleRequest) (*pb.UpdateDownlinkScheduleResponse, error) {
+	if req.DownlinkSchedule == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "downlinkSchedule expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDownlinkScheduleAccess(auth.Update, req.DownlinkSchedule.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		s, err := storage.GetDownlinkSchedule(tx, req.DownlinkSchedule.Id)
+		if err != nil {
+			return errToRPCError(err)
+		}
+
+		if err := downlinkScheduleFromPB(req.DownlinkSchedule, &s); err != nil {
+			return err
+		}
+
+		if err := storage.UpdateDownlinkSchedule(tx, &s); err != nil {
+			return errToRPCError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateDownlinkScheduleResponse{}, nil
+}
+
+// Delete deletes the downlink schedule matching the given id.
+func (a *DownlinkScheduleAPI) Delete(ctx context.Context, req *pb.DeleteDownlinkScheduleRequest) (*pb.DeleteDownlinkScheduleResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDownlinkScheduleAccess(auth.Delete, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteDownlinkSchedule(config.C.PostgreSQL.DB, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteDownlinkScheduleResponse{}, nil
+}
+
+// List returns the downlink schedules of the given organization.
+func (a *DownlinkScheduleAPI) List(ctx context.Context, req *pb.ListDownlinkScheduleRequest) (*pb.ListDownlinkScheduleResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateDownlinkSchedulesAccess(auth.List, req.OrganizationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetDownlinkScheduleCountForOrganizationID(config.C.PostgreSQL.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	schedules, err := storage.GetDownlinkSchedulesForOrganizationID(config.C.PostgreSQL.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := pb.ListDownlinkScheduleResponse{
+		TotalCount: int64(count),
+	}
+
+	for _, s := range schedules {
+		item := pb.DownlinkScheduleListItem{
+			Id:             s.ID,
+			Name:           s.Name,
+			OrganizationID: s.OrganizationID,
+			CronExpression: s.CronExpression,
+			NextRunAt:      s.NextRunAt.Format(time.RFC3339Nano),
+			CreatedAt:      s.CreatedAt.Format(time.RFC3339Nano),
+			UpdatedAt:      s.UpdatedAt.Format(time.RFC3339Nano),
+		}
+		if s.LastRunAt != nil {
+			item.LastRunAt = s.LastRunAt.Format(time.RFC3339Nano)
+		}
+
+		out.Result = append(out.Result, &item)
+	}
+
+	return &out, nil
+}
+
+// downlinkScheduleFromPB sets the user-editable fields of the given
+// downlink schedule from the given API representation. Note that the
+// organization of an existing schedule can not be changed.
+func downlinkScheduleFromPB(in *pb.DownlinkSchedule, s *storage.DownlinkSchedule) error {
+	// validate before the conversion to uint8, as this would truncate it
+	if in.FPort == 0 || in.FPort > 223 {
+		return grpc.Errorf(codes.InvalidArgument, "fPort must be between 1 and 223")
+	}
+
+	s.Name = in.Name
+	s.CronExpression = in.CronExpression
+	s.Confirmed = in.Confirmed
+	s.FPort = uint8(in.FPort)
+	s.Data = in.Data
+	s.DevEUIs = nil
+	s.ApplicationID = nil
+	s.DeviceProfileID = nil
+	s.Object = nil
+
+	for _, str := range in.DevEUIs {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(str)); err != nil {
+			return grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+		}
+		s.DevEUIs = append(s.DevEUIs, devEUI)
+	}
+	if in.ApplicationID != 0 {
+		s.ApplicationID = &in.ApplicationID
+	}
+	if in.DeviceProfileID != "" {
+		s.DeviceProfileID = &in.DeviceProfileID
+	}
+	if in.JsonObject != "" {
+		s.Object = &in.JsonObject
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDownlinkScheduleAPI(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nsClient := test.NewNetworkServerClient()
+
+	config.C.PostgreSQL.DB = db
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization, application and an api instance", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		ctx := context.Background()
+		validator := &TestValidator{}
+		api := NewDownlinkScheduleAPI(validator)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		Convey("When creating a downlink schedule with an invalid cron expression", func() {
+			_, err := api.Create(ctx, &pb.CreateDownlinkScheduleRequest{
+				DownlinkSchedule: &pb.DownlinkSchedule{
+					OrganizationID: org.ID,
+					Name:           "test-schedule",
+					CronExpression: "every hour",
+					ApplicationID:  app.ID,
+					FPort:          10,
+					Data:           []byte{1, 2, 3},
+				},
+			})
+
+			Convey("Then an invalid argument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When creating a downlink schedule with an fPort which does not fit in a byte", func() {
+			_, err := api.Create(ctx, &pb.CreateDownlinkScheduleRequest{
+				DownlinkSchedule: &pb.DownlinkSchedule{
+					OrganizationID: org.ID,
+					Name:           "test-schedule",
+					CronExpression: "@hourly",
+					ApplicationID:  app.ID,
+					FPort:          266,
+					Data:           []byte{1, 2, 3},
+				},
+			})
+
+			Convey("Then an invalid argument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When creating a downlink schedule", func() {
+			createResp, err := api.Create(ctx, &pb.CreateDownlinkScheduleRequest{
+				DownlinkSchedule: &pb.DownlinkSchedule{
+					OrganizationID: org.ID,
+					Name:           "test-schedule",
+					CronExpression: "@daily",
+					ApplicationID:  app.ID,
+					FPort:          10,
+					JsonObject:     `{"interval": 60}`,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(validator.validatorFuncs, ShouldHaveLength, 1)
+			So(createResp.Id, ShouldBeGreaterThan, 0)
+
+			Convey("Then the downlink schedule has been created", func() {
+				s, err := api.Get(ctx, &pb.GetDownlinkScheduleRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(s.DownlinkSchedule.Name, ShouldEqual, "test-schedule")
+				So(s.DownlinkSchedule.CronExpression, ShouldEqual, "@daily")
+				So(s.DownlinkSchedule.ApplicationID, ShouldEqual, app.ID)
+				So(s.DownlinkSchedule.FPort, ShouldEqual, 10)
+				So(s.DownlinkSchedule.JsonObject, ShouldEqual, `{"interval": 60}`)
+				So(s.LastRunAt, ShouldEqual, "")
+				So(s.NextRunAt, ShouldNotEqual, "")
+			})
+
+			Convey("Then the downlink schedule is listed", func() {
+				resp, err := api.List(ctx, &pb.ListDownlinkScheduleRequest{
+					OrganizationID: org.ID,
+					Limit:          10,
+				})
+				So(err, ShouldBeNil)
+				So(resp.TotalCount, ShouldEqual, 1)
+				So(resp.Result, ShouldHaveLength, 1)
+				So(resp.Result[0].Id, ShouldEqual, createResp.Id)
+				So(resp.Result[0].CronExpression, ShouldEqual, "@daily")
+			})
+
+			Convey("When updating the downlink schedule", func() {
+				_, err := api.Update(ctx, &pb.UpdateDownlinkScheduleRequest{
+					DownlinkSchedule: &pb.DownlinkSchedule{
+						Id:             createResp.Id,
+						Name:           "test-schedule-updated",
+						CronExpression: "*/15 * * * *",
+						ApplicationID:  app.ID,
+						Confirmed:      true,
+						FPort:          20,
+						Data:           []byte{4, 5, 6},
+					},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the downlink schedule has been updated", func() {
+					s, err := api.Get(ctx, &pb.GetDownlinkScheduleRequest{
+						Id: createResp.Id,
+					})
+					So(err, ShouldBeNil)
+					So(s.DownlinkSchedule.OrganizationID, ShouldEqual, org.ID)
+					So(s.DownlinkSchedule.Name, ShouldEqual, "test-schedule-updated")
+					So(s.DownlinkSchedule.CronExpression, ShouldEqual, "*/15 * * * *")
+					So(s.DownlinkSchedule.Confirmed, ShouldBeTrue)
+					So(s.DownlinkSchedule.FPort, ShouldEqual, 20)
+					So(s.DownlinkSchedule.Data, ShouldResemble, []byte{4, 5, 6})
+					So(s.DownlinkSchedule.JsonObject, ShouldEqual, "")
+				})
+			})
+
+			Convey("When deleting the downlink schedule", func() {
+				_, err := api.Delete(ctx, &pb.DeleteDownlinkScheduleRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the downlink schedule has been deleted", func() {
+					_, err := api.Get(ctx, &pb.GetDownlinkScheduleRequest{
+						Id: createResp.Id,
+					})
+					So(grpc.Code(err), ShouldEqual, codes.NotFound)
+				})
+			})
+		})
+	})
+}
@@ -14,6 +14,29 @@ var errToCode = map[error]codes.Code{
 	storage.ErrDoesNotExist:                    codes.NotFound,
 	storage.ErrUsedByOtherObjects:              codes.FailedPrecondition,
 	storage.ErrApplicationInvalidName:          codes.InvalidArgument,
+	storage.ErrInvalidWASMModule:               codes.InvalidArgument,
+	storage.ErrCodecInvalidName:                codes.InvalidArgument,
+	storage.ErrCodecInvalidType:                codes.InvalidArgument,
+	storage.ErrCodecInvalidReference:           codes.InvalidArgument,
+	storage.ErrDeviceProfileInvalidClockSync:   codes.InvalidArgument,
+	storage.ErrInvalidFieldsMetadata:           codes.InvalidArgument,
+	storage.ErrInvalidDownlinkSchedule:         codes.InvalidArgument,
+	storage.ErrDownlinkRetryPolicyInvalid:      codes.InvalidArgument,
+	storage.ErrDuplicateDownlink:               codes.AlreadyExists,
+	storage.ErrDeviceShadowInvalid:             codes.InvalidArgument,
+	storage.ErrDownlinkScheduleInvalidName:     codes.InvalidArgument,
+	storage.ErrDownlinkScheduleInvalidCron:     codes.InvalidArgument,
+	storage.ErrDownlinkScheduleInvalidTarget:   codes.InvalidArgument,
+	storage.ErrDownlinkScheduleInvalidPayload:  codes.InvalidArgument,
+	storage.ErrMulticastGroupInvalidName:       codes.InvalidArgument,
+	storage.ErrMulticastGroupInvalidType:       codes.InvalidArgument,
+	storage.ErrMulticastGroupInvalidConfig:     codes.InvalidArgument,
+	storage.ErrMulticastGroupInvalidDevice:     codes.InvalidArgument,
+	storage.ErrMulticastGroupNoMcKey:           codes.FailedPrecondition,
+	storage.ErrFirmwareInvalid:                 codes.InvalidArgument,
+	storage.ErrFUOTACampaignInvalidName:        codes.InvalidArgument,
+	storage.ErrFUOTACampaignInvalidConfig:      codes.InvalidArgument,
+	storage.ErrFUOTACampaignInvalidTarget:      codes.InvalidArgument,
 	storage.ErrNodeInvalidName:                 codes.InvalidArgument,
 	storage.ErrNodeMaxRXDelay:                  codes.InvalidArgument,
 	storage.ErrCFListTooManyChannels:           codes.InvalidArgument,
@@ -22,6 +45,13 @@ var errToCode = map[error]codes.Code{
 	storage.ErrInvalidUsernameOrPassword:       codes.Unauthenticated,
 	storage.ErrInvalidEmail:                    codes.InvalidArgument,
 	storage.ErrInvalidGatewayDiscoveryInterval: codes.InvalidArgument,
+	storage.ErrInvalidNetID:                    codes.InvalidArgument,
+	storage.ErrInvalidClaimCode:                codes.InvalidArgument,
+	storage.ErrClaimCodeLength:                 codes.InvalidArgument,
+	storage.ErrClaimNetworkServerMismatch:      codes.FailedPrecondition,
+	storage.ErrClaimOrganizationMismatch:       codes.FailedPrecondition,
+	storage.ErrClaimRateLimited:                codes.ResourceExhausted,
+	storage.ErrDeviceInvalidTags:               codes.InvalidArgument,
 	httphandler.ErrInvalidHeaderName:           codes.InvalidArgument,
 	influxdbhandler.ErrInvalidPrecision:        codes.InvalidArgument,
 }
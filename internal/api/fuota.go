@@ -0,0 +1,351 @@
+package api
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// FUOTAAPI exports the firmware and FUOTA campaign related functions.
+type FUOTAAPI struct {
+	validator auth.Validator
+}
+
+// NewFUOTAAPI creates a new FUOTAAPI.
+func NewFUOTAAPI(validator auth.Validator) *FUOTAAPI {
+	return &FUOTAAPI{
+		validator: validator,
+	}
+}
+
+// CreateFirmware uploads the given firmware image.
+func (a *FUOTAAPI) CreateFirmware(ctx context.Context, req *pb.CreateFirmwareRequest) (*pb.CreateFirmwareResponse, error) {
+	if req.Firmware == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "firmware expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFirmwaresAccess(auth.Create, req.Firmware.OrganizationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	fw := storage.Firmware{
+		OrganizationID: req.Firmware.OrganizationID,
+		Name:           req.Firmware.Name,
+		Data:           req.Data,
+	}
+
+	if err := storage.CreateFirmware(config.C.PostgreSQL.DB, &fw); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateFirmwareResponse{
+		Id: fw.ID,
+	}, nil
+}
+
+// GetFirmware returns the firmware matching the given id.
+func (a *FUOTAAPI) GetFirmware(ctx context.Context, req *pb.GetFirmwareRequest) (*pb.GetFirmwareResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFirmwareAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	fw, err := storage.GetFirmware(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.GetFirmwareResponse{
+		Firmware: &pb.Firmware{
+			Id:             fw.ID,
+			Name:           fw.Name,
+			OrganizationID: fw.OrganizationID,
+		},
+		Size:      int64(len(fw.Data)),
+		CreatedAt: fw.CreatedAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// DeleteFirmware deletes the firmware matching the given id.
+func (a *FUOTAAPI) DeleteFirmware(ctx context.Context, req *pb.DeleteFirmwareRequest) (*pb.DeleteFirmwareResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFirmwareAccess(auth.Delete, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteFirmware(config.C.PostgreSQL.DB, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteFirmwareResponse{}, nil
+}
+
+// ListFirmwares returns the firmwares of the given organization.
+func (a *FUOTAAPI) ListFirmwares(ctx context.Context, req *pb.ListFirmwareRequest) (*pb.ListFirmwareResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFirmwaresAccess(auth.List, req.OrganizationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetFirmwareCountForOrganizationID(config.C.PostgreSQL.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	items, err := storage.GetFirmwaresForOrganizationID(config.C.PostgreSQL.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := pb.ListFirmwareResponse{
+		TotalCount: int64(count),
+	}
+
+	for _, fw := range items {
+		out.Result = append(out.Result, &pb.FirmwareListItem{
+			Id:             fw.ID,
+			Name:           fw.Name,
+			OrganizationID: fw.OrganizationID,
+			Size:           int64(fw.Size),
+			CreatedAt:      fw.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &out, nil
+}
+
+// CreateCampaign creates the given FUOTA campaign.
+func (a *FUOTAAPI) CreateCampaign(ctx context.Context, req *pb.CreateFUOTACampaignRequest) (*pb.CreateFUOTACampaignResponse, error) {
+	if req.Campaign == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "campaign expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFUOTACampaignsAccess(auth.Create, req.Campaign.ApplicationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if req.Campaign.FragIndex > 255 || req.Campaign.FragSize > 255 || req.Campaign.BlockAckDelay > 255 {
+		return nil, errToRPCError(storage.ErrFUOTACampaignInvalidConfig)
+	}
+
+	c := storage.FUOTACampaign{
+		ApplicationID: req.Campaign.ApplicationID,
+		FirmwareID:    req.Campaign.FirmwareID,
+		Name:          req.Campaign.Name,
+		FragIndex:     uint8(req.Campaign.FragIndex),
+		FragSize:      uint8(req.Campaign.FragSize),
+		Redundancy:    int(req.Campaign.Redundancy),
+		BlockAckDelay: uint8(req.Campaign.BlockAckDelay),
+		StepTimeout:   int(req.Campaign.StepTimeout),
+	}
+
+	if req.Campaign.MulticastGroupID != 0 {
+		c.MulticastGroupID = &req.Campaign.MulticastGroupID
+	}
+
+	var err error
+	c.Descriptor, err = hex.DecodeString(req.Campaign.FileDescriptor)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fileDescriptor: %s", err)
+	}
+
+	for _, s := range req.Campaign.DevEUIs {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(s)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+		}
+		c.DevEUIs = append(c.DevEUIs, devEUI)
+	}
+
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.CreateFUOTACampaign(tx, &c)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateFUOTACampaignResponse{
+		Id: c.ID,
+	}, nil
+}
+
+// GetCampaign returns the FUOTA campaign matching the given id, including
+// its state and progress.
+func (a *FUOTAAPI) GetCampaign(ctx context.Context, req *pb.GetFUOTACampaignRequest) (*pb.GetFUOTACampaignResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFUOTACampaignAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	c, err := storage.GetFUOTACampaign(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	devices, err := storage.GetFUOTACampaignDevices(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetFUOTACampaignResponse{
+		Campaign: &pb.FUOTACampaign{
+			Id:             c.ID,
+			Name:           c.Name,
+			ApplicationID:  c.ApplicationID,
+			FirmwareID:     c.FirmwareID,
+			FragIndex:      uint32(c.FragIndex),
+			FragSize:       uint32(c.FragSize),
+			Redundancy:     uint32(c.Redundancy),
+			BlockAckDelay:  uint32(c.BlockAckDelay),
+			FileDescriptor: hex.EncodeToString(c.Descriptor),
+			StepTimeout:    uint32(c.StepTimeout),
+		},
+		State:       fuotaCampaignStateToPB(c.State),
+		NextStepAt:  c.NextStepAt.Format(time.RFC3339Nano),
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   c.UpdatedAt.Format(time.RFC3339Nano),
+		CompletedAt: formatTimePtr(c.CompletedAt),
+	}
+
+	if c.MulticastGroupID != nil {
+		resp.Campaign.MulticastGroupID = *c.MulticastGroupID
+	}
+
+	for _, devEUI := range c.DevEUIs {
+		resp.Campaign.DevEUIs = append(resp.Campaign.DevEUIs, devEUI.String())
+	}
+
+	for _, d := range devices {
+		if d.SetupCompletedAt != nil {
+			resp.SetupCompletedCount++
+		}
+		if d.StatusReceivedAt != nil {
+			resp.StatusReceivedCount++
+			if d.MissingFrag != nil && *d.MissingFrag == 0 && d.LastError == "" {
+				resp.CompletedCount++
+			}
+		}
+		if d.LastError != "" {
+			resp.ErrorCount++
+		}
+	}
+
+	return &resp, nil
+}
+
+// DeleteCampaign deletes the FUOTA campaign matching the given id.
+func (a *FUOTAAPI) DeleteCampaign(ctx context.Context, req *pb.DeleteFUOTACampaignRequest) (*pb.DeleteFUOTACampaignResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFUOTACampaignAccess(auth.Delete, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteFUOTACampaign(config.C.PostgreSQL.DB, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteFUOTACampaignResponse{}, nil
+}
+
+// ListCampaigns returns the FUOTA campaigns of the given application.
+func (a *FUOTAAPI) ListCampaigns(ctx context.Context, req *pb.ListFUOTACampaignRequest) (*pb.ListFUOTACampaignResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFUOTACampaignsAccess(auth.List, req.ApplicationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetFUOTACampaignCountForApplicationID(config.C.PostgreSQL.DB, req.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	campaigns, err := storage.GetFUOTACampaignsForApplicationID(config.C.PostgreSQL.DB, req.ApplicationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := pb.ListFUOTACampaignResponse{
+		TotalCount: int64(count),
+	}
+
+	for _, c := range campaigns {
+		out.Result = append(out.Result, &pb.FUOTACampaignListItem{
+			Id:            c.ID,
+			Name:          c.Name,
+			ApplicationID: c.ApplicationID,
+			FirmwareID:    c.FirmwareID,
+			State:         fuotaCampaignStateToPB(c.State),
+			CreatedAt:     c.CreatedAt.Format(time.RFC3339Nano),
+			CompletedAt:   formatTimePtr(c.CompletedAt),
+		})
+	}
+
+	return &out, nil
+}
+
+// ListCampaignDevices returns the devices of the FUOTA campaign, including
+// their fragmentation session state.
+func (a *FUOTAAPI) ListCampaignDevices(ctx context.Context, req *pb.ListFUOTACampaignDevicesRequest) (*pb.ListFUOTACampaignDevicesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateFUOTACampaignAccess(auth.Read, req.FuotaCampaignID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	devices, err := storage.GetFUOTACampaignDevices(config.C.PostgreSQL.DB, req.FuotaCampaignID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListFUOTACampaignDevicesResponse
+	for _, d := range devices {
+		item := pb.FUOTACampaignDevice{
+			DevEUI:           d.DevEUI.String(),
+			SetupCompletedAt: formatTimePtr(d.SetupCompletedAt),
+			StatusReceivedAt: formatTimePtr(d.StatusReceivedAt),
+			LastError:        d.LastError,
+		}
+		if d.NbFragReceived != nil {
+			item.NbFragReceived = uint32(*d.NbFragReceived)
+		}
+		if d.MissingFrag != nil {
+			item.MissingFrag = uint32(*d.MissingFrag)
+		}
+		out.Result = append(out.Result, &item)
+	}
+
+	return &out, nil
+}
+
+func fuotaCampaignStateToPB(s storage.FUOTACampaignState) pb.FUOTACampaignState {
+	switch s {
+	case storage.FUOTACampaignFragments:
+		return pb.FUOTACampaignState_FRAGMENTS
+	case storage.FUOTACampaignStatus:
+		return pb.FUOTACampaignState_STATUS
+	case storage.FUOTACampaignDone:
+		return pb.FUOTACampaignState_DONE
+	default:
+		return pb.FUOTACampaignState_SETUP
+	}
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestFUOTAAPI(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nsClient := test.NewNetworkServerClient()
+
+	config.C.PostgreSQL.DB = db
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization, application, device and an api instance", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		ctx := context.Background()
+		validator := &TestValidator{}
+		api := NewFUOTAAPI(validator)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		device := storage.Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(storage.CreateDevice(config.C.PostgreSQL.DB, &device), ShouldBeNil)
+
+		Convey("When creating a firmware without data", func() {
+			_, err := api.CreateFirmware(ctx, &pb.CreateFirmwareRequest{
+				Firmware: &pb.Firmware{
+					Name:           "firmware-v1",
+					OrganizationID: org.ID,
+				},
+			})
+
+			Convey("Then an invalid argument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When creating a firmware", func() {
+			fwResp, err := api.CreateFirmware(ctx, &pb.CreateFirmwareRequest{
+				Firmware: &pb.Firmware{
+					Name:           "firmware-v1",
+					OrganizationID: org.ID,
+				},
+				Data: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			})
+			So(err, ShouldBeNil)
+			So(fwResp.Id, ShouldBeGreaterThan, 0)
+
+			Convey("Then it can be retrieved", func() {
+				fw, err := api.GetFirmware(ctx, &pb.GetFirmwareRequest{Id: fwResp.Id})
+				So(err, ShouldBeNil)
+				So(fw.Firmware, ShouldResemble, &pb.Firmware{
+					Id:             fwResp.Id,
+					Name:           "firmware-v1",
+					OrganizationID: org.ID,
+				})
+				So(fw.Size, ShouldEqual, 10)
+			})
+
+			Convey("Then it is returned by list", func() {
+				list, err := api.ListFirmwares(ctx, &pb.ListFirmwareRequest{
+					OrganizationID: org.ID,
+					Limit:          10,
+				})
+				So(err, ShouldBeNil)
+				So(list.TotalCount, ShouldEqual, 1)
+				So(list.Result, ShouldHaveLength, 1)
+				So(list.Result[0].Size, ShouldEqual, 10)
+			})
+
+			Convey("Then it can be deleted", func() {
+				_, err := api.DeleteFirmware(ctx, &pb.DeleteFirmwareRequest{Id: fwResp.Id})
+				So(err, ShouldBeNil)
+
+				_, err = api.GetFirmware(ctx, &pb.GetFirmwareRequest{Id: fwResp.Id})
+				So(grpc.Code(err), ShouldEqual, codes.NotFound)
+			})
+
+			Convey("When creating a campaign with an invalid descriptor", func() {
+				_, err := api.CreateCampaign(ctx, &pb.CreateFUOTACampaignRequest{
+					Campaign: &pb.FUOTACampaign{
+						Name:           "test-campaign",
+						ApplicationID:  app.ID,
+						FirmwareID:     fwResp.Id,
+						FragSize:       4,
+						FileDescriptor: "0102",
+						StepTimeout:    60,
+						DevEUIs:        []string{device.DevEUI.String()},
+					},
+				})
+
+				Convey("Then an invalid argument error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
+			Convey("When creating a campaign", func() {
+				resp, err := api.CreateCampaign(ctx, &pb.CreateFUOTACampaignRequest{
+					Campaign: &pb.FUOTACampaign{
+						Name:           "test-campaign",
+						ApplicationID:  app.ID,
+						FirmwareID:     fwResp.Id,
+						FragIndex:      1,
+						FragSize:       4,
+						Redundancy:     2,
+						BlockAckDelay:  3,
+						FileDescriptor: "01020304",
+						StepTimeout:    60,
+						DevEUIs:        []string{device.DevEUI.String()},
+					},
+				})
+				So(err, ShouldBeNil)
+				So(resp.Id, ShouldBeGreaterThan, 0)
+
+				Convey("Then it can be retrieved with its state and progress", func() {
+					c, err := api.GetCampaign(ctx, &pb.GetFUOTACampaignRequest{Id: resp.Id})
+					So(err, ShouldBeNil)
+					So(c.Campaign, ShouldResemble, &pb.FUOTACampaign{
+						Id:             resp.Id,
+						Name:           "test-campaign",
+						ApplicationID:  app.ID,
+						FirmwareID:     fwResp.Id,
+						FragIndex:      1,
+						FragSize:       4,
+						Redundancy:     2,
+						BlockAckDelay:  3,
+						FileDescriptor: "01020304",
+						StepTimeout:    60,
+						DevEUIs:        []string{device.DevEUI.String()},
+					})
+					So(c.State, ShouldEqual, pb.FUOTACampaignState_SETUP)
+					So(c.SetupCompletedCount, ShouldEqual, 0)
+					So(c.CompletedAt, ShouldEqual, "")
+				})
+
+				Convey("Then the progress reflects the device state", func() {
+					devices, err := storage.GetFUOTACampaignDevices(config.C.PostgreSQL.DB, resp.Id)
+					So(err, ShouldBeNil)
+					So(devices, ShouldHaveLength, 1)
+
+					now := devices[0].CreatedAt
+					nbFrag := 5
+					missing := 0
+					devices[0].SetupCompletedAt = &now
+					devices[0].StatusReceivedAt = &now
+					devices[0].NbFragReceived = &nbFrag
+					devices[0].MissingFrag = &missing
+					So(storage.UpdateFUOTACampaignDevice(config.C.PostgreSQL.DB, devices[0]), ShouldBeNil)
+
+					c, err := api.GetCampaign(ctx, &pb.GetFUOTACampaignRequest{Id: resp.Id})
+					So(err, ShouldBeNil)
+					So(c.SetupCompletedCount, ShouldEqual, 1)
+					So(c.StatusReceivedCount, ShouldEqual, 1)
+					So(c.CompletedCount, ShouldEqual, 1)
+					So(c.ErrorCount, ShouldEqual, 0)
+
+					list, err := api.ListCampaignDevices(ctx, &pb.ListFUOTACampaignDevicesRequest{FuotaCampaignID: resp.Id})
+					So(err, ShouldBeNil)
+					So(list.Result, ShouldHaveLength, 1)
+					So(list.Result[0].DevEUI, ShouldEqual, device.DevEUI.String())
+					So(list.Result[0].NbFragReceived, ShouldEqual, 5)
+				})
+
+				Convey("Then it is returned by list", func() {
+					list, err := api.ListCampaigns(ctx, &pb.ListFUOTACampaignRequest{
+						ApplicationID: app.ID,
+						Limit:         10,
+					})
+					So(err, ShouldBeNil)
+					So(list.TotalCount, ShouldEqual, 1)
+					So(list.Result, ShouldHaveLength, 1)
+					So(list.Result[0].Id, ShouldEqual, resp.Id)
+					So(list.Result[0].State, ShouldEqual, pb.FUOTACampaignState_SETUP)
+				})
+
+				Convey("Then the firmware can not be deleted", func() {
+					_, err := api.DeleteFirmware(ctx, &pb.DeleteFirmwareRequest{Id: fwResp.Id})
+					So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+				})
+
+				Convey("Then it can be deleted", func() {
+					_, err := api.DeleteCampaign(ctx, &pb.DeleteFUOTACampaignRequest{Id: resp.Id})
+					So(err, ShouldBeNil)
+
+					_, err = api.GetCampaign(ctx, &pb.GetFUOTACampaignRequest{Id: resp.Id})
+					So(grpc.Code(err), ShouldEqual, codes.NotFound)
+				})
+			})
+		})
+	})
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/satori/go.uuid"
 )
 
 // GatewayAPI exports the Gateway related functions.
@@ -48,13 +51,17 @@ func (a *GatewayAPI) Create(ctx context.Context, req *pb.CreateGatewayRequest) (
 	}
 
 	createReq := ns.CreateGatewayRequest{
-		Mac:              mac[:],
-		Name:             req.Name,
-		Description:      req.Description,
-		Latitude:         req.Latitude,
-		Longitude:        req.Longitude,
-		Altitude:         req.Altitude,
-		GatewayProfileID: req.GatewayProfileID,
+		Gateway: &ns.Gateway{
+			Id: mac[:],
+			Location: &common.Location{
+				Latitude:  req.Latitude,
+				Longitude: req.Longitude,
+				Altitude:  req.Altitude,
+			},
+		},
+	}
+	if req.GatewayProfileID != "" {
+		createReq.Gateway.GatewayProfileId = uuid.FromStringOrNil(req.GatewayProfileID).Bytes()
 	}
 
 	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
@@ -122,29 +129,46 @@ func (a *GatewayAPI) Get(ctx context.Context, req *pb.GetGatewayRequest) (*pb.Ge
 	}
 
 	getResp, err := nsClient.GetGateway(ctx, &ns.GetGatewayRequest{
-		Mac: mac[:],
+		Id: mac[:],
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	ret := &pb.GetGatewayResponse{
-		Mac:              mac.String(),
-		Name:             gw.Name,
-		Description:      gw.Description,
-		OrganizationID:   gw.OrganizationID,
-		Ping:             gw.Ping,
-		Latitude:         getResp.Latitude,
-		Longitude:        getResp.Longitude,
-		Altitude:         getResp.Altitude,
-		CreatedAt:        gw.CreatedAt.Format(time.RFC3339Nano),
-		UpdatedAt:        gw.UpdatedAt.Format(time.RFC3339Nano),
-		FirstSeenAt:      getResp.FirstSeenAt,
-		LastSeenAt:       getResp.LastSeenAt,
-		GatewayProfileID: getResp.GatewayProfileID,
-		NetworkServerID:  gw.NetworkServerID,
-	}
-	return ret, err
+		Mac:             mac.String(),
+		Name:            gw.Name,
+		Description:     gw.Description,
+		OrganizationID:  gw.OrganizationID,
+		Ping:            gw.Ping,
+		CreatedAt:       gw.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:       gw.UpdatedAt.Format(time.RFC3339Nano),
+		NetworkServerID: gw.NetworkServerID,
+	}
+
+	if loc := getResp.GetGateway().GetLocation(); loc != nil {
+		ret.Latitude = loc.Latitude
+		ret.Longitude = loc.Longitude
+		ret.Altitude = loc.Altitude
+	}
+
+	if gpID := getResp.GetGateway().GetGatewayProfileId(); len(gpID) != 0 {
+		ret.GatewayProfileID = uuid.FromBytesOrNil(gpID).String()
+	}
+
+	if getResp.FirstSeenAt != nil {
+		if ts, err := ptypes.Timestamp(getResp.FirstSeenAt); err == nil {
+			ret.FirstSeenAt = ts.Format(time.RFC3339Nano)
+		}
+	}
+
+	if getResp.LastSeenAt != nil {
+		if ts, err := ptypes.Timestamp(getResp.LastSeenAt); err == nil {
+			ret.LastSeenAt = ts.Format(time.RFC3339Nano)
+		}
+	}
+
+	return ret, nil
 }
 
 // List lists the gateways.
@@ -255,13 +279,17 @@ func (a *GatewayAPI) Update(ctx context.Context, req *pb.UpdateGatewayRequest) (
 		}
 
 		updateReq := ns.UpdateGatewayRequest{
-			Mac:              mac[:],
-			Name:             req.Name,
-			Description:      req.Description,
-			Latitude:         req.Latitude,
-			Longitude:        req.Longitude,
-			Altitude:         req.Altitude,
-			GatewayProfileID: req.GatewayProfileID,
+			Gateway: &ns.Gateway{
+				Id: mac[:],
+				Location: &common.Location{
+					Latitude:  req.Latitude,
+					Longitude: req.Longitude,
+					Altitude:  req.Altitude,
+				},
+			},
+		}
+		if req.GatewayProfileID != "" {
+			updateReq.Gateway.GatewayProfileId = uuid.FromStringOrNil(req.GatewayProfileID).Bytes()
 		}
 
 		n, err := storage.GetNetworkServer(tx, gw.NetworkServerID)
@@ -346,11 +374,31 @@ func (a *GatewayAPI) GetStats(ctx context.Context, req *pb.GetGatewayStatsReques
 		return nil, grpc.Errorf(codes.InvalidArgument, "bad interval: %s", req.Interval)
 	}
 
+	start, err := time.Parse(time.RFC3339Nano, req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "startTimestamp: %s", err)
+	}
+
+	end, err := time.Parse(time.RFC3339Nano, req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "endTimestamp: %s", err)
+	}
+
+	startPB, err := ptypes.TimestampProto(start)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "startTimestamp: %s", err)
+	}
+
+	endPB, err := ptypes.TimestampProto(end)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "endTimestamp: %s", err)
+	}
+
 	statsReq := ns.GetGatewayStatsRequest{
-		Mac:            mac[:],
+		GatewayId:      mac[:],
 		Interval:       ns.AggregationInterval(interval),
-		StartTimestamp: req.StartTimestamp,
-		EndTimestamp:   req.EndTimestamp,
+		StartTimestamp: startPB,
+		EndTimestamp:   endPB,
 	}
 	stats, err := nsClient.GetGatewayStats(ctx, &statsReq)
 	if err != nil {
@@ -359,10 +407,15 @@ func (a *GatewayAPI) GetStats(ctx context.Context, req *pb.GetGatewayStatsReques
 
 	result := make([]*pb.GatewayStats, len(stats.Result))
 	for i, stat := range stats.Result {
+		ts, err := ptypes.Timestamp(stat.Timestamp)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
 		result[i] = &pb.GatewayStats{
-			Timestamp:           stat.Timestamp,
+			Timestamp:           ts.Format(time.RFC3339Nano),
 			RxPacketsReceived:   stat.RxPacketsReceived,
-			RxPacketsReceivedOK: stat.RxPacketsReceivedOK,
+			RxPacketsReceivedOK: stat.RxPacketsReceivedOk,
 			TxPacketsReceived:   stat.TxPacketsReceived,
 			TxPacketsEmitted:    stat.TxPacketsEmitted,
 		}
@@ -435,7 +488,7 @@ func (a *GatewayAPI) StreamFrameLogs(req *pb.StreamGatewayFrameLogsRequest, srv
 	}
 
 	streamClient, err := nsClient.StreamFrameLogsForGateway(srv.Context(), &ns.StreamFrameLogsForGatewayRequest{
-		Mac: mac[:],
+		GatewayId: mac[:],
 	})
 	if err != nil {
 		return err
@@ -447,7 +500,7 @@ func (a *GatewayAPI) StreamFrameLogs(req *pb.StreamGatewayFrameLogsRequest, srv
 			return err
 		}
 
-		up, down, err := convertUplinkAndDownlinkFrames(resp.UplinkFrames, resp.DownlinkFrames)
+		up, down, err := convertUplinkAndDownlinkFrames(resp.GetUplinkFrameSet(), resp.GetDownlinkFrame())
 		if err != nil {
 			return errToRPCError(err)
 		}
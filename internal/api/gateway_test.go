@@ -8,12 +8,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/smartystreets/goconvey/convey"
 
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan"
 )
@@ -51,17 +53,22 @@ func TestGatewayAPI(t *testing.T) {
 		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org2), ShouldBeNil)
 
 		now := time.Now().UTC()
+		firstSeenAt, err := ptypes.TimestampProto(now.Add(2 * time.Second))
+		So(err, ShouldBeNil)
+		lastSeenAt, err := ptypes.TimestampProto(now.Add(3 * time.Second))
+		So(err, ShouldBeNil)
+
 		getGatewayResponseNS := ns.GetGatewayResponse{
-			Mac:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
-			Name:        "test-gateway",
-			Description: "test gateway",
-			Latitude:    1.1234,
-			Longitude:   1.1235,
-			Altitude:    5.5,
-			CreatedAt:   now.UTC().Format(time.RFC3339Nano),
-			UpdatedAt:   now.UTC().Add(1 * time.Second).Format(time.RFC3339Nano),
-			FirstSeenAt: now.UTC().Add(2 * time.Second).Format(time.RFC3339Nano),
-			LastSeenAt:  now.UTC().Add(3 * time.Second).Format(time.RFC3339Nano),
+			Gateway: &ns.Gateway{
+				Id: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Location: &common.Location{
+					Latitude:  1.1234,
+					Longitude: 1.1235,
+					Altitude:  5.5,
+				},
+			},
+			FirstSeenAt: firstSeenAt,
+			LastSeenAt:  lastSeenAt,
 		}
 
 		getGatewayResponseAS := pb.GetGatewayResponse{
@@ -97,12 +104,14 @@ func TestGatewayAPI(t *testing.T) {
 			Convey("Then the correct request was forwarded to the network-server api", func() {
 				So(nsClient.CreateGatewayChan, ShouldHaveLength, 1)
 				So(<-nsClient.CreateGatewayChan, ShouldResemble, ns.CreateGatewayRequest{
-					Mac:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
-					Name:        "test-gateway",
-					Description: "test gateway",
-					Latitude:    1.1234,
-					Longitude:   1.1235,
-					Altitude:    5.5,
+					Gateway: &ns.Gateway{
+						Id: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Location: &common.Location{
+							Latitude:  1.1234,
+							Longitude: 1.1235,
+							Altitude:  5.5,
+						},
+					},
 				})
 			})
 
@@ -131,7 +140,7 @@ func TestGatewayAPI(t *testing.T) {
 				Convey("Then the correct network-server request was made", func() {
 					So(nsClient.GetGatewayChan, ShouldHaveLength, 1)
 					So(<-nsClient.GetGatewayChan, ShouldResemble, ns.GetGatewayRequest{
-						Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Id: []byte{1, 2, 3, 4, 5, 6, 7, 8},
 					})
 				})
 			})
@@ -235,12 +244,14 @@ func TestGatewayAPI(t *testing.T) {
 				Convey("Then the expected request was sent to the network-server", func() {
 					So(nsClient.UpdateGatewayChan, ShouldHaveLength, 1)
 					So(<-nsClient.UpdateGatewayChan, ShouldResemble, ns.UpdateGatewayRequest{
-						Mac:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
-						Name:        "test-gateway-updated",
-						Description: "updated test gateway",
-						Latitude:    1.1235,
-						Longitude:   1.1236,
-						Altitude:    5.7,
+						Gateway: &ns.Gateway{
+							Id: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+							Location: &common.Location{
+								Latitude:  1.1235,
+								Longitude: 1.1236,
+								Altitude:  5.7,
+							},
+						},
 					})
 				})
 			})
@@ -271,12 +282,14 @@ func TestGatewayAPI(t *testing.T) {
 				Convey("Then the expected request was sent to the network-server", func() {
 					So(nsClient.UpdateGatewayChan, ShouldHaveLength, 1)
 					So(<-nsClient.UpdateGatewayChan, ShouldResemble, ns.UpdateGatewayRequest{
-						Mac:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
-						Name:        "test-gateway-updated",
-						Description: "updated test gateway",
-						Latitude:    1.1235,
-						Longitude:   1.1236,
-						Altitude:    5.7,
+						Gateway: &ns.Gateway{
+							Id: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+							Location: &common.Location{
+								Latitude:  1.1235,
+								Longitude: 1.1236,
+								Altitude:  5.7,
+							},
+						},
 					})
 				})
 			})
@@ -300,20 +313,24 @@ func TestGatewayAPI(t *testing.T) {
 				Convey("Then the expected request was sent to the network-server", func() {
 					So(nsClient.DeleteGatewayChan, ShouldHaveLength, 1)
 					So(<-nsClient.DeleteGatewayChan, ShouldResemble, ns.DeleteGatewayRequest{
-						Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Id: []byte{1, 2, 3, 4, 5, 6, 7, 8},
 					})
 				})
 			})
 
 			Convey("When calling GetStats", func() {
 				now := time.Now().UTC()
+				nowPB, err := ptypes.TimestampProto(now)
+				So(err, ShouldBeNil)
+				endPB, err := ptypes.TimestampProto(now.Add(24 * time.Hour))
+				So(err, ShouldBeNil)
 
 				nsClient.GetGatewayStatsResponse = ns.GetGatewayStatsResponse{
 					Result: []*ns.GatewayStats{
 						{
-							Timestamp:           now.Format(time.RFC3339Nano),
+							Timestamp:           nowPB,
 							RxPacketsReceived:   10,
-							RxPacketsReceivedOK: 9,
+							RxPacketsReceivedOk: 9,
 							TxPacketsReceived:   8,
 							TxPacketsEmitted:    7,
 						},
@@ -333,10 +350,10 @@ func TestGatewayAPI(t *testing.T) {
 				Convey("Then the correct request / response was forwarded to / from the network-server api", func() {
 					So(nsClient.GetGatewayStatsChan, ShouldHaveLength, 1)
 					So(<-nsClient.GetGatewayStatsChan, ShouldResemble, ns.GetGatewayStatsRequest{
-						Mac:            []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						GatewayId:      []byte{1, 2, 3, 4, 5, 6, 7, 8},
 						Interval:       ns.AggregationInterval_DAY,
-						StartTimestamp: now.Format(time.RFC3339Nano),
-						EndTimestamp:   now.Add(24 * time.Hour).Format(time.RFC3339Nano),
+						StartTimestamp: nowPB,
+						EndTimestamp:   endPB,
 					})
 
 					So(resp, ShouldResemble, &pb.GetGatewayStatsResponse{
@@ -5,13 +5,31 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/lora-app-server/internal/handler"
 	"github.com/brocaar/lora-app-server/internal/join"
+	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
 )
 
+// ulMetaData contains the uplink meta-data of a (re)join-request. This is not
+// part of the JoinReq message, but it can be included by the network-server
+// to provide the receiving gateways, using the ULMetaData format of the
+// PRStartReq message.
+type ulMetaData struct {
+	RecvTime *time.Time
+	GWInfo   []struct {
+		ID   backend.HEXBytes
+		RSSI *int
+		SNR  *float64
+		Lat  *float64
+		Lon  *float64
+	}
+}
+
 // JoinServerAPI implements the join-server API as documented in the LoRaWAN
 // backend interfaces specification.
 type JoinServerAPI struct{}
@@ -46,7 +64,13 @@ func (a *JoinServerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	switch basePL.MessageType {
 	case backend.JoinReq:
-		a.handleJoinReq(w, b)
+		a.handleJoinReq(w, b, getClientCN(r))
+	case backend.RejoinReq:
+		a.handleRejoinReq(w, b, getClientCN(r))
+	case backend.AppSKeyReq:
+		a.handleAppSKeyReq(w, b)
+	case backend.HomeNSReq:
+		a.handleHomeNSReq(w, b)
 	default:
 		a.returnError(w, http.StatusBadRequest, backend.Other, fmt.Sprintf("invalid MessageType: %s", basePL.MessageType))
 	}
@@ -84,7 +108,7 @@ func (a *JoinServerAPI) returnPayload(w http.ResponseWriter, code int, pl interf
 	w.Write(b)
 }
 
-func (a *JoinServerAPI) handleJoinReq(w http.ResponseWriter, b []byte) {
+func (a *JoinServerAPI) handleJoinReq(w http.ResponseWriter, b []byte, clientCN string) {
 	var joinReqPL backend.JoinReqPayload
 	err := json.Unmarshal(b, &joinReqPL)
 	if err != nil {
@@ -92,7 +116,7 @@ func (a *JoinServerAPI) handleJoinReq(w http.ResponseWriter, b []byte) {
 		return
 	}
 
-	ans := join.HandleJoinRequest(joinReqPL)
+	ans := join.HandleJoinRequest(joinReqPL, clientCN, getRXInfo(b))
 
 	log.WithFields(log.Fields{
 		"message_type":   ans.BasePayload.MessageType,
@@ -104,3 +128,117 @@ func (a *JoinServerAPI) handleJoinReq(w http.ResponseWriter, b []byte) {
 
 	a.returnPayload(w, http.StatusOK, ans)
 }
+
+func (a *JoinServerAPI) handleRejoinReq(w http.ResponseWriter, b []byte, clientCN string) {
+	// the rejoin-request contains the same fields as the join-request
+	var rejoinReqPL backend.JoinReqPayload
+	err := json.Unmarshal(b, &rejoinReqPL)
+	if err != nil {
+		a.returnError(w, http.StatusBadRequest, backend.Other, err.Error())
+		return
+	}
+
+	ans := join.HandleRejoinRequest(rejoinReqPL, clientCN, getRXInfo(b))
+
+	log.WithFields(log.Fields{
+		"message_type":   ans.BasePayload.MessageType,
+		"sender_id":      ans.BasePayload.SenderID,
+		"receiver_id":    ans.BasePayload.ReceiverID,
+		"transaction_id": ans.BasePayload.TransactionID,
+		"result_code":    ans.Result.ResultCode,
+	}).Info("js: sending response")
+
+	a.returnPayload(w, http.StatusOK, ans)
+}
+
+func (a *JoinServerAPI) handleAppSKeyReq(w http.ResponseWriter, b []byte) {
+	var appSKeyReqPL backend.AppSKeyReqPayload
+	err := json.Unmarshal(b, &appSKeyReqPL)
+	if err != nil {
+		a.returnError(w, http.StatusBadRequest, backend.Other, err.Error())
+		return
+	}
+
+	ans := join.HandleAppSKeyRequest(appSKeyReqPL)
+
+	log.WithFields(log.Fields{
+		"message_type":   ans.BasePayload.MessageType,
+		"sender_id":      ans.BasePayload.SenderID,
+		"receiver_id":    ans.BasePayload.ReceiverID,
+		"transaction_id": ans.BasePayload.TransactionID,
+		"result_code":    ans.Result.ResultCode,
+	}).Info("js: sending response")
+
+	a.returnPayload(w, http.StatusOK, ans)
+}
+
+func (a *JoinServerAPI) handleHomeNSReq(w http.ResponseWriter, b []byte) {
+	var homeNSReqPL backend.HomeNSReqPayload
+	err := json.Unmarshal(b, &homeNSReqPL)
+	if err != nil {
+		a.returnError(w, http.StatusBadRequest, backend.Other, err.Error())
+		return
+	}
+
+	ans := join.HandleHomeNSRequest(homeNSReqPL)
+
+	log.WithFields(log.Fields{
+		"message_type":   ans.BasePayload.MessageType,
+		"sender_id":      ans.BasePayload.SenderID,
+		"receiver_id":    ans.BasePayload.ReceiverID,
+		"transaction_id": ans.BasePayload.TransactionID,
+		"result_code":    ans.Result.ResultCode,
+	}).Info("js: sending response")
+
+	a.returnPayload(w, http.StatusOK, ans)
+}
+
+// getClientCN returns the common name of the (verified) TLS client
+// certificate of the request, or an empty string when the request was not
+// made using a client certificate.
+func getClientCN(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+	return r.TLS.PeerCertificates[0].Subject.CommonName
+}
+
+// getRXInfo returns the receiving gateways of the (re)join-request, when
+// included by the network-server.
+func getRXInfo(b []byte) []handler.RXInfo {
+	var pl struct {
+		ULMetaData *ulMetaData
+	}
+	if err := json.Unmarshal(b, &pl); err != nil {
+		log.WithError(err).Warning("js: unmarshal ULMetaData error")
+		return nil
+	}
+	if pl.ULMetaData == nil {
+		return nil
+	}
+
+	var out []handler.RXInfo
+	for _, gw := range pl.ULMetaData.GWInfo {
+		rxInfo := handler.RXInfo{
+			Time: pl.ULMetaData.RecvTime,
+		}
+		if len(gw.ID) == len(lorawan.EUI64{}) {
+			copy(rxInfo.MAC[:], gw.ID)
+		}
+		if gw.RSSI != nil {
+			rxInfo.RSSI = *gw.RSSI
+		}
+		if gw.SNR != nil {
+			rxInfo.LoRaSNR = *gw.SNR
+		}
+		if gw.Lat != nil {
+			rxInfo.Latitude = *gw.Lat
+		}
+		if gw.Lon != nil {
+			rxInfo.Longitude = *gw.Lon
+		}
+		out = append(out, rxInfo)
+	}
+
+	return out
+}
@@ -6,9 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/join"
 	"github.com/brocaar/lora-app-server/internal/test/testhandler"
 
 	"github.com/brocaar/lora-app-server/internal/storage"
@@ -43,6 +45,7 @@ func TestJoinServerAPI(t *testing.T) {
 		n := storage.NetworkServer{
 			Name:   "test-ns",
 			Server: "test-ns:1234",
+			NetID:  []byte{1, 2, 3},
 		}
 		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
 
@@ -88,7 +91,44 @@ func TestJoinServerAPI(t *testing.T) {
 			server := httptest.NewServer(&api)
 			defer server.Close()
 
+			Convey("When making a HomeNSReq call for an unknown device", func() {
+				homeNSReqPayload := backend.HomeNSReqPayload{
+					BasePayload: backend.BasePayload{
+						ProtocolVersion: backend.ProtocolVersion1_0,
+						SenderID:        "030201",
+						ReceiverID:      "0807060504030201",
+						TransactionID:   1236,
+						MessageType:     backend.HomeNSReq,
+					},
+					DevEUI: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				}
+				homeNSReqPayloadJSON, err := json.Marshal(homeNSReqPayload)
+				So(err, ShouldBeNil)
+
+				req, err := http.NewRequest("POST", server.URL, bytes.NewReader(homeNSReqPayloadJSON))
+				So(err, ShouldBeNil)
+
+				resp, err := http.DefaultClient.Do(req)
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+				Convey("Then UnknownDevEUI is returned", func() {
+					var homeNSAnsPayload backend.HomeNSAnsPayload
+					So(json.NewDecoder(resp.Body).Decode(&homeNSAnsPayload), ShouldBeNil)
+					So(homeNSAnsPayload.Result.ResultCode, ShouldEqual, backend.UnknownDevEUI)
+				})
+			})
+
 			Convey("When making a JoinReq call", func() {
+				cfList := lorawan.CFList{
+					CFListType: lorawan.CFListChannel,
+					Payload: &lorawan.CFListChannelPayload{
+						Channels: [5]uint32{868700000, 868900000},
+					},
+				}
+				cfListBytes, err := cfList.MarshalBinary()
+				So(err, ShouldBeNil)
+
 				jrPHY := lorawan.PHYPayload{
 					MHDR: lorawan.MHDR{
 						MType: lorawan.JoinRequest,
@@ -96,11 +136,11 @@ func TestJoinServerAPI(t *testing.T) {
 					},
 					MACPayload: &lorawan.JoinRequestPayload{
 						DevEUI:   d.DevEUI,
-						AppEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
-						DevNonce: lorawan.DevNonce{1, 2},
+						JoinEUI:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+						DevNonce: 258,
 					},
 				}
-				So(jrPHY.SetMIC(dk.AppKey), ShouldBeNil)
+				So(jrPHY.SetUplinkJoinMIC(dk.AppKey), ShouldBeNil)
 				jrPHYBytes, err := jrPHY.MarshalBinary()
 				So(err, ShouldBeNil)
 
@@ -110,18 +150,18 @@ func TestJoinServerAPI(t *testing.T) {
 						Major: lorawan.LoRaWANR1,
 					},
 					MACPayload: &lorawan.JoinAcceptPayload{
-						AppNonce: lorawan.AppNonce{1, 0, 0},
-						NetID:    lorawan.NetID{1, 2, 3},
-						DevAddr:  lorawan.DevAddr{1, 2, 3, 4},
+						JoinNonce: 1,
+						HomeNetID: lorawan.NetID{1, 2, 3},
+						DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
 						DLSettings: lorawan.DLSettings{
 							RX2DataRate: 5,
 							RX1DROffset: 1,
 						},
 						RXDelay: 1,
-						CFList:  &lorawan.CFList{868700000, 868900000},
+						CFList:  &cfList,
 					},
 				}
-				So(jaPHY.SetMIC(dk.AppKey), ShouldBeNil)
+				So(jaPHY.SetDownlinkJoinMIC(lorawan.JoinRequestType, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, 258, dk.AppKey), ShouldBeNil)
 				So(jaPHY.EncryptJoinAcceptPayload(dk.AppKey), ShouldBeNil)
 				jaPHYBytes, err := jaPHY.MarshalBinary()
 				So(err, ShouldBeNil)
@@ -143,7 +183,7 @@ func TestJoinServerAPI(t *testing.T) {
 						RX1DROffset: 1,
 					},
 					RxDelay: 1,
-					CFList:  &lorawan.CFList{868700000, 868900000},
+					CFList:  backend.HEXBytes(cfListBytes),
 				}
 				joinReqPayloadJSON, err := json.Marshal(joinReqPayload)
 				So(err, ShouldBeNil)
@@ -155,6 +195,9 @@ func TestJoinServerAPI(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(resp.StatusCode, ShouldEqual, http.StatusOK)
 
+				da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+				So(err, ShouldBeNil)
+
 				Convey("Then the expected response is returned", func() {
 					var joinAnsPayload backend.JoinAnsPayload
 					So(json.NewDecoder(resp.Body).Decode(&joinAnsPayload), ShouldBeNil)
@@ -171,8 +214,111 @@ func TestJoinServerAPI(t *testing.T) {
 						},
 						PHYPayload: backend.HEXBytes(jaPHYBytes),
 						NwkSKey: &backend.KeyEnvelope{
-							AESKey: lorawan.AES128Key{223, 83, 195, 95, 48, 52, 204, 206, 208, 255, 53, 76, 112, 222, 4, 223},
+							AESKey: backend.HEXBytes{223, 83, 195, 95, 48, 52, 204, 206, 208, 255, 53, 76, 112, 222, 4, 223},
 						},
+						SessionKeyID: backend.HEXBytes(da.SessionKeyID),
+					})
+				})
+
+				Convey("When making an AppSKeyReq call", func() {
+					appSKeyReqPayload := backend.AppSKeyReqPayload{
+						BasePayload: backend.BasePayload{
+							ProtocolVersion: backend.ProtocolVersion1_0,
+							SenderID:        "lora-app-server",
+							ReceiverID:      "0807060504030201",
+							TransactionID:   1235,
+							MessageType:     backend.AppSKeyReq,
+						},
+						DevEUI:       d.DevEUI,
+						SessionKeyID: backend.HEXBytes(da.SessionKeyID),
+					}
+					appSKeyReqPayloadJSON, err := json.Marshal(appSKeyReqPayload)
+					So(err, ShouldBeNil)
+
+					Convey("Given no KEK is configured for the application-server", func() {
+						req, err := http.NewRequest("POST", server.URL, bytes.NewReader(appSKeyReqPayloadJSON))
+						So(err, ShouldBeNil)
+
+						resp, err := http.DefaultClient.Do(req)
+						So(err, ShouldBeNil)
+						So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+						Convey("Then the AppSKey is not returned", func() {
+							var appSKeyAnsPayload backend.AppSKeyAnsPayload
+							So(json.NewDecoder(resp.Body).Decode(&appSKeyAnsPayload), ShouldBeNil)
+							So(appSKeyAnsPayload.Result.ResultCode, ShouldEqual, backend.Other)
+							So(appSKeyAnsPayload.AppSKey, ShouldBeNil)
+						})
+					})
+
+					Convey("Given a KEK is configured for the application-server", func() {
+						config.C.JoinServer.KEK.ASKEKLabel = "lora-app-server"
+						config.C.JoinServer.KEK.Set = []config.KEK{
+							{Label: "lora-app-server", KEK: "000102030405060708090a0b0c0d0e0f"},
+						}
+						defer func() {
+							config.C.JoinServer.KEK.ASKEKLabel = ""
+							config.C.JoinServer.KEK.Set = nil
+						}()
+
+						req, err := http.NewRequest("POST", server.URL, bytes.NewReader(appSKeyReqPayloadJSON))
+						So(err, ShouldBeNil)
+
+						resp, err := http.DefaultClient.Do(req)
+						So(err, ShouldBeNil)
+						So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+						Convey("Then the wrapped AppSKey is returned", func() {
+							var appSKeyAnsPayload backend.AppSKeyAnsPayload
+							So(json.NewDecoder(resp.Body).Decode(&appSKeyAnsPayload), ShouldBeNil)
+							So(appSKeyAnsPayload.Result.ResultCode, ShouldEqual, backend.Success)
+							So(appSKeyAnsPayload.MessageType, ShouldEqual, backend.AppSKeyAns)
+							So(appSKeyAnsPayload.AppSKey.KEKLabel, ShouldEqual, "lora-app-server")
+
+							appSKey, err := join.UnwrapKeyEnvelope(appSKeyAnsPayload.AppSKey)
+							So(err, ShouldBeNil)
+							So(appSKey, ShouldEqual, da.AppSKey)
+						})
+					})
+				})
+
+				Convey("When making a HomeNSReq call", func() {
+					homeNSReqPayload := backend.HomeNSReqPayload{
+						BasePayload: backend.BasePayload{
+							ProtocolVersion: backend.ProtocolVersion1_0,
+							SenderID:        "030201",
+							ReceiverID:      "0807060504030201",
+							TransactionID:   1236,
+							MessageType:     backend.HomeNSReq,
+						},
+						DevEUI: d.DevEUI,
+					}
+					homeNSReqPayloadJSON, err := json.Marshal(homeNSReqPayload)
+					So(err, ShouldBeNil)
+
+					req, err := http.NewRequest("POST", server.URL, bytes.NewReader(homeNSReqPayloadJSON))
+					So(err, ShouldBeNil)
+
+					resp, err := http.DefaultClient.Do(req)
+					So(err, ShouldBeNil)
+					So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+					Convey("Then the NetID of the home network-server is returned", func() {
+						var homeNSAnsPayload backend.HomeNSAnsPayload
+						So(json.NewDecoder(resp.Body).Decode(&homeNSAnsPayload), ShouldBeNil)
+						So(homeNSAnsPayload, ShouldResemble, backend.HomeNSAnsPayload{
+							BasePayload: backend.BasePayload{
+								ProtocolVersion: backend.ProtocolVersion1_0,
+								SenderID:        "0807060504030201",
+								ReceiverID:      "030201",
+								TransactionID:   1236,
+								MessageType:     backend.HomeNSAns,
+							},
+							Result: backend.Result{
+								ResultCode: backend.Success,
+							},
+							HNetID: lorawan.NetID{1, 2, 3},
+						})
 					})
 				})
 
@@ -190,3 +336,38 @@ func TestJoinServerAPI(t *testing.T) {
 		})
 	})
 }
+
+func TestGetRXInfo(t *testing.T) {
+	Convey("Given a join-request with ULMetaData", t, func() {
+		b := []byte(`{
+			"MessageType": "JoinReq",
+			"ULMetaData": {
+				"RecvTime": "2018-06-01T12:00:00Z",
+				"GWInfo": [
+					{"ID": "0102030405060708", "RSSI": -60, "SNR": 5.5, "Lat": 1.123, "Lon": 2.123}
+				]
+			}
+		}`)
+
+		Convey("Then getRXInfo returns the expected rx-info", func() {
+			recvTime := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+			rxInfo := getRXInfo(b)
+			So(rxInfo, ShouldHaveLength, 1)
+			So(*rxInfo[0].Time, ShouldHappenOnOrBetween, recvTime, recvTime)
+			rxInfo[0].Time = nil
+			So(rxInfo[0], ShouldResemble, handler.RXInfo{
+				MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				RSSI:      -60,
+				LoRaSNR:   5.5,
+				Latitude:  1.123,
+				Longitude: 2.123,
+			})
+		})
+	})
+
+	Convey("Given a join-request without ULMetaData", t, func() {
+		Convey("Then getRXInfo returns nil", func() {
+			So(getRXInfo([]byte(`{"MessageType": "JoinReq"}`)), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,443 @@
+package api
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/applayer/multicastsetup"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/multicast"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastGroupAPI exports the MulticastGroup related functions.
+type MulticastGroupAPI struct {
+	validator auth.Validator
+}
+
+// NewMulticastGroupAPI creates a new MulticastGroupAPI.
+func NewMulticastGroupAPI(validator auth.Validator) *MulticastGroupAPI {
+	return &MulticastGroupAPI{
+		validator: validator,
+	}
+}
+
+// Create creates the given multicast-group.
+func (a *MulticastGroupAPI) Create(ctx context.Context, req *pb.CreateMulticastGroupRequest) (*pb.CreateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicastGroup expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupsAccess(auth.Create, req.MulticastGroup.ApplicationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	mg := storage.MulticastGroup{
+		ApplicationID: req.MulticastGroup.ApplicationID,
+	}
+	if err := multicastGroupFromPB(req.MulticastGroup, &mg); err != nil {
+		return nil, err
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.CreateMulticastGroup(tx, &mg)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateMulticastGroupResponse{
+		Id: mg.ID,
+	}, nil
+}
+
+// Get returns the multicast-group matching the given id.
+func (a *MulticastGroupAPI) Get(ctx context.Context, req *pb.GetMulticastGroupRequest) (*pb.GetMulticastGroupResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Read, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	mg, err := storage.GetMulticastGroup(config.C.PostgreSQL.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetMulticastGroupResponse{
+		MulticastGroup: &pb.MulticastGroup{
+			Id:             mg.ID,
+			Name:           mg.Name,
+			ApplicationID:  mg.ApplicationID,
+			McAddr:         mg.McAddr.String(),
+			McAppSKey:      mg.McAppSKey.String(),
+			McNwkSKey:      mg.McNwkSKey.String(),
+			McGroupID:      uint32(mg.McGroupID),
+			GroupType:      multicastGroupTypeToPB(mg.GroupType),
+			Dr:             uint32(mg.DR),
+			Frequency:      uint32(mg.Frequency),
+			PingSlotPeriod: uint32(mg.PingSlotPeriod),
+		},
+		McKeyIsSet: mg.McKey != nil,
+		FCnt:       mg.FCnt,
+		CreatedAt:  mg.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:  mg.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	return &resp, nil
+}
+
+// Update updates the given multicast-group.
+func (a *MulticastGroupAPI) Update(ctx context.Context, req *pb.UpdateMulticastGroupRequest) (*pb.UpdateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicastGroup expected")
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Update, req.MulticastGroup.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		mg, err := storage.GetMulticastGroup(tx, req.MulticastGroup.Id)
+		if err != nil {
+			return errToRPCError(err)
+		}
+
+		if err := multicastGroupFromPB(req.MulticastGroup, &mg); err != nil {
+			return err
+		}
+
+		if err := storage.UpdateMulticastGroup(tx, &mg); err != nil {
+			return errToRPCError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateMulticastGroupResponse{}, nil
+}
+
+// Delete deletes the multicast-group matching the given id.
+func (a *MulticastGroupAPI) Delete(ctx context.Context, req *pb.DeleteMulticastGroupRequest) (*pb.DeleteMulticastGroupResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Delete, req.Id),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.DeleteMulticastGroup(tx, req.Id)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteMulticastGroupResponse{}, nil
+}
+
+// List returns the multicast-groups of the given application.
+func (a *MulticastGroupAPI) List(ctx context.Context, req *pb.ListMulticastGroupRequest) (*pb.ListMulticastGroupResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupsAccess(auth.List, req.ApplicationID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.GetMulticastGroupCountForApplicationID(config.C.PostgreSQL.DB, req.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	groups, err := storage.GetMulticastGroupsForApplicationID(config.C.PostgreSQL.DB, req.ApplicationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := pb.ListMulticastGroupResponse{
+		TotalCount: int64(count),
+	}
+
+	for _, mg := range groups {
+		out.Result = append(out.Result, &pb.MulticastGroupListItem{
+			Id:            mg.ID,
+			Name:          mg.Name,
+			ApplicationID: mg.ApplicationID,
+			McAddr:        mg.McAddr.String(),
+			GroupType:     multicastGroupTypeToPB(mg.GroupType),
+		})
+	}
+
+	return &out, nil
+}
+
+// AddDevice adds the given device to the multicast-group.
+func (a *MulticastGroupAPI) AddDevice(ctx context.Context, req *pb.AddDeviceToMulticastGroupRequest) (*pb.AddDeviceToMulticastGroupResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Update, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.AddDeviceToMulticastGroup(tx, req.MulticastGroupID, devEUI)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.AddDeviceToMulticastGroupResponse{}, nil
+}
+
+// RemoveDevice removes the given device from the multicast-group.
+func (a *MulticastGroupAPI) RemoveDevice(ctx context.Context, req *pb.RemoveDeviceFromMulticastGroupRequest) (*pb.RemoveDeviceFromMulticastGroupResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Update, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.RemoveDeviceFromMulticastGroup(tx, req.MulticastGroupID, devEUI)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.RemoveDeviceFromMulticastGroupResponse{}, nil
+}
+
+// ListDevices returns the devices of the multicast-group.
+func (a *MulticastGroupAPI) ListDevices(ctx context.Context, req *pb.ListMulticastGroupDevicesRequest) (*pb.ListMulticastGroupDevicesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Read, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	devices, err := storage.GetMulticastGroupDevices(config.C.PostgreSQL.DB, req.MulticastGroupID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListMulticastGroupDevicesResponse
+	for _, d := range devices {
+		out.Result = append(out.Result, &pb.MulticastGroupDevice{
+			DevEUI:             d.DevEUI.String(),
+			SetupRequestedAt:   formatTimePtr(d.SetupRequestedAt),
+			SetupCompletedAt:   formatTimePtr(d.SetupCompletedAt),
+			SessionRequestedAt: formatTimePtr(d.SessionRequestedAt),
+			SessionStartAt:     formatTimePtr(d.SessionStartAt),
+			LastError:          d.LastError,
+			CreatedAt:          d.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &out, nil
+}
+
+// Setup provisions the McKey and McAddr of the multicast-group to its
+// devices.
+func (a *MulticastGroupAPI) Setup(ctx context.Context, req *pb.SetupMulticastGroupRequest) (*pb.SetupMulticastGroupResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Update, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := multicast.SetupDevices(config.C.PostgreSQL.DB, req.MulticastGroupID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.SetupMulticastGroupResponse{}, nil
+}
+
+// StartSession requests the devices of the multicast-group to start a
+// multicast session.
+func (a *MulticastGroupAPI) StartSession(ctx context.Context, req *pb.StartMulticastSessionRequest) (*pb.StartMulticastSessionResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupAccess(auth.Update, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	sessionTime, err := time.Parse(time.RFC3339Nano, req.SessionTime)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "sessionTime: %s", err)
+	}
+
+	if req.Timeout > 15 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "max timeout value is 15")
+	}
+
+	if err := multicast.StartSession(config.C.PostgreSQL.DB, req.MulticastGroupID, sessionTime, uint8(req.Timeout)); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.StartMulticastSessionResponse{}, nil
+}
+
+// Enqueue encrypts the given payload and adds it to the multicast-queue.
+func (a *MulticastGroupAPI) Enqueue(ctx context.Context, req *pb.EnqueueMulticastQueueItemRequest) (*pb.EnqueueMulticastQueueItemResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupQueueAccess(auth.Create, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if req.FPort == 0 || req.FPort > 255 || req.FPort == uint32(multicastsetup.DefaultFPort) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fPort must be > 0 and must not be the Remote Multicast Setup fPort")
+	}
+
+	var fCnt uint32
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		fCnt, err = multicast.Enqueue(tx, req.MulticastGroupID, uint8(req.FPort), req.Data)
+		return err
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EnqueueMulticastQueueItemResponse{
+		FCnt: fCnt,
+	}, nil
+}
+
+// ListQueue returns the items pending in the multicast-queue.
+func (a *MulticastGroupAPI) ListQueue(ctx context.Context, req *pb.ListMulticastQueueItemsRequest) (*pb.ListMulticastQueueItemsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupQueueAccess(auth.List, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	items, err := multicast.GetQueue(config.C.PostgreSQL.DB, req.MulticastGroupID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListMulticastQueueItemsResponse
+	for _, qi := range items {
+		out.Items = append(out.Items, &pb.MulticastQueueItem{
+			FCnt:       qi.FCnt,
+			FPort:      uint32(qi.FPort),
+			FrmPayload: qi.FRMPayload,
+			CreatedAt:  qi.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &out, nil
+}
+
+// FlushQueue flushes the multicast-queue.
+func (a *MulticastGroupAPI) FlushQueue(ctx context.Context, req *pb.FlushMulticastQueueItemsRequest) (*pb.FlushMulticastQueueItemsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateMulticastGroupQueueAccess(auth.Delete, req.MulticastGroupID),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return multicast.FlushQueue(tx, req.MulticastGroupID)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.FlushMulticastQueueItemsResponse{}, nil
+}
+
+// multicastGroupFromPB sets the user-editable fields of the given
+// multicast-group from the given API representation. When the McKey is
+// given (or already known and no session keys are given), the session keys
+// are derived from it. Note that the application of an existing
+// multicast-group can not be changed.
+func multicastGroupFromPB(in *pb.MulticastGroup, mg *storage.MulticastGroup) error {
+	mg.Name = in.Name
+	mg.McGroupID = uint8(in.McGroupID)
+	mg.DR = uint8(in.Dr)
+	mg.Frequency = int(in.Frequency)
+	mg.PingSlotPeriod = uint8(in.PingSlotPeriod)
+
+	switch in.GroupType {
+	case pb.MulticastGroupType_CLASS_B:
+		mg.GroupType = storage.MulticastGroupClassB
+	default:
+		mg.GroupType = storage.MulticastGroupClassC
+	}
+
+	if err := mg.McAddr.UnmarshalText([]byte(in.McAddr)); err != nil {
+		return grpc.Errorf(codes.InvalidArgument, "mcAddr: %s", err)
+	}
+
+	switch {
+	case in.McKey != "":
+		var mcKey lorawan.AES128Key
+		if err := mcKey.UnmarshalText([]byte(in.McKey)); err != nil {
+			return grpc.Errorf(codes.InvalidArgument, "mcKey: %s", err)
+		}
+		mg.McKey = &mcKey
+	case in.McAppSKey != "" || in.McNwkSKey != "":
+		if err := mg.McAppSKey.UnmarshalText([]byte(in.McAppSKey)); err != nil {
+			return grpc.Errorf(codes.InvalidArgument, "mcAppSKey: %s", err)
+		}
+		if err := mg.McNwkSKey.UnmarshalText([]byte(in.McNwkSKey)); err != nil {
+			return grpc.Errorf(codes.InvalidArgument, "mcNwkSKey: %s", err)
+		}
+		mg.McKey = nil
+	case mg.McKey == nil:
+		return grpc.Errorf(codes.InvalidArgument, "mcKey or mcAppSKey and mcNwkSKey expected")
+	}
+
+	if mg.McKey != nil {
+		var err error
+		mg.McAppSKey, err = multicastsetup.GetMcAppSKey(*mg.McKey, mg.McAddr)
+		if err != nil {
+			return grpc.Errorf(codes.Internal, "get McAppSKey error: %s", err)
+		}
+		mg.McNwkSKey, err = multicastsetup.GetMcNetSKey(*mg.McKey, mg.McAddr)
+		if err != nil {
+			return grpc.Errorf(codes.Internal, "get McNwkSKey error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func multicastGroupTypeToPB(t storage.MulticastGroupType) pb.MulticastGroupType {
+	if t == storage.MulticastGroupClassB {
+		return pb.MulticastGroupType_CLASS_B
+	}
+	return pb.MulticastGroupType_CLASS_C
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/applayer/multicastsetup"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestMulticastGroupAPI(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nsClient := test.NewNetworkServerClient()
+
+	config.C.PostgreSQL.DB = db
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization, application, device and an api instance", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		ctx := context.Background()
+		validator := &TestValidator{}
+		api := NewMulticastGroupAPI(validator)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		device := storage.Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(storage.CreateDevice(config.C.PostgreSQL.DB, &device), ShouldBeNil)
+
+		Convey("When creating a multicast-group without keys", func() {
+			_, err := api.Create(ctx, &pb.CreateMulticastGroupRequest{
+				MulticastGroup: &pb.MulticastGroup{
+					Name:          "test-group",
+					ApplicationID: app.ID,
+					McAddr:        "01020304",
+					Frequency:     869525000,
+				},
+			})
+
+			Convey("Then an invalid argument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When creating a multicast-group with a McKey", func() {
+			resp, err := api.Create(ctx, &pb.CreateMulticastGroupRequest{
+				MulticastGroup: &pb.MulticastGroup{
+					Name:          "test-group",
+					ApplicationID: app.ID,
+					McAddr:        "01020304",
+					McKey:         "01020304050607080102030405060708",
+					McGroupID:     1,
+					GroupType:     pb.MulticastGroupType_CLASS_C,
+					Dr:            3,
+					Frequency:     869525000,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(resp.Id, ShouldBeGreaterThan, 0)
+
+			Convey("Then the session keys have been derived from the McKey", func() {
+				mcKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+				mcAddr := lorawan.DevAddr{1, 2, 3, 4}
+				mcAppSKey, err := multicastsetup.GetMcAppSKey(mcKey, mcAddr)
+				So(err, ShouldBeNil)
+				mcNwkSKey, err := multicastsetup.GetMcNetSKey(mcKey, mcAddr)
+				So(err, ShouldBeNil)
+
+				mg, err := api.Get(ctx, &pb.GetMulticastGroupRequest{Id: resp.Id})
+				So(err, ShouldBeNil)
+				So(mg.McKeyIsSet, ShouldBeTrue)
+				So(mg.MulticastGroup, ShouldResemble, &pb.MulticastGroup{
+					Id:            resp.Id,
+					Name:          "test-group",
+					ApplicationID: app.ID,
+					McAddr:        "01020304",
+					McAppSKey:     mcAppSKey.String(),
+					McNwkSKey:     mcNwkSKey.String(),
+					McGroupID:     1,
+					GroupType:     pb.MulticastGroupType_CLASS_C,
+					Dr:            3,
+					Frequency:     869525000,
+				})
+			})
+
+			Convey("Then it can be updated", func() {
+				_, err := api.Update(ctx, &pb.UpdateMulticastGroupRequest{
+					MulticastGroup: &pb.MulticastGroup{
+						Id:             resp.Id,
+						Name:           "updated-group",
+						McAddr:         "04030201",
+						McGroupID:      2,
+						GroupType:      pb.MulticastGroupType_CLASS_B,
+						Dr:             2,
+						Frequency:      869525000,
+						PingSlotPeriod: 5,
+					},
+				})
+				So(err, ShouldBeNil)
+
+				mg, err := api.Get(ctx, &pb.GetMulticastGroupRequest{Id: resp.Id})
+				So(err, ShouldBeNil)
+				So(mg.McKeyIsSet, ShouldBeTrue)
+				So(mg.MulticastGroup.Name, ShouldEqual, "updated-group")
+				So(mg.MulticastGroup.GroupType, ShouldEqual, pb.MulticastGroupType_CLASS_B)
+				So(mg.MulticastGroup.PingSlotPeriod, ShouldEqual, 5)
+			})
+
+			Convey("Then it is returned by list", func() {
+				list, err := api.List(ctx, &pb.ListMulticastGroupRequest{
+					ApplicationID: app.ID,
+					Limit:         10,
+				})
+				So(err, ShouldBeNil)
+				So(list.TotalCount, ShouldEqual, 1)
+				So(list.Result, ShouldHaveLength, 1)
+				So(list.Result[0].Id, ShouldEqual, resp.Id)
+			})
+
+			Convey("Then a device can be added and removed", func() {
+				_, err := api.AddDevice(ctx, &pb.AddDeviceToMulticastGroupRequest{
+					MulticastGroupID: resp.Id,
+					DevEUI:           device.DevEUI.String(),
+				})
+				So(err, ShouldBeNil)
+
+				devices, err := api.ListDevices(ctx, &pb.ListMulticastGroupDevicesRequest{
+					MulticastGroupID: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(devices.Result, ShouldHaveLength, 1)
+				So(devices.Result[0].DevEUI, ShouldEqual, device.DevEUI.String())
+				So(devices.Result[0].SetupRequestedAt, ShouldEqual, "")
+
+				_, err = api.RemoveDevice(ctx, &pb.RemoveDeviceFromMulticastGroupRequest{
+					MulticastGroupID: resp.Id,
+					DevEUI:           device.DevEUI.String(),
+				})
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then enqueueing on the Remote Multicast Setup fPort returns an error", func() {
+				_, err := api.Enqueue(ctx, &pb.EnqueueMulticastQueueItemRequest{
+					MulticastGroupID: resp.Id,
+					FPort:            200,
+					Data:             []byte{1, 2, 3},
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("When enqueueing a payload", func() {
+				enqResp, err := api.Enqueue(ctx, &pb.EnqueueMulticastQueueItemRequest{
+					MulticastGroupID: resp.Id,
+					FPort:            10,
+					Data:             []byte{1, 2, 3},
+				})
+				So(err, ShouldBeNil)
+				So(enqResp.FCnt, ShouldEqual, 0)
+
+				Convey("Then the item is enqueued at the network-server", func() {
+					req := <-nsClient.EnqueueMulticastQueueItemChan
+					So(req.MulticastQueueItem.FCnt, ShouldEqual, 0)
+					So(req.MulticastQueueItem.FPort, ShouldEqual, 10)
+				})
+
+				Convey("Then the item is in the queue", func() {
+					nsClient.GetMulticastQueueItemsForMulticastGroupResponse = ns.GetMulticastQueueItemsForMulticastGroupResponse{
+						MulticastQueueItems: []*ns.MulticastQueueItem{
+							{FCnt: 0, FPort: 10, FrmPayload: []byte{1, 2, 3}},
+						},
+					}
+
+					queue, err := api.ListQueue(ctx, &pb.ListMulticastQueueItemsRequest{
+						MulticastGroupID: resp.Id,
+					})
+					So(err, ShouldBeNil)
+					So(queue.Items, ShouldHaveLength, 1)
+					So(queue.Items[0].FPort, ShouldEqual, 10)
+				})
+
+				Convey("Then the queue can be flushed", func() {
+					_, err := api.FlushQueue(ctx, &pb.FlushMulticastQueueItemsRequest{
+						MulticastGroupID: resp.Id,
+					})
+					So(err, ShouldBeNil)
+
+					req := <-nsClient.FlushMulticastQueueForMulticastGroupChan
+					So(req.MulticastGroupId, ShouldNotBeEmpty)
+				})
+			})
+
+			Convey("Then it can be deleted", func() {
+				_, err := api.Delete(ctx, &pb.DeleteMulticastGroupRequest{Id: resp.Id})
+				So(err, ShouldBeNil)
+
+				_, err = api.Get(ctx, &pb.GetMulticastGroupRequest{Id: resp.Id})
+				So(grpc.Code(err), ShouldEqual, codes.NotFound)
+			})
+		})
+	})
+}
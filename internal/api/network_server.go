@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/hex"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -12,7 +13,8 @@ import (
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/storage"
-	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/golang/protobuf/ptypes/empty"
 )
 
 // NetworkServerAPI exports the NetworkServer related functions.
@@ -48,9 +50,16 @@ func (a *NetworkServerAPI) Create(ctx context.Context, req *pb.CreateNetworkServ
 		GatewayDiscoveryInterval:    int(req.GatewayDiscoveryInterval),
 		GatewayDiscoveryTXFrequency: int(req.GatewayDiscoveryTXFrequency),
 		GatewayDiscoveryDR:          int(req.GatewayDiscoveryDR),
+		JoinServerClientCN:          req.JoinServerClientCN,
 	}
 
-	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+	netID, err := netIDFromString(req.NetID)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "netID: %s", err)
+	}
+	ns.NetID = netID
+
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
 		return storage.CreateNetworkServer(tx, &ns)
 	})
 	if err != nil {
@@ -80,7 +89,7 @@ func (a *NetworkServerAPI) Get(ctx context.Context, req *pb.GetNetworkServerRequ
 
 	nsClient, err := config.C.NetworkServer.Pool.Get(n.Server, []byte(n.CACert), []byte(n.TLSCert), []byte(n.TLSKey))
 	if err == nil {
-		resp, err := nsClient.GetVersion(context.Background(), &ns.GetVersionRequest{})
+		resp, err := nsClient.GetVersion(context.Background(), &empty.Empty{})
 		if err == nil {
 			region = resp.Region.String()
 			version = resp.Version
@@ -103,6 +112,8 @@ func (a *NetworkServerAPI) Get(ctx context.Context, req *pb.GetNetworkServerRequ
 		GatewayDiscoveryDR:          uint32(n.GatewayDiscoveryDR),
 		Region:                      region,
 		Version:                     version,
+		NetID:                       hex.EncodeToString(n.NetID),
+		JoinServerClientCN:          n.JoinServerClientCN,
 	}, nil
 }
 
@@ -129,6 +140,12 @@ func (a *NetworkServerAPI) Update(ctx context.Context, req *pb.UpdateNetworkServ
 	ns.GatewayDiscoveryInterval = int(req.GatewayDiscoveryInterval)
 	ns.GatewayDiscoveryTXFrequency = int(req.GatewayDiscoveryTXFrequency)
 	ns.GatewayDiscoveryDR = int(req.GatewayDiscoveryDR)
+	ns.JoinServerClientCN = req.JoinServerClientCN
+
+	ns.NetID, err = netIDFromString(req.NetID)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "netID: %s", err)
+	}
 
 	if req.TlsKey != "" {
 		ns.TLSKey = req.TlsKey
@@ -226,3 +243,17 @@ func (a *NetworkServerAPI) List(ctx context.Context, req *pb.ListNetworkServerRe
 
 	return &resp, nil
 }
+
+// netIDFromString returns the NetID bytes for the given HEX encoded NetID,
+// or nil when the string is empty.
+func netIDFromString(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	return netID[:], nil
+}
@@ -38,6 +38,15 @@ func TestNetworkServerAPI(t *testing.T) {
 		}
 		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
 
+		Convey("Then Create with an invalid NetID returns an error", func() {
+			_, err := api.Create(ctx, &pb.CreateNetworkServerRequest{
+				Name:   "test ns",
+				Server: "test-ns:1234",
+				NetID:  "0102",
+			})
+			So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+		})
+
 		Convey("Then Create creates a network-server", func() {
 			resp, err := api.Create(ctx, &pb.CreateNetworkServerRequest{
 				Name:                        "test ns",
@@ -52,6 +61,8 @@ func TestNetworkServerAPI(t *testing.T) {
 				GatewayDiscoveryInterval:    5,
 				GatewayDiscoveryTXFrequency: 868100000,
 				GatewayDiscoveryDR:          5,
+				NetID:                       "010203",
+				JoinServerClientCN:          "ns.example.com",
 			})
 			So(err, ShouldBeNil)
 			So(resp.Id, ShouldBeGreaterThan, 0)
@@ -63,6 +74,8 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(getResp.Name, ShouldEqual, "test ns")
 				So(getResp.Server, ShouldEqual, "test-ns:1234")
+				So(getResp.NetID, ShouldEqual, "010203")
+				So(getResp.JoinServerClientCN, ShouldEqual, "ns.example.com")
 			})
 
 			Convey("Then the CA and TLS fields are populated", func() {
@@ -91,6 +104,7 @@ func TestNetworkServerAPI(t *testing.T) {
 					GatewayDiscoveryInterval:    1,
 					GatewayDiscoveryTXFrequency: 868300000,
 					GatewayDiscoveryDR:          4,
+					NetID:                       "030201",
 				})
 				So(err, ShouldBeNil)
 
@@ -114,6 +128,8 @@ func TestNetworkServerAPI(t *testing.T) {
 					So(n.GatewayDiscoveryInterval, ShouldEqual, 1)
 					So(n.GatewayDiscoveryTXFrequency, ShouldEqual, 868300000)
 					So(n.GatewayDiscoveryDR, ShouldEqual, 4)
+					So(n.NetID, ShouldResemble, []byte{3, 2, 1})
+					So(n.JoinServerClientCN, ShouldEqual, "")
 				})
 			})
 
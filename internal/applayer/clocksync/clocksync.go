@@ -0,0 +1,288 @@
+// Package clocksync implements the LoRaWAN Application Layer Clock
+// Synchronization package (v1.0.0), used to synchronize the real-time clock
+// of devices with the (GPS) time of the network.
+package clocksync
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultFPort defines the FPort used by the Clock Synchronization package.
+const DefaultFPort uint8 = 202
+
+// PackageIdentifier and PackageVersion implemented by this package.
+const (
+	PackageIdentifier uint8 = 1
+	PackageVersion    uint8 = 1
+)
+
+// CID defines the command identifier.
+type CID byte
+
+// Available command identifiers.
+const (
+	PackageVersionReq           CID = 0x00
+	PackageVersionAns           CID = 0x00
+	AppTimeReq                  CID = 0x01
+	AppTimeAns                  CID = 0x01
+	DeviceAppTimePeriodicityReq CID = 0x02
+	DeviceAppTimePeriodicityAns CID = 0x02
+	ForceDeviceResyncReq        CID = 0x03
+)
+
+// CommandPayload defines the interface that a command payload must implement.
+type CommandPayload interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+}
+
+// Command defines a Clock Synchronization command.
+type Command struct {
+	CID     CID
+	Payload CommandPayload
+}
+
+// MarshalBinary encodes the command to a slice of bytes.
+func (c Command) MarshalBinary() ([]byte, error) {
+	b := []byte{byte(c.CID)}
+
+	if c.Payload != nil {
+		p, err := c.Payload.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal payload error")
+		}
+		b = append(b, p...)
+	}
+
+	return b, nil
+}
+
+// Commands defines a slice of commands.
+type Commands []Command
+
+// MarshalBinary encodes the commands to a slice of bytes.
+func (c Commands) MarshalBinary() ([]byte, error) {
+	var out []byte
+
+	for _, cmd := range c {
+		b, err := cmd.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalUplinkCommands decodes the given uplink payload (sent by the
+// device) into a slice of commands.
+func UnmarshalUplinkCommands(b []byte) (Commands, error) {
+	var out Commands
+
+	for len(b) > 0 {
+		cmd := Command{
+			CID: CID(b[0]),
+		}
+		b = b[1:]
+
+		var size int
+		switch cmd.CID {
+		case PackageVersionAns:
+			cmd.Payload = &PackageVersionAnsPayload{}
+			size = 2
+		case AppTimeReq:
+			cmd.Payload = &AppTimeReqPayload{}
+			size = 5
+		case DeviceAppTimePeriodicityAns:
+			cmd.Payload = &DeviceAppTimePeriodicityAnsPayload{}
+			size = 5
+		default:
+			return nil, fmt.Errorf("unsupported CID: %d", cmd.CID)
+		}
+
+		if len(b) < size {
+			return nil, fmt.Errorf("not enough bytes for CID %d payload, expected %d bytes", cmd.CID, size)
+		}
+
+		if err := cmd.Payload.UnmarshalBinary(b[:size]); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal CID %d payload error", cmd.CID)
+		}
+		b = b[size:]
+
+		out = append(out, cmd)
+	}
+
+	return out, nil
+}
+
+// PackageVersionAnsPayload implements the PackageVersionAns payload.
+type PackageVersionAnsPayload struct {
+	PackageIdentifier uint8
+	PackageVersion    uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p PackageVersionAnsPayload) MarshalBinary() ([]byte, error) {
+	return []byte{p.PackageIdentifier, p.PackageVersion}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *PackageVersionAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return errors.New("2 bytes are expected")
+	}
+	p.PackageIdentifier = data[0]
+	p.PackageVersion = data[1]
+	return nil
+}
+
+// AppTimeReqPayload implements the AppTimeReq payload.
+type AppTimeReqPayload struct {
+	// DeviceTime defines the time of the device (seconds since the GPS
+	// epoch, modulo 2^32) when sending the uplink.
+	DeviceTime uint32
+	// AnsRequired is set when the device requires an answer, even when its
+	// clock is correct.
+	AnsRequired bool
+	// TokenReq is a 4 bit counter, which must be echoed in the AppTimeAns.
+	TokenReq uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p AppTimeReqPayload) MarshalBinary() ([]byte, error) {
+	if p.TokenReq > 15 {
+		return nil, errors.New("max TokenReq value is 15")
+	}
+
+	b := make([]byte, 5)
+	binary.LittleEndian.PutUint32(b[0:4], p.DeviceTime)
+	b[4] = p.TokenReq
+	if p.AnsRequired {
+		b[4] |= 1 << 4
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *AppTimeReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 5 {
+		return errors.New("5 bytes are expected")
+	}
+	p.DeviceTime = binary.LittleEndian.Uint32(data[0:4])
+	p.AnsRequired = data[4]&(1<<4) != 0
+	p.TokenReq = data[4] & 0x0f
+	return nil
+}
+
+// AppTimeAnsPayload implements the AppTimeAns payload.
+type AppTimeAnsPayload struct {
+	// TimeCorrection defines the correction (in seconds) which must be
+	// applied to the clock of the device.
+	TimeCorrection int32
+	// TokenAns echoes the TokenReq of the AppTimeReq.
+	TokenAns uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p AppTimeAnsPayload) MarshalBinary() ([]byte, error) {
+	if p.TokenAns > 15 {
+		return nil, errors.New("max TokenAns value is 15")
+	}
+
+	b := make([]byte, 5)
+	binary.LittleEndian.PutUint32(b[0:4], uint32(p.TimeCorrection))
+	b[4] = p.TokenAns
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *AppTimeAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 5 {
+		return errors.New("5 bytes are expected")
+	}
+	p.TimeCorrection = int32(binary.LittleEndian.Uint32(data[0:4]))
+	p.TokenAns = data[4] & 0x0f
+	return nil
+}
+
+// DeviceAppTimePeriodicityReqPayload implements the
+// DeviceAppTimePeriodicityReq payload.
+type DeviceAppTimePeriodicityReqPayload struct {
+	// Periodicity defines the interval of the AppTimeReq uplinks sent by
+	// the device, 128 * 2^Periodicity seconds (0 - 15).
+	Periodicity uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p DeviceAppTimePeriodicityReqPayload) MarshalBinary() ([]byte, error) {
+	if p.Periodicity > 15 {
+		return nil, errors.New("max Periodicity value is 15")
+	}
+	return []byte{p.Periodicity}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *DeviceAppTimePeriodicityReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.Periodicity = data[0] & 0x0f
+	return nil
+}
+
+// DeviceAppTimePeriodicityAnsPayload implements the
+// DeviceAppTimePeriodicityAns payload.
+type DeviceAppTimePeriodicityAnsPayload struct {
+	NotSupported bool
+	// Time defines the time of the device (seconds since the GPS epoch,
+	// modulo 2^32).
+	Time uint32
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p DeviceAppTimePeriodicityAnsPayload) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 5)
+	if p.NotSupported {
+		b[0] = 0x01
+	}
+	binary.LittleEndian.PutUint32(b[1:5], p.Time)
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *DeviceAppTimePeriodicityAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 5 {
+		return errors.New("5 bytes are expected")
+	}
+	p.NotSupported = data[0]&0x01 != 0
+	p.Time = binary.LittleEndian.Uint32(data[1:5])
+	return nil
+}
+
+// ForceDeviceResyncReqPayload implements the ForceDeviceResyncReq payload.
+type ForceDeviceResyncReqPayload struct {
+	// NbTransmissions defines the number of AppTimeReq uplinks the device
+	// must send (0 - 7).
+	NbTransmissions uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p ForceDeviceResyncReqPayload) MarshalBinary() ([]byte, error) {
+	if p.NbTransmissions > 7 {
+		return nil, errors.New("max NbTransmissions value is 7")
+	}
+	return []byte{p.NbTransmissions}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *ForceDeviceResyncReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.NbTransmissions = data[0] & 0x07
+	return nil
+}
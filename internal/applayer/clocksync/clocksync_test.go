@@ -0,0 +1,84 @@
+package clocksync
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCommands(t *testing.T) {
+	Convey("Given a set of downlink commands", t, func() {
+		tests := []struct {
+			Name    string
+			Command Command
+			Bytes   []byte
+		}{
+			{
+				Name: "AppTimeAns",
+				Command: Command{
+					CID: AppTimeAns,
+					Payload: &AppTimeAnsPayload{
+						TimeCorrection: -2,
+						TokenAns:       5,
+					},
+				},
+				Bytes: []byte{0x01, 0xfe, 0xff, 0xff, 0xff, 0x05},
+			},
+			{
+				Name: "DeviceAppTimePeriodicityReq",
+				Command: Command{
+					CID: DeviceAppTimePeriodicityReq,
+					Payload: &DeviceAppTimePeriodicityReqPayload{
+						Periodicity: 10,
+					},
+				},
+				Bytes: []byte{0x02, 0x0a},
+			},
+			{
+				Name: "ForceDeviceResyncReq",
+				Command: Command{
+					CID: ForceDeviceResyncReq,
+					Payload: &ForceDeviceResyncReqPayload{
+						NbTransmissions: 3,
+					},
+				},
+				Bytes: []byte{0x03, 0x03},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				b, err := test.Command.MarshalBinary()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, test.Bytes)
+			})
+		}
+	})
+
+	Convey("Given an uplink payload containing multiple commands", t, func() {
+		b := []byte{
+			0x00, 0x01, 0x01, // PackageVersionAns
+			0x01, 0x04, 0x03, 0x02, 0x01, 0x1a, // AppTimeReq with AnsRequired
+			0x02, 0x01, 0x08, 0x07, 0x06, 0x05, // DeviceAppTimePeriodicityAns with NotSupported
+		}
+
+		Convey("Then UnmarshalUplinkCommands returns the expected commands", func() {
+			cmds, err := UnmarshalUplinkCommands(b)
+			So(err, ShouldBeNil)
+			So(cmds, ShouldResemble, Commands{
+				{CID: PackageVersionAns, Payload: &PackageVersionAnsPayload{PackageIdentifier: 1, PackageVersion: 1}},
+				{CID: AppTimeReq, Payload: &AppTimeReqPayload{DeviceTime: 0x01020304, AnsRequired: true, TokenReq: 10}},
+				{CID: DeviceAppTimePeriodicityAns, Payload: &DeviceAppTimePeriodicityAnsPayload{NotSupported: true, Time: 0x05060708}},
+			})
+		})
+	})
+
+	Convey("Given an uplink payload with a truncated AppTimeReq", t, func() {
+		_, err := UnmarshalUplinkCommands([]byte{0x01, 0x04, 0x03})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package fragmentation
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Encode fragments the given data into fragments of the given size and
+// appends the given number of redundancy (forward error correction)
+// fragments, using the parity check matrix defined by the Fragmented Data
+// Block Transport specification (FragmentationMatrix 0). The length of data
+// must be a multiple of fragmentSize (see Pad).
+func Encode(data []byte, fragmentSize, redundancy int) ([][]byte, error) {
+	if fragmentSize <= 0 {
+		return nil, errors.New("fragment-size must be > 0")
+	}
+	if len(data) == 0 || len(data)%fragmentSize != 0 {
+		return nil, errors.New("length of data must be a (non-zero) multiple of the fragment-size")
+	}
+	if redundancy < 0 {
+		return nil, errors.New("redundancy must be >= 0")
+	}
+
+	var fragments [][]byte
+	for i := 0; i < len(data); i += fragmentSize {
+		fragments = append(fragments, data[i:i+fragmentSize])
+	}
+
+	nbFrag := len(fragments)
+	for n := 1; n <= redundancy; n++ {
+		coded := make([]byte, fragmentSize)
+		for i, set := range matrixLine(n, nbFrag) {
+			if !set {
+				continue
+			}
+			for j := range coded {
+				coded[j] ^= fragments[i][j]
+			}
+		}
+		fragments = append(fragments, coded)
+	}
+
+	return fragments, nil
+}
+
+// Pad pads the given data with zero bytes to a multiple of fragmentSize.
+// It returns the padded data and the number of padding bytes.
+func Pad(data []byte, fragmentSize int) ([]byte, int) {
+	padding := 0
+	if r := len(data) % fragmentSize; r != 0 {
+		padding = fragmentSize - r
+	}
+
+	out := make([]byte, len(data)+padding)
+	copy(out, data)
+	return out, padding
+}
+
+// matrixLine returns line n (starting at 1) of the parity check matrix for
+// m uncoded fragments.
+func matrixLine(n, m int) []bool {
+	line := make([]bool, m)
+
+	mm := 0
+	if isPowerOf2(m) {
+		mm = 1
+	}
+
+	x := 1 + 1001*n
+	for nbCoeff := 0; nbCoeff < m/2; nbCoeff++ {
+		r := 1 << 16
+		for r >= m || line[r] {
+			x = prbs23(x)
+			r = x % (m + mm)
+		}
+		line[r] = true
+	}
+
+	return line
+}
+
+// prbs23 implements the pseudo-random binary sequence generator
+// (x^23 + x^5 + 1) used for generating the parity check matrix.
+func prbs23(x int) int {
+	b0 := x & 1
+	b1 := (x & 32) / 32
+	return x/2 + (b0^b1)<<22
+}
+
+func isPowerOf2(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
@@ -0,0 +1,411 @@
+// Package fragmentation implements the LoRaWAN Fragmented Data Block
+// Transport application-layer package (v1.0.0), used to transport large
+// data blocks (e.g. firmware images) to devices.
+package fragmentation
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultFPort defines the FPort used by the Fragmented Data Block Transport
+// package.
+const DefaultFPort uint8 = 201
+
+// PackageIdentifier and PackageVersion implemented by this package.
+const (
+	PackageIdentifier uint8 = 3
+	PackageVersion    uint8 = 1
+)
+
+// CID defines the command identifier.
+type CID byte
+
+// Available command identifiers.
+const (
+	PackageVersionReq    CID = 0x00
+	PackageVersionAns    CID = 0x00
+	FragSessionStatusReq CID = 0x01
+	FragSessionStatusAns CID = 0x01
+	FragSessionSetupReq  CID = 0x02
+	FragSessionSetupAns  CID = 0x02
+	FragSessionDeleteReq CID = 0x03
+	FragSessionDeleteAns CID = 0x03
+	DataFragment         CID = 0x08
+)
+
+// CommandPayload defines the interface that a command payload must implement.
+type CommandPayload interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+}
+
+// Command defines a Fragmented Data Block Transport command.
+type Command struct {
+	CID     CID
+	Payload CommandPayload
+}
+
+// MarshalBinary encodes the command to a slice of bytes.
+func (c Command) MarshalBinary() ([]byte, error) {
+	b := []byte{byte(c.CID)}
+
+	if c.Payload != nil {
+		p, err := c.Payload.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal payload error")
+		}
+		b = append(b, p...)
+	}
+
+	return b, nil
+}
+
+// Commands defines a slice of commands.
+type Commands []Command
+
+// MarshalBinary encodes the commands to a slice of bytes.
+func (c Commands) MarshalBinary() ([]byte, error) {
+	var out []byte
+
+	for _, cmd := range c {
+		b, err := cmd.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalUplinkCommands decodes the given uplink payload (sent by the
+// device) into a slice of commands.
+func UnmarshalUplinkCommands(b []byte) (Commands, error) {
+	var out Commands
+
+	for len(b) > 0 {
+		cmd := Command{
+			CID: CID(b[0]),
+		}
+		b = b[1:]
+
+		var size int
+		switch cmd.CID {
+		case PackageVersionAns:
+			cmd.Payload = &PackageVersionAnsPayload{}
+			size = 2
+		case FragSessionStatusAns:
+			cmd.Payload = &FragSessionStatusAnsPayload{}
+			size = 4
+		case FragSessionSetupAns:
+			cmd.Payload = &FragSessionSetupAnsPayload{}
+			size = 1
+		case FragSessionDeleteAns:
+			cmd.Payload = &FragSessionDeleteAnsPayload{}
+			size = 1
+		default:
+			return nil, fmt.Errorf("unsupported CID: %d", cmd.CID)
+		}
+
+		if len(b) < size {
+			return nil, fmt.Errorf("not enough bytes for CID %d payload, expected %d bytes", cmd.CID, size)
+		}
+
+		if err := cmd.Payload.UnmarshalBinary(b[:size]); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal CID %d payload error", cmd.CID)
+		}
+		b = b[size:]
+
+		out = append(out, cmd)
+	}
+
+	return out, nil
+}
+
+// PackageVersionAnsPayload implements the PackageVersionAns payload.
+type PackageVersionAnsPayload struct {
+	PackageIdentifier uint8
+	PackageVersion    uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p PackageVersionAnsPayload) MarshalBinary() ([]byte, error) {
+	return []byte{p.PackageIdentifier, p.PackageVersion}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *PackageVersionAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return errors.New("2 bytes are expected")
+	}
+	p.PackageIdentifier = data[0]
+	p.PackageVersion = data[1]
+	return nil
+}
+
+// FragSessionStatusReqPayload implements the FragSessionStatusReq payload.
+type FragSessionStatusReqPayload struct {
+	FragIndex uint8
+	// Participants requests all the devices to answer (also the devices
+	// which received all fragments).
+	Participants bool
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p FragSessionStatusReqPayload) MarshalBinary() ([]byte, error) {
+	if p.FragIndex > 3 {
+		return nil, errors.New("max FragIndex value is 3")
+	}
+
+	b := p.FragIndex << 1
+	if p.Participants {
+		b |= 1
+	}
+	return []byte{b}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *FragSessionStatusReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.FragIndex = (data[0] >> 1) & 0x03
+	p.Participants = data[0]&0x01 != 0
+	return nil
+}
+
+// FragSessionStatusAnsPayload implements the FragSessionStatusAns payload.
+type FragSessionStatusAnsPayload struct {
+	FragIndex uint8
+	// NbFragReceived defines the number of fragments received by the device.
+	NbFragReceived uint16
+	// MissingFrag defines the number of fragments still missing to
+	// reconstruct the data block (max. 255).
+	MissingFrag uint8
+	// NotEnoughMatrixMemory is set when the device ran out of memory to
+	// reconstruct the data block.
+	NotEnoughMatrixMemory bool
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p FragSessionStatusAnsPayload) MarshalBinary() ([]byte, error) {
+	if p.FragIndex > 3 {
+		return nil, errors.New("max FragIndex value is 3")
+	}
+	if p.NbFragReceived >= 1<<14 {
+		return nil, errors.New("max NbFragReceived value is 2^14 - 1")
+	}
+
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint16(b[0:2], uint16(p.FragIndex)<<14|p.NbFragReceived)
+	b[2] = p.MissingFrag
+	if p.NotEnoughMatrixMemory {
+		b[3] = 1
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *FragSessionStatusAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return errors.New("4 bytes are expected")
+	}
+	v := binary.LittleEndian.Uint16(data[0:2])
+	p.FragIndex = uint8(v >> 14)
+	p.NbFragReceived = v & 0x3fff
+	p.MissingFrag = data[2]
+	p.NotEnoughMatrixMemory = data[3]&0x01 != 0
+	return nil
+}
+
+// FragSessionSetupReqPayload implements the FragSessionSetupReq payload.
+type FragSessionSetupReqPayload struct {
+	FragIndex uint8
+	// McGroupBitMask defines the multicast-groups (bit 0 - 3) allowed as
+	// input to this fragmentation session. When 0, only unicast is used.
+	McGroupBitMask uint8
+	// NbFrag defines the number of uncoded fragments.
+	NbFrag uint16
+	// FragSize defines the size of each fragment in bytes.
+	FragSize uint8
+	// FragmentationMatrix defines the fragmentation algorithm used (0 is
+	// the only defined algorithm).
+	FragmentationMatrix uint8
+	// BlockAckDelay defines the random delay (2^(BlockAckDelay + 4)
+	// seconds) before the device sends the data block ack.
+	BlockAckDelay uint8
+	// Padding defines the number of padding bytes added to the last
+	// fragment.
+	Padding uint8
+	// Descriptor is a freely allocated field describing the data block
+	// (e.g. the firmware version).
+	Descriptor [4]byte
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p FragSessionSetupReqPayload) MarshalBinary() ([]byte, error) {
+	if p.FragIndex > 3 {
+		return nil, errors.New("max FragIndex value is 3")
+	}
+	if p.McGroupBitMask > 15 {
+		return nil, errors.New("max McGroupBitMask value is 15")
+	}
+	if p.FragmentationMatrix > 7 {
+		return nil, errors.New("max FragmentationMatrix value is 7")
+	}
+	if p.BlockAckDelay > 7 {
+		return nil, errors.New("max BlockAckDelay value is 7")
+	}
+
+	b := make([]byte, 10)
+	b[0] = p.FragIndex<<4 | p.McGroupBitMask
+	binary.LittleEndian.PutUint16(b[1:3], p.NbFrag)
+	b[3] = p.FragSize
+	b[4] = p.FragmentationMatrix<<3 | p.BlockAckDelay
+	b[5] = p.Padding
+	copy(b[6:10], p.Descriptor[:])
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *FragSessionSetupReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 10 {
+		return errors.New("10 bytes are expected")
+	}
+	p.FragIndex = (data[0] >> 4) & 0x03
+	p.McGroupBitMask = data[0] & 0x0f
+	p.NbFrag = binary.LittleEndian.Uint16(data[1:3])
+	p.FragSize = data[3]
+	p.FragmentationMatrix = (data[4] >> 3) & 0x07
+	p.BlockAckDelay = data[4] & 0x07
+	p.Padding = data[5]
+	copy(p.Descriptor[:], data[6:10])
+	return nil
+}
+
+// FragSessionSetupAnsPayload implements the FragSessionSetupAns payload.
+type FragSessionSetupAnsPayload struct {
+	FragIndex                    uint8
+	WrongDescriptor              bool
+	FragSessionIndexNotSupported bool
+	NotEnoughMemory              bool
+	EncodingUnsupported          bool
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p FragSessionSetupAnsPayload) MarshalBinary() ([]byte, error) {
+	b := (p.FragIndex & 0x03) << 6
+	if p.WrongDescriptor {
+		b |= 1 << 3
+	}
+	if p.FragSessionIndexNotSupported {
+		b |= 1 << 2
+	}
+	if p.NotEnoughMemory {
+		b |= 1 << 1
+	}
+	if p.EncodingUnsupported {
+		b |= 1
+	}
+	return []byte{b}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *FragSessionSetupAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.FragIndex = data[0] >> 6
+	p.WrongDescriptor = data[0]&(1<<3) != 0
+	p.FragSessionIndexNotSupported = data[0]&(1<<2) != 0
+	p.NotEnoughMemory = data[0]&(1<<1) != 0
+	p.EncodingUnsupported = data[0]&1 != 0
+	return nil
+}
+
+// FragSessionDeleteReqPayload implements the FragSessionDeleteReq payload.
+type FragSessionDeleteReqPayload struct {
+	FragIndex uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p FragSessionDeleteReqPayload) MarshalBinary() ([]byte, error) {
+	if p.FragIndex > 3 {
+		return nil, errors.New("max FragIndex value is 3")
+	}
+	return []byte{p.FragIndex}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *FragSessionDeleteReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.FragIndex = data[0] & 0x03
+	return nil
+}
+
+// FragSessionDeleteAnsPayload implements the FragSessionDeleteAns payload.
+type FragSessionDeleteAnsPayload struct {
+	FragIndex           uint8
+	SessionDoesNotExist bool
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p FragSessionDeleteAnsPayload) MarshalBinary() ([]byte, error) {
+	b := p.FragIndex & 0x03
+	if p.SessionDoesNotExist {
+		b |= 1 << 2
+	}
+	return []byte{b}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *FragSessionDeleteAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.FragIndex = data[0] & 0x03
+	p.SessionDoesNotExist = data[0]&(1<<2) != 0
+	return nil
+}
+
+// DataFragmentPayload implements the DataFragment payload.
+type DataFragmentPayload struct {
+	FragIndex uint8
+	// N defines the fragment number (starting at 1). Fragments 1 - NbFrag
+	// are the uncoded fragments, the fragments after NbFrag are the
+	// redundancy (coded) fragments.
+	N       uint16
+	Payload []byte
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p DataFragmentPayload) MarshalBinary() ([]byte, error) {
+	if p.FragIndex > 3 {
+		return nil, errors.New("max FragIndex value is 3")
+	}
+	if p.N >= 1<<14 {
+		return nil, errors.New("max N value is 2^14 - 1")
+	}
+
+	b := make([]byte, 2, 2+len(p.Payload))
+	binary.LittleEndian.PutUint16(b, uint16(p.FragIndex)<<14|p.N)
+	return append(b, p.Payload...), nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *DataFragmentPayload) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("at least 2 bytes are expected")
+	}
+	v := binary.LittleEndian.Uint16(data[0:2])
+	p.FragIndex = uint8(v >> 14)
+	p.N = v & 0x3fff
+	p.Payload = append([]byte{}, data[2:]...)
+	return nil
+}
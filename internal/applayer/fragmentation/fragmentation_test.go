@@ -0,0 +1,174 @@
+package fragmentation
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCommands(t *testing.T) {
+	Convey("Given a set of downlink commands", t, func() {
+		tests := []struct {
+			Name    string
+			Command Command
+			Bytes   []byte
+		}{
+			{
+				Name: "FragSessionSetupReq",
+				Command: Command{
+					CID: FragSessionSetupReq,
+					Payload: &FragSessionSetupReqPayload{
+						FragIndex:      1,
+						McGroupBitMask: 2,
+						NbFrag:         300,
+						FragSize:       50,
+						BlockAckDelay:  3,
+						Padding:        7,
+						Descriptor:     [4]byte{1, 2, 3, 4},
+					},
+				},
+				Bytes: []byte{0x02, 0x12, 0x2c, 0x01, 0x32, 0x03, 0x07, 0x01, 0x02, 0x03, 0x04},
+			},
+			{
+				Name: "FragSessionStatusReq",
+				Command: Command{
+					CID: FragSessionStatusReq,
+					Payload: &FragSessionStatusReqPayload{
+						FragIndex:    2,
+						Participants: true,
+					},
+				},
+				Bytes: []byte{0x01, 0x05},
+			},
+			{
+				Name: "FragSessionDeleteReq",
+				Command: Command{
+					CID: FragSessionDeleteReq,
+					Payload: &FragSessionDeleteReqPayload{
+						FragIndex: 3,
+					},
+				},
+				Bytes: []byte{0x03, 0x03},
+			},
+			{
+				Name: "DataFragment",
+				Command: Command{
+					CID: DataFragment,
+					Payload: &DataFragmentPayload{
+						FragIndex: 1,
+						N:         258,
+						Payload:   []byte{1, 2, 3},
+					},
+				},
+				Bytes: []byte{0x08, 0x02, 0x41, 1, 2, 3},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				b, err := test.Command.MarshalBinary()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, test.Bytes)
+			})
+		}
+	})
+
+	Convey("Given an uplink payload containing multiple answers", t, func() {
+		b := []byte{
+			0x00, 0x03, 0x01, // PackageVersionAns
+			0x02, 0x42, // FragSessionSetupAns with NotEnoughMemory
+			0x01, 0x0a, 0x40, 0x02, 0x00, // FragSessionStatusAns
+			0x03, 0x05, // FragSessionDeleteAns with SessionDoesNotExist
+		}
+
+		Convey("Then UnmarshalUplinkCommands returns the expected commands", func() {
+			cmds, err := UnmarshalUplinkCommands(b)
+			So(err, ShouldBeNil)
+			So(cmds, ShouldResemble, Commands{
+				{CID: PackageVersionAns, Payload: &PackageVersionAnsPayload{PackageIdentifier: 3, PackageVersion: 1}},
+				{CID: FragSessionSetupAns, Payload: &FragSessionSetupAnsPayload{FragIndex: 1, NotEnoughMemory: true}},
+				{CID: FragSessionStatusAns, Payload: &FragSessionStatusAnsPayload{FragIndex: 1, NbFragReceived: 10, MissingFrag: 2}},
+				{CID: FragSessionDeleteAns, Payload: &FragSessionDeleteAnsPayload{FragIndex: 1, SessionDoesNotExist: true}},
+			})
+		})
+	})
+
+	Convey("Given an uplink payload with an unsupported CID", t, func() {
+		_, err := UnmarshalUplinkCommands([]byte{0x08, 0x00})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestEncode(t *testing.T) {
+	Convey("Given a data block which is not a multiple of the fragment-size", t, func() {
+		data := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22}
+
+		Convey("Then Encode returns an error", func() {
+			_, err := Encode(data, 5, 2)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("When padding the data", func() {
+			padded, padding := Pad(data, 5)
+			So(padding, ShouldEqual, 3)
+			So(padded, ShouldHaveLength, 25)
+
+			Convey("When encoding the data with redundancy", func() {
+				fragments, err := Encode(padded, 5, 4)
+				So(err, ShouldBeNil)
+
+				Convey("Then the uncoded and redundancy fragments are returned", func() {
+					So(fragments, ShouldHaveLength, 9)
+					for i := 0; i < 5; i++ {
+						So(fragments[i], ShouldResemble, padded[i*5:i*5+5])
+					}
+				})
+
+				Convey("Then each redundancy fragment is the XOR of the fragments of its matrix line", func() {
+					for n := 1; n <= 4; n++ {
+						line := matrixLine(n, 5)
+
+						var count int
+						exp := make([]byte, 5)
+						for i, set := range line {
+							if set {
+								count++
+								for j := range exp {
+									exp[j] ^= fragments[i][j]
+								}
+							}
+						}
+						So(count, ShouldEqual, 2)
+						So(fragments[4+n], ShouldResemble, exp)
+					}
+				})
+
+				Convey("Then a lost fragment can be recovered using a redundancy fragment", func() {
+					line := matrixLine(1, 5)
+
+					var lost int
+					for i, set := range line {
+						if set {
+							lost = i
+							break
+						}
+					}
+
+					recovered := append([]byte{}, fragments[5]...)
+					for i, set := range line {
+						if set && i != lost {
+							for j := range recovered {
+								recovered[j] ^= fragments[i][j]
+							}
+						}
+					}
+					So(recovered, ShouldResemble, fragments[lost])
+				})
+			})
+		})
+	})
+}
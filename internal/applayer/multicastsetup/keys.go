@@ -0,0 +1,73 @@
+package multicastsetup
+
+import (
+	"crypto/aes"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// GetMcRootKeyForGenAppKey returns the McRootKey given a GenAppKey
+// (LoRaWAN 1.0.x devices).
+func GetMcRootKeyForGenAppKey(genAppKey lorawan.AES128Key) (lorawan.AES128Key, error) {
+	return encrypt(genAppKey, [16]byte{})
+}
+
+// GetMcRootKeyForAppKey returns the McRootKey given an AppKey (LoRaWAN 1.1
+// devices).
+func GetMcRootKeyForAppKey(appKey lorawan.AES128Key) (lorawan.AES128Key, error) {
+	return encrypt(appKey, [16]byte{0x20})
+}
+
+// GetMcKEKey returns the McKEKey given the McRootKey.
+func GetMcKEKey(mcRootKey lorawan.AES128Key) (lorawan.AES128Key, error) {
+	return encrypt(mcRootKey, [16]byte{})
+}
+
+// GetMcKeyEncrypted returns the encrypted McKey as sent in the
+// McGroupSetupReq. The device obtains the McKey by encrypting this value
+// with the McKEKey.
+func GetMcKeyEncrypted(mcKEKey, mcKey lorawan.AES128Key) (lorawan.AES128Key, error) {
+	var out lorawan.AES128Key
+
+	block, err := aes.NewCipher(mcKEKey[:])
+	if err != nil {
+		return out, errors.Wrap(err, "new cipher error")
+	}
+	block.Decrypt(out[:], mcKey[:])
+
+	return out, nil
+}
+
+// GetMcAppSKey returns the McAppSKey given the McKey and McAddr.
+func GetMcAppSKey(mcKey lorawan.AES128Key, mcAddr lorawan.DevAddr) (lorawan.AES128Key, error) {
+	return encrypt(mcKey, mcAddrBlock(0x01, mcAddr))
+}
+
+// GetMcNetSKey returns the McNetSKey given the McKey and McAddr.
+func GetMcNetSKey(mcKey lorawan.AES128Key, mcAddr lorawan.DevAddr) (lorawan.AES128Key, error) {
+	return encrypt(mcKey, mcAddrBlock(0x02, mcAddr))
+}
+
+func mcAddrBlock(prefix byte, mcAddr lorawan.DevAddr) [16]byte {
+	var b [16]byte
+	b[0] = prefix
+	// McAddr is encoded little-endian
+	for i := 0; i < 4; i++ {
+		b[1+i] = mcAddr[3-i]
+	}
+	return b
+}
+
+func encrypt(key lorawan.AES128Key, b [16]byte) (lorawan.AES128Key, error) {
+	var out lorawan.AES128Key
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return out, errors.Wrap(err, "new cipher error")
+	}
+	block.Encrypt(out[:], b[:])
+
+	return out, nil
+}
@@ -0,0 +1,453 @@
+// Package multicastsetup implements the LoRaWAN Remote Multicast Setup
+// application-layer package (v1.0.0), used to provision the multicast
+// session keys (McKey) and to start class B / C multicast sessions on
+// devices.
+package multicastsetup
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// DefaultFPort defines the FPort used by the Remote Multicast Setup package.
+const DefaultFPort uint8 = 200
+
+// PackageIdentifier and PackageVersion implemented by this package.
+const (
+	PackageIdentifier uint8 = 2
+	PackageVersion    uint8 = 1
+)
+
+// CID defines the command identifier.
+type CID byte
+
+// Available command identifiers.
+const (
+	PackageVersionReq  CID = 0x00
+	PackageVersionAns  CID = 0x00
+	McGroupSetupReq    CID = 0x02
+	McGroupSetupAns    CID = 0x02
+	McGroupDeleteReq   CID = 0x03
+	McGroupDeleteAns   CID = 0x03
+	McClassCSessionReq CID = 0x04
+	McClassCSessionAns CID = 0x04
+	McClassBSessionReq CID = 0x05
+	McClassBSessionAns CID = 0x05
+)
+
+// CommandPayload defines the interface that a command payload must implement.
+type CommandPayload interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+}
+
+// Command defines a Remote Multicast Setup command.
+type Command struct {
+	CID     CID
+	Payload CommandPayload
+}
+
+// MarshalBinary encodes the command to a slice of bytes.
+func (c Command) MarshalBinary() ([]byte, error) {
+	b := []byte{byte(c.CID)}
+
+	if c.Payload != nil {
+		p, err := c.Payload.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal payload error")
+		}
+		b = append(b, p...)
+	}
+
+	return b, nil
+}
+
+// Commands defines a slice of commands.
+type Commands []Command
+
+// MarshalBinary encodes the commands to a slice of bytes.
+func (c Commands) MarshalBinary() ([]byte, error) {
+	var out []byte
+
+	for _, cmd := range c {
+		b, err := cmd.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalUplinkCommands decodes the given uplink payload (sent by the
+// device) into a slice of commands.
+func UnmarshalUplinkCommands(b []byte) (Commands, error) {
+	var out Commands
+
+	for len(b) > 0 {
+		cmd := Command{
+			CID: CID(b[0]),
+		}
+		b = b[1:]
+
+		var size int
+		switch cmd.CID {
+		case PackageVersionAns:
+			cmd.Payload = &PackageVersionAnsPayload{}
+			size = 2
+		case McGroupSetupAns:
+			cmd.Payload = &McGroupSetupAnsPayload{}
+			size = 1
+		case McGroupDeleteAns:
+			cmd.Payload = &McGroupDeleteAnsPayload{}
+			size = 1
+		case McClassCSessionAns, McClassBSessionAns:
+			cmd.Payload = &McClassSessionAnsPayload{}
+			size = 1
+			// the TimeToStart field is only present when there is no error
+			if len(b) > 0 && b[0]&0x1c == 0 {
+				size = 4
+			}
+		default:
+			return nil, fmt.Errorf("unsupported CID: %d", cmd.CID)
+		}
+
+		if len(b) < size {
+			return nil, fmt.Errorf("not enough bytes for CID %d payload, expected %d bytes", cmd.CID, size)
+		}
+
+		if err := cmd.Payload.UnmarshalBinary(b[:size]); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal CID %d payload error", cmd.CID)
+		}
+		b = b[size:]
+
+		out = append(out, cmd)
+	}
+
+	return out, nil
+}
+
+// PackageVersionAnsPayload implements the PackageVersionAns payload.
+type PackageVersionAnsPayload struct {
+	PackageIdentifier uint8
+	PackageVersion    uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p PackageVersionAnsPayload) MarshalBinary() ([]byte, error) {
+	return []byte{p.PackageIdentifier, p.PackageVersion}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *PackageVersionAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return errors.New("2 bytes are expected")
+	}
+	p.PackageIdentifier = data[0]
+	p.PackageVersion = data[1]
+	return nil
+}
+
+// McGroupSetupReqPayload implements the McGroupSetupReq payload.
+type McGroupSetupReqPayload struct {
+	McGroupID      uint8
+	McAddr         lorawan.DevAddr
+	McKeyEncrypted lorawan.AES128Key
+	MinMcFCnt      uint32
+	MaxMcFCnt      uint32
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McGroupSetupReqPayload) MarshalBinary() ([]byte, error) {
+	if p.McGroupID > 3 {
+		return nil, errors.New("max McGroupID value is 3")
+	}
+
+	b := make([]byte, 29)
+	b[0] = p.McGroupID
+	// McAddr is encoded little-endian
+	for i := 0; i < 4; i++ {
+		b[1+i] = p.McAddr[3-i]
+	}
+	copy(b[5:21], p.McKeyEncrypted[:])
+	binary.LittleEndian.PutUint32(b[21:25], p.MinMcFCnt)
+	binary.LittleEndian.PutUint32(b[25:29], p.MaxMcFCnt)
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McGroupSetupReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 29 {
+		return errors.New("29 bytes are expected")
+	}
+
+	p.McGroupID = data[0] & 0x03
+	for i := 0; i < 4; i++ {
+		p.McAddr[3-i] = data[1+i]
+	}
+	copy(p.McKeyEncrypted[:], data[5:21])
+	p.MinMcFCnt = binary.LittleEndian.Uint32(data[21:25])
+	p.MaxMcFCnt = binary.LittleEndian.Uint32(data[25:29])
+
+	return nil
+}
+
+// McGroupSetupAnsPayload implements the McGroupSetupAns payload.
+type McGroupSetupAnsPayload struct {
+	McGroupID uint8
+	IDError   bool
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McGroupSetupAnsPayload) MarshalBinary() ([]byte, error) {
+	b := p.McGroupID & 0x03
+	if p.IDError {
+		b |= 1 << 2
+	}
+	return []byte{b}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McGroupSetupAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.McGroupID = data[0] & 0x03
+	p.IDError = data[0]&(1<<2) != 0
+	return nil
+}
+
+// McGroupDeleteReqPayload implements the McGroupDeleteReq payload.
+type McGroupDeleteReqPayload struct {
+	McGroupID uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McGroupDeleteReqPayload) MarshalBinary() ([]byte, error) {
+	if p.McGroupID > 3 {
+		return nil, errors.New("max McGroupID value is 3")
+	}
+	return []byte{p.McGroupID}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McGroupDeleteReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.McGroupID = data[0] & 0x03
+	return nil
+}
+
+// McGroupDeleteAnsPayload implements the McGroupDeleteAns payload.
+type McGroupDeleteAnsPayload struct {
+	McGroupID        uint8
+	McGroupUndefined bool
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McGroupDeleteAnsPayload) MarshalBinary() ([]byte, error) {
+	b := p.McGroupID & 0x03
+	if p.McGroupUndefined {
+		b |= 1 << 2
+	}
+	return []byte{b}, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McGroupDeleteAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return errors.New("1 byte is expected")
+	}
+	p.McGroupID = data[0] & 0x03
+	p.McGroupUndefined = data[0]&(1<<2) != 0
+	return nil
+}
+
+// McClassCSessionReqPayload implements the McClassCSessionReq payload.
+type McClassCSessionReqPayload struct {
+	McGroupID uint8
+	// SessionTime defines the start of the session in seconds since the
+	// GPS epoch (modulo 2^32).
+	SessionTime uint32
+	// SessionTimeOut defines the max. duration of the session
+	// (2^SessionTimeOut seconds).
+	SessionTimeOut uint8
+	// DLFrequency defines the downlink frequency in Hz.
+	DLFrequency uint32
+	DR          uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McClassCSessionReqPayload) MarshalBinary() ([]byte, error) {
+	if p.McGroupID > 3 {
+		return nil, errors.New("max McGroupID value is 3")
+	}
+	if p.SessionTimeOut > 15 {
+		return nil, errors.New("max SessionTimeOut value is 15")
+	}
+
+	b := make([]byte, 10)
+	b[0] = p.McGroupID
+	binary.LittleEndian.PutUint32(b[1:5], p.SessionTime)
+	b[5] = p.SessionTimeOut
+	if err := putFrequency(b[6:9], p.DLFrequency); err != nil {
+		return nil, err
+	}
+	b[9] = p.DR
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McClassCSessionReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 10 {
+		return errors.New("10 bytes are expected")
+	}
+	p.McGroupID = data[0] & 0x03
+	p.SessionTime = binary.LittleEndian.Uint32(data[1:5])
+	p.SessionTimeOut = data[5] & 0x0f
+	p.DLFrequency = getFrequency(data[6:9])
+	p.DR = data[9]
+	return nil
+}
+
+// McClassBSessionReqPayload implements the McClassBSessionReq payload.
+type McClassBSessionReqPayload struct {
+	McGroupID uint8
+	// SessionTime defines the start of the session in seconds since the
+	// GPS epoch (modulo 2^32).
+	SessionTime uint32
+	// TimeOut defines the max. duration of the session
+	// (2^TimeOut beacon periods).
+	TimeOut uint8
+	// Periodicity defines the ping-slot periodicity
+	// (2^Periodicity seconds).
+	Periodicity uint8
+	// DLFrequency defines the downlink frequency in Hz.
+	DLFrequency uint32
+	DR          uint8
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McClassBSessionReqPayload) MarshalBinary() ([]byte, error) {
+	if p.McGroupID > 3 {
+		return nil, errors.New("max McGroupID value is 3")
+	}
+	if p.TimeOut > 15 {
+		return nil, errors.New("max TimeOut value is 15")
+	}
+	if p.Periodicity > 7 {
+		return nil, errors.New("max Periodicity value is 7")
+	}
+
+	b := make([]byte, 10)
+	b[0] = p.McGroupID
+	binary.LittleEndian.PutUint32(b[1:5], p.SessionTime)
+	b[5] = p.Periodicity<<4 | p.TimeOut
+	if err := putFrequency(b[6:9], p.DLFrequency); err != nil {
+		return nil, err
+	}
+	b[9] = p.DR
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McClassBSessionReqPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 10 {
+		return errors.New("10 bytes are expected")
+	}
+	p.McGroupID = data[0] & 0x03
+	p.SessionTime = binary.LittleEndian.Uint32(data[1:5])
+	p.TimeOut = data[5] & 0x0f
+	p.Periodicity = (data[5] >> 4) & 0x07
+	p.DLFrequency = getFrequency(data[6:9])
+	p.DR = data[9]
+	return nil
+}
+
+// McClassSessionAnsPayload implements the McClassCSessionAns and
+// McClassBSessionAns payloads.
+type McClassSessionAnsPayload struct {
+	McGroupID        uint8
+	McGroupUndefined bool
+	FreqError        bool
+	DRError          bool
+	// TimeToStart defines the time in seconds until the session starts.
+	// It is only set when none of the error flags are set.
+	TimeToStart *uint32
+}
+
+// MarshalBinary encodes the payload to a slice of bytes.
+func (p McClassSessionAnsPayload) MarshalBinary() ([]byte, error) {
+	b := []byte{p.McGroupID & 0x03}
+	if p.DRError {
+		b[0] |= 1 << 2
+	}
+	if p.FreqError {
+		b[0] |= 1 << 3
+	}
+	if p.McGroupUndefined {
+		b[0] |= 1 << 4
+	}
+
+	if p.TimeToStart != nil {
+		if *p.TimeToStart >= 1<<24 {
+			return nil, errors.New("max TimeToStart value is 2^24 - 1")
+		}
+		ts := make([]byte, 4)
+		binary.LittleEndian.PutUint32(ts, *p.TimeToStart)
+		b = append(b, ts[:3]...)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes the payload from a slice of bytes.
+func (p *McClassSessionAnsPayload) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 && len(data) != 4 {
+		return errors.New("1 or 4 bytes are expected")
+	}
+
+	p.McGroupID = data[0] & 0x03
+	p.DRError = data[0]&(1<<2) != 0
+	p.FreqError = data[0]&(1<<3) != 0
+	p.McGroupUndefined = data[0]&(1<<4) != 0
+	p.TimeToStart = nil
+
+	if len(data) == 4 {
+		ts := binary.LittleEndian.Uint32(append(append([]byte{}, data[1:4]...), 0))
+		p.TimeToStart = &ts
+	}
+
+	return nil
+}
+
+// putFrequency encodes the frequency (in Hz) as 24 bit value in steps of
+// 100 Hz.
+func putFrequency(b []byte, freq uint32) error {
+	if freq%100 != 0 {
+		return errors.New("frequency must be a multiple of 100")
+	}
+	freq = freq / 100
+	if freq >= 1<<24 {
+		return errors.New("max frequency value is 2^24 - 1")
+	}
+
+	ts := make([]byte, 4)
+	binary.LittleEndian.PutUint32(ts, freq)
+	copy(b, ts[:3])
+
+	return nil
+}
+
+func getFrequency(b []byte) uint32 {
+	return binary.LittleEndian.Uint32(append(append([]byte{}, b[:3]...), 0)) * 100
+}
@@ -0,0 +1,163 @@
+package multicastsetup
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestCommands(t *testing.T) {
+	Convey("Given a set of downlink commands", t, func() {
+		timeToStart := uint32(0x010203)
+
+		tests := []struct {
+			Name    string
+			Command Command
+			Bytes   []byte
+		}{
+			{
+				Name: "McGroupSetupReq",
+				Command: Command{
+					CID: McGroupSetupReq,
+					Payload: &McGroupSetupReqPayload{
+						McGroupID:      2,
+						McAddr:         lorawan.DevAddr{1, 2, 3, 4},
+						McKeyEncrypted: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+						MinMcFCnt:      10,
+						MaxMcFCnt:      0xffffffff,
+					},
+				},
+				Bytes: []byte{0x02, 0x02, 4, 3, 2, 1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 10, 0, 0, 0, 0xff, 0xff, 0xff, 0xff},
+			},
+			{
+				Name: "McGroupDeleteReq",
+				Command: Command{
+					CID: McGroupDeleteReq,
+					Payload: &McGroupDeleteReqPayload{
+						McGroupID: 3,
+					},
+				},
+				Bytes: []byte{0x03, 0x03},
+			},
+			{
+				Name: "McClassCSessionReq",
+				Command: Command{
+					CID: McClassCSessionReq,
+					Payload: &McClassCSessionReqPayload{
+						McGroupID:      1,
+						SessionTime:    0x01020304,
+						SessionTimeOut: 12,
+						DLFrequency:    869525000,
+						DR:             3,
+					},
+				},
+				Bytes: []byte{0x04, 0x01, 0x04, 0x03, 0x02, 0x01, 0x0c, 0xd2, 0xad, 0x84, 0x03},
+			},
+			{
+				Name: "McClassBSessionReq",
+				Command: Command{
+					CID: McClassBSessionReq,
+					Payload: &McClassBSessionReqPayload{
+						McGroupID:   1,
+						SessionTime: 0x01020304,
+						TimeOut:     12,
+						Periodicity: 3,
+						DLFrequency: 869525000,
+						DR:          3,
+					},
+				},
+				Bytes: []byte{0x05, 0x01, 0x04, 0x03, 0x02, 0x01, 0x3c, 0xd2, 0xad, 0x84, 0x03},
+			},
+			{
+				Name: "McClassCSessionAns",
+				Command: Command{
+					CID: McClassCSessionAns,
+					Payload: &McClassSessionAnsPayload{
+						McGroupID:   1,
+						TimeToStart: &timeToStart,
+					},
+				},
+				Bytes: []byte{0x04, 0x01, 0x03, 0x02, 0x01},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				b, err := test.Command.MarshalBinary()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, test.Bytes)
+			})
+		}
+	})
+
+	Convey("Given an uplink payload containing multiple answers", t, func() {
+		b := []byte{
+			0x00, 0x02, 0x01, // PackageVersionAns
+			0x02, 0x05, // McGroupSetupAns with IDError
+			0x04, 0x09, // McClassCSessionAns with FreqError
+			0x05, 0x02, 0x0a, 0x00, 0x00, // McClassBSessionAns
+		}
+
+		Convey("Then UnmarshalUplinkCommands returns the expected commands", func() {
+			timeToStart := uint32(10)
+
+			cmds, err := UnmarshalUplinkCommands(b)
+			So(err, ShouldBeNil)
+			So(cmds, ShouldResemble, Commands{
+				{CID: PackageVersionAns, Payload: &PackageVersionAnsPayload{PackageIdentifier: 2, PackageVersion: 1}},
+				{CID: McGroupSetupAns, Payload: &McGroupSetupAnsPayload{McGroupID: 1, IDError: true}},
+				{CID: McClassCSessionAns, Payload: &McClassSessionAnsPayload{McGroupID: 1, FreqError: true}},
+				{CID: McClassBSessionAns, Payload: &McClassSessionAnsPayload{McGroupID: 2, TimeToStart: &timeToStart}},
+			})
+		})
+	})
+
+	Convey("Given an uplink payload with an unsupported CID", t, func() {
+		_, err := UnmarshalUplinkCommands([]byte{0x01, 0x00})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestKeys(t *testing.T) {
+	Convey("Given a GenAppKey, McKey and McAddr", t, func() {
+		genAppKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+		mcKey := lorawan.AES128Key{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+		mcAddr := lorawan.DevAddr{1, 2, 3, 4}
+
+		Convey("Then the device recovers the McKey from the encrypted McKey", func() {
+			mcRootKey, err := GetMcRootKeyForGenAppKey(genAppKey)
+			So(err, ShouldBeNil)
+			mcKEKey, err := GetMcKEKey(mcRootKey)
+			So(err, ShouldBeNil)
+
+			mcKeyEncrypted, err := GetMcKeyEncrypted(mcKEKey, mcKey)
+			So(err, ShouldBeNil)
+			So(mcKeyEncrypted, ShouldNotResemble, mcKey)
+
+			recovered, err := encrypt(mcKEKey, mcKeyEncrypted)
+			So(err, ShouldBeNil)
+			So(recovered, ShouldResemble, mcKey)
+		})
+
+		Convey("Then the McAppSKey and McNetSKey are derived from the McKey", func() {
+			mcAppSKey, err := GetMcAppSKey(mcKey, mcAddr)
+			So(err, ShouldBeNil)
+			mcNetSKey, err := GetMcNetSKey(mcKey, mcAddr)
+			So(err, ShouldBeNil)
+
+			exp, err := encrypt(mcKey, [16]byte{0x01, 4, 3, 2, 1})
+			So(err, ShouldBeNil)
+			So(mcAppSKey, ShouldResemble, exp)
+
+			exp, err = encrypt(mcKey, [16]byte{0x02, 4, 3, 2, 1})
+			So(err, ShouldBeNil)
+			So(mcNetSKey, ShouldResemble, exp)
+		})
+	})
+}
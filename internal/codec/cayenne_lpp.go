@@ -1,14 +1,86 @@
 package codec
 
 import (
-	"bytes"
+	"encoding/binary"
 	"encoding/gob"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
 	"github.com/pkg/errors"
-	"github.com/selvakn/go-cayenne-lib/cayennelpp"
 )
 
 func init() {
 	gob.Register(CayenneLPP{})
+	gob.Register(Accelerometer{})
+	gob.Register(Gyrometer{})
+	gob.Register(GPSLocation{})
+	gob.Register(Colour{})
+}
+
+// Cayenne LPP (and IPSO based extension) data types.
+const (
+	lppDigitalInput      byte = 0
+	lppDigitalOutput     byte = 1
+	lppAnalogInput       byte = 2
+	lppAnalogOutput      byte = 3
+	lppGenericSensor     byte = 100
+	lppIlluminanceSensor byte = 101
+	lppPresenceSensor    byte = 102
+	lppTemperatureSensor byte = 103
+	lppHumiditySensor    byte = 104
+	lppAccelerometer     byte = 113
+	lppBarometer         byte = 115
+	lppVoltage           byte = 116
+	lppCurrent           byte = 117
+	lppFrequency         byte = 118
+	lppPercentage        byte = 120
+	lppAltitude          byte = 121
+	lppConcentration     byte = 125
+	lppPower             byte = 128
+	lppDistance          byte = 130
+	lppEnergy            byte = 131
+	lppDirection         byte = 132
+	lppUnixTime          byte = 133
+	lppGyrometer         byte = 134
+	lppColour            byte = 135
+	lppGPSLocation       byte = 136
+	lppSwitch            byte = 142
+)
+
+// lppTypes contains the name (as used in the JSON object) and the data size
+// (in bytes) for each supported type.
+var lppTypes = map[byte]struct {
+	name string
+	size int
+}{
+	lppDigitalInput:      {"digitalInput", 1},
+	lppDigitalOutput:     {"digitalOutput", 1},
+	lppAnalogInput:       {"analogInput", 2},
+	lppAnalogOutput:      {"analogOutput", 2},
+	lppGenericSensor:     {"genericSensor", 4},
+	lppIlluminanceSensor: {"illuminanceSensor", 2},
+	lppPresenceSensor:    {"presenceSensor", 1},
+	lppTemperatureSensor: {"temperatureSensor", 2},
+	lppHumiditySensor:    {"humiditySensor", 1},
+	lppAccelerometer:     {"accelerometer", 6},
+	lppBarometer:         {"barometer", 2},
+	lppVoltage:           {"voltage", 2},
+	lppCurrent:           {"current", 2},
+	lppFrequency:         {"frequency", 4},
+	lppPercentage:        {"percentage", 1},
+	lppAltitude:          {"altitude", 2},
+	lppConcentration:     {"concentration", 2},
+	lppPower:             {"power", 2},
+	lppDistance:          {"distance", 4},
+	lppEnergy:            {"energy", 4},
+	lppDirection:         {"direction", 2},
+	lppUnixTime:          {"unixTime", 4},
+	lppGyrometer:         {"gyrometer", 6},
+	lppColour:            {"colour", 3},
+	lppGPSLocation:       {"gpsLocation", 9},
+	lppSwitch:            {"switch", 1},
 }
 
 // Accelerometer defines the accelerometer data.
@@ -32,12 +104,31 @@ type GPSLocation struct {
 	Altitude  float32 `json:"altitude"`
 }
 
+// Colour defines the RGB colour data.
+type Colour struct {
+	R uint8 `json:"r"`
+	G uint8 `json:"g"`
+	B uint8 `json:"b"`
+}
+
+// CayenneLPPHistoryItem defines a measurement which was reported more than
+// once (for the same channel and type) within a single payload. This is used
+// by devices sending multiple historical readings in one uplink, optionally
+// prefixed by a unix-time measurement.
+type CayenneLPPHistoryItem struct {
+	Time    *time.Time  `json:"time,omitempty"`
+	Channel uint8       `json:"channel"`
+	Type    string      `json:"type"`
+	Value   interface{} `json:"value"`
+}
+
 // CayenneLPP defines the Cayenne LPP data structure.
 type CayenneLPP struct {
 	DigitalInput      map[byte]uint8         `json:"digitalInput,omitempty" influxdb:"digital_input"`
 	DigitalOutput     map[byte]uint8         `json:"digitalOutput,omitempty" influxdb:"digital_output"`
 	AnalogInput       map[byte]float32       `json:"analogInput,omitempty" influxdb:"analog_input"`
 	AnalogOutput      map[byte]float32       `json:"analogOutput,omitempty" influxdb:"analog_output"`
+	GenericSensor     map[byte]uint32        `json:"genericSensor,omitempty" influxdb:"generic_sensor"`
 	IlluminanceSensor map[byte]uint16        `json:"illuminanceSensor,omitempty" influxdb:"illuminance_sensor"`
 	PresenceSensor    map[byte]uint8         `json:"presenceSensor,omitempty" influxdb:"presence_sensor"`
 	TemperatureSensor map[byte]float32       `json:"temperatureSensor,omitempty" influxdb:"temperature_sensor"`
@@ -50,10 +141,20 @@ type CayenneLPP struct {
 	Current           map[byte]float32       `json:"current,omitempty" influxdb:"current"`
 	Frequency         map[byte]float32       `json:"frequency,omitempty" influxdb:"frequency"`
 	Energy            map[byte]float32       `json:"energy,omitempty" influxdb:"energy"`
-}
-
-type target struct {
-	values *CayenneLPP
+	Percentage        map[byte]uint8         `json:"percentage,omitempty" influxdb:"percentage"`
+	Altitude          map[byte]int16         `json:"altitude,omitempty" influxdb:"altitude"`
+	Concentration     map[byte]uint16        `json:"concentration,omitempty" influxdb:"concentration"`
+	Power             map[byte]uint16        `json:"power,omitempty" influxdb:"power"`
+	Distance          map[byte]float32       `json:"distance,omitempty" influxdb:"distance"`
+	Direction         map[byte]uint16        `json:"direction,omitempty" influxdb:"direction"`
+	UnixTime          map[byte]uint32        `json:"unixTime,omitempty" influxdb:"unix_time"`
+	Colour            map[byte]Colour        `json:"colour,omitempty" influxdb:"colour"`
+	Switch            map[byte]uint8         `json:"switch,omitempty" influxdb:"switch"`
+
+	// History contains the earlier readings in case a channel / type
+	// combination was reported more than once. The maps above always contain
+	// the last reading.
+	History []CayenneLPPHistoryItem `json:"history,omitempty" influxdb:"-"`
 }
 
 // Object returns the CayenneLPP data object.
@@ -61,188 +162,364 @@ func (c CayenneLPP) Object() interface{} {
 	return c
 }
 
-func (t *target) Port(channel uint8, value float32) {
+type lppItem struct {
+	channel uint8
+	typ     byte
+	value   interface{}
+	time    *time.Time
 }
 
-func (t *target) DigitalInput(channel, value uint8) {
-	if t.values.DigitalInput == nil {
-		t.values.DigitalInput = make(map[uint8]uint8)
-	}
-	t.values.DigitalInput[channel] = value
-}
-
-func (t *target) DigitalOutput(channel, value uint8) {
-	if t.values.DigitalOutput == nil {
-		t.values.DigitalOutput = make(map[uint8]uint8)
-	}
-	t.values.DigitalOutput[channel] = value
-}
-
-func (t *target) AnalogInput(channel uint8, value float32) {
-	if t.values.AnalogInput == nil {
-		t.values.AnalogInput = make(map[uint8]float32)
-	}
-	t.values.AnalogInput[channel] = value
-}
-
-func (t *target) AnalogOutput(channel uint8, value float32) {
-	if t.values.AnalogOutput == nil {
-		t.values.AnalogOutput = make(map[uint8]float32)
-	}
-	t.values.AnalogOutput[channel] = value
-}
-
-func (t *target) Luminosity(channel uint8, value uint16) {
-	if t.values.IlluminanceSensor == nil {
-		t.values.IlluminanceSensor = make(map[uint8]uint16)
-	}
-	t.values.IlluminanceSensor[channel] = value
-}
-
-func (t *target) Presence(channel, value uint8) {
-	if t.values.PresenceSensor == nil {
-		t.values.PresenceSensor = make(map[uint8]uint8)
-	}
-	t.values.PresenceSensor[channel] = value
-}
-
-func (t *target) Temperature(channel uint8, celcius float32) {
-	if t.values.TemperatureSensor == nil {
-		t.values.TemperatureSensor = make(map[uint8]float32)
-	}
-	t.values.TemperatureSensor[channel] = celcius
-}
-
-func (t *target) RelativeHumidity(channel uint8, rh float32) {
-	if t.values.HumiditySensor == nil {
-		t.values.HumiditySensor = make(map[uint8]float32)
-	}
-	t.values.HumiditySensor[channel] = rh
-}
-
-func (t *target) Accelerometer(channel uint8, x, y, z float32) {
-	if t.values.Accelerometer == nil {
-		t.values.Accelerometer = make(map[uint8]Accelerometer)
-	}
-	t.values.Accelerometer[channel] = Accelerometer{X: x, Y: y, Z: z}
-}
-
-func (t *target) BarometricPressure(channel uint8, hpa float32) {
-	if t.values.Barometer == nil {
-		t.values.Barometer = make(map[uint8]float32)
+// DecodeBytes decodes the payload from a slice of bytes.
+// Decoding stops at the first unknown data type, the measurements decoded
+// until that point are kept.
+func (c *CayenneLPP) DecodeBytes(data []byte) error {
+	var items []lppItem
+	var ts *time.Time
+
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return errors.New("decode error: unexpected end of payload")
+		}
+		channel, typ := data[0], data[1]
+
+		t, ok := lppTypes[typ]
+		if !ok {
+			break
+		}
+		if len(data) < 2+t.size {
+			return errors.Errorf("decode error: not enough bytes for %s on channel %d", t.name, channel)
+		}
+
+		value := decodeLPPValue(typ, data[2:2+t.size])
+		if typ == lppUnixTime {
+			tt := time.Unix(int64(value.(uint32)), 0).UTC()
+			ts = &tt
+		}
+
+		items = append(items, lppItem{channel: channel, typ: typ, value: value, time: ts})
+		data = data[2+t.size:]
+	}
+
+	// keep track of the last index per channel / type so that the earlier
+	// readings can be moved to the history
+	last := make(map[[2]byte]int)
+	for i, item := range items {
+		last[[2]byte{item.typ, item.channel}] = i
+	}
+
+	for i, item := range items {
+		if last[[2]byte{item.typ, item.channel}] != i {
+			c.History = append(c.History, CayenneLPPHistoryItem{
+				Time:    item.time,
+				Channel: item.channel,
+				Type:    lppTypes[item.typ].name,
+				Value:   item.value,
+			})
+			continue
+		}
+		c.set(item.channel, item.typ, item.value)
 	}
-	t.values.Barometer[channel] = hpa
-}
 
-func (t *target) Gyrometer(channel uint8, x, y, z float32) {
-	if t.values.Gyrometer == nil {
-		t.values.Gyrometer = make(map[uint8]Gyrometer)
-	}
-	t.values.Gyrometer[channel] = Gyrometer{X: x, Y: y, Z: z}
+	return nil
 }
 
-func (t *target) GPS(channel uint8, latitude, longitude, altitude float32) {
-	if t.values.GPSLocation == nil {
-		t.values.GPSLocation = make(map[uint8]GPSLocation)
-	}
-	t.values.GPSLocation[channel] = GPSLocation{Latitude: latitude, Longitude: longitude, Altitude: altitude}
+// EncodeToBytes encodes the payload to a slice of bytes. This includes the
+// actuator types (digital / analog output, colour and switch) and can be used
+// for sending actuator commands to the device.
+func (c CayenneLPP) EncodeToBytes() ([]byte, error) {
+	var out []byte
+
+	add := func(typ byte, m interface{}) {
+		v := reflect.ValueOf(m)
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Uint() < keys[j].Uint() })
+
+		for _, k := range keys {
+			out = append(out, byte(k.Uint()), typ)
+			out = append(out, encodeLPPValue(typ, v.MapIndex(k).Interface())...)
+		}
+	}
+
+	add(lppUnixTime, c.UnixTime)
+	add(lppDigitalInput, c.DigitalInput)
+	add(lppDigitalOutput, c.DigitalOutput)
+	add(lppAnalogInput, c.AnalogInput)
+	add(lppAnalogOutput, c.AnalogOutput)
+	add(lppGenericSensor, c.GenericSensor)
+	add(lppIlluminanceSensor, c.IlluminanceSensor)
+	add(lppPresenceSensor, c.PresenceSensor)
+	add(lppTemperatureSensor, c.TemperatureSensor)
+	add(lppHumiditySensor, c.HumiditySensor)
+	add(lppAccelerometer, c.Accelerometer)
+	add(lppBarometer, c.Barometer)
+	add(lppVoltage, c.Voltage)
+	add(lppCurrent, c.Current)
+	add(lppFrequency, c.Frequency)
+	add(lppPercentage, c.Percentage)
+	add(lppAltitude, c.Altitude)
+	add(lppConcentration, c.Concentration)
+	add(lppPower, c.Power)
+	add(lppDistance, c.Distance)
+	add(lppEnergy, c.Energy)
+	add(lppDirection, c.Direction)
+	add(lppGyrometer, c.Gyrometer)
+	add(lppColour, c.Colour)
+	add(lppGPSLocation, c.GPSLocation)
+	add(lppSwitch, c.Switch)
+
+	return out, nil
 }
 
-func (t *target) Voltage(channel uint8, value float32) {
-	if t.values.Voltage == nil {
-		t.values.Voltage = make(map[uint8]float32)
+func (c *CayenneLPP) set(channel uint8, typ byte, value interface{}) {
+	switch typ {
+	case lppDigitalInput:
+		if c.DigitalInput == nil {
+			c.DigitalInput = make(map[byte]uint8)
+		}
+		c.DigitalInput[channel] = value.(uint8)
+	case lppDigitalOutput:
+		if c.DigitalOutput == nil {
+			c.DigitalOutput = make(map[byte]uint8)
+		}
+		c.DigitalOutput[channel] = value.(uint8)
+	case lppAnalogInput:
+		if c.AnalogInput == nil {
+			c.AnalogInput = make(map[byte]float32)
+		}
+		c.AnalogInput[channel] = value.(float32)
+	case lppAnalogOutput:
+		if c.AnalogOutput == nil {
+			c.AnalogOutput = make(map[byte]float32)
+		}
+		c.AnalogOutput[channel] = value.(float32)
+	case lppGenericSensor:
+		if c.GenericSensor == nil {
+			c.GenericSensor = make(map[byte]uint32)
+		}
+		c.GenericSensor[channel] = value.(uint32)
+	case lppIlluminanceSensor:
+		if c.IlluminanceSensor == nil {
+			c.IlluminanceSensor = make(map[byte]uint16)
+		}
+		c.IlluminanceSensor[channel] = value.(uint16)
+	case lppPresenceSensor:
+		if c.PresenceSensor == nil {
+			c.PresenceSensor = make(map[byte]uint8)
+		}
+		c.PresenceSensor[channel] = value.(uint8)
+	case lppTemperatureSensor:
+		if c.TemperatureSensor == nil {
+			c.TemperatureSensor = make(map[byte]float32)
+		}
+		c.TemperatureSensor[channel] = value.(float32)
+	case lppHumiditySensor:
+		if c.HumiditySensor == nil {
+			c.HumiditySensor = make(map[byte]float32)
+		}
+		c.HumiditySensor[channel] = value.(float32)
+	case lppAccelerometer:
+		if c.Accelerometer == nil {
+			c.Accelerometer = make(map[byte]Accelerometer)
+		}
+		c.Accelerometer[channel] = value.(Accelerometer)
+	case lppBarometer:
+		if c.Barometer == nil {
+			c.Barometer = make(map[byte]float32)
+		}
+		c.Barometer[channel] = value.(float32)
+	case lppVoltage:
+		if c.Voltage == nil {
+			c.Voltage = make(map[byte]float32)
+		}
+		c.Voltage[channel] = value.(float32)
+	case lppCurrent:
+		if c.Current == nil {
+			c.Current = make(map[byte]float32)
+		}
+		c.Current[channel] = value.(float32)
+	case lppFrequency:
+		if c.Frequency == nil {
+			c.Frequency = make(map[byte]float32)
+		}
+		c.Frequency[channel] = value.(float32)
+	case lppPercentage:
+		if c.Percentage == nil {
+			c.Percentage = make(map[byte]uint8)
+		}
+		c.Percentage[channel] = value.(uint8)
+	case lppAltitude:
+		if c.Altitude == nil {
+			c.Altitude = make(map[byte]int16)
+		}
+		c.Altitude[channel] = value.(int16)
+	case lppConcentration:
+		if c.Concentration == nil {
+			c.Concentration = make(map[byte]uint16)
+		}
+		c.Concentration[channel] = value.(uint16)
+	case lppPower:
+		if c.Power == nil {
+			c.Power = make(map[byte]uint16)
+		}
+		c.Power[channel] = value.(uint16)
+	case lppDistance:
+		if c.Distance == nil {
+			c.Distance = make(map[byte]float32)
+		}
+		c.Distance[channel] = value.(float32)
+	case lppEnergy:
+		if c.Energy == nil {
+			c.Energy = make(map[byte]float32)
+		}
+		c.Energy[channel] = value.(float32)
+	case lppDirection:
+		if c.Direction == nil {
+			c.Direction = make(map[byte]uint16)
+		}
+		c.Direction[channel] = value.(uint16)
+	case lppUnixTime:
+		if c.UnixTime == nil {
+			c.UnixTime = make(map[byte]uint32)
+		}
+		c.UnixTime[channel] = value.(uint32)
+	case lppGyrometer:
+		if c.Gyrometer == nil {
+			c.Gyrometer = make(map[byte]Gyrometer)
+		}
+		c.Gyrometer[channel] = value.(Gyrometer)
+	case lppColour:
+		if c.Colour == nil {
+			c.Colour = make(map[byte]Colour)
+		}
+		c.Colour[channel] = value.(Colour)
+	case lppGPSLocation:
+		if c.GPSLocation == nil {
+			c.GPSLocation = make(map[byte]GPSLocation)
+		}
+		c.GPSLocation[channel] = value.(GPSLocation)
+	case lppSwitch:
+		if c.Switch == nil {
+			c.Switch = make(map[byte]uint8)
+		}
+		c.Switch[channel] = value.(uint8)
 	}
-	t.values.Voltage[channel] = value
 }
 
-func (t *target) Current(channel uint8, value float32) {
-	if t.values.Current == nil {
-		t.values.Current = make(map[uint8]float32)
+func decodeLPPValue(typ byte, b []byte) interface{} {
+	switch typ {
+	case lppDigitalInput, lppDigitalOutput, lppPresenceSensor, lppPercentage, lppSwitch:
+		return b[0]
+	case lppAnalogInput, lppAnalogOutput:
+		return float32(int16(binary.BigEndian.Uint16(b))) / 100
+	case lppGenericSensor, lppUnixTime:
+		return binary.BigEndian.Uint32(b)
+	case lppIlluminanceSensor, lppConcentration, lppPower, lppDirection:
+		return binary.BigEndian.Uint16(b)
+	case lppTemperatureSensor:
+		return float32(int16(binary.BigEndian.Uint16(b))) / 10
+	case lppHumiditySensor:
+		return float32(b[0]) / 2
+	case lppAccelerometer:
+		return Accelerometer{
+			X: float32(int16(binary.BigEndian.Uint16(b[0:2]))) / 1000,
+			Y: float32(int16(binary.BigEndian.Uint16(b[2:4]))) / 1000,
+			Z: float32(int16(binary.BigEndian.Uint16(b[4:6]))) / 1000,
+		}
+	case lppBarometer:
+		return float32(binary.BigEndian.Uint16(b)) / 10
+	case lppVoltage:
+		return float32(binary.BigEndian.Uint16(b)) / 100
+	case lppCurrent:
+		return float32(binary.BigEndian.Uint16(b)) / 1000
+	case lppFrequency:
+		return float32(binary.BigEndian.Uint32(b))
+	case lppAltitude:
+		return int16(binary.BigEndian.Uint16(b))
+	case lppDistance, lppEnergy:
+		return float32(binary.BigEndian.Uint32(b)) / 1000
+	case lppGyrometer:
+		return Gyrometer{
+			X: float32(int16(binary.BigEndian.Uint16(b[0:2]))) / 100,
+			Y: float32(int16(binary.BigEndian.Uint16(b[2:4]))) / 100,
+			Z: float32(int16(binary.BigEndian.Uint16(b[4:6]))) / 100,
+		}
+	case lppColour:
+		return Colour{R: b[0], G: b[1], B: b[2]}
+	case lppGPSLocation:
+		return GPSLocation{
+			Latitude:  float32(int24(b[0:3])) / 10000,
+			Longitude: float32(int24(b[3:6])) / 10000,
+			Altitude:  float32(int24(b[6:9])) / 100,
+		}
 	}
-	t.values.Current[channel] = value
+	return nil
 }
 
-func (t *target) Frequency(channel uint8, value float32) {
-	if t.values.Frequency == nil {
-		t.values.Frequency = make(map[uint8]float32)
-	}
-	t.values.Frequency[channel] = value
+func encodeLPPValue(typ byte, value interface{}) []byte {
+	b := make([]byte, lppTypes[typ].size)
+
+	switch typ {
+	case lppDigitalInput, lppDigitalOutput, lppPresenceSensor, lppPercentage, lppSwitch:
+		b[0] = value.(uint8)
+	case lppAnalogInput, lppAnalogOutput:
+		binary.BigEndian.PutUint16(b, uint16(int16(scale(value.(float32), 100))))
+	case lppGenericSensor, lppUnixTime:
+		binary.BigEndian.PutUint32(b, value.(uint32))
+	case lppIlluminanceSensor, lppConcentration, lppPower, lppDirection:
+		binary.BigEndian.PutUint16(b, value.(uint16))
+	case lppTemperatureSensor:
+		binary.BigEndian.PutUint16(b, uint16(int16(scale(value.(float32), 10))))
+	case lppHumiditySensor:
+		b[0] = uint8(scale(value.(float32), 2))
+	case lppAccelerometer:
+		v := value.(Accelerometer)
+		binary.BigEndian.PutUint16(b[0:2], uint16(int16(scale(v.X, 1000))))
+		binary.BigEndian.PutUint16(b[2:4], uint16(int16(scale(v.Y, 1000))))
+		binary.BigEndian.PutUint16(b[4:6], uint16(int16(scale(v.Z, 1000))))
+	case lppBarometer:
+		binary.BigEndian.PutUint16(b, uint16(scale(value.(float32), 10)))
+	case lppVoltage:
+		binary.BigEndian.PutUint16(b, uint16(scale(value.(float32), 100)))
+	case lppCurrent:
+		binary.BigEndian.PutUint16(b, uint16(scale(value.(float32), 1000)))
+	case lppFrequency:
+		binary.BigEndian.PutUint32(b, uint32(scale(value.(float32), 1)))
+	case lppAltitude:
+		binary.BigEndian.PutUint16(b, uint16(value.(int16)))
+	case lppDistance, lppEnergy:
+		binary.BigEndian.PutUint32(b, uint32(scale(value.(float32), 1000)))
+	case lppGyrometer:
+		v := value.(Gyrometer)
+		binary.BigEndian.PutUint16(b[0:2], uint16(int16(scale(v.X, 100))))
+		binary.BigEndian.PutUint16(b[2:4], uint16(int16(scale(v.Y, 100))))
+		binary.BigEndian.PutUint16(b[4:6], uint16(int16(scale(v.Z, 100))))
+	case lppColour:
+		v := value.(Colour)
+		b[0], b[1], b[2] = v.R, v.G, v.B
+	case lppGPSLocation:
+		v := value.(GPSLocation)
+		putInt24(b[0:3], scale(v.Latitude, 10000))
+		putInt24(b[3:6], scale(v.Longitude, 10000))
+		putInt24(b[6:9], scale(v.Altitude, 100))
+	}
+
+	return b
 }
 
-func (t *target) Energy(channel uint8, value float32) {
-	if t.values.Energy == nil {
-		t.values.Energy = make(map[uint8]float32)
-	}
-	t.values.Energy[channel] = value
+func scale(v float32, factor float64) int64 {
+	return int64(math.Round(float64(v) * factor))
 }
 
-// DecodeBytes decodes the payload from a slice of bytes.
-func (c *CayenneLPP) DecodeBytes(data []byte) error {
-	decoder := cayennelpp.NewDecoder(bytes.NewBuffer(data))
-	target := &target{c}
-	err := decoder.DecodeUplink(target)
-
-	if err != nil {
-		return errors.Wrap(err, "decode error")
+func int24(b []byte) int32 {
+	v := int32(b[0])<<16 | int32(b[1])<<8 | int32(b[2])
+	if v&0x800000 != 0 {
+		v |= ^0xffffff
 	}
-
-	return nil
+	return v
 }
 
-// EncodeToBytes encodes the payload to a slice of bytes.
-func (c CayenneLPP) EncodeToBytes() ([]byte, error) {
-	w := bytes.NewBuffer([]byte{})
-
-	encoder := cayennelpp.NewEncoder()
-
-	for k, v := range c.DigitalInput {
-		encoder.AddDigitalInput(k, v)
-	}
-	for k, v := range c.DigitalOutput {
-		encoder.AddDigitalOutput(k, v)
-	}
-	for k, v := range c.AnalogInput {
-		encoder.AddAnalogInput(k, v)
-	}
-	for k, v := range c.AnalogOutput {
-		encoder.AddAnalogOutput(k, v)
-	}
-	for k, v := range c.IlluminanceSensor {
-		encoder.AddLuminosity(k, v)
-	}
-	for k, v := range c.PresenceSensor {
-		encoder.AddPresence(k, v)
-	}
-	for k, v := range c.TemperatureSensor {
-		encoder.AddTemperature(k, v)
-	}
-	for k, v := range c.HumiditySensor {
-		encoder.AddRelativeHumidity(k, v)
-	}
-	for k, v := range c.Accelerometer {
-		encoder.AddAccelerometer(k, v.X, v.Y, v.Z)
-	}
-	for k, v := range c.Barometer {
-		encoder.AddBarometricPressure(k, v)
-	}
-	for k, v := range c.Gyrometer {
-		encoder.AddGyrometer(k, v.X, v.Y, v.Z)
-	}
-	for k, v := range c.GPSLocation {
-		encoder.AddGPS(k, v.Latitude, v.Longitude, v.Altitude)
-	}
-	for k, v := range c.Voltage {
-		encoder.AddVoltage(k, v)
-	}
-	for k, v := range c.Current {
-		encoder.AddCurrent(k, v)
-	}
-	for k, v := range c.Energy {
-		encoder.AddEnergy(k, v)
-	}
-	for k, v := range c.Frequency {
-		encoder.AddFrequency(k, v)
-	}
-
-	return w.Bytes(), nil
+func putInt24(b []byte, v int64) {
+	b[0] = byte(v >> 16)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v)
 }
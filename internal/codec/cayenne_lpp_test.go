@@ -3,6 +3,7 @@ package codec
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -108,8 +109,8 @@ func TestCayenneLPPDecode(t *testing.T) {
 				Name:  "2 barometers",
 				Bytes: []byte{3, 115, 4, 31, 5, 115, 9, 196},
 				Struct: CayenneLPP{
-					Barometer: map[byte]uint8{
-						3: 105,
+					Barometer: map[byte]float32{
+						3: 105.5,
 						5: 250,
 					},
 				},
@@ -133,6 +134,96 @@ func TestCayenneLPPDecode(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name:  "generic sensor",
+				Bytes: []byte{1, 100, 0, 1, 226, 64},
+				Struct: CayenneLPP{
+					GenericSensor: map[byte]uint32{
+						1: 123456,
+					},
+				},
+			},
+			{
+				Name:  "voltage and current",
+				Bytes: []byte{1, 116, 1, 74, 2, 117, 1, 244},
+				Struct: CayenneLPP{
+					Voltage: map[byte]float32{
+						1: 3.3,
+					},
+					Current: map[byte]float32{
+						2: 0.5,
+					},
+				},
+			},
+			{
+				Name:  "frequency",
+				Bytes: []byte{1, 118, 0, 0, 3, 232},
+				Struct: CayenneLPP{
+					Frequency: map[byte]float32{
+						1: 1000,
+					},
+				},
+			},
+			{
+				Name:  "percentage and altitude",
+				Bytes: []byte{1, 120, 75, 2, 121, 255, 156},
+				Struct: CayenneLPP{
+					Percentage: map[byte]uint8{
+						1: 75,
+					},
+					Altitude: map[byte]int16{
+						2: -100,
+					},
+				},
+			},
+			{
+				Name:  "concentration and power",
+				Bytes: []byte{1, 125, 1, 144, 2, 128, 0, 60},
+				Struct: CayenneLPP{
+					Concentration: map[byte]uint16{
+						1: 400,
+					},
+					Power: map[byte]uint16{
+						2: 60,
+					},
+				},
+			},
+			{
+				Name:  "distance and energy",
+				Bytes: []byte{1, 130, 0, 0, 4, 210, 2, 131, 0, 0, 48, 57},
+				Struct: CayenneLPP{
+					Distance: map[byte]float32{
+						1: 1.234,
+					},
+					Energy: map[byte]float32{
+						2: 12.345,
+					},
+				},
+			},
+			{
+				Name:  "direction and unix time",
+				Bytes: []byte{0, 133, 91, 202, 140, 0, 1, 132, 0, 180},
+				Struct: CayenneLPP{
+					UnixTime: map[byte]uint32{
+						0: 1540000768,
+					},
+					Direction: map[byte]uint16{
+						1: 180,
+					},
+				},
+			},
+			{
+				Name:  "colour and switch",
+				Bytes: []byte{1, 135, 255, 128, 0, 2, 142, 1},
+				Struct: CayenneLPP{
+					Colour: map[byte]Colour{
+						1: {R: 255, G: 128, B: 0},
+					},
+					Switch: map[byte]uint8{
+						2: 1,
+					},
+				},
+			},
 		}
 
 		for i, test := range tests {
@@ -152,5 +243,74 @@ func TestCayenneLPPDecode(t *testing.T) {
 				})
 			})
 		}
+
+		Convey("Given a payload containing an unknown type", func() {
+			b := []byte{3, 103, 1, 16, 1, 200, 1, 2, 3, 5, 104, 41}
+
+			Convey("Then the measurements before the unknown type are decoded", func() {
+				lpp := CayenneLPP{}
+				So(lpp.DecodeBytes(b), ShouldBeNil)
+				So(lpp, ShouldResemble, CayenneLPP{
+					TemperatureSensor: map[byte]float32{
+						3: 27.2,
+					},
+				})
+			})
+		})
+
+		Convey("Given a payload with a truncated measurement", func() {
+			b := []byte{3, 103, 1}
+
+			Convey("Then DecodeBytes returns an error", func() {
+				lpp := CayenneLPP{}
+				So(lpp.DecodeBytes(b), ShouldNotBeNil)
+			})
+		})
+
+		Convey("Given a payload containing historical readings", func() {
+			b := []byte{
+				0, 133, 91, 202, 140, 0, 3, 103, 1, 16,
+				0, 133, 91, 202, 147, 8, 3, 103, 0, 255,
+			}
+
+			Convey("Then the last readings are set and the earlier readings are added to the history", func() {
+				lpp := CayenneLPP{}
+				So(lpp.DecodeBytes(b), ShouldBeNil)
+
+				ts1 := time.Unix(1540000768, 0).UTC()
+				So(lpp, ShouldResemble, CayenneLPP{
+					UnixTime: map[byte]uint32{
+						0: 1540002568,
+					},
+					TemperatureSensor: map[byte]float32{
+						3: 25.5,
+					},
+					History: []CayenneLPPHistoryItem{
+						{Time: &ts1, Channel: 0, Type: "unixTime", Value: uint32(1540000768)},
+						{Time: &ts1, Channel: 3, Type: "temperatureSensor", Value: float32(27.2)},
+					},
+				})
+			})
+		})
+
+		Convey("Given a set of actuator commands", func() {
+			lpp := CayenneLPP{
+				DigitalOutput: map[byte]uint8{
+					1: 1,
+				},
+				AnalogOutput: map[byte]float32{
+					2: 12.5,
+				},
+				Switch: map[byte]uint8{
+					3: 0,
+				},
+			}
+
+			Convey("Then EncodeToBytes returns the expected bytes", func() {
+				b, err := lpp.EncodeToBytes()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, []byte{1, 1, 1, 2, 3, 4, 226, 3, 142, 0})
+			})
+		})
 	})
 }
@@ -0,0 +1,415 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+func init() {
+	gob.Register(CBOR{})
+	gob.Register([]SenMLRecord{})
+}
+
+// CBOR implements the CBOR (RFC 7049) codec. SenML-CBOR (RFC 8428) payloads
+// are resolved into a flat list of SenMLRecord items.
+type CBOR struct {
+	Data interface{}
+}
+
+// Object returns the object data.
+func (c CBOR) Object() interface{} {
+	return c.Data
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c CBOR) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Data)
+}
+
+// UnmarshalJSON implement json.Unmarshaler.
+func (c *CBOR) UnmarshalJSON(text []byte) error {
+	var err error
+	c.Data, err = decodeJSONObject(text)
+	return err
+}
+
+// DecodeBytes decodes the payload from a slice of bytes.
+func (c *CBOR) DecodeBytes(data []byte) error {
+	obj, err := unmarshalCBOR(data)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal cbor error")
+	}
+
+	if pack, ok := senMLRecords(obj); ok {
+		records, err := resolveSenML(pack)
+		if err != nil {
+			return errors.Wrap(err, "resolve senml error")
+		}
+		c.Data = records
+		return nil
+	}
+
+	c.Data = normalizeMaps(obj)
+	return nil
+}
+
+// EncodeToBytes encodes the payload to a slice of bytes.
+func (c CBOR) EncodeToBytes() ([]byte, error) {
+	b, err := marshalCBOR(c.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal cbor error")
+	}
+	return b, nil
+}
+
+// maxNestingDepth defines the max. nesting depth of arrays and maps accepted
+// by the CBOR and MessagePack decoders.
+const maxNestingDepth = 32
+
+// cborDecoder decodes a single CBOR (RFC 7049) data item into a generic
+// object. Unsigned integers are decoded as uint64, negative integers as
+// int64, floats as float64, arrays as []interface{} and maps as
+// map[interface{}]interface{}. Tags are ignored (the tagged item is returned).
+type cborDecoder struct {
+	b   []byte
+	pos int
+}
+
+func unmarshalCBOR(b []byte) (interface{}, error) {
+	d := cborDecoder{b: b}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.b) {
+		return nil, errors.New("extraneous data after cbor data item")
+	}
+	return v, nil
+}
+
+func (d *cborDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.b) {
+		return 0, errors.New("unexpected end of cbor data")
+	}
+	b := d.b[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *cborDecoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.b)-d.pos) {
+		return nil, errors.New("unexpected end of cbor data")
+	}
+	b := d.b[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// head reads the initial byte and argument of a data item. For indefinite
+// length items, indefinite is set to true.
+func (d *cborDecoder) head() (major byte, info byte, arg uint64, indefinite bool, err error) {
+	ib, err := d.readByte()
+	if err != nil {
+		return
+	}
+	major, info = ib>>5, ib&0x1f
+
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		var b []byte
+		b, err = d.read(1 << (info - 24))
+		if err != nil {
+			return
+		}
+		for _, v := range b {
+			arg = arg<<8 | uint64(v)
+		}
+	case info == 31 && major >= 2 && major <= 5:
+		indefinite = true
+	default:
+		err = fmt.Errorf("invalid cbor additional information %d for major type %d", info, major)
+	}
+	return
+}
+
+func (d *cborDecoder) isBreak() bool {
+	if d.pos < len(d.b) && d.b[d.pos] == 0xff {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > maxNestingDepth {
+		return nil, errors.New("max. cbor nesting depth exceeded")
+	}
+
+	major, info, arg, indefinite, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		return arg, nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor negative integer overflows int64")
+		}
+		return -1 - int64(arg), nil
+	case 2, 3:
+		var b []byte
+		if indefinite {
+			for !d.isBreak() {
+				m, _, n, ind, err := d.head()
+				if err != nil {
+					return nil, err
+				}
+				if m != major || ind {
+					return nil, errors.New("invalid cbor indefinite length string chunk")
+				}
+				chunk, err := d.read(n)
+				if err != nil {
+					return nil, err
+				}
+				b = append(b, chunk...)
+			}
+		} else {
+			chunk, err := d.read(arg)
+			if err != nil {
+				return nil, err
+			}
+			b = append([]byte{}, chunk...)
+		}
+		if major == 2 {
+			return b, nil
+		}
+		if !utf8.Valid(b) {
+			return nil, errors.New("cbor text string is not valid utf-8")
+		}
+		return string(b), nil
+	case 4:
+		if !indefinite && arg > uint64(len(d.b)-d.pos) {
+			return nil, errors.New("unexpected end of cbor data")
+		}
+		out := make([]interface{}, 0, int(arg))
+		for i := uint64(0); indefinite || i < arg; i++ {
+			if indefinite && d.isBreak() {
+				break
+			}
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case 5:
+		if !indefinite && arg > uint64(len(d.b)-d.pos)/2 {
+			return nil, errors.New("unexpected end of cbor data")
+		}
+		out := make(map[interface{}]interface{}, int(arg))
+		for i := uint64(0); indefinite || i < arg; i++ {
+			if indefinite && d.isBreak() {
+				break
+			}
+			k, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch kt := k.(type) {
+			case []byte:
+				k = string(kt)
+			case []interface{}, map[interface{}]interface{}:
+				return nil, errors.New("cbor map key must not be an array or map")
+			}
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = v
+		}
+		return out, nil
+	case 6:
+		return d.decode(depth + 1)
+	default:
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		case 25:
+			return float64(float16ToFloat32(uint16(arg))), nil
+		case 26:
+			return float64(math.Float32frombits(uint32(arg))), nil
+		case 27:
+			return math.Float64frombits(arg), nil
+		default:
+			return nil, fmt.Errorf("unsupported cbor simple value %d", arg)
+		}
+	}
+}
+
+// float16ToFloat32 converts an IEEE 754 half-precision float.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0:
+		// zero or subnormal
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f:
+		return math.Float32frombits(sign | 0xff<<23 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | frac<<13)
+	}
+}
+
+// marshalCBOR encodes the given object as CBOR. Map keys are sorted
+// using the canonical CBOR ordering (RFC 7049 section 3.9).
+func marshalCBOR(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeCBOR(&buf, v, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeCBORHead(buf *bytes.Buffer, major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		buf.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(arg))
+	case arg <= math.MaxUint16:
+		buf.WriteByte(major<<5 | 25)
+		binary.Write(buf, binary.BigEndian, uint16(arg))
+	case arg <= math.MaxUint32:
+		buf.WriteByte(major<<5 | 26)
+		binary.Write(buf, binary.BigEndian, uint32(arg))
+	default:
+		buf.WriteByte(major<<5 | 27)
+		binary.Write(buf, binary.BigEndian, arg)
+	}
+}
+
+func encodeCBORInt(buf *bytes.Buffer, i int64) {
+	if i < 0 {
+		encodeCBORHead(buf, 1, uint64(-1-i))
+		return
+	}
+	encodeCBORHead(buf, 0, uint64(i))
+}
+
+func encodeCBOR(buf *bytes.Buffer, v interface{}, depth int) error {
+	if depth > maxNestingDepth {
+		return errors.New("max. cbor nesting depth exceeded")
+	}
+
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(0xf6)
+	case bool:
+		if v {
+			buf.WriteByte(0xf5)
+		} else {
+			buf.WriteByte(0xf4)
+		}
+	case int:
+		encodeCBORInt(buf, int64(v))
+	case int8:
+		encodeCBORInt(buf, int64(v))
+	case int16:
+		encodeCBORInt(buf, int64(v))
+	case int32:
+		encodeCBORInt(buf, int64(v))
+	case int64:
+		encodeCBORInt(buf, v)
+	case uint:
+		encodeCBORHead(buf, 0, uint64(v))
+	case uint8:
+		encodeCBORHead(buf, 0, uint64(v))
+	case uint16:
+		encodeCBORHead(buf, 0, uint64(v))
+	case uint32:
+		encodeCBORHead(buf, 0, uint64(v))
+	case uint64:
+		encodeCBORHead(buf, 0, v)
+	case float32:
+		buf.WriteByte(0xfa)
+		binary.Write(buf, binary.BigEndian, math.Float32bits(v))
+	case float64:
+		buf.WriteByte(0xfb)
+		binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+	case []byte:
+		encodeCBORHead(buf, 2, uint64(len(v)))
+		buf.Write(v)
+	case string:
+		encodeCBORHead(buf, 3, uint64(len(v)))
+		buf.WriteString(v)
+	case []interface{}:
+		encodeCBORHead(buf, 4, uint64(len(v)))
+		for _, item := range v {
+			if err := encodeCBOR(buf, item, depth+1); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, val := range v {
+			m[k] = val
+		}
+		return encodeCBOR(buf, m, depth)
+	case map[interface{}]interface{}:
+		type pair struct {
+			key []byte
+			val interface{}
+		}
+		pairs := make([]pair, 0, len(v))
+		for k, val := range v {
+			var kb bytes.Buffer
+			if err := encodeCBOR(&kb, k, depth+1); err != nil {
+				return err
+			}
+			pairs = append(pairs, pair{key: kb.Bytes(), val: val})
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			if len(pairs[i].key) != len(pairs[j].key) {
+				return len(pairs[i].key) < len(pairs[j].key)
+			}
+			return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+		})
+
+		encodeCBORHead(buf, 5, uint64(len(pairs)))
+		for _, p := range pairs {
+			buf.Write(p.key)
+			if err := encodeCBOR(buf, p.val, depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported cbor type %T", v)
+	}
+
+	return nil
+}
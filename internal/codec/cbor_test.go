@@ -0,0 +1,128 @@
+package codec
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCBOR(t *testing.T) {
+	Convey("Given a CBOR codec", t, func() {
+		var c CBOR
+
+		Convey("When decoding a CBOR map", func() {
+			b, err := marshalCBOR(map[string]interface{}{
+				"temperature": 21.5,
+				"humidity":    55,
+				"status":      map[string]interface{}{"ok": true},
+			})
+			So(err, ShouldBeNil)
+			So(c.DecodeBytes(b), ShouldBeNil)
+
+			Convey("Then the object can be marshaled as JSON", func() {
+				b, err := json.Marshal(c)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"humidity":55,"status":{"ok":true},"temperature":21.5}`)
+			})
+		})
+
+		Convey("When decoding a SenML-CBOR pack", func() {
+			b, err := marshalCBOR([]interface{}{
+				map[interface{}]interface{}{-2: "urn:dev:ow:10e2073a0108006:", -3: 1276020076, -4: "A", 0: "voltage", 1: "V", 2: 120.1},
+				map[interface{}]interface{}{0: "current", 6: -5, 2: 1.2},
+				map[interface{}]interface{}{0: "open", 4: true},
+			})
+			So(err, ShouldBeNil)
+			So(c.DecodeBytes(b), ShouldBeNil)
+
+			Convey("Then the records are resolved", func() {
+				v1, v2, vb := 120.1, 1.2, true
+				So(c.Object(), ShouldResemble, []SenMLRecord{
+					{Name: "urn:dev:ow:10e2073a0108006:voltage", Time: 1276020076, Unit: "V", Value: &v1},
+					{Name: "urn:dev:ow:10e2073a0108006:current", Time: 1276020071, Unit: "A", Value: &v2},
+					{Name: "urn:dev:ow:10e2073a0108006:open", Time: 1276020076, Unit: "A", BoolValue: &vb},
+				})
+			})
+		})
+
+		Convey("When decoding a SenML-CBOR pack with a base version and sum", func() {
+			b, err := marshalCBOR([]interface{}{
+				map[interface{}]interface{}{-1: 10, 0: "a", 5: 1},
+			})
+			So(err, ShouldBeNil)
+			So(c.DecodeBytes(b), ShouldBeNil)
+
+			Convey("Then the version is not used as base sum", func() {
+				sum := 1.0
+				So(c.Object(), ShouldResemble, []SenMLRecord{
+					{Name: "a", Sum: &sum},
+				})
+			})
+		})
+
+		Convey("When decoding a SenML-CBOR pack with a base sum", func() {
+			b, err := marshalCBOR([]interface{}{
+				map[interface{}]interface{}{-6: 10, 0: "a", 5: 1},
+			})
+			So(err, ShouldBeNil)
+			So(c.DecodeBytes(b), ShouldBeNil)
+
+			Convey("Then the base sum is applied", func() {
+				sum := 11.0
+				So(c.Object(), ShouldResemble, []SenMLRecord{
+					{Name: "a", Sum: &sum},
+				})
+			})
+		})
+
+		Convey("When decoding an indefinite length array with a half-float and tagged value", func() {
+			So(c.DecodeBytes([]byte{0x9f, 0x01, 0xf9, 0x3c, 0x00, 0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0, 0x20, 0xff}), ShouldBeNil)
+
+			Convey("Then the values are decoded", func() {
+				So(c.Object(), ShouldResemble, []interface{}{uint64(1), float64(1), uint64(1363896240), int64(-1)})
+			})
+		})
+
+		Convey("When decoding an invalid payload", func() {
+			Convey("Then an error is returned", func() {
+				for _, b := range [][]byte{
+					{0xa1},                   // truncated map
+					{0x01, 0x02},             // extraneous data
+					{0x7a, 0xff, 0xff, 0xff}, // string length exceeds payload
+					{0x1c},                   // reserved additional information
+					{0x62, 0xc3, 0x28},       // invalid utf-8
+				} {
+					So(c.DecodeBytes(b), ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When decoding a payload exceeding the max. nesting depth", func() {
+			b := make([]byte, maxNestingDepth+2)
+			for i := range b {
+				b[i] = 0x81
+			}
+			b[len(b)-1] = 0x00
+
+			Convey("Then an error is returned", func() {
+				So(c.DecodeBytes(b), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When encoding an object from JSON", func() {
+			So(json.Unmarshal([]byte(`{"interval": 60, "factor": 1.5, "name": "test"}`), &c), ShouldBeNil)
+			b, err := c.EncodeToBytes()
+			So(err, ShouldBeNil)
+
+			Convey("Then integers are encoded as CBOR integers", func() {
+				So(b, ShouldResemble, []byte{
+					0xa3,
+					0x64, 'n', 'a', 'm', 'e', 0x64, 't', 'e', 's', 't',
+					0x66, 'f', 'a', 'c', 't', 'o', 'r', 0xfb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+					0x68, 'i', 'n', 't', 'e', 'r', 'v', 'a', 'l', 0x18, 0x3c,
+				})
+			})
+		})
+	})
+}
@@ -5,8 +5,11 @@ type Type string
 
 // Available codec types.
 const (
-	CayenneLPPType Type = "CAYENNE_LPP"
-	CustomJSType   Type = "CUSTOM_JS"
+	CayenneLPPType  Type = "CAYENNE_LPP"
+	CustomJSType    Type = "CUSTOM_JS"
+	CBORType        Type = "CBOR"
+	MessagePackType Type = "MESSAGEPACK"
+	WASMType        Type = "WASM"
 )
 
 // Payload defines a codec payload.
@@ -24,6 +27,12 @@ func NewPayload(t Type, fPort uint8, encodeScript, decodeScript string) Payload
 		return &CayenneLPP{}
 	case CustomJSType:
 		return NewCustomJS(fPort, encodeScript, decodeScript)
+	case CBORType:
+		return &CBOR{}
+	case MessagePackType:
+		return &MessagePack{}
+	case WASMType:
+		return NewWASM(fPort, decodeScript)
 	default:
 		return nil
 	}
@@ -0,0 +1,425 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+func init() {
+	gob.Register(MessagePack{})
+}
+
+// MessagePack implements the MessagePack codec. SenML payloads (using the
+// JSON labels as map keys) are resolved into a flat list of SenMLRecord items.
+type MessagePack struct {
+	Data interface{}
+}
+
+// Object returns the object data.
+func (m MessagePack) Object() interface{} {
+	return m.Data
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m MessagePack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Data)
+}
+
+// UnmarshalJSON implement json.Unmarshaler.
+func (m *MessagePack) UnmarshalJSON(text []byte) error {
+	var err error
+	m.Data, err = decodeJSONObject(text)
+	return err
+}
+
+// DecodeBytes decodes the payload from a slice of bytes.
+func (m *MessagePack) DecodeBytes(data []byte) error {
+	obj, err := unmarshalMessagePack(data)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal messagepack error")
+	}
+
+	if pack, ok := senMLRecords(obj); ok {
+		records, err := resolveSenML(pack)
+		if err != nil {
+			return errors.Wrap(err, "resolve senml error")
+		}
+		m.Data = records
+		return nil
+	}
+
+	m.Data = normalizeMaps(obj)
+	return nil
+}
+
+// EncodeToBytes encodes the payload to a slice of bytes.
+func (m MessagePack) EncodeToBytes() ([]byte, error) {
+	b, err := marshalMessagePack(m.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal messagepack error")
+	}
+	return b, nil
+}
+
+// msgpackDecoder decodes a single MessagePack object into a generic object.
+// Unsigned integers are decoded as uint64, signed integers as int64, floats
+// as float64, arrays as []interface{} and maps as
+// map[interface{}]interface{}. Extension types are not supported.
+type msgpackDecoder struct {
+	b   []byte
+	pos int
+}
+
+func unmarshalMessagePack(b []byte) (interface{}, error) {
+	d := msgpackDecoder{b: b}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.b) {
+		return nil, errors.New("extraneous data after messagepack object")
+	}
+	return v, nil
+}
+
+func (d *msgpackDecoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.b)-d.pos) {
+		return nil, errors.New("unexpected end of messagepack data")
+	}
+	b := d.b[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of n bytes.
+func (d *msgpackDecoder) uint(n uint64) (uint64, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var out uint64
+	for _, v := range b {
+		out = out<<8 | uint64(v)
+	}
+	return out, nil
+}
+
+func (d *msgpackDecoder) decode(depth int) (interface{}, error) {
+	if depth > maxNestingDepth {
+		return nil, errors.New("max. messagepack nesting depth exceeded")
+	}
+
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0x80 && c <= 0x8f:
+		return d.decodeMap(uint64(c&0x0f), depth)
+	case c >= 0x90 && c <= 0x9f:
+		return d.decodeArray(uint64(c&0x0f), depth)
+	case c >= 0xa0 && c <= 0xbf:
+		return d.decodeString(uint64(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.uint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case 0xca:
+		n, err := d.uint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(n))), nil
+	case 0xcb:
+		n, err := d.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(n), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return d.uint(1 << (c - 0xcc))
+	case 0xd0:
+		n, err := d.uint(1)
+		return int64(int8(n)), err
+	case 0xd1:
+		n, err := d.uint(2)
+		return int64(int16(n)), err
+	case 0xd2:
+		n, err := d.uint(4)
+		return int64(int32(n)), err
+	case 0xd3:
+		n, err := d.uint(8)
+		return int64(n), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.uint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(n)
+	case 0xdc, 0xdd:
+		n, err := d.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n, depth)
+	case 0xde, 0xdf:
+		n, err := d.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n, depth)
+	default:
+		return nil, fmt.Errorf("unsupported messagepack type 0x%02x", c)
+	}
+}
+
+func (d *msgpackDecoder) decodeString(n uint64) (interface{}, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(b) {
+		return nil, errors.New("messagepack string is not valid utf-8")
+	}
+	return string(b), nil
+}
+
+func (d *msgpackDecoder) decodeArray(n uint64, depth int) (interface{}, error) {
+	if n > uint64(len(d.b)-d.pos) {
+		return nil, errors.New("unexpected end of messagepack data")
+	}
+	out := make([]interface{}, 0, int(n))
+	for i := uint64(0); i < n; i++ {
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (d *msgpackDecoder) decodeMap(n uint64, depth int) (interface{}, error) {
+	if n > uint64(len(d.b)-d.pos)/2 {
+		return nil, errors.New("unexpected end of messagepack data")
+	}
+	out := make(map[interface{}]interface{}, int(n))
+	for i := uint64(0); i < n; i++ {
+		k, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		switch kt := k.(type) {
+		case []byte:
+			k = string(kt)
+		case []interface{}, map[interface{}]interface{}:
+			return nil, errors.New("messagepack map key must not be an array or map")
+		}
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// marshalMessagePack encodes the given object as MessagePack, using the most
+// compact integer representation. Map keys are sorted so that the output is
+// deterministic.
+func marshalMessagePack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeMessagePack(&buf, v, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeMessagePackLength writes the header of a str, bin, array or map
+// object. fix holds the fix-type prefix (0 when there is none) and fixMax
+// the max. length for this prefix, codes the 8, 16 and 32 bit codes.
+func encodeMessagePackLength(buf *bytes.Buffer, n int, fix byte, fixMax int, codes [3]byte) error {
+	switch {
+	case fix != 0 && n <= fixMax:
+		buf.WriteByte(fix | byte(n))
+	case codes[0] != 0 && n <= math.MaxUint8:
+		buf.WriteByte(codes[0])
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(codes[1])
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	case uint64(n) <= math.MaxUint32:
+		buf.WriteByte(codes[2])
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	default:
+		return errors.New("messagepack object too large")
+	}
+	return nil
+}
+
+func encodeMessagePackUint(buf *bytes.Buffer, i uint64) {
+	switch {
+	case i <= 0x7f:
+		buf.WriteByte(byte(i))
+	case i <= math.MaxUint8:
+		buf.WriteByte(0xcc)
+		buf.WriteByte(byte(i))
+	case i <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		binary.Write(buf, binary.BigEndian, uint16(i))
+	case i <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		binary.Write(buf, binary.BigEndian, uint32(i))
+	default:
+		buf.WriteByte(0xcf)
+		binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+func encodeMessagePackInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0:
+		encodeMessagePackUint(buf, uint64(i))
+	case i >= -32:
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		binary.Write(buf, binary.BigEndian, int16(i))
+	case i >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		binary.Write(buf, binary.BigEndian, int32(i))
+	default:
+		buf.WriteByte(0xd3)
+		binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+func encodeMessagePack(buf *bytes.Buffer, v interface{}, depth int) error {
+	if depth > maxNestingDepth {
+		return errors.New("max. messagepack nesting depth exceeded")
+	}
+
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case int:
+		encodeMessagePackInt(buf, int64(v))
+	case int8:
+		encodeMessagePackInt(buf, int64(v))
+	case int16:
+		encodeMessagePackInt(buf, int64(v))
+	case int32:
+		encodeMessagePackInt(buf, int64(v))
+	case int64:
+		encodeMessagePackInt(buf, v)
+	case uint:
+		encodeMessagePackUint(buf, uint64(v))
+	case uint8:
+		encodeMessagePackUint(buf, uint64(v))
+	case uint16:
+		encodeMessagePackUint(buf, uint64(v))
+	case uint32:
+		encodeMessagePackUint(buf, uint64(v))
+	case uint64:
+		encodeMessagePackUint(buf, v)
+	case float32:
+		buf.WriteByte(0xca)
+		binary.Write(buf, binary.BigEndian, math.Float32bits(v))
+	case float64:
+		buf.WriteByte(0xcb)
+		binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+	case string:
+		if err := encodeMessagePackLength(buf, len(v), 0xa0, 31, [3]byte{0xd9, 0xda, 0xdb}); err != nil {
+			return err
+		}
+		buf.WriteString(v)
+	case []byte:
+		if err := encodeMessagePackLength(buf, len(v), 0, 0, [3]byte{0xc4, 0xc5, 0xc6}); err != nil {
+			return err
+		}
+		buf.Write(v)
+	case []interface{}:
+		if err := encodeMessagePackLength(buf, len(v), 0x90, 15, [3]byte{0, 0xdc, 0xdd}); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := encodeMessagePack(buf, item, depth+1); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, val := range v {
+			m[k] = val
+		}
+		return encodeMessagePack(buf, m, depth)
+	case map[interface{}]interface{}:
+		type pair struct {
+			key []byte
+			val interface{}
+		}
+		pairs := make([]pair, 0, len(v))
+		for k, val := range v {
+			var kb bytes.Buffer
+			if err := encodeMessagePack(&kb, k, depth+1); err != nil {
+				return err
+			}
+			pairs = append(pairs, pair{key: kb.Bytes(), val: val})
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+		})
+
+		if err := encodeMessagePackLength(buf, len(pairs), 0x80, 15, [3]byte{0, 0xde, 0xdf}); err != nil {
+			return err
+		}
+		for _, p := range pairs {
+			buf.Write(p.key)
+			if err := encodeMessagePack(buf, p.val, depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported messagepack type %T", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMessagePack(t *testing.T) {
+	Convey("Given a MessagePack codec", t, func() {
+		var m MessagePack
+
+		Convey("When decoding a MessagePack map", func() {
+			b, err := marshalMessagePack(map[string]interface{}{
+				"temperature": 21.5,
+				"counts":      []interface{}{1, 2, 3},
+			})
+			So(err, ShouldBeNil)
+			So(m.DecodeBytes(b), ShouldBeNil)
+
+			Convey("Then the object can be marshaled as JSON", func() {
+				b, err := json.Marshal(m)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"counts":[1,2,3],"temperature":21.5}`)
+			})
+		})
+
+		Convey("When decoding a SenML pack", func() {
+			b, err := marshalMessagePack([]interface{}{
+				map[string]interface{}{"bn": "dev1:", "bt": 1000, "n": "temp", "u": "Cel", "v": 23.5},
+				map[string]interface{}{"n": "label", "t": 10, "vs": "kitchen"},
+			})
+			So(err, ShouldBeNil)
+			So(m.DecodeBytes(b), ShouldBeNil)
+
+			Convey("Then the records are resolved", func() {
+				v, vs := 23.5, "kitchen"
+				So(m.Object(), ShouldResemble, []SenMLRecord{
+					{Name: "dev1:temp", Time: 1000, Unit: "Cel", Value: &v},
+					{Name: "dev1:label", Time: 1010, StringValue: &vs},
+				})
+			})
+		})
+
+		Convey("When decoding signed integers, floats and binary data", func() {
+			So(m.DecodeBytes([]byte{0x94, 0xd0, 0x80, 0xfb, 0xca, 0x3f, 0xc0, 0x00, 0x00, 0xc4, 0x02, 0x01, 0x02}), ShouldBeNil)
+
+			Convey("Then the values are decoded", func() {
+				So(m.Object(), ShouldResemble, []interface{}{int64(-128), int64(-5), float64(1.5), []byte{0x01, 0x02}})
+			})
+		})
+
+		Convey("When decoding an invalid payload", func() {
+			Convey("Then an error is returned", func() {
+				for _, b := range [][]byte{
+					{0x81},                         // truncated map
+					{0x01, 0x02},                   // extraneous data
+					{0xdb, 0xff, 0xff, 0xff, 0xff}, // string length exceeds payload
+					{0xd4, 0x01, 0x01},             // extension type
+				} {
+					So(m.DecodeBytes(b), ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When encoding an object from JSON", func() {
+			So(json.Unmarshal([]byte(`{"interval": 60}`), &m), ShouldBeNil)
+			b, err := m.EncodeToBytes()
+			So(err, ShouldBeNil)
+
+			Convey("Then integers are encoded as MessagePack integers", func() {
+				So(b, ShouldResemble, []byte{0x81, 0xa8, 'i', 'n', 't', 'e', 'r', 'v', 'a', 'l', 0x3c})
+			})
+		})
+
+		Convey("When encoding negative integers and strings", func() {
+			So(json.Unmarshal([]byte(`[-1, -200, "abc"]`), &m), ShouldBeNil)
+			b, err := m.EncodeToBytes()
+			So(err, ShouldBeNil)
+
+			Convey("Then the most compact representation is used", func() {
+				So(b, ShouldResemble, []byte{0x93, 0xff, 0xd1, 0xff, 0x38, 0xa3, 'a', 'b', 'c'})
+			})
+		})
+	})
+}
@@ -0,0 +1,238 @@
+package codec
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DataType defines the data type of a codec output field.
+type DataType string
+
+// Available field data types.
+const (
+	NumberDataType  DataType = "NUMBER"
+	IntegerDataType DataType = "INTEGER"
+	StringDataType  DataType = "STRING"
+	BooleanDataType DataType = "BOOLEAN"
+)
+
+// FieldMetadata describes a single field of the decoded codec object.
+type FieldMetadata struct {
+	// Unit of the value, e.g. "Cel" or "%RH" (SenML units are recommended).
+	Unit string `json:"unit,omitempty"`
+
+	// DataType of the value. When set, the decoded value is normalized to
+	// this type.
+	DataType DataType `json:"dataType,omitempty"`
+
+	// Min and Max define the expected range of the value. Values out of
+	// this range are removed from the decoded object.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+
+	// SemanticType defines the meaning of the value, e.g. "temperature" or
+	// "humidity".
+	SemanticType string `json:"semanticType,omitempty"`
+}
+
+// Fields contains the metadata of the codec output fields, keyed by the
+// path of the field within the decoded object. Nested fields are separated
+// by a dot, e.g. "temperatureSensor.1".
+type Fields map[string]FieldMetadata
+
+// Validate validates the fields metadata.
+func (f Fields) Validate() error {
+	for path, m := range f {
+		if path == "" || strings.HasPrefix(path, ".") || strings.HasSuffix(path, ".") {
+			return fmt.Errorf("invalid field path '%s'", path)
+		}
+
+		switch m.DataType {
+		case "", NumberDataType, IntegerDataType, StringDataType, BooleanDataType:
+		default:
+			return fmt.Errorf("field '%s' has invalid data type '%s'", path, m.DataType)
+		}
+
+		if (m.Min != nil || m.Max != nil) && (m.DataType == StringDataType || m.DataType == BooleanDataType) {
+			return fmt.Errorf("field '%s' of data type %s can not have a min or max value", path, m.DataType)
+		}
+
+		if m.Min != nil && m.Max != nil && *m.Min > *m.Max {
+			return fmt.Errorf("field '%s' has a min value greater than the max value", path)
+		}
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (f Fields) Value() (driver.Value, error) {
+	if len(f) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface.
+func (f *Fields) Scan(src interface{}) error {
+	if src == nil {
+		*f = nil
+		return nil
+	}
+
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	return json.Unmarshal(b, f)
+}
+
+// NormalizeObject converts the values of the given decoded object to the
+// data types defined by the fields metadata and validates them against the
+// min and max values. Only map based objects (e.g. the output of the CBOR,
+// MessagePack, WebAssembly and custom JavaScript codecs) are normalized,
+// other objects are returned as-is. A field which can not be normalized or
+// which is out of range is removed from the object, the returned error
+// contains the errors of all removed fields. The object is always returned,
+// also when an error is returned.
+func NormalizeObject(obj interface{}, fields Fields) (interface{}, error) {
+	if len(fields) == 0 {
+		return obj, nil
+	}
+
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return obj, nil
+	}
+
+	// sort the paths so that the returned error is deterministic
+	var paths []string
+	for path, meta := range fields {
+		if meta.DataType == "" && meta.Min == nil && meta.Max == nil {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var errs []string
+	for _, path := range paths {
+		if err := normalizeField(m, strings.Split(path, "."), fields[path]); err != nil {
+			errs = append(errs, fmt.Sprintf("normalize field '%s' error: %s", path, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return m, errors.New(strings.Join(errs, ", "))
+	}
+
+	return m, nil
+}
+
+// normalizeField normalizes the field at the given path. On error, the
+// field is removed.
+func normalizeField(m map[string]interface{}, path []string, meta FieldMetadata) error {
+	v, ok := m[path[0]]
+	if !ok {
+		return nil
+	}
+
+	if len(path) > 1 {
+		if nested, ok := v.(map[string]interface{}); ok {
+			return normalizeField(nested, path[1:], meta)
+		}
+		return nil
+	}
+
+	out, err := meta.Normalize(v)
+	if err == nil {
+		err = meta.validateRange(out)
+	}
+	if err != nil {
+		delete(m, path[0])
+		return err
+	}
+	m[path[0]] = out
+	return nil
+}
+
+// validateRange validates that the given numeric value is within the min
+// and max value of the field. Non-numeric values are not validated.
+func (m FieldMetadata) validateRange(v interface{}) error {
+	f, ok := toFloat64(v)
+	if !ok {
+		return nil
+	}
+
+	if m.Min != nil && f < *m.Min {
+		return fmt.Errorf("value %v is less than the min value %v", v, *m.Min)
+	}
+
+	if m.Max != nil && f > *m.Max {
+		return fmt.Errorf("value %v is greater than the max value %v", v, *m.Max)
+	}
+
+	return nil
+}
+
+// Normalize converts the given value to the data type of the field. When no
+// data type is set or the value is of an unsupported type, the value is
+// returned as-is. An error is returned when the value does not represent a
+// valid value of the data type (e.g. 10.5 as integer).
+func (m FieldMetadata) Normalize(v interface{}) (interface{}, error) {
+	switch m.DataType {
+	case NumberDataType:
+		if f, ok := toFloat64(v); ok {
+			return f, nil
+		}
+		if s, ok := v.(string); ok {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("can not convert '%s' to number", s)
+			}
+			return f, nil
+		}
+	case IntegerDataType:
+		if f, ok := toFloat64(v); ok {
+			if f != math.Trunc(f) {
+				return nil, fmt.Errorf("can not convert %v to integer", v)
+			}
+			return int64(f), nil
+		}
+		if s, ok := v.(string); ok {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("can not convert '%s' to integer", s)
+			}
+			return i, nil
+		}
+	case StringDataType:
+		if _, ok := v.(string); !ok {
+			return fmt.Sprintf("%v", v), nil
+		}
+	case BooleanDataType:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil {
+				return nil, fmt.Errorf("can not convert '%s' to boolean", b)
+			}
+			return parsed, nil
+		}
+		if f, ok := toFloat64(v); ok {
+			return f != 0, nil
+		}
+	}
+
+	return v, nil
+}
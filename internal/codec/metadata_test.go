@@ -0,0 +1,160 @@
+package codec
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFields(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		min := -40.0
+		max := 85.0
+
+		tests := []struct {
+			Name          string
+			Fields        Fields
+			ExpectedError string
+		}{
+			{
+				Name: "valid fields",
+				Fields: Fields{
+					"temperature":   {Unit: "Cel", DataType: NumberDataType, Min: &min, Max: &max, SemanticType: "temperature"},
+					"status.online": {DataType: BooleanDataType},
+				},
+			},
+			{
+				Name:          "empty path",
+				Fields:        Fields{"": {}},
+				ExpectedError: "invalid field path ''",
+			},
+			{
+				Name:          "invalid data type",
+				Fields:        Fields{"temperature": {DataType: "FLOAT"}},
+				ExpectedError: "field 'temperature' has invalid data type 'FLOAT'",
+			},
+			{
+				Name:          "range on string",
+				Fields:        Fields{"name": {DataType: StringDataType, Min: &min}},
+				ExpectedError: "field 'name' of data type STRING can not have a min or max value",
+			},
+			{
+				Name:          "min greater than max",
+				Fields:        Fields{"temperature": {Min: &max, Max: &min}},
+				ExpectedError: "field 'temperature' has a min value greater than the max value",
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				err := test.Fields.Validate()
+				if test.ExpectedError == "" {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, test.ExpectedError)
+				}
+			})
+		}
+	})
+
+	Convey("Given fields metadata", t, func() {
+		fields := Fields{
+			"temperature":   {DataType: NumberDataType},
+			"counter":       {DataType: IntegerDataType},
+			"status.online": {DataType: BooleanDataType},
+			"serial":        {DataType: StringDataType},
+			"humidity":      {Unit: "%RH"},
+		}
+
+		Convey("When normalizing a map object", func() {
+			obj, err := NormalizeObject(map[string]interface{}{
+				"temperature": int64(21),
+				"counter":     float64(10),
+				"status":      map[string]interface{}{"online": "true"},
+				"serial":      int64(12345),
+				"humidity":    float64(55),
+			}, fields)
+			So(err, ShouldBeNil)
+
+			Convey("Then the values are converted to the defined data types", func() {
+				So(obj, ShouldResemble, map[string]interface{}{
+					"temperature": float64(21),
+					"counter":     int64(10),
+					"status":      map[string]interface{}{"online": true},
+					"serial":      "12345",
+					"humidity":    float64(55),
+				})
+			})
+		})
+
+		Convey("When normalizing a non-integer value to integer", func() {
+			obj, err := NormalizeObject(map[string]interface{}{
+				"counter":     10.5,
+				"temperature": int64(21),
+			}, fields)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "normalize field 'counter' error: can not convert 10.5 to integer")
+			})
+
+			Convey("Then only the invalid field is removed", func() {
+				So(obj, ShouldResemble, map[string]interface{}{
+					"temperature": float64(21),
+				})
+			})
+		})
+
+		Convey("Given a min and max value", func() {
+			min := -40.0
+			max := 85.0
+			fields["temperature"] = FieldMetadata{DataType: NumberDataType, Min: &min, Max: &max}
+			fields["humidity"] = FieldMetadata{Max: &max}
+
+			Convey("When normalizing values within the range", func() {
+				obj, err := NormalizeObject(map[string]interface{}{
+					"temperature": int64(-40),
+					"humidity":    float64(55),
+				}, fields)
+
+				Convey("Then the values are kept", func() {
+					So(err, ShouldBeNil)
+					So(obj, ShouldResemble, map[string]interface{}{
+						"temperature": float64(-40),
+						"humidity":    float64(55),
+					})
+				})
+			})
+
+			Convey("When normalizing values out of range", func() {
+				obj, err := NormalizeObject(map[string]interface{}{
+					"temperature": int64(-41),
+					"humidity":    float64(90),
+					"counter":     float64(10),
+				}, fields)
+
+				Convey("Then an error is returned for each field", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, "normalize field 'humidity' error: value 90 is greater than the max value 85, normalize field 'temperature' error: value -41 is less than the min value -40")
+				})
+
+				Convey("Then only the fields out of range are removed", func() {
+					So(obj, ShouldResemble, map[string]interface{}{
+						"counter": int64(10),
+					})
+				})
+			})
+		})
+
+		Convey("When normalizing a struct object", func() {
+			obj, err := NormalizeObject(CayenneLPP{}, fields)
+
+			Convey("Then the object is returned as-is", func() {
+				So(err, ShouldBeNil)
+				So(obj, ShouldResemble, CayenneLPP{})
+			})
+		})
+	})
+}
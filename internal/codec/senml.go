@@ -0,0 +1,340 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// SenML labels, see RFC 8428 section 6 (CBOR representation). In the JSON
+// (and MessagePack) representation the labels are used as map keys.
+var senMLLabels = map[int64]string{
+	-6: "bs",
+	-5: "bv",
+	-4: "bu",
+	-3: "bt",
+	-2: "bn",
+	-1: "bver",
+	0:  "n",
+	1:  "u",
+	2:  "v",
+	3:  "vs",
+	4:  "vb",
+	5:  "s",
+	6:  "t",
+	7:  "ut",
+	8:  "vd",
+}
+
+// SenMLRecord holds a single resolved SenML record. Base-values are applied
+// so that every record is self-contained.
+type SenMLRecord struct {
+	Name        string   `json:"name"`
+	Time        float64  `json:"time,omitempty"`
+	UpdateTime  float64  `json:"updateTime,omitempty"`
+	Unit        string   `json:"unit,omitempty"`
+	Value       *float64 `json:"value,omitempty"`
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	DataValue   string   `json:"dataValue,omitempty"`
+	Sum         *float64 `json:"sum,omitempty"`
+}
+
+// normalizeMaps converts the map[interface{}]interface{} values returned by
+// the CBOR and MessagePack decoders into map[string]interface{} so that the
+// object can be marshaled to JSON.
+func normalizeMaps(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[fmt.Sprintf("%v", k)] = normalizeMaps(val)
+		}
+		return out
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = normalizeMaps(val)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeMaps(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// decodeJSONObject decodes the given JSON into a generic object. Integer
+// numbers are decoded as int64 so that they are not encoded as floats
+// by the binary codecs.
+func decodeJSONObject(text []byte) (interface{}, error) {
+	var out interface{}
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return resolveJSONNumbers(out), nil
+}
+
+func resolveJSONNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = resolveJSONNumbers(val)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = resolveJSONNumbers(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// senMLRecords returns the SenML pack as a slice of label / value maps,
+// or false when the given object is not a SenML pack.
+func senMLRecords(obj interface{}) ([]map[string]interface{}, bool) {
+	pack, ok := obj.([]interface{})
+	if !ok || len(pack) == 0 {
+		return nil, false
+	}
+
+	var out []map[string]interface{}
+	for _, r := range pack {
+		var rec map[string]interface{}
+
+		switch r := r.(type) {
+		case map[interface{}]interface{}:
+			rec = make(map[string]interface{}, len(r))
+			for k, v := range r {
+				label, ok := senMLLabel(k)
+				if !ok {
+					return nil, false
+				}
+				rec[label] = v
+			}
+		case map[string]interface{}:
+			rec = make(map[string]interface{}, len(r))
+			for k, v := range r {
+				label, ok := senMLLabel(k)
+				if !ok {
+					return nil, false
+				}
+				rec[label] = v
+			}
+		default:
+			return nil, false
+		}
+
+		if len(rec) == 0 {
+			return nil, false
+		}
+		out = append(out, rec)
+	}
+
+	return out, true
+}
+
+func senMLLabel(k interface{}) (string, bool) {
+	var i int64
+	switch k := k.(type) {
+	case string:
+		for _, l := range senMLLabels {
+			if l == k {
+				return k, true
+			}
+		}
+		return "", false
+	case int64:
+		i = k
+	case uint64:
+		if k > math.MaxInt64 {
+			return "", false
+		}
+		i = int64(k)
+	case int8:
+		i = int64(k)
+	case int16:
+		i = int64(k)
+	case int32:
+		i = int64(k)
+	case uint8:
+		i = int64(k)
+	case uint16:
+		i = int64(k)
+	case uint32:
+		i = int64(k)
+	default:
+		return "", false
+	}
+
+	l, ok := senMLLabels[i]
+	return l, ok
+}
+
+// resolveSenML resolves the given SenML records into a flat record list.
+func resolveSenML(pack []map[string]interface{}) ([]SenMLRecord, error) {
+	var out []SenMLRecord
+	var baseName, baseUnit string
+	var baseTime, baseValue, baseSum float64
+
+	for i, rec := range pack {
+		if v, ok := rec["bn"]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: bn must be a string", i)
+			}
+			baseName = s
+		}
+		if v, ok := rec["bu"]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: bu must be a string", i)
+			}
+			baseUnit = s
+		}
+		for label, target := range map[string]*float64{"bt": &baseTime, "bv": &baseValue, "bs": &baseSum} {
+			if v, ok := rec[label]; ok {
+				f, ok := toFloat64(v)
+				if !ok {
+					return nil, fmt.Errorf("senml record %d: %s must be a number", i, label)
+				}
+				*target = f
+			}
+		}
+
+		r := SenMLRecord{
+			Name: baseName,
+			Time: baseTime,
+			Unit: baseUnit,
+		}
+
+		if v, ok := rec["n"]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: n must be a string", i)
+			}
+			r.Name = baseName + s
+		}
+		if v, ok := rec["u"]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: u must be a string", i)
+			}
+			r.Unit = s
+		}
+		if v, ok := rec["t"]; ok {
+			f, ok := toFloat64(v)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: t must be a number", i)
+			}
+			r.Time = baseTime + f
+		}
+		if v, ok := rec["ut"]; ok {
+			f, ok := toFloat64(v)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: ut must be a number", i)
+			}
+			r.UpdateTime = f
+		}
+		if v, ok := rec["v"]; ok {
+			f, ok := toFloat64(v)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: v must be a number", i)
+			}
+			f = baseValue + f
+			r.Value = &f
+		}
+		if v, ok := rec["s"]; ok {
+			f, ok := toFloat64(v)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: s must be a number", i)
+			}
+			f = baseSum + f
+			r.Sum = &f
+		}
+		if v, ok := rec["vs"]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: vs must be a string", i)
+			}
+			r.StringValue = &s
+		}
+		if v, ok := rec["vb"]; ok {
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("senml record %d: vb must be a boolean", i)
+			}
+			r.BoolValue = &b
+		}
+		if v, ok := rec["vd"]; ok {
+			switch d := v.(type) {
+			case string:
+				r.DataValue = d
+			case []byte:
+				r.DataValue = base64.RawURLEncoding.EncodeToString(d)
+			default:
+				return nil, fmt.Errorf("senml record %d: vd must be a string or byte string", i)
+			}
+		}
+
+		// records containing only base fields do not represent a measurement
+		if r.Value == nil && r.Sum == nil && r.StringValue == nil && r.BoolValue == nil && r.DataValue == "" {
+			if _, ok := rec["n"]; !ok {
+				continue
+			}
+		}
+
+		if r.Name == "" {
+			return nil, fmt.Errorf("senml record %d: name is empty", i)
+		}
+
+		out = append(out, r)
+	}
+
+	return out, nil
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,429 @@
+package codec
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+func init() {
+	gob.Register(WASM{})
+}
+
+// WASMMaxExecTime holds the max. time a WASM decode or encode call
+// (including the module instantiation) is allowed to run.
+var WASMMaxExecTime = 50 * time.Millisecond
+
+// WASMMaxFunctionCalls holds the fuel of a WASM decode or encode call,
+// expressed as the max. number of module function calls it is allowed to
+// make. Loops which do not call any function are bounded by WASMMaxExecTime.
+var WASMMaxFunctionCalls int64 = 1000000
+
+// WASMModuleCacheSize holds the max. number of compiled WASM modules to keep
+// in memory. When exceeded, the least recently used module is removed.
+var WASMModuleCacheSize = 32
+
+// WASMMaxMemoryPages holds the max. number of 64KiB memory pages a WASM
+// module instance is allowed to use. Changing this value only has effect
+// before the first WASM codec has been used.
+var WASMMaxMemoryPages uint32 = 16
+
+// Exports expected from a WASM codec module. All pointers and lengths are
+// i32 values. A (ptr, len) result is returned as an i64 with the pointer in
+// the upper and the length in the lower 32 bits. A negative result signals
+// an error, in which case the negated value contains the (ptr, len) of an
+// UTF-8 encoded error message.
+const (
+	// memory is the exported linear memory.
+	wasmExportMemory = "memory"
+
+	// alloc(len) -> ptr allocates len bytes within the module memory.
+	wasmExportAlloc = "alloc"
+
+	// decode(fPort, ptr, len) -> (ptr, len) decodes the FRMPayload bytes
+	// into a JSON object.
+	wasmExportDecode = "decode"
+
+	// encode(fPort, ptr, len) -> (ptr, len) encodes the given JSON object
+	// into FRMPayload bytes.
+	wasmExportEncode = "encode"
+)
+
+var wasmRuntime struct {
+	sync.Mutex
+	runtime wazero.Runtime
+	modules map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+}
+
+// wasmCachedModule holds a compiled module within the module cache. A module
+// which is removed from the cache while still in use is closed by the last
+// user releasing it.
+type wasmCachedModule struct {
+	hash     [sha256.Size]byte
+	compiled wazero.CompiledModule
+	refs     int
+	removed  bool
+}
+
+// wasmFuelKey is the context key of the *wasmFuel of a call.
+type wasmFuelKey struct{}
+
+// wasmFuel holds the remaining fuel of a call. A call is only executed by a
+// single goroutine, therefore no locking is needed.
+type wasmFuel struct {
+	remaining int64
+	exhausted bool
+	cancel    context.CancelFunc
+}
+
+// wasmFuelListener consumes one unit of fuel on every function call and
+// terminates the call (by cancelling its context) once all fuel has been
+// consumed.
+type wasmFuelListener struct{}
+
+func (wasmFuelListener) NewFunctionListener(api.FunctionDefinition) experimental.FunctionListener {
+	return wasmFuelListener{}
+}
+
+func (wasmFuelListener) Before(ctx context.Context, _ api.Module, _ api.FunctionDefinition, _ []uint64, _ experimental.StackIterator) {
+	fuel, ok := ctx.Value(wasmFuelKey{}).(*wasmFuel)
+	if !ok || fuel.exhausted {
+		return
+	}
+
+	fuel.remaining--
+	if fuel.remaining < 0 {
+		fuel.exhausted = true
+		fuel.cancel()
+	}
+}
+
+func (wasmFuelListener) After(context.Context, api.Module, api.FunctionDefinition, []uint64) {}
+
+func (wasmFuelListener) Abort(context.Context, api.Module, api.FunctionDefinition, error) {}
+
+// WASM implements a codec running a user-provided WebAssembly module.
+// The module is stored base64 encoded as the decoder script.
+type WASM struct {
+	fPort  uint8
+	module string
+	Data   interface{}
+}
+
+// NewWASM creates a new WASM codec for the given base64 encoded module.
+func NewWASM(fPort uint8, module string) *WASM {
+	return &WASM{
+		fPort:  fPort,
+		module: module,
+	}
+}
+
+// ValidateWASMModule validates that the given base64 encoded module
+// compiles and provides the required exports. The compiled module is not
+// added to the module cache.
+func ValidateWASMModule(module string) error {
+	b, err := base64.StdEncoding.DecodeString(module)
+	if err != nil {
+		return errors.Wrap(err, "decode module error")
+	}
+
+	compiled, err := compileWASMModule(b)
+	if err != nil {
+		return err
+	}
+	return compiled.Close(context.Background())
+}
+
+// Object returns the object data.
+func (w WASM) Object() interface{} {
+	return w.Data
+}
+
+// MarshalJSON implements json.Marshaler.
+func (w WASM) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.Data)
+}
+
+// UnmarshalJSON implement json.Unmarshaler.
+func (w *WASM) UnmarshalJSON(text []byte) error {
+	return json.Unmarshal(text, &w.Data)
+}
+
+// DecodeBytes decodes the payload from a slice of bytes.
+func (w *WASM) DecodeBytes(data []byte) error {
+	out, err := w.call(wasmExportDecode, data)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(out, &w.Data); err != nil {
+		return errors.Wrap(err, "unmarshal decode result error")
+	}
+
+	return nil
+}
+
+// EncodeToBytes encodes the payload to a slice of bytes.
+func (w WASM) EncodeToBytes() ([]byte, error) {
+	obj, err := json.Marshal(w.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal object error")
+	}
+
+	return w.call(wasmExportEncode, obj)
+}
+
+func (w WASM) call(fn string, input []byte) ([]byte, error) {
+	cached, err := acquireWASMModule(w.module)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseWASMModule(cached)
+
+	ctx, cancel := context.WithTimeout(context.Background(), WASMMaxExecTime)
+	defer cancel()
+
+	fuel := wasmFuel{
+		remaining: WASMMaxFunctionCalls,
+		cancel:    cancel,
+	}
+	ctx = context.WithValue(ctx, wasmFuelKey{}, &fuel)
+
+	// every call gets its own (anonymous) module instance, so that no state
+	// is shared between devices
+	mod, err := wasmRuntime.runtime.InstantiateModule(ctx, cached.compiled, wazero.NewModuleConfig().WithName("").WithStartFunctions())
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiate module error")
+	}
+	defer mod.Close(ctx)
+
+	f := mod.ExportedFunction(fn)
+	if f == nil {
+		return nil, fmt.Errorf("module does not export %s function", fn)
+	}
+
+	ptr, err := wasmWrite(ctx, mod, input)
+	if err != nil {
+		if fuel.exhausted {
+			return nil, errors.New("fuel exhausted")
+		}
+		return nil, err
+	}
+
+	res, err := f.Call(ctx, uint64(w.fPort), uint64(ptr), uint64(len(input)))
+	if err != nil {
+		if fuel.exhausted {
+			return nil, errors.New("fuel exhausted")
+		}
+		if ctx.Err() != nil {
+			return nil, errors.New("execution timeout")
+		}
+		return nil, errors.Wrapf(err, "call %s error", fn)
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("%s must return a single i64 value", fn)
+	}
+
+	result := int64(res[0])
+	if result < 0 {
+		msg, err := wasmRead(mod, uint64(-result))
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s error: %s", fn, msg)
+	}
+
+	return wasmRead(mod, uint64(result))
+}
+
+// wasmWrite allocates memory within the module and writes b to it.
+func wasmWrite(ctx context.Context, mod api.Module, b []byte) (uint32, error) {
+	res, err := mod.ExportedFunction(wasmExportAlloc).Call(ctx, uint64(len(b)))
+	if err != nil {
+		return 0, errors.Wrap(err, "call alloc error")
+	}
+	if len(res) != 1 {
+		return 0, errors.New("alloc must return a single i32 value")
+	}
+
+	ptr := uint32(res[0])
+	if !mod.Memory().Write(ptr, b) {
+		return 0, fmt.Errorf("alloc returned out of range pointer %d", ptr)
+	}
+
+	return ptr, nil
+}
+
+// wasmRead reads the (ptr, len) encoded by the given i64 value from the
+// module memory.
+func wasmRead(mod api.Module, v uint64) ([]byte, error) {
+	ptr, l := uint32(v>>32), uint32(v)
+	b, ok := mod.Memory().Read(ptr, l)
+	if !ok {
+		return nil, fmt.Errorf("result (ptr: %d, len: %d) is out of memory range", ptr, l)
+	}
+
+	// the returned slice is a view of the module memory, which is released
+	// when the module is closed
+	out := make([]byte, len(b))
+	copy(out, b)
+	return out, nil
+}
+
+// getWASMRuntime returns the runtime, creating it on the first call.
+func getWASMRuntime() (wazero.Runtime, error) {
+	wasmRuntime.Lock()
+	defer wasmRuntime.Unlock()
+
+	if wasmRuntime.runtime != nil {
+		return wasmRuntime.runtime, nil
+	}
+
+	ctx := context.Background()
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(WASMMaxMemoryPages).
+		WithCloseOnContextDone(true),
+	)
+
+	// the WASI imports allow modules compiled with e.g. the Rust or
+	// wasi-sdk toolchains. No filesystem, environment or network is
+	// exposed to the module.
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		rt.Close(ctx)
+		return nil, errors.Wrap(err, "instantiate wasi error")
+	}
+
+	wasmRuntime.runtime = rt
+	wasmRuntime.modules = make(map[[sha256.Size]byte]*list.Element)
+	wasmRuntime.lru = list.New()
+
+	return rt, nil
+}
+
+// compileWASMModule compiles the given module and validates its exports.
+// The compilation does not count against the WASMMaxExecTime of a call.
+func compileWASMModule(b []byte) (wazero.CompiledModule, error) {
+	rt, err := getWASMRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.WithValue(context.Background(), experimental.FunctionListenerFactoryKey{}, wasmFuelListener{})
+
+	compiled, err := rt.CompileModule(ctx, b)
+	if err != nil {
+		return nil, errors.Wrap(err, "compile module error")
+	}
+
+	exports := compiled.ExportedFunctions()
+	for _, name := range []string{wasmExportAlloc, wasmExportDecode, wasmExportEncode} {
+		if _, ok := exports[name]; !ok {
+			compiled.Close(ctx)
+			return nil, fmt.Errorf("module must export %s function", name)
+		}
+	}
+	if _, ok := compiled.ExportedMemories()[wasmExportMemory]; !ok {
+		compiled.Close(ctx)
+		return nil, fmt.Errorf("module must export %s", wasmExportMemory)
+	}
+
+	return compiled, nil
+}
+
+// acquireWASMModule returns the compiled module for the given base64
+// encoded module. Compiled modules are cached by their SHA256 hash. The
+// returned module must be released using releaseWASMModule.
+func acquireWASMModule(module string) (*wasmCachedModule, error) {
+	b, err := base64.StdEncoding.DecodeString(module)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode module error")
+	}
+	hash := sha256.Sum256(b)
+
+	if cached := getCachedWASMModule(hash); cached != nil {
+		return cached, nil
+	}
+
+	// the module is compiled without holding the lock, so that calls of
+	// other (cached) modules are not blocked by the compilation
+	compiled, err := compileWASMModule(b)
+	if err != nil {
+		return nil, err
+	}
+
+	wasmRuntime.Lock()
+	defer wasmRuntime.Unlock()
+
+	// the same module might have been compiled concurrently
+	if el, ok := wasmRuntime.modules[hash]; ok {
+		compiled.Close(context.Background())
+
+		wasmRuntime.lru.MoveToFront(el)
+		cached := el.Value.(*wasmCachedModule)
+		cached.refs++
+		return cached, nil
+	}
+
+	cached := &wasmCachedModule{
+		hash:     hash,
+		compiled: compiled,
+		refs:     1,
+	}
+	wasmRuntime.modules[hash] = wasmRuntime.lru.PushFront(cached)
+
+	for wasmRuntime.lru.Len() > WASMModuleCacheSize {
+		el := wasmRuntime.lru.Back()
+		old := el.Value.(*wasmCachedModule)
+
+		wasmRuntime.lru.Remove(el)
+		delete(wasmRuntime.modules, old.hash)
+
+		old.removed = true
+		if old.refs == 0 {
+			old.compiled.Close(context.Background())
+		}
+	}
+
+	return cached, nil
+}
+
+// getCachedWASMModule returns the cached module for the given hash or nil
+// when it is not cached.
+func getCachedWASMModule(hash [sha256.Size]byte) *wasmCachedModule {
+	wasmRuntime.Lock()
+	defer wasmRuntime.Unlock()
+
+	el, ok := wasmRuntime.modules[hash]
+	if !ok {
+		return nil
+	}
+
+	wasmRuntime.lru.MoveToFront(el)
+	cached := el.Value.(*wasmCachedModule)
+	cached.refs++
+	return cached
+}
+
+// releaseWASMModule releases a module returned by acquireWASMModule.
+func releaseWASMModule(cached *wasmCachedModule) {
+	wasmRuntime.Lock()
+	defer wasmRuntime.Unlock()
+
+	cached.refs--
+	if cached.refs == 0 && cached.removed {
+		cached.compiled.Close(context.Background())
+	}
+}
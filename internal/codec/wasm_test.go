@@ -0,0 +1,127 @@
+package codec
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testWASMModule exports memory, alloc and decode / encode functions which
+// echo the input. On fPort 99 they loop forever, on fPort 98 they call
+// alloc forever and for an empty input they return the error
+// "empty payload".
+const testWASMModule = "AGFzbQEAAAABDQJgAX8Bf2ADf39/AX4DAwIAAQUDAQABBgcBfwFBgAgLByQEBm1lbW9yeQIABWFsbG9jAAAGZGVjb2RlAAEGZW5jb2RlAAEKSgILACMAIwAgAGokAAs8ACAAQeMARgRAA0AMAAsLIABB4gBGBEADQEEAEAAaDAALCyACRQRAQvP//////30PCyABrUIghiACrYQLCxQBAEGAEAsNZW1wdHkgcGF5bG9hZA=="
+
+func TestWASM(t *testing.T) {
+	Convey("Given a WASM module", t, func() {
+		Convey("Then ValidateWASMModule returns no error", func() {
+			So(ValidateWASMModule(testWASMModule), ShouldBeNil)
+		})
+
+		Convey("Then ValidateWASMModule returns an error for invalid modules", func() {
+			So(ValidateWASMModule("not base64!"), ShouldNotBeNil)
+			So(ValidateWASMModule(base64.StdEncoding.EncodeToString([]byte("function Decode() {}"))), ShouldNotBeNil)
+		})
+
+		Convey("When decoding a payload", func() {
+			w := NewWASM(10, testWASMModule)
+			So(w.DecodeBytes([]byte(`{"temperature":21.5}`)), ShouldBeNil)
+
+			Convey("Then the object is returned", func() {
+				So(w.Object(), ShouldResemble, map[string]interface{}{
+					"temperature": 21.5,
+				})
+			})
+		})
+
+		Convey("When encoding an object", func() {
+			w := NewWASM(10, testWASMModule)
+			So(json.Unmarshal([]byte(`{"interval":60}`), w), ShouldBeNil)
+			b, err := w.EncodeToBytes()
+			So(err, ShouldBeNil)
+
+			Convey("Then the expected bytes are returned", func() {
+				So(string(b), ShouldEqual, `{"interval":60}`)
+			})
+		})
+
+		Convey("When the module returns an error", func() {
+			w := NewWASM(10, testWASMModule)
+			err := w.DecodeBytes(nil)
+
+			Convey("Then the error message is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "decode error: empty payload")
+			})
+		})
+
+		Convey("When the module exceeds the max execution time", func() {
+			w := NewWASM(99, testWASMModule)
+			err := w.DecodeBytes([]byte{1, 2, 3})
+
+			Convey("Then a timeout error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "execution timeout")
+			})
+		})
+
+		Convey("When the module exceeds the max function calls", func() {
+			maxExecTime, maxCalls := WASMMaxExecTime, WASMMaxFunctionCalls
+			WASMMaxExecTime = 10 * time.Second
+			WASMMaxFunctionCalls = 1000
+			defer func() {
+				WASMMaxExecTime, WASMMaxFunctionCalls = maxExecTime, maxCalls
+			}()
+
+			w := NewWASM(98, testWASMModule)
+			err := w.DecodeBytes([]byte{1, 2, 3})
+
+			Convey("Then a fuel exhausted error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "fuel exhausted")
+			})
+		})
+
+		Convey("When using more modules than the cache size", func() {
+			cacheSize := WASMModuleCacheSize
+			WASMModuleCacheSize = 1
+			defer func() {
+				WASMModuleCacheSize = cacheSize
+			}()
+
+			// appending a custom section results in a different module hash
+			other := base64.StdEncoding.EncodeToString(append(mustDecodeBase64(testWASMModule), 0x00, 0x03, 0x01, 'x', 0x00))
+
+			for _, module := range []string{testWASMModule, other, testWASMModule} {
+				w := NewWASM(10, module)
+				So(w.DecodeBytes([]byte(`{"temperature":21.5}`)), ShouldBeNil)
+			}
+
+			Convey("Then only the last used module is cached", func() {
+				So(wasmRuntime.lru.Len(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When validating a module", func() {
+			other := base64.StdEncoding.EncodeToString(append(mustDecodeBase64(testWASMModule), 0x00, 0x03, 0x01, 'y', 0x00))
+			So(ValidateWASMModule(other), ShouldBeNil)
+
+			Convey("Then it is not added to the cache", func() {
+				b := mustDecodeBase64(other)
+				So(getCachedWASMModule(sha256.Sum256(b)), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func mustDecodeBase64(s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
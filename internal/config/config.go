@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/garyburd/redigo/redis"
 
 	"github.com/brocaar/lora-app-server/internal/common"
@@ -20,6 +22,11 @@ type Config struct {
 		DSN         string `mapstructure:"dsn"`
 		Automigrate bool
 		DB          *common.DBLogger `mapstructure:"db"`
+
+		Encryption struct {
+			MasterKeyID string      `mapstructure:"master_key_id"`
+			MasterKeys  []MasterKey `mapstructure:"master_keys"`
+		}
 	} `mapstructure:"postgresql"`
 
 	Redis struct {
@@ -51,6 +58,20 @@ type Config struct {
 			DisableAssignExistingUsers bool   `mapstructure:"disable_assign_existing_users"`
 		} `mapstructure:"external_api"`
 
+		Downlink struct {
+			IdempotencyWindow time.Duration `mapstructure:"idempotency_window"`
+			StatusRetention   time.Duration `mapstructure:"status_retention"`
+		}
+
+		DownlinkBatch struct {
+			Concurrency int
+		} `mapstructure:"downlink_batch"`
+
+		DeviceClaim struct {
+			FailureMax    int           `mapstructure:"failure_max"`
+			FailureWindow time.Duration `mapstructure:"failure_window"`
+		} `mapstructure:"device_claim"`
+
 		Branding struct {
 			Header       string
 			Footer       string
@@ -59,10 +80,41 @@ type Config struct {
 	} `mapstructure:"application_server"`
 
 	JoinServer struct {
-		Bind    string
-		CACert  string `mapstructure:"ca_cert"`
-		TLSCert string `mapstructure:"tls_cert"`
-		TLSKey  string `mapstructure:"tls_key"`
+		Bind                string
+		CACert              string        `mapstructure:"ca_cert"`
+		TLSCert             string        `mapstructure:"tls_cert"`
+		TLSKey              string        `mapstructure:"tls_key"`
+		DevNonceHistorySize int           `mapstructure:"dev_nonce_history_size"`
+		SessionKeyLifetime  time.Duration `mapstructure:"session_key_lifetime"`
+		JoinHistorySize     int           `mapstructure:"join_history_size"`
+
+		AllowUnauthenticatedSenders bool `mapstructure:"allow_unauthenticated_senders"`
+
+		KEK struct {
+			ASKEKLabel string `mapstructure:"as_kek_label"`
+			Require    bool   `mapstructure:"require"`
+
+			Set []KEK
+		} `mapstructure:"kek"`
+
+		RateLimit struct {
+			DevEUIMax        int           `mapstructure:"dev_eui_max"`
+			DevEUIWindow     time.Duration `mapstructure:"dev_eui_window"`
+			SenderIDMax      int           `mapstructure:"sender_id_max"`
+			SenderIDWindow   time.Duration `mapstructure:"sender_id_window"`
+			MICFailureMax    int           `mapstructure:"mic_failure_max"`
+			MICFailureWindow time.Duration `mapstructure:"mic_failure_window"`
+			BlockDuration    time.Duration `mapstructure:"block_duration"`
+		} `mapstructure:"rate_limit"`
+
+		PKCS11 struct {
+			Enabled    bool
+			Module     string
+			TokenLabel string `mapstructure:"token_label"`
+			PIN        string `mapstructure:"pin"`
+
+			SessionPoolSize int `mapstructure:"session_pool_size"`
+		} `mapstructure:"pkcs11"`
 	} `mapstructure:"join_server"`
 
 	NetworkServer struct {
@@ -72,3 +124,19 @@ type Config struct {
 
 // C holds the global configuration.
 var C Config
+
+// KEK defines a key-encryption key (KEK), used to wrap session-keys, and its
+// label.
+type KEK struct {
+	Label string
+	KEK   string `mapstructure:"kek"`
+}
+
+// MasterKey defines a master-key, used to encrypt the device keys stored
+// in the database. The key is either configured directly or read from a
+// file, both HEX encoded.
+type MasterKey struct {
+	ID      string
+	Key     string
+	KeyFile string `mapstructure:"key_file"`
+}
@@ -0,0 +1,254 @@
+package downlink
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+)
+
+// DownlinkBatch defines a downlink payload which must be enqueued for
+// multiple devices of an application. The devices are selected by DevEUIs,
+// else by DeviceProfileID and / or Tags (the devices must have all the given
+// tags). When these are empty, all the devices of the application are
+// selected. When Object is set, it is encoded for each device
+// using the application codec. When IdempotencyKey is set, devices for which
+// the key was already used result in an ErrDuplicateDownlink error.
+type DownlinkBatch struct {
+	ApplicationID   int64
+	DevEUIs         []lorawan.EUI64
+	DeviceProfileID string
+	Tags            storage.DeviceTags
+	Reference       string
+	Confirmed       bool
+	FPort           uint8
+	Data            []byte
+	Object          *string
+	RetryPolicy     *storage.DownlinkRetryPolicy
+	IdempotencyKey  string
+}
+
+// BatchResult holds the enqueue result for a single device of a batch.
+type BatchResult struct {
+	DevEUI lorawan.EUI64
+	FCnt   uint32
+	Error  error
+}
+
+// EnqueueDownlinkBatch enqueues the downlink payload for each of the devices
+// selected by the given batch and returns the result for each device.
+// The devices are handled concurrently (see the downlink_batch concurrency
+// setting). Given DevEUIs which do not belong to the application result in
+// an ErrDoesNotExist error for that device.
+func EnqueueDownlinkBatch(b DownlinkBatch) ([]BatchResult, error) {
+	if b.RetryPolicy != nil {
+		if err := b.RetryPolicy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	app, err := storage.GetApplication(config.C.PostgreSQL.DB, b.ApplicationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get application error")
+	}
+
+	devices, err := storage.GetDevicesForApplicationIDAndSelector(config.C.PostgreSQL.DB, b.ApplicationID, b.DevEUIs, b.DeviceProfileID, b.Tags)
+	if err != nil {
+		return nil, errors.Wrap(err, "get devices error")
+	}
+
+	// all the devices of an application are provisioned on the same
+	// network-server
+	n, err := storage.GetNetworkServerForServiceProfileID(config.C.PostgreSQL.DB, app.ServiceProfileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get network-server error")
+	}
+	nsClient, err := config.C.NetworkServer.Pool.Get(n.Server, []byte(n.CACert), []byte(n.TLSCert), []byte(n.TLSKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get network-server client error")
+	}
+
+	policies, err := getBatchRetryPolicies(b, devices)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := config.C.ApplicationServer.DownlinkBatch.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	log.WithFields(log.Fields{
+		"application_id": app.ID,
+		"reference":      b.Reference,
+		"devices":        len(devices),
+	}).Info("enqueueing downlink batch")
+
+	results := make([]BatchResult, len(devices))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range devices {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			results[i] = enqueueDownlinkBatchForDevice(nsClient, app, devices[i], b, policies[devices[i].DeviceProfileID])
+		}(i)
+	}
+	wg.Wait()
+
+	// report the requested devices which are not part of the application
+	found := make(map[lorawan.EUI64]struct{})
+	for _, d := range devices {
+		found[d.DevEUI] = struct{}{}
+	}
+	for _, devEUI := range b.DevEUIs {
+		if _, ok := found[devEUI]; ok {
+			continue
+		}
+		found[devEUI] = struct{}{}
+		results = append(results, BatchResult{
+			DevEUI: devEUI,
+			Error:  storage.ErrDoesNotExist,
+		})
+	}
+
+	return results, nil
+}
+
+// handleDataDownBatchPayload enqueues the batch data-down payload received
+// from the integration handler. Errors of individual devices are logged.
+func handleDataDownBatchPayload(pl handler.DataDownPayload) error {
+	if pl.ScheduledAt != nil || pl.ExpiresAt != nil {
+		return errors.New("scheduledAt and expiresAt are not supported for batch payloads")
+	}
+
+	b := DownlinkBatch{
+		ApplicationID:   pl.ApplicationID,
+		DevEUIs:         pl.DevEUIs,
+		DeviceProfileID: pl.DeviceProfileID,
+		Tags:            pl.Tags,
+		Reference:       pl.Reference,
+		Confirmed:       pl.Confirmed,
+		FPort:           pl.FPort,
+		Data:            pl.Data,
+		IdempotencyKey:  pl.IdempotencyKey,
+	}
+
+	if pl.RetryPolicy != nil {
+		b.RetryPolicy = &storage.DownlinkRetryPolicy{
+			MaxAttempts: pl.RetryPolicy.MaxAttempts,
+			Backoff:     pl.RetryPolicy.Backoff,
+			Reencode:    pl.RetryPolicy.Reencode,
+		}
+	}
+
+	if pl.Object != nil {
+		obj := string(pl.Object)
+		b.Object = &obj
+	}
+
+	results, err := EnqueueDownlinkBatch(b)
+	if err != nil {
+		return errors.Wrap(err, "enqueue downlink batch error")
+	}
+
+	for _, res := range results {
+		if res.Error != nil {
+			log.WithFields(log.Fields{
+				"dev_eui":        res.DevEUI,
+				"application_id": pl.ApplicationID,
+				"reference":      pl.Reference,
+			}).Errorf("enqueue downlink batch payload error: %s", res.Error)
+		}
+	}
+
+	return nil
+}
+
+// getBatchRetryPolicies returns the retry policy to use per device-profile
+// of the given devices.
+func getBatchRetryPolicies(b DownlinkBatch, devices []storage.Device) (map[string]storage.DownlinkRetryPolicy, error) {
+	policies := make(map[string]storage.DownlinkRetryPolicy)
+	if !b.Confirmed {
+		return policies, nil
+	}
+
+	for _, d := range devices {
+		if _, ok := policies[d.DeviceProfileID]; ok {
+			continue
+		}
+
+		if b.RetryPolicy != nil {
+			policies[d.DeviceProfileID] = *b.RetryPolicy
+			continue
+		}
+
+		dp, err := storage.GetDeviceProfileMeta(config.C.PostgreSQL.DB, d.DeviceProfileID)
+		if err != nil {
+			return nil, errors.Wrap(err, "get device-profile error")
+		}
+		policies[d.DeviceProfileID] = dp.DownlinkRetryPolicy
+	}
+
+	return policies, nil
+}
+
+// enqueueDownlinkBatchForDevice enqueues the payload of the batch for the
+// given device.
+func enqueueDownlinkBatchForDevice(nsClient ns.NetworkServerServiceClient, app storage.Application, d storage.Device, b DownlinkBatch, policy storage.DownlinkRetryPolicy) BatchResult {
+	res := BatchResult{
+		DevEUI: d.DevEUI,
+	}
+
+	data := b.Data
+	if b.Object != nil {
+		var err error
+		data, err = EncodeObject(app, d, b.FPort, json.RawMessage(*b.Object))
+		if err != nil {
+			logCodecError(app, d, err)
+			res.Error = err
+			return res
+		}
+	}
+
+	s := storage.DeviceQueueItemStatus{
+		DevEUI:              d.DevEUI,
+		Reference:           b.Reference,
+		Confirmed:           b.Confirmed,
+		State:               storage.DeviceQueueItemQueued,
+		DownlinkRetryPolicy: policy,
+	}
+	if policy.Enabled() {
+		s.FPort = b.FPort
+		s.Data = data
+		s.Object = b.Object
+	}
+
+	err := WithIdempotencyKey(d.DevEUI, b.IdempotencyKey, func() error {
+		return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+			return enqueueDownlinkPayloadWithClient(tx, nsClient, &s, b.FPort, data)
+		})
+	})
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	res.FCnt = *s.FCnt
+	return res
+}
@@ -0,0 +1,213 @@
+package downlink
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestEnqueueDownlinkBatch(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	config.C.ApplicationServer.DownlinkBatch.Concurrency = 2
+
+	Convey("Given a clean database with an application and three devices", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dps := []storage.DeviceProfile{
+			{Name: "test-dp-1", OrganizationID: org.ID, NetworkServerID: n.ID},
+			{Name: "test-dp-2", OrganizationID: org.ID, NetworkServerID: n.ID, DownlinkRetryPolicy: storage.DownlinkRetryPolicy{MaxAttempts: 3}},
+		}
+		for i := range dps {
+			So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dps[i]), ShouldBeNil)
+		}
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		// the third device is not activated
+		devices := []storage.Device{
+			{ApplicationID: app.ID, DeviceProfileID: dps[0].DeviceProfile.DeviceProfileID, Name: "test-node-1", DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Tags: storage.DeviceTags{"building": "a", "floor": "1"}},
+			{ApplicationID: app.ID, DeviceProfileID: dps[1].DeviceProfile.DeviceProfileID, Name: "test-node-2", DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Tags: storage.DeviceTags{"building": "a", "floor": "2"}},
+			{ApplicationID: app.ID, DeviceProfileID: dps[0].DeviceProfile.DeviceProfileID, Name: "test-node-3", DevEUI: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, Tags: storage.DeviceTags{"building": "b"}},
+		}
+		for i := range devices {
+			So(storage.CreateDevice(config.C.PostgreSQL.DB, &devices[i]), ShouldBeNil)
+		}
+		for i := range devices[:2] {
+			So(storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &storage.DeviceActivation{
+				DevEUI:  devices[i].DevEUI,
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			}), ShouldBeNil)
+		}
+
+		Convey("When enqueueing a batch for all the devices of the application", func() {
+			results, err := EnqueueDownlinkBatch(DownlinkBatch{
+				ApplicationID: app.ID,
+				Reference:     "test-batch",
+				FPort:         2,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload was enqueued for the activated devices", func() {
+				So(results, ShouldHaveLength, 3)
+				So(results[0], ShouldResemble, BatchResult{DevEUI: devices[0].DevEUI, FCnt: 12})
+				So(results[1], ShouldResemble, BatchResult{DevEUI: devices[1].DevEUI, FCnt: 12})
+				So(results[2].DevEUI, ShouldEqual, devices[2].DevEUI)
+				So(results[2].Error, ShouldNotBeNil)
+
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 2)
+			})
+
+			Convey("Then a device-queue item status was created for the enqueued devices", func() {
+				s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, devices[0].DevEUI, "test-batch")
+				So(err, ShouldBeNil)
+				So(s.State, ShouldEqual, storage.DeviceQueueItemQueued)
+				So(*s.FCnt, ShouldEqual, 12)
+
+				_, err = storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, devices[2].DevEUI, "test-batch")
+				So(err, ShouldEqual, storage.ErrDoesNotExist)
+			})
+		})
+
+		Convey("When enqueueing a confirmed batch for the devices using a device-profile", func() {
+			results, err := EnqueueDownlinkBatch(DownlinkBatch{
+				ApplicationID:   app.ID,
+				DeviceProfileID: dps[1].DeviceProfile.DeviceProfileID,
+				Reference:       "test-batch",
+				Confirmed:       true,
+				FPort:           2,
+				Data:            []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload was only enqueued for the device using the device-profile", func() {
+				So(results, ShouldResemble, []BatchResult{
+					{DevEUI: devices[1].DevEUI, FCnt: 12},
+				})
+			})
+
+			Convey("Then the retry policy of the device-profile is used", func() {
+				s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, devices[1].DevEUI, "test-batch")
+				So(err, ShouldBeNil)
+				So(s.DownlinkRetryPolicy.MaxAttempts, ShouldEqual, 3)
+				So(s.Data, ShouldResemble, []byte{1, 2, 3, 4})
+			})
+		})
+
+		Convey("When enqueueing a batch for the devices having the given tags", func() {
+			results, err := EnqueueDownlinkBatch(DownlinkBatch{
+				ApplicationID: app.ID,
+				Tags:          storage.DeviceTags{"building": "a"},
+				Reference:     "test-batch",
+				FPort:         2,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload was enqueued for the devices having these tags", func() {
+				So(results, ShouldResemble, []BatchResult{
+					{DevEUI: devices[0].DevEUI, FCnt: 12},
+					{DevEUI: devices[1].DevEUI, FCnt: 12},
+				})
+			})
+		})
+
+		Convey("When enqueueing a batch for the devices using a device-profile and having the given tags", func() {
+			results, err := EnqueueDownlinkBatch(DownlinkBatch{
+				ApplicationID:   app.ID,
+				DeviceProfileID: dps[0].DeviceProfile.DeviceProfileID,
+				Tags:            storage.DeviceTags{"building": "a"},
+				Reference:       "test-batch",
+				FPort:           2,
+				Data:            []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload was only enqueued for the device matching both", func() {
+				So(results, ShouldResemble, []BatchResult{
+					{DevEUI: devices[0].DevEUI, FCnt: 12},
+				})
+			})
+		})
+
+		Convey("When enqueueing a batch for a list of DevEUIs", func() {
+			unknown := lorawan.EUI64{9, 9, 9, 9, 9, 9, 9, 9}
+			results, err := EnqueueDownlinkBatch(DownlinkBatch{
+				ApplicationID: app.ID,
+				DevEUIs:       []lorawan.EUI64{devices[0].DevEUI, unknown},
+				Reference:     "test-batch",
+				FPort:         2,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the unknown DevEUI results in an error", func() {
+				So(results, ShouldResemble, []BatchResult{
+					{DevEUI: devices[0].DevEUI, FCnt: 12},
+					{DevEUI: unknown, Error: storage.ErrDoesNotExist},
+				})
+			})
+		})
+
+		Convey("When handling a batch data-down payload", func() {
+			err := handleDataDownPayload(handler.DataDownPayload{
+				ApplicationID: app.ID,
+				Batch:         true,
+				DevEUIs:       []lorawan.EUI64{devices[1].DevEUI},
+				Reference:     "test-batch",
+				FPort:         2,
+				Data:          []byte{1, 2, 3, 4},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload was enqueued for the given device", func() {
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+				req := <-nsClient.CreateDeviceQueueItemChan
+				So(req.Item.DevEui, ShouldResemble, devices[1].DevEUI[:])
+			})
+		})
+	})
+}
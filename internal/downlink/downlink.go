@@ -3,6 +3,7 @@ package downlink
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -35,6 +36,10 @@ func HandleDataDownPayloads() {
 }
 
 func handleDataDownPayload(pl handler.DataDownPayload) error {
+	if pl.Batch {
+		return handleDataDownBatchPayload(pl)
+	}
+
 	d, err := storage.GetDevice(config.C.PostgreSQL.DB, pl.DevEUI)
 	if err != nil {
 		return fmt.Errorf("get device error: %s", err)
@@ -48,46 +53,234 @@ func handleDataDownPayload(pl handler.DataDownPayload) error {
 		return errors.New("enqueue downlink payload: device does not exist for given application")
 	}
 
+	var object *string
+	var retry *storage.DownlinkRetryPolicy
+
+	if pl.RetryPolicy != nil {
+		retry = &storage.DownlinkRetryPolicy{
+			MaxAttempts: pl.RetryPolicy.MaxAttempts,
+			Backoff:     pl.RetryPolicy.Backoff,
+			Reencode:    pl.RetryPolicy.Reencode,
+		}
+	}
+
 	// if Object is set, try to encode it to bytes using the application codec
 	if pl.Object != nil {
+		obj := string(pl.Object)
+		object = &obj
+
 		app, err := storage.GetApplication(config.C.PostgreSQL.DB, d.ApplicationID)
 		if err != nil {
 			return errors.Wrap(err, "get application error")
 		}
 
-		// get the codec payload configured for the application
-		codecPL := codec.NewPayload(app.PayloadCodec, pl.FPort, app.PayloadEncoderScript, app.PayloadDecoderScript)
-		if codecPL == nil {
-			logCodecError(app, d, errors.New("no or invalid codec configured for application"))
-			return errors.New("no or invalid codec configured for application")
+		pl.Data, err = EncodeObject(app, d, pl.FPort, pl.Object)
+		if err != nil {
+			logCodecError(app, d, err)
+			return err
 		}
+	}
+
+	err = WithIdempotencyKey(pl.DevEUI, pl.IdempotencyKey, func() error {
+		return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+			if _, err := ScheduleDownlinkPayload(tx, pl.DevEUI, pl.Reference, pl.Confirmed, pl.FPort, pl.Data, object, retry, pl.ScheduledAt, pl.ExpiresAt); err != nil {
+				return errors.Wrap(err, "enqueue downlink device-queue item error")
+			}
+			return nil
+		})
+	})
+	if err == storage.ErrDuplicateDownlink {
+		// e.g. a redelivered MQTT message, which must be ignored
+		return nil
+	}
+	return err
+}
+
+// EncodeObject encodes the given object to bytes using the codec configured
+// for the application / device-profile of the device.
+func EncodeObject(app storage.Application, d storage.Device, fPort uint8, object json.RawMessage) ([]byte, error) {
+	cc, err := storage.GetCodecConfigForDevice(config.C.PostgreSQL.DB, app, d.DeviceProfileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get codec config error")
+	}
+
+	// get the codec payload configured for the application / device-profile
+	codecPL := codec.NewPayload(cc.Type, fPort, cc.EncoderScript, cc.DecoderScript)
+	if codecPL == nil {
+		return nil, errors.New("no or invalid codec configured for application")
+	}
 
-		err = json.Unmarshal(pl.Object, &codecPL)
+	if err := json.Unmarshal(object, &codecPL); err != nil {
+		return nil, errors.Wrap(err, "unmarshal to codec payload error")
+	}
+
+	b, err := codecPL.EncodeToBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal codec payload to binary error")
+	}
+
+	return b, nil
+}
+
+// ScheduleDownlinkPayload adds the downlink payload to the network-server
+// device-queue when scheduledAt is nil or not in the future, in which case
+// expiresAt is ignored. Otherwise the payload is stored as scheduled item,
+// which will be enqueued by ScheduledDownlinksLoop once it is due. It returns
+// the ID of the scheduled item (0 when the payload was enqueued directly).
+// For confirmed payloads, the given retry policy is used, or the retry policy
+// of the device-profile when nil. The object is the codec object from which
+// the data was encoded (optional), it is used for re-encoding the data on a
+// retry.
+func ScheduleDownlinkPayload(db sqlx.Ext, devEUI lorawan.EUI64, reference string, confirmed bool, fPort uint8, data []byte, object *string, retry *storage.DownlinkRetryPolicy, scheduledAt, expiresAt *time.Time) (int64, error) {
+	item := storage.DeviceQueueScheduledItem{
+		DevEUI:      devEUI,
+		Reference:   reference,
+		Confirmed:   confirmed,
+		FPort:       fPort,
+		Data:        data,
+		ScheduledAt: time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+
+	s := storage.DeviceQueueItemStatus{
+		DevEUI:    devEUI,
+		Reference: reference,
+		Confirmed: confirmed,
+		State:     storage.DeviceQueueItemQueued,
+	}
+
+	if confirmed {
+		policy, err := getDownlinkRetryPolicy(db, devEUI, retry)
 		if err != nil {
-			logCodecError(app, d, err)
-			return errors.Wrap(err, "unmarshal to codec payload error")
+			return 0, errors.Wrap(err, "get downlink retry policy error")
+		}
+
+		s.DownlinkRetryPolicy = policy
+		if policy.Enabled() {
+			s.FPort = fPort
+			s.Data = data
+			s.Object = object
 		}
+	}
+
+	if scheduledAt == nil || !scheduledAt.After(item.ScheduledAt) {
+		return 0, enqueueDownlinkPayload(db, &s, fPort, data)
+	}
 
-		pl.Data, err = codecPL.EncodeToBytes()
+	item.ScheduledAt = *scheduledAt
+	if err := storage.CreateDeviceQueueScheduledItem(db, &item); err != nil {
+		return 0, errors.Wrap(err, "create device-queue scheduled item error")
+	}
+
+	s.DeviceQueueScheduledItemID = &item.ID
+	if err := storage.CreateDeviceQueueItemStatus(db, &s); err != nil {
+		return 0, errors.Wrap(err, "create device-queue item status error")
+	}
+
+	return item.ID, nil
+}
+
+// RetryDownlinkPayload enqueues the confirmed downlink of the given status
+// again, when its retry policy allows an other attempt. When the policy has
+// a backoff, the downlink is stored as scheduled item. It returns false when
+// there are no attempts left.
+func RetryDownlinkPayload(db sqlx.Ext, s *storage.DeviceQueueItemStatus) (bool, error) {
+	if !s.Confirmed || s.Attempt >= s.DownlinkRetryPolicy.MaxAttempts {
+		return false, nil
+	}
+
+	if s.DownlinkRetryPolicy.Reencode && s.Object != nil {
+		d, err := storage.GetDevice(db, s.DevEUI)
+		if err != nil {
+			return false, errors.Wrap(err, "get device error")
+		}
+		app, err := storage.GetApplication(db, d.ApplicationID)
+		if err != nil {
+			return false, errors.Wrap(err, "get application error")
+		}
+
+		s.Data, err = EncodeObject(app, d, s.FPort, json.RawMessage(*s.Object))
 		if err != nil {
 			logCodecError(app, d, err)
-			return errors.Wrap(err, "marshal codec payload to binary error")
+			return false, err
 		}
 	}
 
-	return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
-		if err := EnqueueDownlinkPayload(tx, pl.DevEUI, pl.Reference, pl.Confirmed, pl.FPort, pl.Data); err != nil {
-			return errors.Wrap(err, "enqueue downlink device-queue item error")
-		}
-		return nil
-	})
+	s.Attempt++
+	s.FCnt = nil
+	s.DeviceQueueScheduledItemID = nil
+	s.SentAt = nil
+	s.CompletedAt = nil
+	s.State = storage.DeviceQueueItemQueued
+
+	log.WithFields(log.Fields{
+		"dev_eui":   s.DevEUI,
+		"reference": s.Reference,
+		"attempt":   s.Attempt,
+	}).Info("retrying confirmed downlink")
+
+	backoff := s.DownlinkRetryPolicy.BackoffForAttempt(s.Attempt)
+	if backoff == 0 {
+		return true, enqueueDownlinkPayload(db, s, s.FPort, s.Data)
+	}
+
+	item := storage.DeviceQueueScheduledItem{
+		DevEUI:      s.DevEUI,
+		Reference:   s.Reference,
+		Confirmed:   s.Confirmed,
+		FPort:       s.FPort,
+		Data:        s.Data,
+		ScheduledAt: time.Now().Add(backoff),
+	}
+	if err := storage.CreateDeviceQueueScheduledItem(db, &item); err != nil {
+		return false, errors.Wrap(err, "create device-queue scheduled item error")
+	}
+
+	s.DeviceQueueScheduledItemID = &item.ID
+	if err := storage.UpdateDeviceQueueItemStatus(db, s); err != nil {
+		return false, errors.Wrap(err, "update device-queue item status error")
+	}
+
+	return true, nil
+}
+
+// getDownlinkRetryPolicy returns the given retry policy, or the retry policy
+// of the device-profile of the device when nil.
+func getDownlinkRetryPolicy(db sqlx.Queryer, devEUI lorawan.EUI64, retry *storage.DownlinkRetryPolicy) (storage.DownlinkRetryPolicy, error) {
+	if retry != nil {
+		return *retry, retry.Validate()
+	}
+
+	d, err := storage.GetDevice(db, devEUI)
+	if err != nil {
+		return storage.DownlinkRetryPolicy{}, errors.Wrap(err, "get device error")
+	}
+
+	dp, err := storage.GetDeviceProfileMeta(db, d.DeviceProfileID)
+	if err != nil {
+		return storage.DownlinkRetryPolicy{}, errors.Wrap(err, "get device-profile error")
+	}
+
+	return dp.DownlinkRetryPolicy, nil
 }
 
 // EnqueueDownlinkPayload adds the downlink payload to the network-server
-// device-queue.
+// device-queue and creates the device-queue item status for it.
 func EnqueueDownlinkPayload(db sqlx.Ext, devEUI lorawan.EUI64, reference string, confirmed bool, fPort uint8, data []byte) error {
+	return enqueueDownlinkPayload(db, &storage.DeviceQueueItemStatus{
+		DevEUI:    devEUI,
+		Reference: reference,
+		Confirmed: confirmed,
+		State:     storage.DeviceQueueItemQueued,
+	}, fPort, data)
+}
+
+// enqueueDownlinkPayload adds the downlink payload to the network-server
+// device-queue. The given device-queue item status is created, or updated
+// when it already exists (e.g. for a scheduled item).
+func enqueueDownlinkPayload(db sqlx.Ext, s *storage.DeviceQueueItemStatus, fPort uint8, data []byte) error {
 	// get network-server and network-server api client
-	n, err := storage.GetNetworkServerForDevEUI(db, devEUI)
+	n, err := storage.GetNetworkServerForDevEUI(db, s.DevEUI)
 	if err != nil {
 		return errors.Wrap(err, "get network-server error")
 	}
@@ -96,9 +289,20 @@ func EnqueueDownlinkPayload(db sqlx.Ext, devEUI lorawan.EUI64, reference string,
 		return errors.Wrap(err, "get network-server client error")
 	}
 
+	return enqueueDownlinkPayloadWithClient(db, nsClient, s, fPort, data)
+}
+
+// enqueueDownlinkPayloadWithClient adds the downlink payload to the device-queue
+// of the given network-server client. This avoids the network-server lookup
+// when enqueueing for many devices of the same application.
+func enqueueDownlinkPayloadWithClient(db sqlx.Ext, nsClient ns.NetworkServerServiceClient, s *storage.DeviceQueueItemStatus, fPort uint8, data []byte) error {
+	devEUI := s.DevEUI
+	reference := s.Reference
+	confirmed := s.Confirmed
+
 	// get fCnt to use for encrypting and enqueueing
 	resp, err := nsClient.GetNextDownlinkFCntForDevEUI(context.Background(), &ns.GetNextDownlinkFCntForDevEUIRequest{
-		DevEUI: devEUI[:],
+		DevEui: devEUI[:],
 	})
 	if err != nil {
 		return errors.Wrap(err, "get next downlink fcnt for deveui error")
@@ -116,6 +320,12 @@ func EnqueueDownlinkPayload(db sqlx.Ext, devEUI lorawan.EUI64, reference string,
 		return errors.Wrap(err, "encrypt frmpayload error")
 	}
 
+	// store the delivery status of the device-queue item
+	s.FCnt = &resp.FCnt
+	if err := saveDeviceQueueItemStatus(db, s); err != nil {
+		return errors.Wrap(err, "save device-queue item status error")
+	}
+
 	// create device-queue mapping (for mapping a device-queue item to an
 	// user-given reference)
 	if confirmed == true {
@@ -132,7 +342,7 @@ func EnqueueDownlinkPayload(db sqlx.Ext, devEUI lorawan.EUI64, reference string,
 	// enqueue device-queue item
 	_, err = nsClient.CreateDeviceQueueItem(context.Background(), &ns.CreateDeviceQueueItemRequest{
 		Item: &ns.DeviceQueueItem{
-			DevEUI:     devEUI[:],
+			DevEui:     devEUI[:],
 			FrmPayload: b,
 			FCnt:       resp.FCnt,
 			FPort:      uint32(fPort),
@@ -153,13 +363,28 @@ func EnqueueDownlinkPayload(db sqlx.Ext, devEUI lorawan.EUI64, reference string,
 	return nil
 }
 
+// saveDeviceQueueItemStatus creates the given device-queue item status when
+// it does not exist yet, else it updates it.
+func saveDeviceQueueItemStatus(db sqlx.Ext, s *storage.DeviceQueueItemStatus) error {
+	if s.ID == 0 {
+		return storage.CreateDeviceQueueItemStatus(db, s)
+	}
+	return storage.UpdateDeviceQueueItemStatus(db, s)
+}
+
 func logCodecError(a storage.Application, d storage.Device, err error) {
+	logError(a, d, "CODEC", err)
+}
+
+// logError logs the given error to the device event-log and sends it as
+// error notification to the integration handler.
+func logError(a storage.Application, d storage.Device, typ string, err error) {
 	errNotification := handler.ErrorNotification{
 		ApplicationID:   a.ID,
 		ApplicationName: a.Name,
 		DeviceName:      d.Name,
 		DevEUI:          d.DevEUI,
-		Type:            "CODEC",
+		Type:            typ,
 		Error:           err.Error(),
 	}
 
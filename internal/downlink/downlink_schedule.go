@@ -0,0 +1,132 @@
+package downlink
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// DownlinkSchedulesLoop is a never returning function running the due
+// downlink schedules.
+func DownlinkSchedulesLoop() {
+	for {
+		for {
+			handled, err := handleDownlinkSchedule()
+			if err != nil {
+				log.Errorf("handle downlink schedule error: %s", err)
+				break
+			}
+			if !handled {
+				break
+			}
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// handleDownlinkSchedule takes the next due downlink schedule, sets its next
+// run and enqueues its payload for each of the target devices. Runs which
+// were missed (e.g. because no instance was running) are not caught up.
+// It returns false when there was no schedule due.
+func handleDownlinkSchedule() (bool, error) {
+	var s storage.DownlinkSchedule
+	var devEUIs []lorawan.EUI64
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		s, err = storage.GetDueDownlinkScheduleForUpdate(tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		next, err := s.Next(now)
+		if err != nil {
+			return errors.Wrap(err, "get next run error")
+		}
+
+		if err := storage.SetDownlinkScheduleRun(tx, s.ID, now, next); err != nil {
+			return errors.Wrap(err, "set downlink schedule run error")
+		}
+
+		devEUIs, err = storage.GetDownlinkScheduleTargetDevEUIs(tx, s)
+		if err != nil {
+			return errors.Wrap(err, "get downlink schedule targets error")
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return false, nil
+		}
+		return false, err
+	}
+
+	log.WithFields(log.Fields{
+		"id":      s.ID,
+		"name":    s.Name,
+		"devices": len(devEUIs),
+	}).Info("running downlink schedule")
+
+	apps := make(map[int64]storage.Application)
+	for _, devEUI := range devEUIs {
+		if err := enqueueDownlinkScheduleForDevice(s, devEUI, apps); err != nil {
+			log.WithFields(log.Fields{
+				"id":      s.ID,
+				"dev_eui": devEUI,
+			}).WithError(err).Error("enqueue downlink schedule payload error")
+		}
+	}
+
+	return true, nil
+}
+
+// enqueueDownlinkScheduleForDevice enqueues the payload of the downlink
+// schedule for the given device. Enqueue errors are sent as error
+// notification to the integration handler of the application. The given
+// apps map is used to cache the applications of the schedule run.
+func enqueueDownlinkScheduleForDevice(s storage.DownlinkSchedule, devEUI lorawan.EUI64, apps map[int64]storage.Application) error {
+	d, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device error")
+	}
+
+	app, ok := apps[d.ApplicationID]
+	if !ok {
+		app, err = storage.GetApplication(config.C.PostgreSQL.DB, d.ApplicationID)
+		if err != nil {
+			return errors.Wrap(err, "get application error")
+		}
+		apps[app.ID] = app
+	}
+
+	data := s.Data
+	if s.Object != nil {
+		data, err = EncodeObject(app, d, s.FPort, json.RawMessage(*s.Object))
+		if err != nil {
+			logCodecError(app, d, err)
+			return err
+		}
+	}
+
+	reference := fmt.Sprintf("downlink-schedule-%d", s.ID)
+	err = storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return EnqueueDownlinkPayload(tx, devEUI, reference, s.Confirmed, s.FPort, data)
+	})
+	if err != nil {
+		err = errors.Wrap(err, "enqueue downlink schedule payload error")
+		logError(app, d, "DOWNLINK", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDownlinkSchedules(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = storage.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database an organization, application + node", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		h := testhandler.NewTestHandler()
+		config.C.ApplicationServer.Integration.Handler = h
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		device := storage.Device{
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			Name:            "test-node",
+			DevEUI:          [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDevice(config.C.PostgreSQL.DB, &device), ShouldBeNil)
+
+		da := storage.DeviceActivation{
+			DevEUI:  [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			DevAddr: [4]byte{1, 2, 3, 4},
+			AppSKey: [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		So(storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &da), ShouldBeNil)
+
+		b, err := lorawan.EncryptFRMPayload(da.AppSKey, false, da.DevAddr, 12, []byte{1, 2, 3, 4})
+		So(err, ShouldBeNil)
+
+		Convey("Given a downlink schedule targeting the application", func() {
+			s := storage.DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "@hourly",
+				ApplicationID:  &app.ID,
+				FPort:          2,
+				Data:           []byte{1, 2, 3, 4},
+			}
+			So(storage.CreateDownlinkSchedule(config.C.PostgreSQL.DB, &s), ShouldBeNil)
+
+			Convey("Then the schedule is not handled before it is due", func() {
+				handled, err := handleDownlinkSchedule()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeFalse)
+			})
+
+			Convey("When the schedule becomes due", func() {
+				_, err := config.C.PostgreSQL.DB.Exec("update downlink_schedule set next_run_at = now() - interval '1 second'")
+				So(err, ShouldBeNil)
+
+				handled, err := handleDownlinkSchedule()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeTrue)
+
+				Convey("Then the payload has been enqueued for the device", func() {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+					So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
+						Item: &ns.DeviceQueueItem{
+							DevEui:     device.DevEUI[:],
+							FrmPayload: b,
+							FCnt:       12,
+							FPort:      2,
+						},
+					})
+				})
+
+				Convey("Then the last and next run have been updated", func() {
+					s2, err := storage.GetDownlinkSchedule(config.C.PostgreSQL.DB, s.ID)
+					So(err, ShouldBeNil)
+					So(s2.LastRunAt, ShouldNotBeNil)
+					So(s2.NextRunAt.After(time.Now()), ShouldBeTrue)
+				})
+
+				Convey("Then the schedule is not handled again", func() {
+					handled, err := handleDownlinkSchedule()
+					So(err, ShouldBeNil)
+					So(handled, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("Given a due downlink schedule with an object and no codec configured", func() {
+			object := `{"temperature": 20}`
+			s := storage.DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "@hourly",
+				DevEUIs:        []lorawan.EUI64{device.DevEUI},
+				FPort:          2,
+				Object:         &object,
+			}
+			So(storage.CreateDownlinkSchedule(config.C.PostgreSQL.DB, &s), ShouldBeNil)
+			_, err := config.C.PostgreSQL.DB.Exec("update downlink_schedule set next_run_at = now() - interval '1 second'")
+			So(err, ShouldBeNil)
+
+			Convey("When handling the schedule", func() {
+				handled, err := handleDownlinkSchedule()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeTrue)
+
+				Convey("Then the payload has not been enqueued", func() {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+				})
+
+				Convey("Then a codec error notification has been sent", func() {
+					So(h.SendErrorNotificationChan, ShouldHaveLength, 1)
+					errNotification := <-h.SendErrorNotificationChan
+					So(errNotification.Type, ShouldEqual, "CODEC")
+					So(errNotification.DevEUI, ShouldEqual, device.DevEUI)
+				})
+			})
+		})
+	})
+}
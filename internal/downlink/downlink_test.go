@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brocaar/lora-app-server/internal/codec"
 	"github.com/brocaar/lora-app-server/internal/config"
@@ -112,7 +113,7 @@ func TestHandleDownlinkQueueItem(t *testing.T) {
 
 					ExpectedCreateDeviceQueueItemRequest: ns.CreateDeviceQueueItemRequest{
 						Item: &ns.DeviceQueueItem{
-							DevEUI:     device.DevEUI[:],
+							DevEui:     device.DevEUI[:],
 							FrmPayload: b,
 							FCnt:       12,
 							FPort:      2,
@@ -133,7 +134,7 @@ func TestHandleDownlinkQueueItem(t *testing.T) {
 
 					ExpectedCreateDeviceQueueItemRequest: ns.CreateDeviceQueueItemRequest{
 						Item: &ns.DeviceQueueItem{
-							DevEUI:     device.DevEUI[:],
+							DevEui:     device.DevEUI[:],
 							FrmPayload: b,
 							FCnt:       12,
 							FPort:      2,
@@ -176,7 +177,7 @@ func TestHandleDownlinkQueueItem(t *testing.T) {
 
 					ExpectedCreateDeviceQueueItemRequest: ns.CreateDeviceQueueItemRequest{
 						Item: &ns.DeviceQueueItem{
-							DevEUI:     device.DevEUI[:],
+							DevEui:     device.DevEUI[:],
 							FrmPayload: b,
 							FCnt:       12,
 							FPort:      2,
@@ -216,5 +217,61 @@ func TestHandleDownlinkQueueItem(t *testing.T) {
 				})
 			}
 		})
+
+		Convey("Given a confirmed downlink with a retry policy", func() {
+			err := handleDataDownPayload(handler.DataDownPayload{
+				ApplicationID: app.ID,
+				DevEUI:        device.DevEUI,
+				Reference:     "test-retry",
+				Confirmed:     true,
+				FPort:         2,
+				Data:          []byte{1, 2, 3, 4},
+				RetryPolicy: &handler.DownlinkRetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     10,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+			<-nsClient.CreateDeviceQueueItemChan
+
+			s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, device.DevEUI, "test-retry")
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload and retry policy are stored", func() {
+				So(s.Attempt, ShouldEqual, 1)
+				So(s.FPort, ShouldEqual, 2)
+				So(s.Data, ShouldResemble, []byte{1, 2, 3, 4})
+				So(s.DownlinkRetryPolicy, ShouldResemble, storage.DownlinkRetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     10,
+				})
+			})
+
+			Convey("When retrying the downlink", func() {
+				retried, err := RetryDownlinkPayload(config.C.PostgreSQL.DB, &s)
+				So(err, ShouldBeNil)
+				So(retried, ShouldBeTrue)
+
+				Convey("Then a scheduled item was created after the backoff", func() {
+					So(s.Attempt, ShouldEqual, 2)
+					So(s.FCnt, ShouldBeNil)
+					So(s.DeviceQueueScheduledItemID, ShouldNotBeNil)
+
+					item, err := storage.GetDeviceQueueScheduledItem(config.C.PostgreSQL.DB, *s.DeviceQueueScheduledItemID)
+					So(err, ShouldBeNil)
+					So(item.Confirmed, ShouldBeTrue)
+					So(item.Data, ShouldResemble, []byte{1, 2, 3, 4})
+					So(item.ScheduledAt.After(time.Now().Add(9*time.Second)), ShouldBeTrue)
+				})
+
+				Convey("Then the downlink is not retried after the max attempts", func() {
+					s.Attempt = 3
+					retried, err := RetryDownlinkPayload(config.C.PostgreSQL.DB, &s)
+					So(err, ShouldBeNil)
+					So(retried, ShouldBeFalse)
+				})
+			})
+		})
 	})
 }
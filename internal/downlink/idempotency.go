@@ -0,0 +1,72 @@
+package downlink
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const idempotencyKeyTempl = "lora:as:device:%s:downlink:idempotency:%s"
+
+// WithIdempotencyKey calls the given enqueue function, unless the given
+// idempotency key has already been used for the device within the configured
+// window, in which case ErrDuplicateDownlink is returned. When the enqueue
+// function returns an error, the key is released so that the downlink can be
+// enqueued again. An empty key disables the deduplication.
+func WithIdempotencyKey(devEUI lorawan.EUI64, key string, f func() error) error {
+	window := config.C.ApplicationServer.Downlink.IdempotencyWindow
+	if key == "" || window <= 0 {
+		return f()
+	}
+
+	if err := claimIdempotencyKey(devEUI, key, window); err != nil {
+		return err
+	}
+
+	if err := f(); err != nil {
+		if relErr := releaseIdempotencyKey(devEUI, key); relErr != nil {
+			log.WithError(relErr).WithField("dev_eui", devEUI).Error("release idempotency key error")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func claimIdempotencyKey(devEUI lorawan.EUI64, key string, window time.Duration) error {
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	_, err := redis.String(c.Do("SET", fmt.Sprintf(idempotencyKeyTempl, devEUI, key), "1", "PX", int64(window/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			log.WithFields(log.Fields{
+				"dev_eui":         devEUI,
+				"idempotency_key": key,
+			}).Warning("duplicate downlink idempotency key")
+			return storage.ErrDuplicateDownlink
+		}
+		return errors.Wrap(err, "set idempotency key error")
+	}
+
+	return nil
+}
+
+func releaseIdempotencyKey(devEUI lorawan.EUI64, key string) error {
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	_, err := redis.Int(c.Do("DEL", fmt.Sprintf(idempotencyKeyTempl, devEUI, key)))
+	if err != nil {
+		return errors.Wrap(err, "delete idempotency key error")
+	}
+
+	return nil
+}
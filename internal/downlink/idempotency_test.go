@@ -0,0 +1,67 @@
+package downlink
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestWithIdempotencyKey(t *testing.T) {
+	conf := test.GetConfig()
+	config.C.Redis.Pool = storage.NewRedisPool(conf.RedisURL)
+	config.C.ApplicationServer.Downlink.IdempotencyWindow = time.Minute
+
+	Convey("Given a clean Redis database", t, func() {
+		test.MustFlushRedis(config.C.Redis.Pool)
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		var calls int
+		enqueue := func() error {
+			calls++
+			return nil
+		}
+
+		Convey("When enqueueing with an idempotency key", func() {
+			So(WithIdempotencyKey(devEUI, "test-key", enqueue), ShouldBeNil)
+			So(calls, ShouldEqual, 1)
+
+			Convey("Then enqueueing with the same key returns an error", func() {
+				So(WithIdempotencyKey(devEUI, "test-key", enqueue), ShouldEqual, storage.ErrDuplicateDownlink)
+				So(calls, ShouldEqual, 1)
+			})
+
+			Convey("Then the same key can be used for an other device", func() {
+				So(WithIdempotencyKey(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, "test-key", enqueue), ShouldBeNil)
+				So(calls, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When enqueueing with an idempotency key fails", func() {
+			err := WithIdempotencyKey(devEUI, "test-key", func() error {
+				return errors.New("boom")
+			})
+			So(err, ShouldNotBeNil)
+
+			Convey("Then the key can be used again", func() {
+				So(WithIdempotencyKey(devEUI, "test-key", enqueue), ShouldBeNil)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When enqueueing without idempotency key", func() {
+			So(WithIdempotencyKey(devEUI, "", enqueue), ShouldBeNil)
+			So(WithIdempotencyKey(devEUI, "", enqueue), ShouldBeNil)
+
+			Convey("Then the payload is never deduplicated", func() {
+				So(calls, ShouldEqual, 2)
+			})
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package downlink
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// ScheduledDownlinksLoop is a never returning function adding the scheduled
+// downlink payloads to the device-queue once they are due.
+func ScheduledDownlinksLoop() {
+	for {
+		for {
+			handled, err := handleScheduledDownlink()
+			if err != nil {
+				log.Errorf("handle scheduled downlink error: %s", err)
+				break
+			}
+			if !handled {
+				break
+			}
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// ScheduledDownlinkRetryInterval defines the interval after which a
+// scheduled downlink is retried when it could not be enqueued.
+var ScheduledDownlinkRetryInterval = time.Minute
+
+// ScheduledDownlinkErrorInterval defines the min. interval between two error
+// notifications for a scheduled downlink which could not be enqueued.
+var ScheduledDownlinkErrorInterval = time.Hour
+
+const scheduledDownlinkErrorKeyTempl = "lora:as:device:scheduled:%d:error"
+
+// handleScheduledDownlink handles the next due scheduled item. The item is
+// locked, added to the device-queue and deleted within a single transaction.
+// When the item could not be enqueued, it is kept and retried after
+// ScheduledDownlinkRetryInterval until it expires. These failures are
+// reported at most once per ScheduledDownlinkErrorInterval, expired items
+// are dropped and always reported as error notification. It returns false
+// when there was no item due.
+func handleScheduledDownlink() (bool, error) {
+	var item storage.DeviceQueueScheduledItem
+	var expired bool
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		item, err = storage.GetDueDeviceQueueScheduledItemForUpdate(tx)
+		if err != nil {
+			return err
+		}
+
+		s, err := getScheduledItemStatus(tx, item)
+		if err != nil {
+			return errors.Wrap(err, "get device-queue item status error")
+		}
+
+		if item.Expired(time.Now()) {
+			expired = true
+
+			s.SetState(storage.DeviceQueueItemExpired, time.Now())
+			if err := saveDeviceQueueItemStatus(tx, &s); err != nil {
+				return errors.Wrap(err, "save device-queue item status error")
+			}
+		} else if err := enqueueDownlinkPayload(tx, &s, item.FPort, item.Data); err != nil {
+			return errors.Wrap(err, "enqueue scheduled downlink error")
+		}
+
+		if err := storage.DeleteDeviceQueueScheduledItem(tx, item.ID); err != nil {
+			return errors.Wrap(err, "delete device-queue scheduled item error")
+		}
+
+		return nil
+	})
+	if err != nil {
+		if item.ID == 0 {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return false, nil
+			}
+			return false, errors.Wrap(err, "get due device-queue scheduled item error")
+		}
+
+		notify, nErr := scheduledDownlinkErrorDue(item)
+		if nErr != nil {
+			log.WithError(nErr).Error("get scheduled downlink error notification due error")
+		}
+		if notify {
+			notifyScheduledDownlinkError(item, "DOWNLINK", err)
+		} else {
+			log.WithFields(log.Fields{
+				"id":      item.ID,
+				"dev_eui": item.DevEUI,
+			}).WithError(err).Warning("scheduled downlink could not be enqueued, will retry")
+		}
+
+		// the transaction has been rolled back, postpone the item so that it
+		// does not block the other due items
+		if err := storage.PostponeDeviceQueueScheduledItem(config.C.PostgreSQL.DB, item.ID, time.Now().Add(ScheduledDownlinkRetryInterval)); err != nil && err != storage.ErrDoesNotExist {
+			return true, errors.Wrap(err, "postpone device-queue scheduled item error")
+		}
+
+		return true, nil
+	}
+
+	if expired {
+		notifyScheduledDownlinkError(item, "DOWNLINK_EXPIRED", errors.New("scheduled downlink expired before it could be enqueued"))
+	}
+
+	return true, nil
+}
+
+// getScheduledItemStatus returns the device-queue item status of the given
+// scheduled item. A new (not yet stored) status is returned for items which
+// were scheduled without status.
+func getScheduledItemStatus(db sqlx.Queryer, item storage.DeviceQueueScheduledItem) (storage.DeviceQueueItemStatus, error) {
+	s, err := storage.GetDeviceQueueItemStatusForScheduledItem(db, item.ID)
+	if err == nil {
+		return s, nil
+	}
+	if errors.Cause(err) != storage.ErrDoesNotExist {
+		return s, err
+	}
+
+	return storage.DeviceQueueItemStatus{
+		DevEUI:                     item.DevEUI,
+		Reference:                  item.Reference,
+		Confirmed:                  item.Confirmed,
+		DeviceQueueScheduledItemID: &item.ID,
+		State:                      storage.DeviceQueueItemQueued,
+	}, nil
+}
+
+// scheduledDownlinkErrorDue returns true when no error notification has been
+// sent for the given item within the last ScheduledDownlinkErrorInterval.
+func scheduledDownlinkErrorDue(item storage.DeviceQueueScheduledItem) (bool, error) {
+	n, err := storage.IncrWindowCounter(config.C.Redis.Pool, fmt.Sprintf(scheduledDownlinkErrorKeyTempl, item.ID), ScheduledDownlinkErrorInterval)
+	if err != nil {
+		return true, err
+	}
+	return n == 1, nil
+}
+
+func notifyScheduledDownlinkError(item storage.DeviceQueueScheduledItem, typ string, cause error) {
+	log.WithFields(log.Fields{
+		"id":        item.ID,
+		"dev_eui":   item.DevEUI,
+		"reference": item.Reference,
+	}).WithError(cause).Error("scheduled downlink error")
+
+	d, err := storage.GetDevice(config.C.PostgreSQL.DB, item.DevEUI)
+	if err != nil {
+		log.WithError(err).Error("get device error")
+		return
+	}
+
+	a, err := storage.GetApplication(config.C.PostgreSQL.DB, d.ApplicationID)
+	if err != nil {
+		log.WithError(err).Error("get application error")
+		return
+	}
+
+	logError(a, d, typ, cause)
+}
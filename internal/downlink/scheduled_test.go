@@ -0,0 +1,238 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestScheduledDownlinks(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = storage.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database an organization, application + node", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		h := testhandler.NewTestHandler()
+		config.C.ApplicationServer.Integration.Handler = h
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		device := storage.Device{
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			Name:            "test-node",
+			DevEUI:          [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDevice(config.C.PostgreSQL.DB, &device), ShouldBeNil)
+
+		da := storage.DeviceActivation{
+			DevEUI:  [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			DevAddr: [4]byte{1, 2, 3, 4},
+			AppSKey: [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		So(storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &da), ShouldBeNil)
+
+		b, err := lorawan.EncryptFRMPayload(da.AppSKey, false, da.DevAddr, 12, []byte{1, 2, 3, 4})
+		So(err, ShouldBeNil)
+
+		Convey("When handling a data-down payload scheduled in the future", func() {
+			scheduledAt := time.Now().Add(time.Hour)
+			So(handleDataDownPayload(handler.DataDownPayload{
+				ApplicationID: app.ID,
+				DevEUI:        device.DevEUI,
+				FPort:         2,
+				Data:          []byte{1, 2, 3, 4},
+				ScheduledAt:   &scheduledAt,
+			}), ShouldBeNil)
+
+			Convey("Then the payload has not been enqueued", func() {
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+			})
+
+			Convey("Then a scheduled item has been created", func() {
+				items, err := storage.GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, device.DevEUI)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+			})
+
+			Convey("Then the item is not handled before it is due", func() {
+				handled, err := handleScheduledDownlink()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeFalse)
+			})
+
+			Convey("When the item becomes due", func() {
+				_, err := config.C.PostgreSQL.DB.Exec("update device_queue_scheduled_item set scheduled_at = now() - interval '1 second'")
+				So(err, ShouldBeNil)
+
+				handled, err := handleScheduledDownlink()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeTrue)
+
+				Convey("Then the payload has been enqueued", func() {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+					So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
+						Item: &ns.DeviceQueueItem{
+							DevEui:     device.DevEUI[:],
+							FrmPayload: b,
+							FCnt:       12,
+							FPort:      2,
+						},
+					})
+				})
+
+				Convey("Then the scheduled item has been removed", func() {
+					items, err := storage.GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, device.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+
+				Convey("Then the item status contains the FCnt", func() {
+					s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, device.DevEUI, "")
+					So(err, ShouldBeNil)
+					So(s.State, ShouldEqual, storage.DeviceQueueItemQueued)
+					So(s.DeviceQueueScheduledItemID, ShouldNotBeNil)
+					So(*s.FCnt, ShouldEqual, 12)
+				})
+			})
+
+			Convey("When the item becomes due but can not be enqueued", func() {
+				_, err := config.C.PostgreSQL.DB.Exec("update device_queue_scheduled_item set scheduled_at = now() - interval '1 second'")
+				So(err, ShouldBeNil)
+				_, err = config.C.PostgreSQL.DB.Exec("delete from device_activation")
+				So(err, ShouldBeNil)
+
+				handled, err := handleScheduledDownlink()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeTrue)
+
+				Convey("Then an error notification has been sent", func() {
+					So(h.SendErrorNotificationChan, ShouldHaveLength, 1)
+					errNotification := <-h.SendErrorNotificationChan
+					So(errNotification.Type, ShouldEqual, "DOWNLINK")
+				})
+
+				Convey("Then the scheduled item has been kept and postponed", func() {
+					items, err := storage.GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, device.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 1)
+					So(items[0].ScheduledAt.After(time.Now()), ShouldBeTrue)
+
+					handled, err := handleScheduledDownlink()
+					So(err, ShouldBeNil)
+					So(handled, ShouldBeFalse)
+				})
+
+				Convey("When the retry fails again", func() {
+					<-h.SendErrorNotificationChan
+					_, err := config.C.PostgreSQL.DB.Exec("update device_queue_scheduled_item set scheduled_at = now() - interval '1 second'")
+					So(err, ShouldBeNil)
+
+					handled, err := handleScheduledDownlink()
+					So(err, ShouldBeNil)
+					So(handled, ShouldBeTrue)
+
+					Convey("Then no error notification has been sent", func() {
+						So(h.SendErrorNotificationChan, ShouldHaveLength, 0)
+					})
+				})
+
+				Convey("Then the item status is still queued", func() {
+					s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, device.DevEUI, "")
+					So(err, ShouldBeNil)
+					So(s.State, ShouldEqual, storage.DeviceQueueItemQueued)
+					So(s.FCnt, ShouldBeNil)
+				})
+			})
+
+			Convey("When the item expired before it became due", func() {
+				_, err := config.C.PostgreSQL.DB.Exec("update device_queue_scheduled_item set scheduled_at = now() - interval '2 seconds', expires_at = now() - interval '1 second'")
+				So(err, ShouldBeNil)
+
+				handled, err := handleScheduledDownlink()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeTrue)
+
+				Convey("Then the payload has not been enqueued", func() {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+				})
+
+				Convey("Then an error notification has been sent", func() {
+					So(h.SendErrorNotificationChan, ShouldHaveLength, 1)
+					errNotification := <-h.SendErrorNotificationChan
+					So(errNotification.Type, ShouldEqual, "DOWNLINK_EXPIRED")
+					So(errNotification.DevEUI, ShouldEqual, device.DevEUI)
+				})
+
+				Convey("Then the scheduled item has been removed", func() {
+					items, err := storage.GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, device.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+
+				Convey("Then the item status has been set to expired", func() {
+					s, err := storage.GetDeviceQueueItemStatusForReference(config.C.PostgreSQL.DB, device.DevEUI, "")
+					So(err, ShouldBeNil)
+					So(s.State, ShouldEqual, storage.DeviceQueueItemExpired)
+					So(s.FCnt, ShouldBeNil)
+					So(s.CompletedAt, ShouldNotBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package downlink
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceQueueItemStatusCleanupInterval defines the interval at which the
+// device-queue item statuses older than the configured retention are
+// removed.
+var DeviceQueueItemStatusCleanupInterval = time.Hour
+
+// DeviceQueueItemStatusCleanupLoop is a never returning function removing
+// the device-queue item statuses which have not been updated within the
+// configured retention.
+func DeviceQueueItemStatusCleanupLoop() {
+	for {
+		if err := cleanupDeviceQueueItemStatus(); err != nil {
+			log.WithError(err).Error("device-queue item status cleanup error")
+		}
+		time.Sleep(DeviceQueueItemStatusCleanupInterval)
+	}
+}
+
+// cleanupDeviceQueueItemStatus removes the device-queue item statuses which
+// have not been updated within the configured retention. A retention of 0
+// disables the cleanup.
+func cleanupDeviceQueueItemStatus() error {
+	retention := config.C.ApplicationServer.Downlink.StatusRetention
+	if retention <= 0 {
+		return nil
+	}
+
+	count, err := storage.DeleteDeviceQueueItemStatusBefore(config.C.PostgreSQL.DB, time.Now().Add(-retention))
+	if err != nil {
+		return errors.Wrap(err, "delete device-queue item status error")
+	}
+
+	if count > 0 {
+		log.WithField("count", count).Info("device-queue item statuses removed")
+	}
+
+	return nil
+}
+
+// GetDeviceQueueItemStatus returns the most recent device-queue item status
+// for the given DevEUI and reference. When the item is no longer present in
+// the network-server device-queue, it is only set to sent when the downlink
+// frame-counter of the device-session has progressed beyond the FCnt of the
+// item. Otherwise, as the item might have been discarded by the
+// network-server, its state is set to unknown.
+func GetDeviceQueueItemStatus(db sqlx.Ext, devEUI lorawan.EUI64, reference string) (storage.DeviceQueueItemStatus, error) {
+	s, err := storage.GetDeviceQueueItemStatusForReference(db, devEUI, reference)
+	if err != nil {
+		return s, errors.Wrap(err, "get device-queue item status error")
+	}
+
+	if (s.State != storage.DeviceQueueItemQueued && s.State != storage.DeviceQueueItemUnknown) || s.FCnt == nil {
+		return s, nil
+	}
+
+	n, err := storage.GetNetworkServerForDevEUI(db, devEUI)
+	if err != nil {
+		return s, errors.Wrap(err, "get network-server error")
+	}
+	nsClient, err := config.C.NetworkServer.Pool.Get(n.Server, []byte(n.CACert), []byte(n.TLSCert), []byte(n.TLSKey))
+	if err != nil {
+		return s, errors.Wrap(err, "get network-server client error")
+	}
+
+	resp, err := nsClient.GetDeviceQueueItemsForDevEUI(context.Background(), &ns.GetDeviceQueueItemsForDevEUIRequest{
+		DevEui: devEUI[:],
+	})
+	if err != nil {
+		return s, errors.Wrap(err, "get device-queue items error")
+	}
+
+	for _, qi := range resp.Items {
+		if qi.FCnt == *s.FCnt {
+			return s, nil
+		}
+	}
+
+	state := storage.DeviceQueueItemUnknown
+
+	// the next downlink frame-counter only reflects the device-session
+	// when the device-queue is empty
+	if len(resp.Items) == 0 {
+		fCntResp, err := nsClient.GetNextDownlinkFCntForDevEUI(context.Background(), &ns.GetNextDownlinkFCntForDevEUIRequest{
+			DevEui: devEUI[:],
+		})
+		if err != nil {
+			return s, errors.Wrap(err, "get next downlink fcnt error")
+		}
+
+		if fCntResp.FCnt > *s.FCnt {
+			state = storage.DeviceQueueItemSent
+		}
+	}
+
+	if state == s.State {
+		return s, nil
+	}
+
+	s.SetState(state, time.Now())
+	if err := storage.UpdateDeviceQueueItemStatus(db, &s); err != nil {
+		return s, errors.Wrap(err, "update device-queue item status error")
+	}
+
+	return s, nil
+}
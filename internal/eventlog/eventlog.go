@@ -24,6 +24,7 @@ const (
 	ACK    = "ack"
 	Join   = "join"
 	Error  = "error"
+	Shadow = "shadow"
 )
 
 // EventLog contains an event log.
@@ -0,0 +1,345 @@
+// Package fuota implements the firmware update over the air (FUOTA)
+// campaigns, using the LoRaWAN Fragmented Data Block Transport
+// application-layer package.
+package fuota
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/fragmentation"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/downlink"
+	"github.com/brocaar/lora-app-server/internal/multicast"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// CampaignsLoop is a never returning function executing the due steps of
+// the FUOTA campaigns.
+func CampaignsLoop() {
+	for {
+		for {
+			handled, err := handleCampaign()
+			if err != nil {
+				log.Errorf("handle fuota campaign error: %s", err)
+				break
+			}
+			if !handled {
+				break
+			}
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// handleCampaign takes the FUOTA campaign of which the next step is due,
+// moves it to the next state and executes the step. Between each step, the
+// campaign waits for the step timeout so that the devices are able to
+// receive the downlinks and send their answers.
+// It returns false when there was no campaign due.
+func handleCampaign() (bool, error) {
+	var c storage.FUOTACampaign
+	var devices []storage.FUOTACampaignDevice
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		var err error
+		c, err = storage.GetDueFUOTACampaignForUpdate(tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		next := now.Add(time.Duration(c.StepTimeout) * time.Second)
+		var completedAt *time.Time
+
+		state := c.State
+		switch c.State {
+		case storage.FUOTACampaignSetup:
+			state = storage.FUOTACampaignFragments
+		case storage.FUOTACampaignFragments:
+			state = storage.FUOTACampaignStatus
+		case storage.FUOTACampaignStatus:
+			state = storage.FUOTACampaignDone
+		default:
+			completedAt = &now
+		}
+
+		if err := storage.SetFUOTACampaignState(tx, c.ID, state, next, completedAt); err != nil {
+			return errors.Wrap(err, "set fuota campaign state error")
+		}
+
+		devices, err = storage.GetFUOTACampaignDevices(tx, c.ID)
+		if err != nil {
+			return errors.Wrap(err, "get fuota campaign devices error")
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return false, nil
+		}
+		return false, err
+	}
+
+	log.WithFields(log.Fields{
+		"id":      c.ID,
+		"state":   c.State,
+		"devices": len(devices),
+	}).Info("handling fuota campaign step")
+
+	switch c.State {
+	case storage.FUOTACampaignSetup:
+		err = fragSessionSetup(c, devices)
+	case storage.FUOTACampaignFragments:
+		err = sendFragments(c, devices)
+	case storage.FUOTACampaignStatus:
+		err = fragSessionStatus(c, devices)
+	}
+	if err != nil {
+		return true, errors.Wrapf(err, "fuota campaign %d step %s error", c.ID, c.State)
+	}
+
+	return true, nil
+}
+
+// fragSessionSetup sends the FragSessionSetupReq to each device of the
+// campaign.
+func fragSessionSetup(c storage.FUOTACampaign, devices []storage.FUOTACampaignDevice) error {
+	fw, err := storage.GetFirmware(config.C.PostgreSQL.DB, c.FirmwareID)
+	if err != nil {
+		return errors.Wrap(err, "get firmware error")
+	}
+
+	_, padding := fragmentation.Pad(fw.Data, int(c.FragSize))
+
+	pl := fragmentation.FragSessionSetupReqPayload{
+		FragIndex:     c.FragIndex,
+		NbFrag:        uint16((len(fw.Data) + padding) / int(c.FragSize)),
+		FragSize:      c.FragSize,
+		BlockAckDelay: c.BlockAckDelay,
+		Padding:       uint8(padding),
+	}
+	copy(pl.Descriptor[:], c.Descriptor)
+
+	if c.MulticastGroupID != nil {
+		mg, err := storage.GetMulticastGroup(config.C.PostgreSQL.DB, *c.MulticastGroupID)
+		if err != nil {
+			return errors.Wrap(err, "get multicast-group error")
+		}
+		pl.McGroupBitMask = 1 << mg.McGroupID
+	}
+
+	b, err := fragmentation.Command{
+		CID:     fragmentation.FragSessionSetupReq,
+		Payload: &pl,
+	}.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	for _, d := range devices {
+		d.SetupCompletedAt = nil
+		d.LastError = ""
+		if err := downlink.EnqueueDownlinkPayload(config.C.PostgreSQL.DB, d.DevEUI, reference(c.ID), false, fragmentation.DefaultFPort, b); err != nil {
+			d.LastError = err.Error()
+		}
+
+		if err := storage.UpdateFUOTACampaignDevice(config.C.PostgreSQL.DB, d); err != nil {
+			return errors.Wrap(err, "update fuota campaign device error")
+		}
+	}
+
+	return nil
+}
+
+// sendFragments sends the (coded) fragments of the firmware, either to the
+// multicast-group of the campaign or to each device which completed the
+// setup.
+func sendFragments(c storage.FUOTACampaign, devices []storage.FUOTACampaignDevice) error {
+	fw, err := storage.GetFirmware(config.C.PostgreSQL.DB, c.FirmwareID)
+	if err != nil {
+		return errors.Wrap(err, "get firmware error")
+	}
+
+	data, _ := fragmentation.Pad(fw.Data, int(c.FragSize))
+	fragments, err := fragmentation.Encode(data, int(c.FragSize), c.Redundancy)
+	if err != nil {
+		return errors.Wrap(err, "encode fragments error")
+	}
+
+	var payloads [][]byte
+	for i, f := range fragments {
+		b, err := fragmentation.Command{
+			CID: fragmentation.DataFragment,
+			Payload: &fragmentation.DataFragmentPayload{
+				FragIndex: c.FragIndex,
+				N:         uint16(i + 1),
+				Payload:   f,
+			},
+		}.MarshalBinary()
+		if err != nil {
+			return errors.Wrap(err, "marshal binary error")
+		}
+		payloads = append(payloads, b)
+	}
+
+	if c.MulticastGroupID != nil {
+		return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+			for _, b := range payloads {
+				if _, err := multicast.Enqueue(tx, *c.MulticastGroupID, fragmentation.DefaultFPort, b); err != nil {
+					return errors.Wrap(err, "enqueue multicast fragment error")
+				}
+			}
+			return nil
+		})
+	}
+
+	for _, d := range devices {
+		if d.SetupCompletedAt == nil {
+			continue
+		}
+
+		for _, b := range payloads {
+			if err := downlink.EnqueueDownlinkPayload(config.C.PostgreSQL.DB, d.DevEUI, reference(c.ID), false, fragmentation.DefaultFPort, b); err != nil {
+				d.LastError = err.Error()
+				if err := storage.UpdateFUOTACampaignDevice(config.C.PostgreSQL.DB, d); err != nil {
+					return errors.Wrap(err, "update fuota campaign device error")
+				}
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// fragSessionStatus sends the FragSessionStatusReq to each device which
+// completed the setup.
+func fragSessionStatus(c storage.FUOTACampaign, devices []storage.FUOTACampaignDevice) error {
+	b, err := fragmentation.Command{
+		CID: fragmentation.FragSessionStatusReq,
+		Payload: &fragmentation.FragSessionStatusReqPayload{
+			FragIndex:    c.FragIndex,
+			Participants: true,
+		},
+	}.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	for _, d := range devices {
+		if d.SetupCompletedAt == nil {
+			continue
+		}
+
+		if err := downlink.EnqueueDownlinkPayload(config.C.PostgreSQL.DB, d.DevEUI, reference(c.ID), false, fragmentation.DefaultFPort, b); err != nil {
+			d.LastError = err.Error()
+			if err := storage.UpdateFUOTACampaignDevice(config.C.PostgreSQL.DB, d); err != nil {
+				return errors.Wrap(err, "update fuota campaign device error")
+			}
+		}
+	}
+
+	return nil
+}
+
+// HandleFragmentationCommand handles the Fragmented Data Block Transport
+// answers, sent by the given device on the fragmentation fPort.
+func HandleFragmentationCommand(db sqlx.Ext, devEUI lorawan.EUI64, b []byte) error {
+	cmds, err := fragmentation.UnmarshalUplinkCommands(b)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal commands error")
+	}
+
+	for _, cmd := range cmds {
+		var err error
+
+		switch pl := cmd.Payload.(type) {
+		case *fragmentation.FragSessionSetupAnsPayload:
+			err = handleFragSessionSetupAns(db, devEUI, pl)
+		case *fragmentation.FragSessionStatusAnsPayload:
+			err = handleFragSessionStatusAns(db, devEUI, pl)
+		default:
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"cid":     cmd.CID,
+			}).Info("fragmentation command ignored")
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "handle command error (cid: %d)", cmd.CID)
+		}
+	}
+
+	return nil
+}
+
+func handleFragSessionSetupAns(db sqlx.Ext, devEUI lorawan.EUI64, pl *fragmentation.FragSessionSetupAnsPayload) error {
+	d, err := storage.GetFUOTACampaignDeviceForFragIndex(db, devEUI, pl.FragIndex)
+	if err != nil {
+		return errors.Wrap(err, "get fuota campaign device error")
+	}
+
+	d.SetupCompletedAt = nil
+	d.LastError = ""
+
+	switch {
+	case pl.WrongDescriptor:
+		d.LastError = "FragSessionSetupAns: wrong descriptor"
+	case pl.FragSessionIndexNotSupported:
+		d.LastError = "FragSessionSetupAns: FragIndex not supported"
+	case pl.NotEnoughMemory:
+		d.LastError = "FragSessionSetupAns: not enough memory"
+	case pl.EncodingUnsupported:
+		d.LastError = "FragSessionSetupAns: encoding unsupported"
+	default:
+		now := time.Now()
+		d.SetupCompletedAt = &now
+	}
+
+	log.WithFields(log.Fields{
+		"fuota_campaign_id": d.FUOTACampaignID,
+		"dev_eui":           devEUI,
+		"error":             d.LastError,
+	}).Info("FragSessionSetupAns received")
+
+	return storage.UpdateFUOTACampaignDevice(db, d)
+}
+
+func handleFragSessionStatusAns(db sqlx.Ext, devEUI lorawan.EUI64, pl *fragmentation.FragSessionStatusAnsPayload) error {
+	d, err := storage.GetFUOTACampaignDeviceForFragIndex(db, devEUI, pl.FragIndex)
+	if err != nil {
+		return errors.Wrap(err, "get fuota campaign device error")
+	}
+
+	now := time.Now()
+	nbFragReceived := int(pl.NbFragReceived)
+	missingFrag := int(pl.MissingFrag)
+
+	d.StatusReceivedAt = &now
+	d.NbFragReceived = &nbFragReceived
+	d.MissingFrag = &missingFrag
+	d.LastError = ""
+	if pl.NotEnoughMatrixMemory {
+		d.LastError = "FragSessionStatusAns: not enough matrix memory"
+	}
+
+	log.WithFields(log.Fields{
+		"fuota_campaign_id": d.FUOTACampaignID,
+		"dev_eui":           devEUI,
+		"nb_frag_received":  nbFragReceived,
+		"missing_frag":      missingFrag,
+	}).Info("FragSessionStatusAns received")
+
+	return storage.UpdateFUOTACampaignDevice(db, d)
+}
+
+func reference(id int64) string {
+	return fmt.Sprintf("fuota-campaign-%d", id)
+}
@@ -0,0 +1,273 @@
+package fuota
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/fragmentation"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestFUOTA(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+
+	Convey("Given a clean database with a device, a firmware and a FUOTA campaign", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		device := storage.Device{
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			Name:            "test-node",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDevice(db, &device), ShouldBeNil)
+
+		da := storage.DeviceActivation{
+			DevEUI:  device.DevEUI,
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			AppSKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		So(storage.CreateDeviceActivation(db, &da), ShouldBeNil)
+
+		fw := storage.Firmware{
+			OrganizationID: org.ID,
+			Name:           "firmware-v1",
+			Data:           []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		}
+		So(storage.CreateFirmware(db, &fw), ShouldBeNil)
+
+		c := storage.FUOTACampaign{
+			ApplicationID: app.ID,
+			FirmwareID:    fw.ID,
+			Name:          "test-campaign",
+			FragIndex:     1,
+			FragSize:      4,
+			Redundancy:    2,
+			BlockAckDelay: 3,
+			Descriptor:    []byte{1, 2, 3, 4},
+			StepTimeout:   60,
+			DevEUIs:       []lorawan.EUI64{device.DevEUI},
+		}
+		So(storage.CreateFUOTACampaign(db, &c), ShouldBeNil)
+
+		Convey("Given a FUOTA campaign using a multicast-group", func() {
+			now := time.Now()
+			So(storage.SetFUOTACampaignState(db, c.ID, storage.FUOTACampaignDone, now, &now), ShouldBeNil)
+
+			mg := storage.MulticastGroup{
+				ApplicationID: app.ID,
+				Name:          "test-group",
+				McAddr:        lorawan.DevAddr{4, 3, 2, 1},
+				McAppSKey:     lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+				McGroupID:     2,
+				GroupType:     storage.MulticastGroupClassC,
+				Frequency:     869525000,
+			}
+			So(storage.CreateMulticastGroup(db, &mg), ShouldBeNil)
+			So(storage.AddDeviceToMulticastGroup(db, mg.ID, device.DevEUI), ShouldBeNil)
+
+			mc := storage.FUOTACampaign{
+				ApplicationID:    app.ID,
+				FirmwareID:       fw.ID,
+				MulticastGroupID: &mg.ID,
+				Name:             "test-multicast-campaign",
+				FragIndex:        1,
+				FragSize:         4,
+				Redundancy:       2,
+				Descriptor:       []byte{1, 2, 3, 4},
+				StepTimeout:      60,
+			}
+			So(storage.CreateFUOTACampaign(db, &mc), ShouldBeNil)
+
+			Convey("When handling the fragments step", func() {
+				So(storage.SetFUOTACampaignState(db, mc.ID, storage.FUOTACampaignFragments, time.Now(), nil), ShouldBeNil)
+				handled, err := handleCampaign()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeTrue)
+
+				Convey("Then the fragments have been enqueued once for the multicast-group", func() {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+					So(nsClient.EnqueueMulticastQueueItemChan, ShouldHaveLength, 5)
+
+					for i := 0; i < 5; i++ {
+						req := <-nsClient.EnqueueMulticastQueueItemChan
+						So(req.MulticastQueueItem.FCnt, ShouldEqual, i)
+						So(req.MulticastQueueItem.FPort, ShouldEqual, fragmentation.DefaultFPort)
+					}
+				})
+			})
+		})
+
+		Convey("When handling the setup step", func() {
+			handled, err := handleCampaign()
+			So(err, ShouldBeNil)
+			So(handled, ShouldBeTrue)
+
+			Convey("Then the FragSessionSetupReq has been enqueued", func() {
+				b, err := fragmentation.Command{
+					CID: fragmentation.FragSessionSetupReq,
+					Payload: &fragmentation.FragSessionSetupReqPayload{
+						FragIndex:     1,
+						NbFrag:        3,
+						FragSize:      4,
+						BlockAckDelay: 3,
+						Padding:       2,
+						Descriptor:    [4]byte{1, 2, 3, 4},
+					},
+				}.MarshalBinary()
+				So(err, ShouldBeNil)
+				b, err = lorawan.EncryptFRMPayload(da.AppSKey, false, da.DevAddr, 12, b)
+				So(err, ShouldBeNil)
+
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+				So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     device.DevEUI[:],
+						FrmPayload: b,
+						FCnt:       12,
+						FPort:      uint32(fragmentation.DefaultFPort),
+					},
+				})
+			})
+
+			Convey("Then the next step is the fragments step after the step timeout", func() {
+				c2, err := storage.GetFUOTACampaign(db, c.ID)
+				So(err, ShouldBeNil)
+				So(c2.State, ShouldEqual, storage.FUOTACampaignFragments)
+				So(c2.NextStepAt.After(time.Now().Add(50*time.Second)), ShouldBeTrue)
+
+				handled, err := handleCampaign()
+				So(err, ShouldBeNil)
+				So(handled, ShouldBeFalse)
+			})
+
+			Convey("When the device answers with a FragSessionSetupAns with an error", func() {
+				So(HandleFragmentationCommand(db, device.DevEUI, []byte{0x02, 0x44}), ShouldBeNil)
+
+				Convey("Then the error is stored", func() {
+					devices, err := storage.GetFUOTACampaignDevices(db, c.ID)
+					So(err, ShouldBeNil)
+					So(devices[0].SetupCompletedAt, ShouldBeNil)
+					So(devices[0].LastError, ShouldNotEqual, "")
+				})
+			})
+
+			Convey("When the device answers with a FragSessionSetupAns", func() {
+				So(HandleFragmentationCommand(db, device.DevEUI, []byte{0x02, 0x40}), ShouldBeNil)
+				<-nsClient.CreateDeviceQueueItemChan
+
+				Convey("Then the setup is completed", func() {
+					devices, err := storage.GetFUOTACampaignDevices(db, c.ID)
+					So(err, ShouldBeNil)
+					So(devices[0].SetupCompletedAt, ShouldNotBeNil)
+					So(devices[0].LastError, ShouldEqual, "")
+				})
+
+				Convey("When handling the fragments step", func() {
+					So(storage.SetFUOTACampaignState(db, c.ID, storage.FUOTACampaignFragments, time.Now(), nil), ShouldBeNil)
+					handled, err := handleCampaign()
+					So(err, ShouldBeNil)
+					So(handled, ShouldBeTrue)
+
+					Convey("Then the uncoded and redundancy fragments have been enqueued", func() {
+						So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 5)
+					})
+
+					Convey("Then the next step is the status step", func() {
+						c2, err := storage.GetFUOTACampaign(db, c.ID)
+						So(err, ShouldBeNil)
+						So(c2.State, ShouldEqual, storage.FUOTACampaignStatus)
+					})
+				})
+
+				Convey("When handling the status step", func() {
+					So(storage.SetFUOTACampaignState(db, c.ID, storage.FUOTACampaignStatus, time.Now(), nil), ShouldBeNil)
+					handled, err := handleCampaign()
+					So(err, ShouldBeNil)
+					So(handled, ShouldBeTrue)
+
+					Convey("Then the FragSessionStatusReq has been enqueued", func() {
+						So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+					})
+
+					Convey("When the device answers with a FragSessionStatusAns", func() {
+						So(HandleFragmentationCommand(db, device.DevEUI, []byte{0x01, 0x05, 0x40, 0x00, 0x00}), ShouldBeNil)
+
+						Convey("Then the status is stored", func() {
+							devices, err := storage.GetFUOTACampaignDevices(db, c.ID)
+							So(err, ShouldBeNil)
+							So(devices[0].StatusReceivedAt, ShouldNotBeNil)
+							So(*devices[0].NbFragReceived, ShouldEqual, 5)
+							So(*devices[0].MissingFrag, ShouldEqual, 0)
+						})
+					})
+
+					Convey("When handling the done step", func() {
+						So(storage.SetFUOTACampaignState(db, c.ID, storage.FUOTACampaignDone, time.Now(), nil), ShouldBeNil)
+						handled, err := handleCampaign()
+						So(err, ShouldBeNil)
+						So(handled, ShouldBeTrue)
+
+						Convey("Then the campaign is completed", func() {
+							c2, err := storage.GetFUOTACampaign(db, c.ID)
+							So(err, ShouldBeNil)
+							So(c2.CompletedAt, ShouldNotBeNil)
+						})
+					})
+				})
+			})
+		})
+	})
+}
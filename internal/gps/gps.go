@@ -0,0 +1,59 @@
+// Package gps implements the conversion between time.Time and the GPS time
+// as used by the LoRaWAN application-layer packages.
+package gps
+
+import (
+	"time"
+)
+
+var gpsEpochTime = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// leapSecondsTable contains the UTC times at which a leap second was
+// introduced since the GPS epoch.
+var leapSecondsTable = []time.Time{
+	time.Date(1981, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1982, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1983, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1985, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1987, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(1989, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(1990, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(1992, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1993, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1994, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1995, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(1997, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(1998, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(2005, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(2008, time.December, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(2012, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(2015, time.June, 30, 23, 59, 59, 0, time.UTC),
+	time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC),
+}
+
+// TimeSinceGPSEpoch returns the time duration since the GPS epoch
+// (1980-01-06 00:00:00 UTC) for the given time, including the leap
+// seconds.
+func TimeSinceGPSEpoch(t time.Time) time.Duration {
+	var offset time.Duration
+	for _, ls := range leapSecondsTable {
+		if ls.Before(t) {
+			offset += time.Second
+		}
+	}
+
+	return t.Sub(gpsEpochTime) + offset
+}
+
+// NewFromTimeSinceGPSEpoch returns the time.Time for the given duration
+// since the GPS epoch.
+func NewFromTimeSinceGPSEpoch(d time.Duration) time.Time {
+	t := gpsEpochTime.Add(d)
+	for _, ls := range leapSecondsTable {
+		if ls.Before(t) {
+			t = t.Add(-time.Second)
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,38 @@
+package gps
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGPS(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Time              time.Time
+			TimeSinceGPSEpoch time.Duration
+		}{
+			{
+				Time:              gpsEpochTime,
+				TimeSinceGPSEpoch: 0,
+			},
+			{
+				Time:              time.Date(2010, time.January, 28, 16, 36, 24, 0, time.UTC),
+				TimeSinceGPSEpoch: 948731799 * time.Second,
+			},
+			{
+				Time:              time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC),
+				TimeSinceGPSEpoch: 1198800018 * time.Second,
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Time, i), func() {
+				So(TimeSinceGPSEpoch(test.Time), ShouldEqual, test.TimeSinceGPSEpoch)
+				So(NewFromTimeSinceGPSEpoch(test.TimeSinceGPSEpoch).Equal(test.Time), ShouldBeTrue)
+			})
+		}
+	})
+}
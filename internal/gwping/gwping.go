@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/garyburd/redigo/redis"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -57,33 +58,38 @@ func HandleReceivedPing(req *as.HandleProprietaryUplinkRequest) error {
 		for _, rx := range req.RxInfo {
 			var receivedAt *time.Time
 			var mac lorawan.EUI64
-			copy(mac[:], rx.Mac)
+			copy(mac[:], rx.GatewayId)
 
 			// ignore pings received by the sending gateway
 			if ping.GatewayMAC == mac {
 				continue
 			}
 
-			if rx.Time != "" {
-				t, err := time.Parse(time.RFC3339Nano, rx.Time)
+			if rx.Time != nil {
+				t, err := ptypes.Timestamp(rx.Time)
 				if err != nil {
-					return errors.Wrap(err, "parse time error")
+					return errors.Wrap(err, "get timestamp error")
 				}
 				receivedAt = &t
 			}
 
-			err := storage.CreateGatewayPingRX(tx, &storage.GatewayPingRX{
+			gwPingRX := storage.GatewayPingRX{
 				PingID:     id,
 				GatewayMAC: mac,
 				ReceivedAt: receivedAt,
 				RSSI:       int(rx.Rssi),
-				LoRaSNR:    rx.LoRaSNR,
-				Location: storage.GPSPoint{
-					Latitude:  rx.Latitude,
-					Longitude: rx.Longitude,
-				},
-				Altitude: rx.Altitude,
-			})
+				LoRaSNR:    rx.LoraSnr,
+			}
+
+			if rx.Location != nil {
+				gwPingRX.Location = storage.GPSPoint{
+					Latitude:  rx.Location.Latitude,
+					Longitude: rx.Location.Longitude,
+				}
+				gwPingRX.Altitude = rx.Location.Altitude
+			}
+
+			err := storage.CreateGatewayPingRX(tx, &gwPingRX)
 			if err != nil {
 				return errors.Wrap(err, "create gateway ping rx error")
 			}
@@ -187,11 +193,11 @@ func sendPing(mic lorawan.MIC, n storage.NetworkServer, ping storage.GatewayPing
 	}
 
 	_, err = nsClient.SendProprietaryPayload(context.Background(), &ns.SendProprietaryPayloadRequest{
-		Mic:         mic[:],
-		GatewayMACs: [][]byte{ping.GatewayMAC[:]},
-		IPol:        false,
-		Frequency:   uint32(ping.Frequency),
-		Dr:          uint32(ping.DR),
+		Mic:                   mic[:],
+		GatewayMacs:           [][]byte{ping.GatewayMAC[:]},
+		PolarizationInversion: false,
+		Frequency:             uint32(ping.Frequency),
+		Dr:                    uint32(ping.DR),
 	})
 	if err != nil {
 		return errors.Wrap(err, "send proprietary payload error")
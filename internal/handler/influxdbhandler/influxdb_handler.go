@@ -199,7 +199,7 @@ func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
 	})
 
 	// parse object to measurements
-	measurements = append(measurements, objectToMeasurements(pl, "device_frmpayload_data", pl.Object)...)
+	measurements = append(measurements, objectToMeasurements(pl, "device_frmpayload_data", "", pl.Object)...)
 
 	if len(measurements) == 0 {
 		return nil
@@ -231,12 +231,15 @@ func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
 	return nil
 }
 
-func objectToMeasurements(pl handler.DataUpPayload, prefix string, obj interface{}) []measurement {
+// objectToMeasurements converts the given object into measurements. The path
+// is the path of the object within pl.Object and is used to lookup the
+// field metadata (when provided by the codec).
+func objectToMeasurements(pl handler.DataUpPayload, prefix, path string, obj interface{}) []measurement {
 	var out []measurement
 
 	switch o := obj.(type) {
 	case int, uint, float32, float64, uint8, int8, uint16, int16, uint32, int32, uint64, int64, string, bool:
-		out = append(out, measurement{
+		m := measurement{
 			Name: prefix,
 			Tags: map[string]string{
 				"application_name": pl.ApplicationName,
@@ -247,7 +250,23 @@ func objectToMeasurements(pl handler.DataUpPayload, prefix string, obj interface
 			Values: map[string]interface{}{
 				"value": o,
 			},
-		})
+		}
+
+		// use the field metadata for typed values instead of the type
+		// resulting from the payload decoding
+		if meta, ok := pl.ObjectMetadata[path]; ok {
+			if v, err := meta.Normalize(o); err == nil {
+				m.Values["value"] = v
+			}
+			if meta.Unit != "" {
+				m.Tags["unit"] = meta.Unit
+			}
+			if meta.SemanticType != "" {
+				m.Tags["semantic_type"] = meta.SemanticType
+			}
+		}
+
+		out = append(out, m)
 
 	default:
 		switch reflect.TypeOf(o).Kind() {
@@ -266,7 +285,7 @@ func objectToMeasurements(pl handler.DataUpPayload, prefix string, obj interface
 					continue
 				}
 
-				out = append(out, objectToMeasurements(pl, prefix+"_"+keyName, v.MapIndex(k).Interface())...)
+				out = append(out, objectToMeasurements(pl, prefix+"_"+keyName, fieldPath(path, keyName), v.MapIndex(k).Interface())...)
 			}
 
 		case reflect.Struct:
@@ -281,6 +300,9 @@ func objectToMeasurements(pl handler.DataUpPayload, prefix string, obj interface
 				}
 
 				fieldName := v.Type().Field(i).Tag.Get("influxdb")
+				if fieldName == "-" {
+					continue
+				}
 				if fieldName == "" {
 					fieldName = strings.ToLower(v.Type().Field(i).Name)
 				}
@@ -292,12 +314,12 @@ func objectToMeasurements(pl handler.DataUpPayload, prefix string, obj interface
 					continue
 				}
 
-				out = append(out, objectToMeasurements(pl, prefix+"_"+fieldName, v.Field(i).Interface())...)
+				out = append(out, objectToMeasurements(pl, prefix+"_"+fieldName, fieldPath(path, jsonFieldName(v.Type().Field(i))), v.Field(i).Interface())...)
 			}
 
 		case reflect.Ptr:
 			v := reflect.Indirect(reflect.ValueOf(o))
-			out = append(out, objectToMeasurements(pl, prefix, v.Interface())...)
+			out = append(out, objectToMeasurements(pl, prefix, path, v.Interface())...)
 
 		default:
 			log.WithField("type_name", fmt.Sprintf("%T", o)).Warning("influxdb handler: unhandled type!")
@@ -308,6 +330,23 @@ func objectToMeasurements(pl handler.DataUpPayload, prefix string, obj interface
 	return out
 }
 
+// fieldPath returns the path of the given field within the parent path.
+func fieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// jsonFieldName returns the JSON name of the given struct field, as the
+// field metadata paths refer to the JSON representation of the object.
+func jsonFieldName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
 func mapToLocation(pl handler.DataUpPayload, prefix string, obj reflect.Value) []measurement {
 	var latFloat, longFloat float64
 
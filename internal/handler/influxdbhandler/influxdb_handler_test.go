@@ -206,6 +206,35 @@ device_uplink,application_name=test-app,bandwidth=125,bitrate=0,dev_eui=01020304
 					},
 					ExpectedBody: `device_frmpayload_data_gps_location_10_altitude,application_name=test-app,dev_eui=0102030405060708,device_name=test-dev,f_port=20 value=3.123000
 device_frmpayload_data_gps_location_10_location,application_name=test-app,dev_eui=0102030405060708,device_name=test-dev,f_port=20 geohash="s01w2k3vvqre",latitude=1.123000,longitude=2.123000
+device_uplink,application_name=test-app,bandwidth=125,bitrate=0,dev_eui=0102030405060708,device_name=test-dev,frequency=868100000,modulation=LORA,spreading_factor=10 value=1i`,
+				},
+				{
+					Name: "With object metadata",
+					Payload: handler.DataUpPayload{
+						ApplicationName: "test-app",
+						DeviceName:      "test-dev",
+						DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						FCnt:            10,
+						FPort:           20,
+						TXInfo: handler.TXInfo{
+							Frequency: 868100000,
+							DataRate: handler.DataRate{
+								Modulation:   "LORA",
+								SpreadFactor: 10,
+								Bandwidth:    125,
+							},
+						},
+						Object: map[string]interface{}{
+							"temperature": 21,
+							"counter":     3.0,
+						},
+						ObjectMetadata: codec.Fields{
+							"temperature": {Unit: "Cel", DataType: codec.NumberDataType, SemanticType: "temperature"},
+							"counter":     {DataType: codec.IntegerDataType},
+						},
+					},
+					ExpectedBody: `device_frmpayload_data_counter,application_name=test-app,dev_eui=0102030405060708,device_name=test-dev,f_port=20 value=3i
+device_frmpayload_data_temperature,application_name=test-app,dev_eui=0102030405060708,device_name=test-dev,f_port=20,semantic_type=temperature,unit=Cel value=21.000000
 device_uplink,application_name=test-app,bandwidth=125,bitrate=0,dev_eui=0102030405060708,device_name=test-dev,frequency=868100000,modulation=LORA,spreading_factor=10 value=1i`,
 				},
 			}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/brocaar/lora-app-server/internal/codec"
 	"github.com/brocaar/lorawan"
 )
 
@@ -57,17 +58,38 @@ type DataUpPayload struct {
 	FPort               uint8         `json:"fPort"`
 	Data                []byte        `json:"data"`
 	Object              interface{}   `json:"object,omitempty"`
+	ObjectMetadata      codec.Fields  `json:"objectMetadata,omitempty"`
 }
 
-// DataDownPayload represents a data-down payload.
+// DataDownPayload represents a data-down payload. When Batch is set, the
+// payload must be enqueued for multiple devices of the application, selected
+// by DevEUIs, else by DeviceProfileID and / or Tags, else all the devices.
+// A payload with an IdempotencyKey which was already used for the device is
+// ignored.
 type DataDownPayload struct {
-	ApplicationID int64           `json:"applicationID,string"`
-	DevEUI        lorawan.EUI64   `json:"devEUI"`
-	Reference     string          `json:"reference"`
-	Confirmed     bool            `json:"confirmed"`
-	FPort         uint8           `json:"fPort"`
-	Data          []byte          `json:"data"`
-	Object        json.RawMessage `json:"object"`
+	ApplicationID   int64                `json:"applicationID,string"`
+	DevEUI          lorawan.EUI64        `json:"devEUI"`
+	Reference       string               `json:"reference"`
+	Confirmed       bool                 `json:"confirmed"`
+	FPort           uint8                `json:"fPort"`
+	Data            []byte               `json:"data"`
+	Object          json.RawMessage      `json:"object"`
+	ScheduledAt     *time.Time           `json:"scheduledAt,omitempty"`
+	ExpiresAt       *time.Time           `json:"expiresAt,omitempty"`
+	RetryPolicy     *DownlinkRetryPolicy `json:"retryPolicy,omitempty"`
+	Batch           bool                 `json:"-"`
+	DevEUIs         []lorawan.EUI64      `json:"devEUIs,omitempty"`
+	DeviceProfileID string               `json:"deviceProfileID,omitempty"`
+	Tags            map[string]string    `json:"tags,omitempty"`
+	IdempotencyKey  string               `json:"idempotencyKey,omitempty"`
+}
+
+// DownlinkRetryPolicy defines the retry policy of a confirmed downlink which
+// is not acknowledged by the device. The backoff is in seconds.
+type DownlinkRetryPolicy struct {
+	MaxAttempts int  `json:"maxAttempts"`
+	Backoff     int  `json:"backoff"`
+	Reencode    bool `json:"reencode"`
 }
 
 // JoinNotification defines the payload sent to the application on
@@ -78,10 +100,12 @@ type JoinNotification struct {
 	DeviceName      string          `json:"deviceName"`
 	DevEUI          lorawan.EUI64   `json:"devEUI"`
 	DevAddr         lorawan.DevAddr `json:"devAddr"`
+	RXInfo          []RXInfo        `json:"rxInfo,omitempty"`
 }
 
 // ACKNotification defines the payload sent to the application
-// on an ACK event.
+// on an ACK event. Status contains the delivery state of the downlink
+// (ACKNOWLEDGED or NACKED).
 type ACKNotification struct {
 	ApplicationID   int64         `json:"applicationID,string"`
 	ApplicationName string        `json:"applicationName"`
@@ -90,6 +114,7 @@ type ACKNotification struct {
 	Reference       string        `json:"reference"`
 	Acknowledged    bool          `json:"acknowledged"`
 	FCnt            uint32        `json:"fCnt"`
+	Status          string        `json:"status,omitempty"`
 }
 
 // ErrorNotification defines the payload sent to the application
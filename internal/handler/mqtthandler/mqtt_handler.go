@@ -27,37 +27,40 @@ const downlinkLockTTL = time.Millisecond * 100
 
 // Config holds the configuration for the MQTT handler.
 type Config struct {
-	Server                string
-	Username              string
-	Password              string
-	QOS                   uint8  `mapstructure:"qos"`
-	CleanSession          bool   `mapstructure:"clean_session"`
-	ClientID              string `mapstructure:"client_id"`
-	CACert                string `mapstructure:"ca_cert"`
-	TLSCert               string `mapstructure:"tls_cert"`
-	TLSKey                string `mapstructure:"tls_key"`
-	UplinkTopicTemplate   string `mapstructure:"uplink_topic_template"`
-	DownlinkTopicTemplate string `mapstructure:"downlink_topic_template"`
-	JoinTopicTemplate     string `mapstructure:"join_topic_template"`
-	AckTopicTemplate      string `mapstructure:"ack_topic_template"`
-	ErrorTopicTemplate    string `mapstructure:"error_topic_template"`
+	Server                     string
+	Username                   string
+	Password                   string
+	QOS                        uint8  `mapstructure:"qos"`
+	CleanSession               bool   `mapstructure:"clean_session"`
+	ClientID                   string `mapstructure:"client_id"`
+	CACert                     string `mapstructure:"ca_cert"`
+	TLSCert                    string `mapstructure:"tls_cert"`
+	TLSKey                     string `mapstructure:"tls_key"`
+	UplinkTopicTemplate        string `mapstructure:"uplink_topic_template"`
+	DownlinkTopicTemplate      string `mapstructure:"downlink_topic_template"`
+	BatchDownlinkTopicTemplate string `mapstructure:"batch_downlink_topic_template"`
+	JoinTopicTemplate          string `mapstructure:"join_topic_template"`
+	AckTopicTemplate           string `mapstructure:"ack_topic_template"`
+	ErrorTopicTemplate         string `mapstructure:"error_topic_template"`
 }
 
 // MQTTHandler implements a MQTT handler for sending and receiving data by
 // an application.
 type MQTTHandler struct {
-	conn             mqtt.Client
-	dataDownChan     chan handler.DataDownPayload
-	wg               sync.WaitGroup
-	redisPool        *redis.Pool
-	config           Config
-	uplinkTemplate   *template.Template
-	downlinkTemplate *template.Template
-	joinTemplate     *template.Template
-	ackTemplate      *template.Template
-	errorTemplate    *template.Template
-	downlinkTopic    string
-	downlinkRegexp   *regexp.Regexp
+	conn                mqtt.Client
+	dataDownChan        chan handler.DataDownPayload
+	wg                  sync.WaitGroup
+	redisPool           *redis.Pool
+	config              Config
+	uplinkTemplate      *template.Template
+	downlinkTemplate    *template.Template
+	joinTemplate        *template.Template
+	ackTemplate         *template.Template
+	errorTemplate       *template.Template
+	downlinkTopic       string
+	downlinkRegexp      *regexp.Regexp
+	batchDownlinkTopic  string
+	batchDownlinkRegexp *regexp.Regexp
 }
 
 // NewHandler creates a new MQTT handler.
@@ -115,6 +118,29 @@ func NewHandler(p *redis.Pool, c Config) (handler.Handler, error) {
 		return nil, errors.Wrap(err, "compile regexp error")
 	}
 
+	// generate batch downlink topic (and regexp) matching all applications
+	if h.config.BatchDownlinkTopicTemplate != "" {
+		batchTemplate, err := template.New("batch_downlink").Parse(h.config.BatchDownlinkTopicTemplate)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse batch downlink template error")
+		}
+
+		topic.Reset()
+		if err = batchTemplate.Execute(topic, struct{ ApplicationID string }{"+"}); err != nil {
+			return nil, errors.Wrap(err, "execute template error")
+		}
+		h.batchDownlinkTopic = topic.String()
+
+		topic.Reset()
+		if err = batchTemplate.Execute(topic, struct{ ApplicationID string }{`(?P<application_id>\w+)`}); err != nil {
+			return nil, errors.Wrap(err, "execute template error")
+		}
+		h.batchDownlinkRegexp, err = regexp.Compile("^" + topic.String() + "$")
+		if err != nil {
+			return nil, errors.Wrap(err, "compile regexp error")
+		}
+	}
+
 	opts := mqtt.NewClientOptions()
 	opts.AddBroker(h.config.Server)
 	opts.SetUsername(h.config.Username)
@@ -195,6 +221,12 @@ func (h *MQTTHandler) Close() error {
 	if token := h.conn.Unsubscribe(h.downlinkTopic); token.Wait() && token.Error() != nil {
 		return fmt.Errorf("handler/mqtt: unsubscribe from %s error: %s", h.downlinkTopic, token.Error())
 	}
+	if h.batchDownlinkTopic != "" {
+		log.WithField("topic", h.batchDownlinkTopic).Info("handler/mqtt: unsubscribing from batch tx topic")
+		if token := h.conn.Unsubscribe(h.batchDownlinkTopic); token.Wait() && token.Error() != nil {
+			return fmt.Errorf("handler/mqtt: unsubscribe from %s error: %s", h.batchDownlinkTopic, token.Error())
+		}
+	}
 	log.Info("handler/mqtt: handling last items in queue")
 	h.wg.Wait()
 	close(h.dataDownChan)
@@ -287,6 +319,25 @@ func (h *MQTTHandler) getTXTopicVariables(topic string) (int64, lorawan.EUI64, e
 	return applicationID, devEUI, nil
 }
 
+func (h *MQTTHandler) getBatchTXTopicApplicationID(topic string) (int64, error) {
+	match := h.batchDownlinkRegexp.FindStringSubmatch(topic)
+	if len(match) != len(h.batchDownlinkRegexp.SubexpNames()) {
+		return 0, errors.New("topic regex match error")
+	}
+
+	for i, name := range h.batchDownlinkRegexp.SubexpNames() {
+		if name == "application_id" {
+			applicationID, err := strconv.ParseInt(match[i], 10, 64)
+			if err != nil {
+				return 0, errors.Wrap(err, "parse application id error")
+			}
+			return applicationID, nil
+		}
+	}
+
+	return 0, errors.New("topic regexp does not contain application id")
+}
+
 func (h *MQTTHandler) txPayloadHandler(c mqtt.Client, msg mqtt.Message) {
 	h.wg.Add(1)
 	defer h.wg.Done()
@@ -340,6 +391,55 @@ func (h *MQTTHandler) txPayloadHandler(c mqtt.Client, msg mqtt.Message) {
 	h.dataDownChan <- pl
 }
 
+func (h *MQTTHandler) txBatchPayloadHandler(c mqtt.Client, msg mqtt.Message) {
+	h.wg.Add(1)
+	defer h.wg.Done()
+
+	log.WithField("topic", msg.Topic()).Info("handler/mqtt: batch data-down payload received")
+	topicApplicationID, err := h.getBatchTXTopicApplicationID(msg.Topic())
+	if err != nil {
+		log.WithError(err).Warning("handler/mqtt: get variables from topic error")
+		return
+	}
+
+	var pl handler.DataDownPayload
+	dec := json.NewDecoder(bytes.NewReader(msg.Payload()))
+	if err := dec.Decode(&pl); err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).Errorf("handler/mqtt: batch tx payload unmarshal error: %s", err)
+		return
+	}
+
+	pl.ApplicationID = topicApplicationID
+	pl.DevEUI = lorawan.EUI64{}
+	pl.Batch = true
+
+	if pl.FPort == 0 || pl.FPort > 224 {
+		log.WithFields(log.Fields{
+			"topic":  msg.Topic(),
+			"f_port": pl.FPort,
+		}).Error("handler/mqtt: fPort must be between 1 - 224")
+		return
+	}
+
+	// see txPayloadHandler, only one instance must handle the payload
+	key := fmt.Sprintf("lora:as:downlink:batch:lock:%d:%s", pl.ApplicationID, pl.Reference)
+	redisConn := h.redisPool.Get()
+	defer redisConn.Close()
+
+	_, err = redis.String(redisConn.Do("SET", key, "lock", "PX", int64(downlinkLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return
+		}
+		log.Errorf("handler/mqtt: acquire batch downlink payload lock error: %s", err)
+		return
+	}
+
+	h.dataDownChan <- pl
+}
+
 func (h *MQTTHandler) onConnected(c mqtt.Client) {
 	log.Info("handler/mqtt: connected to mqtt broker")
 	for {
@@ -352,6 +452,23 @@ func (h *MQTTHandler) onConnected(c mqtt.Client) {
 			time.Sleep(time.Second)
 			continue
 		}
+		break
+	}
+
+	if h.batchDownlinkTopic == "" {
+		return
+	}
+
+	for {
+		log.WithFields(log.Fields{
+			"topic": h.batchDownlinkTopic,
+			"qos":   h.config.QOS,
+		}).Info("handler/mqtt: subscribing to batch tx topic")
+		if token := h.conn.Subscribe(h.batchDownlinkTopic, h.config.QOS, h.txBatchPayloadHandler); token.Wait() && token.Error() != nil {
+			log.WithField("topic", h.batchDownlinkTopic).Errorf("handler/mqtt: subscribe error: %s", token.Error())
+			time.Sleep(time.Second)
+			continue
+		}
 		return
 	}
 }
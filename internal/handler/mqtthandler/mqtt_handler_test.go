@@ -29,15 +29,16 @@ func TestMQTTHandler(t *testing.T) {
 			h, err := NewHandler(
 				p,
 				Config{
-					Server:                conf.MQTTServer,
-					Username:              conf.MQTTUsername,
-					Password:              conf.MQTTPassword,
-					CleanSession:          true,
-					UplinkTopicTemplate:   "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/rx",
-					DownlinkTopicTemplate: "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/tx",
-					JoinTopicTemplate:     "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/join",
-					AckTopicTemplate:      "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/ack",
-					ErrorTopicTemplate:    "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/error",
+					Server:                     conf.MQTTServer,
+					Username:                   conf.MQTTUsername,
+					Password:                   conf.MQTTPassword,
+					CleanSession:               true,
+					UplinkTopicTemplate:        "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/rx",
+					DownlinkTopicTemplate:      "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/tx",
+					BatchDownlinkTopicTemplate: "application/{{ .ApplicationID }}/tx",
+					JoinTopicTemplate:          "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/join",
+					AckTopicTemplate:           "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/ack",
+					ErrorTopicTemplate:         "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/error",
 				},
 			)
 			So(err, ShouldBeNil)
@@ -201,6 +202,29 @@ func TestMQTTHandler(t *testing.T) {
 
 					So(h.DataDownChan(), ShouldHaveLength, 0)
 				})
+
+				Convey("When published to the batch topic", func() {
+					pl.Reference = "batch-1234"
+					pl.DevEUIs = []lorawan.EUI64{{1, 2, 3, 4, 5, 6, 7, 8}, {8, 7, 6, 5, 4, 3, 2, 1}}
+					b, err := json.Marshal(pl)
+					So(err, ShouldBeNil)
+					token := c.Publish("application/123/tx", 0, false, b)
+					token.Wait()
+					So(token.Error(), ShouldBeNil)
+
+					Convey("Then the batch payload is received by the handler", func() {
+						So(<-h.DataDownChan(), ShouldResemble, handler.DataDownPayload{
+							ApplicationID: 123,
+							Reference:     "batch-1234",
+							Confirmed:     false,
+							FPort:         1,
+							Data:          []byte("hello"),
+							Object:        json.RawMessage("null"),
+							Batch:         true,
+							DevEUIs:       []lorawan.EUI64{{1, 2, 3, 4, 5, 6, 7, 8}, {8, 7, 6, 5, 4, 3, 2, 1}},
+						})
+					})
+				})
 			})
 		})
 	})
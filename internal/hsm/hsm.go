@@ -0,0 +1,24 @@
+// Package hsm implements the cryptographic operations using keys stored in
+// a hardware security module (HSM). The keys are referenced by their label
+// and never leave the HSM.
+package hsm
+
+import "github.com/pkg/errors"
+
+// ErrKeyNotFound is returned when no key exists for the given label.
+var ErrKeyNotFound = errors.New("hsm: key not found")
+
+// Backend defines the operations which are performed by the HSM.
+type Backend interface {
+	// Encrypt encrypts the given data (multiple of 16 bytes) using AES-ECB
+	// and the key with the given label.
+	Encrypt(label string, data []byte) ([]byte, error)
+
+	// Decrypt decrypts the given data (multiple of 16 bytes) using AES-ECB
+	// and the key with the given label.
+	Decrypt(label string, data []byte) ([]byte, error)
+
+	// CMAC returns the AES-CMAC of the given data using the key with the
+	// given label.
+	CMAC(label string, data []byte) ([]byte, error)
+}
@@ -0,0 +1,316 @@
+//go:build cgo
+// +build cgo
+
+package hsm
+
+import (
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+)
+
+// PKCS11 implements the Backend interface using a PKCS#11 token (e.g. a
+// network HSM or SoftHSM). The keys must be stored as AES secret-key objects
+// (CKK_AES), identified by their CKA_LABEL.
+//
+// The operations are executed using a pool of sessions, so that concurrent
+// operations do not have to wait for each other. A session which has become
+// invalid (e.g. after a restart of the HSM) is re-opened. The key handles
+// are cached by label.
+type PKCS11 struct {
+	ctx        *pkcs11.Ctx
+	tokenLabel string
+	pin        string
+
+	// sessions contains the pool of sessions, a zero handle is a session
+	// which has not (yet) been opened
+	sessions chan pkcs11.SessionHandle
+
+	keysMu sync.RWMutex
+	keys   map[string]pkcs11.ObjectHandle
+}
+
+// NewPKCS11 loads the given PKCS#11 module and opens a (logged in) session
+// on the token with the given label. The other sessions of the pool, of the
+// given size, are opened on first use.
+func NewPKCS11(module, tokenLabel, pin string, poolSize int) (*PKCS11, error) {
+	if poolSize < 1 {
+		return nil, errors.New("hsm: session pool size must be at least 1")
+	}
+
+	ctx := pkcs11.New(module)
+	if ctx == nil {
+		return nil, errors.Errorf("hsm: load pkcs#11 module %s error", module)
+	}
+
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, errors.Wrap(err, "hsm: initialize error")
+	}
+
+	p := PKCS11{
+		ctx:        ctx,
+		tokenLabel: tokenLabel,
+		pin:        pin,
+		sessions:   make(chan pkcs11.SessionHandle, poolSize),
+		keys:       make(map[string]pkcs11.ObjectHandle),
+	}
+
+	// the first session is opened directly to validate the configuration
+	session, err := p.openSession()
+	if err != nil {
+		ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+
+	p.sessions <- session
+	for i := 1; i < poolSize; i++ {
+		p.sessions <- 0
+	}
+
+	return &p, nil
+}
+
+// openSession opens a new session on the token and logs in. As the login
+// state is shared by all sessions of the token, the token might already be
+// logged in.
+func (p *PKCS11) openSession() (pkcs11.SessionHandle, error) {
+	slots, err := p.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.Wrap(err, "hsm: get slot list error")
+	}
+
+	for _, slot := range slots {
+		ti, err := p.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, errors.Wrap(err, "hsm: get token info error")
+		}
+		if ti.Label != p.tokenLabel {
+			continue
+		}
+
+		session, err := p.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+		if err != nil {
+			return 0, errors.Wrap(err, "hsm: open session error")
+		}
+
+		if err := p.ctx.Login(session, pkcs11.CKU_USER, p.pin); err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+			p.ctx.CloseSession(session)
+			return 0, errors.Wrap(err, "hsm: login error")
+		}
+
+		return session, nil
+	}
+
+	return 0, errors.Errorf("hsm: token %s not found", p.tokenLabel)
+}
+
+// Close closes the sessions and unloads the PKCS#11 module. It waits until
+// all sessions have been returned to the pool.
+func (p *PKCS11) Close() error {
+	for i := 0; i < cap(p.sessions); i++ {
+		session := <-p.sessions
+		if session != 0 {
+			p.ctx.CloseSession(session)
+		}
+	}
+
+	err := p.ctx.Finalize()
+	p.ctx.Destroy()
+
+	if err != nil {
+		return errors.Wrap(err, "hsm: finalize error")
+	}
+	return nil
+}
+
+// Encrypt encrypts the given data using AES-ECB.
+func (p *PKCS11) Encrypt(label string, data []byte) ([]byte, error) {
+	return p.do(label, func(session pkcs11.SessionHandle, key pkcs11.ObjectHandle) ([]byte, error) {
+		if err := p.ctx.EncryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_ECB, nil)}, key); err != nil {
+			return nil, errors.Wrap(err, "hsm: encrypt init error")
+		}
+		b, err := p.ctx.Encrypt(session, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "hsm: encrypt error")
+		}
+		return b, nil
+	})
+}
+
+// Decrypt decrypts the given data using AES-ECB.
+func (p *PKCS11) Decrypt(label string, data []byte) ([]byte, error) {
+	return p.do(label, func(session pkcs11.SessionHandle, key pkcs11.ObjectHandle) ([]byte, error) {
+		if err := p.ctx.DecryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_ECB, nil)}, key); err != nil {
+			return nil, errors.Wrap(err, "hsm: decrypt init error")
+		}
+		b, err := p.ctx.Decrypt(session, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "hsm: decrypt error")
+		}
+		return b, nil
+	})
+}
+
+// CMAC returns the AES-CMAC of the given data.
+func (p *PKCS11) CMAC(label string, data []byte) ([]byte, error) {
+	return p.do(label, func(session pkcs11.SessionHandle, key pkcs11.ObjectHandle) ([]byte, error) {
+		if err := p.ctx.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_CMAC, nil)}, key); err != nil {
+			return nil, errors.Wrap(err, "hsm: sign init error")
+		}
+		b, err := p.ctx.Sign(session, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "hsm: sign error")
+		}
+		return b, nil
+	})
+}
+
+// do executes the given operation using a session of the pool and the key
+// with the given label. When the session has become invalid, it is
+// re-opened and the operation is retried once. The same applies to an
+// invalid (cached) key handle.
+func (p *PKCS11) do(label string, f func(pkcs11.SessionHandle, pkcs11.ObjectHandle) ([]byte, error)) ([]byte, error) {
+	session := <-p.sessions
+	defer func() {
+		p.sessions <- session
+	}()
+
+	var err error
+	for retry := false; ; retry = true {
+		if session == 0 {
+			session, err = p.openSession()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var key pkcs11.ObjectHandle
+		var b []byte
+
+		key, err = p.getKey(session, label)
+		if err == nil {
+			b, err = f(session, key)
+		}
+		if err == nil {
+			return b, nil
+		}
+
+		switch {
+		case isSessionError(err):
+			// the key handles might be invalid as well, e.g. after a
+			// restart of the HSM
+			p.ctx.CloseSession(session)
+			session = 0
+			p.flushKeys()
+		case isKeyHandleError(err):
+			p.deleteKey(label)
+		default:
+			return nil, err
+		}
+
+		if retry {
+			return nil, err
+		}
+	}
+}
+
+// getKey returns the handle of the AES key with the given label, either from
+// the cache or from the token.
+func (p *PKCS11) getKey(session pkcs11.SessionHandle, label string) (pkcs11.ObjectHandle, error) {
+	p.keysMu.RLock()
+	key, ok := p.keys[label]
+	p.keysMu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := p.findKey(session, label)
+	if err != nil {
+		return 0, err
+	}
+
+	p.keysMu.Lock()
+	p.keys[label] = key
+	p.keysMu.Unlock()
+
+	return key, nil
+}
+
+// deleteKey removes the key handle for the given label from the cache.
+func (p *PKCS11) deleteKey(label string) {
+	p.keysMu.Lock()
+	delete(p.keys, label)
+	p.keysMu.Unlock()
+}
+
+// flushKeys removes all key handles from the cache.
+func (p *PKCS11) flushKeys() {
+	p.keysMu.Lock()
+	p.keys = make(map[string]pkcs11.ObjectHandle)
+	p.keysMu.Unlock()
+}
+
+// findKey returns the handle of the AES key with the given label.
+func (p *PKCS11) findKey(session pkcs11.SessionHandle, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+
+	if err := p.ctx.FindObjectsInit(session, template); err != nil {
+		return 0, errors.Wrap(err, "hsm: find objects init error")
+	}
+	objs, _, err := p.ctx.FindObjects(session, 1)
+	if finalErr := p.ctx.FindObjectsFinal(session); err == nil && finalErr != nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "hsm: find objects error")
+	}
+	if len(objs) == 0 {
+		return 0, ErrKeyNotFound
+	}
+
+	return objs[0], nil
+}
+
+// isSessionError returns true when the given error indicates that the
+// session is no longer valid and must be re-opened.
+func isSessionError(err error) bool {
+	e, ok := errors.Cause(err).(pkcs11.Error)
+	if !ok {
+		return false
+	}
+
+	switch e {
+	case pkcs11.CKR_SESSION_HANDLE_INVALID,
+		pkcs11.CKR_SESSION_CLOSED,
+		pkcs11.CKR_USER_NOT_LOGGED_IN,
+		pkcs11.CKR_DEVICE_REMOVED,
+		pkcs11.CKR_TOKEN_NOT_PRESENT:
+		return true
+	default:
+		return false
+	}
+}
+
+// isKeyHandleError returns true when the given error indicates that the
+// (cached) key handle is no longer valid.
+func isKeyHandleError(err error) bool {
+	e, ok := errors.Cause(err).(pkcs11.Error)
+	if !ok {
+		return false
+	}
+
+	switch e {
+	case pkcs11.CKR_OBJECT_HANDLE_INVALID,
+		pkcs11.CKR_KEY_HANDLE_INVALID:
+		return true
+	default:
+		return false
+	}
+}
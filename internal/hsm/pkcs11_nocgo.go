@@ -0,0 +1,36 @@
+//go:build !cgo
+// +build !cgo
+
+package hsm
+
+import "github.com/pkg/errors"
+
+// PKCS11 implements the Backend interface using a PKCS#11 token. As the
+// PKCS#11 module is loaded using cgo, it is not available when built
+// without cgo (CGO_ENABLED=0).
+type PKCS11 struct{}
+
+// NewPKCS11 returns an error, as the PKCS#11 backend requires cgo.
+func NewPKCS11(module, tokenLabel, pin string, poolSize int) (*PKCS11, error) {
+	return nil, errors.New("hsm: pkcs#11 is not supported by this build (requires cgo)")
+}
+
+// Close is not supported without cgo.
+func (p *PKCS11) Close() error {
+	return errors.New("hsm: pkcs#11 is not supported by this build")
+}
+
+// Encrypt is not supported without cgo.
+func (p *PKCS11) Encrypt(label string, data []byte) ([]byte, error) {
+	return nil, errors.New("hsm: pkcs#11 is not supported by this build")
+}
+
+// Decrypt is not supported without cgo.
+func (p *PKCS11) Decrypt(label string, data []byte) ([]byte, error) {
+	return nil, errors.New("hsm: pkcs#11 is not supported by this build")
+}
+
+// CMAC is not supported without cgo.
+func (p *PKCS11) CMAC(label string, data []byte) ([]byte, error) {
+	return nil, errors.New("hsm: pkcs#11 is not supported by this build")
+}
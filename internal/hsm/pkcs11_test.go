@@ -0,0 +1,114 @@
+//go:build cgo
+// +build cgo
+
+package hsm
+
+import (
+	"crypto/aes"
+	"testing"
+
+	"github.com/jacobsa/crypto/cmac"
+	"github.com/miekg/pkcs11"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+// TestPKCS11 requires a PKCS#11 token, e.g. provided by SoftHSM:
+//
+//	softhsm2-util --init-token --free --label lora-app-server --pin 1234 --so-pin 1234
+//
+//	TEST_PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so \
+//	TEST_PKCS11_TOKEN_LABEL=lora-app-server \
+//	TEST_PKCS11_PIN=1234 go test ./internal/hsm/...
+func TestPKCS11(t *testing.T) {
+	conf := test.GetConfig()
+	if conf.PKCS11Module == "" {
+		t.Skip("TEST_PKCS11_MODULE is not set")
+	}
+
+	Convey("Given a PKCS#11 backend", t, func() {
+		p, err := NewPKCS11(conf.PKCS11Module, conf.PKCS11TokenLabel, conf.PKCS11PIN, 1)
+		So(err, ShouldBeNil)
+		defer p.Close()
+
+		// the key is created as session object, which exists until this
+		// session is closed
+		session, err := p.openSession()
+		So(err, ShouldBeNil)
+		defer p.ctx.CloseSession(session)
+
+		Convey("Given an AES key stored in the token", func() {
+			key := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+			data := []byte{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+
+			obj, err := p.ctx.CreateObject(session, []*pkcs11.Attribute{
+				pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+				pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+				pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+				pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+				pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+				pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+				pkcs11.NewAttribute(pkcs11.CKA_VALUE, key),
+				pkcs11.NewAttribute(pkcs11.CKA_LABEL, "0102030405060708:app_key"),
+			})
+			So(err, ShouldBeNil)
+			defer p.ctx.DestroyObject(session, obj)
+
+			block, err := aes.NewCipher(key)
+			So(err, ShouldBeNil)
+
+			Convey("Then Encrypt returns the expected ciphertext", func() {
+				exp := make([]byte, len(data))
+				block.Encrypt(exp, data)
+
+				b, err := p.Encrypt("0102030405060708:app_key", data)
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, exp)
+			})
+
+			Convey("Then Decrypt returns the expected plaintext", func() {
+				exp := make([]byte, len(data))
+				block.Decrypt(exp, data)
+
+				b, err := p.Decrypt("0102030405060708:app_key", data)
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, exp)
+			})
+
+			Convey("Then CMAC returns the expected MAC", func() {
+				hash, err := cmac.New(key)
+				So(err, ShouldBeNil)
+				_, err = hash.Write(data)
+				So(err, ShouldBeNil)
+
+				b, err := p.CMAC("0102030405060708:app_key", data)
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, hash.Sum(nil))
+			})
+
+			Convey("Then using an unknown label returns ErrKeyNotFound", func() {
+				_, err := p.Encrypt("0102030405060708:nwk_key", data)
+				So(err, ShouldEqual, ErrKeyNotFound)
+			})
+
+			Convey("When the session of the pool has been closed", func() {
+				_, err := p.Encrypt("0102030405060708:app_key", data)
+				So(err, ShouldBeNil)
+
+				poolSession := <-p.sessions
+				So(p.ctx.CloseSession(poolSession), ShouldBeNil)
+				p.sessions <- poolSession
+
+				Convey("Then Encrypt re-opens the session", func() {
+					exp := make([]byte, len(data))
+					block.Encrypt(exp, data)
+
+					b, err := p.Encrypt("0102030405060708:app_key", data)
+					So(err, ShouldBeNil)
+					So(b, ShouldResemble, exp)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package join
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// errNoASKEK is returned when no KEK is configured for the
+// application-server (as_kek_label).
+var errNoASKEK = errors.New("no kek configured for the application-server")
+
+// HandleAppSKeyRequest handles a given AppSKey request, made by an
+// application-server, and returns an AppSKey answer payload. The AppSKey is
+// wrapped using the KEK configured by the as_kek_label and is not returned
+// when this KEK is not configured.
+func HandleAppSKeyRequest(pl backend.AppSKeyReqPayload) backend.AppSKeyAnsPayload {
+	ans := backend.AppSKeyAnsPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        pl.ReceiverID,
+			ReceiverID:      pl.SenderID,
+			TransactionID:   pl.TransactionID,
+			MessageType:     backend.AppSKeyAns,
+		},
+		DevEUI:       pl.DevEUI,
+		SessionKeyID: pl.SessionKeyID,
+	}
+
+	appSKey, err := getAppSKeyEnvelope(pl)
+	if err != nil {
+		var resCode backend.ResultCode
+
+		switch errors.Cause(err) {
+		case storage.ErrDoesNotExist:
+			resCode = backend.UnknownDevEUI
+		default:
+			resCode = backend.Other
+		}
+
+		ans.Result = backend.Result{
+			ResultCode:  resCode,
+			Description: err.Error(),
+		}
+		return ans
+	}
+
+	ans.Result = backend.Result{
+		ResultCode: backend.Success,
+	}
+	ans.AppSKey = appSKey
+
+	return ans
+}
+
+func getAppSKeyEnvelope(pl backend.AppSKeyReqPayload) (*backend.KeyEnvelope, error) {
+	if len(pl.SessionKeyID) == 0 {
+		return nil, errors.New("SessionKeyID must be set")
+	}
+
+	da, err := storage.GetDeviceActivationForSessionKeyID(config.C.PostgreSQL.DB, pl.DevEUI, pl.SessionKeyID[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "get device-activation error")
+	}
+
+	return newAppSKeyEnvelope(da.AppSKey)
+}
+
+// newAppSKeyEnvelope returns the given AppSKey, wrapped using the KEK
+// configured by the as_kek_label. Unlike the network session-keys, which are
+// sent unwrapped to network-servers not supporting a KEK, the AppSKey is
+// never sent unwrapped. In this case errNoASKEK is returned.
+func newAppSKeyEnvelope(appSKey lorawan.AES128Key) (*backend.KeyEnvelope, error) {
+	kek, err := getKEK(config.C.JoinServer.KEK.ASKEKLabel)
+	if err != nil {
+		return nil, err
+	}
+	if kek == nil {
+		return nil, errNoASKEK
+	}
+
+	ke, err := newKeyEnvelope(config.C.JoinServer.KEK.ASKEKLabel, appSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "new key-envelope error")
+	}
+
+	return ke, nil
+}
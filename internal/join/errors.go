@@ -4,5 +4,8 @@ import "github.com/pkg/errors"
 
 // Errors
 var (
-	ErrInvalidMIC = errors.New("invalid mic")
+	ErrInvalidMIC    = errors.New("invalid mic")
+	ErrRateLimited   = errors.New("join-request rate limit exceeded")
+	ErrDeviceBlocked = errors.New("device is blocked because of repeated mic failures")
+	ErrUnknownSender = errors.New("sender is not the network-server of the device")
 )
@@ -0,0 +1,71 @@
+package join
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// HandleHomeNSRequest handles a given HomeNS request, made by a (roaming)
+// network-server, and returns a HomeNS answer payload. The NetID of the home
+// network-server is the NetID of the network-server which handled the last
+// join-request of the device.
+func HandleHomeNSRequest(pl backend.HomeNSReqPayload) backend.HomeNSAnsPayload {
+	ans := backend.HomeNSAnsPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        pl.ReceiverID,
+			ReceiverID:      pl.SenderID,
+			TransactionID:   pl.TransactionID,
+			MessageType:     backend.HomeNSAns,
+		},
+	}
+
+	netID, err := getHomeNetID(pl.DevEUI)
+	if err != nil {
+		var resCode backend.ResultCode
+
+		switch errors.Cause(err) {
+		case storage.ErrDoesNotExist:
+			resCode = backend.UnknownDevEUI
+		default:
+			resCode = backend.Other
+		}
+
+		ans.Result = backend.Result{
+			ResultCode:  resCode,
+			Description: err.Error(),
+		}
+		return ans
+	}
+
+	ans.Result = backend.Result{
+		ResultCode: backend.Success,
+	}
+	ans.HNetID = netID
+
+	return ans
+}
+
+func getHomeNetID(devEUI lorawan.EUI64) (lorawan.NetID, error) {
+	if _, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI); err != nil {
+		return lorawan.NetID{}, errors.Wrap(err, "get device error")
+	}
+
+	da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, devEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return lorawan.NetID{}, errors.New("device has not been activated")
+		}
+		return lorawan.NetID{}, errors.Wrap(err, "get device-activation error")
+	}
+
+	if da.NetID == nil {
+		return lorawan.NetID{}, errors.New("device has not been activated by a join-request")
+	}
+
+	return *da.NetID, nil
+}
@@ -1,10 +1,13 @@
 package join
 
 import (
-	"crypto/aes"
-	"encoding/binary"
+	"bytes"
+	"crypto/rand"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
@@ -23,10 +26,37 @@ type context struct {
 	device         storage.Device
 	application    storage.Application
 	deviceKeys     storage.DeviceKeys
-	appNonce       lorawan.AppNonce
+	joinNonce      lorawan.JoinNonce
 	nwkSKey        lorawan.AES128Key
 	appSKey        lorawan.AES128Key
 	netID          lorawan.NetID
+	sessionKeyID   []byte
+	clientCN       string
+	rxInfo         []handler.RXInfo
+
+	// network-server of the device, senderAuthenticated is set when the
+	// sender has been authenticated by its NetID or TLS client certificate
+	networkServer       storage.NetworkServer
+	senderAuthenticated bool
+
+	// LoRaWAN 1.1
+	optNeg      bool
+	joinReqType lorawan.JoinType
+	joinEUI     lorawan.EUI64
+	devNonce    lorawan.DevNonce
+	fNwkSIntKey lorawan.AES128Key
+	sNwkSIntKey lorawan.AES128Key
+	nwkSEncKey  lorawan.AES128Key
+	jsIntKey    lorawan.AES128Key
+	jsEncKey    lorawan.AES128Key
+
+	// activation of the current session, used for the rejoin-requests
+	// type 0 and 2
+	deviceActivation storage.DeviceActivation
+
+	// root-keys, either loaded from the database or stored in the HSM
+	appKey rootKey
+	nwkKey rootKey
 }
 
 type task func(*context) error
@@ -35,49 +65,109 @@ type flow struct {
 	joinRequestTasks []task
 }
 
-func (f *flow) run(pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+func (f *flow) run(pl backend.JoinReqPayload, clientCN string, rxInfo []handler.RXInfo) (*context, error) {
 	ctx := context{
 		joinReqPayload: pl,
+		clientCN:       clientCN,
+		rxInfo:         rxInfo,
 	}
 
 	for _, t := range f.joinRequestTasks {
 		if err := t(&ctx); err != nil {
-			return ctx.joinAnsPayload, err
+			return &ctx, err
 		}
 	}
 
-	return ctx.joinAnsPayload, nil
+	return &ctx, nil
 }
 
 var joinFlow = &flow{
 	joinRequestTasks: []task{
+		checkRateLimit,
 		setPHYPayload,
+		setJoinRequestFields,
 		getDevice,
+		validateNetworkServer,
+		checkSenderRateLimit,
 		getApplication,
 		getDeviceKeys,
+		setJSKeys,
 		validateMIC,
-		setAppNonce,
+		validateDevNonce,
+		setJoinNonce,
 		setNetID,
 		setSessionKeys,
 		createDeviceActivationRecord,
 		flushDeviceQueueMapping,
 		sendJoinNotification,
 		createJoinAnsPayload,
+		setJoinAnsAppSKeyAndLifetime,
+	},
+}
+
+// rejoinFlow handles the Rejoin-request types 0, 1 and 2 of LoRaWAN 1.1
+// devices. Rejoin-requests are only accepted from authenticated
+// network-servers. The MIC of types 0 and 2 is validated using the
+// SNwkSIntKey of the last activation of the device.
+var rejoinFlow = &flow{
+	joinRequestTasks: []task{
+		checkRateLimit,
+		setPHYPayload,
+		setRejoinRequestFields,
+		getDevice,
+		validateNetworkServer,
+		validateSenderAuthenticated,
+		checkSenderRateLimit,
+		getApplication,
+		getDeviceKeys,
+		setJSKeys,
+		validateRejoinMIC,
+		validateRJCount0,
+		validateRJCount1,
+		setRejoinJoinEUI,
+		setJoinNonce,
+		setNetID,
+		setSessionKeys,
+		createDeviceActivationRecord,
+		flushDeviceQueueMapping,
+		sendJoinNotification,
+		createJoinAnsPayload,
+		setJoinAnsAppSKeyAndLifetime,
 	},
 }
 
 // HandleJoinRequest handles a given join-request and returns a join-answer
-// payload.
-func HandleJoinRequest(pl backend.JoinReqPayload) backend.JoinAnsPayload {
+// payload. The clientCN contains the common name of the TLS client
+// certificate of the network-server (empty when not using TLS). The
+// (optional) rxInfo contains the gateways which received the join-request,
+// as supplied by the network-server.
+func HandleJoinRequest(pl backend.JoinReqPayload, clientCN string, rxInfo []handler.RXInfo) backend.JoinAnsPayload {
+	return handleRequest(joinFlow, pl, clientCN, rxInfo, backend.JoinAns)
+}
+
+// HandleRejoinRequest handles a given rejoin-request (LoRaWAN 1.1) and
+// returns a rejoin-answer payload. The rejoin-request and answer contain the
+// same fields as the join-request and answer.
+func HandleRejoinRequest(pl backend.JoinReqPayload, clientCN string, rxInfo []handler.RXInfo) backend.JoinAnsPayload {
+	return handleRequest(rejoinFlow, pl, clientCN, rxInfo, backend.RejoinAns)
+}
+
+func handleRequest(f *flow, pl backend.JoinReqPayload, clientCN string, rxInfo []handler.RXInfo, ansType backend.MessageType) backend.JoinAnsPayload {
+	protocolVersion := pl.ProtocolVersion
+	if protocolVersion == "" {
+		protocolVersion = backend.ProtocolVersion1_0
+	}
+
 	basePayload := backend.BasePayload{
-		ProtocolVersion: backend.ProtocolVersion1_0,
+		ProtocolVersion: protocolVersion,
 		SenderID:        pl.ReceiverID,
 		ReceiverID:      pl.SenderID,
 		TransactionID:   pl.TransactionID,
-		MessageType:     backend.JoinAns,
+		MessageType:     ansType,
 	}
 
-	jaPL, err := joinFlow.run(pl)
+	ctx, err := f.run(pl, clientCN, rxInfo)
+	jaPL := ctx.joinAnsPayload
 	if err != nil {
 		var resCode backend.ResultCode
 
@@ -86,6 +176,12 @@ func HandleJoinRequest(pl backend.JoinReqPayload) backend.JoinAnsPayload {
 			resCode = backend.UnknownDevEUI
 		case ErrInvalidMIC:
 			resCode = backend.MICFailed
+		case ErrRateLimited, ErrDeviceBlocked:
+			resCode = backend.ActivationDisallowed
+		case ErrUnknownSender:
+			resCode = backend.UnknownSender
+		case storage.ErrDevNonceReplayed:
+			resCode = backend.JoinReqFailed
 		default:
 			resCode = backend.Other
 		}
@@ -100,6 +196,11 @@ func HandleJoinRequest(pl backend.JoinReqPayload) backend.JoinAnsPayload {
 	}
 
 	jaPL.BasePayload = basePayload
+
+	if err := createDeviceJoinRecord(ctx, jaPL.Result); err != nil {
+		log.WithError(err).Error("js: create device-join error")
+	}
+
 	return jaPL
 }
 
@@ -110,6 +211,50 @@ func setPHYPayload(ctx *context) error {
 	return nil
 }
 
+func setJoinRequestFields(ctx *context) error {
+	jrPL, ok := ctx.phyPayload.MACPayload.(*lorawan.JoinRequestPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.JoinRequestPayload, got %T", ctx.phyPayload.MACPayload)
+	}
+
+	ctx.optNeg = isLoRaWAN11(ctx.joinReqPayload.MACVersion)
+	ctx.joinReqType = lorawan.JoinRequestType
+	ctx.joinEUI = jrPL.JoinEUI
+	ctx.devNonce = jrPL.DevNonce
+
+	return nil
+}
+
+// setRejoinRequestFields sets the fields of the rejoin-request. The RJcount
+// replaces the DevNonce in the key derivation and the join-accept MIC. Only
+// type 1 contains the JoinEUI, see setRejoinJoinEUI for types 0 and 2.
+func setRejoinRequestFields(ctx *context) error {
+	var devEUI lorawan.EUI64
+
+	switch pl := ctx.phyPayload.MACPayload.(type) {
+	case *lorawan.RejoinRequestType02Payload:
+		devEUI = pl.DevEUI
+		ctx.joinReqType = pl.RejoinType
+		ctx.devNonce = lorawan.DevNonce(pl.RJCount0)
+	case *lorawan.RejoinRequestType1Payload:
+		devEUI = pl.DevEUI
+		ctx.joinReqType = pl.RejoinType
+		ctx.joinEUI = pl.JoinEUI
+		ctx.devNonce = lorawan.DevNonce(pl.RJCount1)
+	default:
+		return fmt.Errorf("expected rejoin-request payload, got %T", ctx.phyPayload.MACPayload)
+	}
+
+	if devEUI != ctx.joinReqPayload.DevEUI {
+		return errors.New("DevEUI of rejoin-request does not match")
+	}
+
+	// only LoRaWAN 1.1 devices send rejoin-requests
+	ctx.optNeg = true
+
+	return nil
+}
+
 func getDevice(ctx *context) error {
 	d, err := storage.GetDevice(config.C.PostgreSQL.DB, ctx.joinReqPayload.DevEUI)
 	if err != nil {
@@ -120,6 +265,61 @@ func getDevice(ctx *context) error {
 	return nil
 }
 
+// validateNetworkServer validates that the join-request was sent by the
+// network-server of the device, based on the NetID and TLS client
+// certificate configured for the network-server. When neither is configured,
+// join-requests are rejected unless unauthenticated senders are allowed.
+func validateNetworkServer(ctx *context) error {
+	n, err := storage.GetNetworkServerForDevEUI(config.C.PostgreSQL.DB, ctx.device.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get network-server error")
+	}
+
+	logFields := log.Fields{
+		"dev_eui":           ctx.device.DevEUI,
+		"network_server_id": n.ID,
+		"sender_id":         ctx.joinReqPayload.SenderID,
+		"client_cn":         ctx.clientCN,
+	}
+
+	if n.NetID != nil {
+		var senderID lorawan.NetID
+		if err := senderID.UnmarshalText([]byte(ctx.joinReqPayload.SenderID)); err != nil || !bytes.Equal(senderID[:], n.NetID) {
+			log.WithFields(logFields).Warning("js: sender-id does not match net-id of network-server")
+			return errors.Wrap(ErrUnknownSender, "sender-id mismatch")
+		}
+	}
+
+	if n.JoinServerClientCN != "" && n.JoinServerClientCN != ctx.clientCN {
+		log.WithFields(logFields).Warning("js: client certificate does not match network-server")
+		return errors.Wrap(ErrUnknownSender, "client certificate mismatch")
+	}
+
+	ctx.networkServer = n
+	ctx.senderAuthenticated = n.NetID != nil || n.JoinServerClientCN != ""
+
+	if !ctx.senderAuthenticated && !config.C.JoinServer.AllowUnauthenticatedSenders {
+		log.WithFields(logFields).Warning("js: network-server has no net-id or client certificate configured")
+		return errors.Wrap(ErrUnknownSender, "unauthenticated sender")
+	}
+
+	return nil
+}
+
+// validateSenderAuthenticated rejects requests of which the sender has not
+// been authenticated, as the network-server of the device has neither a
+// NetID nor a TLS client certificate configured.
+func validateSenderAuthenticated(ctx *context) error {
+	if !ctx.senderAuthenticated {
+		log.WithFields(log.Fields{
+			"dev_eui":   ctx.device.DevEUI,
+			"sender_id": ctx.joinReqPayload.SenderID,
+		}).Warning("js: rejoin-request from unauthenticated sender")
+		return errors.Wrap(ErrUnknownSender, "unauthenticated sender")
+	}
+	return nil
+}
+
 func getApplication(ctx *context) error {
 	a, err := storage.GetApplication(config.C.PostgreSQL.DB, ctx.device.ApplicationID)
 	if err != nil {
@@ -136,21 +336,181 @@ func getDeviceKeys(ctx *context) error {
 		return errors.Wrap(err, "get device-keys error")
 	}
 	ctx.deviceKeys = dk
+
+	if hsmBackend != nil {
+		ctx.appKey = hsmKey{backend: hsmBackend, label: AppKeyLabel(ctx.device.DevEUI)}
+		ctx.nwkKey = hsmKey{backend: hsmBackend, label: NwkKeyLabel(ctx.device.DevEUI)}
+	} else {
+		ctx.appKey = memoryKey(dk.AppKey)
+		ctx.nwkKey = memoryKey(dk.NwkKey)
+	}
+
+	return nil
+}
+
+// setJSKeys derives the JSIntKey and JSEncKey, used for the join-accept MIC
+// and the encryption of a join-accept answering a rejoin-request
+// (LoRaWAN 1.1).
+func setJSKeys(ctx *context) error {
+	if !ctx.optNeg {
+		return nil
+	}
+
+	var err error
+	ctx.jsIntKey, err = getJSIntKey(ctx.nwkKey, ctx.device.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get js_int_key error")
+	}
+	ctx.jsEncKey, err = getJSEncKey(ctx.nwkKey, ctx.device.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get js_enc_key error")
+	}
+
 	return nil
 }
 
 func validateMIC(ctx *context) error {
-	ok, err := ctx.phyPayload.ValidateMIC(ctx.deviceKeys.AppKey)
+	// LoRaWAN 1.1 devices use the NwkKey for the join-request MIC
+	key := ctx.appKey
+	if ctx.optNeg {
+		key = ctx.nwkKey
+	}
+
+	// the MIC is computed over MHDR | MACPayload
+	b := ctx.joinReqPayload.PHYPayload[:]
+	if len(b) < 4 {
+		return errors.New("PHYPayload must be at least 4 bytes")
+	}
+
+	mic, err := key.cmac(b[:len(b)-4])
+	if err != nil {
+		return errors.Wrap(err, "validate mic error")
+	}
+	if !bytes.Equal(mic[0:4], b[len(b)-4:]) {
+		if err := handleMICFailure(ctx); err != nil {
+			log.WithError(err).Error("js: handle mic failure error")
+		}
+		return ErrInvalidMIC
+	}
+	return nil
+}
+
+// validateDevNonce rejects join-requests re-using a DevNonce of the device,
+// which would otherwise allow to replay a captured join-request.
+func validateDevNonce(ctx *context) error {
+	counter := isDevNonceCounter(ctx.joinReqPayload.MACVersion)
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.ValidateAndStoreDevNonce(tx, ctx.device.DevEUI, uint16(ctx.devNonce), counter, config.C.JoinServer.DevNonceHistorySize)
+	})
+	if err != nil {
+		return errors.Wrap(err, "validate dev-nonce error")
+	}
+
+	return nil
+}
+
+// isDevNonceCounter returns true when the given LoRaWAN version uses a
+// counter as DevNonce (LoRaWAN 1.0.3 and later), instead of random values.
+func isDevNonceCounter(macVersion string) bool {
+	switch {
+	case macVersion == "",
+		strings.HasPrefix(macVersion, "1.0.0"),
+		strings.HasPrefix(macVersion, "1.0.1"),
+		strings.HasPrefix(macVersion, "1.0.2"):
+		return false
+	default:
+		return true
+	}
+}
+
+// validateRejoinMIC validates the MIC of a rejoin-request. The MIC of type
+// 1 is computed using the JSIntKey, the MIC of types 0 and 2 using the
+// SNwkSIntKey of the current session, which is the last activation of the
+// device.
+func validateRejoinMIC(ctx *context) error {
+	key := ctx.jsIntKey
+
+	if ctx.joinReqType != lorawan.RejoinRequestType1 {
+		da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, ctx.device.DevEUI)
+		if err != nil {
+			return errors.Wrap(err, "get last device-activation error")
+		}
+		if da.SNwkSIntKey == nil {
+			return errors.Wrap(ErrInvalidMIC, "s_nwk_s_int_key of session is unknown")
+		}
+		ctx.deviceActivation = da
+		key = *da.SNwkSIntKey
+	}
+
+	ok, err := ctx.phyPayload.ValidateUplinkJoinMIC(key)
 	if err != nil {
 		return errors.Wrap(err, "validate mic error")
 	}
 	if !ok {
+		if err := handleMICFailure(ctx); err != nil {
+			log.WithError(err).Error("js: handle mic failure error")
+		}
 		return ErrInvalidMIC
 	}
 	return nil
 }
 
-func setAppNonce(ctx *context) error {
+// validateRJCount0 rejects rejoin-requests type 0 and 2 re-using a RJcount0
+// value within the session of the device. The device resets the RJcount0
+// on each new session.
+func validateRJCount0(ctx *context) error {
+	if ctx.joinReqType == lorawan.RejoinRequestType1 {
+		return nil
+	}
+
+	rjCount := int(ctx.devNonce)
+	if rjCount < ctx.deviceActivation.RJCount0 {
+		return storage.ErrDevNonceReplayed
+	}
+
+	if err := storage.UpdateDeviceActivationRJCount0(config.C.PostgreSQL.DB, ctx.deviceActivation.ID, rjCount+1); err != nil {
+		return errors.Wrap(err, "update device-activation rj_count0 error")
+	}
+
+	return nil
+}
+
+// validateRJCount1 rejects rejoin-requests type 1 re-using a RJcount1
+// value.
+func validateRJCount1(ctx *context) error {
+	if ctx.joinReqType != lorawan.RejoinRequestType1 {
+		return nil
+	}
+
+	rjCount := int(ctx.devNonce)
+	if rjCount < ctx.deviceKeys.RJCount1 {
+		return storage.ErrDevNonceReplayed
+	}
+	ctx.deviceKeys.RJCount1 = rjCount + 1
+
+	return nil
+}
+
+// setRejoinJoinEUI sets the JoinEUI used by the device for rejoin-requests
+// type 0 and 2, as these do not contain the JoinEUI.
+func setRejoinJoinEUI(ctx *context) error {
+	if ctx.joinReqType == lorawan.RejoinRequestType1 {
+		return nil
+	}
+
+	if ctx.deviceKeys.JoinEUI == (lorawan.EUI64{}) {
+		return errors.New("JoinEUI of device is unknown")
+	}
+	ctx.joinEUI = ctx.deviceKeys.JoinEUI
+
+	return nil
+}
+
+func setJoinNonce(ctx *context) error {
+	// the JoinEUI is stored as rejoin-requests type 0 and 2 do not contain
+	// the JoinEUI
+	ctx.deviceKeys.JoinEUI = ctx.joinEUI
 	ctx.deviceKeys.JoinNonce++
 	if ctx.deviceKeys.JoinNonce > (2<<23)-1 {
 		return errors.New("join-nonce overflow")
@@ -160,14 +520,20 @@ func setAppNonce(ctx *context) error {
 		return errors.Wrap(err, "update device-keys error")
 	}
 
-	b := make([]byte, 4)
-	binary.LittleEndian.PutUint32(b, uint32(ctx.deviceKeys.JoinNonce))
-	copy(ctx.appNonce[:], b[0:3])
+	ctx.joinNonce = lorawan.JoinNonce(ctx.deviceKeys.JoinNonce)
 
 	return nil
 }
 
+// setNetID sets the NetID of the network-server of the device. Only when
+// the network-server has no NetID configured, the NetID is taken from the
+// SenderID of the request.
 func setNetID(ctx *context) error {
+	if ctx.networkServer.NetID != nil {
+		copy(ctx.netID[:], ctx.networkServer.NetID)
+		return nil
+	}
+
 	if err := ctx.netID.UnmarshalText([]byte(ctx.joinReqPayload.SenderID)); err != nil {
 		return errors.Wrap(err, "unmarshal netid error")
 	}
@@ -177,30 +543,74 @@ func setNetID(ctx *context) error {
 func setSessionKeys(ctx *context) error {
 	var err error
 
-	jrPL, ok := ctx.phyPayload.MACPayload.(*lorawan.JoinRequestPayload)
-	if !ok {
-		return fmt.Errorf("expected *lorawan.JoinRequestPayload, got %T", ctx.phyPayload.MACPayload)
+	if ctx.optNeg {
+		return setSessionKeys11(ctx)
 	}
 
-	ctx.nwkSKey, err = getNwkSKey(ctx.deviceKeys.AppKey, ctx.netID, ctx.appNonce, jrPL.DevNonce)
+	ctx.nwkSKey, err = getNwkSKey(ctx.appKey, ctx.netID, ctx.joinNonce, ctx.devNonce)
 	if err != nil {
 		return errors.Wrap(err, "get nwk_s_key error")
 	}
 
-	ctx.appSKey, err = getAppSKey(ctx.deviceKeys.AppKey, ctx.netID, ctx.appNonce, jrPL.DevNonce)
+	ctx.appSKey, err = getAppSKey(ctx.appKey, ctx.netID, ctx.joinNonce, ctx.devNonce)
+	if err != nil {
+		return errors.Wrap(err, "get app_s_key error")
+	}
+
+	return nil
+}
+
+// setSessionKeys11 derives the LoRaWAN 1.1 session keys. In case of a
+// rejoin-request, the DevNonce is replaced by the RJcount.
+func setSessionKeys11(ctx *context) error {
+	var err error
+
+	ctx.fNwkSIntKey, err = getSKey11(0x01, ctx.nwkKey, ctx.joinNonce, ctx.joinEUI, ctx.devNonce)
+	if err != nil {
+		return errors.Wrap(err, "get f_nwk_s_int_key error")
+	}
+
+	ctx.appSKey, err = getSKey11(0x02, ctx.appKey, ctx.joinNonce, ctx.joinEUI, ctx.devNonce)
 	if err != nil {
 		return errors.Wrap(err, "get app_s_key error")
 	}
 
+	ctx.sNwkSIntKey, err = getSKey11(0x03, ctx.nwkKey, ctx.joinNonce, ctx.joinEUI, ctx.devNonce)
+	if err != nil {
+		return errors.Wrap(err, "get s_nwk_s_int_key error")
+	}
+
+	ctx.nwkSEncKey, err = getSKey11(0x04, ctx.nwkKey, ctx.joinNonce, ctx.joinEUI, ctx.devNonce)
+	if err != nil {
+		return errors.Wrap(err, "get nwk_s_enc_key error")
+	}
+
+	// the activation only stores the network session key for reference
+	ctx.nwkSKey = ctx.fNwkSIntKey
+
 	return nil
 }
 
 func createDeviceActivationRecord(ctx *context) error {
+	// the SessionKeyID is used by the application-server to request the
+	// AppSKey of this activation
+	ctx.sessionKeyID = make([]byte, 16)
+	if _, err := rand.Read(ctx.sessionKeyID); err != nil {
+		return errors.Wrap(err, "read random bytes error")
+	}
+
 	da := storage.DeviceActivation{
-		DevEUI:  ctx.device.DevEUI,
-		DevAddr: ctx.joinReqPayload.DevAddr,
-		AppSKey: ctx.appSKey,
-		NwkSKey: ctx.nwkSKey,
+		DevEUI:       ctx.device.DevEUI,
+		DevAddr:      ctx.joinReqPayload.DevAddr,
+		AppSKey:      ctx.appSKey,
+		NwkSKey:      ctx.nwkSKey,
+		SessionKeyID: ctx.sessionKeyID,
+		NetID:        &ctx.netID,
+	}
+
+	// the SNwkSIntKey is used to validate the rejoin-requests type 0 and 2
+	if ctx.optNeg {
+		da.SNwkSIntKey = &ctx.sNwkSIntKey
 	}
 
 	if err := storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &da); err != nil {
@@ -214,6 +624,9 @@ func flushDeviceQueueMapping(ctx *context) error {
 	if err := storage.FlushDeviceQueueMappingForDevEUI(config.C.PostgreSQL.DB, ctx.device.DevEUI); err != nil {
 		return errors.Wrap(err, "flush device-queue mapping error")
 	}
+	if err := storage.FlushDeviceQueueItemStatusForDevEUI(config.C.PostgreSQL.DB, ctx.device.DevEUI); err != nil {
+		return errors.Wrap(err, "flush device-queue item status error")
+	}
 	return nil
 }
 
@@ -224,6 +637,7 @@ func sendJoinNotification(ctx *context) error {
 		DeviceName:      ctx.device.Name,
 		DevEUI:          ctx.device.DevEUI,
 		DevAddr:         ctx.joinReqPayload.DevAddr,
+		RXInfo:          ctx.rxInfo,
 	}
 
 	err := eventlog.LogEventForDevice(ctx.device.DevEUI, eventlog.EventLog{
@@ -241,33 +655,60 @@ func sendJoinNotification(ctx *context) error {
 	return nil
 }
 
+// createDeviceJoinRecord stores the handled join-request, including its
+// result, in the join history of the device. Requests for unknown devices
+// and requests rejected before the device was retrieved are not stored.
+func createDeviceJoinRecord(ctx *context, result backend.Result) error {
+	if ctx.device.DevEUI == (lorawan.EUI64{}) {
+		return nil
+	}
+
+	// the JoinNonce is only set when the join-request has been accepted
+	var joinNonce int
+	if ctx.joinNonce != 0 {
+		joinNonce = ctx.deviceKeys.JoinNonce
+	}
+
+	dj := storage.DeviceJoin{
+		DevEUI:      ctx.device.DevEUI,
+		MessageType: string(ctx.joinReqPayload.MessageType),
+		DevNonce:    int(ctx.devNonce),
+		JoinNonce:   joinNonce,
+		DevAddr:     ctx.joinReqPayload.DevAddr,
+		SenderID:    ctx.joinReqPayload.SenderID,
+		ResultCode:  string(result.ResultCode),
+		Description: result.Description,
+	}
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return storage.CreateDeviceJoin(tx, &dj, config.C.JoinServer.JoinHistorySize)
+	})
+	if err != nil {
+		return errors.Wrap(err, "create device-join error")
+	}
+
+	return nil
+}
+
 func createJoinAnsPayload(ctx *context) error {
-	phy := lorawan.PHYPayload{
-		MHDR: lorawan.MHDR{
-			MType: lorawan.JoinAccept,
-			Major: lorawan.LoRaWANR1,
-		},
-		MACPayload: &lorawan.JoinAcceptPayload{
-			AppNonce:   ctx.appNonce,
-			NetID:      ctx.netID,
-			DevAddr:    ctx.joinReqPayload.DevAddr,
-			DLSettings: ctx.joinReqPayload.DLSettings,
-			RXDelay:    uint8(ctx.joinReqPayload.RxDelay),
-			CFList:     ctx.joinReqPayload.CFList,
-		},
+	if ctx.optNeg {
+		return createJoinAnsPayload11(ctx)
 	}
 
-	if err := phy.SetMIC(ctx.deviceKeys.AppKey); err != nil {
-		return err
+	ja, err := getJoinAcceptPayload(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get join-accept payload error")
 	}
 
-	if err := phy.EncryptJoinAcceptPayload(ctx.deviceKeys.AppKey); err != nil {
-		return err
+	b, err := marshalJoinAccept(ja, ctx.joinReqType, ctx.joinEUI, ctx.devNonce, ctx.appKey, ctx.appKey)
+	if err != nil {
+		return errors.Wrap(err, "marshal join-accept error")
 	}
 
-	b, err := phy.MarshalBinary()
+	// the session-keys are wrapped using the KEK of the network-server
+	nwkSKey, err := newKeyEnvelope(ctx.joinReqPayload.SenderID, ctx.nwkSKey)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "new key-envelope error")
 	}
 
 	ctx.joinAnsPayload = backend.JoinAnsPayload{
@@ -275,50 +716,127 @@ func createJoinAnsPayload(ctx *context) error {
 		Result: backend.Result{
 			ResultCode: backend.Success,
 		},
-		NwkSKey: &backend.KeyEnvelope{
-			AESKey: ctx.nwkSKey,
-		},
-		// TODO: add AppSKey and Lifetime
+		NwkSKey:      nwkSKey,
+		SessionKeyID: backend.HEXBytes(ctx.sessionKeyID),
 	}
 
 	return nil
 }
 
-// getNwkSKey returns the network session key.
-func getNwkSKey(appkey lorawan.AES128Key, netID lorawan.NetID, appNonce [3]byte, devNonce [2]byte) (lorawan.AES128Key, error) {
-	return getSKey(0x01, appkey, netID, appNonce, devNonce)
-}
+// createJoinAnsPayload11 creates the join-answer for a LoRaWAN 1.1 device.
+// The join-accept has the OptNeg bit set and its MIC is computed using the
+// JSIntKey. A join-accept answering a join-request is encrypted using the
+// NwkKey, one answering a rejoin-request using the JSEncKey.
+func createJoinAnsPayload11(ctx *context) error {
+	ja, err := getJoinAcceptPayload(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get join-accept payload error")
+	}
 
-// getAppSKey returns the application session key.
-func getAppSKey(appkey lorawan.AES128Key, netID lorawan.NetID, appNonce [3]byte, devNonce [2]byte) (lorawan.AES128Key, error) {
-	return getSKey(0x02, appkey, netID, appNonce, devNonce)
-}
+	key := ctx.nwkKey
+	if ctx.joinReqType != lorawan.JoinRequestType {
+		key = memoryKey(ctx.jsEncKey)
+	}
 
-func getSKey(typ byte, appkey lorawan.AES128Key, netID lorawan.NetID, appNonce [3]byte, devNonce [2]byte) (lorawan.AES128Key, error) {
-	var key lorawan.AES128Key
-	b := make([]byte, 0, 16)
-	b = append(b, typ)
+	b, err := marshalJoinAccept(ja, ctx.joinReqType, ctx.joinEUI, ctx.devNonce, memoryKey(ctx.jsIntKey), key)
+	if err != nil {
+		return errors.Wrap(err, "marshal join-accept error")
+	}
 
-	// little endian
-	for i := len(appNonce) - 1; i >= 0; i-- {
-		b = append(b, appNonce[i])
+	// the session-keys are wrapped using the KEK of the network-server
+	var keys [3]*backend.KeyEnvelope
+	for i, key := range []lorawan.AES128Key{ctx.fNwkSIntKey, ctx.sNwkSIntKey, ctx.nwkSEncKey} {
+		keys[i], err = newKeyEnvelope(ctx.joinReqPayload.SenderID, key)
+		if err != nil {
+			return errors.Wrap(err, "new key-envelope error")
+		}
 	}
-	for i := len(netID) - 1; i >= 0; i-- {
-		b = append(b, netID[i])
+
+	ctx.joinAnsPayload = backend.JoinAnsPayload{
+		PHYPayload: backend.HEXBytes(b),
+		Result: backend.Result{
+			ResultCode: backend.Success,
+		},
+		FNwkSIntKey:  keys[0],
+		SNwkSIntKey:  keys[1],
+		NwkSEncKey:   keys[2],
+		SessionKeyID: backend.HEXBytes(ctx.sessionKeyID),
 	}
-	for i := len(devNonce) - 1; i >= 0; i-- {
-		b = append(b, devNonce[i])
+
+	return nil
+}
+
+// getJoinAcceptPayload returns the join-accept payload, using the
+// DLSettings, RxDelay and (optional) CFList provided by the network-server.
+// The OptNeg bit is set for LoRaWAN 1.1 devices.
+func getJoinAcceptPayload(ctx *context) (lorawan.JoinAcceptPayload, error) {
+	ja := lorawan.JoinAcceptPayload{
+		JoinNonce:  ctx.joinNonce,
+		HomeNetID:  ctx.netID,
+		DevAddr:    ctx.joinReqPayload.DevAddr,
+		DLSettings: ctx.joinReqPayload.DLSettings,
+		RXDelay:    uint8(ctx.joinReqPayload.RxDelay),
+	}
+	ja.DLSettings.OptNeg = ctx.optNeg
+
+	if len(ctx.joinReqPayload.CFList) != 0 {
+		ja.CFList = &lorawan.CFList{}
+		if err := ja.CFList.UnmarshalBinary(ctx.joinReqPayload.CFList[:]); err != nil {
+			return ja, errors.Wrap(err, "unmarshal cflist error")
+		}
 	}
-	pad := make([]byte, 7)
-	b = append(b, pad...)
 
-	block, err := aes.NewCipher(appkey[:])
+	return ja, nil
+}
+
+// setJoinAnsAppSKeyAndLifetime adds the AppSKey and the session-key lifetime
+// to the join-answer. The AppSKey is only added when a KEK is configured for
+// the application-server, as it must not be sent unwrapped.
+func setJoinAnsAppSKeyAndLifetime(ctx *context) error {
+	appSKey, err := newAppSKeyEnvelope(ctx.appSKey)
+	if err != nil && err != errNoASKEK {
+		return errors.Wrap(err, "new appskey key-envelope error")
+	}
+	ctx.joinAnsPayload.AppSKey = appSKey
+
+	if lifetime := int(config.C.JoinServer.SessionKeyLifetime / time.Second); lifetime > 0 {
+		ctx.joinAnsPayload.Lifetime = &lifetime
+	}
+
+	return nil
+}
+
+// isLoRaWAN11 returns true when the given LoRaWAN version is 1.1 or later.
+func isLoRaWAN11(macVersion string) bool {
+	return strings.HasPrefix(macVersion, "1.1")
+}
+
+// getNwkSKey returns the network session key.
+func getNwkSKey(appKey rootKey, netID lorawan.NetID, joinNonce lorawan.JoinNonce, devNonce lorawan.DevNonce) (lorawan.AES128Key, error) {
+	return getSKey(0x01, appKey, netID, joinNonce, devNonce)
+}
+
+// getAppSKey returns the application session key.
+func getAppSKey(appKey rootKey, netID lorawan.NetID, joinNonce lorawan.JoinNonce, devNonce lorawan.DevNonce) (lorawan.AES128Key, error) {
+	return getSKey(0x02, appKey, netID, joinNonce, devNonce)
+}
+
+// getSKey returns a LoRaWAN 1.0 session key:
+// aes128_encrypt(AppKey, typ | JoinNonce | NetID | DevNonce | pad16)
+func getSKey(typ byte, appKey rootKey, netID lorawan.NetID, joinNonce lorawan.JoinNonce, devNonce lorawan.DevNonce) (lorawan.AES128Key, error) {
+	joinNonceB, err := joinNonce.MarshalBinary()
 	if err != nil {
-		return key, err
+		return lorawan.AES128Key{}, errors.Wrap(err, "marshal join-nonce error")
 	}
-	if block.BlockSize() != len(b) {
-		return key, fmt.Errorf("block-size of %d bytes is expected", len(b))
+	devNonceB, err := devNonce.MarshalBinary()
+	if err != nil {
+		return lorawan.AES128Key{}, errors.Wrap(err, "marshal dev-nonce error")
 	}
-	block.Encrypt(key[:], b)
-	return key, nil
+
+	b := []byte{typ}
+	b = append(b, joinNonceB...)
+	b = append(b, reverse(netID[:])...)
+	b = append(b, devNonceB...)
+
+	return encryptBlock(appKey, b)
 }
@@ -3,8 +3,12 @@ package join
 import (
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
 
 	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/handler"
 	"github.com/brocaar/lora-app-server/internal/test/testhandler"
 
 	"github.com/brocaar/lora-app-server/internal/storage"
@@ -44,6 +48,7 @@ func TestJoin(t *testing.T) {
 		n := storage.NetworkServer{
 			Name:   "test-ns",
 			Server: "test-ns:1234",
+			NetID:  []byte{1, 2, 3},
 		}
 		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
 
@@ -85,6 +90,15 @@ func TestJoin(t *testing.T) {
 		So(storage.CreateDeviceKeys(config.C.PostgreSQL.DB, &dk), ShouldBeNil)
 
 		Convey("Given a set of tests", func() {
+			cfList := lorawan.CFList{
+				CFListType: lorawan.CFListChannel,
+				Payload: &lorawan.CFListChannelPayload{
+					Channels: [5]uint32{868700000, 868900000},
+				},
+			}
+			cfListBytes, err := cfList.MarshalBinary()
+			So(err, ShouldBeNil)
+
 			validJRPHY := lorawan.PHYPayload{
 				MHDR: lorawan.MHDR{
 					MType: lorawan.JoinRequest,
@@ -92,11 +106,11 @@ func TestJoin(t *testing.T) {
 				},
 				MACPayload: &lorawan.JoinRequestPayload{
 					DevEUI:   d.DevEUI,
-					AppEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
-					DevNonce: lorawan.DevNonce{1, 2},
+					JoinEUI:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					DevNonce: 258,
 				},
 			}
-			So(validJRPHY.SetMIC(dk.AppKey), ShouldBeNil)
+			So(validJRPHY.SetUplinkJoinMIC(dk.AppKey), ShouldBeNil)
 			validJRPHYBytes, err := validJRPHY.MarshalBinary()
 			So(err, ShouldBeNil)
 
@@ -107,11 +121,11 @@ func TestJoin(t *testing.T) {
 				},
 				MACPayload: &lorawan.JoinRequestPayload{
 					DevEUI:   d.DevEUI,
-					AppEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
-					DevNonce: lorawan.DevNonce{1, 2},
+					JoinEUI:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					DevNonce: 258,
 				},
 			}
-			So(invalidMICJRPHY.SetMIC(lorawan.AES128Key{}), ShouldBeNil)
+			So(invalidMICJRPHY.SetUplinkJoinMIC(lorawan.AES128Key{}), ShouldBeNil)
 			invalidMICJRPHYBytes, err := invalidMICJRPHY.MarshalBinary()
 			So(err, ShouldBeNil)
 
@@ -121,18 +135,18 @@ func TestJoin(t *testing.T) {
 					Major: lorawan.LoRaWANR1,
 				},
 				MACPayload: &lorawan.JoinAcceptPayload{
-					AppNonce: lorawan.AppNonce{1, 0, 0},
-					NetID:    lorawan.NetID{1, 2, 3},
-					DevAddr:  lorawan.DevAddr{1, 2, 3, 4},
+					JoinNonce: 1,
+					HomeNetID: lorawan.NetID{1, 2, 3},
+					DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
 					DLSettings: lorawan.DLSettings{
 						RX2DataRate: 5,
 						RX1DROffset: 1,
 					},
 					RXDelay: 1,
-					CFList:  &lorawan.CFList{868700000, 868900000},
+					CFList:  &cfList,
 				},
 			}
-			So(validJAPHY.SetMIC(dk.AppKey), ShouldBeNil)
+			So(validJAPHY.SetDownlinkJoinMIC(lorawan.JoinRequestType, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, 258, dk.AppKey), ShouldBeNil)
 			So(validJAPHY.EncryptJoinAcceptPayload(dk.AppKey), ShouldBeNil)
 			validJAPHYBytes, err := validJAPHY.MarshalBinary()
 			So(err, ShouldBeNil)
@@ -162,7 +176,7 @@ func TestJoin(t *testing.T) {
 							RX1DROffset: 1,
 						},
 						RxDelay: 1,
-						CFList:  &lorawan.CFList{868700000, 868900000},
+						CFList:  backend.HEXBytes(cfListBytes),
 					},
 					ExpectedPayload: backend.JoinAnsPayload{
 						BasePayload: backend.BasePayload{
@@ -177,7 +191,7 @@ func TestJoin(t *testing.T) {
 						},
 						PHYPayload: backend.HEXBytes(validJAPHYBytes),
 						NwkSKey: &backend.KeyEnvelope{
-							AESKey: lorawan.AES128Key{223, 83, 195, 95, 48, 52, 204, 206, 208, 255, 53, 76, 112, 222, 4, 223},
+							AESKey: backend.HEXBytes{223, 83, 195, 95, 48, 52, 204, 206, 208, 255, 53, 76, 112, 222, 4, 223},
 						},
 					},
 				},
@@ -200,7 +214,7 @@ func TestJoin(t *testing.T) {
 							RX1DROffset: 1,
 						},
 						RxDelay: 1,
-						CFList:  &lorawan.CFList{868700000, 868900000},
+						CFList:  backend.HEXBytes(cfListBytes),
 					},
 					ExpectedPayload: backend.JoinAnsPayload{
 						BasePayload: backend.BasePayload{
@@ -235,7 +249,7 @@ func TestJoin(t *testing.T) {
 							RX1DROffset: 1,
 						},
 						RxDelay: 1,
-						CFList:  &lorawan.CFList{868700000, 868900000},
+						CFList:  backend.HEXBytes(cfListBytes),
 					},
 					ExpectedPayload: backend.JoinAnsPayload{
 						BasePayload: backend.BasePayload{
@@ -273,7 +287,7 @@ func TestJoin(t *testing.T) {
 							RX1DROffset: 1,
 						},
 						RxDelay: 1,
-						CFList:  &lorawan.CFList{868700000, 868900000},
+						CFList:  backend.HEXBytes(cfListBytes),
 					},
 					ExpectedPayload: backend.JoinAnsPayload{
 						BasePayload: backend.BasePayload{
@@ -289,6 +303,82 @@ func TestJoin(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: "join-request with replayed dev-nonce",
+					PreRun: func() error {
+						return storage.ValidateAndStoreDevNonce(config.C.PostgreSQL.DB, d.DevEUI, 258, false, 10)
+					},
+					RequestPayload: backend.JoinReqPayload{
+						BasePayload: backend.BasePayload{
+							ProtocolVersion: backend.ProtocolVersion1_0,
+							SenderID:        "010203",
+							ReceiverID:      "0807060504030201",
+							TransactionID:   1234,
+							MessageType:     backend.JoinReq,
+						},
+						MACVersion: "1.0.2",
+						PHYPayload: backend.HEXBytes(validJRPHYBytes),
+						DevEUI:     d.DevEUI,
+						DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+						DLSettings: lorawan.DLSettings{
+							RX2DataRate: 5,
+							RX1DROffset: 1,
+						},
+						RxDelay: 1,
+						CFList:  backend.HEXBytes(cfListBytes),
+					},
+					ExpectedPayload: backend.JoinAnsPayload{
+						BasePayload: backend.BasePayload{
+							ProtocolVersion: backend.ProtocolVersion1_0,
+							SenderID:        "0807060504030201",
+							ReceiverID:      "010203",
+							TransactionID:   1234,
+							MessageType:     backend.JoinAns,
+						},
+						Result: backend.Result{
+							ResultCode:  backend.JoinReqFailed,
+							Description: "validate dev-nonce error: dev-nonce has already been used",
+						},
+					},
+				},
+				{
+					Name: "join-request with dev-nonce lower than the last dev-nonce (LoRaWAN 1.0.3)",
+					PreRun: func() error {
+						return storage.ValidateAndStoreDevNonce(config.C.PostgreSQL.DB, d.DevEUI, 300, true, 10)
+					},
+					RequestPayload: backend.JoinReqPayload{
+						BasePayload: backend.BasePayload{
+							ProtocolVersion: backend.ProtocolVersion1_0,
+							SenderID:        "010203",
+							ReceiverID:      "0807060504030201",
+							TransactionID:   1234,
+							MessageType:     backend.JoinReq,
+						},
+						MACVersion: "1.0.3",
+						PHYPayload: backend.HEXBytes(validJRPHYBytes),
+						DevEUI:     d.DevEUI,
+						DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+						DLSettings: lorawan.DLSettings{
+							RX2DataRate: 5,
+							RX1DROffset: 1,
+						},
+						RxDelay: 1,
+						CFList:  backend.HEXBytes(cfListBytes),
+					},
+					ExpectedPayload: backend.JoinAnsPayload{
+						BasePayload: backend.BasePayload{
+							ProtocolVersion: backend.ProtocolVersion1_0,
+							SenderID:        "0807060504030201",
+							ReceiverID:      "010203",
+							TransactionID:   1234,
+							MessageType:     backend.JoinAns,
+						},
+						Result: backend.Result{
+							ResultCode:  backend.JoinReqFailed,
+							Description: "validate dev-nonce error: dev-nonce has already been used",
+						},
+					},
+				},
 			}
 
 			for i, test := range tests {
@@ -297,15 +387,323 @@ func TestJoin(t *testing.T) {
 						So(test.PreRun(), ShouldBeNil)
 					}
 
-					ans := HandleJoinRequest(test.RequestPayload)
-					So(ans, ShouldResemble, test.ExpectedPayload)
+					ans := HandleJoinRequest(test.RequestPayload, "", nil)
 
 					if ans.Result.ResultCode == backend.Success {
-						_, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+						da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
 						So(err, ShouldBeNil)
+						So(da.SessionKeyID, ShouldHaveLength, 16)
+						test.ExpectedPayload.SessionKeyID = backend.HEXBytes(da.SessionKeyID)
+					}
+					So(ans, ShouldResemble, test.ExpectedPayload)
+
+					if ans.Result.ResultCode == backend.Success {
+
+						Convey("Then the same join-request is rejected", func() {
+							ans := HandleJoinRequest(test.RequestPayload, "", nil)
+							So(ans.Result.ResultCode, ShouldEqual, backend.JoinReqFailed)
+						})
 					}
 				})
 			}
+
+			Convey("Given the network-server has a NetID and client certificate configured", func() {
+				n.NetID = []byte{1, 2, 3}
+				n.JoinServerClientCN = "ns.example.com"
+				So(storage.UpdateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+				Convey("Then a join-request with matching SenderID and client certificate is accepted", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "ns.example.com", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				})
+
+				Convey("Then a join-request with a different client certificate is rejected", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "other.example.com", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.UnknownSender)
+				})
+
+				Convey("Then a join-request with a different SenderID is rejected", func() {
+					pl := tests[0].RequestPayload
+					pl.SenderID = "030201"
+					ans := HandleJoinRequest(pl, "ns.example.com", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.UnknownSender)
+				})
+			})
+
+			Convey("Given the network-server has neither a NetID nor a client certificate configured", func() {
+				n.NetID = nil
+				So(storage.UpdateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+				Convey("Then a join-request is rejected", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.UnknownSender)
+				})
+
+				Convey("Given unauthenticated senders are allowed", func() {
+					config.C.JoinServer.AllowUnauthenticatedSenders = true
+					defer func() {
+						config.C.JoinServer.AllowUnauthenticatedSenders = false
+					}()
+
+					Convey("Then a join-request is accepted", func() {
+						ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+						So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					})
+				})
+			})
+
+			Convey("Given the network-server has only a client certificate configured", func() {
+				n.NetID = nil
+				n.JoinServerClientCN = "ns.example.com"
+				So(storage.UpdateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+				Convey("Then a join-request with matching client certificate is accepted", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "ns.example.com", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				})
+			})
+
+			Convey("Given a join-history size of 1", func() {
+				config.C.JoinServer.JoinHistorySize = 1
+				defer func() {
+					config.C.JoinServer.JoinHistorySize = 0
+				}()
+
+				Convey("When handling a join-request with rx-info", func() {
+					now := time.Now().UTC()
+					rxInfo := []handler.RXInfo{
+						{
+							MAC:     lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+							Time:    &now,
+							RSSI:    -60,
+							LoRaSNR: 5.5,
+						},
+					}
+
+					ans := HandleJoinRequest(tests[0].RequestPayload, "", rxInfo)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+
+					Convey("Then the join-notification contains the rx-info", func() {
+						So(h.SendJoinNotificationChan, ShouldHaveLength, 1)
+						jn := <-h.SendJoinNotificationChan
+						So(jn.RXInfo, ShouldResemble, rxInfo)
+					})
+
+					Convey("Then a device-join record was created", func() {
+						joins, err := storage.GetDeviceJoins(config.C.PostgreSQL.DB, d.DevEUI, 10, 0)
+						So(err, ShouldBeNil)
+						So(joins, ShouldHaveLength, 1)
+						So(joins[0].MessageType, ShouldEqual, string(backend.JoinReq))
+						So(joins[0].DevNonce, ShouldEqual, 258)
+						So(joins[0].JoinNonce, ShouldEqual, 1)
+						So(joins[0].DevAddr, ShouldEqual, lorawan.DevAddr{1, 2, 3, 4})
+						So(joins[0].SenderID, ShouldEqual, "010203")
+						So(joins[0].ResultCode, ShouldEqual, string(backend.Success))
+					})
+
+					Convey("When handling a join-request with invalid MIC", func() {
+						ans := HandleJoinRequest(tests[1].RequestPayload, "", nil)
+						So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+
+						Convey("Then only the failed join-request is kept", func() {
+							joins, err := storage.GetDeviceJoins(config.C.PostgreSQL.DB, d.DevEUI, 10, 0)
+							So(err, ShouldBeNil)
+							So(joins, ShouldHaveLength, 1)
+							So(joins[0].ResultCode, ShouldEqual, string(backend.MICFailed))
+							So(joins[0].JoinNonce, ShouldEqual, 0)
+						})
+					})
+				})
+			})
+
+			Convey("Given a KEK for the application-server and a session-key lifetime", func() {
+				config.C.JoinServer.KEK.ASKEKLabel = "lora-app-server"
+				config.C.JoinServer.KEK.Set = []config.KEK{
+					{Label: "lora-app-server", KEK: "000102030405060708090a0b0c0d0e0f"},
+				}
+				config.C.JoinServer.SessionKeyLifetime = time.Hour
+				defer func() {
+					config.C.JoinServer.KEK.ASKEKLabel = ""
+					config.C.JoinServer.KEK.Set = nil
+					config.C.JoinServer.SessionKeyLifetime = 0
+				}()
+
+				Convey("Then the join-answer contains the wrapped AppSKey and the lifetime", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					So(ans.Lifetime, ShouldNotBeNil)
+					So(*ans.Lifetime, ShouldEqual, 3600)
+					So(ans.AppSKey, ShouldNotBeNil)
+					So(ans.AppSKey.KEKLabel, ShouldEqual, "lora-app-server")
+
+					da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(da.NetID, ShouldResemble, &lorawan.NetID{1, 2, 3})
+
+					appSKey, err := UnwrapKeyEnvelope(ans.AppSKey)
+					So(err, ShouldBeNil)
+					So(appSKey, ShouldEqual, da.AppSKey)
+				})
+			})
+
+			Convey("Given a DevEUI join-request rate limit", func() {
+				config.C.JoinServer.RateLimit.DevEUIMax = 1
+				config.C.JoinServer.RateLimit.DevEUIWindow = time.Minute
+				defer func() {
+					config.C.JoinServer.RateLimit.DevEUIMax = 0
+				}()
+
+				Convey("Then join-requests exceeding the limit are rejected", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+
+					ans = HandleJoinRequest(tests[0].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.ActivationDisallowed)
+
+					Convey("Then the device is listed as throttled", func() {
+						devices, err := ListThrottledDevices(app.ID)
+						So(err, ShouldBeNil)
+						So(devices, ShouldHaveLength, 1)
+						So(devices[0].DevEUI, ShouldEqual, d.DevEUI)
+						So(devices[0].Reason, ShouldEqual, ThrottleRateLimit)
+					})
+
+					Convey("When the device has been throttled more than 24 hours ago", func() {
+						c := config.C.Redis.Pool.Get()
+						defer c.Close()
+
+						ms := time.Now().Add(-25*time.Hour).UnixNano() / int64(time.Millisecond)
+						_, err := c.Do("ZADD", fmt.Sprintf(throttledKeyTempl, app.ID), ms, d.DevEUI.String())
+						So(err, ShouldBeNil)
+
+						Convey("Then the device and its reason are removed", func() {
+							devices, err := ListThrottledDevices(app.ID)
+							So(err, ShouldBeNil)
+							So(devices, ShouldHaveLength, 0)
+
+							exists, err := redis.Bool(c.Do("HEXISTS", fmt.Sprintf(throttledReasonsKeyTempl, app.ID), d.DevEUI.String()))
+							So(err, ShouldBeNil)
+							So(exists, ShouldBeFalse)
+						})
+					})
+				})
+			})
+
+			Convey("Given a SenderID join-request rate limit", func() {
+				config.C.JoinServer.RateLimit.SenderIDMax = 1
+				config.C.JoinServer.RateLimit.SenderIDWindow = time.Minute
+				defer func() {
+					config.C.JoinServer.RateLimit.SenderIDMax = 0
+				}()
+
+				Convey("Then join-requests exceeding the limit are rejected", func() {
+					ans := HandleJoinRequest(tests[1].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+
+					ans = HandleJoinRequest(tests[0].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.ActivationDisallowed)
+
+					devices, err := ListThrottledDevices(app.ID)
+					So(err, ShouldBeNil)
+					So(devices, ShouldHaveLength, 0)
+				})
+
+				Convey("Given the network-server has a NetID configured", func() {
+					n.NetID = []byte{1, 2, 3}
+					So(storage.UpdateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+					Convey("Then join-requests with a spoofed SenderID do not count against the limit", func() {
+						pl := tests[0].RequestPayload
+						pl.SenderID = "030201"
+						for i := 0; i < 2; i++ {
+							ans := HandleJoinRequest(pl, "", nil)
+							So(ans.Result.ResultCode, ShouldEqual, backend.UnknownSender)
+						}
+
+						ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+						So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					})
+				})
+			})
+
+			Convey("Given a MIC failure limit", func() {
+				config.C.JoinServer.RateLimit.MICFailureMax = 2
+				config.C.JoinServer.RateLimit.MICFailureWindow = time.Minute
+				config.C.JoinServer.RateLimit.BlockDuration = time.Hour
+				defer func() {
+					config.C.JoinServer.RateLimit.MICFailureMax = 0
+					config.C.JoinServer.RateLimit.BlockDuration = 0
+				}()
+
+				Convey("When the limit is reached", func() {
+					for i := 0; i < 2; i++ {
+						ans := HandleJoinRequest(tests[1].RequestPayload, "", nil)
+						So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+					}
+
+					Convey("Then an error notification was sent", func() {
+						So(h.SendErrorNotificationChan, ShouldHaveLength, 1)
+						errNotification := <-h.SendErrorNotificationChan
+						So(errNotification.DevEUI, ShouldEqual, d.DevEUI)
+						So(errNotification.Type, ShouldEqual, "JOIN_MIC")
+					})
+
+					Convey("Then the device is listed as throttled", func() {
+						devices, err := ListThrottledDevices(app.ID)
+						So(err, ShouldBeNil)
+						So(devices, ShouldHaveLength, 1)
+						So(devices[0].Reason, ShouldEqual, ThrottleMICFailures)
+					})
+
+					Convey("Then a valid join-request is rejected", func() {
+						ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+						So(ans.Result.ResultCode, ShouldEqual, backend.ActivationDisallowed)
+					})
+				})
+			})
+
+			Convey("Given the AppKey is stored in the HSM", func() {
+				SetHSM(testHSM{AppKeyLabel(d.DevEUI): dk.AppKey})
+				defer SetHSM(nil)
+
+				// the AppKey is not stored in the database
+				So(storage.UpdateDeviceKeys(config.C.PostgreSQL.DB, &storage.DeviceKeys{DevEUI: d.DevEUI}), ShouldBeNil)
+
+				Convey("Then the join-request is handled using the HSM", func() {
+					ans := HandleJoinRequest(tests[0].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					So(ans.PHYPayload, ShouldResemble, backend.HEXBytes(validJAPHYBytes))
+					So(ans.NwkSKey, ShouldResemble, tests[0].ExpectedPayload.NwkSKey)
+				})
+
+				Convey("Then a join-request with invalid MIC is rejected", func() {
+					ans := HandleJoinRequest(tests[1].RequestPayload, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+				})
+			})
 		})
 	})
 }
+
+func TestIsDevNonceCounter(t *testing.T) {
+	Convey("Given a set of LoRaWAN versions", t, func() {
+		tests := []struct {
+			MACVersion string
+			Counter    bool
+		}{
+			{"", false},
+			{"1.0.0", false},
+			{"1.0.1", false},
+			{"1.0.2", false},
+			{"1.0.2rB", false},
+			{"1.0.3", true},
+			{"1.1.0", true},
+		}
+
+		for _, test := range tests {
+			Convey(fmt.Sprintf("Then %q uses a counter: %t", test.MACVersion, test.Counter), func() {
+				So(isDevNonceCounter(test.MACVersion), ShouldEqual, test.Counter)
+			})
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package join
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/keywrap"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// getKEK returns the key-encryption key for the given label. It returns nil
+// when no KEK has been configured for the label.
+func getKEK(label string) ([]byte, error) {
+	if label == "" {
+		return nil, nil
+	}
+
+	for _, k := range config.C.JoinServer.KEK.Set {
+		if k.Label != label {
+			continue
+		}
+
+		kek, err := hex.DecodeString(k.KEK)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode kek %s error", label)
+		}
+		return kek, nil
+	}
+
+	return nil, nil
+}
+
+// newKeyEnvelope returns the key-envelope for the given key. The key is
+// wrapped using the KEK matching the given label. When no KEK is configured
+// for the label, an error is returned when a KEK is required, else the key
+// is sent unwrapped and without label.
+func newKeyEnvelope(label string, key lorawan.AES128Key) (*backend.KeyEnvelope, error) {
+	kek, err := getKEK(label)
+	if err != nil {
+		return nil, err
+	}
+
+	if kek == nil {
+		if config.C.JoinServer.KEK.Require {
+			return nil, errors.Errorf("no kek configured for label: %s", label)
+		}
+
+		log.WithField("kek_label", label).Warning("js: no kek configured for label, sending unwrapped session-key")
+		return &backend.KeyEnvelope{
+			AESKey: backend.HEXBytes(key[:]),
+		}, nil
+	}
+
+	b, err := keywrap.Wrap(kek, key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "wrap key error")
+	}
+
+	return &backend.KeyEnvelope{
+		KEKLabel: label,
+		AESKey:   backend.HEXBytes(b),
+	}, nil
+}
+
+// UnwrapKeyEnvelope returns the key from the given key-envelope. When the
+// envelope has a KEK label, the key is unwrapped using the KEK configured
+// for this label.
+func UnwrapKeyEnvelope(ke *backend.KeyEnvelope) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+
+	if ke == nil {
+		return key, errors.New("key-envelope must not be nil")
+	}
+
+	if ke.KEKLabel == "" {
+		if len(ke.AESKey) != len(key) {
+			return key, errors.New("unwrapped key must be 16 bytes")
+		}
+		copy(key[:], ke.AESKey)
+		return key, nil
+	}
+
+	kek, err := getKEK(ke.KEKLabel)
+	if err != nil {
+		return key, err
+	}
+	if kek == nil {
+		return key, errors.Errorf("unknown kek label: %s", ke.KEKLabel)
+	}
+
+	b, err := keywrap.Unwrap(kek, ke.AESKey[:])
+	if err != nil {
+		return key, errors.Wrap(err, "unwrap key error")
+	}
+	if len(b) != len(key) {
+		return key, errors.New("unwrapped key must be 16 bytes")
+	}
+	copy(key[:], b)
+
+	return key, nil
+}
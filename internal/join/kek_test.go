@@ -0,0 +1,78 @@
+package join
+
+import (
+	"testing"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKeyEnvelope(t *testing.T) {
+	Convey("Given a KEK configured for label 010203", t, func() {
+		config.C.JoinServer.KEK.Set = []config.KEK{
+			{Label: "010203", KEK: "000102030405060708090a0b0c0d0e0f"},
+		}
+		defer func() {
+			config.C.JoinServer.KEK.Set = nil
+		}()
+
+		key := lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+
+		Convey("Then newKeyEnvelope wraps the key for label 010203", func() {
+			ke, err := newKeyEnvelope("010203", key)
+			So(err, ShouldBeNil)
+			So(ke, ShouldResemble, &backend.KeyEnvelope{
+				KEKLabel: "010203",
+				AESKey:   backend.HEXBytes{0x1f, 0xa6, 0x8b, 0x0a, 0x81, 0x12, 0xb4, 0x47, 0xae, 0xf3, 0x4b, 0xd8, 0xfb, 0x5a, 0x7b, 0x82, 0x9d, 0x3e, 0x86, 0x23, 0x71, 0xd2, 0xcf, 0xe5},
+			})
+
+			Convey("Then UnwrapKeyEnvelope returns the key", func() {
+				k, err := UnwrapKeyEnvelope(ke)
+				So(err, ShouldBeNil)
+				So(k, ShouldEqual, key)
+			})
+		})
+
+		Convey("Then newKeyEnvelope does not wrap the key for an unknown label", func() {
+			ke, err := newKeyEnvelope("030201", key)
+			So(err, ShouldBeNil)
+			So(ke, ShouldResemble, &backend.KeyEnvelope{
+				AESKey: backend.HEXBytes(key[:]),
+			})
+
+			Convey("Then UnwrapKeyEnvelope returns the key", func() {
+				k, err := UnwrapKeyEnvelope(ke)
+				So(err, ShouldBeNil)
+				So(k, ShouldEqual, key)
+			})
+		})
+
+		Convey("Given a KEK is required", func() {
+			config.C.JoinServer.KEK.Require = true
+			defer func() {
+				config.C.JoinServer.KEK.Require = false
+			}()
+
+			Convey("Then newKeyEnvelope wraps the key for label 010203", func() {
+				ke, err := newKeyEnvelope("010203", key)
+				So(err, ShouldBeNil)
+				So(ke.KEKLabel, ShouldEqual, "010203")
+			})
+
+			Convey("Then newKeyEnvelope returns an error for an unknown label", func() {
+				_, err := newKeyEnvelope("030201", key)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("Then UnwrapKeyEnvelope returns an error for an unknown label", func() {
+			_, err := UnwrapKeyEnvelope(&backend.KeyEnvelope{
+				KEKLabel: "030201",
+				AESKey:   backend.HEXBytes{0x1f, 0xa6, 0x8b, 0x0a, 0x81, 0x12, 0xb4, 0x47, 0xae, 0xf3, 0x4b, 0xd8, 0xfb, 0x5a, 0x7b, 0x82, 0x9d, 0x3e, 0x86, 0x23, 0x71, 0xd2, 0xcf, 0xe5},
+			})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
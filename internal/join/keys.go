@@ -0,0 +1,131 @@
+package join
+
+import (
+	"crypto/aes"
+	"fmt"
+
+	"github.com/jacobsa/crypto/cmac"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/hsm"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+var hsmBackend hsm.Backend
+
+// SetHSM sets the HSM in which the device root-keys (AppKey and NwkKey) are
+// stored. When set, the root-keys stored in the database are not used and
+// all operations using these keys are performed by the HSM.
+func SetHSM(b hsm.Backend) {
+	hsmBackend = b
+}
+
+// RootKeysInHSM returns true when the device root-keys are stored in a HSM.
+func RootKeysInHSM() bool {
+	return hsmBackend != nil
+}
+
+// AppKeyLabel returns the HSM label of the AppKey of the given device.
+func AppKeyLabel(devEUI lorawan.EUI64) string {
+	return fmt.Sprintf("%s:app_key", devEUI)
+}
+
+// NwkKeyLabel returns the HSM label of the NwkKey of the given device.
+func NwkKeyLabel(devEUI lorawan.EUI64) string {
+	return fmt.Sprintf("%s:nwk_key", devEUI)
+}
+
+// GenAppKeyLabel returns the HSM label of the GenAppKey of the given device.
+func GenAppKeyLabel(devEUI lorawan.EUI64) string {
+	return fmt.Sprintf("%s:gen_app_key", devEUI)
+}
+
+// GetMcRootKey returns the McRootKey used by the Remote Multicast Setup
+// package. For LoRaWAN 1.1 devices it is derived from the AppKey, for
+// LoRaWAN 1.0.x devices from the GenAppKey.
+func GetMcRootKey(dk storage.DeviceKeys, macVersion string) (lorawan.AES128Key, error) {
+	if isLoRaWAN11(macVersion) {
+		if RootKeysInHSM() {
+			return encryptBlock(hsmKey{backend: hsmBackend, label: AppKeyLabel(dk.DevEUI)}, []byte{0x20})
+		}
+		return encryptBlock(memoryKey(dk.AppKey), []byte{0x20})
+	}
+
+	if RootKeysInHSM() {
+		return encryptBlock(hsmKey{backend: hsmBackend, label: GenAppKeyLabel(dk.DevEUI)}, nil)
+	}
+	if dk.GenAppKey == (lorawan.AES128Key{}) {
+		return lorawan.AES128Key{}, errors.New("GenAppKey is not set")
+	}
+	return encryptBlock(memoryKey(dk.GenAppKey), nil)
+}
+
+// rootKey implements the AES operations needed for the join. A root-key is
+// either kept in memory or is stored in the HSM.
+type rootKey interface {
+	encrypt(b []byte) ([]byte, error)
+	decrypt(b []byte) ([]byte, error)
+	cmac(b []byte) ([]byte, error)
+}
+
+// memoryKey implements rootKey for keys loaded from the database and for
+// keys derived by the join-server (e.g. the JSIntKey).
+type memoryKey lorawan.AES128Key
+
+func (k memoryKey) encrypt(b []byte) ([]byte, error) {
+	return k.ecb(b, true)
+}
+
+func (k memoryKey) decrypt(b []byte) ([]byte, error) {
+	return k.ecb(b, false)
+}
+
+func (k memoryKey) ecb(b []byte, encrypt bool) ([]byte, error) {
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new cipher error")
+	}
+	if len(b)%block.BlockSize() != 0 {
+		return nil, fmt.Errorf("data must be a multiple of %d bytes", block.BlockSize())
+	}
+
+	out := make([]byte, len(b))
+	for i := 0; i < len(b); i += block.BlockSize() {
+		if encrypt {
+			block.Encrypt(out[i:i+block.BlockSize()], b[i:i+block.BlockSize()])
+		} else {
+			block.Decrypt(out[i:i+block.BlockSize()], b[i:i+block.BlockSize()])
+		}
+	}
+	return out, nil
+}
+
+func (k memoryKey) cmac(b []byte) ([]byte, error) {
+	hash, err := cmac.New(k[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new cmac error")
+	}
+	if _, err := hash.Write(b); err != nil {
+		return nil, errors.Wrap(err, "write cmac error")
+	}
+	return hash.Sum([]byte{}), nil
+}
+
+// hsmKey implements rootKey for keys stored in the HSM.
+type hsmKey struct {
+	backend hsm.Backend
+	label   string
+}
+
+func (k hsmKey) encrypt(b []byte) ([]byte, error) {
+	return k.backend.Encrypt(k.label, b)
+}
+
+func (k hsmKey) decrypt(b []byte) ([]byte, error) {
+	return k.backend.Decrypt(k.label, b)
+}
+
+func (k hsmKey) cmac(b []byte) ([]byte, error) {
+	return k.backend.CMAC(k.label, b)
+}
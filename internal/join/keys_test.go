@@ -0,0 +1,142 @@
+package join
+
+import (
+	"testing"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/multicastsetup"
+	"github.com/brocaar/lora-app-server/internal/hsm"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testHSM implements the hsm.Backend interface using in-memory keys.
+type testHSM map[string]lorawan.AES128Key
+
+func (h testHSM) getKey(label string) (memoryKey, error) {
+	key, ok := h[label]
+	if !ok {
+		return memoryKey{}, hsm.ErrKeyNotFound
+	}
+	return memoryKey(key), nil
+}
+
+func (h testHSM) Encrypt(label string, data []byte) ([]byte, error) {
+	key, err := h.getKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return key.encrypt(data)
+}
+
+func (h testHSM) Decrypt(label string, data []byte) ([]byte, error) {
+	key, err := h.getKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return key.decrypt(data)
+}
+
+func (h testHSM) CMAC(label string, data []byte) ([]byte, error) {
+	key, err := h.getKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return key.cmac(data)
+}
+
+func TestRootKeys(t *testing.T) {
+	Convey("Given a memory key", t, func() {
+		key := memoryKey{0x2b, 0x7e, 0x15, 0x16, 0x28, 0xae, 0xd2, 0xa6, 0xab, 0xf7, 0x15, 0x88, 0x09, 0xcf, 0x4f, 0x3c}
+		block := []byte{0x6b, 0xc1, 0xbe, 0xe2, 0x2e, 0x40, 0x9f, 0x96, 0xe9, 0x3d, 0x7e, 0x11, 0x73, 0x93, 0x17, 0x2a}
+
+		Convey("Then encrypt returns the expected ciphertext (FIPS-197 / SP 800-38A)", func() {
+			b, err := key.encrypt(block)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{0x3a, 0xd7, 0x7b, 0xb4, 0x0d, 0x7a, 0x36, 0x60, 0xa8, 0x9e, 0xca, 0xf3, 0x24, 0x66, 0xef, 0x97})
+
+			Convey("Then decrypt returns the plaintext", func() {
+				b, err := key.decrypt(b)
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, block)
+			})
+		})
+
+		Convey("Then cmac returns the expected MAC (RFC 4493)", func() {
+			b, err := key.cmac(block)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{0x07, 0x0a, 0x16, 0xb4, 0x6b, 0x4d, 0x41, 0x44, 0xf7, 0x9b, 0xdd, 0x9d, 0xd0, 0x4a, 0x28, 0x7c})
+		})
+
+		Convey("Then encrypt returns an error for an invalid length", func() {
+			_, err := key.encrypt([]byte{1, 2, 3})
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a set of device-keys", t, func() {
+		dk := storage.DeviceKeys{
+			DevEUI:    lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppKey:    lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			GenAppKey: lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+		}
+
+		Convey("Then the McRootKey of a LoRaWAN 1.0.x device is derived from the GenAppKey", func() {
+			expected, err := multicastsetup.GetMcRootKeyForGenAppKey(dk.GenAppKey)
+			So(err, ShouldBeNil)
+
+			key, err := GetMcRootKey(dk, "1.0.2")
+			So(err, ShouldBeNil)
+			So(key, ShouldEqual, expected)
+		})
+
+		Convey("Then the McRootKey of a LoRaWAN 1.1 device is derived from the AppKey", func() {
+			expected, err := multicastsetup.GetMcRootKeyForAppKey(dk.AppKey)
+			So(err, ShouldBeNil)
+
+			key, err := GetMcRootKey(dk, "1.1.0")
+			So(err, ShouldBeNil)
+			So(key, ShouldEqual, expected)
+		})
+
+		Convey("Then GetMcRootKey returns an error for a LoRaWAN 1.0.x device without GenAppKey", func() {
+			dk.GenAppKey = lorawan.AES128Key{}
+			_, err := GetMcRootKey(dk, "1.0.2")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a key stored in the HSM", t, func() {
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		appKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+		backend := testHSM{AppKeyLabel(devEUI): appKey}
+
+		Convey("Then the session-keys equal the session-keys derived in memory", func() {
+			hsmSKey, err := getAppSKey(hsmKey{backend: backend, label: AppKeyLabel(devEUI)}, lorawan.NetID{1, 2, 3}, 1, 258)
+			So(err, ShouldBeNil)
+
+			memSKey, err := getAppSKey(memoryKey(appKey), lorawan.NetID{1, 2, 3}, 1, 258)
+			So(err, ShouldBeNil)
+
+			So(hsmSKey, ShouldEqual, memSKey)
+		})
+
+		Convey("Then the McRootKey equals the McRootKey derived in memory", func() {
+			dk := storage.DeviceKeys{DevEUI: devEUI, AppKey: appKey}
+			mcRootKey, err := GetMcRootKey(dk, "1.1.0")
+			So(err, ShouldBeNil)
+
+			SetHSM(backend)
+			defer SetHSM(nil)
+
+			hsmMcRootKey, err := GetMcRootKey(storage.DeviceKeys{DevEUI: devEUI}, "1.1.0")
+			So(err, ShouldBeNil)
+			So(hsmMcRootKey, ShouldEqual, mcRootKey)
+		})
+
+		Convey("Then using an unknown key returns an error", func() {
+			_, err := getAppSKey(hsmKey{backend: backend, label: NwkKeyLabel(devEUI)}, lorawan.NetID{1, 2, 3}, 1, 258)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package join
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// marshalJoinAccept returns the encrypted join-accept PHYPayload. The MIC is
+// computed using the micKey, the payload and MIC are encrypted using the
+// encKey. When the OptNeg bit is set (LoRaWAN 1.1), the MIC is computed over
+// JoinReqType | JoinEUI | DevNonce | MHDR | join-accept payload, else over
+// MHDR | join-accept payload.
+func marshalJoinAccept(ja lorawan.JoinAcceptPayload, joinReqType lorawan.JoinType, joinEUI lorawan.EUI64, devNonce lorawan.DevNonce, micKey, encKey rootKey) ([]byte, error) {
+	mhdr := lorawan.MHDR{
+		MType: lorawan.JoinAccept,
+		Major: lorawan.LoRaWANR1,
+	}
+	mhdrB, err := mhdr.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal mhdr error")
+	}
+
+	pl, err := ja.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal join-accept payload error")
+	}
+
+	var micB []byte
+	if ja.DLSettings.OptNeg {
+		devNonceB, err := devNonce.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal dev-nonce error")
+		}
+
+		micB = append(micB, byte(joinReqType))
+		micB = append(micB, reverse(joinEUI[:])...)
+		micB = append(micB, devNonceB...)
+	}
+	micB = append(micB, mhdrB...)
+	micB = append(micB, pl...)
+
+	mic, err := micKey.cmac(micB)
+	if err != nil {
+		return nil, errors.Wrap(err, "calculate mic error")
+	}
+	pl = append(pl, mic[0:4]...)
+
+	// the join-accept is encrypted using the aes decrypt operation, so that
+	// the device only needs to implement the encrypt operation
+	pl, err = encKey.decrypt(pl)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt join-accept error")
+	}
+
+	return append(mhdrB, pl...), nil
+}
+
+// getSKey11 returns a LoRaWAN 1.1 session key:
+// aes128_encrypt(key, typ | JoinNonce | JoinEUI | DevNonce | pad16)
+func getSKey11(typ byte, key rootKey, joinNonce lorawan.JoinNonce, joinEUI lorawan.EUI64, devNonce lorawan.DevNonce) (lorawan.AES128Key, error) {
+	joinNonceB, err := joinNonce.MarshalBinary()
+	if err != nil {
+		return lorawan.AES128Key{}, errors.Wrap(err, "marshal join-nonce error")
+	}
+	devNonceB, err := devNonce.MarshalBinary()
+	if err != nil {
+		return lorawan.AES128Key{}, errors.Wrap(err, "marshal dev-nonce error")
+	}
+
+	b := []byte{typ}
+	b = append(b, joinNonceB...)
+	b = append(b, reverse(joinEUI[:])...)
+	b = append(b, devNonceB...)
+
+	return encryptBlock(key, b)
+}
+
+// getJSIntKey returns the JSIntKey: aes128_encrypt(NwkKey, 0x06 | DevEUI | pad16)
+func getJSIntKey(nwkKey rootKey, devEUI lorawan.EUI64) (lorawan.AES128Key, error) {
+	return encryptBlock(nwkKey, append([]byte{0x06}, reverse(devEUI[:])...))
+}
+
+// getJSEncKey returns the JSEncKey: aes128_encrypt(NwkKey, 0x05 | DevEUI | pad16)
+func getJSEncKey(nwkKey rootKey, devEUI lorawan.EUI64) (lorawan.AES128Key, error) {
+	return encryptBlock(nwkKey, append([]byte{0x05}, reverse(devEUI[:])...))
+}
+
+// encryptBlock encrypts the given bytes, padded to 16 bytes, using the
+// given key.
+func encryptBlock(key rootKey, b []byte) (lorawan.AES128Key, error) {
+	var out lorawan.AES128Key
+
+	if len(b) > len(out) {
+		return out, fmt.Errorf("max %d bytes expected", len(out))
+	}
+
+	in := make([]byte, len(out))
+	copy(in, b)
+
+	enc, err := key.encrypt(in)
+	if err != nil {
+		return out, errors.Wrap(err, "encrypt error")
+	}
+	if len(enc) != len(out) {
+		return out, fmt.Errorf("%d bytes expected", len(out))
+	}
+	copy(out[:], enc)
+
+	return out, nil
+}
+
+// reverse returns a copy of the given bytes in reverse order (big-endian to
+// little-endian and vice versa).
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
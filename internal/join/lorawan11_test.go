@@ -0,0 +1,390 @@
+package join
+
+import (
+	"testing"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testRejoinRequest returns a rejoin-request type 1 PHYPayload, with the
+// MIC computed using the given key.
+func testRejoinRequest(joinEUI, devEUI lorawan.EUI64, rjCount uint16, key lorawan.AES128Key) []byte {
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.RejoinRequest,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.RejoinRequestType1Payload{
+			RejoinType: lorawan.RejoinRequestType1,
+			JoinEUI:    joinEUI,
+			DevEUI:     devEUI,
+			RJCount1:   rjCount,
+		},
+	}
+	if err := phy.SetUplinkJoinMIC(key); err != nil {
+		panic(err)
+	}
+	b, err := phy.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// testRejoinRequestType0 returns a rejoin-request type 0 PHYPayload, with
+// the MIC computed using the given key.
+func testRejoinRequestType0(netID lorawan.NetID, devEUI lorawan.EUI64, rjCount uint16, key lorawan.AES128Key) []byte {
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.RejoinRequest,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.RejoinRequestType02Payload{
+			RejoinType: lorawan.RejoinRequestType0,
+			NetID:      netID,
+			DevEUI:     devEUI,
+			RJCount0:   rjCount,
+		},
+	}
+	if err := phy.SetUplinkJoinMIC(key); err != nil {
+		panic(err)
+	}
+	b, err := phy.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// decryptJoinAccept decrypts the given join-accept PHYPayload.
+func decryptJoinAccept(b []byte, key lorawan.AES128Key) (lorawan.PHYPayload, *lorawan.JoinAcceptPayload) {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(b); err != nil {
+		panic(err)
+	}
+	if err := phy.DecryptJoinAcceptPayload(key); err != nil {
+		panic(err)
+	}
+	return phy, phy.MACPayload.(*lorawan.JoinAcceptPayload)
+}
+
+func TestMarshalJoinAccept(t *testing.T) {
+	Convey("Given a join-accept payload and a set of keys", t, func() {
+		ja := lorawan.JoinAcceptPayload{
+			JoinNonce: 1,
+			HomeNetID: lorawan.NetID{4, 5, 6},
+			DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+		}
+		joinEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+		devNonce := lorawan.DevNonce(258)
+		jsIntKey := lorawan.AES128Key{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+		key := lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}
+
+		Convey("Then a LoRaWAN 1.0 join-accept is encrypted and has a valid MIC", func() {
+			b, err := marshalJoinAccept(ja, lorawan.JoinRequestType, joinEUI, devNonce, memoryKey(key), memoryKey(key))
+			So(err, ShouldBeNil)
+
+			phy, pl := decryptJoinAccept(b, key)
+			So(*pl, ShouldResemble, ja)
+
+			ok, err := phy.ValidateDownlinkJoinMIC(lorawan.JoinRequestType, joinEUI, devNonce, key)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then a LoRaWAN 1.1 join-accept is encrypted with the OptNeg bit set and a valid MIC", func() {
+			ja.DLSettings.OptNeg = true
+
+			b, err := marshalJoinAccept(ja, lorawan.JoinRequestType, joinEUI, devNonce, memoryKey(jsIntKey), memoryKey(key))
+			So(err, ShouldBeNil)
+
+			phy, pl := decryptJoinAccept(b, key)
+			So(pl.DLSettings.OptNeg, ShouldBeTrue)
+
+			ok, err := phy.ValidateDownlinkJoinMIC(lorawan.JoinRequestType, joinEUI, devNonce, jsIntKey)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+	})
+}
+
+func TestJoinLoRaWAN11(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := storage.NewRedisPool(conf.RedisURL)
+
+	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = p
+
+	Convey("Given a clean database with a LoRaWAN 1.1 device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+		test.MustFlushRedis(p)
+
+		nsClient := test.NewNetworkServerClient()
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+		config.C.ApplicationServer.Integration.Handler = testhandler.NewTestHandler()
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+			NetID:  []byte{1, 2, 3},
+		}
+		So(storage.CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			Name:            "test-sp",
+		}
+		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			Name:            "test-dp",
+		}
+		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(storage.CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		d := storage.Device{
+			ApplicationID:   app.ID,
+			Name:            "test-device",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(storage.CreateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
+
+		dk := storage.DeviceKeys{
+			DevEUI: d.DevEUI,
+			AppKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			NwkKey: lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+		}
+		So(storage.CreateDeviceKeys(config.C.PostgreSQL.DB, &dk), ShouldBeNil)
+
+		joinEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+		jsIntKey, err := getJSIntKey(memoryKey(dk.NwkKey), d.DevEUI)
+		So(err, ShouldBeNil)
+		jsEncKey, err := getJSEncKey(memoryKey(dk.NwkKey), d.DevEUI)
+		So(err, ShouldBeNil)
+
+		jrPHY := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinRequest,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinRequestPayload{
+				DevEUI:   d.DevEUI,
+				JoinEUI:  joinEUI,
+				DevNonce: 258,
+			},
+		}
+		So(jrPHY.SetUplinkJoinMIC(dk.NwkKey), ShouldBeNil)
+		jrPHYBytes, err := jrPHY.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		basePayload := backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        "010203",
+			ReceiverID:      "0807060504030201",
+			TransactionID:   1234,
+			MessageType:     backend.JoinReq,
+		}
+
+		Convey("When handling a join-request signed with the AppKey", func() {
+			So(jrPHY.SetUplinkJoinMIC(dk.AppKey), ShouldBeNil)
+			b, err := jrPHY.MarshalBinary()
+			So(err, ShouldBeNil)
+
+			ans := HandleJoinRequest(backend.JoinReqPayload{
+				BasePayload: basePayload,
+				MACVersion:  "1.1.0",
+				PHYPayload:  backend.HEXBytes(b),
+				DevEUI:      d.DevEUI,
+				DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			}, "", nil)
+
+			Convey("Then the MIC is rejected", func() {
+				So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+			})
+		})
+
+		Convey("When handling a join-request", func() {
+			ans := HandleJoinRequest(backend.JoinReqPayload{
+				BasePayload: basePayload,
+				MACVersion:  "1.1.0",
+				PHYPayload:  backend.HEXBytes(jrPHYBytes),
+				DevEUI:      d.DevEUI,
+				DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			}, "", nil)
+
+			Convey("Then the network session keys are returned", func() {
+				So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				So(ans.MessageType, ShouldEqual, backend.JoinAns)
+				So(ans.FNwkSIntKey, ShouldNotBeNil)
+				So(ans.SNwkSIntKey, ShouldNotBeNil)
+				So(ans.NwkSEncKey, ShouldNotBeNil)
+				So(ans.FNwkSIntKey.AESKey, ShouldNotResemble, ans.SNwkSIntKey.AESKey)
+				So(ans.SNwkSIntKey.AESKey, ShouldNotResemble, ans.NwkSEncKey.AESKey)
+			})
+
+			Convey("Then the join-accept is encrypted using the NwkKey and has the OptNeg bit set", func() {
+				_, pl := decryptJoinAccept(ans.PHYPayload[:], dk.NwkKey)
+				So(pl.DLSettings.OptNeg, ShouldBeTrue)
+			})
+
+			Convey("Then the JoinEUI is stored", func() {
+				keys, err := storage.GetDeviceKeys(config.C.PostgreSQL.DB, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(keys.JoinEUI, ShouldEqual, joinEUI)
+			})
+
+			Convey("When handling a rejoin-request type 1", func() {
+				rjBasePayload := basePayload
+				rjBasePayload.MessageType = backend.RejoinReq
+
+				rjPL := backend.JoinReqPayload{
+					BasePayload: rjBasePayload,
+					MACVersion:  "1.1.0",
+					PHYPayload:  backend.HEXBytes(testRejoinRequest(joinEUI, d.DevEUI, 5, jsIntKey)),
+					DevEUI:      d.DevEUI,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+				}
+				ans := HandleRejoinRequest(rjPL, "", nil)
+
+				Convey("Then a rejoin-answer encrypted using the JSEncKey is returned", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					So(ans.MessageType, ShouldEqual, backend.RejoinAns)
+
+					phy, pl := decryptJoinAccept(ans.PHYPayload[:], jsEncKey)
+					So(pl.DLSettings.OptNeg, ShouldBeTrue)
+
+					ok, err := phy.ValidateDownlinkJoinMIC(lorawan.RejoinRequestType1, joinEUI, 5, jsIntKey)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+				})
+
+				Convey("Then the RJcount1 is stored", func() {
+					keys, err := storage.GetDeviceKeys(config.C.PostgreSQL.DB, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(keys.RJCount1, ShouldEqual, 6)
+				})
+
+				Convey("Then the same rejoin-request is rejected", func() {
+					ans := HandleRejoinRequest(rjPL, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.JoinReqFailed)
+				})
+			})
+
+			Convey("Then the SNwkSIntKey is stored with the device-activation", func() {
+				da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(da.SNwkSIntKey, ShouldNotBeNil)
+				So(da.SNwkSIntKey[:], ShouldResemble, ans.SNwkSIntKey.AESKey[:])
+			})
+
+			Convey("When handling a rejoin-request type 0", func() {
+				da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+				So(err, ShouldBeNil)
+
+				rjBasePayload := basePayload
+				rjBasePayload.MessageType = backend.RejoinReq
+
+				rjPL := backend.JoinReqPayload{
+					BasePayload: rjBasePayload,
+					MACVersion:  "1.1.0",
+					PHYPayload:  backend.HEXBytes(testRejoinRequestType0(lorawan.NetID{1, 2, 3}, d.DevEUI, 3, *da.SNwkSIntKey)),
+					DevEUI:      d.DevEUI,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+				}
+				ans := HandleRejoinRequest(rjPL, "", nil)
+
+				Convey("Then a rejoin-answer is returned", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					So(ans.MessageType, ShouldEqual, backend.RejoinAns)
+				})
+
+				Convey("Then the RJcount0 is stored with the device-activation", func() {
+					da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(da.RJCount0, ShouldEqual, 4)
+				})
+
+				Convey("Then the same rejoin-request is rejected", func() {
+					ans := HandleRejoinRequest(rjPL, "", nil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.JoinReqFailed)
+				})
+			})
+
+			Convey("When handling a rejoin-request type 0 with an invalid MIC", func() {
+				rjBasePayload := basePayload
+				rjBasePayload.MessageType = backend.RejoinReq
+
+				ans := HandleRejoinRequest(backend.JoinReqPayload{
+					BasePayload: rjBasePayload,
+					MACVersion:  "1.1.0",
+					PHYPayload:  backend.HEXBytes(testRejoinRequestType0(lorawan.NetID{1, 2, 3}, d.DevEUI, 3, dk.NwkKey)),
+					DevEUI:      d.DevEUI,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+				}, "", nil)
+
+				Convey("Then the MIC is rejected", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+				})
+			})
+
+			Convey("Given the network-server has no NetID or client certificate configured", func() {
+				n.NetID = nil
+				So(storage.UpdateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+				Convey("When handling a rejoin-request type 1", func() {
+					ans := HandleRejoinRequest(backend.JoinReqPayload{
+						BasePayload: basePayload,
+						MACVersion:  "1.1.0",
+						PHYPayload:  backend.HEXBytes(testRejoinRequest(joinEUI, d.DevEUI, 5, jsIntKey)),
+						DevEUI:      d.DevEUI,
+						DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+					}, "", nil)
+
+					Convey("Then the sender is rejected", func() {
+						So(ans.Result.ResultCode, ShouldEqual, backend.UnknownSender)
+					})
+				})
+			})
+
+			Convey("When handling a rejoin-request type 1 with an invalid MIC", func() {
+				ans := HandleRejoinRequest(backend.JoinReqPayload{
+					BasePayload: basePayload,
+					MACVersion:  "1.1.0",
+					PHYPayload:  backend.HEXBytes(testRejoinRequest(joinEUI, d.DevEUI, 5, dk.NwkKey)),
+					DevEUI:      d.DevEUI,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+				}, "", nil)
+
+				Convey("Then the MIC is rejected", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,267 @@
+package join
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	devEUIRateKeyTempl       = "lora:as:js:device:%s:rate"
+	senderIDRateKeyTempl     = "lora:as:js:sender:%s:rate"
+	micFailuresKeyTempl      = "lora:as:js:device:%s:mic_failures"
+	blockedKeyTempl          = "lora:as:js:device:%s:blocked"
+	throttledKeyTempl        = "lora:as:js:application:%d:throttled"
+	throttledReasonsKeyTempl = "lora:as:js:application:%d:throttled:reasons"
+)
+
+// throttledRetention defines how long a throttled device is listed.
+const throttledRetention = 24 * time.Hour
+
+// Throttle reasons.
+const (
+	ThrottleRateLimit   = "RATE_LIMIT"
+	ThrottleMICFailures = "MIC_FAILURES"
+)
+
+// ThrottledDevice defines a device for which join-requests were throttled.
+type ThrottledDevice struct {
+	DevEUI      lorawan.EUI64
+	Reason      string
+	ThrottledAt time.Time
+}
+
+// checkRateLimit rejects the join-request when the device has been blocked
+// or when the number of join-requests for the DevEUI exceeds the configured
+// limit. As this is validated before any database read, a misbehaving
+// device can't exhaust the join-server.
+func checkRateLimit(ctx *context) error {
+	conf := config.C.JoinServer.RateLimit
+	devEUI := ctx.joinReqPayload.DevEUI
+
+	if conf.MICFailureMax > 0 && conf.BlockDuration > 0 {
+		blocked, err := isBlocked(devEUI)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrDeviceBlocked
+		}
+	}
+
+	if conf.DevEUIMax > 0 {
+		n, err := storage.IncrWindowCounter(config.C.Redis.Pool, fmt.Sprintf(devEUIRateKeyTempl, devEUI), conf.DevEUIWindow)
+		if err != nil {
+			return err
+		}
+		if n > conf.DevEUIMax {
+			// only the first throttled join-request within the window is
+			// recorded, to avoid a database read for every request
+			if n == conf.DevEUIMax+1 {
+				log.WithField("dev_eui", devEUI).Warning("js: device join-request rate limit exceeded")
+
+				if d, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI); err == nil {
+					if err := recordThrottledDevice(d.ApplicationID, devEUI, ThrottleRateLimit); err != nil {
+						log.WithError(err).Error("js: record throttled device error")
+					}
+				}
+			}
+			return errors.Wrap(ErrRateLimited, "dev-eui")
+		}
+	}
+
+	return nil
+}
+
+// checkSenderRateLimit rejects the join-request when the number of
+// join-requests for the SenderID exceeds the configured limit. This is
+// validated after validateNetworkServer, so that a sender using the
+// SenderID of an other network-server can't exhaust its limit.
+func checkSenderRateLimit(ctx *context) error {
+	conf := config.C.JoinServer.RateLimit
+	if conf.SenderIDMax <= 0 {
+		return nil
+	}
+
+	n, err := storage.IncrWindowCounter(config.C.Redis.Pool, fmt.Sprintf(senderIDRateKeyTempl, ctx.joinReqPayload.SenderID), conf.SenderIDWindow)
+	if err != nil {
+		return err
+	}
+	if n > conf.SenderIDMax {
+		if n == conf.SenderIDMax+1 {
+			log.WithField("sender_id", ctx.joinReqPayload.SenderID).Warning("js: sender-id join-request rate limit exceeded")
+		}
+		return errors.Wrap(ErrRateLimited, "sender-id")
+	}
+
+	return nil
+}
+
+// handleMICFailure records the MIC failure of the join-request. When the
+// number of MIC failures within the configured window reaches the limit,
+// the device is blocked and an error notification is sent.
+func handleMICFailure(ctx *context) error {
+	conf := config.C.JoinServer.RateLimit
+	if conf.MICFailureMax <= 0 {
+		return nil
+	}
+
+	n, err := storage.IncrWindowCounter(config.C.Redis.Pool, fmt.Sprintf(micFailuresKeyTempl, ctx.device.DevEUI), conf.MICFailureWindow)
+	if err != nil {
+		return err
+	}
+	if n != conf.MICFailureMax {
+		return nil
+	}
+
+	if conf.BlockDuration > 0 {
+		if err := blockDevice(ctx.device.DevEUI, conf.BlockDuration); err != nil {
+			return err
+		}
+	}
+
+	if err := recordThrottledDevice(ctx.application.ID, ctx.device.DevEUI, ThrottleMICFailures); err != nil {
+		return err
+	}
+
+	errNotification := handler.ErrorNotification{
+		ApplicationID:   ctx.application.ID,
+		ApplicationName: ctx.application.Name,
+		DeviceName:      ctx.device.Name,
+		DevEUI:          ctx.device.DevEUI,
+		Type:            "JOIN_MIC",
+		Error:           fmt.Sprintf("%d join-requests with invalid mic, device blocked for %s", n, conf.BlockDuration),
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":      ctx.device.DevEUI,
+		"mic_failures": n,
+	}).Warning("js: device blocked because of repeated mic failures")
+
+	if err := eventlog.LogEventForDevice(ctx.device.DevEUI, eventlog.EventLog{
+		Type:    eventlog.Error,
+		Payload: errNotification,
+	}); err != nil {
+		log.WithError(err).Error("log event for device error")
+	}
+
+	if err := config.C.ApplicationServer.Integration.Handler.SendErrorNotification(errNotification); err != nil {
+		log.WithError(err).Error("send error notification to handler error")
+	}
+
+	return nil
+}
+
+// ListThrottledDevices returns the devices of the given application for
+// which join-requests were throttled within the last 24 hours, most recent
+// first.
+func ListThrottledDevices(applicationID int64) ([]ThrottledDevice, error) {
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(throttledKeyTempl, applicationID)
+	reasonsKey := fmt.Sprintf(throttledReasonsKeyTempl, applicationID)
+	minScore := time.Now().Add(-throttledRetention).UnixNano() / int64(time.Millisecond)
+
+	if _, err := c.Do("ZREMRANGEBYSCORE", key, "-inf", fmt.Sprintf("(%d", minScore)); err != nil {
+		return nil, errors.Wrap(err, "remove expired throttled devices error")
+	}
+
+	// the reasons are read before the devices, as recordThrottledDevice
+	// adds the device before its reason
+	reasons, err := redis.StringMap(c.Do("HGETALL", reasonsKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get throttle reasons error")
+	}
+
+	values, err := redis.Strings(c.Do("ZREVRANGE", key, 0, -1, "WITHSCORES"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get throttled devices error")
+	}
+
+	var out []ThrottledDevice
+	for i := 0; i+1 < len(values); i += 2 {
+		var td ThrottledDevice
+		if err := td.DevEUI.UnmarshalText([]byte(values[i])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal dev_eui error")
+		}
+
+		var ms int64
+		if _, err := fmt.Sscan(values[i+1], &ms); err != nil {
+			return nil, errors.Wrap(err, "parse score error")
+		}
+		td.ThrottledAt = time.Unix(0, ms*int64(time.Millisecond))
+
+		td.Reason = reasons[values[i]]
+		delete(reasons, values[i])
+
+		out = append(out, td)
+	}
+
+	// remove the reasons of the devices which are no longer listed
+	if len(reasons) != 0 {
+		args := redis.Args{}.Add(reasonsKey)
+		for devEUI := range reasons {
+			args = args.Add(devEUI)
+		}
+		if _, err := c.Do("HDEL", args...); err != nil {
+			return nil, errors.Wrap(err, "remove expired throttle reasons error")
+		}
+	}
+
+	return out, nil
+}
+
+func isBlocked(devEUI lorawan.EUI64) (bool, error) {
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	blocked, err := redis.Bool(c.Do("EXISTS", fmt.Sprintf(blockedKeyTempl, devEUI)))
+	if err != nil {
+		return false, errors.Wrap(err, "get blocked error")
+	}
+	return blocked, nil
+}
+
+func blockDevice(devEUI lorawan.EUI64, d time.Duration) error {
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	if _, err := c.Do("SET", fmt.Sprintf(blockedKeyTempl, devEUI), "1", "PX", int64(d/time.Millisecond)); err != nil {
+		return errors.Wrap(err, "block device error")
+	}
+	return nil
+}
+
+func recordThrottledDevice(applicationID int64, devEUI lorawan.EUI64, reason string) error {
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(throttledKeyTempl, applicationID)
+	reasonsKey := fmt.Sprintf(throttledReasonsKeyTempl, applicationID)
+	ms := time.Now().UnixNano() / int64(time.Millisecond)
+
+	if _, err := c.Do("ZADD", key, ms, devEUI.String()); err != nil {
+		return errors.Wrap(err, "add throttled device error")
+	}
+	if _, err := c.Do("HSET", reasonsKey, devEUI.String(), reason); err != nil {
+		return errors.Wrap(err, "set throttle reason error")
+	}
+
+	for _, k := range []string{key, reasonsKey} {
+		if _, err := c.Do("PEXPIRE", k, int64(throttledRetention/time.Millisecond)); err != nil {
+			return errors.Wrap(err, "set throttled devices expire error")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Package keywrap implements the AES key wrap algorithm as specified by
+// RFC 3394. It is used to protect session keys exchanged over the LoRaWAN
+// backend interfaces.
+package keywrap
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// defaultIV is the default initial value (RFC 3394, section 2.2.3.1).
+var defaultIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+// ErrIntegrityCheck is returned when the unwrapped key fails the integrity
+// check, e.g. because the wrong key-encryption key was used.
+var ErrIntegrityCheck = errors.New("keywrap: integrity check failed")
+
+// Wrap wraps the given plaintext key using the given key-encryption key
+// (KEK). The KEK must be 16, 24 or 32 bytes, the plaintext a multiple of 8
+// bytes and at least 16 bytes. The returned ciphertext is 8 bytes longer than
+// the plaintext.
+func Wrap(kek, plaintext []byte) ([]byte, error) {
+	if len(plaintext) < 16 || len(plaintext)%8 != 0 {
+		return nil, errors.New("keywrap: plaintext must be a multiple of 8 bytes and at least 16 bytes")
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errors.Wrap(err, "keywrap: new cipher error")
+	}
+
+	n := len(plaintext) / 8
+	r := make([]byte, len(plaintext))
+	copy(r, plaintext)
+
+	a := make([]byte, 8)
+	copy(a, defaultIV)
+
+	b := make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 0; i < n; i++ {
+			copy(b[:8], a)
+			copy(b[8:], r[i*8:i*8+8])
+			block.Encrypt(b, b)
+
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(a, binary.BigEndian.Uint64(b[:8])^t)
+			copy(r[i*8:i*8+8], b[8:])
+		}
+	}
+
+	return append(a, r...), nil
+}
+
+// Unwrap unwraps the given ciphertext using the given key-encryption key
+// (KEK) and returns the plaintext key.
+func Unwrap(kek, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 24 || len(ciphertext)%8 != 0 {
+		return nil, errors.New("keywrap: ciphertext must be a multiple of 8 bytes and at least 24 bytes")
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errors.Wrap(err, "keywrap: new cipher error")
+	}
+
+	n := len(ciphertext)/8 - 1
+	r := make([]byte, n*8)
+	copy(r, ciphertext[8:])
+
+	a := make([]byte, 8)
+	copy(a, ciphertext[:8])
+
+	b := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n - 1; i >= 0; i-- {
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(b[:8], binary.BigEndian.Uint64(a)^t)
+			copy(b[8:], r[i*8:i*8+8])
+			block.Decrypt(b, b)
+
+			copy(a, b[:8])
+			copy(r[i*8:i*8+8], b[8:])
+		}
+	}
+
+	if subtle.ConstantTimeCompare(a, defaultIV) != 1 {
+		return nil, ErrIntegrityCheck
+	}
+
+	return r, nil
+}
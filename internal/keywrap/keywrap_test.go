@@ -0,0 +1,86 @@
+package keywrap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKeyWrap(t *testing.T) {
+	Convey("Given the RFC 3394 test vectors", t, func() {
+		tests := []struct {
+			Name       string
+			KEK        string
+			Plaintext  string
+			Ciphertext string
+		}{
+			{
+				Name:       "128 bits key data with a 128-bit KEK",
+				KEK:        "000102030405060708090a0b0c0d0e0f",
+				Plaintext:  "00112233445566778899aabbccddeeff",
+				Ciphertext: "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5",
+			},
+			{
+				Name:       "128 bits key data with a 192-bit KEK",
+				KEK:        "000102030405060708090a0b0c0d0e0f1011121314151617",
+				Plaintext:  "00112233445566778899aabbccddeeff",
+				Ciphertext: "96778b25ae6ca435f92b5b97c050aed2468ab8a17ad84e5d",
+			},
+			{
+				Name:       "128 bits key data with a 256-bit KEK",
+				KEK:        "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				Plaintext:  "00112233445566778899aabbccddeeff",
+				Ciphertext: "64e8c3f9ce0f5ba263e9777905818a2a93c8191e7d6e8ae7",
+			},
+			{
+				Name:       "256 bits key data with a 256-bit KEK",
+				KEK:        "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				Plaintext:  "00112233445566778899aabbccddeeff000102030405060708090a0b0c0d0e0f",
+				Ciphertext: "28c9f404c4b810f4cbccb35cfb87f8263f5786e2d80ed326cbc7f0e71a99f43bfb988b9b7a02dd21",
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				kek, _ := hex.DecodeString(test.KEK)
+				plaintext, _ := hex.DecodeString(test.Plaintext)
+
+				Convey("Then Wrap returns the expected ciphertext", func() {
+					b, err := Wrap(kek, plaintext)
+					So(err, ShouldBeNil)
+					So(hex.EncodeToString(b), ShouldEqual, test.Ciphertext)
+				})
+
+				Convey("Then Unwrap returns the plaintext", func() {
+					ciphertext, _ := hex.DecodeString(test.Ciphertext)
+					b, err := Unwrap(kek, ciphertext)
+					So(err, ShouldBeNil)
+					So(hex.EncodeToString(b), ShouldEqual, test.Plaintext)
+				})
+			})
+		}
+	})
+
+	Convey("Given a wrapped key", t, func() {
+		kek, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+		ciphertext, _ := hex.DecodeString("1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5")
+
+		Convey("Then Unwrap using an other KEK fails the integrity check", func() {
+			otherKEK, _ := hex.DecodeString("0f0e0d0c0b0a09080706050403020100")
+			_, err := Unwrap(otherKEK, ciphertext)
+			So(err, ShouldEqual, ErrIntegrityCheck)
+		})
+
+		Convey("Then Unwrap of an invalid ciphertext length returns an error", func() {
+			_, err := Unwrap(kek, ciphertext[:16])
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then Wrap of an invalid plaintext length returns an error", func() {
+			_, err := Wrap(kek, []byte{1, 2, 3})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,432 @@
+// Package multicast implements the enqueueing of multicast-group downlinks
+// and the provisioning of the multicast-group members using the LoRaWAN
+// Remote Multicast Setup application-layer package.
+package multicast
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/multicastsetup"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/downlink"
+	"github.com/brocaar/lora-app-server/internal/gps"
+	"github.com/brocaar/lora-app-server/internal/join"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+)
+
+// maxMcFCnt defines the MaxMcFCnt sent to the devices on setup.
+const maxMcFCnt = 0xffffffff
+
+// Enqueue encrypts the given payload once, using the McAppSKey of the
+// multicast-group, and enqueues it at the network-server for the next
+// frame-counter of the group. The MIC is computed by the network-server
+// using the McNwkSKey provisioned when creating the multicast-group. It
+// returns the frame-counter used for the payload.
+func Enqueue(db sqlx.Ext, id int64, fPort uint8, data []byte) (uint32, error) {
+	if fPort == 0 || fPort == multicastsetup.DefaultFPort {
+		return 0, errors.New("invalid fPort")
+	}
+
+	mg, err := storage.GetMulticastGroup(db, id)
+	if err != nil {
+		return 0, errors.Wrap(err, "get multicast-group error")
+	}
+
+	fCnt, err := storage.IncrementMulticastGroupFCnt(db, id)
+	if err != nil {
+		return 0, errors.Wrap(err, "increment multicast-group fcnt error")
+	}
+
+	b, err := lorawan.EncryptFRMPayload(mg.McAppSKey, false, mg.McAddr, fCnt, data)
+	if err != nil {
+		return 0, errors.Wrap(err, "encrypt frmpayload error")
+	}
+
+	err = storage.CreateMulticastQueueItem(db, &storage.MulticastQueueItem{
+		MulticastGroupID: id,
+		FCnt:             fCnt,
+		FPort:            fPort,
+		FRMPayload:       b,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "create multicast queue-item error")
+	}
+
+	nsClient, err := getNSClient(db, id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = nsClient.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+		MulticastQueueItem: &ns.MulticastQueueItem{
+			MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+			FCnt:             fCnt,
+			FPort:            uint32(fPort),
+			FrmPayload:       b,
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "enqueue multicast queue-item error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": id,
+		"f_cnt":              fCnt,
+	}).Info("multicast queue-item enqueued")
+
+	return fCnt, nil
+}
+
+// GetQueue returns the items pending in the multicast-queue of the
+// network-server. The creation timestamp is taken from the items stored
+// on enqueue.
+func GetQueue(db sqlx.Queryer, id int64) ([]storage.MulticastQueueItem, error) {
+	mg, err := storage.GetMulticastGroup(db, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get multicast-group error")
+	}
+
+	stored, err := storage.GetMulticastQueueItemsForMulticastGroup(db, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get multicast queue-items error")
+	}
+
+	createdAt := make(map[uint32]time.Time)
+	for _, qi := range stored {
+		createdAt[qi.FCnt] = qi.CreatedAt
+	}
+
+	nsClient, err := getNSClient(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := nsClient.GetMulticastQueueItemsForMulticastGroup(context.Background(), &ns.GetMulticastQueueItemsForMulticastGroupRequest{
+		MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "get multicast queue-items error")
+	}
+
+	var items []storage.MulticastQueueItem
+	for _, qi := range resp.MulticastQueueItems {
+		items = append(items, storage.MulticastQueueItem{
+			CreatedAt:        createdAt[qi.FCnt],
+			MulticastGroupID: id,
+			FCnt:             qi.FCnt,
+			FPort:            uint8(qi.FPort),
+			FRMPayload:       qi.FrmPayload,
+		})
+	}
+
+	return items, nil
+}
+
+// FlushQueue flushes the multicast-queue, at the network-server and the
+// items stored on enqueue.
+func FlushQueue(db sqlx.Ext, id int64) error {
+	mg, err := storage.GetMulticastGroup(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	if err := storage.FlushMulticastQueueForMulticastGroup(db, id); err != nil {
+		return errors.Wrap(err, "flush multicast queue error")
+	}
+
+	nsClient, err := getNSClient(db, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = nsClient.FlushMulticastQueueForMulticastGroup(context.Background(), &ns.FlushMulticastQueueForMulticastGroupRequest{
+		MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "flush multicast queue error")
+	}
+
+	return nil
+}
+
+// SetupDevices sends the McGroupSetupReq to each member of the
+// multicast-group, provisioning the McKey (encrypted using the McKEKey of
+// each device) and the McAddr. The McRootKey of the device is derived from
+// its GenAppKey (LoRaWAN 1.0.x) or AppKey (LoRaWAN 1.1). Errors are stored per member
+// so that a single failing member does not block the other members.
+func SetupDevices(db sqlx.Ext, id int64) error {
+	mg, err := storage.GetMulticastGroup(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	if mg.McKey == nil {
+		return storage.ErrMulticastGroupNoMcKey
+	}
+
+	devices, err := storage.GetMulticastGroupDevices(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group devices error")
+	}
+
+	for _, d := range devices {
+		now := time.Now()
+		d.SetupRequestedAt = &now
+		d.SetupCompletedAt = nil
+		d.LastError = ""
+
+		if err := setupDevice(db, mg, d.DevEUI); err != nil {
+			log.WithFields(log.Fields{
+				"multicast_group_id": id,
+				"dev_eui":            d.DevEUI,
+			}).WithError(err).Error("multicast-group setup error")
+			d.SetupRequestedAt = nil
+			d.LastError = err.Error()
+		}
+
+		if err := storage.UpdateMulticastGroupDevice(db, d); err != nil {
+			return errors.Wrap(err, "update multicast-group device error")
+		}
+	}
+
+	return nil
+}
+
+// StartSession sends the McClassCSessionReq or McClassBSessionReq (depending
+// the multicast-group type) to each member for which the setup has been
+// completed. The session starts at the given time and ends after
+// 2^timeout seconds (class C) or beacon periods (class B).
+func StartSession(db sqlx.Ext, id int64, sessionTime time.Time, timeout uint8) error {
+	mg, err := storage.GetMulticastGroup(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	var cmd multicastsetup.Command
+	gpsTime := uint32(gps.TimeSinceGPSEpoch(sessionTime) / time.Second)
+
+	switch mg.GroupType {
+	case storage.MulticastGroupClassC:
+		cmd = multicastsetup.Command{
+			CID: multicastsetup.McClassCSessionReq,
+			Payload: &multicastsetup.McClassCSessionReqPayload{
+				McGroupID:      mg.McGroupID,
+				SessionTime:    gpsTime,
+				SessionTimeOut: timeout,
+				DLFrequency:    uint32(mg.Frequency),
+				DR:             mg.DR,
+			},
+		}
+	case storage.MulticastGroupClassB:
+		cmd = multicastsetup.Command{
+			CID: multicastsetup.McClassBSessionReq,
+			Payload: &multicastsetup.McClassBSessionReqPayload{
+				McGroupID:   mg.McGroupID,
+				SessionTime: gpsTime,
+				TimeOut:     timeout,
+				Periodicity: mg.PingSlotPeriod,
+				DLFrequency: uint32(mg.Frequency),
+				DR:          mg.DR,
+			},
+		}
+	default:
+		return storage.ErrMulticastGroupInvalidType
+	}
+
+	b, err := cmd.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	devices, err := storage.GetMulticastGroupDevices(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group devices error")
+	}
+
+	for _, d := range devices {
+		if d.SetupCompletedAt == nil {
+			continue
+		}
+
+		now := time.Now()
+		d.SessionRequestedAt = &now
+		d.SessionStartAt = nil
+		d.LastError = ""
+
+		if err := downlink.EnqueueDownlinkPayload(db, d.DevEUI, reference(id), false, multicastsetup.DefaultFPort, b); err != nil {
+			log.WithFields(log.Fields{
+				"multicast_group_id": id,
+				"dev_eui":            d.DevEUI,
+			}).WithError(err).Error("multicast-group session request error")
+			d.SessionRequestedAt = nil
+			d.LastError = err.Error()
+		}
+
+		if err := storage.UpdateMulticastGroupDevice(db, d); err != nil {
+			return errors.Wrap(err, "update multicast-group device error")
+		}
+	}
+
+	return nil
+}
+
+// HandleRemoteMulticastSetupCommand handles the Remote Multicast Setup
+// answers, sent by the given device on the Remote Multicast Setup fPort.
+func HandleRemoteMulticastSetupCommand(db sqlx.Ext, devEUI lorawan.EUI64, b []byte) error {
+	cmds, err := multicastsetup.UnmarshalUplinkCommands(b)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal commands error")
+	}
+
+	for _, cmd := range cmds {
+		var err error
+
+		switch pl := cmd.Payload.(type) {
+		case *multicastsetup.McGroupSetupAnsPayload:
+			err = handleMcGroupSetupAns(db, devEUI, pl)
+		case *multicastsetup.McClassSessionAnsPayload:
+			err = handleMcClassSessionAns(db, devEUI, pl)
+		default:
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"cid":     cmd.CID,
+			}).Info("remote multicast setup command ignored")
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "handle command error (cid: %d)", cmd.CID)
+		}
+	}
+
+	return nil
+}
+
+func setupDevice(db sqlx.Ext, mg storage.MulticastGroup, devEUI lorawan.EUI64) error {
+	d, err := storage.GetDevice(db, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device error")
+	}
+
+	dp, err := storage.GetDeviceProfile(db, d.DeviceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get device-profile error")
+	}
+
+	dk, err := storage.GetDeviceKeys(db, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device-keys error")
+	}
+
+	mcRootKey, err := join.GetMcRootKey(dk, dp.DeviceProfile.MACVersion)
+	if err != nil {
+		return errors.Wrap(err, "get McRootKey error")
+	}
+
+	mcKEKey, err := multicastsetup.GetMcKEKey(mcRootKey)
+	if err != nil {
+		return errors.Wrap(err, "get McKEKey error")
+	}
+
+	mcKeyEncrypted, err := multicastsetup.GetMcKeyEncrypted(mcKEKey, *mg.McKey)
+	if err != nil {
+		return errors.Wrap(err, "get encrypted McKey error")
+	}
+
+	cmd := multicastsetup.Command{
+		CID: multicastsetup.McGroupSetupReq,
+		Payload: &multicastsetup.McGroupSetupReqPayload{
+			McGroupID:      mg.McGroupID,
+			McAddr:         mg.McAddr,
+			McKeyEncrypted: mcKeyEncrypted,
+			MinMcFCnt:      mg.FCnt,
+			MaxMcFCnt:      maxMcFCnt,
+		},
+	}
+	b, err := cmd.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	return downlink.EnqueueDownlinkPayload(db, devEUI, reference(mg.ID), false, multicastsetup.DefaultFPort, b)
+}
+
+func handleMcGroupSetupAns(db sqlx.Ext, devEUI lorawan.EUI64, pl *multicastsetup.McGroupSetupAnsPayload) error {
+	d, err := storage.GetMulticastGroupDeviceForMcGroupID(db, devEUI, pl.McGroupID)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group device error")
+	}
+
+	if pl.IDError {
+		d.SetupCompletedAt = nil
+		d.LastError = "McGroupSetupAns: McGroupID not supported by device"
+	} else {
+		now := time.Now()
+		d.SetupCompletedAt = &now
+		d.LastError = ""
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": d.MulticastGroupID,
+		"dev_eui":            devEUI,
+		"id_error":           pl.IDError,
+	}).Info("McGroupSetupAns received")
+
+	return storage.UpdateMulticastGroupDevice(db, d)
+}
+
+func handleMcClassSessionAns(db sqlx.Ext, devEUI lorawan.EUI64, pl *multicastsetup.McClassSessionAnsPayload) error {
+	d, err := storage.GetMulticastGroupDeviceForMcGroupID(db, devEUI, pl.McGroupID)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group device error")
+	}
+
+	d.SessionStartAt = nil
+	d.LastError = ""
+
+	switch {
+	case pl.McGroupUndefined:
+		d.LastError = "McClassSessionAns: McGroup undefined"
+	case pl.FreqError:
+		d.LastError = "McClassSessionAns: frequency not supported by device"
+	case pl.DRError:
+		d.LastError = "McClassSessionAns: data-rate not supported by device"
+	case pl.TimeToStart != nil:
+		startAt := time.Now().Add(time.Duration(*pl.TimeToStart) * time.Second)
+		d.SessionStartAt = &startAt
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": d.MulticastGroupID,
+		"dev_eui":            devEUI,
+		"error":              d.LastError,
+	}).Info("McClassSessionAns received")
+
+	return storage.UpdateMulticastGroupDevice(db, d)
+}
+
+func reference(id int64) string {
+	return fmt.Sprintf("multicast-group-%d", id)
+}
+
+func getNSClient(db sqlx.Queryer, id int64) (ns.NetworkServerServiceClient, error) {
+	n, err := storage.GetNetworkServerForMulticastGroupID(db, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get network-server error")
+	}
+
+	nsClient, err := config.C.NetworkServer.Pool.Get(n.Server, []byte(n.CACert), []byte(n.TLSCert), []byte(n.TLSKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get network-server client error")
+	}
+
+	return nsClient, nil
+}
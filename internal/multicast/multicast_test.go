@@ -0,0 +1,277 @@
+package multicast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/multicastsetup"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestMulticast(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+
+	Convey("Given a clean database with a device and a multicast-group", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		nsClient.GetDeviceProfileResponse = ns.GetDeviceProfileResponse{
+			DeviceProfile: &ns.DeviceProfile{
+				MacVersion: "1.0.2",
+			},
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		device := storage.Device{
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			Name:            "test-node",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDevice(db, &device), ShouldBeNil)
+
+		dk := storage.DeviceKeys{
+			DevEUI:    device.DevEUI,
+			AppKey:    lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+			GenAppKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDeviceKeys(db, &dk), ShouldBeNil)
+
+		da := storage.DeviceActivation{
+			DevEUI:  device.DevEUI,
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			AppSKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		So(storage.CreateDeviceActivation(db, &da), ShouldBeNil)
+
+		mcKey := lorawan.AES128Key{1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8}
+		mg := storage.MulticastGroup{
+			ApplicationID: app.ID,
+			Name:          "test-group",
+			McAddr:        lorawan.DevAddr{4, 3, 2, 1},
+			McKey:         &mcKey,
+			McAppSKey:     lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+			McGroupID:     1,
+			GroupType:     storage.MulticastGroupClassC,
+			DR:            3,
+			Frequency:     869525000,
+			FCnt:          5,
+		}
+		So(storage.CreateMulticastGroup(db, &mg), ShouldBeNil)
+		So(storage.AddDeviceToMulticastGroup(db, mg.ID, device.DevEUI), ShouldBeNil)
+
+		Convey("When enqueueing a multicast payload", func() {
+			fCnt, err := Enqueue(db, mg.ID, 10, []byte{1, 2, 3})
+			So(err, ShouldBeNil)
+			So(fCnt, ShouldEqual, 5)
+
+			b, err := lorawan.EncryptFRMPayload(mg.McAppSKey, false, mg.McAddr, 5, []byte{1, 2, 3})
+			So(err, ShouldBeNil)
+
+			Convey("Then the payload is encrypted using the McAppSKey and enqueued at the network-server", func() {
+				So(<-nsClient.EnqueueMulticastQueueItemChan, ShouldResemble, ns.EnqueueMulticastQueueItemRequest{
+					MulticastQueueItem: &ns.MulticastQueueItem{
+						MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+						FCnt:             5,
+						FPort:            10,
+						FrmPayload:       b,
+					},
+				})
+
+				items, err := storage.GetMulticastQueueItemsForMulticastGroup(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+				So(items[0].FCnt, ShouldEqual, 5)
+				So(items[0].FRMPayload, ShouldResemble, b)
+			})
+
+			Convey("Then the frame-counter of the group has been incremented", func() {
+				mg2, err := storage.GetMulticastGroup(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(mg2.FCnt, ShouldEqual, 6)
+			})
+
+			Convey("Then the queue returns the items pending at the network-server", func() {
+				nsClient.GetMulticastQueueItemsForMulticastGroupResponse = ns.GetMulticastQueueItemsForMulticastGroupResponse{
+					MulticastQueueItems: []*ns.MulticastQueueItem{
+						{FCnt: 5, FPort: 10, FrmPayload: b},
+					},
+				}
+
+				items, err := GetQueue(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+				So(items[0].FCnt, ShouldEqual, 5)
+				So(items[0].FPort, ShouldEqual, 10)
+				So(items[0].CreatedAt.IsZero(), ShouldBeFalse)
+			})
+
+			Convey("Then the queue can be flushed", func() {
+				So(FlushQueue(db, mg.ID), ShouldBeNil)
+				So(<-nsClient.FlushMulticastQueueForMulticastGroupChan, ShouldResemble, ns.FlushMulticastQueueForMulticastGroupRequest{
+					MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+				})
+
+				items, err := storage.GetMulticastQueueItemsForMulticastGroup(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the McKey of the group is unknown", func() {
+			mg.McKey = nil
+			So(storage.UpdateMulticastGroup(db, &mg), ShouldBeNil)
+
+			Convey("Then SetupDevices returns an error", func() {
+				err := SetupDevices(db, mg.ID)
+				So(errors.Cause(err), ShouldEqual, storage.ErrMulticastGroupNoMcKey)
+			})
+		})
+
+		Convey("When calling SetupDevices", func() {
+			So(SetupDevices(db, mg.ID), ShouldBeNil)
+
+			Convey("Then the McGroupSetupReq has been enqueued", func() {
+				mcRootKey, err := multicastsetup.GetMcRootKeyForGenAppKey(dk.GenAppKey)
+				So(err, ShouldBeNil)
+				mcKEKey, err := multicastsetup.GetMcKEKey(mcRootKey)
+				So(err, ShouldBeNil)
+				mcKeyEncrypted, err := multicastsetup.GetMcKeyEncrypted(mcKEKey, mcKey)
+				So(err, ShouldBeNil)
+
+				cmd := multicastsetup.Command{
+					CID: multicastsetup.McGroupSetupReq,
+					Payload: &multicastsetup.McGroupSetupReqPayload{
+						McGroupID:      1,
+						McAddr:         mg.McAddr,
+						McKeyEncrypted: mcKeyEncrypted,
+						MinMcFCnt:      5,
+						MaxMcFCnt:      0xffffffff,
+					},
+				}
+				b, err := cmd.MarshalBinary()
+				So(err, ShouldBeNil)
+				b, err = lorawan.EncryptFRMPayload(da.AppSKey, false, da.DevAddr, 12, b)
+				So(err, ShouldBeNil)
+
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+				So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     device.DevEUI[:],
+						FrmPayload: b,
+						FCnt:       12,
+						FPort:      uint32(multicastsetup.DefaultFPort),
+					},
+				})
+			})
+
+			Convey("Then the setup is pending", func() {
+				members, err := storage.GetMulticastGroupDevices(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(members, ShouldHaveLength, 1)
+				So(members[0].SetupRequestedAt, ShouldNotBeNil)
+				So(members[0].SetupCompletedAt, ShouldBeNil)
+			})
+
+			Convey("When the device answers with an McGroupSetupAns with IDError", func() {
+				So(HandleRemoteMulticastSetupCommand(db, device.DevEUI, []byte{0x02, 0x05}), ShouldBeNil)
+
+				Convey("Then the error is stored", func() {
+					members, err := storage.GetMulticastGroupDevices(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(members[0].SetupCompletedAt, ShouldBeNil)
+					So(members[0].LastError, ShouldNotEqual, "")
+				})
+			})
+
+			Convey("When the device answers with an McGroupSetupAns", func() {
+				So(HandleRemoteMulticastSetupCommand(db, device.DevEUI, []byte{0x02, 0x01}), ShouldBeNil)
+				<-nsClient.CreateDeviceQueueItemChan
+
+				Convey("Then the setup is completed", func() {
+					members, err := storage.GetMulticastGroupDevices(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(members[0].SetupCompletedAt, ShouldNotBeNil)
+					So(members[0].LastError, ShouldEqual, "")
+				})
+
+				Convey("When starting a session", func() {
+					So(StartSession(db, mg.ID, time.Now().Add(time.Minute), 8), ShouldBeNil)
+
+					Convey("Then the McClassCSessionReq has been enqueued", func() {
+						So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+						req := <-nsClient.CreateDeviceQueueItemChan
+						So(req.Item.FPort, ShouldEqual, multicastsetup.DefaultFPort)
+
+						b, err := lorawan.EncryptFRMPayload(da.AppSKey, false, da.DevAddr, 12, req.Item.FrmPayload)
+						So(err, ShouldBeNil)
+						So(b[0], ShouldEqual, byte(multicastsetup.McClassCSessionReq))
+					})
+
+					Convey("When the device answers with an McClassCSessionAns", func() {
+						So(HandleRemoteMulticastSetupCommand(db, device.DevEUI, []byte{0x04, 0x01, 0x3c, 0x00, 0x00}), ShouldBeNil)
+
+						Convey("Then the session start is stored", func() {
+							members, err := storage.GetMulticastGroupDevices(db, mg.ID)
+							So(err, ShouldBeNil)
+							So(members[0].SessionRequestedAt, ShouldNotBeNil)
+							So(members[0].SessionStartAt, ShouldNotBeNil)
+							So(members[0].SessionStartAt.After(time.Now().Add(50*time.Second)), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
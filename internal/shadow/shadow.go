@@ -0,0 +1,288 @@
+// Package shadow implements the device shadow: the desired state of a device
+// set by the application is compared with the state reported by the device
+// in its (decoded) uplinks, and the difference is sent to the device when it
+// is next seen.
+package shadow
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/downlink"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const reference = "shadow-delta"
+
+// DeltaResendTimeout defines the duration after which the same delta is
+// enqueued again when the device still did not report the desired state
+// (e.g. because the downlink was lost or not applied by the device).
+var DeltaResendTimeout = time.Hour
+
+// Event contains the device shadow state, it is logged to the device
+// event-log on each shadow change.
+type Event struct {
+	DevEUI         lorawan.EUI64   `json:"devEUI"`
+	Desired        json.RawMessage `json:"desired,omitempty"`
+	DesiredVersion int64           `json:"desiredVersion"`
+	Reported       json.RawMessage `json:"reported,omitempty"`
+	Delta          json.RawMessage `json:"delta,omitempty"`
+	DeltaEnqueued  bool            `json:"deltaEnqueued"`
+}
+
+// UpdateDesired replaces the desired state of the given device, creating the
+// shadow when it does not exist yet, and increments the desired version.
+// The delta is enqueued (on fPort) on the next uplink of the device.
+func UpdateDesired(db sqlx.Ext, devEUI lorawan.EUI64, desired string, fPort uint8, confirmed bool) (storage.DeviceShadow, error) {
+	s, err := storage.GetDeviceShadow(db, devEUI, true)
+	if err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return s, errors.Wrap(err, "get device shadow error")
+	}
+	exists := err == nil
+
+	s.DevEUI = devEUI
+	s.Desired = &desired
+	s.DesiredVersion++
+	s.FPort = fPort
+	s.Confirmed = confirmed
+	s.DeltaSent = nil
+	s.DeltaSentAt = nil
+
+	if exists {
+		err = storage.UpdateDeviceShadow(db, &s)
+	} else {
+		err = storage.CreateDeviceShadow(db, &s)
+	}
+	if err != nil {
+		return s, err
+	}
+
+	delta, err := GetDelta(s)
+	if err != nil {
+		return s, err
+	}
+	logEvent(s, delta, false)
+
+	return s, nil
+}
+
+// HandleUplink merges the given decoded uplink object into the reported
+// state of the device. When the reported state differs from the desired
+// state, the delta is encoded using the application codec and enqueued,
+// unless the same delta has already been enqueued within the
+// DeltaResendTimeout. Devices without a shadow
+// and objects which are not JSON objects are ignored. A shadow change is
+// logged when the reported state changed or a delta was enqueued.
+func HandleUplink(db sqlx.Ext, app storage.Application, d storage.Device, object interface{}) error {
+	if object == nil {
+		return nil
+	}
+
+	reported, err := toMap(object)
+	if err != nil || reported == nil {
+		return nil
+	}
+
+	s, err := storage.GetDeviceShadow(db, d.DevEUI, true)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get device shadow error")
+	}
+
+	var previous string
+	if s.Reported != nil {
+		previous = *s.Reported
+
+		var current map[string]interface{}
+		if err := json.Unmarshal([]byte(*s.Reported), &current); err != nil {
+			return errors.Wrap(err, "unmarshal reported state error")
+		}
+		reported = merge(current, reported)
+	}
+
+	b, err := json.Marshal(reported)
+	if err != nil {
+		return errors.Wrap(err, "marshal reported state error")
+	}
+	now := time.Now()
+	reportedStr := string(b)
+	s.Reported = &reportedStr
+	s.ReportedAt = &now
+
+	delta, err := GetDelta(s)
+	if err != nil {
+		return err
+	}
+
+	var enqueue bool
+	if delta == nil {
+		s.DeltaSent = nil
+		s.DeltaSentAt = nil
+	} else if s.DeltaSent == nil || !jsonEqual(*s.DeltaSent, string(delta)) {
+		enqueue = true
+	} else if s.DeltaSentAt == nil || now.Sub(*s.DeltaSentAt) >= DeltaResendTimeout {
+		enqueue = true
+	}
+
+	if enqueue {
+		data, err := downlink.EncodeObject(app, d, s.FPort, delta)
+		if err != nil {
+			return errors.Wrap(err, "encode delta error")
+		}
+
+		deltaStr := string(delta)
+		if _, err := downlink.ScheduleDownlinkPayload(db, d.DevEUI, reference, s.Confirmed, s.FPort, data, &deltaStr, nil, nil, nil); err != nil {
+			return errors.Wrap(err, "enqueue delta error")
+		}
+
+		s.DeltaSent = &deltaStr
+		s.DeltaSentAt = &now
+
+		log.WithFields(log.Fields{
+			"dev_eui":         d.DevEUI,
+			"desired_version": s.DesiredVersion,
+		}).Info("device shadow delta enqueued")
+	}
+
+	if err := storage.UpdateDeviceShadow(db, &s); err != nil {
+		return errors.Wrap(err, "update device shadow error")
+	}
+
+	if enqueue || !jsonEqual(previous, reportedStr) {
+		logEvent(s, delta, enqueue)
+	}
+
+	return nil
+}
+
+// GetDelta returns the delta of the given shadow as JSON object, or nil when
+// the reported state matches the desired state.
+func GetDelta(s storage.DeviceShadow) (json.RawMessage, error) {
+	if s.Desired == nil {
+		return nil, nil
+	}
+
+	var desired, reported map[string]interface{}
+	if err := json.Unmarshal([]byte(*s.Desired), &desired); err != nil {
+		return nil, errors.Wrap(err, "unmarshal desired state error")
+	}
+	if s.Reported != nil {
+		if err := json.Unmarshal([]byte(*s.Reported), &reported); err != nil {
+			return nil, errors.Wrap(err, "unmarshal reported state error")
+		}
+	}
+
+	d := diff(desired, reported)
+	if len(d) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal delta error")
+	}
+	return b, nil
+}
+
+// diff returns the fields of desired which are missing or have a different
+// value in reported. Nested objects are compared field by field.
+func diff(desired, reported map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+
+	for k, v := range desired {
+		rv, ok := reported[k]
+		if !ok {
+			out[k] = v
+			continue
+		}
+
+		dm, dOK := v.(map[string]interface{})
+		rm, rOK := rv.(map[string]interface{})
+		if dOK && rOK {
+			if nested := diff(dm, rm); len(nested) != 0 {
+				out[k] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(v, rv) {
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+// merge merges the fields of src into dst, nested objects are merged field
+// by field.
+func merge(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+
+	for k, v := range src {
+		sm, sOK := v.(map[string]interface{})
+		dm, dOK := dst[k].(map[string]interface{})
+		if sOK && dOK {
+			dst[k] = merge(dm, sm)
+			continue
+		}
+		dst[k] = v
+	}
+
+	return dst
+}
+
+// toMap converts the given decoded object (which might be a struct, e.g. in
+// case of the Cayenne LPP codec) to a JSON object.
+func toMap(object interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func jsonEqual(a, b string) bool {
+	var av, bv interface{}
+	if json.Unmarshal([]byte(a), &av) != nil || json.Unmarshal([]byte(b), &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+func logEvent(s storage.DeviceShadow, delta json.RawMessage, deltaEnqueued bool) {
+	e := Event{
+		DevEUI:         s.DevEUI,
+		DesiredVersion: s.DesiredVersion,
+		Delta:          delta,
+		DeltaEnqueued:  deltaEnqueued,
+	}
+	if s.Desired != nil {
+		e.Desired = json.RawMessage(*s.Desired)
+	}
+	if s.Reported != nil {
+		e.Reported = json.RawMessage(*s.Reported)
+	}
+
+	if err := eventlog.LogEventForDevice(s.DevEUI, eventlog.EventLog{
+		Type:    eventlog.Shadow,
+		Payload: e,
+	}); err != nil {
+		log.WithError(err).Error("log event for device error")
+	}
+}
@@ -0,0 +1,207 @@
+package shadow
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/codec"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDiffAndMerge(t *testing.T) {
+	Convey("Given a desired and reported state", t, func() {
+		var desired, reported map[string]interface{}
+		So(json.Unmarshal([]byte(`{"interval": 60, "led": true, "thresholds": {"low": 10, "high": 30}}`), &desired), ShouldBeNil)
+		So(json.Unmarshal([]byte(`{"interval": 30, "temperature": 21.5, "thresholds": {"low": 10}}`), &reported), ShouldBeNil)
+
+		Convey("Then diff returns the fields which are missing or different", func() {
+			So(diff(desired, reported), ShouldResemble, map[string]interface{}{
+				"interval": float64(60),
+				"led":      true,
+				"thresholds": map[string]interface{}{
+					"high": float64(30),
+				},
+			})
+		})
+
+		Convey("Then merging the desired state results in an empty diff", func() {
+			merged := merge(reported, desired)
+			So(merged["temperature"], ShouldEqual, 21.5)
+			So(diff(desired, merged), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestHandleUplink(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = storage.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database with an activated device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:            "test-dp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			PayloadCodec:     codec.CustomJSType,
+			PayloadEncoderScript: `
+				function Encode(fPort, obj) {
+					return [obj.interval];
+				}
+			`,
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		d := storage.Device{
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			Name:            "test-node",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDevice(db, &d), ShouldBeNil)
+
+		So(storage.CreateDeviceActivation(db, &storage.DeviceActivation{
+			DevEUI:  d.DevEUI,
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+		}), ShouldBeNil)
+
+		Convey("When the device does not have a shadow", func() {
+			So(HandleUplink(db, app, d, map[string]interface{}{"interval": 30}), ShouldBeNil)
+
+			Convey("Then nothing is enqueued", func() {
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+				_, err := storage.GetDeviceShadow(db, d.DevEUI, false)
+				So(err, ShouldEqual, storage.ErrDoesNotExist)
+			})
+		})
+
+		Convey("When setting the desired state", func() {
+			s, err := UpdateDesired(db, d.DevEUI, `{"interval": 60}`, 10, false)
+			So(err, ShouldBeNil)
+			So(s.DesiredVersion, ShouldEqual, 1)
+
+			Convey("Then the delta equals the desired state", func() {
+				delta, err := GetDelta(s)
+				So(err, ShouldBeNil)
+				So(string(delta), ShouldEqual, `{"interval":60}`)
+			})
+
+			Convey("When the device reports a different state", func() {
+				So(HandleUplink(db, app, d, map[string]interface{}{"interval": 30, "temperature": 21.5}), ShouldBeNil)
+
+				Convey("Then the delta is encoded and enqueued", func() {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+					req := <-nsClient.CreateDeviceQueueItemChan
+					So(req.Item.FPort, ShouldEqual, 10)
+
+					st, err := storage.GetDeviceQueueItemStatusForReference(db, d.DevEUI, reference)
+					So(err, ShouldBeNil)
+					So(st.State, ShouldEqual, storage.DeviceQueueItemQueued)
+				})
+
+				Convey("Then the reported state is stored", func() {
+					s, err := storage.GetDeviceShadow(db, d.DevEUI, false)
+					So(err, ShouldBeNil)
+					So(jsonEqual(*s.Reported, `{"interval": 30, "temperature": 21.5}`), ShouldBeTrue)
+					So(s.DeltaSent, ShouldNotBeNil)
+					So(s.ReportedAt, ShouldNotBeNil)
+				})
+
+				Convey("Then the same delta is not enqueued again on the next uplink", func() {
+					<-nsClient.CreateDeviceQueueItemChan
+					So(HandleUplink(db, app, d, map[string]interface{}{"temperature": 22.0}), ShouldBeNil)
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+				})
+
+				Convey("Then the same delta is enqueued again after the resend timeout", func() {
+					<-nsClient.CreateDeviceQueueItemChan
+
+					s, err := storage.GetDeviceShadow(db, d.DevEUI, false)
+					So(err, ShouldBeNil)
+					sentAt := time.Now().Add(-DeltaResendTimeout)
+					s.DeltaSentAt = &sentAt
+					So(storage.UpdateDeviceShadow(db, &s), ShouldBeNil)
+
+					So(HandleUplink(db, app, d, map[string]interface{}{"temperature": 22.0}), ShouldBeNil)
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+				})
+
+				Convey("When the device reports the desired state", func() {
+					<-nsClient.CreateDeviceQueueItemChan
+					So(HandleUplink(db, app, d, map[string]interface{}{"interval": 60}), ShouldBeNil)
+
+					Convey("Then nothing is enqueued and the shadow is in sync", func() {
+						So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+
+						s, err := storage.GetDeviceShadow(db, d.DevEUI, false)
+						So(err, ShouldBeNil)
+						So(s.DeltaSent, ShouldBeNil)
+
+						delta, err := GetDelta(s)
+						So(err, ShouldBeNil)
+						So(delta, ShouldBeNil)
+					})
+				})
+
+				Convey("When the desired state is updated", func() {
+					<-nsClient.CreateDeviceQueueItemChan
+					s, err := UpdateDesired(db, d.DevEUI, `{"interval": 120}`, 10, false)
+					So(err, ShouldBeNil)
+					So(s.DesiredVersion, ShouldEqual, 2)
+
+					Convey("Then the new delta is enqueued on the next uplink", func() {
+						So(HandleUplink(db, app, d, map[string]interface{}{"temperature": 22.0}), ShouldBeNil)
+						So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+					})
+				})
+			})
+		})
+	})
+}
@@ -14,14 +14,17 @@ var applicationNameRegexp = regexp.MustCompile(`^[\w-]+$`)
 
 // Application represents an application.
 type Application struct {
-	ID                   int64      `db:"id"`
-	Name                 string     `db:"name"`
-	Description          string     `db:"description"`
-	OrganizationID       int64      `db:"organization_id"`
-	ServiceProfileID     string     `db:"service_profile_id"`
-	PayloadCodec         codec.Type `db:"payload_codec"`
-	PayloadEncoderScript string     `db:"payload_encoder_script"`
-	PayloadDecoderScript string     `db:"payload_decoder_script"`
+	ID                    int64        `db:"id"`
+	Name                  string       `db:"name"`
+	Description           string       `db:"description"`
+	OrganizationID        int64        `db:"organization_id"`
+	ServiceProfileID      string       `db:"service_profile_id"`
+	PayloadCodec          codec.Type   `db:"payload_codec"`
+	PayloadEncoderScript  string       `db:"payload_encoder_script"`
+	PayloadDecoderScript  string       `db:"payload_decoder_script"`
+	PayloadFieldsMetadata codec.Fields `db:"payload_fields_metadata"`
+	CodecID               *int64       `db:"codec_id"`
+	CodecVersion          *int         `db:"codec_version"`
 }
 
 // ApplicationListItem devices the application as a list item.
@@ -36,6 +39,18 @@ func (a Application) Validate() error {
 		return ErrApplicationInvalidName
 	}
 
+	if a.PayloadCodec == codec.WASMType {
+		if err := codec.ValidateWASMModule(a.PayloadDecoderScript); err != nil {
+			log.WithError(err).WithField("name", a.Name).Warning("invalid wasm codec module")
+			return ErrInvalidWASMModule
+		}
+	}
+
+	if err := a.PayloadFieldsMetadata.Validate(); err != nil {
+		log.WithError(err).WithField("name", a.Name).Warning("invalid codec fields metadata")
+		return ErrInvalidFieldsMetadata
+	}
+
 	return nil
 }
 
@@ -53,8 +68,11 @@ func CreateApplication(db sqlx.Queryer, item *Application) error {
 			service_profile_id,
 			payload_codec,
 			payload_encoder_script,
-			payload_decoder_script
-		) values ($1, $2, $3, $4, $5, $6, $7) returning id`,
+			payload_decoder_script,
+			payload_fields_metadata,
+			codec_id,
+			codec_version
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) returning id`,
 		item.Name,
 		item.Description,
 		item.OrganizationID,
@@ -62,6 +80,9 @@ func CreateApplication(db sqlx.Queryer, item *Application) error {
 		item.PayloadCodec,
 		item.PayloadEncoderScript,
 		item.PayloadDecoderScript,
+		item.PayloadFieldsMetadata,
+		item.CodecID,
+		item.CodecVersion,
 	)
 	if err != nil {
 		return handlePSQLError(Insert, err, "insert error")
@@ -293,7 +314,10 @@ func UpdateApplication(db sqlx.Execer, item Application) error {
 			service_profile_id = $5,
 			payload_codec = $6,
 			payload_encoder_script = $7,
-			payload_decoder_script = $8
+			payload_decoder_script = $8,
+			payload_fields_metadata = $9,
+			codec_id = $10,
+			codec_version = $11
 		where id = $1`,
 		item.ID,
 		item.Name,
@@ -303,6 +327,9 @@ func UpdateApplication(db sqlx.Execer, item Application) error {
 		item.PayloadCodec,
 		item.PayloadEncoderScript,
 		item.PayloadDecoderScript,
+		item.PayloadFieldsMetadata,
+		item.CodecID,
+		item.CodecVersion,
 	)
 	if err != nil {
 		return handlePSQLError(Update, err, "update error")
@@ -0,0 +1,473 @@
+package storage
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/codec"
+)
+
+// Codec defines an organization codec which can be shared by the
+// applications and device-profiles of the organization.
+type Codec struct {
+	ID             int64        `db:"id"`
+	OrganizationID int64        `db:"organization_id"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+	Name           string       `db:"name"`
+	Type           codec.Type   `db:"type"`
+	EncoderScript  string       `db:"encoder_script"`
+	DecoderScript  string       `db:"decoder_script"`
+	FieldsMetadata codec.Fields `db:"fields_metadata"`
+	Version        int          `db:"version"`
+}
+
+// CodecVersion defines a (historical) version of a codec.
+type CodecVersion struct {
+	CodecID        int64        `db:"codec_id"`
+	Version        int          `db:"version"`
+	CreatedAt      time.Time    `db:"created_at"`
+	Type           codec.Type   `db:"type"`
+	EncoderScript  string       `db:"encoder_script"`
+	DecoderScript  string       `db:"decoder_script"`
+	FieldsMetadata codec.Fields `db:"fields_metadata"`
+}
+
+// CodecReference defines an application or device-profile referencing
+// a codec.
+type CodecReference struct {
+	ApplicationID   *int64  `db:"application_id"`
+	DeviceProfileID *string `db:"device_profile_id"`
+	Name            string  `db:"name"`
+	Version         *int    `db:"codec_version"`
+}
+
+// CodecConfig contains the codec configuration to use for a device.
+type CodecConfig struct {
+	Type           codec.Type
+	EncoderScript  string
+	DecoderScript  string
+	FieldsMetadata codec.Fields
+
+	// CodecID and Version are set when the configuration is the result of
+	// an organization codec.
+	CodecID *int64
+	Version int
+}
+
+// Validate validates the codec data.
+func (c Codec) Validate() error {
+	if c.Name == "" {
+		return ErrCodecInvalidName
+	}
+
+	if codec.NewPayload(c.Type, 0, c.EncoderScript, c.DecoderScript) == nil {
+		return ErrCodecInvalidType
+	}
+
+	if c.Type == codec.WASMType {
+		if err := codec.ValidateWASMModule(c.DecoderScript); err != nil {
+			log.WithError(err).WithField("name", c.Name).Warning("invalid wasm codec module")
+			return ErrInvalidWASMModule
+		}
+	}
+
+	if err := c.FieldsMetadata.Validate(); err != nil {
+		log.WithError(err).WithField("name", c.Name).Warning("invalid codec fields metadata")
+		return ErrInvalidFieldsMetadata
+	}
+
+	return nil
+}
+
+// CreateCodec creates the given codec, together with its first version.
+func CreateCodec(db sqlx.Ext, c *Codec) error {
+	if err := c.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.Version = 1
+
+	err := sqlx.Get(db, &c.ID, `
+		insert into codec (
+			organization_id,
+			created_at,
+			updated_at,
+			name,
+			type,
+			encoder_script,
+			decoder_script,
+			fields_metadata,
+			version
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9) returning id`,
+		c.OrganizationID,
+		c.CreatedAt,
+		c.UpdatedAt,
+		c.Name,
+		c.Type,
+		c.EncoderScript,
+		c.DecoderScript,
+		c.FieldsMetadata,
+		c.Version,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	if err := createCodecVersion(db, *c); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"id":   c.ID,
+		"name": c.Name,
+	}).Info("codec created")
+
+	return nil
+}
+
+// GetCodec returns the codec matching the given id.
+func GetCodec(db sqlx.Queryer, id int64) (Codec, error) {
+	var c Codec
+	err := sqlx.Get(db, &c, "select * from codec where id = $1", id)
+	if err != nil {
+		return c, handlePSQLError(Select, err, "select error")
+	}
+
+	return c, nil
+}
+
+// UpdateCodec updates the given codec. When the type, one of the scripts or
+// the fields metadata has been modified, the version of the codec is incremented and a new
+// version record is created.
+func UpdateCodec(db sqlx.Ext, c *Codec) error {
+	if err := c.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	current, err := getCodecForUpdate(db, c.ID)
+	if err != nil {
+		return err
+	}
+
+	c.CreatedAt = current.CreatedAt
+	c.Version = current.Version
+	c.UpdatedAt = time.Now()
+
+	newVersion := c.Type != current.Type ||
+		c.EncoderScript != current.EncoderScript ||
+		c.DecoderScript != current.DecoderScript ||
+		!reflect.DeepEqual(c.FieldsMetadata, current.FieldsMetadata)
+	if newVersion {
+		c.Version++
+	}
+
+	res, err := db.Exec(`
+		update codec
+		set
+			updated_at = $2,
+			name = $3,
+			type = $4,
+			encoder_script = $5,
+			decoder_script = $6,
+			fields_metadata = $7,
+			version = $8
+		where id = $1`,
+		c.ID,
+		c.UpdatedAt,
+		c.Name,
+		c.Type,
+		c.EncoderScript,
+		c.DecoderScript,
+		c.FieldsMetadata,
+		c.Version,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	if newVersion {
+		if err := createCodecVersion(db, *c); err != nil {
+			return err
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"id":      c.ID,
+		"name":    c.Name,
+		"version": c.Version,
+	}).Info("codec updated")
+
+	return nil
+}
+
+// DeleteCodec deletes the codec matching the given id. A codec can not be
+// deleted while it is referenced by an application or device-profile.
+func DeleteCodec(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from codec where id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("codec deleted")
+
+	return nil
+}
+
+// GetCodecCountForOrganizationID returns the total number of codecs for the
+// given organization.
+func GetCodecCountForOrganizationID(db sqlx.Queryer, organizationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from codec where organization_id = $1", organizationID)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetCodecsForOrganizationID returns a slice of codecs for the given
+// organization, sorted by name.
+func GetCodecsForOrganizationID(db sqlx.Queryer, organizationID int64, limit, offset int) ([]Codec, error) {
+	var codecs []Codec
+	err := sqlx.Select(db, &codecs, `
+		select *
+		from codec
+		where
+			organization_id = $1
+		order by name
+		limit $2 offset $3`,
+		organizationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return codecs, nil
+}
+
+// GetCodecVersion returns the given version of the codec.
+func GetCodecVersion(db sqlx.Queryer, codecID int64, version int) (CodecVersion, error) {
+	var cv CodecVersion
+	err := sqlx.Get(db, &cv, `
+		select *
+		from codec_version
+		where
+			codec_id = $1
+			and version = $2`,
+		codecID,
+		version,
+	)
+	if err != nil {
+		return cv, handlePSQLError(Select, err, "select error")
+	}
+
+	return cv, nil
+}
+
+// GetCodecVersions returns all versions of the given codec, newest
+// version first.
+func GetCodecVersions(db sqlx.Queryer, codecID int64) ([]CodecVersion, error) {
+	var versions []CodecVersion
+	err := sqlx.Select(db, &versions, `
+		select *
+		from codec_version
+		where
+			codec_id = $1
+		order by version desc`,
+		codecID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return versions, nil
+}
+
+// GetCodecReferences returns the applications and device-profiles
+// referencing the given codec.
+func GetCodecReferences(db sqlx.Queryer, codecID int64) ([]CodecReference, error) {
+	var refs []CodecReference
+	err := sqlx.Select(db, &refs, `
+		select
+			id as application_id,
+			null as device_profile_id,
+			name,
+			codec_version
+		from application
+		where
+			codec_id = $1
+		union all
+		select
+			null as application_id,
+			device_profile_id,
+			name,
+			codec_version
+		from device_profile
+		where
+			codec_id = $1
+		order by name`,
+		codecID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return refs, nil
+}
+
+// ValidateCodecReference validates that the given codec (and version)
+// exists and belongs to the given organization.
+func ValidateCodecReference(db sqlx.Queryer, organizationID int64, codecID *int64, version *int) error {
+	if codecID == nil {
+		if version != nil {
+			return ErrCodecInvalidReference
+		}
+		return nil
+	}
+
+	c, err := GetCodec(db, *codecID)
+	if err != nil {
+		if err == ErrDoesNotExist {
+			return ErrCodecInvalidReference
+		}
+		return errors.Wrap(err, "get codec error")
+	}
+
+	if c.OrganizationID != organizationID {
+		return ErrCodecInvalidReference
+	}
+
+	if version != nil && (*version < 1 || *version > c.Version) {
+		return ErrCodecInvalidReference
+	}
+
+	return nil
+}
+
+// GetCodecConfigForDevice returns the codec configuration for a device
+// of the given application and device-profile. The codec of the
+// device-profile takes precedence over the codec of the application. When
+// neither references an organization codec, the payload codec configured
+// on the application is returned.
+func GetCodecConfigForDevice(db sqlx.Queryer, app Application, deviceProfileID string) (CodecConfig, error) {
+	var dp struct {
+		CodecID      *int64 `db:"codec_id"`
+		CodecVersion *int   `db:"codec_version"`
+	}
+	err := sqlx.Get(db, &dp, `
+		select
+			codec_id,
+			codec_version
+		from device_profile
+		where
+			device_profile_id = $1`,
+		deviceProfileID,
+	)
+	if err != nil {
+		return CodecConfig{}, handlePSQLError(Select, err, "select error")
+	}
+
+	codecID, version := app.CodecID, app.CodecVersion
+	if dp.CodecID != nil {
+		codecID, version = dp.CodecID, dp.CodecVersion
+	}
+
+	if codecID == nil {
+		return CodecConfig{
+			Type:           app.PayloadCodec,
+			EncoderScript:  app.PayloadEncoderScript,
+			DecoderScript:  app.PayloadDecoderScript,
+			FieldsMetadata: app.PayloadFieldsMetadata,
+		}, nil
+	}
+
+	var cv CodecVersion
+	if version != nil {
+		cv, err = GetCodecVersion(db, *codecID, *version)
+	} else {
+		err = sqlx.Get(db, &cv, `
+			select cv.*
+			from codec_version cv
+			inner join codec c
+				on c.id = cv.codec_id and c.version = cv.version
+			where
+				c.id = $1`,
+			*codecID,
+		)
+		if err != nil {
+			err = handlePSQLError(Select, err, "select error")
+		}
+	}
+	if err != nil {
+		return CodecConfig{}, errors.Wrap(err, "get codec version error")
+	}
+
+	return CodecConfig{
+		Type:           cv.Type,
+		EncoderScript:  cv.EncoderScript,
+		DecoderScript:  cv.DecoderScript,
+		FieldsMetadata: cv.FieldsMetadata,
+		CodecID:        codecID,
+		Version:        cv.Version,
+	}, nil
+}
+
+func getCodecForUpdate(db sqlx.Queryer, id int64) (Codec, error) {
+	var c Codec
+	err := sqlx.Get(db, &c, "select * from codec where id = $1 for update", id)
+	if err != nil {
+		return c, handlePSQLError(Select, err, "select error")
+	}
+
+	return c, nil
+}
+
+func createCodecVersion(db sqlx.Execer, c Codec) error {
+	_, err := db.Exec(`
+		insert into codec_version (
+			codec_id,
+			version,
+			created_at,
+			type,
+			encoder_script,
+			decoder_script,
+			fields_metadata
+		) values ($1, $2, $3, $4, $5, $6, $7)`,
+		c.ID,
+		c.Version,
+		c.UpdatedAt,
+		c.Type,
+		c.EncoderScript,
+		c.DecoderScript,
+		c.FieldsMetadata,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert codec version error")
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/codec"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestCodec(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization, network-server, service-profile, device-profile and application", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:       org.ID,
+			ServiceProfileID:     sp.ServiceProfile.ServiceProfileID,
+			Name:                 "test-app",
+			PayloadCodec:         codec.CustomJSType,
+			PayloadEncoderScript: "app encoder",
+			PayloadDecoderScript: "app decoder",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("When creating a codec with an invalid type", func() {
+			c := Codec{
+				OrganizationID: org.ID,
+				Name:           "test-codec",
+				Type:           "INVALID",
+			}
+			err := CreateCodec(db, &c)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrCodecInvalidType)
+			})
+		})
+
+		Convey("When creating a codec", func() {
+			c := Codec{
+				OrganizationID: org.ID,
+				Name:           "test-codec",
+				Type:           codec.CustomJSType,
+				EncoderScript:  "encoder v1",
+				DecoderScript:  "decoder v1",
+			}
+			So(CreateCodec(db, &c), ShouldBeNil)
+			c.CreatedAt = c.CreatedAt.Truncate(time.Millisecond).UTC()
+			c.UpdatedAt = c.UpdatedAt.Truncate(time.Millisecond).UTC()
+
+			Convey("Then it has version 1", func() {
+				So(c.Version, ShouldEqual, 1)
+
+				versions, err := GetCodecVersions(db, c.ID)
+				So(err, ShouldBeNil)
+				So(versions, ShouldHaveLength, 1)
+				So(versions[0].Version, ShouldEqual, 1)
+				So(versions[0].DecoderScript, ShouldEqual, "decoder v1")
+			})
+
+			Convey("Then it can be get by id", func() {
+				c2, err := GetCodec(db, c.ID)
+				So(err, ShouldBeNil)
+				c2.CreatedAt = c2.CreatedAt.Truncate(time.Millisecond).UTC()
+				c2.UpdatedAt = c2.UpdatedAt.Truncate(time.Millisecond).UTC()
+				So(c2, ShouldResemble, c)
+			})
+
+			Convey("Then it is listed for the organization", func() {
+				count, err := GetCodecCountForOrganizationID(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				codecs, err := GetCodecsForOrganizationID(db, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(codecs, ShouldHaveLength, 1)
+				So(codecs[0].ID, ShouldEqual, c.ID)
+			})
+
+			Convey("When only updating the name", func() {
+				c.Name = "test-codec-updated"
+				So(UpdateCodec(db, &c), ShouldBeNil)
+
+				Convey("Then no new version is created", func() {
+					So(c.Version, ShouldEqual, 1)
+
+					versions, err := GetCodecVersions(db, c.ID)
+					So(err, ShouldBeNil)
+					So(versions, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When updating the fields metadata", func() {
+				c.FieldsMetadata = codec.Fields{
+					"temperature": {Unit: "Cel", DataType: codec.NumberDataType},
+				}
+				So(UpdateCodec(db, &c), ShouldBeNil)
+
+				Convey("Then a new version is created containing the fields metadata", func() {
+					So(c.Version, ShouldEqual, 2)
+
+					cv, err := GetCodecVersion(db, c.ID, 2)
+					So(err, ShouldBeNil)
+					So(cv.FieldsMetadata, ShouldResemble, c.FieldsMetadata)
+				})
+			})
+
+			Convey("When updating the decoder script", func() {
+				c.DecoderScript = "decoder v2"
+				So(UpdateCodec(db, &c), ShouldBeNil)
+
+				Convey("Then a new version is created", func() {
+					So(c.Version, ShouldEqual, 2)
+
+					versions, err := GetCodecVersions(db, c.ID)
+					So(err, ShouldBeNil)
+					So(versions, ShouldHaveLength, 2)
+					So(versions[0].Version, ShouldEqual, 2)
+					So(versions[0].DecoderScript, ShouldEqual, "decoder v2")
+					So(versions[1].Version, ShouldEqual, 1)
+					So(versions[1].DecoderScript, ShouldEqual, "decoder v1")
+				})
+
+				Convey("When the application references the codec pinned to version 1", func() {
+					version := 1
+					app.CodecID = &c.ID
+					app.CodecVersion = &version
+					So(UpdateApplication(db, app), ShouldBeNil)
+
+					Convey("Then GetCodecConfigForDevice returns version 1", func() {
+						cc, err := GetCodecConfigForDevice(db, app, dp.DeviceProfile.DeviceProfileID)
+						So(err, ShouldBeNil)
+						So(cc, ShouldResemble, CodecConfig{
+							Type:          codec.CustomJSType,
+							EncoderScript: "encoder v1",
+							DecoderScript: "decoder v1",
+							CodecID:       &c.ID,
+							Version:       1,
+						})
+					})
+
+					Convey("Then GetCodecReferences returns the application", func() {
+						refs, err := GetCodecReferences(db, c.ID)
+						So(err, ShouldBeNil)
+						So(refs, ShouldHaveLength, 1)
+						So(*refs[0].ApplicationID, ShouldEqual, app.ID)
+						So(refs[0].DeviceProfileID, ShouldBeNil)
+						So(*refs[0].Version, ShouldEqual, 1)
+					})
+
+					Convey("Then the codec can not be deleted", func() {
+						So(errors.Cause(DeleteCodec(db, c.ID)), ShouldEqual, ErrUsedByOtherObjects)
+					})
+				})
+
+				Convey("When the device-profile references the codec", func() {
+					dp.CodecID = &c.ID
+					So(UpdateDeviceProfile(db, &dp), ShouldBeNil)
+
+					Convey("Then GetCodecConfigForDevice returns the latest version", func() {
+						cc, err := GetCodecConfigForDevice(db, app, dp.DeviceProfile.DeviceProfileID)
+						So(err, ShouldBeNil)
+						So(cc, ShouldResemble, CodecConfig{
+							Type:          codec.CustomJSType,
+							EncoderScript: "encoder v1",
+							DecoderScript: "decoder v2",
+							CodecID:       &c.ID,
+							Version:       2,
+						})
+					})
+				})
+			})
+
+			Convey("Then ValidateCodecReference validates the organization and version", func() {
+				version := 2
+				So(ValidateCodecReference(db, org.ID, &c.ID, nil), ShouldBeNil)
+				So(ValidateCodecReference(db, org.ID+1, &c.ID, nil), ShouldEqual, ErrCodecInvalidReference)
+				So(ValidateCodecReference(db, org.ID, &c.ID, &version), ShouldEqual, ErrCodecInvalidReference)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteCodec(db, c.ID), ShouldBeNil)
+				_, err := GetCodec(db, c.ID)
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+		})
+
+		Convey("Then GetCodecConfigForDevice returns the application codec when no codec is referenced", func() {
+			cc, err := GetCodecConfigForDevice(db, app, dp.DeviceProfile.DeviceProfileID)
+			So(err, ShouldBeNil)
+			So(cc, ShouldResemble, CodecConfig{
+				Type:          codec.CustomJSType,
+				EncoderScript: "app encoder",
+				DecoderScript: "app decoder",
+			})
+		})
+	})
+}
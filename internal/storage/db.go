@@ -45,6 +45,33 @@ func NewRedisPool(redisURL string) *redis.Pool {
 	}
 }
 
+// IncrWindowCounter increments the counter with the given key and returns
+// its value. The window starts with the first increment, the counter is
+// created together with its expiration within a transaction, so that it
+// can't be left without expiration.
+func IncrWindowCounter(p *redis.Pool, key string, window time.Duration) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("SET", key, 0, "PX", int64(window/time.Millisecond), "NX")
+	c.Send("INCR", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return 0, errors.Wrap(err, "increment counter error")
+	}
+	if len(values) != 2 {
+		return 0, fmt.Errorf("expected 2 results, got %d", len(values))
+	}
+
+	n, err := redis.Int(values[1], nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "increment counter error")
+	}
+
+	return n, nil
+}
+
 // OpenDatabase opens the database and performs a ping to make sure the
 // database is up.
 func OpenDatabase(dsn string) (*common.DBLogger, error) {
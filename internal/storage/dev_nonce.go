@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// ValidateAndStoreDevNonce validates that the given DevNonce has not been
+// used before by the device and stores it. When counter is set (LoRaWAN
+// 1.0.3+), the DevNonce must be greater than the last used DevNonce.
+// Otherwise (random DevNonce values), it must not be in the history of
+// used DevNonces, which is bounded to historySize items.
+// ErrDevNonceReplayed is returned when the DevNonce has already been used.
+func ValidateAndStoreDevNonce(db sqlx.Ext, devEUI lorawan.EUI64, devNonce uint16, counter bool, historySize int) error {
+	// lock the device-keys so that concurrent join-requests for the same
+	// device are validated in order
+	var n int
+	err := sqlx.Get(db, &n, "select 1 from device_keys where dev_eui = $1 for update", devEUI[:])
+	if err != nil {
+		return handlePSQLError(Select, err, "select error")
+	}
+
+	var used bool
+	if counter {
+		err = sqlx.Get(db, &used, "select coalesce(max(dev_nonce) >= $2, false) from device_dev_nonce where dev_eui = $1", devEUI[:], int(devNonce))
+	} else {
+		err = sqlx.Get(db, &used, "select count(*) > 0 from device_dev_nonce where dev_eui = $1 and dev_nonce = $2", devEUI[:], int(devNonce))
+	}
+	if err != nil {
+		return handlePSQLError(Select, err, "select error")
+	}
+	if used {
+		log.WithFields(log.Fields{
+			"dev_eui":   devEUI,
+			"dev_nonce": devNonce,
+		}).Warning("dev-nonce has already been used")
+		return ErrDevNonceReplayed
+	}
+
+	_, err = db.Exec(`
+		insert into device_dev_nonce (
+			dev_eui,
+			dev_nonce,
+			created_at
+		) values ($1, $2, $3)`,
+		devEUI[:],
+		int(devNonce),
+		time.Now(),
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	// only keep the last historySize items, in case of a counter only the
+	// last (highest) value is needed
+	if counter || historySize < 1 {
+		historySize = 1
+	}
+
+	_, err = db.Exec(`
+		delete from device_dev_nonce
+		where
+			dev_eui = $1
+			and dev_nonce not in (
+				select dev_nonce
+				from device_dev_nonce
+				where dev_eui = $1
+				order by created_at desc, dev_nonce desc
+				limit $2
+			)`,
+		devEUI[:],
+		historySize,
+	)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+
+	return nil
+}
+
+// GetDevNonces returns the used DevNonces of the given device, most recent
+// first.
+func GetDevNonces(db sqlx.Queryer, devEUI lorawan.EUI64) ([]uint16, error) {
+	var nonces []uint16
+	err := sqlx.Select(db, &nonces, `
+		select
+			dev_nonce
+		from
+			device_dev_nonce
+		where
+			dev_eui = $1
+		order by created_at desc, dev_nonce desc`,
+		devEUI[:],
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return nonces, nil
+}
+
+// DeleteDevNonces deletes the used DevNonces of the given device, e.g. after
+// the device-keys have been changed.
+func DeleteDevNonces(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	_, err := db.Exec("delete from device_dev_nonce where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+
+	log.WithField("dev_eui", devEUI).Info("device dev-nonces deleted")
+	return nil
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDevNonce(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with a device and device-keys", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		d := Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(CreateDevice(db, &d), ShouldBeNil)
+		So(CreateDeviceKeys(db, &DeviceKeys{DevEUI: d.DevEUI}), ShouldBeNil)
+
+		Convey("Then random DevNonces can only be used once", func() {
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 10, false, 2), ShouldBeNil)
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 5, false, 2), ShouldBeNil)
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 10, false, 2), ShouldEqual, ErrDevNonceReplayed)
+
+			Convey("Then the history is bounded", func() {
+				So(ValidateAndStoreDevNonce(db, d.DevEUI, 7, false, 2), ShouldBeNil)
+
+				nonces, err := GetDevNonces(db, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(nonces, ShouldResemble, []uint16{7, 5})
+				So(ValidateAndStoreDevNonce(db, d.DevEUI, 10, false, 2), ShouldBeNil)
+			})
+		})
+
+		Convey("Then counter DevNonces must be increasing", func() {
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 10, true, 2), ShouldBeNil)
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 10, true, 2), ShouldEqual, ErrDevNonceReplayed)
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 9, true, 2), ShouldEqual, ErrDevNonceReplayed)
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 11, true, 2), ShouldBeNil)
+
+			nonces, err := GetDevNonces(db, d.DevEUI)
+			So(err, ShouldBeNil)
+			So(nonces, ShouldResemble, []uint16{11})
+		})
+
+		Convey("Then the DevNonces can be deleted", func() {
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 10, true, 2), ShouldBeNil)
+			So(DeleteDevNonces(db, d.DevEUI), ShouldBeNil)
+			So(ValidateAndStoreDevNonce(db, d.DevEUI, 1, true, 2), ShouldBeNil)
+		})
+
+		Convey("Then DevNonces can not be stored for a device without keys", func() {
+			So(ValidateAndStoreDevNonce(db, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, 1, false, 2), ShouldEqual, ErrDoesNotExist)
+		})
+	})
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"google.golang.org/grpc"
@@ -10,7 +13,9 @@ import (
 	"github.com/brocaar/loraserver/api/ns"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lora-app-server/internal/config"
@@ -30,6 +35,42 @@ type Device struct {
 	SkipFCntCheck       bool          `db:"-"`
 	DeviceStatusBattery *int          `db:"device_status_battery"`
 	DeviceStatusMargin  *int          `db:"device_status_margin"`
+	Tags                DeviceTags    `db:"tags"`
+}
+
+// DeviceTags contains the user-defined key / value tags of a device. The
+// tags can be used to select the devices of a downlink batch.
+type DeviceTags map[string]string
+
+// Value implements the driver.Valuer interface.
+func (t DeviceTags) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements the sql.Scanner interface. Empty tags are scanned as nil.
+func (t *DeviceTags) Scan(src interface{}) error {
+	*t = nil
+	if src == nil {
+		return nil
+	}
+
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	var tags DeviceTags
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return err
+	}
+	if len(tags) != 0 {
+		*t = tags
+	}
+
+	return nil
 }
 
 // DeviceListItem defines the Device as list item.
@@ -40,16 +81,76 @@ type DeviceListItem struct {
 
 // Validate validates the device data.
 func (d Device) Validate() error {
+	for k := range d.Tags {
+		if k == "" {
+			return ErrDeviceInvalidTags
+		}
+	}
 	return nil
 }
 
-// DeviceKeys defines the keys for a LoRaWAN device.
+// DeviceKeys defines the keys for a LoRaWAN device. LoRaWAN 1.0 devices
+// only use the AppKey, LoRaWAN 1.1 devices use both the NwkKey and AppKey.
+// The GenAppKey is used by LoRaWAN 1.0 devices to derive the keys of the
+// application-layer packages (e.g. Remote Multicast Setup).
+// The JoinEUI is the JoinEUI of the last join-request, it is used for the
+// rejoin-requests type 0 and 2 and RJCount1 is the minimum expected RJcount1
+// of the next rejoin-request type 1.
 type DeviceKeys struct {
 	CreatedAt time.Time         `db:"created_at"`
 	UpdatedAt time.Time         `db:"updated_at"`
 	DevEUI    lorawan.EUI64     `db:"dev_eui"`
 	AppKey    lorawan.AES128Key `db:"app_key"`
+	NwkKey    lorawan.AES128Key `db:"nwk_key"`
+	GenAppKey lorawan.AES128Key `db:"gen_app_key"`
 	JoinNonce int               `db:"join_nonce"`
+	JoinEUI   lorawan.EUI64     `db:"join_eui"`
+	RJCount1  int               `db:"rj_count1"`
+}
+
+// deviceKeysRow defines the device_keys row, containing the encrypted keys
+// (see key_encryption.go).
+type deviceKeysRow struct {
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+	DevEUI      lorawan.EUI64 `db:"dev_eui"`
+	AppKey      []byte        `db:"app_key"`
+	NwkKey      []byte        `db:"nwk_key"`
+	GenAppKey   []byte        `db:"gen_app_key"`
+	JoinNonce   int           `db:"join_nonce"`
+	JoinEUI     lorawan.EUI64 `db:"join_eui"`
+	RJCount1    int           `db:"rj_count1"`
+	DEK         []byte        `db:"dek"`
+	MasterKeyID string        `db:"master_key_id"`
+}
+
+func (r deviceKeysRow) deviceKeys() (DeviceKeys, error) {
+	keys, err := decryptKeys(r.MasterKeyID, r.DEK, r.AppKey, r.NwkKey)
+	if err != nil {
+		return DeviceKeys{}, err
+	}
+
+	// the GenAppKey is not set for device-keys stored before it was added
+	var genAppKey lorawan.AES128Key
+	if r.GenAppKey != nil {
+		k, err := decryptKeys(r.MasterKeyID, r.DEK, r.GenAppKey)
+		if err != nil {
+			return DeviceKeys{}, err
+		}
+		genAppKey = k[0]
+	}
+
+	return DeviceKeys{
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+		DevEUI:    r.DevEUI,
+		AppKey:    keys[0],
+		NwkKey:    keys[1],
+		GenAppKey: genAppKey,
+		JoinNonce: r.JoinNonce,
+		JoinEUI:   r.JoinEUI,
+		RJCount1:  r.RJCount1,
+	}, nil
 }
 
 // DeviceActivation defines the device-activation for a LoRaWAN device.
@@ -60,6 +161,77 @@ type DeviceActivation struct {
 	DevAddr   lorawan.DevAddr   `db:"dev_addr"`
 	AppSKey   lorawan.AES128Key `db:"app_s_key"`
 	NwkSKey   lorawan.AES128Key `db:"nwk_s_key"`
+
+	// SessionKeyID identifies the session-keys of the activation (backend
+	// interfaces) and is used to request the AppSKey by an
+	// application-server through the join-server API.
+	SessionKeyID []byte `db:"session_key_id"`
+
+	// NetID contains the NetID of the (home) network-server which handled
+	// the join-request. It is nil for ABP activations.
+	NetID *lorawan.NetID `db:"net_id"`
+
+	// SNwkSIntKey contains the serving network session integrity key of
+	// LoRaWAN 1.1 activations, which is used to validate the MIC of the
+	// rejoin-requests type 0 and 2. It is nil for other activations.
+	// RJCount0 is the minimum expected RJcount0 of the next rejoin-request
+	// type 0 or 2 within the session of the activation.
+	SNwkSIntKey *lorawan.AES128Key `db:"s_nwk_s_int_key"`
+	RJCount0    int                `db:"rj_count0"`
+}
+
+// deviceActivationRow defines the device_activation row, containing the
+// encrypted keys (see key_encryption.go).
+type deviceActivationRow struct {
+	ID           int64           `db:"id"`
+	CreatedAt    time.Time       `db:"created_at"`
+	DevEUI       lorawan.EUI64   `db:"dev_eui"`
+	DevAddr      lorawan.DevAddr `db:"dev_addr"`
+	AppSKey      []byte          `db:"app_s_key"`
+	NwkSKey      []byte          `db:"nwk_s_key"`
+	SessionKeyID []byte          `db:"session_key_id"`
+	NetID        []byte          `db:"net_id"`
+	DEK          []byte          `db:"dek"`
+	MasterKeyID  string          `db:"master_key_id"`
+	SNwkSIntKey  []byte          `db:"s_nwk_s_int_key"`
+	RJCount0     int             `db:"rj_count0"`
+}
+
+func (r deviceActivationRow) deviceActivation() (DeviceActivation, error) {
+	keys, err := decryptKeys(r.MasterKeyID, r.DEK, r.AppSKey, r.NwkSKey)
+	if err != nil {
+		return DeviceActivation{}, err
+	}
+
+	da := DeviceActivation{
+		ID:           r.ID,
+		CreatedAt:    r.CreatedAt,
+		DevEUI:       r.DevEUI,
+		DevAddr:      r.DevAddr,
+		AppSKey:      keys[0],
+		NwkSKey:      keys[1],
+		SessionKeyID: r.SessionKeyID,
+		RJCount0:     r.RJCount0,
+	}
+
+	if r.SNwkSIntKey != nil {
+		k, err := decryptKeys(r.MasterKeyID, r.DEK, r.SNwkSIntKey)
+		if err != nil {
+			return DeviceActivation{}, err
+		}
+		da.SNwkSIntKey = &k[0]
+	}
+
+	if r.NetID != nil {
+		var netID lorawan.NetID
+		if len(r.NetID) != len(netID) {
+			return DeviceActivation{}, errors.New("net_id must be exactly 3 bytes")
+		}
+		copy(netID[:], r.NetID)
+		da.NetID = &netID
+	}
+
+	return da, nil
 }
 
 // CreateDevice creates the given device.
@@ -83,8 +255,9 @@ func CreateDevice(db sqlx.Ext, d *Device) error {
 			description,
 			device_status_battery,
 			device_status_margin,
-			last_seen_at
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			last_seen_at,
+			tags
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
 		d.DevEUI[:],
 		d.CreatedAt,
 		d.UpdatedAt,
@@ -95,6 +268,7 @@ func CreateDevice(db sqlx.Ext, d *Device) error {
 		d.DeviceStatusBattery,
 		d.DeviceStatusMargin,
 		d.LastSeenAt,
+		d.Tags,
 	)
 	if err != nil {
 		return handlePSQLError(Insert, err, "insert error")
@@ -117,10 +291,10 @@ func CreateDevice(db sqlx.Ext, d *Device) error {
 
 	_, err = nsClient.CreateDevice(context.Background(), &ns.CreateDeviceRequest{
 		Device: &ns.Device{
-			DevEUI:           d.DevEUI[:],
-			DeviceProfileID:  d.DeviceProfileID,
-			ServiceProfileID: app.ServiceProfileID,
-			RoutingProfileID: config.C.ApplicationServer.ID,
+			DevEui:           d.DevEUI[:],
+			DeviceProfileId:  uuid.FromStringOrNil(d.DeviceProfileID).Bytes(),
+			ServiceProfileId: uuid.FromStringOrNil(app.ServiceProfileID).Bytes(),
+			RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 			SkipFCntCheck:    d.SkipFCntCheck,
 		},
 	})
@@ -155,7 +329,7 @@ func GetDevice(db sqlx.Queryer, devEUI lorawan.EUI64) (Device, error) {
 	}
 
 	resp, err := nsClient.GetDevice(context.Background(), &ns.GetDeviceRequest{
-		DevEUI: d.DevEUI[:],
+		DevEui: d.DevEUI[:],
 	})
 	if err != nil {
 		return d, err
@@ -224,6 +398,65 @@ func GetDeviceCountForApplicationID(db sqlx.Queryer, applicationID int64, search
 	return count, nil
 }
 
+// GetDevicesForApplicationIDAndSelector returns the devices of the given
+// application matching the given selector. When DevEUIs are given, the
+// devices with these DevEUIs are returned, else the devices using the given
+// device-profile id (when set) and having all the given tags. Without
+// selector, all the devices of the application are returned.
+func GetDevicesForApplicationIDAndSelector(db sqlx.Queryer, applicationID int64, devEUIs []lorawan.EUI64, deviceProfileID string, tags DeviceTags) ([]Device, error) {
+	var devices []Device
+	var err error
+
+	switch {
+	case len(devEUIs) != 0:
+		eui := make(pq.ByteaArray, len(devEUIs))
+		for i := range devEUIs {
+			eui[i] = devEUIs[i][:]
+		}
+
+		err = sqlx.Select(db, &devices, `
+			select *
+			from device
+			where
+				application_id = $1
+				and dev_eui = any($2)
+			order by dev_eui`,
+			applicationID,
+			eui,
+		)
+	case deviceProfileID != "":
+		err = sqlx.Select(db, &devices, `
+			select *
+			from device
+			where
+				application_id = $1
+				and device_profile_id = $2
+				and tags @> $3
+			order by dev_eui`,
+			applicationID,
+			deviceProfileID,
+			tags,
+		)
+	default:
+		// the empty tags object is contained by every tags object
+		err = sqlx.Select(db, &devices, `
+			select *
+			from device
+			where
+				application_id = $1
+				and tags @> $2
+			order by dev_eui`,
+			applicationID,
+			tags,
+		)
+	}
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return devices, nil
+}
+
 // UpdateDevice updates the given device.
 func UpdateDevice(db sqlx.Ext, d *Device) error {
 	if err := d.Validate(); err != nil {
@@ -242,7 +475,8 @@ func UpdateDevice(db sqlx.Ext, d *Device) error {
 			description = $6,
 			device_status_battery = $7,
 			device_status_margin = $8,
-			last_seen_at = $9
+			last_seen_at = $9,
+			tags = $10
         where
             dev_eui = $1`,
 		d.DevEUI[:],
@@ -254,6 +488,7 @@ func UpdateDevice(db sqlx.Ext, d *Device) error {
 		d.DeviceStatusBattery,
 		d.DeviceStatusMargin,
 		d.LastSeenAt,
+		d.Tags,
 	)
 	if err != nil {
 		return handlePSQLError(Update, err, "update error")
@@ -283,10 +518,10 @@ func UpdateDevice(db sqlx.Ext, d *Device) error {
 
 	_, err = nsClient.UpdateDevice(context.Background(), &ns.UpdateDeviceRequest{
 		Device: &ns.Device{
-			DevEUI:           d.DevEUI[:],
-			DeviceProfileID:  d.DeviceProfileID,
-			ServiceProfileID: app.ServiceProfileID,
-			RoutingProfileID: config.C.ApplicationServer.ID,
+			DevEui:           d.DevEUI[:],
+			DeviceProfileId:  uuid.FromStringOrNil(d.DeviceProfileID).Bytes(),
+			ServiceProfileId: uuid.FromStringOrNil(app.ServiceProfileID).Bytes(),
+			RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 			SkipFCntCheck:    d.SkipFCntCheck,
 		},
 	})
@@ -327,7 +562,7 @@ func DeleteDevice(db sqlx.Ext, devEUI lorawan.EUI64) error {
 	}
 
 	_, err = nsClient.DeleteDevice(context.Background(), &ns.DeleteDeviceRequest{
-		DevEUI: devEUI[:],
+		DevEui: devEUI[:],
 	})
 	if err != nil && grpc.Code(err) != codes.NotFound {
 		log.WithError(err).Error("network-server delete device api error")
@@ -347,19 +582,36 @@ func CreateDeviceKeys(db sqlx.Execer, dc *DeviceKeys) error {
 	dc.CreatedAt = now
 	dc.UpdatedAt = now
 
-	_, err := db.Exec(`
+	masterKeyID, dek, keys, err := encryptKeys(dc.AppKey, dc.NwkKey, dc.GenAppKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt keys error")
+	}
+
+	_, err = db.Exec(`
         insert into device_keys (
             created_at,
             updated_at,
             dev_eui,
 			app_key,
-			join_nonce
-        ) values ($1, $2, $3, $4, $5)`,
+			join_nonce,
+			nwk_key,
+			join_eui,
+			rj_count1,
+			dek,
+			master_key_id,
+			gen_app_key
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
 		dc.CreatedAt,
 		dc.UpdatedAt,
 		dc.DevEUI[:],
-		dc.AppKey[:],
+		keys[0],
 		dc.JoinNonce,
+		keys[1],
+		dc.JoinEUI[:],
+		dc.RJCount1,
+		dek,
+		masterKeyID,
+		keys[2],
 	)
 	if err != nil {
 		return handlePSQLError(Insert, err, "insert error")
@@ -374,11 +626,16 @@ func CreateDeviceKeys(db sqlx.Execer, dc *DeviceKeys) error {
 
 // GetDeviceKeys returns the device-keys for the given DevEUI.
 func GetDeviceKeys(db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceKeys, error) {
-	var dc DeviceKeys
+	var row deviceKeysRow
 
-	err := sqlx.Get(db, &dc, "select * from device_keys where dev_eui = $1", devEUI[:])
+	err := sqlx.Get(db, &row, "select * from device_keys where dev_eui = $1", devEUI[:])
 	if err != nil {
-		return dc, handlePSQLError(Select, err, "select error")
+		return DeviceKeys{}, handlePSQLError(Select, err, "select error")
+	}
+
+	dc, err := row.deviceKeys()
+	if err != nil {
+		return dc, errors.Wrap(err, "decrypt keys error")
 	}
 
 	return dc, nil
@@ -388,18 +645,35 @@ func GetDeviceKeys(db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceKeys, error) {
 func UpdateDeviceKeys(db sqlx.Execer, dc *DeviceKeys) error {
 	dc.UpdatedAt = time.Now()
 
+	masterKeyID, dek, keys, err := encryptKeys(dc.AppKey, dc.NwkKey, dc.GenAppKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt keys error")
+	}
+
 	res, err := db.Exec(`
         update device_keys
         set
             updated_at = $2,
 			app_key = $3,
-			join_nonce = $4
+			join_nonce = $4,
+			nwk_key = $5,
+			join_eui = $6,
+			rj_count1 = $7,
+			dek = $8,
+			master_key_id = $9,
+			gen_app_key = $10
         where
             dev_eui = $1`,
 		dc.DevEUI[:],
 		dc.UpdatedAt,
-		dc.AppKey[:],
+		keys[0],
 		dc.JoinNonce,
+		keys[1],
+		dc.JoinEUI[:],
+		dc.RJCount1,
+		dek,
+		masterKeyID,
+		keys[2],
 	)
 	if err != nil {
 		return handlePSQLError(Update, err, "update error")
@@ -442,20 +716,42 @@ func DeleteDeviceKeys(db sqlx.Execer, devEUI lorawan.EUI64) error {
 func CreateDeviceActivation(db sqlx.Queryer, da *DeviceActivation) error {
 	da.CreatedAt = time.Now()
 
-	err := sqlx.Get(db, &da.ID, `
+	masterKeyID, dek, keys, err := encryptKeys(da.sessionKeys()...)
+	if err != nil {
+		return errors.Wrap(err, "encrypt keys error")
+	}
+
+	var netID []byte
+	if da.NetID != nil {
+		netID = da.NetID[:]
+	}
+
+	err = sqlx.Get(db, &da.ID, `
         insert into device_activation (
             created_at,
             dev_eui,
             dev_addr,
             app_s_key,
-            nwk_s_key
-        ) values ($1, $2, $3, $4, $5)
+            nwk_s_key,
+            session_key_id,
+            net_id,
+            dek,
+            master_key_id,
+            s_nwk_s_int_key,
+            rj_count0
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
         returning id`,
 		da.CreatedAt,
 		da.DevEUI[:],
 		da.DevAddr[:],
-		da.AppSKey[:],
-		da.NwkSKey[:],
+		keys[0],
+		keys[1],
+		da.SessionKeyID,
+		netID,
+		dek,
+		masterKeyID,
+		da.sNwkSIntKeyBytes(keys),
+		da.RJCount0,
 	)
 	if err != nil {
 		return handlePSQLError(Insert, err, "insert error")
@@ -471,9 +767,9 @@ func CreateDeviceActivation(db sqlx.Queryer, da *DeviceActivation) error {
 
 // GetLastDeviceActivationForDevEUI returns the most recent device-activation for the given DevEUI.
 func GetLastDeviceActivationForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceActivation, error) {
-	var da DeviceActivation
+	var row deviceActivationRow
 
-	err := sqlx.Get(db, &da, `
+	err := sqlx.Get(db, &row, `
         select *
         from device_activation
         where
@@ -484,7 +780,56 @@ func GetLastDeviceActivationForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (De
 		devEUI[:],
 	)
 	if err != nil {
-		return da, handlePSQLError(Select, err, "select error")
+		return DeviceActivation{}, handlePSQLError(Select, err, "select error")
+	}
+
+	da, err := row.deviceActivation()
+	if err != nil {
+		return da, errors.Wrap(err, "decrypt keys error")
+	}
+
+	return da, nil
+}
+
+// UpdateDeviceActivationRJCount0 updates the minimum expected RJcount0 of
+// the given device-activation.
+func UpdateDeviceActivationRJCount0(db sqlx.Execer, id int64, rjCount0 int) error {
+	res, err := db.Exec("update device_activation set rj_count0 = $2 where id = $1", id, rjCount0)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	return nil
+}
+
+// GetDeviceActivationForSessionKeyID returns the device-activation for the
+// given DevEUI and SessionKeyID.
+func GetDeviceActivationForSessionKeyID(db sqlx.Queryer, devEUI lorawan.EUI64, sessionKeyID []byte) (DeviceActivation, error) {
+	var row deviceActivationRow
+
+	err := sqlx.Get(db, &row, `
+        select *
+        from device_activation
+        where
+            dev_eui = $1
+            and session_key_id = $2`,
+		devEUI[:],
+		sessionKeyID,
+	)
+	if err != nil {
+		return DeviceActivation{}, handlePSQLError(Select, err, "select error")
+	}
+
+	da, err := row.deviceActivation()
+	if err != nil {
+		return da, errors.Wrap(err, "decrypt keys error")
 	}
 
 	return da, nil
@@ -507,3 +852,22 @@ func DeleteAllDevicesForApplicationID(db sqlx.Ext, applicationID int64) error {
 
 	return nil
 }
+
+// sessionKeys returns the session-keys of the device-activation to encrypt.
+// The SNwkSIntKey is only included when set.
+func (da DeviceActivation) sessionKeys() []lorawan.AES128Key {
+	keys := []lorawan.AES128Key{da.AppSKey, da.NwkSKey}
+	if da.SNwkSIntKey != nil {
+		keys = append(keys, *da.SNwkSIntKey)
+	}
+	return keys
+}
+
+// sNwkSIntKeyBytes returns the encrypted SNwkSIntKey from the keys
+// encrypted using sessionKeys or nil (stored as NULL) when not set.
+func (da DeviceActivation) sNwkSIntKeyBytes(keys [][]byte) interface{} {
+	if da.SNwkSIntKey == nil {
+		return nil
+	}
+	return keys[2]
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceJoin defines a (re)join-request handled by the join-server, including
+// failed requests. These are kept as join history per device.
+type DeviceJoin struct {
+	ID          int64           `db:"id"`
+	CreatedAt   time.Time       `db:"created_at"`
+	DevEUI      lorawan.EUI64   `db:"dev_eui"`
+	MessageType string          `db:"message_type"`
+	DevNonce    int             `db:"dev_nonce"`
+	JoinNonce   int             `db:"join_nonce"`
+	DevAddr     lorawan.DevAddr `db:"dev_addr"`
+	SenderID    string          `db:"sender_id"`
+	ResultCode  string          `db:"result_code"`
+	Description string          `db:"description"`
+}
+
+// CreateDeviceJoin creates the given device-join. Only the last historySize
+// device-joins of the device are kept.
+func CreateDeviceJoin(db sqlx.Ext, dj *DeviceJoin, historySize int) error {
+	dj.CreatedAt = time.Now()
+
+	err := sqlx.Get(db, &dj.ID, `
+		insert into device_join (
+			created_at,
+			dev_eui,
+			message_type,
+			dev_nonce,
+			join_nonce,
+			dev_addr,
+			sender_id,
+			result_code,
+			description
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		returning id`,
+		dj.CreatedAt,
+		dj.DevEUI[:],
+		dj.MessageType,
+		dj.DevNonce,
+		dj.JoinNonce,
+		dj.DevAddr[:],
+		dj.SenderID,
+		dj.ResultCode,
+		dj.Description,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	if historySize < 1 {
+		historySize = 1
+	}
+
+	_, err = db.Exec(`
+		delete from device_join
+		where
+			dev_eui = $1
+			and id not in (
+				select id
+				from device_join
+				where dev_eui = $1
+				order by created_at desc, id desc
+				limit $2
+			)`,
+		dj.DevEUI[:],
+		historySize,
+	)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+
+	return nil
+}
+
+// GetDeviceJoinCount returns the number of device-joins for the given DevEUI.
+func GetDeviceJoinCount(db sqlx.Queryer, devEUI lorawan.EUI64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from device_join where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+	return count, nil
+}
+
+// GetDeviceJoins returns the device-joins for the given DevEUI, most recent
+// first.
+func GetDeviceJoins(db sqlx.Queryer, devEUI lorawan.EUI64, limit, offset int) ([]DeviceJoin, error) {
+	var joins []DeviceJoin
+	err := sqlx.Select(db, &joins, `
+		select *
+		from device_join
+		where
+			dev_eui = $1
+		order by created_at desc, id desc
+		limit $2
+		offset $3`,
+		devEUI[:],
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+	return joins, nil
+}
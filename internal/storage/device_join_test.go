@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDeviceJoin(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with a device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		d := Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(CreateDevice(db, &d), ShouldBeNil)
+
+		Convey("When creating device-joins", func() {
+			for i := 0; i < 3; i++ {
+				dj := DeviceJoin{
+					DevEUI:      d.DevEUI,
+					MessageType: "JoinReq",
+					DevNonce:    i,
+					JoinNonce:   i + 1,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+					SenderID:    "010203",
+					ResultCode:  "Success",
+				}
+				So(CreateDeviceJoin(db, &dj, 2), ShouldBeNil)
+			}
+
+			Convey("Then the history is bounded", func() {
+				count, err := GetDeviceJoinCount(db, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+			})
+
+			Convey("Then GetDeviceJoins returns the most recent device-joins first", func() {
+				joins, err := GetDeviceJoins(db, d.DevEUI, 10, 0)
+				So(err, ShouldBeNil)
+				So(joins, ShouldHaveLength, 2)
+				So(joins[0].DevNonce, ShouldEqual, 2)
+				So(joins[1].DevNonce, ShouldEqual, 1)
+
+				So(joins[0], ShouldResemble, DeviceJoin{
+					ID:          joins[0].ID,
+					CreatedAt:   joins[0].CreatedAt,
+					DevEUI:      d.DevEUI,
+					MessageType: "JoinReq",
+					DevNonce:    2,
+					JoinNonce:   3,
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+					SenderID:    "010203",
+					ResultCode:  "Success",
+				})
+			})
+
+			Convey("Then GetDeviceJoins applies the limit and offset", func() {
+				joins, err := GetDeviceJoins(db, d.DevEUI, 1, 1)
+				So(err, ShouldBeNil)
+				So(joins, ShouldHaveLength, 1)
+				So(joins[0].DevNonce, ShouldEqual, 1)
+			})
+		})
+	})
+}
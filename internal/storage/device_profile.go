@@ -19,27 +19,48 @@ import (
 )
 
 // DeviceProfile defines the device-profile.
+// When ClockSyncEnabled is set, the Clock Synchronization requests of the
+// devices are answered. When ClockSyncPeriodicity is set, the devices are
+// requested to synchronize their clock every 128 * 2^periodicity seconds.
+// The DownlinkRetryPolicy is the default retry policy of the confirmed
+// downlinks enqueued for the devices.
 type DeviceProfile struct {
-	NetworkServerID int64                 `db:"network_server_id"`
-	OrganizationID  int64                 `db:"organization_id"`
-	CreatedAt       time.Time             `db:"created_at"`
-	UpdatedAt       time.Time             `db:"updated_at"`
-	Name            string                `db:"name"`
-	DeviceProfile   backend.DeviceProfile `db:"-"`
+	NetworkServerID      int64                 `db:"network_server_id"`
+	OrganizationID       int64                 `db:"organization_id"`
+	CreatedAt            time.Time             `db:"created_at"`
+	UpdatedAt            time.Time             `db:"updated_at"`
+	Name                 string                `db:"name"`
+	CodecID              *int64                `db:"codec_id"`
+	CodecVersion         *int                  `db:"codec_version"`
+	ClockSyncEnabled     bool                  `db:"clock_sync_enabled"`
+	ClockSyncPeriodicity *uint8                `db:"clock_sync_periodicity"`
+	DeviceProfile        backend.DeviceProfile `db:"-"`
+	DownlinkRetryPolicy
 }
 
 // DeviceProfileMeta defines the device-profile meta record.
 type DeviceProfileMeta struct {
-	DeviceProfileID string    `db:"device_profile_id"`
-	NetworkServerID int64     `db:"network_server_id"`
-	OrganizationID  int64     `db:"organization_id"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
-	Name            string    `db:"name"`
+	DeviceProfileID      string    `db:"device_profile_id"`
+	NetworkServerID      int64     `db:"network_server_id"`
+	OrganizationID       int64     `db:"organization_id"`
+	CreatedAt            time.Time `db:"created_at"`
+	UpdatedAt            time.Time `db:"updated_at"`
+	Name                 string    `db:"name"`
+	CodecID              *int64    `db:"codec_id"`
+	CodecVersion         *int      `db:"codec_version"`
+	ClockSyncEnabled     bool      `db:"clock_sync_enabled"`
+	ClockSyncPeriodicity *uint8    `db:"clock_sync_periodicity"`
+	DownlinkRetryPolicy
 }
 
 // Validate validates the device-profile data.
 func (dp DeviceProfile) Validate() error {
+	if dp.ClockSyncPeriodicity != nil && *dp.ClockSyncPeriodicity > 15 {
+		return ErrDeviceProfileInvalidClockSync
+	}
+	if err := dp.DownlinkRetryPolicy.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -63,14 +84,28 @@ func CreateDeviceProfile(db sqlx.Ext, dp *DeviceProfile) error {
             organization_id,
             created_at,
             updated_at,
-            name
-        ) values ($1, $2, $3, $4, $5, $6)`,
+            name,
+            codec_id,
+            codec_version,
+            clock_sync_enabled,
+            clock_sync_periodicity,
+            retry_max_attempts,
+            retry_backoff,
+            retry_reencode
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
 		dp.DeviceProfile.DeviceProfileID,
 		dp.NetworkServerID,
 		dp.OrganizationID,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.Name,
+		dp.CodecID,
+		dp.CodecVersion,
+		dp.ClockSyncEnabled,
+		dp.ClockSyncPeriodicity,
+		dp.DownlinkRetryPolicy.MaxAttempts,
+		dp.DownlinkRetryPolicy.Backoff,
+		dp.DownlinkRetryPolicy.Reencode,
 	)
 	if err != nil {
 		log.WithField("device_profile_id", dp.DeviceProfile.DeviceProfileID).Errorf("create device-profile error: %s", err)
@@ -94,26 +129,26 @@ func CreateDeviceProfile(db sqlx.Ext, dp *DeviceProfile) error {
 
 	_, err = nsClient.CreateDeviceProfile(context.Background(), &ns.CreateDeviceProfileRequest{
 		DeviceProfile: &ns.DeviceProfile{
-			DeviceProfileID:    dp.DeviceProfile.DeviceProfileID,
+			Id:                 uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
 			SupportsClassB:     dp.DeviceProfile.SupportsClassB,
 			ClassBTimeout:      uint32(dp.DeviceProfile.ClassBTimeout),
 			PingSlotPeriod:     uint32(dp.DeviceProfile.PingSlotPeriod),
-			PingSlotDR:         uint32(dp.DeviceProfile.PingSlotDR),
+			PingSlotDr:         uint32(dp.DeviceProfile.PingSlotDR),
 			PingSlotFreq:       uint32(dp.DeviceProfile.PingSlotFreq),
 			SupportsClassC:     dp.DeviceProfile.SupportsClassC,
 			ClassCTimeout:      uint32(dp.DeviceProfile.ClassCTimeout),
 			MacVersion:         dp.DeviceProfile.MACVersion,
 			RegParamsRevision:  dp.DeviceProfile.RegParamsRevision,
-			RxDelay1:           uint32(dp.DeviceProfile.RXDelay1),
-			RxDROffset1:        uint32(dp.DeviceProfile.RXDROffset1),
-			RxDataRate2:        uint32(dp.DeviceProfile.RXDataRate2),
-			RxFreq2:            uint32(dp.DeviceProfile.RXFreq2),
+			RxDelay_1:          uint32(dp.DeviceProfile.RXDelay1),
+			RxDrOffset_1:       uint32(dp.DeviceProfile.RXDROffset1),
+			RxDatarate_2:       uint32(dp.DeviceProfile.RXDataRate2),
+			RxFreq_2:           uint32(dp.DeviceProfile.RXFreq2),
 			FactoryPresetFreqs: factoryPresetFreqs,
-			MaxEIRP:            uint32(dp.DeviceProfile.MaxEIRP),
+			MaxEirp:            uint32(dp.DeviceProfile.MaxEIRP),
 			MaxDutyCycle:       uint32(dp.DeviceProfile.MaxDutyCycle),
 			SupportsJoin:       dp.DeviceProfile.SupportsJoin,
 			RfRegion:           string(dp.DeviceProfile.RFRegion),
-			Supports32BitFCnt:  dp.DeviceProfile.Supports32bitFCnt,
+			Supports_32BitFCnt: dp.DeviceProfile.Supports32bitFCnt,
 		},
 	})
 	if err != nil {
@@ -137,7 +172,14 @@ func GetDeviceProfile(db sqlx.Queryer, id string) (DeviceProfile, error) {
 			organization_id,
 			created_at,
 			updated_at,
-			name
+			name,
+			codec_id,
+			codec_version,
+			clock_sync_enabled,
+			clock_sync_periodicity,
+			retry_max_attempts,
+			retry_backoff,
+			retry_reencode
 		from device_profile
 		where
 			device_profile_id = $1`,
@@ -147,7 +189,7 @@ func GetDeviceProfile(db sqlx.Queryer, id string) (DeviceProfile, error) {
 		return dp, handlePSQLError(Select, err, "select error")
 	}
 
-	err := row.Scan(&dp.DeviceProfile.DeviceProfileID, &dp.NetworkServerID, &dp.OrganizationID, &dp.CreatedAt, &dp.UpdatedAt, &dp.Name)
+	err := row.Scan(&dp.DeviceProfile.DeviceProfileID, &dp.NetworkServerID, &dp.OrganizationID, &dp.CreatedAt, &dp.UpdatedAt, &dp.Name, &dp.CodecID, &dp.CodecVersion, &dp.ClockSyncEnabled, &dp.ClockSyncPeriodicity, &dp.DownlinkRetryPolicy.MaxAttempts, &dp.DownlinkRetryPolicy.Backoff, &dp.DownlinkRetryPolicy.Reencode)
 	if err != nil {
 		return dp, handlePSQLError(Scan, err, "scan error")
 	}
@@ -163,7 +205,7 @@ func GetDeviceProfile(db sqlx.Queryer, id string) (DeviceProfile, error) {
 	}
 
 	resp, err := nsClient.GetDeviceProfile(context.Background(), &ns.GetDeviceProfileRequest{
-		DeviceProfileID: id,
+		Id: uuid.FromStringOrNil(id).Bytes(),
 	})
 	if err != nil {
 		return dp, handleGrpcError(err, "get device-profile error")
@@ -182,22 +224,22 @@ func GetDeviceProfile(db sqlx.Queryer, id string) (DeviceProfile, error) {
 		SupportsClassB:     resp.DeviceProfile.SupportsClassB,
 		ClassBTimeout:      int(resp.DeviceProfile.ClassBTimeout),
 		PingSlotPeriod:     int(resp.DeviceProfile.PingSlotPeriod),
-		PingSlotDR:         int(resp.DeviceProfile.PingSlotDR),
+		PingSlotDR:         int(resp.DeviceProfile.PingSlotDr),
 		PingSlotFreq:       backend.Frequency(resp.DeviceProfile.PingSlotFreq),
 		SupportsClassC:     resp.DeviceProfile.SupportsClassC,
 		ClassCTimeout:      int(resp.DeviceProfile.ClassCTimeout),
 		MACVersion:         resp.DeviceProfile.MacVersion,
 		RegParamsRevision:  resp.DeviceProfile.RegParamsRevision,
-		RXDelay1:           int(resp.DeviceProfile.RxDelay1),
-		RXDROffset1:        int(resp.DeviceProfile.RxDROffset1),
-		RXDataRate2:        int(resp.DeviceProfile.RxDataRate2),
-		RXFreq2:            backend.Frequency(resp.DeviceProfile.RxFreq2),
+		RXDelay1:           int(resp.DeviceProfile.RxDelay_1),
+		RXDROffset1:        int(resp.DeviceProfile.RxDrOffset_1),
+		RXDataRate2:        int(resp.DeviceProfile.RxDatarate_2),
+		RXFreq2:            backend.Frequency(resp.DeviceProfile.RxFreq_2),
 		FactoryPresetFreqs: factoryPresetFreqs,
-		MaxEIRP:            int(resp.DeviceProfile.MaxEIRP),
+		MaxEIRP:            int(resp.DeviceProfile.MaxEirp),
 		MaxDutyCycle:       backend.Percentage(resp.DeviceProfile.MaxDutyCycle),
 		SupportsJoin:       resp.DeviceProfile.SupportsJoin,
 		RFRegion:           backend.RFRegion(resp.DeviceProfile.RfRegion),
-		Supports32bitFCnt:  resp.DeviceProfile.Supports32BitFCnt,
+		Supports32bitFCnt:  resp.DeviceProfile.Supports_32BitFCnt,
 	}
 
 	return dp, nil
@@ -226,26 +268,26 @@ func UpdateDeviceProfile(db sqlx.Ext, dp *DeviceProfile) error {
 
 	_, err = nsClient.UpdateDeviceProfile(context.Background(), &ns.UpdateDeviceProfileRequest{
 		DeviceProfile: &ns.DeviceProfile{
-			DeviceProfileID:    dp.DeviceProfile.DeviceProfileID,
+			Id:                 uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
 			SupportsClassB:     dp.DeviceProfile.SupportsClassB,
 			ClassBTimeout:      uint32(dp.DeviceProfile.ClassBTimeout),
 			PingSlotPeriod:     uint32(dp.DeviceProfile.PingSlotPeriod),
-			PingSlotDR:         uint32(dp.DeviceProfile.PingSlotDR),
+			PingSlotDr:         uint32(dp.DeviceProfile.PingSlotDR),
 			PingSlotFreq:       uint32(dp.DeviceProfile.PingSlotFreq),
 			SupportsClassC:     dp.DeviceProfile.SupportsClassC,
 			ClassCTimeout:      uint32(dp.DeviceProfile.ClassCTimeout),
 			MacVersion:         dp.DeviceProfile.MACVersion,
 			RegParamsRevision:  dp.DeviceProfile.RegParamsRevision,
-			RxDelay1:           uint32(dp.DeviceProfile.RXDelay1),
-			RxDROffset1:        uint32(dp.DeviceProfile.RXDROffset1),
-			RxDataRate2:        uint32(dp.DeviceProfile.RXDataRate2),
-			RxFreq2:            uint32(dp.DeviceProfile.RXFreq2),
+			RxDelay_1:          uint32(dp.DeviceProfile.RXDelay1),
+			RxDrOffset_1:       uint32(dp.DeviceProfile.RXDROffset1),
+			RxDatarate_2:       uint32(dp.DeviceProfile.RXDataRate2),
+			RxFreq_2:           uint32(dp.DeviceProfile.RXFreq2),
 			FactoryPresetFreqs: factoryPresetFreqs,
-			MaxEIRP:            uint32(dp.DeviceProfile.MaxEIRP),
+			MaxEirp:            uint32(dp.DeviceProfile.MaxEIRP),
 			MaxDutyCycle:       uint32(dp.DeviceProfile.MaxDutyCycle),
 			SupportsJoin:       dp.DeviceProfile.SupportsJoin,
 			RfRegion:           string(dp.DeviceProfile.RFRegion),
-			Supports32BitFCnt:  dp.DeviceProfile.Supports32bitFCnt,
+			Supports_32BitFCnt: dp.DeviceProfile.Supports32bitFCnt,
 		},
 	})
 	if err != nil {
@@ -258,11 +300,25 @@ func UpdateDeviceProfile(db sqlx.Ext, dp *DeviceProfile) error {
         update device_profile
         set
             updated_at = $2,
-            name = $3
+            name = $3,
+            codec_id = $4,
+            codec_version = $5,
+            clock_sync_enabled = $6,
+            clock_sync_periodicity = $7,
+            retry_max_attempts = $8,
+            retry_backoff = $9,
+            retry_reencode = $10
         where device_profile_id = $1`,
 		dp.DeviceProfile.DeviceProfileID,
 		dp.UpdatedAt,
 		dp.Name,
+		dp.CodecID,
+		dp.CodecVersion,
+		dp.ClockSyncEnabled,
+		dp.ClockSyncPeriodicity,
+		dp.DownlinkRetryPolicy.MaxAttempts,
+		dp.DownlinkRetryPolicy.Backoff,
+		dp.DownlinkRetryPolicy.Reencode,
 	)
 	if err != nil {
 		return handlePSQLError(Update, err, "update error")
@@ -307,7 +363,7 @@ func DeleteDeviceProfile(db sqlx.Ext, id string) error {
 	}
 
 	_, err = nsClient.DeleteDeviceProfile(context.Background(), &ns.DeleteDeviceProfileRequest{
-		DeviceProfileID: id,
+		Id: uuid.FromStringOrNil(id).Bytes(),
 	})
 	if err != nil && grpc.Code(err) != codes.NotFound {
 		return handleGrpcError(err, "delete device-profile error")
@@ -390,6 +446,19 @@ func GetDeviceProfileCountForApplicationID(db sqlx.Queryer, applicationID int64)
 	return count, nil
 }
 
+// GetDeviceProfileMeta returns the local device-profile record matching
+// the given id, without retrieving the device-profile from the
+// network-server.
+func GetDeviceProfileMeta(db sqlx.Queryer, id string) (DeviceProfileMeta, error) {
+	var dp DeviceProfileMeta
+	err := sqlx.Get(db, &dp, "select * from device_profile where device_profile_id = $1", id)
+	if err != nil {
+		return dp, handlePSQLError(Select, err, "select error")
+	}
+
+	return dp, nil
+}
+
 // GetDeviceProfiles returns a slice of device-profiles.
 func GetDeviceProfiles(db sqlx.Queryer, limit, offset int) ([]DeviceProfileMeta, error) {
 	var dps []DeviceProfileMeta
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/brocaar/loraserver/api/ns"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
 
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/test"
@@ -80,52 +82,52 @@ func TestDeviceProfile(t *testing.T) {
 			So(nsClient.CreateDeviceProfileChan, ShouldHaveLength, 1)
 			So(<-nsClient.CreateDeviceProfileChan, ShouldResemble, ns.CreateDeviceProfileRequest{
 				DeviceProfile: &ns.DeviceProfile{
-					DeviceProfileID:    dp.DeviceProfile.DeviceProfileID,
+					Id:                 uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
 					SupportsClassB:     true,
 					ClassBTimeout:      10,
 					PingSlotPeriod:     20,
-					PingSlotDR:         5,
+					PingSlotDr:         5,
 					PingSlotFreq:       868100000,
 					SupportsClassC:     true,
 					ClassCTimeout:      30,
 					MacVersion:         "1.0.2",
 					RegParamsRevision:  "B",
-					RxDelay1:           1,
-					RxDROffset1:        1,
-					RxDataRate2:        6,
-					RxFreq2:            868300000,
+					RxDelay_1:          1,
+					RxDrOffset_1:       1,
+					RxDatarate_2:       6,
+					RxFreq_2:           868300000,
 					FactoryPresetFreqs: []uint32{868100000, 868300000, 868500000},
-					MaxEIRP:            14,
+					MaxEirp:            14,
 					MaxDutyCycle:       10,
 					SupportsJoin:       true,
 					RfRegion:           "EU868",
-					Supports32BitFCnt:  true,
+					Supports_32BitFCnt: true,
 				},
 			})
 
 			Convey("Then GetDeviceProfile returns the device-profile", func() {
 				nsClient.GetDeviceProfileResponse = ns.GetDeviceProfileResponse{
 					DeviceProfile: &ns.DeviceProfile{
-						DeviceProfileID:    dp.DeviceProfile.DeviceProfileID,
+						Id:                 uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
 						SupportsClassB:     true,
 						ClassBTimeout:      10,
 						PingSlotPeriod:     20,
-						PingSlotDR:         5,
+						PingSlotDr:         5,
 						PingSlotFreq:       868100000,
 						SupportsClassC:     true,
 						ClassCTimeout:      30,
 						MacVersion:         "1.0.2",
 						RegParamsRevision:  "B",
-						RxDelay1:           1,
-						RxDROffset1:        1,
-						RxDataRate2:        6,
-						RxFreq2:            868300000,
+						RxDelay_1:          1,
+						RxDrOffset_1:       1,
+						RxDatarate_2:       6,
+						RxFreq_2:           868300000,
 						FactoryPresetFreqs: []uint32{868100000, 868300000, 868500000},
-						MaxEIRP:            14,
+						MaxEirp:            14,
 						MaxDutyCycle:       10,
 						SupportsJoin:       true,
 						RfRegion:           "EU868",
-						Supports32BitFCnt:  true,
+						Supports_32BitFCnt: true,
 					},
 				}
 
@@ -138,7 +140,15 @@ func TestDeviceProfile(t *testing.T) {
 			})
 
 			Convey("Then UpdateDeviceProfile updates the device-profile", func() {
+				periodicity := uint8(10)
 				dp.Name = "updated-device-profile"
+				dp.ClockSyncEnabled = true
+				dp.ClockSyncPeriodicity = &periodicity
+				dp.DownlinkRetryPolicy = DownlinkRetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     30,
+					Reencode:    true,
+				}
 				dp.DeviceProfile = backend.DeviceProfile{
 					DeviceProfileID:    dp.DeviceProfile.DeviceProfileID,
 					SupportsClassB:     true,
@@ -166,26 +176,26 @@ func TestDeviceProfile(t *testing.T) {
 				So(nsClient.UpdateDeviceProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.UpdateDeviceProfileChan, ShouldResemble, ns.UpdateDeviceProfileRequest{
 					DeviceProfile: &ns.DeviceProfile{
-						DeviceProfileID:    dp.DeviceProfile.DeviceProfileID,
+						Id:                 uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
 						SupportsClassB:     true,
 						ClassBTimeout:      11,
 						PingSlotPeriod:     21,
-						PingSlotDR:         6,
+						PingSlotDr:         6,
 						PingSlotFreq:       868300000,
 						SupportsClassC:     true,
 						ClassCTimeout:      31,
 						MacVersion:         "1.1.0",
 						RegParamsRevision:  "B",
-						RxDelay1:           2,
-						RxDROffset1:        2,
-						RxDataRate2:        5,
-						RxFreq2:            868500000,
+						RxDelay_1:          2,
+						RxDrOffset_1:       2,
+						RxDatarate_2:       5,
+						RxFreq_2:           868500000,
 						FactoryPresetFreqs: []uint32{868100000, 868300000, 868500000, 868700000},
-						MaxEIRP:            17,
+						MaxEirp:            17,
 						MaxDutyCycle:       1,
 						SupportsJoin:       true,
 						RfRegion:           "EU868",
-						Supports32BitFCnt:  true,
+						Supports_32BitFCnt: true,
 					},
 				})
 
@@ -194,13 +204,29 @@ func TestDeviceProfile(t *testing.T) {
 				dpGet.UpdatedAt = dpGet.UpdatedAt.UTC().Truncate(time.Millisecond)
 				So(dpGet.Name, ShouldEqual, "updated-device-profile")
 				So(dpGet.UpdatedAt, ShouldResemble, dp.UpdatedAt)
+				So(dpGet.ClockSyncEnabled, ShouldBeTrue)
+				So(*dpGet.ClockSyncPeriodicity, ShouldEqual, 10)
+				So(dpGet.DownlinkRetryPolicy, ShouldResemble, dp.DownlinkRetryPolicy)
+			})
+
+			Convey("Then UpdateDeviceProfile returns an error on an invalid clock sync periodicity", func() {
+				periodicity := uint8(16)
+				dp.ClockSyncPeriodicity = &periodicity
+				err := UpdateDeviceProfile(config.C.PostgreSQL.DB, &dp)
+				So(errors.Cause(err), ShouldEqual, ErrDeviceProfileInvalidClockSync)
+			})
+
+			Convey("Then UpdateDeviceProfile returns an error on an invalid downlink retry policy", func() {
+				dp.DownlinkRetryPolicy.MaxAttempts = MaxDownlinkRetryAttempts + 1
+				err := UpdateDeviceProfile(config.C.PostgreSQL.DB, &dp)
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkRetryPolicyInvalid)
 			})
 
 			Convey("Then DeleteDeviceProfile deletes the device-profile", func() {
 				So(DeleteDeviceProfile(config.C.PostgreSQL.DB, dp.DeviceProfile.DeviceProfileID), ShouldBeNil)
 				So(nsClient.DeleteDeviceProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.DeleteDeviceProfileChan, ShouldResemble, ns.DeleteDeviceProfileRequest{
-					DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+					Id: uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
 				})
 
 				_, err := GetDeviceProfile(config.C.PostgreSQL.DB, dp.DeviceProfile.DeviceProfileID)
@@ -0,0 +1,398 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceQueueItemState defines the delivery state of a downlink.
+type DeviceQueueItemState string
+
+// Available device-queue item states.
+const (
+	// DeviceQueueItemQueued is set when the item has been added to the
+	// network-server device-queue, or is scheduled to be added.
+	DeviceQueueItemQueued DeviceQueueItemState = "QUEUED"
+	// DeviceQueueItemSent is set when the item has been transmitted to the
+	// device (and is waiting for an ack in case of a confirmed downlink).
+	DeviceQueueItemSent DeviceQueueItemState = "SENT"
+	// DeviceQueueItemUnknown is set when the item is no longer in the
+	// network-server device-queue, but its transmission has not been
+	// confirmed (e.g. it might have been discarded by the network-server).
+	DeviceQueueItemUnknown DeviceQueueItemState = "UNKNOWN"
+	// DeviceQueueItemAcknowledged is set when the device acknowledged the
+	// confirmed downlink.
+	DeviceQueueItemAcknowledged DeviceQueueItemState = "ACKNOWLEDGED"
+	// DeviceQueueItemNacked is set when the device did not acknowledge the
+	// confirmed downlink.
+	DeviceQueueItemNacked DeviceQueueItemState = "NACKED"
+	// DeviceQueueItemExpired is set when the scheduled item expired before
+	// it could be added to the device-queue.
+	DeviceQueueItemExpired DeviceQueueItemState = "EXPIRED"
+	// DeviceQueueItemFlushed is set when the item was removed from the
+	// device-queue before it was transmitted.
+	DeviceQueueItemFlushed DeviceQueueItemState = "FLUSHED"
+	// DeviceQueueItemFailed is set when the scheduled item could not be
+	// added to the device-queue.
+	DeviceQueueItemFailed DeviceQueueItemState = "FAILED"
+)
+
+// MaxDownlinkRetryAttempts defines the max number of attempts of a retry
+// policy.
+const MaxDownlinkRetryAttempts = 10
+
+// DownlinkRetryPolicy defines how a confirmed downlink which was not
+// acknowledged by the device is retried. Before each retry, the policy waits
+// for the backoff (in seconds), which is doubled after each retry.
+// MaxAttempts includes the first attempt, a value of 0 or 1 disables the
+// retries. When Reencode is set, the payload is encoded again from the codec
+// object on each retry.
+type DownlinkRetryPolicy struct {
+	MaxAttempts int  `db:"retry_max_attempts"`
+	Backoff     int  `db:"retry_backoff"`
+	Reencode    bool `db:"retry_reencode"`
+}
+
+// Validate validates the downlink retry policy.
+func (p DownlinkRetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 || p.MaxAttempts > MaxDownlinkRetryAttempts || p.Backoff < 0 {
+		return ErrDownlinkRetryPolicyInvalid
+	}
+	return nil
+}
+
+// Enabled returns true when the policy allows retries.
+func (p DownlinkRetryPolicy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// BackoffForAttempt returns the time to wait before the given attempt
+// (starting at 2 for the first retry).
+func (p DownlinkRetryPolicy) BackoffForAttempt(attempt int) time.Duration {
+	if attempt < 2 {
+		return 0
+	}
+	return time.Duration(p.Backoff) * time.Second << uint(attempt-2)
+}
+
+// DeviceQueueItemStatus holds the delivery status of an enqueued downlink.
+// The FCnt is nil as long as a scheduled item has not been added to the
+// network-server device-queue. When the retry policy is enabled, the FPort,
+// Data and Object are stored so that the downlink can be enqueued again
+// and Attempt holds the current attempt.
+type DeviceQueueItemStatus struct {
+	ID                         int64                `db:"id"`
+	CreatedAt                  time.Time            `db:"created_at"`
+	UpdatedAt                  time.Time            `db:"updated_at"`
+	DevEUI                     lorawan.EUI64        `db:"dev_eui"`
+	Reference                  string               `db:"reference"`
+	Confirmed                  bool                 `db:"confirmed"`
+	FCnt                       *uint32              `db:"f_cnt"`
+	DeviceQueueScheduledItemID *int64               `db:"device_queue_scheduled_item_id"`
+	State                      DeviceQueueItemState `db:"state"`
+	SentAt                     *time.Time           `db:"sent_at"`
+	CompletedAt                *time.Time           `db:"completed_at"`
+	Attempt                    int                  `db:"attempt"`
+	FPort                      uint8                `db:"f_port"`
+	Data                       []byte               `db:"data"`
+	Object                     *string              `db:"object"`
+	DownlinkRetryPolicy
+}
+
+// SetState sets the given state and the sent and completed timestamps
+// implied by it.
+func (s *DeviceQueueItemStatus) SetState(state DeviceQueueItemState, t time.Time) {
+	s.State = state
+
+	switch state {
+	case DeviceQueueItemSent, DeviceQueueItemAcknowledged, DeviceQueueItemNacked:
+		if s.SentAt == nil {
+			s.SentAt = &t
+		}
+	}
+
+	switch state {
+	case DeviceQueueItemAcknowledged, DeviceQueueItemNacked, DeviceQueueItemExpired, DeviceQueueItemFlushed, DeviceQueueItemFailed:
+		s.CompletedAt = &t
+	}
+}
+
+// CreateDeviceQueueItemStatus creates the given device-queue item status.
+func CreateDeviceQueueItemStatus(db sqlx.Queryer, s *DeviceQueueItemStatus) error {
+	if err := s.DownlinkRetryPolicy.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	if s.Attempt == 0 {
+		s.Attempt = 1
+	}
+
+	err := sqlx.Get(db, &s.ID, `
+		insert into device_queue_item_status (
+			created_at,
+			updated_at,
+			dev_eui,
+			reference,
+			confirmed,
+			f_cnt,
+			device_queue_scheduled_item_id,
+			state,
+			sent_at,
+			completed_at,
+			attempt,
+			f_port,
+			data,
+			object,
+			retry_max_attempts,
+			retry_backoff,
+			retry_reencode
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		returning id`,
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.DevEUI[:],
+		s.Reference,
+		s.Confirmed,
+		s.FCnt,
+		s.DeviceQueueScheduledItemID,
+		s.State,
+		s.SentAt,
+		s.CompletedAt,
+		s.Attempt,
+		s.FPort,
+		s.Data,
+		s.Object,
+		s.DownlinkRetryPolicy.MaxAttempts,
+		s.DownlinkRetryPolicy.Backoff,
+		s.DownlinkRetryPolicy.Reencode,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":        s.ID,
+		"dev_eui":   s.DevEUI,
+		"reference": s.Reference,
+		"state":     s.State,
+	}).Info("device-queue item status created")
+
+	return nil
+}
+
+// UpdateDeviceQueueItemStatus updates the given device-queue item status.
+func UpdateDeviceQueueItemStatus(db sqlx.Execer, s *DeviceQueueItemStatus) error {
+	s.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+		update device_queue_item_status
+		set
+			updated_at = $2,
+			f_cnt = $3,
+			state = $4,
+			sent_at = $5,
+			completed_at = $6,
+			device_queue_scheduled_item_id = $7,
+			attempt = $8,
+			data = $9
+		where
+			id = $1`,
+		s.ID,
+		s.UpdatedAt,
+		s.FCnt,
+		s.State,
+		s.SentAt,
+		s.CompletedAt,
+		s.DeviceQueueScheduledItemID,
+		s.Attempt,
+		s.Data,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":        s.ID,
+		"dev_eui":   s.DevEUI,
+		"reference": s.Reference,
+		"state":     s.State,
+	}).Info("device-queue item status updated")
+
+	return nil
+}
+
+// GetDeviceQueueItemStatusForReference returns the most recent device-queue
+// item status for the given DevEUI and reference.
+func GetDeviceQueueItemStatusForReference(db sqlx.Queryer, devEUI lorawan.EUI64, reference string) (DeviceQueueItemStatus, error) {
+	var s DeviceQueueItemStatus
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			device_queue_item_status
+		where
+			dev_eui = $1
+			and reference = $2
+		order by id desc
+		limit 1`,
+		devEUI[:],
+		reference,
+	)
+	if err != nil {
+		return s, handlePSQLError(Select, err, "select error")
+	}
+
+	return s, nil
+}
+
+// GetDeviceQueueItemStatusForFCnt returns the most recent device-queue item
+// status for the given DevEUI and FCnt.
+func GetDeviceQueueItemStatusForFCnt(db sqlx.Queryer, devEUI lorawan.EUI64, fCnt uint32) (DeviceQueueItemStatus, error) {
+	var s DeviceQueueItemStatus
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			device_queue_item_status
+		where
+			dev_eui = $1
+			and f_cnt = $2
+		order by id desc
+		limit 1`,
+		devEUI[:],
+		fCnt,
+	)
+	if err != nil {
+		return s, handlePSQLError(Select, err, "select error")
+	}
+
+	return s, nil
+}
+
+// GetDeviceQueueItemStatusForScheduledItem returns the device-queue item
+// status for the given device-queue scheduled item ID.
+func GetDeviceQueueItemStatusForScheduledItem(db sqlx.Queryer, id int64) (DeviceQueueItemStatus, error) {
+	var s DeviceQueueItemStatus
+	err := sqlx.Get(db, &s, "select * from device_queue_item_status where device_queue_scheduled_item_id = $1", id)
+	if err != nil {
+		return s, handlePSQLError(Select, err, "select error")
+	}
+
+	return s, nil
+}
+
+// SetDeviceQueueItemStatusSentBeforeFCnt sets the queued items (and the
+// items of which the state is unknown) of the given DevEUI with a FCnt lower
+// than the given FCnt to sent, as the network-server transmits the
+// device-queue items in order.
+func SetDeviceQueueItemStatusSentBeforeFCnt(db sqlx.Execer, devEUI lorawan.EUI64, fCnt uint32) error {
+	_, err := db.Exec(`
+		update device_queue_item_status
+		set
+			updated_at = now(),
+			state = $3,
+			sent_at = now()
+		where
+			dev_eui = $1
+			and f_cnt < $2
+			and state in ($4, $5)`,
+		devEUI[:],
+		fCnt,
+		DeviceQueueItemSent,
+		DeviceQueueItemQueued,
+		DeviceQueueItemUnknown,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	return nil
+}
+
+// FlushDeviceQueueItemStatusForDevEUI sets the items of the given DevEUI
+// which are still in the network-server device-queue to flushed.
+func FlushDeviceQueueItemStatusForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	_, err := db.Exec(`
+		update device_queue_item_status
+		set
+			updated_at = now(),
+			state = $2,
+			completed_at = now()
+		where
+			dev_eui = $1
+			and f_cnt is not null
+			and state = $3`,
+		devEUI[:],
+		DeviceQueueItemFlushed,
+		DeviceQueueItemQueued,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	return nil
+}
+
+// FlushScheduledDeviceQueueItemStatusForDevEUI sets the items of the given
+// DevEUI which are still scheduled to flushed.
+func FlushScheduledDeviceQueueItemStatusForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	_, err := db.Exec(`
+		update device_queue_item_status
+		set
+			updated_at = now(),
+			state = $2,
+			completed_at = now()
+		where
+			dev_eui = $1
+			and f_cnt is null
+			and state = $3`,
+		devEUI[:],
+		DeviceQueueItemFlushed,
+		DeviceQueueItemQueued,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	return nil
+}
+
+// DeleteDeviceQueueItemStatusBefore deletes the device-queue item statuses
+// which have not been updated since the given time. Statuses of which the
+// scheduled item is still pending are kept. It returns the number of
+// deleted statuses.
+func DeleteDeviceQueueItemStatusBefore(db sqlx.Execer, t time.Time) (int64, error) {
+	res, err := db.Exec(`
+		delete from device_queue_item_status s
+		where
+			s.updated_at < $1
+			and not exists (
+				select 1
+				from device_queue_scheduled_item dqsi
+				where
+					dqsi.id = s.device_queue_scheduled_item_id
+			)`,
+		t,
+	)
+	if err != nil {
+		return 0, handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "get rows affected error")
+	}
+
+	return ra, nil
+}
@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDeviceQueueItemStatus(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with a device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		d := Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(CreateDevice(db, &d), ShouldBeNil)
+
+		Convey("When creating a device-queue item status", func() {
+			fCnt := uint32(10)
+			s := DeviceQueueItemStatus{
+				DevEUI:    d.DevEUI,
+				Reference: "test-1234",
+				Confirmed: true,
+				FCnt:      &fCnt,
+				State:     DeviceQueueItemQueued,
+			}
+			So(CreateDeviceQueueItemStatus(db, &s), ShouldBeNil)
+			s.CreatedAt = s.CreatedAt.Truncate(time.Millisecond).UTC()
+			s.UpdatedAt = s.UpdatedAt.Truncate(time.Millisecond).UTC()
+
+			Convey("Then it can be retrieved by reference", func() {
+				s2, err := GetDeviceQueueItemStatusForReference(db, d.DevEUI, "test-1234")
+				So(err, ShouldBeNil)
+				s2.CreatedAt = s2.CreatedAt.Truncate(time.Millisecond).UTC()
+				s2.UpdatedAt = s2.UpdatedAt.Truncate(time.Millisecond).UTC()
+				So(s2, ShouldResemble, s)
+
+				_, err = GetDeviceQueueItemStatusForReference(db, d.DevEUI, "test-4321")
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then it can be retrieved by FCnt", func() {
+				s2, err := GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+				So(err, ShouldBeNil)
+				So(s2.ID, ShouldEqual, s.ID)
+
+				_, err = GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 11)
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then it can be set to acknowledged", func() {
+				s.SetState(DeviceQueueItemAcknowledged, time.Now())
+				So(UpdateDeviceQueueItemStatus(db, &s), ShouldBeNil)
+
+				s2, err := GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+				So(err, ShouldBeNil)
+				So(s2.State, ShouldEqual, DeviceQueueItemAcknowledged)
+				So(s2.SentAt, ShouldNotBeNil)
+				So(s2.CompletedAt, ShouldNotBeNil)
+			})
+
+			Convey("Then a more recent item with the same reference is returned", func() {
+				s2 := DeviceQueueItemStatus{
+					DevEUI:    d.DevEUI,
+					Reference: "test-1234",
+					State:     DeviceQueueItemQueued,
+				}
+				So(CreateDeviceQueueItemStatus(db, &s2), ShouldBeNil)
+
+				s3, err := GetDeviceQueueItemStatusForReference(db, d.DevEUI, "test-1234")
+				So(err, ShouldBeNil)
+				So(s3.ID, ShouldEqual, s2.ID)
+			})
+
+			Convey("Then it is set to sent when a later item is acknowledged", func() {
+				So(SetDeviceQueueItemStatusSentBeforeFCnt(db, d.DevEUI, 10), ShouldBeNil)
+				s2, err := GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+				So(err, ShouldBeNil)
+				So(s2.State, ShouldEqual, DeviceQueueItemQueued)
+
+				So(SetDeviceQueueItemStatusSentBeforeFCnt(db, d.DevEUI, 11), ShouldBeNil)
+				s2, err = GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+				So(err, ShouldBeNil)
+				So(s2.State, ShouldEqual, DeviceQueueItemSent)
+				So(s2.SentAt, ShouldNotBeNil)
+				So(s2.CompletedAt, ShouldBeNil)
+			})
+
+			Convey("Then it is set to sent from unknown when a later item is acknowledged", func() {
+				s.SetState(DeviceQueueItemUnknown, time.Now())
+				So(UpdateDeviceQueueItemStatus(db, &s), ShouldBeNil)
+
+				So(SetDeviceQueueItemStatusSentBeforeFCnt(db, d.DevEUI, 11), ShouldBeNil)
+				s2, err := GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+				So(err, ShouldBeNil)
+				So(s2.State, ShouldEqual, DeviceQueueItemSent)
+			})
+
+			Convey("Then it is only deleted after it has not been updated since the given time", func() {
+				count, err := DeleteDeviceQueueItemStatusBefore(db, s.UpdatedAt.Add(-time.Second))
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+
+				count, err = DeleteDeviceQueueItemStatusBefore(db, time.Now().Add(time.Second))
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				_, err = GetDeviceQueueItemStatusForReference(db, d.DevEUI, "test-1234")
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Given a scheduled item status", func() {
+				itemID := int64(123)
+				scheduled := DeviceQueueItemStatus{
+					DevEUI:                     d.DevEUI,
+					Reference:                  "test-scheduled",
+					DeviceQueueScheduledItemID: &itemID,
+					State:                      DeviceQueueItemQueued,
+				}
+				So(CreateDeviceQueueItemStatus(db, &scheduled), ShouldBeNil)
+
+				Convey("Then it is not deleted while the scheduled item is pending", func() {
+					item := DeviceQueueScheduledItem{
+						DevEUI:      d.DevEUI,
+						Reference:   "test-scheduled",
+						FPort:       10,
+						Data:        []byte{1, 2, 3},
+						ScheduledAt: time.Now().Add(time.Hour),
+					}
+					So(CreateDeviceQueueScheduledItem(db, &item), ShouldBeNil)
+					scheduled.DeviceQueueScheduledItemID = &item.ID
+					So(UpdateDeviceQueueItemStatus(db, &scheduled), ShouldBeNil)
+
+					_, err := DeleteDeviceQueueItemStatusBefore(db, time.Now().Add(time.Second))
+					So(err, ShouldBeNil)
+
+					s2, err := GetDeviceQueueItemStatusForScheduledItem(db, item.ID)
+					So(err, ShouldBeNil)
+					So(s2.ID, ShouldEqual, scheduled.ID)
+				})
+
+				Convey("Then it can be retrieved by scheduled item id", func() {
+					s2, err := GetDeviceQueueItemStatusForScheduledItem(db, itemID)
+					So(err, ShouldBeNil)
+					So(s2.ID, ShouldEqual, scheduled.ID)
+					So(s2.FCnt, ShouldBeNil)
+				})
+
+				Convey("Then flushing the device-queue does not flush the scheduled item", func() {
+					So(FlushDeviceQueueItemStatusForDevEUI(db, d.DevEUI), ShouldBeNil)
+
+					s2, err := GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+					So(err, ShouldBeNil)
+					So(s2.State, ShouldEqual, DeviceQueueItemFlushed)
+					So(s2.CompletedAt, ShouldNotBeNil)
+
+					s2, err = GetDeviceQueueItemStatusForScheduledItem(db, itemID)
+					So(err, ShouldBeNil)
+					So(s2.State, ShouldEqual, DeviceQueueItemQueued)
+				})
+
+				Convey("Then flushing the scheduled items only flushes the scheduled item", func() {
+					So(FlushScheduledDeviceQueueItemStatusForDevEUI(db, d.DevEUI), ShouldBeNil)
+
+					s2, err := GetDeviceQueueItemStatusForFCnt(db, d.DevEUI, 10)
+					So(err, ShouldBeNil)
+					So(s2.State, ShouldEqual, DeviceQueueItemQueued)
+
+					s2, err = GetDeviceQueueItemStatusForScheduledItem(db, itemID)
+					So(err, ShouldBeNil)
+					So(s2.State, ShouldEqual, DeviceQueueItemFlushed)
+				})
+			})
+		})
+	})
+}
+
+func TestDownlinkRetryPolicy(t *testing.T) {
+	Convey("Given a set of downlink retry policies", t, func() {
+		tests := []struct {
+			Policy  DownlinkRetryPolicy
+			Error   error
+			Enabled bool
+		}{
+			{DownlinkRetryPolicy{}, nil, false},
+			{DownlinkRetryPolicy{MaxAttempts: 1}, nil, false},
+			{DownlinkRetryPolicy{MaxAttempts: 3, Backoff: 10}, nil, true},
+			{DownlinkRetryPolicy{MaxAttempts: MaxDownlinkRetryAttempts + 1}, ErrDownlinkRetryPolicyInvalid, true},
+			{DownlinkRetryPolicy{MaxAttempts: 3, Backoff: -1}, ErrDownlinkRetryPolicyInvalid, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Then policy %d validates and is enabled as expected", i), func() {
+				So(test.Policy.Validate(), ShouldEqual, test.Error)
+				So(test.Policy.Enabled(), ShouldEqual, test.Enabled)
+			})
+		}
+
+		Convey("Then the backoff is doubled after each attempt", func() {
+			p := DownlinkRetryPolicy{MaxAttempts: 4, Backoff: 10}
+			So(p.BackoffForAttempt(1), ShouldEqual, 0)
+			So(p.BackoffForAttempt(2), ShouldEqual, 10*time.Second)
+			So(p.BackoffForAttempt(3), ShouldEqual, 20*time.Second)
+			So(p.BackoffForAttempt(4), ShouldEqual, 40*time.Second)
+		})
+	})
+}
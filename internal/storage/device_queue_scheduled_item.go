@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceQueueScheduledItem holds a downlink payload which will be added to
+// the device-queue once it is due (at ScheduledAt). When ExpiresAt is set and
+// the item could not be enqueued before this time, it is dropped.
+type DeviceQueueScheduledItem struct {
+	ID          int64         `db:"id"`
+	CreatedAt   time.Time     `db:"created_at"`
+	DevEUI      lorawan.EUI64 `db:"dev_eui"`
+	Reference   string        `db:"reference"`
+	Confirmed   bool          `db:"confirmed"`
+	FPort       uint8         `db:"f_port"`
+	Data        []byte        `db:"data"`
+	ScheduledAt time.Time     `db:"scheduled_at"`
+	ExpiresAt   *time.Time    `db:"expires_at"`
+}
+
+// Validate validates the device-queue scheduled item data.
+func (i DeviceQueueScheduledItem) Validate() error {
+	if i.ExpiresAt != nil && (!i.ExpiresAt.After(i.ScheduledAt) || !i.ExpiresAt.After(time.Now())) {
+		return ErrInvalidDownlinkSchedule
+	}
+	return nil
+}
+
+// Expired returns true when the item has expired at the given time.
+func (i DeviceQueueScheduledItem) Expired(t time.Time) bool {
+	return i.ExpiresAt != nil && !i.ExpiresAt.After(t)
+}
+
+// CreateDeviceQueueScheduledItem creates the given device-queue scheduled
+// item.
+func CreateDeviceQueueScheduledItem(db sqlx.Queryer, item *DeviceQueueScheduledItem) error {
+	if err := item.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	item.CreatedAt = time.Now()
+
+	err := sqlx.Get(db, &item.ID, `
+		insert into device_queue_scheduled_item (
+			created_at,
+			dev_eui,
+			reference,
+			confirmed,
+			f_port,
+			data,
+			scheduled_at,
+			expires_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+		returning id`,
+		item.CreatedAt,
+		item.DevEUI[:],
+		item.Reference,
+		item.Confirmed,
+		item.FPort,
+		item.Data,
+		item.ScheduledAt,
+		item.ExpiresAt,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":           item.ID,
+		"dev_eui":      item.DevEUI,
+		"reference":    item.Reference,
+		"scheduled_at": item.ScheduledAt,
+	}).Info("device-queue scheduled item created")
+
+	return nil
+}
+
+// GetDeviceQueueScheduledItem returns the device-queue scheduled item
+// matching the given ID.
+func GetDeviceQueueScheduledItem(db sqlx.Queryer, id int64) (DeviceQueueScheduledItem, error) {
+	var item DeviceQueueScheduledItem
+	err := sqlx.Get(db, &item, "select * from device_queue_scheduled_item where id = $1", id)
+	if err != nil {
+		return item, handlePSQLError(Select, err, "select error")
+	}
+
+	return item, nil
+}
+
+// GetDeviceQueueScheduledItemsForDevEUI returns the pending scheduled items
+// for the given DevEUI, ordered by the time they are scheduled.
+func GetDeviceQueueScheduledItemsForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) ([]DeviceQueueScheduledItem, error) {
+	var items []DeviceQueueScheduledItem
+	err := sqlx.Select(db, &items, `
+		select
+			*
+		from
+			device_queue_scheduled_item
+		where
+			dev_eui = $1
+		order by scheduled_at, id`,
+		devEUI[:],
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return items, nil
+}
+
+// DeleteDeviceQueueScheduledItem deletes the device-queue scheduled item
+// matching the given ID.
+func DeleteDeviceQueueScheduledItem(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from device_queue_scheduled_item where id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	log.WithField("id", id).Info("device-queue scheduled item deleted")
+	return nil
+}
+
+// FlushDeviceQueueScheduledItemsForDevEUI deletes all the device-queue
+// scheduled items for the given DevEUI.
+func FlushDeviceQueueScheduledItemsForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	_, err := db.Exec("delete from device_queue_scheduled_item where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	return nil
+}
+
+// GetDueDeviceQueueScheduledItemForUpdate returns and locks the device-queue
+// scheduled item which is due the longest. Items locked by an other
+// transaction are skipped, so that multiple instances can handle items
+// concurrently. ErrDoesNotExist is returned when no item is due. Note that
+// this must be executed within a transaction, the item must be deleted
+// within the same transaction once it has been handled.
+func GetDueDeviceQueueScheduledItemForUpdate(db sqlx.Queryer) (DeviceQueueScheduledItem, error) {
+	var item DeviceQueueScheduledItem
+	err := sqlx.Get(db, &item, `
+		select
+			*
+		from
+			device_queue_scheduled_item
+		where
+			scheduled_at <= now()
+		order by scheduled_at, id
+		limit 1
+		for update skip locked`,
+	)
+	if err != nil {
+		return item, handlePSQLError(Select, err, "select error")
+	}
+
+	return item, nil
+}
+
+// PostponeDeviceQueueScheduledItem sets the scheduled time of the
+// device-queue scheduled item matching the given ID to the given time.
+func PostponeDeviceQueueScheduledItem(db sqlx.Execer, id int64, scheduledAt time.Time) error {
+	res, err := db.Exec("update device_queue_scheduled_item set scheduled_at = $2 where id = $1", id, scheduledAt)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	return nil
+}
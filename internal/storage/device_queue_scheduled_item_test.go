@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/pkg/errors"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestDeviceQueueScheduledItem(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database and a device", t, func() {
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(config.C.PostgreSQL.DB, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(config.C.PostgreSQL.DB, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			NetworkServerID: n.ID,
+			OrganizationID:  org.ID,
+			Name:            "test-sp",
+		}
+		So(CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			NetworkServerID: n.ID,
+			OrganizationID:  org.ID,
+			Name:            "test-dp",
+		}
+		So(CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(config.C.PostgreSQL.DB, &app), ShouldBeNil)
+
+		d := Device{
+			Name:            "test-device",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(CreateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
+
+		Convey("When creating a scheduled item which expires before it is scheduled", func() {
+			expiresAt := time.Now().Add(time.Minute)
+			item := DeviceQueueScheduledItem{
+				DevEUI:      d.DevEUI,
+				FPort:       10,
+				Data:        []byte{1, 2, 3, 4},
+				ScheduledAt: time.Now().Add(time.Hour),
+				ExpiresAt:   &expiresAt,
+			}
+			err := CreateDeviceQueueScheduledItem(config.C.PostgreSQL.DB, &item)
+
+			Convey("Then ErrInvalidDownlinkSchedule is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrInvalidDownlinkSchedule)
+			})
+		})
+
+		Convey("When creating a scheduled item", func() {
+			expiresAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Millisecond)
+			item := DeviceQueueScheduledItem{
+				DevEUI:      d.DevEUI,
+				Reference:   "test-123",
+				Confirmed:   true,
+				FPort:       10,
+				Data:        []byte{1, 2, 3, 4},
+				ScheduledAt: time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond),
+				ExpiresAt:   &expiresAt,
+			}
+			So(CreateDeviceQueueScheduledItem(config.C.PostgreSQL.DB, &item), ShouldBeNil)
+			item.CreatedAt = item.CreatedAt.UTC().Truncate(time.Millisecond)
+
+			Convey("Then it can be retrieved", func() {
+				itemGet, err := GetDeviceQueueScheduledItem(config.C.PostgreSQL.DB, item.ID)
+				So(err, ShouldBeNil)
+				itemGet.CreatedAt = itemGet.CreatedAt.UTC().Truncate(time.Millisecond)
+				itemGet.ScheduledAt = itemGet.ScheduledAt.UTC()
+				expires := itemGet.ExpiresAt.UTC()
+				itemGet.ExpiresAt = &expires
+				So(itemGet, ShouldResemble, item)
+			})
+
+			Convey("Then it is returned for the DevEUI", func() {
+				items, err := GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+				So(items[0].ID, ShouldEqual, item.ID)
+			})
+
+			Convey("Then it is not returned before it is due", func() {
+				_, err := GetDueDeviceQueueScheduledItemForUpdate(config.C.PostgreSQL.DB)
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("When the item is due", func() {
+				_, err := config.C.PostgreSQL.DB.Exec("update device_queue_scheduled_item set scheduled_at = now() where id = $1", item.ID)
+				So(err, ShouldBeNil)
+
+				Convey("Then GetDueDeviceQueueScheduledItemForUpdate returns it", func() {
+					due, err := GetDueDeviceQueueScheduledItemForUpdate(config.C.PostgreSQL.DB)
+					So(err, ShouldBeNil)
+					So(due.ID, ShouldEqual, item.ID)
+				})
+
+				Convey("Then the item is skipped while locked by an other transaction", func() {
+					tx, err := config.C.PostgreSQL.DB.Beginx()
+					So(err, ShouldBeNil)
+					defer tx.Rollback()
+
+					due, err := GetDueDeviceQueueScheduledItemForUpdate(tx)
+					So(err, ShouldBeNil)
+					So(due.ID, ShouldEqual, item.ID)
+
+					_, err = GetDueDeviceQueueScheduledItemForUpdate(config.C.PostgreSQL.DB)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("When postponing the item", func() {
+					So(PostponeDeviceQueueScheduledItem(config.C.PostgreSQL.DB, item.ID, time.Now().Add(time.Minute)), ShouldBeNil)
+
+					Convey("Then it is no longer due", func() {
+						_, err := GetDueDeviceQueueScheduledItemForUpdate(config.C.PostgreSQL.DB)
+						So(err, ShouldEqual, ErrDoesNotExist)
+					})
+				})
+			})
+
+			Convey("Then DeleteDeviceQueueScheduledItem deletes the item", func() {
+				So(DeleteDeviceQueueScheduledItem(config.C.PostgreSQL.DB, item.ID), ShouldBeNil)
+				So(DeleteDeviceQueueScheduledItem(config.C.PostgreSQL.DB, item.ID), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then FlushDeviceQueueScheduledItemsForDevEUI flushes all items", func() {
+				So(FlushDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, d.DevEUI), ShouldBeNil)
+				items, err := GetDeviceQueueScheduledItemsForDevEUI(config.C.PostgreSQL.DB, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
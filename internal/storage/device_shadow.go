@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceShadow holds the desired state of a device, as set by the
+// application, and the state reported by the device (the merged objects
+// of the decoded uplinks). Both are JSON objects. The difference between
+// the two (the delta) is encoded using the application codec and enqueued
+// on FPort when the device is seen. DeltaSent holds the last enqueued delta
+// and DeltaSentAt the time it was enqueued, so that the same delta is not
+// enqueued on every uplink.
+type DeviceShadow struct {
+	DevEUI         lorawan.EUI64 `db:"dev_eui"`
+	CreatedAt      time.Time     `db:"created_at"`
+	UpdatedAt      time.Time     `db:"updated_at"`
+	Desired        *string       `db:"desired"`
+	DesiredVersion int64         `db:"desired_version"`
+	Reported       *string       `db:"reported"`
+	ReportedAt     *time.Time    `db:"reported_at"`
+	FPort          uint8         `db:"f_port"`
+	Confirmed      bool          `db:"confirmed"`
+	DeltaSent      *string       `db:"delta_sent"`
+	DeltaSentAt    *time.Time    `db:"delta_sent_at"`
+}
+
+// Validate validates the device shadow data.
+func (s DeviceShadow) Validate() error {
+	if s.FPort == 0 || s.FPort > 223 {
+		return ErrDeviceShadowInvalid
+	}
+
+	if s.Desired != nil {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(*s.Desired), &obj); err != nil || obj == nil {
+			return ErrDeviceShadowInvalid
+		}
+	}
+
+	return nil
+}
+
+// CreateDeviceShadow creates the given device shadow.
+func CreateDeviceShadow(db sqlx.Execer, s *DeviceShadow) error {
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	_, err := db.Exec(`
+		insert into device_shadow (
+			dev_eui,
+			created_at,
+			updated_at,
+			desired,
+			desired_version,
+			reported,
+			reported_at,
+			f_port,
+			confirmed,
+			delta_sent,
+			delta_sent_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		s.DevEUI[:],
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.Desired,
+		s.DesiredVersion,
+		s.Reported,
+		s.ReportedAt,
+		s.FPort,
+		s.Confirmed,
+		s.DeltaSent,
+		s.DeltaSentAt,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": s.DevEUI,
+	}).Info("device shadow created")
+
+	return nil
+}
+
+// GetDeviceShadow returns the device shadow for the given DevEUI. When
+// forUpdate is set, the shadow is locked until the end of the transaction.
+func GetDeviceShadow(db sqlx.Queryer, devEUI lorawan.EUI64, forUpdate bool) (DeviceShadow, error) {
+	var fu string
+	if forUpdate {
+		fu = " for update"
+	}
+
+	var s DeviceShadow
+	err := sqlx.Get(db, &s, "select * from device_shadow where dev_eui = $1"+fu, devEUI[:])
+	if err != nil {
+		return s, handlePSQLError(Select, err, "select error")
+	}
+
+	return s, nil
+}
+
+// UpdateDeviceShadow updates the given device shadow.
+func UpdateDeviceShadow(db sqlx.Execer, s *DeviceShadow) error {
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	s.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+		update device_shadow
+		set
+			updated_at = $2,
+			desired = $3,
+			desired_version = $4,
+			reported = $5,
+			reported_at = $6,
+			f_port = $7,
+			confirmed = $8,
+			delta_sent = $9,
+			delta_sent_at = $10
+		where
+			dev_eui = $1`,
+		s.DevEUI[:],
+		s.UpdatedAt,
+		s.Desired,
+		s.DesiredVersion,
+		s.Reported,
+		s.ReportedAt,
+		s.FPort,
+		s.Confirmed,
+		s.DeltaSent,
+		s.DeltaSentAt,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":         s.DevEUI,
+		"desired_version": s.DesiredVersion,
+	}).Info("device shadow updated")
+
+	return nil
+}
+
+// DeleteDeviceShadow deletes the device shadow for the given DevEUI.
+func DeleteDeviceShadow(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	res, err := db.Exec("delete from device_shadow where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("dev_eui", devEUI).Info("device shadow deleted")
+	return nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDeviceShadow(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with a device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		d := Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(CreateDevice(db, &d), ShouldBeNil)
+
+		Convey("Then an invalid shadow can not be created", func() {
+			desired := `[1, 2, 3]`
+			for _, s := range []DeviceShadow{
+				{DevEUI: d.DevEUI},
+				{DevEUI: d.DevEUI, FPort: 224},
+				{DevEUI: d.DevEUI, FPort: 10, Desired: &desired},
+			} {
+				So(errors.Cause(CreateDeviceShadow(db, &s)), ShouldEqual, ErrDeviceShadowInvalid)
+			}
+		})
+
+		Convey("When creating a device shadow", func() {
+			desired := `{"interval": 60}`
+			s := DeviceShadow{
+				DevEUI:         d.DevEUI,
+				Desired:        &desired,
+				DesiredVersion: 1,
+				FPort:          10,
+			}
+			So(CreateDeviceShadow(db, &s), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				s2, err := GetDeviceShadow(db, d.DevEUI, false)
+				So(err, ShouldBeNil)
+				So(*s2.Desired, ShouldEqual, desired)
+				So(s2.DesiredVersion, ShouldEqual, 1)
+				So(s2.FPort, ShouldEqual, 10)
+				So(s2.Reported, ShouldBeNil)
+			})
+
+			Convey("Then it can be updated", func() {
+				reported := `{"interval": 30}`
+				now := time.Now()
+				s.Reported = &reported
+				s.ReportedAt = &now
+				s.DeltaSent = &desired
+				s.DeltaSentAt = &now
+				s.Confirmed = true
+				So(UpdateDeviceShadow(db, &s), ShouldBeNil)
+
+				s2, err := GetDeviceShadow(db, d.DevEUI, false)
+				So(err, ShouldBeNil)
+				So(*s2.Reported, ShouldEqual, reported)
+				So(*s2.DeltaSent, ShouldEqual, desired)
+				So(s2.ReportedAt, ShouldNotBeNil)
+				So(s2.DeltaSentAt, ShouldNotBeNil)
+				So(s2.Confirmed, ShouldBeTrue)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteDeviceShadow(db, d.DevEUI), ShouldBeNil)
+				_, err := GetDeviceShadow(db, d.DevEUI, false)
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+				So(errors.Cause(DeleteDeviceShadow(db, d.DevEUI)), ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
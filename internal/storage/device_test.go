@@ -8,6 +8,8 @@ import (
 
 	"github.com/brocaar/lorawan"
 
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/brocaar/lora-app-server/internal/config"
@@ -114,6 +116,7 @@ func TestDevice(t *testing.T) {
 				DeviceStatusBattery: &ten,
 				DeviceStatusMargin:  &eleven,
 				SkipFCntCheck:       true,
+				Tags:                DeviceTags{"building": "a"},
 			}
 			So(CreateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
 			d.CreatedAt = d.CreatedAt.UTC().Truncate(time.Millisecond)
@@ -122,10 +125,10 @@ func TestDevice(t *testing.T) {
 			So(nsClient.CreateDeviceChan, ShouldHaveLength, 1)
 			So(<-nsClient.CreateDeviceChan, ShouldResemble, ns.CreateDeviceRequest{
 				Device: &ns.Device{
-					DevEUI:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
-					DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
-					ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
-					RoutingProfileID: config.C.ApplicationServer.ID,
+					DevEui:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					DeviceProfileId:  uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
+					ServiceProfileId: uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
+					RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 					SkipFCntCheck:    true,
 				},
 			})
@@ -133,10 +136,10 @@ func TestDevice(t *testing.T) {
 			Convey("Then GetDevice returns the device", func() {
 				nsClient.GetDeviceResponse = ns.GetDeviceResponse{
 					Device: &ns.Device{
-						DevEUI:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
-						DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
-						ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
-						RoutingProfileID: config.C.ApplicationServer.ID,
+						DevEui:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						DeviceProfileId:  uuid.FromStringOrNil(dp.DeviceProfile.DeviceProfileID).Bytes(),
+						ServiceProfileId: uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
+						RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 						SkipFCntCheck:    true,
 					},
 				}
@@ -159,16 +162,17 @@ func TestDevice(t *testing.T) {
 
 					d.Name = "updated-test-device"
 					d.DeviceProfileID = dp2.DeviceProfile.DeviceProfileID
+					d.Tags = DeviceTags{"building": "b", "floor": "2"}
 					So(UpdateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
 					d.UpdatedAt = d.UpdatedAt.UTC().Truncate(time.Millisecond)
 
 					So(nsClient.UpdateDeviceChan, ShouldHaveLength, 1)
 					So(<-nsClient.UpdateDeviceChan, ShouldResemble, ns.UpdateDeviceRequest{
 						Device: &ns.Device{
-							DevEUI:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
-							DeviceProfileID:  dp2.DeviceProfile.DeviceProfileID,
-							ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
-							RoutingProfileID: config.C.ApplicationServer.ID,
+							DevEui:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
+							DeviceProfileId:  uuid.FromStringOrNil(dp2.DeviceProfile.DeviceProfileID).Bytes(),
+							ServiceProfileId: uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
+							RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 							SkipFCntCheck:    true,
 						},
 					})
@@ -184,7 +188,7 @@ func TestDevice(t *testing.T) {
 					So(DeleteDevice(config.C.PostgreSQL.DB, d.DevEUI), ShouldBeNil)
 					So(nsClient.DeleteDeviceChan, ShouldHaveLength, 1)
 					So(<-nsClient.DeleteDeviceChan, ShouldResemble, ns.DeleteDeviceRequest{
-						DevEUI: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						DevEui: []byte{1, 2, 3, 4, 5, 6, 7, 8},
 					})
 
 					_, err := GetDevice(config.C.PostgreSQL.DB, d.DevEUI)
@@ -195,6 +199,7 @@ func TestDevice(t *testing.T) {
 					dc := DeviceKeys{
 						DevEUI:    d.DevEUI,
 						AppKey:    lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+						NwkKey:    lorawan.AES128Key{1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
 						JoinNonce: 1234,
 					}
 					So(CreateDeviceKeys(config.C.PostgreSQL.DB, &dc), ShouldBeNil)
@@ -211,7 +216,10 @@ func TestDevice(t *testing.T) {
 
 					Convey("Then UpdateDeviceKeys updates the device-keys", func() {
 						dc.AppKey = lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+						dc.GenAppKey = lorawan.AES128Key{3, 3, 3, 3, 3, 3, 3, 3, 4, 4, 4, 4, 4, 4, 4, 4}
 						dc.JoinNonce = 1235
+						dc.JoinEUI = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+						dc.RJCount1 = 10
 						So(UpdateDeviceKeys(config.C.PostgreSQL.DB, &dc), ShouldBeNil)
 						dc.UpdatedAt = dc.UpdatedAt.UTC().Truncate(time.Millisecond)
 
@@ -258,6 +266,25 @@ func TestDevice(t *testing.T) {
 						daGet.CreatedAt = daGet.CreatedAt.UTC().Truncate(time.Millisecond)
 						So(daGet, ShouldResemble, da2)
 					})
+
+					Convey("Then GetDeviceActivationForSessionKeyID returns the device-activation", func() {
+						da2 := DeviceActivation{
+							DevEUI:       d.DevEUI,
+							DevAddr:      lorawan.DevAddr{4, 3, 2, 1},
+							SessionKeyID: []byte{1, 2, 3, 4},
+							NetID:        &lorawan.NetID{1, 2, 3},
+						}
+						So(CreateDeviceActivation(config.C.PostgreSQL.DB, &da2), ShouldBeNil)
+						da2.CreatedAt = da2.CreatedAt.UTC().Truncate(time.Millisecond)
+
+						daGet, err := GetDeviceActivationForSessionKeyID(config.C.PostgreSQL.DB, d.DevEUI, []byte{1, 2, 3, 4})
+						So(err, ShouldBeNil)
+						daGet.CreatedAt = daGet.CreatedAt.UTC().Truncate(time.Millisecond)
+						So(daGet, ShouldResemble, da2)
+
+						_, err = GetDeviceActivationForSessionKeyID(config.C.PostgreSQL.DB, d.DevEUI, []byte{4, 3, 2, 1})
+						So(err, ShouldEqual, ErrDoesNotExist)
+					})
 				})
 			})
 
@@ -274,6 +301,29 @@ func TestDevice(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(count, ShouldEqual, 1)
 			})
+
+			Convey("Then GetDevicesForApplicationIDAndSelector selects the device by its tags", func() {
+				devices, err := GetDevicesForApplicationIDAndSelector(config.C.PostgreSQL.DB, app.ID, nil, "", DeviceTags{"building": "a"})
+				So(err, ShouldBeNil)
+				So(devices, ShouldHaveLength, 1)
+				So(devices[0].Tags, ShouldResemble, DeviceTags{"building": "a"})
+
+				devices, err = GetDevicesForApplicationIDAndSelector(config.C.PostgreSQL.DB, app.ID, nil, dp.DeviceProfile.DeviceProfileID, DeviceTags{"building": "b"})
+				So(err, ShouldBeNil)
+				So(devices, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Then CreateDevice rejects a tag with an empty key", func() {
+			d := Device{
+				DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				ApplicationID:   app.ID,
+				DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+				Name:            "test-device",
+				Tags:            DeviceTags{"": "a"},
+			}
+			err := CreateDevice(config.C.PostgreSQL.DB, &d)
+			So(errors.Cause(err), ShouldEqual, ErrDeviceInvalidTags)
 		})
 	})
 }
@@ -0,0 +1,460 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// DownlinkSchedule defines a recurring downlink, which is enqueued for each
+// of its target devices every time its cron expression matches. The targets
+// are either the given list of devices (DevEUIs), all the devices of the
+// application or all the devices using the device-profile.
+type DownlinkSchedule struct {
+	ID              int64           `db:"id"`
+	OrganizationID  int64           `db:"organization_id"`
+	CreatedAt       time.Time       `db:"created_at"`
+	UpdatedAt       time.Time       `db:"updated_at"`
+	Name            string          `db:"name"`
+	CronExpression  string          `db:"cron_expression"`
+	DevEUIs         []lorawan.EUI64 `db:"-"`
+	ApplicationID   *int64          `db:"application_id"`
+	DeviceProfileID *string         `db:"device_profile_id"`
+	Confirmed       bool            `db:"confirmed"`
+	FPort           uint8           `db:"f_port"`
+	Data            []byte          `db:"data"`
+	Object          *string         `db:"object"`
+	LastRunAt       *time.Time      `db:"last_run_at"`
+	NextRunAt       time.Time       `db:"next_run_at"`
+}
+
+// Validate validates the downlink schedule data.
+func (s DownlinkSchedule) Validate() error {
+	if s.Name == "" {
+		return ErrDownlinkScheduleInvalidName
+	}
+
+	if _, err := cron.ParseStandard(s.CronExpression); err != nil {
+		return ErrDownlinkScheduleInvalidCron
+	}
+
+	var targets int
+	if len(s.DevEUIs) != 0 {
+		targets++
+	}
+	if s.ApplicationID != nil {
+		targets++
+	}
+	if s.DeviceProfileID != nil {
+		targets++
+	}
+	if targets != 1 {
+		return ErrDownlinkScheduleInvalidTarget
+	}
+
+	if s.FPort == 0 || s.FPort > 223 {
+		return ErrDownlinkScheduleInvalidPayload
+	}
+	if s.Object != nil && (len(s.Data) != 0 || !json.Valid([]byte(*s.Object))) {
+		return ErrDownlinkScheduleInvalidPayload
+	}
+
+	return nil
+}
+
+// Next returns the first time after t at which the schedule must run.
+// The cron expression is evaluated in UTC.
+func (s DownlinkSchedule) Next(t time.Time) (time.Time, error) {
+	sched, err := cron.ParseStandard(s.CronExpression)
+	if err != nil {
+		return time.Time{}, ErrDownlinkScheduleInvalidCron
+	}
+
+	return sched.Next(t.UTC()), nil
+}
+
+// CreateDownlinkSchedule creates the given downlink schedule.
+func CreateDownlinkSchedule(db sqlx.Ext, s *DownlinkSchedule) error {
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	if err := validateDownlinkScheduleTarget(db, *s); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	next, err := s.Next(now)
+	if err != nil {
+		return errors.Wrap(err, "get next run error")
+	}
+
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	s.LastRunAt = nil
+	s.NextRunAt = next
+
+	err = sqlx.Get(db, &s.ID, `
+		insert into downlink_schedule (
+			organization_id,
+			created_at,
+			updated_at,
+			name,
+			cron_expression,
+			application_id,
+			device_profile_id,
+			confirmed,
+			f_port,
+			data,
+			object,
+			next_run_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) returning id`,
+		s.OrganizationID,
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.Name,
+		s.CronExpression,
+		s.ApplicationID,
+		s.DeviceProfileID,
+		s.Confirmed,
+		s.FPort,
+		s.Data,
+		s.Object,
+		s.NextRunAt,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	if err := setDownlinkScheduleDevices(db, s.ID, s.DevEUIs); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"id":          s.ID,
+		"name":        s.Name,
+		"next_run_at": s.NextRunAt,
+	}).Info("downlink schedule created")
+
+	return nil
+}
+
+// GetDownlinkSchedule returns the downlink schedule matching the given id.
+func GetDownlinkSchedule(db sqlx.Queryer, id int64) (DownlinkSchedule, error) {
+	var s DownlinkSchedule
+	err := sqlx.Get(db, &s, "select * from downlink_schedule where id = $1", id)
+	if err != nil {
+		return s, handlePSQLError(Select, err, "select error")
+	}
+
+	s.DevEUIs, err = getDownlinkScheduleDevices(db, s.ID)
+	if err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// UpdateDownlinkSchedule updates the given downlink schedule. The next run
+// is re-calculated from the (updated) cron expression.
+func UpdateDownlinkSchedule(db sqlx.Ext, s *DownlinkSchedule) error {
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	if err := validateDownlinkScheduleTarget(db, *s); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	next, err := s.Next(now)
+	if err != nil {
+		return errors.Wrap(err, "get next run error")
+	}
+
+	s.UpdatedAt = now
+	s.NextRunAt = next
+
+	res, err := db.Exec(`
+		update downlink_schedule
+		set
+			updated_at = $2,
+			name = $3,
+			cron_expression = $4,
+			application_id = $5,
+			device_profile_id = $6,
+			confirmed = $7,
+			f_port = $8,
+			data = $9,
+			object = $10,
+			next_run_at = $11
+		where id = $1`,
+		s.ID,
+		s.UpdatedAt,
+		s.Name,
+		s.CronExpression,
+		s.ApplicationID,
+		s.DeviceProfileID,
+		s.Confirmed,
+		s.FPort,
+		s.Data,
+		s.Object,
+		s.NextRunAt,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	if err := setDownlinkScheduleDevices(db, s.ID, s.DevEUIs); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"id":          s.ID,
+		"name":        s.Name,
+		"next_run_at": s.NextRunAt,
+	}).Info("downlink schedule updated")
+
+	return nil
+}
+
+// DeleteDownlinkSchedule deletes the downlink schedule matching the given id.
+func DeleteDownlinkSchedule(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from downlink_schedule where id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("downlink schedule deleted")
+
+	return nil
+}
+
+// GetDownlinkScheduleCountForOrganizationID returns the total number of
+// downlink schedules for the given organization.
+func GetDownlinkScheduleCountForOrganizationID(db sqlx.Queryer, organizationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from downlink_schedule where organization_id = $1", organizationID)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetDownlinkSchedulesForOrganizationID returns a slice of downlink schedules
+// for the given organization, sorted by name. Note that the DevEUIs are not
+// set for the returned items.
+func GetDownlinkSchedulesForOrganizationID(db sqlx.Queryer, organizationID int64, limit, offset int) ([]DownlinkSchedule, error) {
+	var schedules []DownlinkSchedule
+	err := sqlx.Select(db, &schedules, `
+		select *
+		from downlink_schedule
+		where
+			organization_id = $1
+		order by name
+		limit $2 offset $3`,
+		organizationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return schedules, nil
+}
+
+// GetDueDownlinkScheduleForUpdate returns the downlink schedule which is due
+// the longest and locks it for update. Schedules locked by an other
+// transaction are skipped, so that multiple instances can run schedules
+// concurrently. ErrDoesNotExist is returned when no schedule is due.
+func GetDueDownlinkScheduleForUpdate(db sqlx.Queryer) (DownlinkSchedule, error) {
+	var s DownlinkSchedule
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			downlink_schedule
+		where
+			next_run_at <= now()
+		order by next_run_at, id
+		limit 1
+		for update skip locked`,
+	)
+	if err != nil {
+		return s, handlePSQLError(Select, err, "select error")
+	}
+
+	s.DevEUIs, err = getDownlinkScheduleDevices(db, s.ID)
+	if err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// SetDownlinkScheduleRun sets the last and next run of the given downlink
+// schedule.
+func SetDownlinkScheduleRun(db sqlx.Execer, id int64, lastRunAt, nextRunAt time.Time) error {
+	res, err := db.Exec(`
+		update downlink_schedule
+		set
+			last_run_at = $2,
+			next_run_at = $3
+		where id = $1`,
+		id,
+		lastRunAt,
+		nextRunAt,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	return nil
+}
+
+// GetDownlinkScheduleTargetDevEUIs returns the DevEUIs of the devices
+// targeted by the given downlink schedule.
+func GetDownlinkScheduleTargetDevEUIs(db sqlx.Queryer, s DownlinkSchedule) ([]lorawan.EUI64, error) {
+	var devEUIs []lorawan.EUI64
+	var err error
+
+	switch {
+	case s.ApplicationID != nil:
+		err = sqlx.Select(db, &devEUIs, "select dev_eui from device where application_id = $1 order by dev_eui", *s.ApplicationID)
+	case s.DeviceProfileID != nil:
+		err = sqlx.Select(db, &devEUIs, "select dev_eui from device where device_profile_id = $1 order by dev_eui", *s.DeviceProfileID)
+	default:
+		return getDownlinkScheduleDevices(db, s.ID)
+	}
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return devEUIs, nil
+}
+
+// validateDownlinkScheduleTarget validates that the target of the downlink
+// schedule belongs to the organization of the schedule.
+func validateDownlinkScheduleTarget(db sqlx.Queryer, s DownlinkSchedule) error {
+	switch {
+	case s.ApplicationID != nil:
+		return validateDownlinkScheduleTargetCount(db, `
+			select count(*)
+			from application
+			where
+				id = $1
+				and organization_id = $2`,
+			*s.ApplicationID,
+			s.OrganizationID,
+		)
+	case s.DeviceProfileID != nil:
+		return validateDownlinkScheduleTargetCount(db, `
+			select count(*)
+			from device_profile
+			where
+				device_profile_id = $1
+				and organization_id = $2`,
+			*s.DeviceProfileID,
+			s.OrganizationID,
+		)
+	}
+
+	for _, devEUI := range s.DevEUIs {
+		err := validateDownlinkScheduleTargetCount(db, `
+			select count(*)
+			from device d
+			inner join application a
+				on a.id = d.application_id
+			where
+				d.dev_eui = $1
+				and a.organization_id = $2`,
+			devEUI[:],
+			s.OrganizationID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDownlinkScheduleTargetCount(db sqlx.Queryer, query string, args ...interface{}) error {
+	var count int
+	if err := sqlx.Get(db, &count, query, args...); err != nil {
+		return handlePSQLError(Select, err, "select error")
+	}
+	if count == 0 {
+		return ErrDownlinkScheduleInvalidTarget
+	}
+	return nil
+}
+
+func setDownlinkScheduleDevices(db sqlx.Execer, id int64, devEUIs []lorawan.EUI64) error {
+	_, err := db.Exec("delete from downlink_schedule_device where downlink_schedule_id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+
+	for _, devEUI := range devEUIs {
+		_, err := db.Exec(`
+			insert into downlink_schedule_device (
+				downlink_schedule_id,
+				dev_eui
+			) values ($1, $2)`,
+			id,
+			devEUI[:],
+		)
+		if err != nil {
+			return handlePSQLError(Insert, err, "insert error")
+		}
+	}
+
+	return nil
+}
+
+func getDownlinkScheduleDevices(db sqlx.Queryer, id int64) ([]lorawan.EUI64, error) {
+	var devEUIs []lorawan.EUI64
+	err := sqlx.Select(db, &devEUIs, `
+		select dev_eui
+		from downlink_schedule_device
+		where
+			downlink_schedule_id = $1
+		order by dev_eui`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return devEUIs, nil
+}
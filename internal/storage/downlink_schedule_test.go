@@ -0,0 +1,273 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestDownlinkSchedule(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization, network-server, service-profile, device-profile and application", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		devices := []Device{
+			{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Name: "device-1", ApplicationID: app.ID, DeviceProfileID: dp.DeviceProfile.DeviceProfileID},
+			{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Name: "device-2", ApplicationID: app.ID, DeviceProfileID: dp.DeviceProfile.DeviceProfileID},
+		}
+		for i := range devices {
+			So(CreateDevice(db, &devices[i]), ShouldBeNil)
+		}
+
+		org2 := Organization{
+			Name: "test-org-2",
+		}
+		So(CreateOrganization(db, &org2), ShouldBeNil)
+
+		Convey("When creating a downlink schedule with an invalid cron expression", func() {
+			s := DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "every hour",
+				ApplicationID:  &app.ID,
+				FPort:          10,
+				Data:           []byte{1, 2, 3},
+			}
+			err := CreateDownlinkSchedule(db, &s)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkScheduleInvalidCron)
+			})
+		})
+
+		Convey("When creating a downlink schedule with multiple targets", func() {
+			s := DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "@hourly",
+				ApplicationID:  &app.ID,
+				DevEUIs:        []lorawan.EUI64{devices[0].DevEUI},
+				FPort:          10,
+				Data:           []byte{1, 2, 3},
+			}
+			err := CreateDownlinkSchedule(db, &s)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkScheduleInvalidTarget)
+			})
+		})
+
+		Convey("When creating a downlink schedule targeting an application of an other organization", func() {
+			s := DownlinkSchedule{
+				OrganizationID: org2.ID,
+				Name:           "test-schedule",
+				CronExpression: "@hourly",
+				ApplicationID:  &app.ID,
+				FPort:          10,
+				Data:           []byte{1, 2, 3},
+			}
+			err := CreateDownlinkSchedule(db, &s)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkScheduleInvalidTarget)
+			})
+		})
+
+		Convey("When creating a downlink schedule with data and an object", func() {
+			object := `{"temperature": 20}`
+			s := DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "@hourly",
+				ApplicationID:  &app.ID,
+				FPort:          10,
+				Data:           []byte{1, 2, 3},
+				Object:         &object,
+			}
+			err := CreateDownlinkSchedule(db, &s)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkScheduleInvalidPayload)
+			})
+		})
+
+		Convey("When creating a downlink schedule with a reserved fPort", func() {
+			s := DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "@hourly",
+				ApplicationID:  &app.ID,
+				FPort:          224,
+				Data:           []byte{1, 2, 3},
+			}
+			err := CreateDownlinkSchedule(db, &s)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkScheduleInvalidPayload)
+			})
+		})
+
+		Convey("When creating a downlink schedule targeting a list of devices", func() {
+			s := DownlinkSchedule{
+				OrganizationID: org.ID,
+				Name:           "test-schedule",
+				CronExpression: "0 3 * * *",
+				DevEUIs:        []lorawan.EUI64{devices[1].DevEUI},
+				Confirmed:      true,
+				FPort:          10,
+				Data:           []byte{1, 2, 3},
+			}
+			So(CreateDownlinkSchedule(db, &s), ShouldBeNil)
+
+			Convey("Then the next run is set to the next matching time", func() {
+				So(s.NextRunAt.After(time.Now()), ShouldBeTrue)
+				So(s.NextRunAt.Hour(), ShouldEqual, 3)
+				So(s.NextRunAt.Minute(), ShouldEqual, 0)
+			})
+
+			Convey("Then it can be retrieved", func() {
+				s2, err := GetDownlinkSchedule(db, s.ID)
+				So(err, ShouldBeNil)
+				So(s2.Name, ShouldEqual, s.Name)
+				So(s2.CronExpression, ShouldEqual, s.CronExpression)
+				So(s2.DevEUIs, ShouldResemble, s.DevEUIs)
+				So(s2.ApplicationID, ShouldBeNil)
+				So(s2.DeviceProfileID, ShouldBeNil)
+				So(s2.Confirmed, ShouldBeTrue)
+				So(s2.FPort, ShouldEqual, 10)
+				So(s2.Data, ShouldResemble, s.Data)
+				So(s2.Object, ShouldBeNil)
+				So(s2.LastRunAt, ShouldBeNil)
+				So(s2.NextRunAt.Equal(s.NextRunAt), ShouldBeTrue)
+			})
+
+			Convey("Then the target DevEUIs are the given devices", func() {
+				devEUIs, err := GetDownlinkScheduleTargetDevEUIs(db, s)
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldResemble, []lorawan.EUI64{devices[1].DevEUI})
+			})
+
+			Convey("Then the list and count methods return the schedule", func() {
+				count, err := GetDownlinkScheduleCountForOrganizationID(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				schedules, err := GetDownlinkSchedulesForOrganizationID(db, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(schedules, ShouldHaveLength, 1)
+				So(schedules[0].ID, ShouldEqual, s.ID)
+			})
+
+			Convey("Then no schedule is due", func() {
+				_, err := GetDueDownlinkScheduleForUpdate(db)
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("When updating the schedule to target the device-profile", func() {
+				object := `{"temperature": 20}`
+				s.DevEUIs = nil
+				s.DeviceProfileID = &dp.DeviceProfile.DeviceProfileID
+				s.Data = nil
+				s.Object = &object
+				s.CronExpression = "@hourly"
+				So(UpdateDownlinkSchedule(db, &s), ShouldBeNil)
+
+				Convey("Then the schedule has been updated", func() {
+					s2, err := GetDownlinkSchedule(db, s.ID)
+					So(err, ShouldBeNil)
+					So(s2.DevEUIs, ShouldHaveLength, 0)
+					So(*s2.DeviceProfileID, ShouldEqual, dp.DeviceProfile.DeviceProfileID)
+					So(s2.Object, ShouldNotBeNil)
+					So(s2.CronExpression, ShouldEqual, "@hourly")
+				})
+
+				Convey("Then the target DevEUIs are the devices using the device-profile", func() {
+					devEUIs, err := GetDownlinkScheduleTargetDevEUIs(db, s)
+					So(err, ShouldBeNil)
+					So(devEUIs, ShouldResemble, []lorawan.EUI64{devices[0].DevEUI, devices[1].DevEUI})
+				})
+			})
+
+			Convey("When the schedule is due", func() {
+				_, err := db.Exec("update downlink_schedule set next_run_at = now() - interval '1 second'")
+				So(err, ShouldBeNil)
+
+				Convey("Then it is returned as due schedule", func() {
+					s2, err := GetDueDownlinkScheduleForUpdate(db)
+					So(err, ShouldBeNil)
+					So(s2.ID, ShouldEqual, s.ID)
+					So(s2.DevEUIs, ShouldResemble, s.DevEUIs)
+				})
+
+				Convey("When setting the schedule run", func() {
+					now := time.Now()
+					next, err := s.Next(now)
+					So(err, ShouldBeNil)
+					So(SetDownlinkScheduleRun(db, s.ID, now, next), ShouldBeNil)
+
+					Convey("Then it is no longer due", func() {
+						_, err := GetDueDownlinkScheduleForUpdate(db)
+						So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+					})
+				})
+			})
+
+			Convey("When deleting the schedule", func() {
+				So(DeleteDownlinkSchedule(db, s.ID), ShouldBeNil)
+
+				Convey("Then it has been deleted", func() {
+					_, err := GetDownlinkSchedule(db, s.ID)
+					So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
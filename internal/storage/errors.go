@@ -25,6 +25,30 @@ var (
 	ErrDoesNotExist                    = errors.New("object does not exist")
 	ErrUsedByOtherObjects              = errors.New("this object is used by other objects, remove them first")
 	ErrApplicationInvalidName          = errors.New("invalid application name")
+	ErrInvalidWASMModule               = errors.New("invalid WASM codec module")
+	ErrCodecInvalidName                = errors.New("invalid codec name")
+	ErrCodecInvalidType                = errors.New("invalid codec type")
+	ErrCodecInvalidReference           = errors.New("codec (version) does not exist or belongs to a different organization")
+	ErrDeviceProfileInvalidClockSync   = errors.New("max clock sync periodicity value is 15")
+	ErrInvalidFieldsMetadata           = errors.New("invalid codec fields metadata")
+	ErrInvalidDownlinkSchedule         = errors.New("expiresAt must be in the future and after scheduledAt")
+	ErrDownlinkRetryPolicyInvalid      = errors.New("invalid downlink retry policy (max attempts 0-10, backoff >= 0)")
+	ErrDuplicateDownlink               = errors.New("a downlink with the same idempotency key has already been enqueued")
+	ErrDeviceShadowInvalid             = errors.New("fPort must be 1-223 and desired must be a JSON object")
+	ErrDownlinkScheduleInvalidName     = errors.New("invalid downlink schedule name")
+	ErrDownlinkScheduleInvalidCron     = errors.New("invalid cron expression")
+	ErrDownlinkScheduleInvalidTarget   = errors.New("exactly one target (devices, application or device-profile) of the same organization must be set")
+	ErrDownlinkScheduleInvalidPayload  = errors.New("fPort must be 1-223 and only one of data or a valid JSON object can be set")
+	ErrMulticastGroupInvalidName       = errors.New("invalid multicast-group name")
+	ErrMulticastGroupInvalidType       = errors.New("invalid multicast-group type")
+	ErrMulticastGroupInvalidConfig     = errors.New("invalid multicast-group configuration (McGroupID 0-3, DR 0-15, frequency > 0, ping-slot period 0-7)")
+	ErrMulticastGroupInvalidDevice     = errors.New("the device does not belong to the application of the multicast-group")
+	ErrMulticastGroupNoMcKey           = errors.New("the McKey of the multicast-group is unknown")
+	ErrFirmwareInvalid                 = errors.New("firmware name and data must be set")
+	ErrFUOTACampaignInvalidName        = errors.New("invalid FUOTA campaign name")
+	ErrFUOTACampaignInvalidConfig      = errors.New("invalid FUOTA campaign configuration (FragIndex 0-3, fragment-size > 0, redundancy >= 0, max 2^14 - 1 fragments, block ack delay 0-7, 4 byte descriptor, step timeout > 0)")
+	ErrFUOTACampaignInvalidTarget      = errors.New("the firmware must belong to the organization and the multicast-group and (at least one) devices must belong to the application of the FUOTA campaign")
+	ErrDevNonceReplayed                = errors.New("dev-nonce has already been used")
 	ErrNodeInvalidName                 = errors.New("invalid node name")
 	ErrNodeMaxRXDelay                  = errors.New("max value of RXDelay is 15")
 	ErrCFListTooManyChannels           = errors.New("too many channels in channel-list")
@@ -35,6 +59,13 @@ var (
 	ErrGatewayInvalidName              = errors.New("invalid gateway name")
 	ErrInvalidEmail                    = errors.New("invalid e-mail")
 	ErrInvalidGatewayDiscoveryInterval = errors.New("invalid gateway-discovery interval, it must be greater than 0")
+	ErrInvalidNetID                    = errors.New("invalid net_id, it must be exactly 3 bytes")
+	ErrInvalidClaimCode                = errors.New("invalid claim-code")
+	ErrClaimCodeLength                 = errors.New("claim-codes must be at least 12 characters long")
+	ErrClaimNetworkServerMismatch      = errors.New("the device-profile of the device and the service-profile of the application must use the same network-server")
+	ErrClaimOrganizationMismatch       = errors.New("the device-profile of the device must belong to the organization of the application")
+	ErrClaimRateLimited                = errors.New("too many failed claims for this device, try again later")
+	ErrDeviceInvalidTags               = errors.New("device tag keys must not be empty")
 )
 
 func handlePSQLError(action Action, err error, description string) error {
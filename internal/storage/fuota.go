@@ -0,0 +1,605 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Firmware defines a firmware image which can be sent to devices using
+// FUOTA campaigns.
+type Firmware struct {
+	ID             int64     `db:"id"`
+	OrganizationID int64     `db:"organization_id"`
+	CreatedAt      time.Time `db:"created_at"`
+	Name           string    `db:"name"`
+	Data           []byte    `db:"data"`
+}
+
+// FirmwareListItem defines the firmware for listing (without data).
+type FirmwareListItem struct {
+	ID             int64     `db:"id"`
+	OrganizationID int64     `db:"organization_id"`
+	CreatedAt      time.Time `db:"created_at"`
+	Name           string    `db:"name"`
+	Size           int       `db:"size"`
+}
+
+// Validate validates the firmware data.
+func (f Firmware) Validate() error {
+	if f.Name == "" || len(f.Data) == 0 {
+		return ErrFirmwareInvalid
+	}
+	return nil
+}
+
+// FUOTACampaignState defines the state of a FUOTA campaign, which is the
+// next step to execute.
+type FUOTACampaignState string
+
+// Available FUOTA campaign states.
+const (
+	// FUOTACampaignSetup sends the FragSessionSetupReq to the devices.
+	FUOTACampaignSetup FUOTACampaignState = "SETUP"
+	// FUOTACampaignFragments sends the fragments to the devices which
+	// completed the setup.
+	FUOTACampaignFragments FUOTACampaignState = "FRAGMENTS"
+	// FUOTACampaignStatus sends the FragSessionStatusReq to the devices
+	// which completed the setup.
+	FUOTACampaignStatus FUOTACampaignState = "STATUS"
+	// FUOTACampaignDone completes the campaign.
+	FUOTACampaignDone FUOTACampaignState = "DONE"
+)
+
+// FUOTACampaign defines a firmware update over the air campaign, sending
+// a firmware image to a set of devices using the Fragmented Data Block
+// Transport package.
+type FUOTACampaign struct {
+	ID            int64 `db:"id"`
+	ApplicationID int64 `db:"application_id"`
+	FirmwareID    int64 `db:"firmware_id"`
+	// MulticastGroupID is set when the fragments are sent using a
+	// multicast-group (instead of unicast).
+	MulticastGroupID *int64             `db:"multicast_group_id"`
+	CreatedAt        time.Time          `db:"created_at"`
+	UpdatedAt        time.Time          `db:"updated_at"`
+	Name             string             `db:"name"`
+	FragIndex        uint8              `db:"frag_index"`
+	FragSize         uint8              `db:"frag_size"`
+	Redundancy       int                `db:"redundancy"`
+	BlockAckDelay    uint8              `db:"block_ack_delay"`
+	Descriptor       []byte             `db:"descriptor"`
+	StepTimeout      int                `db:"step_timeout"`
+	State            FUOTACampaignState `db:"state"`
+	NextStepAt       time.Time          `db:"next_step_at"`
+	CompletedAt      *time.Time         `db:"completed_at"`
+	DevEUIs          []lorawan.EUI64    `db:"-"`
+}
+
+// Validate validates the FUOTA campaign data.
+func (c FUOTACampaign) Validate() error {
+	if c.Name == "" {
+		return ErrFUOTACampaignInvalidName
+	}
+
+	if c.FragIndex > 3 || c.FragSize == 0 || c.Redundancy < 0 || c.BlockAckDelay > 7 || len(c.Descriptor) != 4 || c.StepTimeout <= 0 {
+		return ErrFUOTACampaignInvalidConfig
+	}
+
+	return nil
+}
+
+// FUOTACampaignDevice defines a device of a FUOTA campaign, including its
+// fragmentation session state.
+type FUOTACampaignDevice struct {
+	FUOTACampaignID  int64         `db:"fuota_campaign_id"`
+	DevEUI           lorawan.EUI64 `db:"dev_eui"`
+	CreatedAt        time.Time     `db:"created_at"`
+	SetupCompletedAt *time.Time    `db:"setup_completed_at"`
+	NbFragReceived   *int          `db:"nb_frag_received"`
+	MissingFrag      *int          `db:"missing_frag"`
+	StatusReceivedAt *time.Time    `db:"status_received_at"`
+	LastError        string        `db:"last_error"`
+}
+
+// CreateFirmware creates the given firmware.
+func CreateFirmware(db sqlx.Queryer, f *Firmware) error {
+	if err := f.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	f.CreatedAt = time.Now()
+
+	err := sqlx.Get(db, &f.ID, `
+		insert into firmware (
+			organization_id,
+			created_at,
+			name,
+			data
+		) values ($1, $2, $3, $4) returning id`,
+		f.OrganizationID,
+		f.CreatedAt,
+		f.Name,
+		f.Data,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":              f.ID,
+		"organization_id": f.OrganizationID,
+		"size":            len(f.Data),
+	}).Info("firmware created")
+
+	return nil
+}
+
+// GetFirmware returns the firmware matching the given id.
+func GetFirmware(db sqlx.Queryer, id int64) (Firmware, error) {
+	var f Firmware
+	err := sqlx.Get(db, &f, "select * from firmware where id = $1", id)
+	if err != nil {
+		return f, handlePSQLError(Select, err, "select error")
+	}
+
+	return f, nil
+}
+
+// DeleteFirmware deletes the firmware matching the given id. Firmware used
+// by a FUOTA campaign can not be deleted.
+func DeleteFirmware(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from firmware where id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("firmware deleted")
+
+	return nil
+}
+
+// GetFirmwareCountForOrganizationID returns the total number of firmwares
+// for the given organization.
+func GetFirmwareCountForOrganizationID(db sqlx.Queryer, organizationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from firmware where organization_id = $1", organizationID)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetFirmwaresForOrganizationID returns a slice of firmwares (without data)
+// for the given organization, sorted by name.
+func GetFirmwaresForOrganizationID(db sqlx.Queryer, organizationID int64, limit, offset int) ([]FirmwareListItem, error) {
+	var items []FirmwareListItem
+	err := sqlx.Select(db, &items, `
+		select
+			id,
+			organization_id,
+			created_at,
+			name,
+			length(data) as size
+		from firmware
+		where
+			organization_id = $1
+		order by name, id
+		limit $2 offset $3`,
+		organizationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return items, nil
+}
+
+// CreateFUOTACampaign creates the given FUOTA campaign. The firmware must
+// belong to the organization of the application, the multicast-group and
+// devices must belong to the application. When no devices are given, the
+// devices of the multicast-group are used.
+func CreateFUOTACampaign(db sqlx.Ext, c *FUOTACampaign) error {
+	if err := c.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	if err := validateFUOTACampaignTarget(db, c); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.State = FUOTACampaignSetup
+	c.NextStepAt = now
+	c.CompletedAt = nil
+
+	err := sqlx.Get(db, &c.ID, `
+		insert into fuota_campaign (
+			application_id,
+			firmware_id,
+			multicast_group_id,
+			created_at,
+			updated_at,
+			name,
+			frag_index,
+			frag_size,
+			redundancy,
+			block_ack_delay,
+			descriptor,
+			step_timeout,
+			state,
+			next_step_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) returning id`,
+		c.ApplicationID,
+		c.FirmwareID,
+		c.MulticastGroupID,
+		c.CreatedAt,
+		c.UpdatedAt,
+		c.Name,
+		c.FragIndex,
+		c.FragSize,
+		c.Redundancy,
+		c.BlockAckDelay,
+		c.Descriptor,
+		c.StepTimeout,
+		c.State,
+		c.NextStepAt,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	for _, devEUI := range c.DevEUIs {
+		_, err := db.Exec(`
+			insert into fuota_campaign_device (
+				fuota_campaign_id,
+				dev_eui,
+				created_at
+			) values ($1, $2, $3)`,
+			c.ID,
+			devEUI[:],
+			now,
+		)
+		if err != nil {
+			return handlePSQLError(Insert, err, "insert error")
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"id":             c.ID,
+		"application_id": c.ApplicationID,
+		"firmware_id":    c.FirmwareID,
+		"devices":        len(c.DevEUIs),
+	}).Info("fuota campaign created")
+
+	return nil
+}
+
+// GetFUOTACampaign returns the FUOTA campaign matching the given id.
+func GetFUOTACampaign(db sqlx.Queryer, id int64) (FUOTACampaign, error) {
+	var c FUOTACampaign
+	err := sqlx.Get(db, &c, "select * from fuota_campaign where id = $1", id)
+	if err != nil {
+		return c, handlePSQLError(Select, err, "select error")
+	}
+
+	err = sqlx.Select(db, &c.DevEUIs, `
+		select dev_eui
+		from fuota_campaign_device
+		where
+			fuota_campaign_id = $1
+		order by dev_eui`,
+		id,
+	)
+	if err != nil {
+		return c, handlePSQLError(Select, err, "select error")
+	}
+
+	return c, nil
+}
+
+// DeleteFUOTACampaign deletes the FUOTA campaign matching the given id.
+func DeleteFUOTACampaign(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from fuota_campaign where id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("fuota campaign deleted")
+
+	return nil
+}
+
+// GetFUOTACampaignCountForApplicationID returns the total number of FUOTA
+// campaigns for the given application.
+func GetFUOTACampaignCountForApplicationID(db sqlx.Queryer, applicationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from fuota_campaign where application_id = $1", applicationID)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetFUOTACampaignsForApplicationID returns a slice of FUOTA campaigns for
+// the given application, the most recent campaign first. Note that the
+// DevEUIs are not set.
+func GetFUOTACampaignsForApplicationID(db sqlx.Queryer, applicationID int64, limit, offset int) ([]FUOTACampaign, error) {
+	var campaigns []FUOTACampaign
+	err := sqlx.Select(db, &campaigns, `
+		select *
+		from fuota_campaign
+		where
+			application_id = $1
+		order by created_at desc, id desc
+		limit $2 offset $3`,
+		applicationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return campaigns, nil
+}
+
+// GetDueFUOTACampaignForUpdate returns the (not completed) FUOTA campaign
+// of which the next step is due the longest and locks it for update.
+// Campaigns locked by an other transaction are skipped. ErrDoesNotExist is
+// returned when no campaign is due. Note that the DevEUIs are not set.
+func GetDueFUOTACampaignForUpdate(db sqlx.Queryer) (FUOTACampaign, error) {
+	var c FUOTACampaign
+	err := sqlx.Get(db, &c, `
+		select
+			*
+		from
+			fuota_campaign
+		where
+			completed_at is null
+			and next_step_at <= now()
+		order by next_step_at, id
+		limit 1
+		for update skip locked`,
+	)
+	if err != nil {
+		return c, handlePSQLError(Select, err, "select error")
+	}
+
+	return c, nil
+}
+
+// SetFUOTACampaignState sets the state (next step) of the given FUOTA
+// campaign and when it is due. A completed campaign (completedAt != nil) is
+// no longer returned by GetDueFUOTACampaignForUpdate.
+func SetFUOTACampaignState(db sqlx.Execer, id int64, state FUOTACampaignState, nextStepAt time.Time, completedAt *time.Time) error {
+	res, err := db.Exec(`
+		update fuota_campaign
+		set
+			updated_at = $2,
+			state = $3,
+			next_step_at = $4,
+			completed_at = $5
+		where id = $1`,
+		id,
+		time.Now(),
+		state,
+		nextStepAt,
+		completedAt,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	return nil
+}
+
+// GetFUOTACampaignDevices returns the devices of the given FUOTA campaign.
+func GetFUOTACampaignDevices(db sqlx.Queryer, id int64) ([]FUOTACampaignDevice, error) {
+	var devices []FUOTACampaignDevice
+	err := sqlx.Select(db, &devices, `
+		select *
+		from fuota_campaign_device
+		where
+			fuota_campaign_id = $1
+		order by dev_eui`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return devices, nil
+}
+
+// GetFUOTACampaignDeviceForFragIndex returns the FUOTA campaign device for
+// the given device and FragIndex (the fragmentation session slot on the
+// device). When the device is part of multiple campaigns using the same
+// FragIndex, the most recent campaign is returned.
+func GetFUOTACampaignDeviceForFragIndex(db sqlx.Queryer, devEUI lorawan.EUI64, fragIndex uint8) (FUOTACampaignDevice, error) {
+	var d FUOTACampaignDevice
+	err := sqlx.Get(db, &d, `
+		select
+			fcd.*
+		from fuota_campaign_device fcd
+		inner join fuota_campaign fc
+			on fc.id = fcd.fuota_campaign_id
+		where
+			fcd.dev_eui = $1
+			and fc.frag_index = $2
+		order by fc.created_at desc, fc.id desc
+		limit 1`,
+		devEUI[:],
+		fragIndex,
+	)
+	if err != nil {
+		return d, handlePSQLError(Select, err, "select error")
+	}
+
+	return d, nil
+}
+
+// GetActiveFUOTACampaignCountForDevEUI returns the number of FUOTA campaigns
+// which have not yet been completed and of which the given device is part.
+func GetActiveFUOTACampaignCountForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select
+			count(*)
+		from fuota_campaign_device fcd
+		inner join fuota_campaign fc
+			on fc.id = fcd.fuota_campaign_id
+		where
+			fcd.dev_eui = $1
+			and fc.completed_at is null`,
+		devEUI[:],
+	)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// UpdateFUOTACampaignDevice updates the fragmentation session state of the
+// given FUOTA campaign device.
+func UpdateFUOTACampaignDevice(db sqlx.Execer, d FUOTACampaignDevice) error {
+	res, err := db.Exec(`
+		update fuota_campaign_device
+		set
+			setup_completed_at = $3,
+			nb_frag_received = $4,
+			missing_frag = $5,
+			status_received_at = $6,
+			last_error = $7
+		where
+			fuota_campaign_id = $1
+			and dev_eui = $2`,
+		d.FUOTACampaignID,
+		d.DevEUI[:],
+		d.SetupCompletedAt,
+		d.NbFragReceived,
+		d.MissingFrag,
+		d.StatusReceivedAt,
+		d.LastError,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	return nil
+}
+
+func validateFUOTACampaignTarget(db sqlx.Queryer, c *FUOTACampaign) error {
+	var size int
+	err := sqlx.Get(db, &size, `
+		select length(f.data)
+		from firmware f
+		inner join application a
+			on a.organization_id = f.organization_id
+		where
+			f.id = $1
+			and a.id = $2`,
+		c.FirmwareID,
+		c.ApplicationID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrFUOTACampaignInvalidTarget
+		}
+		return handlePSQLError(Select, err, "select error")
+	}
+
+	// the fragment number is encoded as 14 bit value
+	nbFrag := (size + int(c.FragSize) - 1) / int(c.FragSize)
+	if nbFrag+c.Redundancy >= 1<<14 {
+		return ErrFUOTACampaignInvalidConfig
+	}
+
+	if c.MulticastGroupID != nil {
+		mg, err := GetMulticastGroup(db, *c.MulticastGroupID)
+		if err != nil {
+			if errors.Cause(err) == ErrDoesNotExist {
+				return ErrFUOTACampaignInvalidTarget
+			}
+			return err
+		}
+		if mg.ApplicationID != c.ApplicationID {
+			return ErrFUOTACampaignInvalidTarget
+		}
+
+		if len(c.DevEUIs) == 0 {
+			members, err := GetMulticastGroupDevices(db, mg.ID)
+			if err != nil {
+				return err
+			}
+			for _, m := range members {
+				c.DevEUIs = append(c.DevEUIs, m.DevEUI)
+			}
+		}
+	}
+
+	if len(c.DevEUIs) == 0 {
+		return ErrFUOTACampaignInvalidTarget
+	}
+
+	for _, devEUI := range c.DevEUIs {
+		var count int
+		err := sqlx.Get(db, &count, `
+			select count(*)
+			from device
+			where
+				dev_eui = $1
+				and application_id = $2`,
+			devEUI[:],
+			c.ApplicationID,
+		)
+		if err != nil {
+			return handlePSQLError(Select, err, "select error")
+		}
+		if count == 0 {
+			return ErrFUOTACampaignInvalidTarget
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestFUOTA(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with two organizations, an application and two devices", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		org2 := Organization{
+			Name: "test-org-2",
+		}
+		So(CreateOrganization(db, &org2), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		devices := []Device{
+			{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Name: "device-1", ApplicationID: app.ID, DeviceProfileID: dp.DeviceProfile.DeviceProfileID},
+			{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Name: "device-2", ApplicationID: app.ID, DeviceProfileID: dp.DeviceProfile.DeviceProfileID},
+		}
+		for i := range devices {
+			So(CreateDevice(db, &devices[i]), ShouldBeNil)
+		}
+
+		Convey("When creating a firmware without data", func() {
+			err := CreateFirmware(db, &Firmware{OrganizationID: org.ID, Name: "empty"})
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrFirmwareInvalid)
+			})
+		})
+
+		Convey("When creating a firmware", func() {
+			fw := Firmware{
+				OrganizationID: org.ID,
+				Name:           "firmware-v1",
+				Data:           []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			}
+			So(CreateFirmware(db, &fw), ShouldBeNil)
+			fw.CreatedAt = fw.CreatedAt.Truncate(time.Millisecond).UTC()
+
+			fw2 := Firmware{
+				OrganizationID: org2.ID,
+				Name:           "firmware-other-org",
+				Data:           []byte{1, 2, 3},
+			}
+			So(CreateFirmware(db, &fw2), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				f, err := GetFirmware(db, fw.ID)
+				So(err, ShouldBeNil)
+				f.CreatedAt = f.CreatedAt.Truncate(time.Millisecond).UTC()
+				So(f, ShouldResemble, fw)
+			})
+
+			Convey("Then it is returned for the organization without data", func() {
+				count, err := GetFirmwareCountForOrganizationID(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				items, err := GetFirmwaresForOrganizationID(db, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+				So(items[0].ID, ShouldEqual, fw.ID)
+				So(items[0].Size, ShouldEqual, 10)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteFirmware(db, fw.ID), ShouldBeNil)
+				_, err := GetFirmware(db, fw.ID)
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then a campaign using the firmware of an other organization can not be created", func() {
+				err := CreateFUOTACampaign(db, &FUOTACampaign{
+					ApplicationID: app.ID,
+					FirmwareID:    fw2.ID,
+					Name:          "test-campaign",
+					FragSize:      4,
+					Descriptor:    []byte{1, 2, 3, 4},
+					StepTimeout:   60,
+					DevEUIs:       []lorawan.EUI64{devices[0].DevEUI},
+				})
+				So(errors.Cause(err), ShouldEqual, ErrFUOTACampaignInvalidTarget)
+			})
+
+			Convey("Then a campaign without devices can not be created", func() {
+				err := CreateFUOTACampaign(db, &FUOTACampaign{
+					ApplicationID: app.ID,
+					FirmwareID:    fw.ID,
+					Name:          "test-campaign",
+					FragSize:      4,
+					Descriptor:    []byte{1, 2, 3, 4},
+					StepTimeout:   60,
+				})
+				So(errors.Cause(err), ShouldEqual, ErrFUOTACampaignInvalidTarget)
+			})
+
+			Convey("Then a campaign with an invalid descriptor can not be created", func() {
+				err := CreateFUOTACampaign(db, &FUOTACampaign{
+					ApplicationID: app.ID,
+					FirmwareID:    fw.ID,
+					Name:          "test-campaign",
+					FragSize:      4,
+					Descriptor:    []byte{1, 2},
+					StepTimeout:   60,
+					DevEUIs:       []lorawan.EUI64{devices[0].DevEUI},
+				})
+				So(errors.Cause(err), ShouldEqual, ErrFUOTACampaignInvalidConfig)
+			})
+
+			Convey("When creating a campaign for a multicast-group without devices", func() {
+				mg := MulticastGroup{
+					ApplicationID: app.ID,
+					Name:          "test-group",
+					McGroupID:     1,
+					GroupType:     MulticastGroupClassC,
+					Frequency:     869525000,
+				}
+				So(CreateMulticastGroup(db, &mg), ShouldBeNil)
+				So(AddDeviceToMulticastGroup(db, mg.ID, devices[1].DevEUI), ShouldBeNil)
+
+				c := FUOTACampaign{
+					ApplicationID:    app.ID,
+					FirmwareID:       fw.ID,
+					MulticastGroupID: &mg.ID,
+					Name:             "multicast-campaign",
+					FragSize:         4,
+					Descriptor:       []byte{1, 2, 3, 4},
+					StepTimeout:      60,
+				}
+				So(CreateFUOTACampaign(db, &c), ShouldBeNil)
+
+				Convey("Then the devices of the multicast-group are used", func() {
+					c2, err := GetFUOTACampaign(db, c.ID)
+					So(err, ShouldBeNil)
+					So(c2.DevEUIs, ShouldResemble, []lorawan.EUI64{devices[1].DevEUI})
+					So(*c2.MulticastGroupID, ShouldEqual, mg.ID)
+				})
+			})
+
+			Convey("When creating a campaign", func() {
+				c := FUOTACampaign{
+					ApplicationID: app.ID,
+					FirmwareID:    fw.ID,
+					Name:          "test-campaign",
+					FragIndex:     1,
+					FragSize:      4,
+					Redundancy:    2,
+					BlockAckDelay: 3,
+					Descriptor:    []byte{1, 2, 3, 4},
+					StepTimeout:   60,
+					DevEUIs:       []lorawan.EUI64{devices[0].DevEUI, devices[1].DevEUI},
+				}
+				So(CreateFUOTACampaign(db, &c), ShouldBeNil)
+				c.CreatedAt = c.CreatedAt.Truncate(time.Millisecond).UTC()
+				c.UpdatedAt = c.UpdatedAt.Truncate(time.Millisecond).UTC()
+				c.NextStepAt = c.NextStepAt.Truncate(time.Millisecond).UTC()
+
+				Convey("Then it can be retrieved", func() {
+					c2, err := GetFUOTACampaign(db, c.ID)
+					So(err, ShouldBeNil)
+					c2.CreatedAt = c2.CreatedAt.Truncate(time.Millisecond).UTC()
+					c2.UpdatedAt = c2.UpdatedAt.Truncate(time.Millisecond).UTC()
+					c2.NextStepAt = c2.NextStepAt.Truncate(time.Millisecond).UTC()
+					So(c2, ShouldResemble, c)
+					So(c2.State, ShouldEqual, FUOTACampaignSetup)
+				})
+
+				Convey("Then it is returned for the application", func() {
+					count, err := GetFUOTACampaignCountForApplicationID(db, app.ID)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 1)
+
+					campaigns, err := GetFUOTACampaignsForApplicationID(db, app.ID, 10, 0)
+					So(err, ShouldBeNil)
+					So(campaigns, ShouldHaveLength, 1)
+					So(campaigns[0].ID, ShouldEqual, c.ID)
+				})
+
+				Convey("Then the firmware can not be deleted", func() {
+					err := DeleteFirmware(db, fw.ID)
+					So(errors.Cause(err), ShouldEqual, ErrUsedByOtherObjects)
+				})
+
+				Convey("Then it is due", func() {
+					due, err := GetDueFUOTACampaignForUpdate(db)
+					So(err, ShouldBeNil)
+					So(due.ID, ShouldEqual, c.ID)
+
+					Convey("When setting the next state in the future", func() {
+						So(SetFUOTACampaignState(db, c.ID, FUOTACampaignFragments, time.Now().Add(time.Minute), nil), ShouldBeNil)
+
+						Convey("Then it is no longer due", func() {
+							_, err := GetDueFUOTACampaignForUpdate(db)
+							So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+
+							c2, err := GetFUOTACampaign(db, c.ID)
+							So(err, ShouldBeNil)
+							So(c2.State, ShouldEqual, FUOTACampaignFragments)
+						})
+					})
+
+					Convey("When completing the campaign", func() {
+						now := time.Now()
+						So(SetFUOTACampaignState(db, c.ID, FUOTACampaignDone, now, &now), ShouldBeNil)
+
+						Convey("Then it is no longer due", func() {
+							_, err := GetDueFUOTACampaignForUpdate(db)
+							So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+						})
+					})
+				})
+
+				Convey("Then the devices are returned", func() {
+					devs, err := GetFUOTACampaignDevices(db, c.ID)
+					So(err, ShouldBeNil)
+					So(devs, ShouldHaveLength, 2)
+					So(devs[0].DevEUI, ShouldEqual, devices[0].DevEUI)
+					So(devs[0].SetupCompletedAt, ShouldBeNil)
+					So(devs[0].NbFragReceived, ShouldBeNil)
+				})
+
+				Convey("Then the device state can be updated and retrieved by FragIndex", func() {
+					d, err := GetFUOTACampaignDeviceForFragIndex(db, devices[0].DevEUI, 1)
+					So(err, ShouldBeNil)
+					So(d.FUOTACampaignID, ShouldEqual, c.ID)
+
+					now := time.Now().Truncate(time.Millisecond).UTC()
+					nbFrag := 3
+					missing := 1
+					d.SetupCompletedAt = &now
+					d.StatusReceivedAt = &now
+					d.NbFragReceived = &nbFrag
+					d.MissingFrag = &missing
+					d.LastError = "test error"
+					So(UpdateFUOTACampaignDevice(db, d), ShouldBeNil)
+
+					d2, err := GetFUOTACampaignDeviceForFragIndex(db, devices[0].DevEUI, 1)
+					So(err, ShouldBeNil)
+					So(d2.SetupCompletedAt.Truncate(time.Millisecond).UTC(), ShouldResemble, now)
+					So(*d2.NbFragReceived, ShouldEqual, 3)
+					So(*d2.MissingFrag, ShouldEqual, 1)
+					So(d2.LastError, ShouldEqual, "test error")
+
+					_, err = GetFUOTACampaignDeviceForFragIndex(db, devices[0].DevEUI, 2)
+					So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Then the devices are part of an active campaign until it has been completed", func() {
+					count, err := GetActiveFUOTACampaignCountForDevEUI(db, devices[0].DevEUI)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 1)
+
+					now := time.Now()
+					So(SetFUOTACampaignState(db, c.ID, FUOTACampaignDone, now, &now), ShouldBeNil)
+
+					count, err = GetActiveFUOTACampaignCountForDevEUI(db, devices[0].DevEUI)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+
+				Convey("Then it can be deleted", func() {
+					So(DeleteFUOTACampaign(db, c.ID), ShouldBeNil)
+					_, err := GetFUOTACampaign(db, c.ID)
+					So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+
+					devs, err := GetFUOTACampaignDevices(db, c.ID)
+					So(err, ShouldBeNil)
+					So(devs, ShouldHaveLength, 0)
+				})
+			})
+		})
+	})
+}
@@ -212,7 +212,7 @@ func DeleteGateway(db sqlx.Ext, mac lorawan.EUI64) error {
 	}
 
 	_, err = nsClient.DeleteGateway(context.Background(), &ns.DeleteGatewayRequest{
-		Mac: mac[:],
+		Id: mac[:],
 	})
 	if err != nil && grpc.Code(err) != codes.NotFound {
 		return errors.Wrap(err, "delete gateway error")
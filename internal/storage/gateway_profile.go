@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/ns"
 )
 
@@ -78,7 +79,7 @@ func CreateGatewayProfile(db sqlx.Ext, gp *GatewayProfile) error {
 
 	req := ns.CreateGatewayProfileRequest{
 		GatewayProfile: &ns.GatewayProfile{
-			GatewayProfileID: gp.GatewayProfileID,
+			Id: uuid.FromStringOrNil(gp.GatewayProfileID).Bytes(),
 		},
 	}
 
@@ -95,9 +96,9 @@ func CreateGatewayProfile(db sqlx.Ext, gp *GatewayProfile) error {
 
 		switch ec.Modulation {
 		case ModulationFSK:
-			c.Modulation = ns.Modulation_FSK
+			c.Modulation = common.Modulation_FSK
 		default:
-			c.Modulation = ns.Modulation_LORA
+			c.Modulation = common.Modulation_LORA
 		}
 
 		for _, sf := range ec.SpreadingFactors {
@@ -154,7 +155,7 @@ func GetGatewayProfile(db sqlx.Queryer, id string) (GatewayProfile, error) {
 	}
 
 	resp, err := nsClient.GetGatewayProfile(context.Background(), &ns.GetGatewayProfileRequest{
-		GatewayProfileID: id,
+		Id: uuid.FromStringOrNil(id).Bytes(),
 	})
 	if err != nil {
 		return gp, handleGrpcError(err, "get gateway-profile error")
@@ -172,7 +173,7 @@ func GetGatewayProfile(db sqlx.Queryer, id string) (GatewayProfile, error) {
 		}
 
 		switch ec.Modulation {
-		case ns.Modulation_FSK:
+		case common.Modulation_FSK:
 			c.Modulation = ModulationFSK
 		default:
 			c.Modulation = ModulationLoRa
@@ -229,7 +230,7 @@ func UpdateGatewayProfile(db sqlx.Ext, gp *GatewayProfile) error {
 
 	req := ns.UpdateGatewayProfileRequest{
 		GatewayProfile: &ns.GatewayProfile{
-			GatewayProfileID: gp.GatewayProfileID,
+			Id: uuid.FromStringOrNil(gp.GatewayProfileID).Bytes(),
 		},
 	}
 
@@ -246,9 +247,9 @@ func UpdateGatewayProfile(db sqlx.Ext, gp *GatewayProfile) error {
 
 		switch ec.Modulation {
 		case ModulationFSK:
-			c.Modulation = ns.Modulation_FSK
+			c.Modulation = common.Modulation_FSK
 		default:
-			c.Modulation = ns.Modulation_LORA
+			c.Modulation = common.Modulation_LORA
 		}
 
 		for _, sf := range ec.SpreadingFactors {
@@ -297,7 +298,7 @@ func DeleteGatewayProfile(db sqlx.Ext, id string) error {
 	}
 
 	_, err = nsClient.DeleteGatewayProfile(context.Background(), &ns.DeleteGatewayProfileRequest{
-		GatewayProfileID: id,
+		Id: uuid.FromStringOrNil(id).Bytes(),
 	})
 	if err != nil {
 		return handleGrpcError(err, "delete gateway-profile error")
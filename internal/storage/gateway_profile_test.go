@@ -4,10 +4,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/ns"
 
 	"github.com/brocaar/lora-app-server/internal/config"
 	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/satori/go.uuid"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -52,11 +54,11 @@ func TestGatewayProfile(t *testing.T) {
 			So(nsClient.CreateGatewayProfileChan, ShouldHaveLength, 1)
 			So(<-nsClient.CreateGatewayProfileChan, ShouldResemble, ns.CreateGatewayProfileRequest{
 				GatewayProfile: &ns.GatewayProfile{
-					GatewayProfileID: gp.GatewayProfileID,
-					Channels:         []uint32{0, 1, 2},
+					Id:       uuid.FromStringOrNil(gp.GatewayProfileID).Bytes(),
+					Channels: []uint32{0, 1, 2},
 					ExtraChannels: []*ns.GatewayProfileExtraChannel{
 						{
-							Modulation:       ns.Modulation_LORA,
+							Modulation:       common.Modulation_LORA,
 							Frequency:        867100000,
 							SpreadingFactors: []uint32{10, 11, 12},
 							Bandwidth:        125,
@@ -68,11 +70,11 @@ func TestGatewayProfile(t *testing.T) {
 			Convey("Then GetGatewayProfile reuturns the gateway-profile", func() {
 				nsClient.GetGatewayProfileResponse = ns.GetGatewayProfileResponse{
 					GatewayProfile: &ns.GatewayProfile{
-						GatewayProfileID: gp.GatewayProfileID,
-						Channels:         []uint32{0, 1, 2},
+						Id:       uuid.FromStringOrNil(gp.GatewayProfileID).Bytes(),
+						Channels: []uint32{0, 1, 2},
 						ExtraChannels: []*ns.GatewayProfileExtraChannel{
 							{
-								Modulation:       ns.Modulation_LORA,
+								Modulation:       common.Modulation_LORA,
 								Frequency:        867100000,
 								SpreadingFactors: []uint32{10, 11, 12},
 								Bandwidth:        125,
@@ -107,11 +109,11 @@ func TestGatewayProfile(t *testing.T) {
 				So(nsClient.UpdateGatewayProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.UpdateGatewayProfileChan, ShouldResemble, ns.UpdateGatewayProfileRequest{
 					GatewayProfile: &ns.GatewayProfile{
-						GatewayProfileID: gp.GatewayProfileID,
-						Channels:         []uint32{0, 1},
+						Id:       uuid.FromStringOrNil(gp.GatewayProfileID).Bytes(),
+						Channels: []uint32{0, 1},
 						ExtraChannels: []*ns.GatewayProfileExtraChannel{
 							{
-								Modulation:       ns.Modulation_LORA,
+								Modulation:       common.Modulation_LORA,
 								Frequency:        867300000,
 								SpreadingFactors: []uint32{9, 10, 11, 12},
 								Bandwidth:        250,
@@ -129,7 +131,7 @@ func TestGatewayProfile(t *testing.T) {
 				So(DeleteGatewayProfile(db, gp.GatewayProfileID), ShouldBeNil)
 				So(nsClient.DeleteGatewayProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.DeleteGatewayProfileChan, ShouldResemble, ns.DeleteGatewayProfileRequest{
-					GatewayProfileID: gp.GatewayProfileID,
+					Id: uuid.FromStringOrNil(gp.GatewayProfileID).Bytes(),
 				})
 
 				_, err := GetGatewayProfile(db, gp.GatewayProfileID)
@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/keywrap"
+	"github.com/brocaar/lorawan"
+)
+
+// The root-keys (device_keys and unclaimed_device) and session-keys
+// (device_activation) are encrypted at rest using envelope encryption. Each
+// row has its own data encryption key (DEK), which is used to wrap the keys
+// of the row. The DEK itself is wrapped using the master-key and stored
+// together with the ID of this master-key, so that the master-key can be
+// rotated by re-wrapping the DEKs. When no master-key is configured, the keys
+// are stored unencrypted.
+
+var masterKeys = struct {
+	sync.RWMutex
+	activeID string
+	keys     map[string][]byte
+}{}
+
+// SetMasterKeys sets the master-keys used for the encryption of the device
+// keys. New keys are encrypted using the master-key with the given active
+// ID, the other keys are used to decrypt keys encrypted with a previous
+// master-key. An empty active ID disables the encryption of new keys.
+func SetMasterKeys(activeID string, keys map[string][]byte) error {
+	for id, key := range keys {
+		if id == "" {
+			return errors.New("master-key id must not be empty")
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return errors.Errorf("master-key %s must be 16, 24 or 32 bytes", id)
+		}
+	}
+
+	if _, ok := keys[activeID]; activeID != "" && !ok {
+		return errors.Errorf("master-key %s is not configured", activeID)
+	}
+
+	masterKeys.Lock()
+	defer masterKeys.Unlock()
+
+	masterKeys.activeID = activeID
+	masterKeys.keys = keys
+
+	return nil
+}
+
+// getMasterKey returns the master-key for the given ID.
+func getMasterKey(id string) ([]byte, error) {
+	masterKeys.RLock()
+	defer masterKeys.RUnlock()
+
+	key, ok := masterKeys.keys[id]
+	if !ok {
+		return nil, errors.Errorf("master-key %s is not configured", id)
+	}
+	return key, nil
+}
+
+// getActiveMasterKeyID returns the ID of the master-key used to encrypt new
+// keys.
+func getActiveMasterKeyID() string {
+	masterKeys.RLock()
+	defer masterKeys.RUnlock()
+
+	return masterKeys.activeID
+}
+
+// encryptKeys encrypts the given keys using a new DEK and returns the
+// master-key ID, the wrapped DEK and the encrypted keys. When no master-key
+// is active, the keys are returned unencrypted.
+func encryptKeys(keys ...lorawan.AES128Key) (string, []byte, [][]byte, error) {
+	out := make([][]byte, len(keys))
+
+	masterKeyID := getActiveMasterKeyID()
+	if masterKeyID == "" {
+		for i := range keys {
+			out[i] = keys[i][:]
+		}
+		return "", nil, out, nil
+	}
+
+	masterKey, err := getMasterKey(masterKeyID)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	dek := make([]byte, 16)
+	if _, err := rand.Read(dek); err != nil {
+		return "", nil, nil, errors.Wrap(err, "read random bytes error")
+	}
+
+	for i := range keys {
+		out[i], err = keywrap.Wrap(dek, keys[i][:])
+		if err != nil {
+			return "", nil, nil, errors.Wrap(err, "wrap key error")
+		}
+	}
+
+	wrappedDEK, err := keywrap.Wrap(masterKey, dek)
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, "wrap dek error")
+	}
+
+	return masterKeyID, wrappedDEK, out, nil
+}
+
+// decryptKeys decrypts the given keys, encrypted by encryptKeys.
+func decryptKeys(masterKeyID string, wrappedDEK []byte, keys ...[]byte) ([]lorawan.AES128Key, error) {
+	out := make([]lorawan.AES128Key, len(keys))
+
+	if masterKeyID == "" {
+		for i := range keys {
+			if len(keys[i]) != len(out[i]) {
+				return nil, errors.New("unencrypted key must be 16 bytes")
+			}
+			copy(out[i][:], keys[i])
+		}
+		return out, nil
+	}
+
+	masterKey, err := getMasterKey(masterKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, err := keywrap.Unwrap(masterKey, wrappedDEK)
+	if err != nil {
+		return nil, errors.Wrap(err, "unwrap dek error")
+	}
+
+	for i := range keys {
+		b, err := keywrap.Unwrap(dek, keys[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "unwrap key error")
+		}
+		if len(b) != len(out[i]) {
+			return nil, errors.New("decrypted key must be 16 bytes")
+		}
+		copy(out[i][:], b)
+	}
+
+	return out, nil
+}
+
+// RotateMasterKeyBatchSize defines the max. number of rows re-encrypted
+// within a single transaction by RotateMasterKey.
+var RotateMasterKeyBatchSize = 1000
+
+// RotateMasterKey re-encrypts all device-keys, device-activations and
+// unclaimed devices which are not encrypted using the active master-key. It
+// returns the number of re-encrypted rows. The rows are re-encrypted in
+// batches of RotateMasterKeyBatchSize rows, each batch within its own
+// transaction, so that the rows are not locked for the whole rotation. When
+// the rotation is interrupted, it can be resumed by running it again.
+func RotateMasterKey(db *common.DBLogger) (int, error) {
+	masterKeyID := getActiveMasterKeyID()
+
+	var count int
+	for _, rotate := range []func(sqlx.Ext, string, int) (int, error){
+		rotateDeviceKeys,
+		rotateDeviceActivations,
+		rotateUnclaimedDevices,
+	} {
+		for {
+			var n int
+			err := Transaction(db, func(tx sqlx.Ext) error {
+				var err error
+				n, err = rotate(tx, masterKeyID, RotateMasterKeyBatchSize)
+				return err
+			})
+			if err != nil {
+				return count, err
+			}
+
+			count += n
+			if n == 0 || n < RotateMasterKeyBatchSize {
+				break
+			}
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"master_key_id": masterKeyID,
+		"count":         count,
+	}).Info("device-keys re-encrypted")
+
+	return count, nil
+}
+
+// rotateDeviceKeys re-encrypts max. limit device-keys which are not
+// encrypted using the given master-key.
+func rotateDeviceKeys(db sqlx.Ext, masterKeyID string, limit int) (int, error) {
+	var keys []deviceKeysRow
+	err := sqlx.Select(db, &keys, "select * from device_keys where master_key_id <> $1 limit $2 for update", masterKeyID, limit)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	for _, row := range keys {
+		dk, err := row.deviceKeys()
+		if err != nil {
+			return 0, errors.Wrapf(err, "decrypt device-keys %s error", row.DevEUI)
+		}
+
+		keyID, dek, b, err := encryptKeys(dk.AppKey, dk.NwkKey, dk.GenAppKey)
+		if err != nil {
+			return 0, errors.Wrap(err, "encrypt keys error")
+		}
+
+		_, err = db.Exec(`
+            update device_keys
+            set
+                app_key = $2,
+                nwk_key = $3,
+                gen_app_key = $4,
+                dek = $5,
+                master_key_id = $6
+            where
+                dev_eui = $1`,
+			dk.DevEUI[:],
+			b[0],
+			b[1],
+			b[2],
+			dek,
+			keyID,
+		)
+		if err != nil {
+			return 0, handlePSQLError(Update, err, "update error")
+		}
+	}
+
+	return len(keys), nil
+}
+
+// rotateDeviceActivations re-encrypts max. limit device-activations which
+// are not encrypted using the given master-key.
+func rotateDeviceActivations(db sqlx.Ext, masterKeyID string, limit int) (int, error) {
+	var activations []deviceActivationRow
+	err := sqlx.Select(db, &activations, "select * from device_activation where master_key_id <> $1 limit $2 for update", masterKeyID, limit)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	for _, row := range activations {
+		da, err := row.deviceActivation()
+		if err != nil {
+			return 0, errors.Wrapf(err, "decrypt device-activation %d error", row.ID)
+		}
+
+		keyID, dek, b, err := encryptKeys(da.sessionKeys()...)
+		if err != nil {
+			return 0, errors.Wrap(err, "encrypt keys error")
+		}
+
+		_, err = db.Exec(`
+            update device_activation
+            set
+                app_s_key = $2,
+                nwk_s_key = $3,
+                s_nwk_s_int_key = $4,
+                dek = $5,
+                master_key_id = $6
+            where
+                id = $1`,
+			da.ID,
+			b[0],
+			b[1],
+			da.sNwkSIntKeyBytes(b),
+			dek,
+			keyID,
+		)
+		if err != nil {
+			return 0, handlePSQLError(Update, err, "update error")
+		}
+	}
+
+	return len(activations), nil
+}
+
+// rotateUnclaimedDevices re-encrypts max. limit unclaimed devices which are
+// not encrypted using the given master-key.
+func rotateUnclaimedDevices(db sqlx.Ext, masterKeyID string, limit int) (int, error) {
+	var unclaimed []unclaimedDeviceRow
+	err := sqlx.Select(db, &unclaimed, "select * from unclaimed_device where master_key_id <> $1 limit $2 for update", masterKeyID, limit)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	for _, row := range unclaimed {
+		ud, err := row.unclaimedDevice()
+		if err != nil {
+			return 0, errors.Wrapf(err, "decrypt unclaimed device %s error", row.DevEUI)
+		}
+
+		keyID, dek, b, err := encryptKeys(ud.AppKey, ud.NwkKey, ud.GenAppKey)
+		if err != nil {
+			return 0, errors.Wrap(err, "encrypt keys error")
+		}
+
+		_, err = db.Exec(`
+            update unclaimed_device
+            set
+                app_key = $2,
+                nwk_key = $3,
+                gen_app_key = $4,
+                dek = $5,
+                master_key_id = $6
+            where
+                dev_eui = $1`,
+			ud.DevEUI[:],
+			b[0],
+			b[1],
+			b[2],
+			dek,
+			keyID,
+		)
+		if err != nil {
+			return 0, handlePSQLError(Update, err, "update error")
+		}
+	}
+
+	return len(unclaimed), nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestKeyEncryption(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with a device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		d := Device{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:            "test-device",
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+		}
+		So(CreateDevice(db, &d), ShouldBeNil)
+
+		appKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+		appSKey := lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+
+		keys := map[string][]byte{
+			"key-1": {1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			"key-2": {2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		}
+		defer SetMasterKeys("", nil)
+
+		Convey("Then SetMasterKeys validates the master-keys", func() {
+			So(SetMasterKeys("key-3", keys), ShouldNotBeNil)
+			So(SetMasterKeys("key-1", map[string][]byte{"key-1": {1, 2, 3}}), ShouldNotBeNil)
+		})
+
+		Convey("Given no master-key is configured", func() {
+			So(SetMasterKeys("", nil), ShouldBeNil)
+			So(CreateDeviceKeys(db, &DeviceKeys{DevEUI: d.DevEUI, AppKey: appKey}), ShouldBeNil)
+
+			Convey("Then the keys are stored unencrypted", func() {
+				var row deviceKeysRow
+				So(sqlx.Get(db, &row, "select * from device_keys where dev_eui = $1", d.DevEUI[:]), ShouldBeNil)
+				So(row.AppKey, ShouldResemble, appKey[:])
+				So(row.MasterKeyID, ShouldEqual, "")
+			})
+
+			Convey("When rotating to master-key key-1", func() {
+				So(SetMasterKeys("key-1", keys), ShouldBeNil)
+				count, err := RotateMasterKey(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				Convey("Then the keys are encrypted using key-1", func() {
+					var row deviceKeysRow
+					So(sqlx.Get(db, &row, "select * from device_keys where dev_eui = $1", d.DevEUI[:]), ShouldBeNil)
+					So(row.AppKey, ShouldHaveLength, 24)
+					So(row.MasterKeyID, ShouldEqual, "key-1")
+
+					dk, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(dk.AppKey, ShouldEqual, appKey)
+				})
+			})
+		})
+
+		Convey("Given master-key key-1 is configured", func() {
+			So(SetMasterKeys("key-1", keys), ShouldBeNil)
+			So(CreateDeviceKeys(db, &DeviceKeys{DevEUI: d.DevEUI, AppKey: appKey}), ShouldBeNil)
+
+			da := DeviceActivation{
+				DevEUI:  d.DevEUI,
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				AppSKey: appSKey,
+			}
+			So(CreateDeviceActivation(db, &da), ShouldBeNil)
+
+			ud := UnclaimedDevice{
+				DevEUI:          lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+				AppKey:          appKey,
+				GenAppKey:       appSKey,
+			}
+			So(CreateUnclaimedDevice(db, &ud, "CLAIM-CODE-1234"), ShouldBeNil)
+
+			Convey("Then the keys are stored encrypted", func() {
+				var row deviceKeysRow
+				So(sqlx.Get(db, &row, "select * from device_keys where dev_eui = $1", d.DevEUI[:]), ShouldBeNil)
+				So(row.AppKey, ShouldHaveLength, 24)
+				So(row.AppKey, ShouldNotResemble, appKey[:])
+				So(row.MasterKeyID, ShouldEqual, "key-1")
+
+				var daRow deviceActivationRow
+				So(sqlx.Get(db, &daRow, "select * from device_activation where id = $1", da.ID), ShouldBeNil)
+				So(daRow.AppSKey, ShouldHaveLength, 24)
+				So(daRow.MasterKeyID, ShouldEqual, "key-1")
+			})
+
+			Convey("Then GetDeviceKeys returns the decrypted keys", func() {
+				dk, err := GetDeviceKeys(db, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(dk.AppKey, ShouldEqual, appKey)
+			})
+
+			Convey("Then GetLastDeviceActivationForDevEUI returns the decrypted keys", func() {
+				daGet, err := GetLastDeviceActivationForDevEUI(db, d.DevEUI)
+				So(err, ShouldBeNil)
+				So(daGet.AppSKey, ShouldEqual, appSKey)
+			})
+
+			Convey("When the master-key is not available", func() {
+				So(SetMasterKeys("", nil), ShouldBeNil)
+
+				Convey("Then GetDeviceKeys returns an error", func() {
+					_, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldNotBeNil)
+				})
+			})
+
+			Convey("When rotating to master-key key-2 in batches of one row", func() {
+				RotateMasterKeyBatchSize = 1
+				defer func() {
+					RotateMasterKeyBatchSize = 1000
+				}()
+
+				So(SetMasterKeys("key-2", keys), ShouldBeNil)
+				count, err := RotateMasterKey(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+
+				Convey("Then the keys can be decrypted without key-1", func() {
+					So(SetMasterKeys("key-2", map[string][]byte{"key-2": keys["key-2"]}), ShouldBeNil)
+
+					dk, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(dk.AppKey, ShouldEqual, appKey)
+
+					daGet, err := GetLastDeviceActivationForDevEUI(db, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(daGet.AppSKey, ShouldEqual, appSKey)
+
+					unclaimed, err := GetUnclaimedDevices(db, 10, 0)
+					So(err, ShouldBeNil)
+					So(unclaimed, ShouldHaveLength, 1)
+					So(unclaimed[0].AppKey, ShouldEqual, appKey)
+					So(unclaimed[0].GenAppKey, ShouldEqual, appSKey)
+				})
+
+				Convey("Then a second rotation does not re-encrypt any keys", func() {
+					count, err := RotateMasterKey(db)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+			})
+		})
+	})
+}
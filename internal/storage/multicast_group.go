@@ -0,0 +1,656 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/brocaar/loraserver/api/ns"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastGroupType defines the multicast-group type.
+type MulticastGroupType string
+
+// Available multicast-group types.
+const (
+	MulticastGroupClassB MulticastGroupType = "CLASS_B"
+	MulticastGroupClassC MulticastGroupType = "CLASS_C"
+)
+
+// MulticastGroup defines a multicast-group. The devices of the group share
+// the McAddr and session keys, so that a single (class B or C) downlink
+// reaches all the member devices. The multicast-group is provisioned to the
+// network-server using the NSMulticastGroupID.
+type MulticastGroup struct {
+	ID                 int64           `db:"id"`
+	NSMulticastGroupID string          `db:"ns_multicast_group_id"`
+	ApplicationID      int64           `db:"application_id"`
+	CreatedAt          time.Time       `db:"created_at"`
+	UpdatedAt          time.Time       `db:"updated_at"`
+	Name               string          `db:"name"`
+	McAddr             lorawan.DevAddr `db:"mc_addr"`
+	// McKey is the key from which the session keys are derived. It is only
+	// set when known, it is required to provision the members using the
+	// Remote Multicast Setup package.
+	McKey          *lorawan.AES128Key `db:"mc_key"`
+	McAppSKey      lorawan.AES128Key  `db:"mc_app_s_key"`
+	McNwkSKey      lorawan.AES128Key  `db:"mc_nwk_s_key"`
+	McGroupID      uint8              `db:"mc_group_id"`
+	GroupType      MulticastGroupType `db:"group_type"`
+	DR             uint8              `db:"dr"`
+	Frequency      int                `db:"frequency"`
+	PingSlotPeriod uint8              `db:"ping_slot_period"`
+	FCnt           uint32             `db:"f_cnt"`
+}
+
+// Validate validates the multicast-group data.
+func (mg MulticastGroup) Validate() error {
+	if mg.Name == "" {
+		return ErrMulticastGroupInvalidName
+	}
+
+	switch mg.GroupType {
+	case MulticastGroupClassB, MulticastGroupClassC:
+	default:
+		return ErrMulticastGroupInvalidType
+	}
+
+	if mg.McGroupID > 3 || mg.DR > 15 || mg.Frequency <= 0 || mg.PingSlotPeriod > 7 {
+		return ErrMulticastGroupInvalidConfig
+	}
+
+	return nil
+}
+
+// MulticastGroupDevice defines a member device of a multicast-group,
+// including the state of its Remote Multicast Setup.
+type MulticastGroupDevice struct {
+	MulticastGroupID   int64         `db:"multicast_group_id"`
+	DevEUI             lorawan.EUI64 `db:"dev_eui"`
+	CreatedAt          time.Time     `db:"created_at"`
+	SetupRequestedAt   *time.Time    `db:"setup_requested_at"`
+	SetupCompletedAt   *time.Time    `db:"setup_completed_at"`
+	SessionRequestedAt *time.Time    `db:"session_requested_at"`
+	SessionStartAt     *time.Time    `db:"session_start_at"`
+	LastError          string        `db:"last_error"`
+}
+
+// MulticastQueueItem defines a multicast downlink payload, encrypted with the
+// McAppSKey of the multicast-group.
+type MulticastQueueItem struct {
+	ID               int64     `db:"id"`
+	CreatedAt        time.Time `db:"created_at"`
+	MulticastGroupID int64     `db:"multicast_group_id"`
+	FCnt             uint32    `db:"f_cnt"`
+	FPort            uint8     `db:"f_port"`
+	FRMPayload       []byte    `db:"frm_payload"`
+}
+
+// CreateMulticastGroup creates the given multicast-group, locally and at
+// the network-server.
+func CreateMulticastGroup(db sqlx.Ext, mg *MulticastGroup) error {
+	if err := mg.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	mg.CreatedAt = now
+	mg.UpdatedAt = now
+	mg.NSMulticastGroupID = uuid.NewV4().String()
+
+	err := sqlx.Get(db, &mg.ID, `
+		insert into multicast_group (
+			ns_multicast_group_id,
+			application_id,
+			created_at,
+			updated_at,
+			name,
+			mc_addr,
+			mc_key,
+			mc_app_s_key,
+			mc_nwk_s_key,
+			mc_group_id,
+			group_type,
+			dr,
+			frequency,
+			ping_slot_period,
+			f_cnt
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) returning id`,
+		mg.NSMulticastGroupID,
+		mg.ApplicationID,
+		mg.CreatedAt,
+		mg.UpdatedAt,
+		mg.Name,
+		mg.McAddr[:],
+		mg.mcKeyBytes(),
+		mg.McAppSKey[:],
+		mg.McNwkSKey[:],
+		mg.McGroupID,
+		mg.GroupType,
+		mg.DR,
+		mg.Frequency,
+		mg.PingSlotPeriod,
+		mg.FCnt,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	app, err := GetApplication(db, mg.ApplicationID)
+	if err != nil {
+		return errors.Wrap(err, "get application error")
+	}
+
+	nsClient, err := getNSClientForMulticastGroupID(db, mg.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = nsClient.CreateMulticastGroup(context.Background(), &ns.CreateMulticastGroupRequest{
+		MulticastGroup: mg.nsMulticastGroup(app.ServiceProfileID),
+	})
+	if err != nil {
+		log.WithError(err).Error("network-server create multicast-group api error")
+		return handleGrpcError(err, "create multicast-group error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":             mg.ID,
+		"application_id": mg.ApplicationID,
+		"mc_addr":        mg.McAddr,
+	}).Info("multicast-group created")
+
+	return nil
+}
+
+// GetMulticastGroup returns the multicast-group matching the given id.
+func GetMulticastGroup(db sqlx.Queryer, id int64) (MulticastGroup, error) {
+	var mg MulticastGroup
+	err := sqlx.Get(db, &mg, "select * from multicast_group where id = $1", id)
+	if err != nil {
+		return mg, handlePSQLError(Select, err, "select error")
+	}
+
+	return mg, nil
+}
+
+// UpdateMulticastGroup updates the given multicast-group, locally and at the
+// network-server. Note that the frame-counter is not updated, the current
+// frame-counter is sent to the network-server as it overwrites its own.
+func UpdateMulticastGroup(db sqlx.Ext, mg *MulticastGroup) error {
+	if err := mg.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	mg.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+		update multicast_group
+		set
+			updated_at = $2,
+			name = $3,
+			mc_addr = $4,
+			mc_key = $5,
+			mc_app_s_key = $6,
+			mc_nwk_s_key = $7,
+			mc_group_id = $8,
+			group_type = $9,
+			dr = $10,
+			frequency = $11,
+			ping_slot_period = $12
+		where id = $1`,
+		mg.ID,
+		mg.UpdatedAt,
+		mg.Name,
+		mg.McAddr[:],
+		mg.mcKeyBytes(),
+		mg.McAppSKey[:],
+		mg.McNwkSKey[:],
+		mg.McGroupID,
+		mg.GroupType,
+		mg.DR,
+		mg.Frequency,
+		mg.PingSlotPeriod,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	app, err := GetApplication(db, mg.ApplicationID)
+	if err != nil {
+		return errors.Wrap(err, "get application error")
+	}
+
+	nsClient, err := getNSClientForMulticastGroupID(db, mg.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = nsClient.UpdateMulticastGroup(context.Background(), &ns.UpdateMulticastGroupRequest{
+		MulticastGroup: mg.nsMulticastGroup(app.ServiceProfileID),
+	})
+	if err != nil {
+		log.WithError(err).Error("network-server update multicast-group api error")
+		return handleGrpcError(err, "update multicast-group error")
+	}
+
+	log.WithField("id", mg.ID).Info("multicast-group updated")
+
+	return nil
+}
+
+// DeleteMulticastGroup deletes the multicast-group matching the given id,
+// locally and at the network-server.
+func DeleteMulticastGroup(db sqlx.Ext, id int64) error {
+	mg, err := GetMulticastGroup(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	nsClient, err := getNSClientForMulticastGroupID(db, id)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.Exec("delete from multicast_group where id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	_, err = nsClient.DeleteMulticastGroup(context.Background(), &ns.DeleteMulticastGroupRequest{
+		Id: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+	})
+	if err != nil && grpc.Code(err) != codes.NotFound {
+		log.WithError(err).Error("network-server delete multicast-group api error")
+		return handleGrpcError(err, "delete multicast-group error")
+	}
+
+	log.WithField("id", id).Info("multicast-group deleted")
+
+	return nil
+}
+
+// GetMulticastGroupCountForApplicationID returns the total number of
+// multicast-groups for the given application.
+func GetMulticastGroupCountForApplicationID(db sqlx.Queryer, applicationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from multicast_group where application_id = $1", applicationID)
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetMulticastGroupCountForDevEUI returns the number of multicast-groups
+// of which the given device is a member.
+func GetMulticastGroupCountForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from multicast_group_device where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetMulticastGroupsForApplicationID returns a slice of multicast-groups for
+// the given application, sorted by name.
+func GetMulticastGroupsForApplicationID(db sqlx.Queryer, applicationID int64, limit, offset int) ([]MulticastGroup, error) {
+	var groups []MulticastGroup
+	err := sqlx.Select(db, &groups, `
+		select *
+		from multicast_group
+		where
+			application_id = $1
+		order by name
+		limit $2 offset $3`,
+		applicationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return groups, nil
+}
+
+// IncrementMulticastGroupFCnt increments the frame-counter of the given
+// multicast-group. It returns the frame-counter to use for the next
+// multicast downlink.
+func IncrementMulticastGroupFCnt(db sqlx.Queryer, id int64) (uint32, error) {
+	var fCnt uint32
+	err := sqlx.Get(db, &fCnt, `
+		update multicast_group
+		set
+			f_cnt = f_cnt + 1
+		where id = $1
+		returning f_cnt - 1`,
+		id,
+	)
+	if err != nil {
+		return 0, handlePSQLError(Update, err, "update error")
+	}
+
+	return fCnt, nil
+}
+
+// AddDeviceToMulticastGroup adds the given device to the multicast-group.
+// The device must belong to the application of the multicast-group.
+func AddDeviceToMulticastGroup(db sqlx.Ext, id int64, devEUI lorawan.EUI64) error {
+	mg, err := GetMulticastGroup(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	d, err := GetDevice(db, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device error")
+	}
+
+	if d.ApplicationID != mg.ApplicationID {
+		return ErrMulticastGroupInvalidDevice
+	}
+
+	_, err = db.Exec(`
+		insert into multicast_group_device (
+			multicast_group_id,
+			dev_eui,
+			created_at
+		) values ($1, $2, $3)`,
+		id,
+		devEUI[:],
+		time.Now(),
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	nsClient, err := getNSClientForMulticastGroupID(db, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = nsClient.AddDeviceToMulticastGroup(context.Background(), &ns.AddDeviceToMulticastGroupRequest{
+		DevEui:           devEUI[:],
+		MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+	})
+	if err != nil {
+		log.WithError(err).Error("network-server add device to multicast-group api error")
+		return handleGrpcError(err, "add device to multicast-group error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":      id,
+		"dev_eui": devEUI,
+	}).Info("device added to multicast-group")
+
+	return nil
+}
+
+// RemoveDeviceFromMulticastGroup removes the given device from the
+// multicast-group.
+func RemoveDeviceFromMulticastGroup(db sqlx.Ext, id int64, devEUI lorawan.EUI64) error {
+	mg, err := GetMulticastGroup(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	res, err := db.Exec(`
+		delete from multicast_group_device
+		where
+			multicast_group_id = $1
+			and dev_eui = $2`,
+		id,
+		devEUI[:],
+	)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	nsClient, err := getNSClientForMulticastGroupID(db, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = nsClient.RemoveDeviceFromMulticastGroup(context.Background(), &ns.RemoveDeviceFromMulticastGroupRequest{
+		DevEui:           devEUI[:],
+		MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+	})
+	if err != nil && grpc.Code(err) != codes.NotFound {
+		log.WithError(err).Error("network-server remove device from multicast-group api error")
+		return handleGrpcError(err, "remove device from multicast-group error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":      id,
+		"dev_eui": devEUI,
+	}).Info("device removed from multicast-group")
+
+	return nil
+}
+
+// GetMulticastGroupDevices returns the member devices of the given
+// multicast-group.
+func GetMulticastGroupDevices(db sqlx.Queryer, id int64) ([]MulticastGroupDevice, error) {
+	var devices []MulticastGroupDevice
+	err := sqlx.Select(db, &devices, `
+		select *
+		from multicast_group_device
+		where
+			multicast_group_id = $1
+		order by dev_eui`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return devices, nil
+}
+
+// GetMulticastGroupDeviceForMcGroupID returns the multicast-group membership
+// of the given device for the given McGroupID (the group slot on the
+// device). When the device is a member of multiple multicast-groups using
+// the same McGroupID, the one for which the setup was requested most
+// recently is returned.
+func GetMulticastGroupDeviceForMcGroupID(db sqlx.Queryer, devEUI lorawan.EUI64, mcGroupID uint8) (MulticastGroupDevice, error) {
+	var d MulticastGroupDevice
+	err := sqlx.Get(db, &d, `
+		select
+			mgd.*
+		from multicast_group_device mgd
+		inner join multicast_group mg
+			on mg.id = mgd.multicast_group_id
+		where
+			mgd.dev_eui = $1
+			and mg.mc_group_id = $2
+		order by mgd.setup_requested_at desc nulls last
+		limit 1`,
+		devEUI[:],
+		mcGroupID,
+	)
+	if err != nil {
+		return d, handlePSQLError(Select, err, "select error")
+	}
+
+	return d, nil
+}
+
+// UpdateMulticastGroupDevice updates the Remote Multicast Setup state of the
+// given multicast-group member.
+func UpdateMulticastGroupDevice(db sqlx.Execer, d MulticastGroupDevice) error {
+	res, err := db.Exec(`
+		update multicast_group_device
+		set
+			setup_requested_at = $3,
+			setup_completed_at = $4,
+			session_requested_at = $5,
+			session_start_at = $6,
+			last_error = $7
+		where
+			multicast_group_id = $1
+			and dev_eui = $2`,
+		d.MulticastGroupID,
+		d.DevEUI[:],
+		d.SetupRequestedAt,
+		d.SetupCompletedAt,
+		d.SessionRequestedAt,
+		d.SessionStartAt,
+		d.LastError,
+	)
+	if err != nil {
+		return handlePSQLError(Update, err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	return nil
+}
+
+// CreateMulticastQueueItem creates the given multicast queue-item.
+func CreateMulticastQueueItem(db sqlx.Queryer, qi *MulticastQueueItem) error {
+	qi.CreatedAt = time.Now()
+
+	err := sqlx.Get(db, &qi.ID, `
+		insert into multicast_queue_item (
+			created_at,
+			multicast_group_id,
+			f_cnt,
+			f_port,
+			frm_payload
+		) values ($1, $2, $3, $4, $5) returning id`,
+		qi.CreatedAt,
+		qi.MulticastGroupID,
+		qi.FCnt,
+		qi.FPort,
+		qi.FRMPayload,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":                 qi.ID,
+		"multicast_group_id": qi.MulticastGroupID,
+		"f_cnt":              qi.FCnt,
+	}).Info("multicast queue-item created")
+
+	return nil
+}
+
+// GetMulticastQueueItemsForMulticastGroup returns the queue-items of the
+// given multicast-group, ordered by frame-counter.
+func GetMulticastQueueItemsForMulticastGroup(db sqlx.Queryer, id int64) ([]MulticastQueueItem, error) {
+	var items []MulticastQueueItem
+	err := sqlx.Select(db, &items, `
+		select *
+		from multicast_queue_item
+		where
+			multicast_group_id = $1
+		order by f_cnt`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	return items, nil
+}
+
+// FlushMulticastQueueForMulticastGroup deletes the queue-items of the given
+// multicast-group.
+func FlushMulticastQueueForMulticastGroup(db sqlx.Execer, id int64) error {
+	_, err := db.Exec("delete from multicast_queue_item where multicast_group_id = $1", id)
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+
+	return nil
+}
+
+// nsMulticastGroup returns the network-server representation of the
+// multicast-group. The network-server expects the ping-slot period in
+// ping-slots (of 30 ms) instead of the periodicity (2^periodicity seconds).
+func (mg MulticastGroup) nsMulticastGroup(serviceProfileID string) *ns.MulticastGroup {
+	out := ns.MulticastGroup{
+		Id:               uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+		McAddr:           mg.McAddr[:],
+		McNwkSKey:        mg.McNwkSKey[:],
+		FCnt:             mg.FCnt,
+		GroupType:        ns.MulticastGroupType_CLASS_C,
+		Dr:               uint32(mg.DR),
+		Frequency:        uint32(mg.Frequency),
+		ServiceProfileId: uuid.FromStringOrNil(serviceProfileID).Bytes(),
+		RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
+	}
+
+	if mg.GroupType == MulticastGroupClassB {
+		out.GroupType = ns.MulticastGroupType_CLASS_B
+		out.PingSlotPeriod = 32 << mg.PingSlotPeriod
+	}
+
+	return &out
+}
+
+// getNSClientForMulticastGroupID returns the network-server client for the
+// given multicast-group.
+func getNSClientForMulticastGroupID(db sqlx.Queryer, id int64) (ns.NetworkServerServiceClient, error) {
+	n, err := GetNetworkServerForMulticastGroupID(db, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get network-server error")
+	}
+
+	nsClient, err := config.C.NetworkServer.Pool.Get(n.Server, []byte(n.CACert), []byte(n.TLSCert), []byte(n.TLSKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get network-server client error")
+	}
+
+	return nsClient, nil
+}
+
+// mcKeyBytes returns the McKey as bytes or nil (stored as NULL) when the
+// McKey is unknown.
+func (mg MulticastGroup) mcKeyBytes() interface{} {
+	if mg.McKey == nil {
+		return nil
+	}
+	return mg.McKey[:]
+}
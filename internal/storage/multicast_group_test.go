@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/ns"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestMulticastGroup(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an organization, network-server, service-profile, device-profile and two applications", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		app2 := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app-2",
+		}
+		So(CreateApplication(db, &app2), ShouldBeNil)
+
+		devices := []Device{
+			{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Name: "device-1", ApplicationID: app.ID, DeviceProfileID: dp.DeviceProfile.DeviceProfileID},
+			{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Name: "device-2", ApplicationID: app2.ID, DeviceProfileID: dp.DeviceProfile.DeviceProfileID},
+		}
+		for i := range devices {
+			So(CreateDevice(db, &devices[i]), ShouldBeNil)
+		}
+
+		Convey("When creating a multicast-group with an invalid type", func() {
+			mg := MulticastGroup{
+				ApplicationID: app.ID,
+				Name:          "test-group",
+				GroupType:     "CLASS_A",
+				Frequency:     869525000,
+			}
+			err := CreateMulticastGroup(db, &mg)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrMulticastGroupInvalidType)
+			})
+		})
+
+		Convey("When creating a multicast-group with an invalid McGroupID", func() {
+			mg := MulticastGroup{
+				ApplicationID: app.ID,
+				Name:          "test-group",
+				GroupType:     MulticastGroupClassC,
+				McGroupID:     4,
+				Frequency:     869525000,
+			}
+			err := CreateMulticastGroup(db, &mg)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrMulticastGroupInvalidConfig)
+			})
+		})
+
+		Convey("When creating a multicast-group", func() {
+			mcKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+			mg := MulticastGroup{
+				ApplicationID: app.ID,
+				Name:          "test-group",
+				McAddr:        lorawan.DevAddr{1, 2, 3, 4},
+				McKey:         &mcKey,
+				McAppSKey:     lorawan.AES128Key{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+				McNwkSKey:     lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+				McGroupID:     1,
+				GroupType:     MulticastGroupClassC,
+				DR:            3,
+				Frequency:     869525000,
+				FCnt:          10,
+			}
+			So(CreateMulticastGroup(db, &mg), ShouldBeNil)
+			mg.CreatedAt = mg.CreatedAt.Truncate(time.Millisecond).UTC()
+			mg.UpdatedAt = mg.UpdatedAt.Truncate(time.Millisecond).UTC()
+
+			Convey("Then it has been created at the network-server", func() {
+				So(<-nsClient.CreateMulticastGroupChan, ShouldResemble, ns.CreateMulticastGroupRequest{
+					MulticastGroup: &ns.MulticastGroup{
+						Id:               uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+						McAddr:           mg.McAddr[:],
+						McNwkSKey:        mg.McNwkSKey[:],
+						FCnt:             10,
+						GroupType:        ns.MulticastGroupType_CLASS_C,
+						Dr:               3,
+						Frequency:        869525000,
+						ServiceProfileId: uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
+						RoutingProfileId: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
+					},
+				})
+			})
+
+			Convey("Then it can be retrieved", func() {
+				mg2, err := GetMulticastGroup(db, mg.ID)
+				So(err, ShouldBeNil)
+				mg2.CreatedAt = mg2.CreatedAt.Truncate(time.Millisecond).UTC()
+				mg2.UpdatedAt = mg2.UpdatedAt.Truncate(time.Millisecond).UTC()
+				So(mg2, ShouldResemble, mg)
+			})
+
+			Convey("Then it is returned for the application", func() {
+				count, err := GetMulticastGroupCountForApplicationID(db, app.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				groups, err := GetMulticastGroupsForApplicationID(db, app.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(groups, ShouldHaveLength, 1)
+				So(groups[0].ID, ShouldEqual, mg.ID)
+
+				count, err = GetMulticastGroupCountForApplicationID(db, app2.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("Then it can be updated without the McKey", func() {
+				mg.Name = "updated-group"
+				mg.McKey = nil
+				mg.GroupType = MulticastGroupClassB
+				mg.PingSlotPeriod = 7
+				So(UpdateMulticastGroup(db, &mg), ShouldBeNil)
+
+				mg2, err := GetMulticastGroup(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(mg2.Name, ShouldEqual, "updated-group")
+				So(mg2.McKey, ShouldBeNil)
+				So(mg2.GroupType, ShouldEqual, MulticastGroupClassB)
+				So(mg2.PingSlotPeriod, ShouldEqual, 7)
+				So(mg2.FCnt, ShouldEqual, 10)
+
+				req := <-nsClient.UpdateMulticastGroupChan
+				So(req.MulticastGroup.GroupType, ShouldEqual, ns.MulticastGroupType_CLASS_B)
+				So(req.MulticastGroup.PingSlotPeriod, ShouldEqual, 4096)
+				So(req.MulticastGroup.FCnt, ShouldEqual, 10)
+			})
+
+			Convey("Then the frame-counter can be incremented", func() {
+				fCnt, err := IncrementMulticastGroupFCnt(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(fCnt, ShouldEqual, 10)
+
+				fCnt, err = IncrementMulticastGroupFCnt(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(fCnt, ShouldEqual, 11)
+
+				mg2, err := GetMulticastGroup(db, mg.ID)
+				So(err, ShouldBeNil)
+				So(mg2.FCnt, ShouldEqual, 12)
+			})
+
+			Convey("Then a device of an other application can not be added", func() {
+				err := AddDeviceToMulticastGroup(db, mg.ID, devices[1].DevEUI)
+				So(errors.Cause(err), ShouldEqual, ErrMulticastGroupInvalidDevice)
+			})
+
+			Convey("When adding a device of the same application", func() {
+				So(AddDeviceToMulticastGroup(db, mg.ID, devices[0].DevEUI), ShouldBeNil)
+
+				Convey("Then it has been added at the network-server", func() {
+					So(<-nsClient.AddDeviceToMulticastGroupChan, ShouldResemble, ns.AddDeviceToMulticastGroupRequest{
+						DevEui:           devices[0].DevEUI[:],
+						MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+					})
+				})
+
+				Convey("Then it can not be added twice", func() {
+					err := AddDeviceToMulticastGroup(db, mg.ID, devices[0].DevEUI)
+					So(errors.Cause(err), ShouldEqual, ErrAlreadyExists)
+				})
+
+				Convey("Then it is returned as member", func() {
+					members, err := GetMulticastGroupDevices(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(members, ShouldHaveLength, 1)
+					So(members[0].DevEUI, ShouldEqual, devices[0].DevEUI)
+					So(members[0].SetupRequestedAt, ShouldBeNil)
+
+					count, err := GetMulticastGroupCountForDevEUI(db, devices[0].DevEUI)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 1)
+
+					count, err = GetMulticastGroupCountForDevEUI(db, devices[1].DevEUI)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+
+				Convey("Then the setup state can be updated and retrieved by McGroupID", func() {
+					members, err := GetMulticastGroupDevices(db, mg.ID)
+					So(err, ShouldBeNil)
+
+					now := time.Now().Truncate(time.Millisecond).UTC()
+					members[0].SetupRequestedAt = &now
+					members[0].LastError = "test error"
+					So(UpdateMulticastGroupDevice(db, members[0]), ShouldBeNil)
+
+					d, err := GetMulticastGroupDeviceForMcGroupID(db, devices[0].DevEUI, 1)
+					So(err, ShouldBeNil)
+					So(d.MulticastGroupID, ShouldEqual, mg.ID)
+					So(d.SetupRequestedAt.Truncate(time.Millisecond).UTC(), ShouldResemble, now)
+					So(d.LastError, ShouldEqual, "test error")
+
+					_, err = GetMulticastGroupDeviceForMcGroupID(db, devices[0].DevEUI, 2)
+					So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Then it can be removed", func() {
+					So(RemoveDeviceFromMulticastGroup(db, mg.ID, devices[0].DevEUI), ShouldBeNil)
+					members, err := GetMulticastGroupDevices(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(members, ShouldHaveLength, 0)
+
+					err = RemoveDeviceFromMulticastGroup(db, mg.ID, devices[0].DevEUI)
+					So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+
+					So(<-nsClient.RemoveDeviceFromMulticastGroupChan, ShouldResemble, ns.RemoveDeviceFromMulticastGroupRequest{
+						DevEui:           devices[0].DevEUI[:],
+						MulticastGroupId: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+					})
+				})
+			})
+
+			Convey("When creating a queue-item", func() {
+				qi := MulticastQueueItem{
+					MulticastGroupID: mg.ID,
+					FCnt:             10,
+					FPort:            5,
+					FRMPayload:       []byte{1, 2, 3},
+				}
+				So(CreateMulticastQueueItem(db, &qi), ShouldBeNil)
+
+				Convey("Then it is returned for the multicast-group", func() {
+					items, err := GetMulticastQueueItemsForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 1)
+					So(items[0].FCnt, ShouldEqual, 10)
+					So(items[0].FPort, ShouldEqual, 5)
+					So(items[0].FRMPayload, ShouldResemble, []byte{1, 2, 3})
+				})
+
+				Convey("Then the queue can be flushed", func() {
+					So(FlushMulticastQueueForMulticastGroup(db, mg.ID), ShouldBeNil)
+					items, err := GetMulticastQueueItemsForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteMulticastGroup(db, mg.ID), ShouldBeNil)
+				_, err := GetMulticastGroup(db, mg.ID)
+				So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+
+				So(<-nsClient.DeleteMulticastGroupChan, ShouldResemble, ns.DeleteMulticastGroupRequest{
+					Id: uuid.FromStringOrNil(mg.NSMulticastGroupID).Bytes(),
+				})
+			})
+		})
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -31,6 +32,17 @@ type NetworkServer struct {
 	GatewayDiscoveryInterval    int       `db:"gateway_discovery_interval"`
 	GatewayDiscoveryTXFrequency int       `db:"gateway_discovery_tx_frequency"`
 	GatewayDiscoveryDR          int       `db:"gateway_discovery_dr"`
+
+	// NetID contains the NetID (3 bytes) of the network-server. When set,
+	// the join-server only accepts join-requests for devices of this
+	// network-server with a matching SenderID.
+	NetID []byte `db:"net_id"`
+
+	// JoinServerClientCN contains the common name of the TLS client
+	// certificate used by the network-server when connecting to the
+	// join-server. When set, the join-server only accepts join-requests for
+	// devices of this network-server using this certificate.
+	JoinServerClientCN string `db:"join_server_client_cn"`
 }
 
 // Validate validates the network-server data.
@@ -38,6 +50,9 @@ func (ns NetworkServer) Validate() error {
 	if ns.GatewayDiscoveryEnabled && ns.GatewayDiscoveryInterval <= 0 {
 		return ErrInvalidGatewayDiscoveryInterval
 	}
+	if ns.NetID != nil && len(ns.NetID) != len(lorawan.NetID{}) {
+		return ErrInvalidNetID
+	}
 	return nil
 }
 
@@ -66,8 +81,10 @@ func CreateNetworkServer(db sqlx.Queryer, n *NetworkServer) error {
 			gateway_discovery_enabled,
 			gateway_discovery_interval,
 			gateway_discovery_tx_frequency,
-			gateway_discovery_dr
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			gateway_discovery_dr,
+			net_id,
+			join_server_client_cn
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		returning id`,
 		n.CreatedAt,
 		n.UpdatedAt,
@@ -83,6 +100,8 @@ func CreateNetworkServer(db sqlx.Queryer, n *NetworkServer) error {
 		n.GatewayDiscoveryInterval,
 		n.GatewayDiscoveryTXFrequency,
 		n.GatewayDiscoveryDR,
+		n.NetID,
+		n.JoinServerClientCN,
 	)
 	if err != nil {
 		return handlePSQLError(Insert, err, "insert error")
@@ -95,12 +114,12 @@ func CreateNetworkServer(db sqlx.Queryer, n *NetworkServer) error {
 
 	_, err = nsClient.CreateRoutingProfile(context.Background(), &ns.CreateRoutingProfileRequest{
 		RoutingProfile: &ns.RoutingProfile{
-			RoutingProfileID: config.C.ApplicationServer.ID,
-			AsID:             config.C.ApplicationServer.API.PublicHost,
+			Id:      uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
+			AsId:    config.C.ApplicationServer.API.PublicHost,
+			CaCert:  n.RoutingProfileCACert,
+			TlsCert: n.RoutingProfileTLSCert,
+			TlsKey:  n.RoutingProfileTLSKey,
 		},
-		CaCert:  n.RoutingProfileCACert,
-		TlsCert: n.RoutingProfileTLSCert,
-		TlsKey:  n.RoutingProfileTLSKey,
 	})
 	if err != nil {
 		log.WithError(err).Error("network-server create routing-profile api error")
@@ -149,7 +168,9 @@ func UpdateNetworkServer(db sqlx.Execer, n *NetworkServer) error {
 			gateway_discovery_enabled = $11,
 			gateway_discovery_interval = $12,
 			gateway_discovery_tx_frequency = $13,
-			gateway_discovery_dr = $14
+			gateway_discovery_dr = $14,
+			net_id = $15,
+			join_server_client_cn = $16
 		where id = $1`,
 		n.ID,
 		n.UpdatedAt,
@@ -165,6 +186,8 @@ func UpdateNetworkServer(db sqlx.Execer, n *NetworkServer) error {
 		n.GatewayDiscoveryInterval,
 		n.GatewayDiscoveryTXFrequency,
 		n.GatewayDiscoveryDR,
+		n.NetID,
+		n.JoinServerClientCN,
 	)
 	if err != nil {
 		return handlePSQLError(Update, err, "update error")
@@ -185,12 +208,12 @@ func UpdateNetworkServer(db sqlx.Execer, n *NetworkServer) error {
 
 	_, err = nsClient.UpdateRoutingProfile(context.Background(), &ns.UpdateRoutingProfileRequest{
 		RoutingProfile: &ns.RoutingProfile{
-			RoutingProfileID: config.C.ApplicationServer.ID,
-			AsID:             config.C.ApplicationServer.API.PublicHost,
+			Id:      uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
+			AsId:    config.C.ApplicationServer.API.PublicHost,
+			CaCert:  n.RoutingProfileCACert,
+			TlsCert: n.RoutingProfileTLSCert,
+			TlsKey:  n.RoutingProfileTLSKey,
 		},
-		CaCert:  n.RoutingProfileCACert,
-		TlsCert: n.RoutingProfileTLSCert,
-		TlsKey:  n.RoutingProfileTLSKey,
 	})
 	if err != nil {
 		log.WithError(err).Error("network-server update routing-profile api error")
@@ -230,7 +253,7 @@ func DeleteNetworkServer(db sqlx.Ext, id int64) error {
 	}
 
 	_, err = nsClient.DeleteRoutingProfile(context.Background(), &ns.DeleteRoutingProfileRequest{
-		RoutingProfileID: config.C.ApplicationServer.ID,
+		Id: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 	})
 	if err != nil {
 		log.WithError(err).Error("network-server delete routing-profile api error")
@@ -386,6 +409,31 @@ func GetNetworkServerForServiceProfileID(db sqlx.Queryer, id string) (NetworkSer
 	return n, nil
 }
 
+// GetNetworkServerForMulticastGroupID returns the network-server for the
+// given multicast-group id.
+func GetNetworkServerForMulticastGroupID(db sqlx.Queryer, id int64) (NetworkServer, error) {
+	var n NetworkServer
+	err := sqlx.Get(db, &n, `
+		select
+			ns.*
+		from
+			network_server ns
+		inner join service_profile sp
+			on sp.network_server_id = ns.id
+		inner join application a
+			on a.service_profile_id = sp.service_profile_id
+		inner join multicast_group mg
+			on mg.application_id = a.id
+		where
+			mg.id = $1`,
+		id,
+	)
+	if err != nil {
+		return n, handlePSQLError(Select, err, "select error")
+	}
+	return n, nil
+}
+
 // GetNetworkServerForGatewayMAC returns the network-server for a given
 // gateway mac.
 func GetNetworkServerForGatewayMAC(db sqlx.Queryer, mac lorawan.EUI64) (NetworkServer, error) {
@@ -9,6 +9,7 @@ import (
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan/backend"
+	"github.com/satori/go.uuid"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -39,6 +40,12 @@ func TestNetworkServer(t *testing.T) {
 				},
 				ExpectedError: ErrInvalidGatewayDiscoveryInterval,
 			},
+			{
+				NetworkServer: NetworkServer{
+					NetID: []byte{1, 2},
+				},
+				ExpectedError: ErrInvalidNetID,
+			},
 		}
 
 		for i, test := range testTable {
@@ -73,6 +80,8 @@ func TestNetworkServer(t *testing.T) {
 				GatewayDiscoveryInterval:    5,
 				GatewayDiscoveryTXFrequency: 868100000,
 				GatewayDiscoveryDR:          5,
+				NetID:                       []byte{1, 2, 3},
+				JoinServerClientCN:          "ns.example.com",
 			}
 			So(CreateNetworkServer(db, &n), ShouldBeNil)
 			n.CreatedAt = n.CreatedAt.UTC().Truncate(time.Millisecond)
@@ -80,12 +89,12 @@ func TestNetworkServer(t *testing.T) {
 			So(nsClient.CreateRoutingProfileChan, ShouldHaveLength, 1)
 			So(<-nsClient.CreateRoutingProfileChan, ShouldResemble, ns.CreateRoutingProfileRequest{
 				RoutingProfile: &ns.RoutingProfile{
-					RoutingProfileID: config.C.ApplicationServer.ID,
-					AsID:             config.C.ApplicationServer.API.PublicHost,
+					Id:      uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
+					AsId:    config.C.ApplicationServer.API.PublicHost,
+					CaCert:  "RPCACERT",
+					TlsCert: "RPTLSCERT",
+					TlsKey:  "RPTLSKEY",
 				},
-				CaCert:  "RPCACERT",
-				TlsCert: "RPTLSCERT",
-				TlsKey:  "RPTLSKEY",
 			})
 
 			Convey("Then GetNetworkServer returns the network-server", func() {
@@ -157,16 +166,18 @@ func TestNetworkServer(t *testing.T) {
 				n.GatewayDiscoveryInterval = 1
 				n.GatewayDiscoveryTXFrequency = 868300000
 				n.GatewayDiscoveryDR = 4
+				n.NetID = nil
+				n.JoinServerClientCN = ""
 				So(UpdateNetworkServer(db, &n), ShouldBeNil)
 				So(nsClient.UpdateRoutingProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.UpdateRoutingProfileChan, ShouldResemble, ns.UpdateRoutingProfileRequest{
 					RoutingProfile: &ns.RoutingProfile{
-						RoutingProfileID: config.C.ApplicationServer.ID,
-						AsID:             config.C.ApplicationServer.API.PublicHost,
+						Id:      uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
+						AsId:    config.C.ApplicationServer.API.PublicHost,
+						CaCert:  "RPCACERT2",
+						TlsCert: "RPTLSCERT2",
+						TlsKey:  "RPTLSKEY2",
 					},
-					CaCert:  "RPCACERT2",
-					TlsCert: "RPTLSCERT2",
-					TlsKey:  "RPTLSKEY2",
 				})
 
 				n.UpdatedAt = n.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -183,7 +194,7 @@ func TestNetworkServer(t *testing.T) {
 				So(DeleteNetworkServer(db, n.ID), ShouldBeNil)
 				So(nsClient.DeleteRoutingProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.DeleteRoutingProfileChan, ShouldResemble, ns.DeleteRoutingProfileRequest{
-					RoutingProfileID: config.C.ApplicationServer.ID,
+					Id: uuid.FromStringOrNil(config.C.ApplicationServer.ID).Bytes(),
 				})
 
 				_, err := GetNetworkServer(db, n.ID)
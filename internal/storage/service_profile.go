@@ -75,12 +75,12 @@ func CreateServiceProfile(db sqlx.Ext, sp *ServiceProfile) error {
 
 	req := ns.CreateServiceProfileRequest{
 		ServiceProfile: &ns.ServiceProfile{
-			ServiceProfileID:       sp.ServiceProfile.ServiceProfileID,
+			Id:                     uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
 			UlRate:                 uint32(sp.ServiceProfile.ULRate),
 			UlBucketSize:           uint32(sp.ServiceProfile.ULBucketSize),
 			DlRate:                 uint32(sp.ServiceProfile.DLRate),
 			DlBucketSize:           uint32(sp.ServiceProfile.DLBucketSize),
-			AddGWMetadata:          sp.ServiceProfile.AddGWMetadata,
+			AddGwMetadata:          sp.ServiceProfile.AddGWMetadata,
 			DevStatusReqFreq:       uint32(sp.ServiceProfile.DevStatusReqFreq),
 			ReportDevStatusBattery: sp.ServiceProfile.ReportDevStatusBattery,
 			ReportDevStatusMargin:  sp.ServiceProfile.ReportDevStatusMargin,
@@ -91,8 +91,8 @@ func CreateServiceProfile(db sqlx.Ext, sp *ServiceProfile) error {
 			HrAllowed:      sp.ServiceProfile.HRAllowed,
 			RaAllowed:      sp.ServiceProfile.RAAllowed,
 			NwkGeoLoc:      sp.ServiceProfile.NwkGeoLoc,
-			TargetPER:      uint32(sp.ServiceProfile.TargetPER),
-			MinGWDiversity: uint32(sp.ServiceProfile.MinGWDiversity),
+			TargetPer:      uint32(sp.ServiceProfile.TargetPER),
+			MinGwDiversity: uint32(sp.ServiceProfile.MinGWDiversity),
 		},
 	}
 
@@ -165,19 +165,19 @@ func GetServiceProfile(db sqlx.Queryer, id string) (ServiceProfile, error) {
 	}
 
 	resp, err := nsClient.GetServiceProfile(context.Background(), &ns.GetServiceProfileRequest{
-		ServiceProfileID: id,
+		Id: uuid.FromStringOrNil(id).Bytes(),
 	})
 	if err != nil {
 		return sp, handleGrpcError(err, "get service-profile error")
 	}
 
 	sp.ServiceProfile = backend.ServiceProfile{
-		ServiceProfileID:       resp.ServiceProfile.ServiceProfileID,
+		ServiceProfileID:       uuid.FromBytesOrNil(resp.ServiceProfile.Id).String(),
 		ULRate:                 int(resp.ServiceProfile.UlRate),
 		ULBucketSize:           int(resp.ServiceProfile.UlBucketSize),
 		DLRate:                 int(resp.ServiceProfile.DlRate),
 		DLBucketSize:           int(resp.ServiceProfile.DlBucketSize),
-		AddGWMetadata:          resp.ServiceProfile.AddGWMetadata,
+		AddGWMetadata:          resp.ServiceProfile.AddGwMetadata,
 		DevStatusReqFreq:       int(resp.ServiceProfile.DevStatusReqFreq),
 		ReportDevStatusBattery: resp.ServiceProfile.ReportDevStatusBattery,
 		ReportDevStatusMargin:  resp.ServiceProfile.ReportDevStatusMargin,
@@ -188,8 +188,8 @@ func GetServiceProfile(db sqlx.Queryer, id string) (ServiceProfile, error) {
 		HRAllowed:      resp.ServiceProfile.HrAllowed,
 		RAAllowed:      resp.ServiceProfile.RaAllowed,
 		NwkGeoLoc:      resp.ServiceProfile.NwkGeoLoc,
-		TargetPER:      backend.Percentage(resp.ServiceProfile.TargetPER),
-		MinGWDiversity: int(resp.ServiceProfile.MinGWDiversity),
+		TargetPER:      backend.Percentage(resp.ServiceProfile.TargetPer),
+		MinGWDiversity: int(resp.ServiceProfile.MinGwDiversity),
 	}
 
 	switch resp.ServiceProfile.UlRatePolicy {
@@ -239,12 +239,12 @@ func UpdateServiceProfile(db sqlx.Ext, sp *ServiceProfile) error {
 
 	req := ns.UpdateServiceProfileRequest{
 		ServiceProfile: &ns.ServiceProfile{
-			ServiceProfileID:       sp.ServiceProfile.ServiceProfileID,
+			Id:                     uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
 			UlRate:                 uint32(sp.ServiceProfile.ULRate),
 			UlBucketSize:           uint32(sp.ServiceProfile.ULBucketSize),
 			DlRate:                 uint32(sp.ServiceProfile.DLRate),
 			DlBucketSize:           uint32(sp.ServiceProfile.DLBucketSize),
-			AddGWMetadata:          sp.ServiceProfile.AddGWMetadata,
+			AddGwMetadata:          sp.ServiceProfile.AddGWMetadata,
 			DevStatusReqFreq:       uint32(sp.ServiceProfile.DevStatusReqFreq),
 			ReportDevStatusBattery: sp.ServiceProfile.ReportDevStatusBattery,
 			ReportDevStatusMargin:  sp.ServiceProfile.ReportDevStatusMargin,
@@ -255,8 +255,8 @@ func UpdateServiceProfile(db sqlx.Ext, sp *ServiceProfile) error {
 			HrAllowed:      sp.ServiceProfile.HRAllowed,
 			RaAllowed:      sp.ServiceProfile.RAAllowed,
 			NwkGeoLoc:      sp.ServiceProfile.NwkGeoLoc,
-			TargetPER:      uint32(sp.ServiceProfile.TargetPER),
-			MinGWDiversity: uint32(sp.ServiceProfile.MinGWDiversity),
+			TargetPer:      uint32(sp.ServiceProfile.TargetPER),
+			MinGwDiversity: uint32(sp.ServiceProfile.MinGWDiversity),
 		},
 	}
 
@@ -319,7 +319,7 @@ func DeleteServiceProfile(db sqlx.Ext, id string) error {
 	}
 
 	_, err = nsClient.DeleteServiceProfile(context.Background(), &ns.DeleteServiceProfileRequest{
-		ServiceProfileID: id,
+		Id: uuid.FromStringOrNil(id).Bytes(),
 	})
 	if err != nil && grpc.Code(err) != codes.NotFound {
 		return handleGrpcError(err, "delete service-profile error")
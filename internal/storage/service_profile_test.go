@@ -8,6 +8,7 @@ import (
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan/backend"
+	"github.com/satori/go.uuid"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -75,14 +76,14 @@ func TestServiceProfile(t *testing.T) {
 			So(nsClient.CreateServiceProfileChan, ShouldHaveLength, 1)
 			So(<-nsClient.CreateServiceProfileChan, ShouldResemble, ns.CreateServiceProfileRequest{
 				ServiceProfile: &ns.ServiceProfile{
-					ServiceProfileID:       sp.ServiceProfile.ServiceProfileID,
+					Id:                     uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
 					UlRate:                 100,
 					UlBucketSize:           10,
 					UlRatePolicy:           ns.RatePolicy_MARK,
 					DlRate:                 200,
 					DlBucketSize:           20,
 					DlRatePolicy:           ns.RatePolicy_DROP,
-					AddGWMetadata:          true,
+					AddGwMetadata:          true,
 					DevStatusReqFreq:       4,
 					ReportDevStatusBattery: true,
 					ReportDevStatusMargin:  true,
@@ -92,8 +93,8 @@ func TestServiceProfile(t *testing.T) {
 					HrAllowed:      true,
 					RaAllowed:      true,
 					NwkGeoLoc:      true,
-					TargetPER:      10,
-					MinGWDiversity: 3,
+					TargetPer:      10,
+					MinGwDiversity: 3,
 				},
 			})
 			sp.CreatedAt = sp.CreatedAt.UTC().Truncate(time.Millisecond)
@@ -102,14 +103,14 @@ func TestServiceProfile(t *testing.T) {
 			Convey("Then GetServiceProfile returns the service-profile", func() {
 				nsClient.GetServiceProfileResponse = ns.GetServiceProfileResponse{
 					ServiceProfile: &ns.ServiceProfile{
-						ServiceProfileID:       sp.ServiceProfile.ServiceProfileID,
+						Id:                     uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
 						UlRate:                 100,
 						UlBucketSize:           10,
 						UlRatePolicy:           ns.RatePolicy_MARK,
 						DlRate:                 200,
 						DlBucketSize:           20,
 						DlRatePolicy:           ns.RatePolicy_DROP,
-						AddGWMetadata:          true,
+						AddGwMetadata:          true,
 						DevStatusReqFreq:       4,
 						ReportDevStatusBattery: true,
 						ReportDevStatusMargin:  true,
@@ -119,8 +120,8 @@ func TestServiceProfile(t *testing.T) {
 						HrAllowed:      true,
 						RaAllowed:      true,
 						NwkGeoLoc:      true,
-						TargetPER:      10,
-						MinGWDiversity: 3,
+						TargetPer:      10,
+						MinGwDiversity: 3,
 					},
 				}
 
@@ -160,14 +161,14 @@ func TestServiceProfile(t *testing.T) {
 				So(nsClient.UpdateServiceProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.UpdateServiceProfileChan, ShouldResemble, ns.UpdateServiceProfileRequest{
 					ServiceProfile: &ns.ServiceProfile{
-						ServiceProfileID:       sp.ServiceProfile.ServiceProfileID,
+						Id:                     uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
 						UlRate:                 101,
 						UlBucketSize:           11,
 						UlRatePolicy:           ns.RatePolicy_DROP,
 						DlRate:                 201,
 						DlBucketSize:           21,
 						DlRatePolicy:           ns.RatePolicy_MARK,
-						AddGWMetadata:          true,
+						AddGwMetadata:          true,
 						DevStatusReqFreq:       5,
 						ReportDevStatusBattery: true,
 						ReportDevStatusMargin:  true,
@@ -177,8 +178,8 @@ func TestServiceProfile(t *testing.T) {
 						HrAllowed:      true,
 						RaAllowed:      true,
 						NwkGeoLoc:      true,
-						TargetPER:      11,
-						MinGWDiversity: 4,
+						TargetPer:      11,
+						MinGwDiversity: 4,
 					},
 				})
 
@@ -193,7 +194,7 @@ func TestServiceProfile(t *testing.T) {
 				So(DeleteServiceProfile(config.C.PostgreSQL.DB, sp.ServiceProfile.ServiceProfileID), ShouldBeNil)
 				So(nsClient.DeleteServiceProfileChan, ShouldHaveLength, 1)
 				So(<-nsClient.DeleteServiceProfileChan, ShouldResemble, ns.DeleteServiceProfileRequest{
-					ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+					Id: uuid.FromStringOrNil(sp.ServiceProfile.ServiceProfileID).Bytes(),
 				})
 
 				_, err := GetServiceProfile(config.C.PostgreSQL.DB, sp.ServiceProfile.ServiceProfileID)
@@ -0,0 +1,339 @@
+package storage
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lorawan"
+)
+
+// claimCodeSize defines the number of random bytes of a generated claim-code.
+const claimCodeSize = 10
+
+// minClaimCodeLength defines the minimum length of a given claim-code, a
+// generated claim-code is 16 characters long.
+const minClaimCodeLength = 12
+
+// claimFailuresKeyTempl defines the key template of the failed claims
+// counter of a DevEUI.
+const claimFailuresKeyTempl = "lora:as:device:%s:claim_failures"
+
+// UnclaimedDevice defines a device which has been provisioned (e.g. in the
+// factory) together with a one-time claim-code, but which has not yet been
+// claimed into an application. The GenAppKey is only used by LoRaWAN 1.0.x
+// devices (see DeviceKeys).
+type UnclaimedDevice struct {
+	DevEUI          lorawan.EUI64
+	CreatedAt       time.Time
+	DeviceProfileID string
+	AppKey          lorawan.AES128Key
+	NwkKey          lorawan.AES128Key
+	GenAppKey       lorawan.AES128Key
+}
+
+// unclaimedDeviceRow defines the unclaimed_device row, containing the
+// encrypted keys (see key_encryption.go).
+type unclaimedDeviceRow struct {
+	DevEUI          lorawan.EUI64 `db:"dev_eui"`
+	CreatedAt       time.Time     `db:"created_at"`
+	DeviceProfileID string        `db:"device_profile_id"`
+	AppKey          []byte        `db:"app_key"`
+	NwkKey          []byte        `db:"nwk_key"`
+	GenAppKey       []byte        `db:"gen_app_key"`
+	DEK             []byte        `db:"dek"`
+	MasterKeyID     string        `db:"master_key_id"`
+	ClaimCodeHash   string        `db:"claim_code_hash"`
+}
+
+func (r unclaimedDeviceRow) unclaimedDevice() (UnclaimedDevice, error) {
+	keys, err := decryptKeys(r.MasterKeyID, r.DEK, r.AppKey, r.NwkKey)
+	if err != nil {
+		return UnclaimedDevice{}, err
+	}
+
+	// the GenAppKey is not set for unclaimed devices stored before it was
+	// added
+	var genAppKey lorawan.AES128Key
+	if r.GenAppKey != nil {
+		k, err := decryptKeys(r.MasterKeyID, r.DEK, r.GenAppKey)
+		if err != nil {
+			return UnclaimedDevice{}, err
+		}
+		genAppKey = k[0]
+	}
+
+	return UnclaimedDevice{
+		DevEUI:          r.DevEUI,
+		CreatedAt:       r.CreatedAt,
+		DeviceProfileID: r.DeviceProfileID,
+		AppKey:          keys[0],
+		NwkKey:          keys[1],
+		GenAppKey:       genAppKey,
+	}, nil
+}
+
+// GenerateClaimCode returns a random claim-code.
+func GenerateClaimCode() (string, error) {
+	b := make([]byte, claimCodeSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random bytes error")
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// CreateUnclaimedDevice creates the given unclaimed device. Only the hash of
+// the claim-code is stored.
+func CreateUnclaimedDevice(db sqlx.Execer, d *UnclaimedDevice, claimCode string) error {
+	if len(claimCode) < minClaimCodeLength {
+		return ErrClaimCodeLength
+	}
+
+	claimCodeHash, err := hash(claimCode, saltSize, HashIterations)
+	if err != nil {
+		return err
+	}
+
+	masterKeyID, dek, keys, err := encryptKeys(d.AppKey, d.NwkKey, d.GenAppKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt keys error")
+	}
+
+	d.CreatedAt = time.Now()
+
+	_, err = db.Exec(`
+		insert into unclaimed_device (
+			dev_eui,
+			created_at,
+			device_profile_id,
+			app_key,
+			nwk_key,
+			gen_app_key,
+			dek,
+			master_key_id,
+			claim_code_hash
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		d.DevEUI[:],
+		d.CreatedAt,
+		d.DeviceProfileID,
+		keys[0],
+		keys[1],
+		keys[2],
+		dek,
+		masterKeyID,
+		claimCodeHash,
+	)
+	if err != nil {
+		return handlePSQLError(Insert, err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":           d.DevEUI,
+		"device_profile_id": d.DeviceProfileID,
+	}).Info("unclaimed device created")
+
+	return nil
+}
+
+// GetUnclaimedDeviceCount returns the total number of unclaimed devices.
+func GetUnclaimedDeviceCount(db sqlx.Queryer) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from unclaimed_device")
+	if err != nil {
+		return 0, handlePSQLError(Select, err, "select error")
+	}
+	return count, nil
+}
+
+// GetUnclaimedDevices returns a slice of unclaimed devices, sorted by
+// creation time.
+func GetUnclaimedDevices(db sqlx.Queryer, limit, offset int) ([]UnclaimedDevice, error) {
+	var rows []unclaimedDeviceRow
+	err := sqlx.Select(db, &rows, `
+		select *
+		from unclaimed_device
+		order by created_at, dev_eui
+		limit $1
+		offset $2`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(Select, err, "select error")
+	}
+
+	var out []UnclaimedDevice
+	for _, row := range rows {
+		d, err := row.unclaimedDevice()
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypt unclaimed device %s error", row.DevEUI)
+		}
+		out = append(out, d)
+	}
+
+	return out, nil
+}
+
+// DeleteUnclaimedDevice deletes the unclaimed device matching the given DevEUI.
+func DeleteUnclaimedDevice(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	res, err := db.Exec("delete from unclaimed_device where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return handlePSQLError(Delete, err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("dev_eui", devEUI).Info("unclaimed device deleted")
+	return nil
+}
+
+// ClaimDevice claims the unclaimed device matching the given DevEUI and
+// claim-code into the given application. It creates the device and its
+// device-keys and removes the device from the unclaimed devices, so that the
+// claim-code can only be used once. An unknown DevEUI and an invalid
+// claim-code both return ErrInvalidClaimCode, so that the unclaimed DevEUIs
+// can't be enumerated. The number of failed claims is limited per DevEUI, so
+// that the claim-code can't be brute-forced. Note that this must be executed
+// within a transaction, as creating the device also creates the device on the
+// network-server.
+func ClaimDevice(db sqlx.Ext, devEUI lorawan.EUI64, claimCode string, applicationID int64) (Device, error) {
+	if err := checkClaimFailures(devEUI); err != nil {
+		return Device{}, err
+	}
+
+	var row unclaimedDeviceRow
+	err := sqlx.Get(db, &row, "select * from unclaimed_device where dev_eui = $1 for update", devEUI[:])
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// hash the claim-code anyway, so that an unknown DevEUI can't be
+			// detected by the response time
+			if _, err := hash(claimCode, saltSize, HashIterations); err != nil {
+				return Device{}, err
+			}
+			log.WithField("dev_eui", devEUI).Warning("claim of unknown unclaimed device")
+			return Device{}, claimFailed(devEUI)
+		}
+		return Device{}, handlePSQLError(Select, err, "select error")
+	}
+
+	if !hashCompare(claimCode, row.ClaimCodeHash) {
+		log.WithField("dev_eui", devEUI).Warning("invalid device claim-code")
+		return Device{}, claimFailed(devEUI)
+	}
+
+	ud, err := row.unclaimedDevice()
+	if err != nil {
+		return Device{}, errors.Wrap(err, "decrypt unclaimed device error")
+	}
+
+	app, err := GetApplication(db, applicationID)
+	if err != nil {
+		return Device{}, errors.Wrap(err, "get application error")
+	}
+
+	dpNS, err := GetNetworkServerForDeviceProfileID(db, ud.DeviceProfileID)
+	if err != nil {
+		return Device{}, errors.Wrap(err, "get network-server for device-profile error")
+	}
+
+	spNS, err := GetNetworkServerForServiceProfileID(db, app.ServiceProfileID)
+	if err != nil {
+		return Device{}, errors.Wrap(err, "get network-server for service-profile error")
+	}
+
+	if dpNS.ID != spNS.ID {
+		return Device{}, ErrClaimNetworkServerMismatch
+	}
+
+	var dpOrganizationID int64
+	err = sqlx.Get(db, &dpOrganizationID, "select organization_id from device_profile where device_profile_id = $1", ud.DeviceProfileID)
+	if err != nil {
+		return Device{}, handlePSQLError(Select, err, "select error")
+	}
+
+	if dpOrganizationID != app.OrganizationID {
+		return Device{}, ErrClaimOrganizationMismatch
+	}
+
+	d := Device{
+		DevEUI:          ud.DevEUI,
+		ApplicationID:   app.ID,
+		DeviceProfileID: ud.DeviceProfileID,
+		Name:            ud.DevEUI.String(),
+	}
+	if err := CreateDevice(db, &d); err != nil {
+		return Device{}, errors.Wrap(err, "create device error")
+	}
+
+	if err := CreateDeviceKeys(db, &DeviceKeys{
+		DevEUI:    ud.DevEUI,
+		AppKey:    ud.AppKey,
+		NwkKey:    ud.NwkKey,
+		GenAppKey: ud.GenAppKey,
+	}); err != nil {
+		return Device{}, errors.Wrap(err, "create device-keys error")
+	}
+
+	if err := DeleteUnclaimedDevice(db, ud.DevEUI); err != nil {
+		return Device{}, errors.Wrap(err, "delete unclaimed device error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":        d.DevEUI,
+		"application_id": d.ApplicationID,
+	}).Info("device claimed")
+
+	return d, nil
+}
+
+// checkClaimFailures returns ErrClaimRateLimited when the number of failed
+// claims for the given DevEUI has reached the configured limit.
+func checkClaimFailures(devEUI lorawan.EUI64) error {
+	conf := config.C.ApplicationServer.DeviceClaim
+	if conf.FailureMax <= 0 {
+		return nil
+	}
+
+	c := config.C.Redis.Pool.Get()
+	defer c.Close()
+
+	n, err := redis.Int(c.Do("GET", fmt.Sprintf(claimFailuresKeyTempl, devEUI)))
+	if err != nil && err != redis.ErrNil {
+		return errors.Wrap(err, "get claim failures error")
+	}
+
+	if n >= conf.FailureMax {
+		log.WithField("dev_eui", devEUI).Warning("device claim rate limited")
+		return ErrClaimRateLimited
+	}
+
+	return nil
+}
+
+// claimFailed counts a failed claim for the given DevEUI and returns
+// ErrInvalidClaimCode.
+func claimFailed(devEUI lorawan.EUI64) error {
+	conf := config.C.ApplicationServer.DeviceClaim
+	if conf.FailureMax <= 0 {
+		return ErrInvalidClaimCode
+	}
+
+	if _, err := IncrWindowCounter(config.C.Redis.Pool, fmt.Sprintf(claimFailuresKeyTempl, devEUI), conf.FailureWindow); err != nil {
+		return errors.Wrap(err, "increment claim failures error")
+	}
+
+	return ErrInvalidClaimCode
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestUnclaimedDevice(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = NewRedisPool(conf.RedisURL)
+	nsClient := test.NewNetworkServerClient()
+	config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+	Convey("Given a clean database with an application and device-profile", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		n := NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := ServiceProfile{
+			Name:            "test-service-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+		}
+		So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := DeviceProfile{
+			Name:            "test-device-profile",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			DeviceProfile:   backend.DeviceProfile{},
+		}
+		So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := Application{
+			OrganizationID:   org.ID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			Name:             "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("Then CreateUnclaimedDevice with a too short claim-code returns an error", func() {
+			ud := UnclaimedDevice{
+				DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			}
+			So(CreateUnclaimedDevice(db, &ud, "SHORT"), ShouldEqual, ErrClaimCodeLength)
+		})
+
+		Convey("When creating an unclaimed device", func() {
+			ud := UnclaimedDevice{
+				DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+				AppKey:          lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+				NwkKey:          lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+				GenAppKey:       lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+			}
+			So(CreateUnclaimedDevice(db, &ud, "CLAIM-CODE-1234"), ShouldBeNil)
+
+			Convey("Then GetUnclaimedDeviceCount returns 1", func() {
+				count, err := GetUnclaimedDeviceCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+			})
+
+			Convey("Then GetUnclaimedDevices returns the device", func() {
+				devices, err := GetUnclaimedDevices(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(devices, ShouldHaveLength, 1)
+				So(devices[0].DevEUI, ShouldEqual, ud.DevEUI)
+				So(devices[0].DeviceProfileID, ShouldEqual, ud.DeviceProfileID)
+				So(devices[0].AppKey, ShouldEqual, ud.AppKey)
+				So(devices[0].NwkKey, ShouldEqual, ud.NwkKey)
+				So(devices[0].GenAppKey, ShouldEqual, ud.GenAppKey)
+			})
+
+			Convey("Then ClaimDevice with an invalid claim-code returns an error", func() {
+				_, err := ClaimDevice(db, ud.DevEUI, "INVALID", app.ID)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidClaimCode)
+			})
+
+			Convey("Then ClaimDevice with an unknown DevEUI returns the same error", func() {
+				_, err := ClaimDevice(db, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, "CLAIM-CODE-1234", app.ID)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidClaimCode)
+			})
+
+			Convey("Given a limit of 2 failed claims", func() {
+				config.C.ApplicationServer.DeviceClaim.FailureMax = 2
+				config.C.ApplicationServer.DeviceClaim.FailureWindow = time.Minute
+				defer func() {
+					config.C.ApplicationServer.DeviceClaim.FailureMax = 0
+				}()
+
+				Convey("Then ClaimDevice is rejected after 2 failed claims", func() {
+					for i := 0; i < 2; i++ {
+						_, err := ClaimDevice(db, ud.DevEUI, "INVALID", app.ID)
+						So(errors.Cause(err), ShouldEqual, ErrInvalidClaimCode)
+					}
+
+					_, err := ClaimDevice(db, ud.DevEUI, "CLAIM-CODE-1234", app.ID)
+					So(errors.Cause(err), ShouldEqual, ErrClaimRateLimited)
+				})
+
+				Convey("Then failed claims of an unknown DevEUI are limited too", func() {
+					devEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+					for i := 0; i < 2; i++ {
+						_, err := ClaimDevice(db, devEUI, "CLAIM-CODE-1234", app.ID)
+						So(errors.Cause(err), ShouldEqual, ErrInvalidClaimCode)
+					}
+
+					_, err := ClaimDevice(db, devEUI, "CLAIM-CODE-1234", app.ID)
+					So(errors.Cause(err), ShouldEqual, ErrClaimRateLimited)
+				})
+
+				Convey("Then failed claims of an other DevEUI are not counted", func() {
+					for i := 0; i < 2; i++ {
+						_, err := ClaimDevice(db, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, "CLAIM-CODE-1234", app.ID)
+						So(errors.Cause(err), ShouldEqual, ErrInvalidClaimCode)
+					}
+
+					_, err := ClaimDevice(db, ud.DevEUI, "CLAIM-CODE-1234", app.ID)
+					So(err, ShouldBeNil)
+				})
+			})
+
+			Convey("Given an application of an other organization", func() {
+				org2 := Organization{
+					Name: "test-org-2",
+				}
+				So(CreateOrganization(db, &org2), ShouldBeNil)
+
+				sp2 := ServiceProfile{
+					Name:            "test-service-profile-2",
+					OrganizationID:  org2.ID,
+					NetworkServerID: n.ID,
+				}
+				So(CreateServiceProfile(db, &sp2), ShouldBeNil)
+
+				app2 := Application{
+					OrganizationID:   org2.ID,
+					ServiceProfileID: sp2.ServiceProfile.ServiceProfileID,
+					Name:             "test-app-2",
+				}
+				So(CreateApplication(db, &app2), ShouldBeNil)
+
+				Convey("Then ClaimDevice returns an error", func() {
+					_, err := ClaimDevice(db, ud.DevEUI, "CLAIM-CODE-1234", app2.ID)
+					So(errors.Cause(err), ShouldEqual, ErrClaimOrganizationMismatch)
+				})
+			})
+
+			Convey("Given an application using a different network-server", func() {
+				n2 := NetworkServer{
+					Name:   "test-ns-2",
+					Server: "test-ns-2:1234",
+				}
+				So(CreateNetworkServer(db, &n2), ShouldBeNil)
+
+				sp2 := ServiceProfile{
+					Name:            "test-service-profile-2",
+					OrganizationID:  org.ID,
+					NetworkServerID: n2.ID,
+				}
+				So(CreateServiceProfile(db, &sp2), ShouldBeNil)
+
+				app2 := Application{
+					OrganizationID:   org.ID,
+					ServiceProfileID: sp2.ServiceProfile.ServiceProfileID,
+					Name:             "test-app-2",
+				}
+				So(CreateApplication(db, &app2), ShouldBeNil)
+
+				Convey("Then ClaimDevice returns an error", func() {
+					_, err := ClaimDevice(db, ud.DevEUI, "CLAIM-CODE-1234", app2.ID)
+					So(errors.Cause(err), ShouldEqual, ErrClaimNetworkServerMismatch)
+				})
+			})
+
+			Convey("When claiming the device", func() {
+				d, err := ClaimDevice(db, ud.DevEUI, "CLAIM-CODE-1234", app.ID)
+				So(err, ShouldBeNil)
+				So(d.ApplicationID, ShouldEqual, app.ID)
+
+				Convey("Then the device and device-keys have been created", func() {
+					d, err := GetDevice(db, ud.DevEUI)
+					So(err, ShouldBeNil)
+					So(d.ApplicationID, ShouldEqual, app.ID)
+					So(d.DeviceProfileID, ShouldEqual, dp.DeviceProfile.DeviceProfileID)
+					So(d.Name, ShouldEqual, "0102030405060708")
+
+					dk, err := GetDeviceKeys(db, ud.DevEUI)
+					So(err, ShouldBeNil)
+					So(dk.AppKey, ShouldEqual, ud.AppKey)
+					So(dk.NwkKey, ShouldEqual, ud.NwkKey)
+					So(dk.GenAppKey, ShouldEqual, ud.GenAppKey)
+				})
+
+				Convey("Then the claim-code can not be used again", func() {
+					_, err := ClaimDevice(db, ud.DevEUI, "CLAIM-CODE-1234", app.ID)
+					So(errors.Cause(err), ShouldEqual, ErrInvalidClaimCode)
+
+					count, err := GetUnclaimedDeviceCount(db)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+			})
+
+			Convey("Then DeleteUnclaimedDevice deletes the device", func() {
+				So(DeleteUnclaimedDevice(db, ud.DevEUI), ShouldBeNil)
+				So(DeleteUnclaimedDevice(db, ud.DevEUI), ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/garyburd/redigo/redis"
+	"github.com/golang/protobuf/ptypes/empty"
 	migrate "github.com/rubenv/sql-migrate"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -23,6 +24,10 @@ type Config struct {
 	MQTTServer   string
 	MQTTUsername string
 	MQTTPassword string
+
+	PKCS11Module     string
+	PKCS11TokenLabel string
+	PKCS11PIN        string
 }
 
 func init() {
@@ -60,6 +65,18 @@ func GetConfig() *Config {
 		c.MQTTPassword = v
 	}
 
+	if v := os.Getenv("TEST_PKCS11_MODULE"); v != "" {
+		c.PKCS11Module = v
+	}
+
+	if v := os.Getenv("TEST_PKCS11_TOKEN_LABEL"); v != "" {
+		c.PKCS11TokenLabel = v
+	}
+
+	if v := os.Getenv("TEST_PKCS11_PIN"); v != "" {
+		c.PKCS11PIN = v
+	}
+
 	return c
 }
 
@@ -89,12 +106,12 @@ func MustFlushRedis(p *redis.Pool) {
 
 // NetworkServerPool is a network-server pool for testing.
 type NetworkServerPool struct {
-	Client      ns.NetworkServerClient
+	Client      ns.NetworkServerServiceClient
 	GetHostname string
 }
 
 // Get returns the Client.
-func (p *NetworkServerPool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (ns.NetworkServerClient, error) {
+func (p *NetworkServerPool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (ns.NetworkServerServiceClient, error) {
 	p.GetHostname = hostname
 	return p.Client, nil
 }
@@ -115,11 +132,9 @@ type NetworkServerClient struct {
 	GetServiceProfileChan     chan ns.GetServiceProfileRequest
 	GetServiceProfileResponse ns.GetServiceProfileResponse
 
-	UpdateServiceProfileChan     chan ns.UpdateServiceProfileRequest
-	UpdateServiceProfileResponse ns.UpdateServiceProfileResponse
+	UpdateServiceProfileChan chan ns.UpdateServiceProfileRequest
 
-	DeleteServiceProfileChan     chan ns.DeleteServiceProfileRequest
-	DeleteServiceProfileResponse ns.DeleteServiceProfileResponse
+	DeleteServiceProfileChan chan ns.DeleteServiceProfileRequest
 
 	CreateRoutingProfileChan     chan ns.CreateRoutingProfileRequest
 	CreateRoutingProfileResponse ns.CreateRoutingProfileResponse
@@ -127,11 +142,9 @@ type NetworkServerClient struct {
 	GetRoutingProfileChan     chan ns.GetRoutingProfileRequest
 	GetRoutingProfileResponse ns.GetRoutingProfileResponse
 
-	UpdateRoutingProfileChan     chan ns.UpdateRoutingProfileRequest
-	UpdateRoutingProfileResponse ns.UpdateRoutingProfileResponse
+	UpdateRoutingProfileChan chan ns.UpdateRoutingProfileRequest
 
-	DeleteRoutingProfileChan     chan ns.DeleteRoutingProfileRequest
-	DeleteRoutingProfileResponse ns.DeleteRoutingProfileResponse
+	DeleteRoutingProfileChan chan ns.DeleteRoutingProfileRequest
 
 	CreateDeviceProfileChan     chan ns.CreateDeviceProfileRequest
 	CreateDeviceProfileResponse ns.CreateDeviceProfileResponse
@@ -139,53 +152,40 @@ type NetworkServerClient struct {
 	GetDeviceProfileChan     chan ns.GetDeviceProfileRequest
 	GetDeviceProfileResponse ns.GetDeviceProfileResponse
 
-	UpdateDeviceProfileChan     chan ns.UpdateDeviceProfileRequest
-	UpdateDeviceProfileResponse ns.UpdateDeviceProfileResponse
+	UpdateDeviceProfileChan chan ns.UpdateDeviceProfileRequest
 
-	DeleteDeviceProfileChan     chan ns.DeleteDeviceProfileRequest
-	DeleteDeviceProfileResponse ns.DeleteDeviceProfileResponse
+	DeleteDeviceProfileChan chan ns.DeleteDeviceProfileRequest
 
-	CreateDeviceChan     chan ns.CreateDeviceRequest
-	CreateDeviceResponse ns.CreateDeviceResponse
+	CreateDeviceChan chan ns.CreateDeviceRequest
 
 	GetDeviceChan     chan ns.GetDeviceRequest
 	GetDeviceResponse ns.GetDeviceResponse
 
-	UpdateDeviceChan     chan ns.UpdateDeviceRequest
-	UpdateDeviceResponse ns.UpdateDeviceResponse
+	UpdateDeviceChan chan ns.UpdateDeviceRequest
 
-	DeleteDeviceChan     chan ns.DeleteDeviceRequest
-	DeleteDeviceResponse ns.DeleteDeviceResponse
+	DeleteDeviceChan chan ns.DeleteDeviceRequest
 
-	ActivateDeviceChan     chan ns.ActivateDeviceRequest
-	ActivateDeviceResponse ns.ActivateDeviceResponse
+	ActivateDeviceChan chan ns.ActivateDeviceRequest
 
-	DeactivateDeviceChan     chan ns.DeactivateDeviceRequest
-	DeactivateDeviceResponse ns.DeactivateDeviceResponse
+	DeactivateDeviceChan chan ns.DeactivateDeviceRequest
 
 	GetDeviceActivationChan     chan ns.GetDeviceActivationRequest
 	GetDeviceActivationResponse ns.GetDeviceActivationResponse
 
-	GetRandomDevAddrChan     chan ns.GetRandomDevAddrRequest
 	GetRandomDevAddrResponse ns.GetRandomDevAddrResponse
 
-	CreateMACCommandQueueItemChan     chan ns.CreateMACCommandQueueItemRequest
-	CreateMACCommandQueueItemResponse ns.CreateMACCommandQueueItemResponse
+	CreateMACCommandQueueItemChan chan ns.CreateMACCommandQueueItemRequest
 
-	SendProprietaryPayloadChan     chan ns.SendProprietaryPayloadRequest
-	SendProprietaryPayloadResponse ns.SendProprietaryPayloadResponse
+	SendProprietaryPayloadChan chan ns.SendProprietaryPayloadRequest
 
-	CreateGatewayChan     chan ns.CreateGatewayRequest
-	CreateGatewayResponse ns.CreateGatewayResponse
+	CreateGatewayChan chan ns.CreateGatewayRequest
 
 	GetGatewayChan     chan ns.GetGatewayRequest
 	GetGatewayResponse ns.GetGatewayResponse
 
-	UpdateGatewayChan     chan ns.UpdateGatewayRequest
-	UpdateGatewayResponse ns.UpdateGatewayResponse
+	UpdateGatewayChan chan ns.UpdateGatewayRequest
 
-	DeleteGatewayChan     chan ns.DeleteGatewayRequest
-	DeleteGatewayResponse ns.DeleteGatewayResponse
+	DeleteGatewayChan chan ns.DeleteGatewayRequest
 
 	GetGatewayStatsChan     chan ns.GetGatewayStatsRequest
 	GetGatewayStatsResponse ns.GetGatewayStatsResponse
@@ -196,17 +196,13 @@ type NetworkServerClient struct {
 	GetGatewayProfileChan     chan ns.GetGatewayProfileRequest
 	GetGatewayProfileResponse ns.GetGatewayProfileResponse
 
-	UpdateGatewayProfileChan     chan ns.UpdateGatewayProfileRequest
-	UpdateGatewayProfileResponse ns.UpdateGatewayProfileResponse
+	UpdateGatewayProfileChan chan ns.UpdateGatewayProfileRequest
 
-	DeleteGatewayProfileChan     chan ns.DeleteGatewayProfileRequest
-	DeleteGatewayProfileResponse ns.DeleteGatewayProfileResponse
+	DeleteGatewayProfileChan chan ns.DeleteGatewayProfileRequest
 
-	CreateDeviceQueueItemChan     chan ns.CreateDeviceQueueItemRequest
-	CreateDeviceQueueItemResponse ns.CreateDeviceQueueItemResponse
+	CreateDeviceQueueItemChan chan ns.CreateDeviceQueueItemRequest
 
-	FlushDeviceQueueForDevEUIChan     chan ns.FlushDeviceQueueForDevEUIRequest
-	FlushDeviceQueueForDevEUIResponse ns.FlushDeviceQueueForDevEUIResponse
+	FlushDeviceQueueForDevEUIChan chan ns.FlushDeviceQueueForDevEUIRequest
 
 	GetDeviceQueueItemsForDevEUIChan     chan ns.GetDeviceQueueItemsForDevEUIRequest
 	GetDeviceQueueItemsForDevEUIResponse ns.GetDeviceQueueItemsForDevEUIResponse
@@ -214,47 +210,76 @@ type NetworkServerClient struct {
 	GetNextDownlinkFCntForDevEUIChan     chan ns.GetNextDownlinkFCntForDevEUIRequest
 	GetNextDownlinkFCntForDevEUIResponse ns.GetNextDownlinkFCntForDevEUIResponse
 
+	CreateMulticastGroupChan     chan ns.CreateMulticastGroupRequest
+	CreateMulticastGroupResponse ns.CreateMulticastGroupResponse
+
+	GetMulticastGroupChan     chan ns.GetMulticastGroupRequest
+	GetMulticastGroupResponse ns.GetMulticastGroupResponse
+
+	UpdateMulticastGroupChan chan ns.UpdateMulticastGroupRequest
+
+	DeleteMulticastGroupChan chan ns.DeleteMulticastGroupRequest
+
+	AddDeviceToMulticastGroupChan chan ns.AddDeviceToMulticastGroupRequest
+
+	RemoveDeviceFromMulticastGroupChan chan ns.RemoveDeviceFromMulticastGroupRequest
+
+	EnqueueMulticastQueueItemChan chan ns.EnqueueMulticastQueueItemRequest
+
+	FlushMulticastQueueForMulticastGroupChan chan ns.FlushMulticastQueueForMulticastGroupRequest
+
+	GetMulticastQueueItemsForMulticastGroupChan     chan ns.GetMulticastQueueItemsForMulticastGroupRequest
+	GetMulticastQueueItemsForMulticastGroupResponse ns.GetMulticastQueueItemsForMulticastGroupResponse
+
 	GetVersionResponse ns.GetVersionResponse
 }
 
 // NewNetworkServerClient creates a new NetworkServerClient.
 func NewNetworkServerClient() *NetworkServerClient {
 	return &NetworkServerClient{
-		CreateServiceProfileChan:         make(chan ns.CreateServiceProfileRequest, 100),
-		GetServiceProfileChan:            make(chan ns.GetServiceProfileRequest, 100),
-		UpdateServiceProfileChan:         make(chan ns.UpdateServiceProfileRequest, 100),
-		DeleteServiceProfileChan:         make(chan ns.DeleteServiceProfileRequest, 100),
-		CreateRoutingProfileChan:         make(chan ns.CreateRoutingProfileRequest, 100),
-		GetRoutingProfileChan:            make(chan ns.GetRoutingProfileRequest, 100),
-		UpdateRoutingProfileChan:         make(chan ns.UpdateRoutingProfileRequest, 100),
-		DeleteRoutingProfileChan:         make(chan ns.DeleteRoutingProfileRequest, 100),
-		CreateDeviceProfileChan:          make(chan ns.CreateDeviceProfileRequest, 100),
-		GetDeviceProfileChan:             make(chan ns.GetDeviceProfileRequest, 100),
-		UpdateDeviceProfileChan:          make(chan ns.UpdateDeviceProfileRequest, 100),
-		DeleteDeviceProfileChan:          make(chan ns.DeleteDeviceProfileRequest, 100),
-		CreateDeviceChan:                 make(chan ns.CreateDeviceRequest, 100),
-		GetDeviceChan:                    make(chan ns.GetDeviceRequest, 100),
-		UpdateDeviceChan:                 make(chan ns.UpdateDeviceRequest, 100),
-		DeleteDeviceChan:                 make(chan ns.DeleteDeviceRequest, 100),
-		ActivateDeviceChan:               make(chan ns.ActivateDeviceRequest, 100),
-		DeactivateDeviceChan:             make(chan ns.DeactivateDeviceRequest, 100),
-		GetDeviceActivationChan:          make(chan ns.GetDeviceActivationRequest, 100),
-		GetRandomDevAddrChan:             make(chan ns.GetRandomDevAddrRequest, 100),
-		CreateMACCommandQueueItemChan:    make(chan ns.CreateMACCommandQueueItemRequest, 100),
-		SendProprietaryPayloadChan:       make(chan ns.SendProprietaryPayloadRequest, 100),
-		CreateGatewayChan:                make(chan ns.CreateGatewayRequest, 100),
-		GetGatewayChan:                   make(chan ns.GetGatewayRequest, 100),
-		UpdateGatewayChan:                make(chan ns.UpdateGatewayRequest, 100),
-		DeleteGatewayChan:                make(chan ns.DeleteGatewayRequest, 100),
-		GetGatewayStatsChan:              make(chan ns.GetGatewayStatsRequest, 100),
-		CreateGatewayProfileChan:         make(chan ns.CreateGatewayProfileRequest, 100),
-		GetGatewayProfileChan:            make(chan ns.GetGatewayProfileRequest, 100),
-		UpdateGatewayProfileChan:         make(chan ns.UpdateGatewayProfileRequest, 100),
-		DeleteGatewayProfileChan:         make(chan ns.DeleteGatewayProfileRequest, 100),
-		GetNextDownlinkFCntForDevEUIChan: make(chan ns.GetNextDownlinkFCntForDevEUIRequest, 100),
-		CreateDeviceQueueItemChan:        make(chan ns.CreateDeviceQueueItemRequest, 100),
-		FlushDeviceQueueForDevEUIChan:    make(chan ns.FlushDeviceQueueForDevEUIRequest, 100),
-		GetDeviceQueueItemsForDevEUIChan: make(chan ns.GetDeviceQueueItemsForDevEUIRequest, 100),
+		CreateServiceProfileChan:                    make(chan ns.CreateServiceProfileRequest, 100),
+		GetServiceProfileChan:                       make(chan ns.GetServiceProfileRequest, 100),
+		UpdateServiceProfileChan:                    make(chan ns.UpdateServiceProfileRequest, 100),
+		DeleteServiceProfileChan:                    make(chan ns.DeleteServiceProfileRequest, 100),
+		CreateRoutingProfileChan:                    make(chan ns.CreateRoutingProfileRequest, 100),
+		GetRoutingProfileChan:                       make(chan ns.GetRoutingProfileRequest, 100),
+		UpdateRoutingProfileChan:                    make(chan ns.UpdateRoutingProfileRequest, 100),
+		DeleteRoutingProfileChan:                    make(chan ns.DeleteRoutingProfileRequest, 100),
+		CreateDeviceProfileChan:                     make(chan ns.CreateDeviceProfileRequest, 100),
+		GetDeviceProfileChan:                        make(chan ns.GetDeviceProfileRequest, 100),
+		UpdateDeviceProfileChan:                     make(chan ns.UpdateDeviceProfileRequest, 100),
+		DeleteDeviceProfileChan:                     make(chan ns.DeleteDeviceProfileRequest, 100),
+		CreateDeviceChan:                            make(chan ns.CreateDeviceRequest, 100),
+		GetDeviceChan:                               make(chan ns.GetDeviceRequest, 100),
+		UpdateDeviceChan:                            make(chan ns.UpdateDeviceRequest, 100),
+		DeleteDeviceChan:                            make(chan ns.DeleteDeviceRequest, 100),
+		ActivateDeviceChan:                          make(chan ns.ActivateDeviceRequest, 100),
+		DeactivateDeviceChan:                        make(chan ns.DeactivateDeviceRequest, 100),
+		GetDeviceActivationChan:                     make(chan ns.GetDeviceActivationRequest, 100),
+		CreateMACCommandQueueItemChan:               make(chan ns.CreateMACCommandQueueItemRequest, 100),
+		SendProprietaryPayloadChan:                  make(chan ns.SendProprietaryPayloadRequest, 100),
+		CreateGatewayChan:                           make(chan ns.CreateGatewayRequest, 100),
+		GetGatewayChan:                              make(chan ns.GetGatewayRequest, 100),
+		UpdateGatewayChan:                           make(chan ns.UpdateGatewayRequest, 100),
+		DeleteGatewayChan:                           make(chan ns.DeleteGatewayRequest, 100),
+		GetGatewayStatsChan:                         make(chan ns.GetGatewayStatsRequest, 100),
+		CreateGatewayProfileChan:                    make(chan ns.CreateGatewayProfileRequest, 100),
+		GetGatewayProfileChan:                       make(chan ns.GetGatewayProfileRequest, 100),
+		UpdateGatewayProfileChan:                    make(chan ns.UpdateGatewayProfileRequest, 100),
+		DeleteGatewayProfileChan:                    make(chan ns.DeleteGatewayProfileRequest, 100),
+		CreateDeviceQueueItemChan:                   make(chan ns.CreateDeviceQueueItemRequest, 100),
+		FlushDeviceQueueForDevEUIChan:               make(chan ns.FlushDeviceQueueForDevEUIRequest, 100),
+		GetDeviceQueueItemsForDevEUIChan:            make(chan ns.GetDeviceQueueItemsForDevEUIRequest, 100),
+		GetNextDownlinkFCntForDevEUIChan:            make(chan ns.GetNextDownlinkFCntForDevEUIRequest, 100),
+		CreateMulticastGroupChan:                    make(chan ns.CreateMulticastGroupRequest, 100),
+		GetMulticastGroupChan:                       make(chan ns.GetMulticastGroupRequest, 100),
+		UpdateMulticastGroupChan:                    make(chan ns.UpdateMulticastGroupRequest, 100),
+		DeleteMulticastGroupChan:                    make(chan ns.DeleteMulticastGroupRequest, 100),
+		AddDeviceToMulticastGroupChan:               make(chan ns.AddDeviceToMulticastGroupRequest, 100),
+		RemoveDeviceFromMulticastGroupChan:          make(chan ns.RemoveDeviceFromMulticastGroupRequest, 100),
+		EnqueueMulticastQueueItemChan:               make(chan ns.EnqueueMulticastQueueItemRequest, 100),
+		FlushMulticastQueueForMulticastGroupChan:    make(chan ns.FlushMulticastQueueForMulticastGroupRequest, 100),
+		GetMulticastQueueItemsForMulticastGroupChan: make(chan ns.GetMulticastQueueItemsForMulticastGroupRequest, 100),
 	}
 }
 
@@ -271,15 +296,15 @@ func (n *NetworkServerClient) GetServiceProfile(ctx context.Context, in *ns.GetS
 }
 
 // UpdateServiceProfile method.
-func (n *NetworkServerClient) UpdateServiceProfile(ctx context.Context, in *ns.UpdateServiceProfileRequest, opts ...grpc.CallOption) (*ns.UpdateServiceProfileResponse, error) {
+func (n *NetworkServerClient) UpdateServiceProfile(ctx context.Context, in *ns.UpdateServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.UpdateServiceProfileChan <- *in
-	return &n.UpdateServiceProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // DeleteServiceProfile method.
-func (n *NetworkServerClient) DeleteServiceProfile(ctx context.Context, in *ns.DeleteServiceProfileRequest, opts ...grpc.CallOption) (*ns.DeleteServiceProfileResponse, error) {
+func (n *NetworkServerClient) DeleteServiceProfile(ctx context.Context, in *ns.DeleteServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.DeleteServiceProfileChan <- *in
-	return &n.DeleteServiceProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // CreateRoutingProfile method.
@@ -295,15 +320,15 @@ func (n *NetworkServerClient) GetRoutingProfile(ctx context.Context, in *ns.GetR
 }
 
 // UpdateRoutingProfile method.
-func (n *NetworkServerClient) UpdateRoutingProfile(ctx context.Context, in *ns.UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*ns.UpdateRoutingProfileResponse, error) {
+func (n *NetworkServerClient) UpdateRoutingProfile(ctx context.Context, in *ns.UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.UpdateRoutingProfileChan <- *in
-	return &n.UpdateRoutingProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // DeleteRoutingProfile method.
-func (n *NetworkServerClient) DeleteRoutingProfile(ctx context.Context, in *ns.DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*ns.DeleteRoutingProfileResponse, error) {
+func (n *NetworkServerClient) DeleteRoutingProfile(ctx context.Context, in *ns.DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.DeleteRoutingProfileChan <- *in
-	return &n.DeleteRoutingProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // CreateDeviceProfile method.
@@ -319,21 +344,21 @@ func (n *NetworkServerClient) GetDeviceProfile(ctx context.Context, in *ns.GetDe
 }
 
 // UpdateDeviceProfile method.
-func (n *NetworkServerClient) UpdateDeviceProfile(ctx context.Context, in *ns.UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*ns.UpdateDeviceProfileResponse, error) {
+func (n *NetworkServerClient) UpdateDeviceProfile(ctx context.Context, in *ns.UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.UpdateDeviceProfileChan <- *in
-	return &n.UpdateDeviceProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // DeleteDeviceProfile method.
-func (n *NetworkServerClient) DeleteDeviceProfile(ctx context.Context, in *ns.DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*ns.DeleteDeviceProfileResponse, error) {
+func (n *NetworkServerClient) DeleteDeviceProfile(ctx context.Context, in *ns.DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.DeleteDeviceProfileChan <- *in
-	return &n.DeleteDeviceProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // CreateDevice method.
-func (n *NetworkServerClient) CreateDevice(ctx context.Context, in *ns.CreateDeviceRequest, opts ...grpc.CallOption) (*ns.CreateDeviceResponse, error) {
+func (n *NetworkServerClient) CreateDevice(ctx context.Context, in *ns.CreateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.CreateDeviceChan <- *in
-	return &n.CreateDeviceResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // GetDevice method.
@@ -343,21 +368,27 @@ func (n *NetworkServerClient) GetDevice(ctx context.Context, in *ns.GetDeviceReq
 }
 
 // UpdateDevice method.
-func (n *NetworkServerClient) UpdateDevice(ctx context.Context, in *ns.UpdateDeviceRequest, opts ...grpc.CallOption) (*ns.UpdateDeviceResponse, error) {
+func (n *NetworkServerClient) UpdateDevice(ctx context.Context, in *ns.UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.UpdateDeviceChan <- *in
-	return &n.UpdateDeviceResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // DeleteDevice method.
-func (n *NetworkServerClient) DeleteDevice(ctx context.Context, in *ns.DeleteDeviceRequest, opts ...grpc.CallOption) (*ns.DeleteDeviceResponse, error) {
+func (n *NetworkServerClient) DeleteDevice(ctx context.Context, in *ns.DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.DeleteDeviceChan <- *in
-	return &n.DeleteDeviceResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // ActivateDevice method.
-func (n *NetworkServerClient) ActivateDevice(ctx context.Context, in *ns.ActivateDeviceRequest, opts ...grpc.CallOption) (*ns.ActivateDeviceResponse, error) {
+func (n *NetworkServerClient) ActivateDevice(ctx context.Context, in *ns.ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.ActivateDeviceChan <- *in
-	return &n.ActivateDeviceResponse, nil
+	return &empty.Empty{}, nil
+}
+
+// DeactivateDevice method.
+func (n *NetworkServerClient) DeactivateDevice(ctx context.Context, in *ns.DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.DeactivateDeviceChan <- *in
+	return &empty.Empty{}, nil
 }
 
 // GetDeviceActivation method.
@@ -366,16 +397,27 @@ func (n *NetworkServerClient) GetDeviceActivation(ctx context.Context, in *ns.Ge
 	return &n.GetDeviceActivationResponse, nil
 }
 
-// DeactivateDevice method.
-func (n *NetworkServerClient) DeactivateDevice(ctx context.Context, in *ns.DeactivateDeviceRequest, opts ...grpc.CallOption) (*ns.DeactivateDeviceResponse, error) {
-	n.DeactivateDeviceChan <- *in
-	return &n.DeactivateDeviceResponse, nil
+// GetRandomDevAddr method.
+func (n *NetworkServerClient) GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ns.GetRandomDevAddrResponse, error) {
+	return &n.GetRandomDevAddrResponse, nil
+}
+
+// CreateMACCommandQueueItem method.
+func (n *NetworkServerClient) CreateMACCommandQueueItem(ctx context.Context, in *ns.CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.CreateMACCommandQueueItemChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// SendProprietaryPayload method.
+func (n *NetworkServerClient) SendProprietaryPayload(ctx context.Context, in *ns.SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.SendProprietaryPayloadChan <- *in
+	return &empty.Empty{}, nil
 }
 
 // CreateGateway method.
-func (n *NetworkServerClient) CreateGateway(ctx context.Context, in *ns.CreateGatewayRequest, opts ...grpc.CallOption) (*ns.CreateGatewayResponse, error) {
+func (n *NetworkServerClient) CreateGateway(ctx context.Context, in *ns.CreateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.CreateGatewayChan <- *in
-	return &n.CreateGatewayResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // GetGateway method.
@@ -385,15 +427,15 @@ func (n *NetworkServerClient) GetGateway(ctx context.Context, in *ns.GetGatewayR
 }
 
 // UpdateGateway method.
-func (n *NetworkServerClient) UpdateGateway(ctx context.Context, in *ns.UpdateGatewayRequest, opts ...grpc.CallOption) (*ns.UpdateGatewayResponse, error) {
+func (n *NetworkServerClient) UpdateGateway(ctx context.Context, in *ns.UpdateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.UpdateGatewayChan <- *in
-	return &n.UpdateGatewayResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // DeleteGateway method.
-func (n *NetworkServerClient) DeleteGateway(ctx context.Context, in *ns.DeleteGatewayRequest, opts ...grpc.CallOption) (*ns.DeleteGatewayResponse, error) {
+func (n *NetworkServerClient) DeleteGateway(ctx context.Context, in *ns.DeleteGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.DeleteGatewayChan <- *in
-	return &n.DeleteGatewayResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // GetGatewayStats method.
@@ -402,24 +444,6 @@ func (n *NetworkServerClient) GetGatewayStats(ctx context.Context, in *ns.GetGat
 	return &n.GetGatewayStatsResponse, nil
 }
 
-// GetRandomDevAddr method.
-func (n *NetworkServerClient) GetRandomDevAddr(ctx context.Context, in *ns.GetRandomDevAddrRequest, opts ...grpc.CallOption) (*ns.GetRandomDevAddrResponse, error) {
-	n.GetRandomDevAddrChan <- *in
-	return &n.GetRandomDevAddrResponse, nil
-}
-
-// CreateMACCommandQueueItem method.
-func (n *NetworkServerClient) CreateMACCommandQueueItem(ctx context.Context, in *ns.CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*ns.CreateMACCommandQueueItemResponse, error) {
-	n.CreateMACCommandQueueItemChan <- *in
-	return &n.CreateMACCommandQueueItemResponse, nil
-}
-
-// SendProprietaryPayload method.
-func (n *NetworkServerClient) SendProprietaryPayload(ctx context.Context, in *ns.SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*ns.SendProprietaryPayloadResponse, error) {
-	n.SendProprietaryPayloadChan <- *in
-	return &n.SendProprietaryPayloadResponse, nil
-}
-
 // CreateGatewayProfile method.
 func (n *NetworkServerClient) CreateGatewayProfile(ctx context.Context, in *ns.CreateGatewayProfileRequest, opts ...grpc.CallOption) (*ns.CreateGatewayProfileResponse, error) {
 	n.CreateGatewayProfileChan <- *in
@@ -433,62 +457,106 @@ func (n *NetworkServerClient) GetGatewayProfile(ctx context.Context, in *ns.GetG
 }
 
 // UpdateGatewayProfile method.
-func (n *NetworkServerClient) UpdateGatewayProfile(ctx context.Context, in *ns.UpdateGatewayProfileRequest, opts ...grpc.CallOption) (*ns.UpdateGatewayProfileResponse, error) {
+func (n *NetworkServerClient) UpdateGatewayProfile(ctx context.Context, in *ns.UpdateGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.UpdateGatewayProfileChan <- *in
-	return &n.UpdateGatewayProfileResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // DeleteGatewayProfile method.
-func (n *NetworkServerClient) DeleteGatewayProfile(ctx context.Context, in *ns.DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*ns.DeleteGatewayProfileResponse, error) {
+func (n *NetworkServerClient) DeleteGatewayProfile(ctx context.Context, in *ns.DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.DeleteGatewayProfileChan <- *in
-	return &n.DeleteGatewayProfileResponse, nil
-}
-
-// MigrateNodeToDeviceSession is not implemented.
-func (n *NetworkServerClient) MigrateNodeToDeviceSession(ctx context.Context, in *ns.MigrateNodeToDeviceSessionRequest, opts ...grpc.CallOption) (*ns.MigrateNodeToDeviceSessionResponse, error) {
-	panic("not implemented")
+	return &empty.Empty{}, nil
 }
 
 // CreateDeviceQueueItem method.
-func (n NetworkServerClient) CreateDeviceQueueItem(ctx context.Context, in *ns.CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*ns.CreateDeviceQueueItemResponse, error) {
+func (n *NetworkServerClient) CreateDeviceQueueItem(ctx context.Context, in *ns.CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.CreateDeviceQueueItemChan <- *in
-	return &n.CreateDeviceQueueItemResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // FlushDeviceQueueForDevEUI method.
-func (n NetworkServerClient) FlushDeviceQueueForDevEUI(ctx context.Context, in *ns.FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*ns.FlushDeviceQueueForDevEUIResponse, error) {
+func (n *NetworkServerClient) FlushDeviceQueueForDevEUI(ctx context.Context, in *ns.FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	n.FlushDeviceQueueForDevEUIChan <- *in
-	return &n.FlushDeviceQueueForDevEUIResponse, nil
+	return &empty.Empty{}, nil
 }
 
 // GetDeviceQueueItemsForDevEUI method.
-func (n NetworkServerClient) GetDeviceQueueItemsForDevEUI(ctx context.Context, in *ns.GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*ns.GetDeviceQueueItemsForDevEUIResponse, error) {
+func (n *NetworkServerClient) GetDeviceQueueItemsForDevEUI(ctx context.Context, in *ns.GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*ns.GetDeviceQueueItemsForDevEUIResponse, error) {
 	n.GetDeviceQueueItemsForDevEUIChan <- *in
 	return &n.GetDeviceQueueItemsForDevEUIResponse, nil
 }
 
 // GetNextDownlinkFCntForDevEUI method.
-func (n NetworkServerClient) GetNextDownlinkFCntForDevEUI(ctx context.Context, in *ns.GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*ns.GetNextDownlinkFCntForDevEUIResponse, error) {
+func (n *NetworkServerClient) GetNextDownlinkFCntForDevEUI(ctx context.Context, in *ns.GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*ns.GetNextDownlinkFCntForDevEUIResponse, error) {
 	n.GetNextDownlinkFCntForDevEUIChan <- *in
 	return &n.GetNextDownlinkFCntForDevEUIResponse, nil
 }
 
+// CreateMulticastGroup method.
+func (n *NetworkServerClient) CreateMulticastGroup(ctx context.Context, in *ns.CreateMulticastGroupRequest, opts ...grpc.CallOption) (*ns.CreateMulticastGroupResponse, error) {
+	n.CreateMulticastGroupChan <- *in
+	return &n.CreateMulticastGroupResponse, nil
+}
+
+// GetMulticastGroup method.
+func (n *NetworkServerClient) GetMulticastGroup(ctx context.Context, in *ns.GetMulticastGroupRequest, opts ...grpc.CallOption) (*ns.GetMulticastGroupResponse, error) {
+	n.GetMulticastGroupChan <- *in
+	return &n.GetMulticastGroupResponse, nil
+}
+
+// UpdateMulticastGroup method.
+func (n *NetworkServerClient) UpdateMulticastGroup(ctx context.Context, in *ns.UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.UpdateMulticastGroupChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// DeleteMulticastGroup method.
+func (n *NetworkServerClient) DeleteMulticastGroup(ctx context.Context, in *ns.DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.DeleteMulticastGroupChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// AddDeviceToMulticastGroup method.
+func (n *NetworkServerClient) AddDeviceToMulticastGroup(ctx context.Context, in *ns.AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.AddDeviceToMulticastGroupChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// RemoveDeviceFromMulticastGroup method.
+func (n *NetworkServerClient) RemoveDeviceFromMulticastGroup(ctx context.Context, in *ns.RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.RemoveDeviceFromMulticastGroupChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// EnqueueMulticastQueueItem method.
+func (n *NetworkServerClient) EnqueueMulticastQueueItem(ctx context.Context, in *ns.EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.EnqueueMulticastQueueItemChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// FlushMulticastQueueForMulticastGroup method.
+func (n *NetworkServerClient) FlushMulticastQueueForMulticastGroup(ctx context.Context, in *ns.FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	n.FlushMulticastQueueForMulticastGroupChan <- *in
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastQueueItemsForMulticastGroup method.
+func (n *NetworkServerClient) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *ns.GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*ns.GetMulticastQueueItemsForMulticastGroupResponse, error) {
+	n.GetMulticastQueueItemsForMulticastGroupChan <- *in
+	return &n.GetMulticastQueueItemsForMulticastGroupResponse, nil
+}
+
 // GetVersion method.
-func (n NetworkServerClient) GetVersion(ctx context.Context, in *ns.GetVersionRequest, opts ...grpc.CallOption) (*ns.GetVersionResponse, error) {
+func (n *NetworkServerClient) GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ns.GetVersionResponse, error) {
 	return &n.GetVersionResponse, nil
 }
 
 // StreamFrameLogsForGateway method.
-func (n NetworkServerClient) StreamFrameLogsForGateway(ctx context.Context, in *ns.StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (ns.NetworkServer_StreamFrameLogsForGatewayClient, error) {
+func (n *NetworkServerClient) StreamFrameLogsForGateway(ctx context.Context, in *ns.StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (ns.NetworkServerService_StreamFrameLogsForGatewayClient, error) {
 	panic("not implemented")
 }
 
 // StreamFrameLogsForDevice method.
-func (n NetworkServerClient) StreamFrameLogsForDevice(ctx context.Context, in *ns.StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (ns.NetworkServer_StreamFrameLogsForDeviceClient, error) {
+func (n *NetworkServerClient) StreamFrameLogsForDevice(ctx context.Context, in *ns.StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (ns.NetworkServerService_StreamFrameLogsForDeviceClient, error) {
 	panic("not implemented")
 }
-
-// MigrateChannelConfigurationToGatewayProfile method.
-func (n NetworkServerClient) MigrateChannelConfigurationToGatewayProfile(ctx context.Context, in *ns.MigrateChannelConfigurationToGatewayProfileRequest, opts ...grpc.CallOption) (*ns.MigrateChannelConfigurationToGatewayProfileResponse, error) {
-	panic("not implemeted")
-}
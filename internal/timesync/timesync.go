@@ -0,0 +1,103 @@
+// Package timesync answers the clock synchronization requests of devices,
+// using the LoRaWAN Application Layer Clock Synchronization package.
+package timesync
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/clocksync"
+	"github.com/brocaar/lora-app-server/internal/downlink"
+	"github.com/brocaar/lora-app-server/internal/gps"
+	"github.com/brocaar/lorawan"
+)
+
+const reference = "clock-sync"
+
+// HandleClockSyncCommand handles the Clock Synchronization commands, sent by
+// the given device on the clock synchronization fPort. The rxTime is the
+// time at which the uplink was received by the gateway(s).
+// When periodicity is set, the device is requested to send its AppTimeReq
+// every 128 * 2^periodicity seconds. This request is sent together with the
+// answer on an AppTimeReq with AnsRequired set, which is sent by the device
+// on its first synchronization (e.g. after a reboot).
+func HandleClockSyncCommand(db sqlx.Ext, devEUI lorawan.EUI64, periodicity *uint8, rxTime time.Time, b []byte) error {
+	cmds, err := clocksync.UnmarshalUplinkCommands(b)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal commands error")
+	}
+
+	var out clocksync.Commands
+
+	for _, cmd := range cmds {
+		switch pl := cmd.Payload.(type) {
+		case *clocksync.AppTimeReqPayload:
+			out = append(out, handleAppTimeReq(devEUI, periodicity, rxTime, pl)...)
+		case *clocksync.DeviceAppTimePeriodicityAnsPayload:
+			log.WithFields(log.Fields{
+				"dev_eui":       devEUI,
+				"not_supported": pl.NotSupported,
+			}).Info("DeviceAppTimePeriodicityAns received")
+		default:
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"cid":     cmd.CID,
+			}).Info("clock sync command ignored")
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	ansB, err := out.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	if err := downlink.EnqueueDownlinkPayload(db, devEUI, reference, false, clocksync.DefaultFPort, ansB); err != nil {
+		return errors.Wrap(err, "enqueue downlink payload error")
+	}
+
+	return nil
+}
+
+func handleAppTimeReq(devEUI lorawan.EUI64, periodicity *uint8, rxTime time.Time, pl *clocksync.AppTimeReqPayload) clocksync.Commands {
+	// the DeviceTime is the number of seconds since the GPS epoch modulo
+	// 2^32, the uint32 subtraction takes care of the roll-over
+	gpsTime := uint32(gps.TimeSinceGPSEpoch(rxTime) / time.Second)
+	correction := int32(gpsTime - pl.DeviceTime)
+
+	log.WithFields(log.Fields{
+		"dev_eui":         devEUI,
+		"device_time":     pl.DeviceTime,
+		"time_correction": correction,
+		"ans_required":    pl.AnsRequired,
+	}).Info("AppTimeReq received")
+
+	var out clocksync.Commands
+
+	if correction != 0 || pl.AnsRequired {
+		out = append(out, clocksync.Command{
+			CID: clocksync.AppTimeAns,
+			Payload: &clocksync.AppTimeAnsPayload{
+				TimeCorrection: correction,
+				TokenAns:       pl.TokenReq,
+			},
+		})
+	}
+
+	if periodicity != nil && pl.AnsRequired {
+		out = append(out, clocksync.Command{
+			CID: clocksync.DeviceAppTimePeriodicityReq,
+			Payload: &clocksync.DeviceAppTimePeriodicityReqPayload{
+				Periodicity: *periodicity,
+			},
+		})
+	}
+
+	return out
+}
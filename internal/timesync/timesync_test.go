@@ -0,0 +1,181 @@
+package timesync
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/applayer/clocksync"
+	"github.com/brocaar/lora-app-server/internal/config"
+	"github.com/brocaar/lora-app-server/internal/gps"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestHandleClockSyncCommand(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+
+	Convey("Given a clean database with an activated device", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		nsClient := test.NewNetworkServerClient()
+		nsClient.GetNextDownlinkFCntForDevEUIResponse = ns.GetNextDownlinkFCntForDevEUIResponse{
+			FCnt: 12,
+		}
+		config.C.NetworkServer.Pool = test.NewNetworkServerPool(nsClient)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		n := storage.NetworkServer{
+			Name:   "test-ns",
+			Server: "test-ns:1234",
+		}
+		So(storage.CreateNetworkServer(db, &n), ShouldBeNil)
+
+		sp := storage.ServiceProfile{
+			Name:            "test-sp",
+			OrganizationID:  org.ID,
+			NetworkServerID: n.ID,
+			ServiceProfile:  backend.ServiceProfile{},
+		}
+		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
+
+		dp := storage.DeviceProfile{
+			Name:             "test-dp",
+			OrganizationID:   org.ID,
+			NetworkServerID:  n.ID,
+			ClockSyncEnabled: true,
+			DeviceProfile:    backend.DeviceProfile{},
+		}
+		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID:   org.ID,
+			Name:             "test-app",
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		device := storage.Device{
+			ApplicationID:   app.ID,
+			DeviceProfileID: dp.DeviceProfile.DeviceProfileID,
+			Name:            "test-node",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateDevice(db, &device), ShouldBeNil)
+
+		da := storage.DeviceActivation{
+			DevEUI:  device.DevEUI,
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			AppSKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		So(storage.CreateDeviceActivation(db, &da), ShouldBeNil)
+
+		rxTime := time.Now()
+		gpsTime := uint32(gps.TimeSinceGPSEpoch(rxTime) / time.Second)
+		periodicity := uint8(10)
+
+		tests := []struct {
+			Name        string
+			Periodicity *uint8
+			Request     clocksync.AppTimeReqPayload
+			Expected    clocksync.Commands
+		}{
+			{
+				Name: "the device clock is behind",
+				Request: clocksync.AppTimeReqPayload{
+					DeviceTime: gpsTime - 10,
+					TokenReq:   3,
+				},
+				Expected: clocksync.Commands{
+					{CID: clocksync.AppTimeAns, Payload: &clocksync.AppTimeAnsPayload{TimeCorrection: 10, TokenAns: 3}},
+				},
+			},
+			{
+				Name: "the device clock is ahead",
+				Request: clocksync.AppTimeReqPayload{
+					DeviceTime: gpsTime + 5,
+					TokenReq:   4,
+				},
+				Expected: clocksync.Commands{
+					{CID: clocksync.AppTimeAns, Payload: &clocksync.AppTimeAnsPayload{TimeCorrection: -5, TokenAns: 4}},
+				},
+			},
+			{
+				Name: "the device clock is in sync",
+				Request: clocksync.AppTimeReqPayload{
+					DeviceTime: gpsTime,
+				},
+			},
+			{
+				Name: "the device clock is in sync and an answer is required",
+				Request: clocksync.AppTimeReqPayload{
+					DeviceTime:  gpsTime,
+					AnsRequired: true,
+					TokenReq:    1,
+				},
+				Expected: clocksync.Commands{
+					{CID: clocksync.AppTimeAns, Payload: &clocksync.AppTimeAnsPayload{TimeCorrection: 0, TokenAns: 1}},
+				},
+			},
+			{
+				Name:        "an answer is required and the periodicity is set",
+				Periodicity: &periodicity,
+				Request: clocksync.AppTimeReqPayload{
+					DeviceTime:  gpsTime - 1,
+					AnsRequired: true,
+					TokenReq:    2,
+				},
+				Expected: clocksync.Commands{
+					{CID: clocksync.AppTimeAns, Payload: &clocksync.AppTimeAnsPayload{TimeCorrection: 1, TokenAns: 2}},
+					{CID: clocksync.DeviceAppTimePeriodicityReq, Payload: &clocksync.DeviceAppTimePeriodicityReqPayload{Periodicity: 10}},
+				},
+			},
+		}
+
+		for i, tst := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", tst.Name, i), func() {
+				b, err := clocksync.Command{
+					CID:     clocksync.AppTimeReq,
+					Payload: &tst.Request,
+				}.MarshalBinary()
+				So(err, ShouldBeNil)
+
+				So(HandleClockSyncCommand(db, device.DevEUI, tst.Periodicity, rxTime, b), ShouldBeNil)
+
+				if len(tst.Expected) == 0 {
+					So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 0)
+					return
+				}
+
+				expB, err := tst.Expected.MarshalBinary()
+				So(err, ShouldBeNil)
+				expB, err = lorawan.EncryptFRMPayload(da.AppSKey, false, da.DevAddr, 12, expB)
+				So(err, ShouldBeNil)
+
+				So(nsClient.CreateDeviceQueueItemChan, ShouldHaveLength, 1)
+				So(<-nsClient.CreateDeviceQueueItemChan, ShouldResemble, ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     device.DevEUI[:],
+						FrmPayload: expB,
+						FCnt:       12,
+						FPort:      uint32(clocksync.DefaultFPort),
+					},
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,58 @@
+-- +migrate Up
+create table codec (
+    id bigserial primary key,
+    organization_id bigint not null references organization on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    name varchar(100) not null,
+    type text not null,
+    encoder_script text not null default '',
+    decoder_script text not null default '',
+    version integer not null
+);
+
+create index idx_codec_organization_id on codec(organization_id);
+create index idx_codec_created_at on codec(created_at);
+create index idx_codec_updated_at on codec(updated_at);
+
+create table codec_version (
+    codec_id bigint not null references codec on delete cascade,
+    version integer not null,
+    created_at timestamp with time zone not null,
+    type text not null,
+    encoder_script text not null default '',
+    decoder_script text not null default '',
+
+    primary key(codec_id, version)
+);
+
+alter table application
+    add column codec_id bigint references codec on delete restrict,
+    add column codec_version integer;
+
+create index idx_application_codec_id on application(codec_id);
+
+alter table device_profile
+    add column codec_id bigint references codec on delete restrict,
+    add column codec_version integer;
+
+create index idx_device_profile_codec_id on device_profile(codec_id);
+
+-- +migrate Down
+drop index idx_device_profile_codec_id;
+alter table device_profile
+    drop column codec_id,
+    drop column codec_version;
+
+drop index idx_application_codec_id;
+alter table application
+    drop column codec_id,
+    drop column codec_version;
+
+drop table codec_version;
+
+drop index idx_codec_organization_id;
+drop index idx_codec_created_at;
+drop index idx_codec_updated_at;
+
+drop table codec;
@@ -0,0 +1,19 @@
+-- +migrate Up
+alter table application
+    add column payload_fields_metadata jsonb;
+
+alter table codec
+    add column fields_metadata jsonb;
+
+alter table codec_version
+    add column fields_metadata jsonb;
+
+-- +migrate Down
+alter table codec_version
+    drop column fields_metadata;
+
+alter table codec
+    drop column fields_metadata;
+
+alter table application
+    drop column payload_fields_metadata;
@@ -0,0 +1,20 @@
+-- +migrate Up
+create table device_queue_scheduled_item (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    dev_eui bytea references device on delete cascade not null,
+    reference text not null,
+    confirmed boolean not null,
+    f_port smallint not null,
+    data bytea not null,
+    scheduled_at timestamp with time zone not null,
+    expires_at timestamp with time zone
+);
+
+create index device_queue_scheduled_item_dev_eui on device_queue_scheduled_item(dev_eui);
+create index device_queue_scheduled_item_scheduled_at on device_queue_scheduled_item(scheduled_at);
+
+-- +migrate Down
+drop index device_queue_scheduled_item_scheduled_at;
+drop index device_queue_scheduled_item_dev_eui;
+drop table device_queue_scheduled_item;
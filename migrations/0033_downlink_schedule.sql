@@ -0,0 +1,37 @@
+-- +migrate Up
+create table downlink_schedule (
+    id bigserial primary key,
+    organization_id bigint not null references organization on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    name varchar(100) not null,
+    cron_expression text not null,
+    application_id bigint references application on delete cascade,
+    device_profile_id uuid references device_profile on delete cascade,
+    confirmed boolean not null,
+    f_port smallint not null,
+    data bytea,
+    object jsonb,
+    last_run_at timestamp with time zone,
+    next_run_at timestamp with time zone not null
+);
+
+create index idx_downlink_schedule_organization_id on downlink_schedule(organization_id);
+create index idx_downlink_schedule_next_run_at on downlink_schedule(next_run_at);
+
+create table downlink_schedule_device (
+    downlink_schedule_id bigint not null references downlink_schedule on delete cascade,
+    dev_eui bytea not null references device on delete cascade,
+
+    primary key(downlink_schedule_id, dev_eui)
+);
+
+create index idx_downlink_schedule_device_dev_eui on downlink_schedule_device(dev_eui);
+
+-- +migrate Down
+drop index idx_downlink_schedule_device_dev_eui;
+drop table downlink_schedule_device;
+
+drop index idx_downlink_schedule_next_run_at;
+drop index idx_downlink_schedule_organization_id;
+drop table downlink_schedule;
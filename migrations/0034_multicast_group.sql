@@ -0,0 +1,57 @@
+-- +migrate Up
+create table multicast_group (
+    id bigserial primary key,
+    ns_multicast_group_id uuid not null,
+    application_id bigint not null references application on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    name varchar(100) not null,
+    mc_addr bytea not null,
+    mc_key bytea,
+    mc_app_s_key bytea not null,
+    mc_nwk_s_key bytea not null,
+    mc_group_id smallint not null,
+    group_type varchar(10) not null,
+    dr smallint not null,
+    frequency integer not null,
+    ping_slot_period smallint not null,
+    f_cnt bigint not null
+);
+
+create index idx_multicast_group_application_id on multicast_group(application_id);
+
+create table multicast_group_device (
+    multicast_group_id bigint not null references multicast_group on delete cascade,
+    dev_eui bytea not null references device on delete cascade,
+    created_at timestamp with time zone not null,
+    setup_requested_at timestamp with time zone,
+    setup_completed_at timestamp with time zone,
+    session_requested_at timestamp with time zone,
+    session_start_at timestamp with time zone,
+    last_error text not null default '',
+
+    primary key(multicast_group_id, dev_eui)
+);
+
+create index idx_multicast_group_device_dev_eui on multicast_group_device(dev_eui);
+
+create table multicast_queue_item (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    multicast_group_id bigint not null references multicast_group on delete cascade,
+    f_cnt bigint not null,
+    f_port smallint not null,
+    frm_payload bytea not null
+);
+
+create index idx_multicast_queue_item_multicast_group_id on multicast_queue_item(multicast_group_id);
+
+-- +migrate Down
+drop index idx_multicast_queue_item_multicast_group_id;
+drop table multicast_queue_item;
+
+drop index idx_multicast_group_device_dev_eui;
+drop table multicast_group_device;
+
+drop index idx_multicast_group_application_id;
+drop table multicast_group;
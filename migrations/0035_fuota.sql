@@ -0,0 +1,60 @@
+-- +migrate Up
+create table firmware (
+    id bigserial primary key,
+    organization_id bigint not null references organization on delete cascade,
+    created_at timestamp with time zone not null,
+    name varchar(100) not null,
+    data bytea not null
+);
+
+create index idx_firmware_organization_id on firmware(organization_id);
+
+create table fuota_campaign (
+    id bigserial primary key,
+    application_id bigint not null references application on delete cascade,
+    firmware_id bigint not null references firmware,
+    multicast_group_id bigint references multicast_group on delete set null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    name varchar(100) not null,
+    frag_index smallint not null,
+    frag_size smallint not null,
+    redundancy integer not null,
+    block_ack_delay smallint not null,
+    descriptor bytea not null,
+    step_timeout integer not null,
+    state varchar(20) not null,
+    next_step_at timestamp with time zone not null,
+    completed_at timestamp with time zone
+);
+
+create index idx_fuota_campaign_application_id on fuota_campaign(application_id);
+create index idx_fuota_campaign_firmware_id on fuota_campaign(firmware_id);
+create index idx_fuota_campaign_next_step_at on fuota_campaign(next_step_at) where completed_at is null;
+
+create table fuota_campaign_device (
+    fuota_campaign_id bigint not null references fuota_campaign on delete cascade,
+    dev_eui bytea not null references device on delete cascade,
+    created_at timestamp with time zone not null,
+    setup_completed_at timestamp with time zone,
+    nb_frag_received integer,
+    missing_frag integer,
+    status_received_at timestamp with time zone,
+    last_error text not null default '',
+
+    primary key(fuota_campaign_id, dev_eui)
+);
+
+create index idx_fuota_campaign_device_dev_eui on fuota_campaign_device(dev_eui);
+
+-- +migrate Down
+drop index idx_fuota_campaign_device_dev_eui;
+drop table fuota_campaign_device;
+
+drop index idx_fuota_campaign_next_step_at;
+drop index idx_fuota_campaign_firmware_id;
+drop index idx_fuota_campaign_application_id;
+drop table fuota_campaign;
+
+drop index idx_firmware_organization_id;
+drop table firmware;
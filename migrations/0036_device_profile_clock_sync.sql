@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_profile
+    add column clock_sync_enabled boolean not null default false,
+    add column clock_sync_periodicity smallint;
+
+-- +migrate Down
+alter table device_profile
+    drop column clock_sync_enabled,
+    drop column clock_sync_periodicity;
@@ -0,0 +1,26 @@
+-- +migrate Up
+create table device_queue_item_status (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    dev_eui bytea references device on delete cascade not null,
+    reference text not null,
+    confirmed boolean not null,
+    f_cnt bigint,
+    device_queue_scheduled_item_id bigint,
+    state varchar(20) not null,
+    sent_at timestamp with time zone,
+    completed_at timestamp with time zone
+);
+
+create index device_queue_item_status_dev_eui_reference on device_queue_item_status(dev_eui, reference);
+create index device_queue_item_status_dev_eui_f_cnt on device_queue_item_status(dev_eui, f_cnt);
+create index device_queue_item_status_device_queue_scheduled_item_id on device_queue_item_status(device_queue_scheduled_item_id);
+create index device_queue_item_status_updated_at on device_queue_item_status(updated_at);
+
+-- +migrate Down
+drop index device_queue_item_status_updated_at;
+drop index device_queue_item_status_device_queue_scheduled_item_id;
+drop index device_queue_item_status_dev_eui_f_cnt;
+drop index device_queue_item_status_dev_eui_reference;
+drop table device_queue_item_status;
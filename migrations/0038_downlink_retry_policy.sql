@@ -0,0 +1,29 @@
+-- +migrate Up
+alter table device_profile
+    add column retry_max_attempts integer not null default 0,
+    add column retry_backoff integer not null default 0,
+    add column retry_reencode boolean not null default false;
+
+alter table device_queue_item_status
+    add column retry_max_attempts integer not null default 0,
+    add column retry_backoff integer not null default 0,
+    add column retry_reencode boolean not null default false,
+    add column attempt integer not null default 1,
+    add column f_port smallint not null default 0,
+    add column data bytea,
+    add column object jsonb;
+
+-- +migrate Down
+alter table device_queue_item_status
+    drop column object,
+    drop column data,
+    drop column f_port,
+    drop column attempt,
+    drop column retry_reencode,
+    drop column retry_backoff,
+    drop column retry_max_attempts;
+
+alter table device_profile
+    drop column retry_reencode,
+    drop column retry_backoff,
+    drop column retry_max_attempts;
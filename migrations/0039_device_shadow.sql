@@ -0,0 +1,17 @@
+-- +migrate Up
+create table device_shadow (
+    dev_eui bytea primary key references device on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    desired jsonb,
+    desired_version bigint not null default 0,
+    reported jsonb,
+    reported_at timestamp with time zone,
+    f_port smallint not null,
+    confirmed boolean not null default false,
+    delta_sent jsonb,
+    delta_sent_at timestamp with time zone
+);
+
+-- +migrate Down
+drop table device_shadow;
@@ -0,0 +1,14 @@
+-- +migrate Up
+create table device_dev_nonce (
+    dev_eui bytea not null references device_keys on delete cascade,
+    dev_nonce integer not null,
+    created_at timestamp with time zone not null,
+
+    primary key(dev_eui, dev_nonce)
+);
+
+create index idx_device_dev_nonce_created_at on device_dev_nonce(dev_eui, created_at);
+
+-- +migrate Down
+drop index idx_device_dev_nonce_created_at;
+drop table device_dev_nonce;
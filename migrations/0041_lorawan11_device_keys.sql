@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table device_keys
+    add column nwk_key bytea not null default decode('00000000000000000000000000000000', 'hex'),
+    add column join_eui bytea not null default decode('0000000000000000', 'hex'),
+    add column rj_count1 integer not null default 0;
+
+-- +migrate Down
+alter table device_keys
+    drop column rj_count1,
+    drop column join_eui,
+    drop column nwk_key;
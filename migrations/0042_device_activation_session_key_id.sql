@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table device_activation
+    add column session_key_id bytea;
+
+create index idx_device_activation_session_key_id on device_activation(session_key_id);
+
+-- +migrate Down
+drop index idx_device_activation_session_key_id;
+
+alter table device_activation
+    drop column session_key_id;
@@ -0,0 +1,17 @@
+-- +migrate Up
+alter table device_keys
+    add column dek bytea,
+    add column master_key_id varchar(100) not null default '';
+
+alter table device_activation
+    add column dek bytea,
+    add column master_key_id varchar(100) not null default '';
+
+-- +migrate Down
+alter table device_activation
+    drop column master_key_id,
+    drop column dek;
+
+alter table device_keys
+    drop column master_key_id,
+    drop column dek;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_activation
+    add column net_id bytea;
+
+-- +migrate Down
+alter table device_activation
+    drop column net_id;
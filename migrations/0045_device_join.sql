@@ -0,0 +1,19 @@
+-- +migrate Up
+create table device_join (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    dev_eui bytea not null references device on delete cascade,
+    message_type varchar(20) not null,
+    dev_nonce integer not null,
+    join_nonce integer not null,
+    dev_addr bytea not null,
+    sender_id varchar(100) not null,
+    result_code varchar(50) not null,
+    description text not null
+);
+
+create index idx_device_join_dev_eui_created_at on device_join(dev_eui, created_at);
+
+-- +migrate Down
+drop index idx_device_join_dev_eui_created_at;
+drop table device_join;
@@ -0,0 +1,12 @@
+-- +migrate Up
+alter table network_server
+    add column net_id bytea,
+    add column join_server_client_cn varchar(255) not null default '';
+
+alter table network_server
+    alter column join_server_client_cn drop default;
+
+-- +migrate Down
+alter table network_server
+    drop column join_server_client_cn,
+    drop column net_id;
@@ -0,0 +1,19 @@
+-- +migrate Up
+create table unclaimed_device (
+    dev_eui bytea primary key,
+    created_at timestamp with time zone not null,
+    device_profile_id uuid not null references device_profile on delete cascade,
+    app_key bytea not null,
+    nwk_key bytea not null,
+    dek bytea,
+    master_key_id varchar(100) not null,
+    claim_code_hash varchar(200) not null
+);
+
+create index idx_unclaimed_device_created_at on unclaimed_device(created_at);
+create index idx_unclaimed_device_device_profile_id on unclaimed_device(device_profile_id);
+
+-- +migrate Down
+drop index idx_unclaimed_device_device_profile_id;
+drop index idx_unclaimed_device_created_at;
+drop table unclaimed_device;
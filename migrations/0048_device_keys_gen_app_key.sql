@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_keys
+    add column gen_app_key bytea;
+
+-- +migrate Down
+alter table device_keys
+    drop column gen_app_key;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_activation
+    add column s_nwk_s_int_key bytea,
+    add column rj_count0 integer not null default 0;
+
+-- +migrate Down
+alter table device_activation
+    drop column rj_count0,
+    drop column s_nwk_s_int_key;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table unclaimed_device
+    add column gen_app_key bytea;
+
+-- +migrate Down
+alter table unclaimed_device
+    drop column gen_app_key;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table device
+    add column tags jsonb not null default '{}';
+
+create index idx_device_tags on device using gin(tags);
+
+-- +migrate Down
+drop index idx_device_tags;
+
+alter table device
+    drop column tags;
@@ -77,6 +77,25 @@ class ApplicationForm extends Component {
     });
   }
 
+  onWASMModuleChange(e) {
+    const file = e.target.files[0];
+    if (file === undefined) {
+      return;
+    }
+
+    const reader = new FileReader();
+    reader.onload = () => {
+      let application = this.state.application;
+      // strip the "data:...;base64," prefix
+      application.payloadDecoderScript = reader.result.split(",")[1];
+      application.payloadEncoderScript = "";
+      this.setState({
+        application: application,
+      });
+    };
+    reader.readAsDataURL(file);
+  }
+
   handleSubmit(e) {
     e.preventDefault();
     this.props.onSubmit(this.state.application);
@@ -94,6 +113,9 @@ class ApplicationForm extends Component {
       {value: "", label: "None"},
       {value: "CAYENNE_LPP", label: "Cayenne LPP"},
       {value: "CUSTOM_JS", label: "Custom JavaScript codec functions"},
+      {value: "CBOR", label: "CBOR"},
+      {value: "MESSAGEPACK", label: "MessagePack"},
+      {value: "WASM", label: "WebAssembly module"},
     ];
 
     const codeMirrorOptions = {
@@ -156,6 +178,20 @@ function Decode(fPort, bytes) {
       ];
     }
 
+    if (this.state.application.payloadCodec === "WASM") {
+      customJSFields = [
+        <div className="form-group" key="wasmModule">
+          <label className="control-label" htmlFor="wasmModule">WebAssembly module</label>
+          <input className="form-control" id="wasmModule" type="file" accept=".wasm" onChange={this.onWASMModuleChange.bind(this)} />
+          <p className="help-block">
+            {this.state.application.payloadDecoderScript ? "A module has been uploaded. Select a file to replace it. " : ""}
+            The module must export <strong>memory</strong>, <strong>alloc(len)</strong>, <strong>decode(fPort, ptr, len)</strong> and
+            <strong> encode(fPort, ptr, len)</strong>. See the documentation for the memory ABI.
+          </p>
+        </div>
+      ];
+    }
+
     return (
       <Loaded loaded={this.state.loaded}>
         <form onSubmit={this.handleSubmit}>